@@ -15,6 +15,7 @@ type Access interface {
 	IsSystem() bool
 	TeamNames() []string
 	CSRFToken() string
+	UserName() string
 }
 
 type access struct {
@@ -115,6 +116,19 @@ func (a *access) CSRFToken() string {
 	return ""
 }
 
+// UserName is the name of the user the token was issued to, or empty for
+// tokens which were not issued to a user.
+func (a *access) UserName() string {
+	if claims, ok := a.Token.Claims.(jwt.MapClaims); ok {
+		if userNameClaim, ok := claims["user_name"]; ok {
+			if userName, ok := userNameClaim.(string); ok {
+				return userName
+			}
+		}
+	}
+	return ""
+}
+
 var requiredRoles = map[string]string{
 	atc.SaveConfig:                    "member",
 	atc.GetConfig:                     "viewer",
@@ -151,8 +165,11 @@ var requiredRoles = map[string]string{
 	atc.CheckResource:                 "member",
 	atc.CheckResourceWebHook:          "member",
 	atc.CheckResourceType:             "member",
+	atc.ListResourceChecks:            "viewer",
+	atc.ResourceCheckEvents:           "viewer",
 	atc.ListResourceVersions:          "viewer",
 	atc.GetResourceVersion:            "viewer",
+	atc.SaveResourceVersion:           "member",
 	atc.EnableResourceVersion:         "member",
 	atc.DisableResourceVersion:        "member",
 	atc.PinResourceVersion:            "member",
@@ -166,6 +183,7 @@ var requiredRoles = map[string]string{
 	atc.OrderPipelines:                "member",
 	atc.PausePipeline:                 "member",
 	atc.UnpausePipeline:               "member",
+	atc.ArchivePipeline:               "member",
 	atc.ExposePipeline:                "member",
 	atc.HidePipeline:                  "member",
 	atc.RenamePipeline:                "member",
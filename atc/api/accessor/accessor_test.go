@@ -270,6 +270,43 @@ var _ = Describe("Accessor", func() {
 		})
 	})
 
+	Describe("Get User Name", func() {
+		JustBeforeEach(func() {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+			access = accessorFactory.Create(req, "some-action")
+		})
+
+		Context("when request has user_name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"user_name": "some-user"}
+			})
+			It("returns the user name", func() {
+				Expect(access.UserName()).To(Equal("some-user"))
+			})
+		})
+
+		Context("when request has user_name claim set to nil", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{"user_name": nil}
+			})
+			It("returns empty", func() {
+				Expect(access.UserName()).To(BeEmpty())
+			})
+		})
+
+		Context("when request does not have user_name claim set", func() {
+			BeforeEach(func() {
+				claims = &jwt.MapClaims{}
+			})
+			It("returns empty", func() {
+				Expect(access.UserName()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Get Team Names", func() {
 		JustBeforeEach(func() {
 			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
@@ -471,6 +508,14 @@ var _ = Describe("Accessor", func() {
 		Entry("member :: "+atc.CheckResourceType, atc.CheckResourceType, "member", true),
 		Entry("viewer :: "+atc.CheckResourceType, atc.CheckResourceType, "viewer", false),
 
+		Entry("owner :: "+atc.ListResourceChecks, atc.ListResourceChecks, "owner", true),
+		Entry("member :: "+atc.ListResourceChecks, atc.ListResourceChecks, "member", true),
+		Entry("viewer :: "+atc.ListResourceChecks, atc.ListResourceChecks, "viewer", true),
+
+		Entry("owner :: "+atc.ResourceCheckEvents, atc.ResourceCheckEvents, "owner", true),
+		Entry("member :: "+atc.ResourceCheckEvents, atc.ResourceCheckEvents, "member", true),
+		Entry("viewer :: "+atc.ResourceCheckEvents, atc.ResourceCheckEvents, "viewer", true),
+
 		Entry("owner :: "+atc.ListResourceVersions, atc.ListResourceVersions, "owner", true),
 		Entry("member :: "+atc.ListResourceVersions, atc.ListResourceVersions, "member", true),
 		Entry("viewer :: "+atc.ListResourceVersions, atc.ListResourceVersions, "viewer", true),
@@ -487,6 +532,10 @@ var _ = Describe("Accessor", func() {
 		Entry("member :: "+atc.DisableResourceVersion, atc.DisableResourceVersion, "member", true),
 		Entry("viewer :: "+atc.DisableResourceVersion, atc.DisableResourceVersion, "viewer", false),
 
+		Entry("owner :: "+atc.SaveResourceVersion, atc.SaveResourceVersion, "owner", true),
+		Entry("member :: "+atc.SaveResourceVersion, atc.SaveResourceVersion, "member", true),
+		Entry("viewer :: "+atc.SaveResourceVersion, atc.SaveResourceVersion, "viewer", false),
+
 		Entry("owner :: "+atc.ListBuildsWithVersionAsInput, atc.ListBuildsWithVersionAsInput, "owner", true),
 		Entry("member :: "+atc.ListBuildsWithVersionAsInput, atc.ListBuildsWithVersionAsInput, "member", true),
 		Entry("viewer :: "+atc.ListBuildsWithVersionAsInput, atc.ListBuildsWithVersionAsInput, "viewer", true),
@@ -527,6 +576,10 @@ var _ = Describe("Accessor", func() {
 		Entry("member :: "+atc.UnpausePipeline, atc.UnpausePipeline, "member", true),
 		Entry("viewer :: "+atc.UnpausePipeline, atc.UnpausePipeline, "viewer", false),
 
+		Entry("owner :: "+atc.ArchivePipeline, atc.ArchivePipeline, "owner", true),
+		Entry("member :: "+atc.ArchivePipeline, atc.ArchivePipeline, "member", true),
+		Entry("viewer :: "+atc.ArchivePipeline, atc.ArchivePipeline, "viewer", false),
+
 		Entry("owner :: "+atc.ExposePipeline, atc.ExposePipeline, "owner", true),
 		Entry("member :: "+atc.ExposePipeline, atc.ExposePipeline, "member", true),
 		Entry("viewer :: "+atc.ExposePipeline, atc.ExposePipeline, "viewer", false),
@@ -201,6 +201,7 @@ var _ = BeforeEach(func() {
 		"1.2.3",
 		"4.5.6",
 		fakeVariablesFactory,
+		nil,
 		credsManagers,
 		interceptTimeoutFactory,
 	)
@@ -16,7 +16,20 @@ import (
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
+// An EventStream is anything whose events can be streamed, such as a build or
+// a resource check.
+type EventStream interface {
+	ID() int
+	Events(from uint) (db.EventSource, error)
+}
+
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+	return NewEventStreamHandler(logger, build)
+}
+
+// NewEventStreamHandler serves the stream's events as server-sent events,
+// resuming from the Last-Event-ID header if given.
+func NewEventStreamHandler(logger lager.Logger, stream EventStream) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		clientNotifier := w.(http.CloseNotifier)
 
@@ -55,9 +68,9 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			writer.writeFlusher = gz
 		}
 
-		events, err := build.Events(eventID)
+		events, err := stream.Events(eventID)
 		if err != nil {
-			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
+			logger.Error("failed-to-get-events", err, lager.Data{"stream-id": stream.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -78,7 +91,7 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 
 					<-clientNotifier.CloseNotify()
 				} else {
-					logger.Error("failed-to-get-next-build-event", err)
+					logger.Error("failed-to-get-next-event", err)
 					return
 				}
 
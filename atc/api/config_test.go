@@ -171,7 +171,14 @@ var _ = Describe("Config API", func() {
 								Resources: []string{"some-resource"},
 							},
 						})
+						fakePipeline.ResourceTypeDefaultsReturns(atc.ResourceTypeDefaults{
+							"some-type": atc.Source{"mirror": "some-mirror"},
+						})
 						fakeTeam.PipelineReturns(fakePipeline, true, nil)
+
+						pipelineConfig.ResourceTypeDefaults = atc.ResourceTypeDefaults{
+							"some-type": atc.Source{"mirror": "some-mirror"},
+						}
 					})
 
 					Context("when the jobs are found", func() {
@@ -488,8 +495,8 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
@@ -572,8 +579,8 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(pipelineState).To(Equal(db.PipelineNoChange))
@@ -626,8 +633,8 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-								Expect(name).To(Equal("a-pipeline"))
+								pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
 										{
@@ -749,8 +756,8 @@ jobs:
 									It("passes validation and saves it un-interpolated", func() {
 										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-										name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-										Expect(name).To(Equal("a-pipeline"))
+										pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+										Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 
 										Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -899,8 +906,8 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								name, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
-								Expect(name).To(Equal("a-pipeline"))
+								pipelineRef, savedConfig, id, pipelineState := dbTeam.SavePipelineArgsForCall(0)
+								Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 								Expect(savedConfig).To(Equal(pipelineConfig))
 								Expect(id).To(Equal(db.ConfigVersion(42)))
 								Expect(pipelineState).To(Equal(expectedDBValue))
@@ -1193,8 +1200,8 @@ jobs:
 					It("saves it", func() {
 						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-						name, savedConfig, id, _ := dbTeam.SavePipelineArgsForCall(0)
-						Expect(name).To(Equal("a-pipeline"))
+						pipelineRef, savedConfig, id, _ := dbTeam.SavePipelineArgsForCall(0)
+						Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
 								{
@@ -1208,6 +1215,91 @@ jobs:
 					})
 				})
 
+				Context("when the config contains an in_parallel step given as a list of steps", func() {
+					BeforeEach(func() {
+						request.Header.Set("Content-Type", "application/x-yaml")
+
+						request.Body = gbytes.BufferWithBytes([]byte(`
+jobs:
+- name: some-job
+  plan:
+  - in_parallel:
+    - task: some-task
+      file: some-input/task.yml
+`))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("saves it with the list as its steps", func() {
+						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+						_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+						Expect(savedConfig.Jobs[0].Plan).To(Equal(atc.PlanSequence{
+							{
+								InParallel: &atc.InParallelConfig{
+									Steps: atc.PlanSequence{
+										{Task: "some-task", TaskConfigPath: "some-input/task.yml"},
+									},
+								},
+							},
+						}))
+					})
+				})
+
+				Context("when the config contains steps with attempts in either form", func() {
+					BeforeEach(func() {
+						request.Header.Set("Content-Type", "application/x-yaml")
+
+						request.Body = gbytes.BufferWithBytes([]byte(`
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    file: some-input/task.yml
+    attempts: 3
+  - task: some-other-task
+    file: some-input/task.yml
+    attempts:
+      count: 5
+      backoff: {initial: 1s, max: 1m, multiplier: 1.5}
+      on: [error]
+`))
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("saves the attempts", func() {
+						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+						_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+						Expect(savedConfig.Jobs[0].Plan).To(Equal(atc.PlanSequence{
+							{
+								Task:           "some-task",
+								TaskConfigPath: "some-input/task.yml",
+								Attempts:       &atc.AttemptsConfig{Count: 3},
+							},
+							{
+								Task:           "some-other-task",
+								TaskConfigPath: "some-input/task.yml",
+								Attempts: &atc.AttemptsConfig{
+									Count: 5,
+									Backoff: &atc.BackoffConfig{
+										Initial:    "1s",
+										Max:        "1m",
+										Multiplier: 1.5,
+									},
+									On: []string{atc.AttemptOnError},
+								},
+							},
+						}))
+					})
+				})
+
 				Context("when the config contains extra keys nested under a valid key", func() {
 					BeforeEach(func() {
 						request.Header.Set("Content-Type", "application/json")
@@ -74,6 +74,13 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	instanceVars, err := atc.InstanceVarsFromQueryParams(query)
+	if err != nil {
+		session.Error("malformed-instance-vars", err)
+		s.handleBadRequest(w, []string{err.Error()}, session)
+		return
+	}
+
 	config, pausedState, err := saveConfigRequestUnmarshaler(r)
 	switch err {
 	case ErrStatusUnsupportedMediaType:
@@ -146,7 +153,10 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, pausedState)
+	_, created, err := team.SavePipeline(atc.PipelineRef{
+		Name:         pipelineName,
+		InstanceVars: instanceVars,
+	}, config, version, pausedState)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -343,6 +353,8 @@ func saveConfigRequestUnmarshaler(r *http.Request) (atc.Config, db.PipelinePause
 			atc.SanitizeDecodeHook,
 			atc.VersionConfigDecodeHook,
 			atc.InputsConfigDecodeHook,
+			atc.InParallelConfigDecodeHook,
+			atc.AttemptsConfigDecodeHook,
 			atc.ContainerLimitsDecodeHook,
 		),
 	}
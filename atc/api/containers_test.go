@@ -362,8 +362,8 @@ var _ = Describe("Containers API", func() {
 					_, err := client.Do(req)
 					Expect(err).NotTo(HaveOccurred())
 
-					_, pipelineName, resourceName, variablesFactory := dbTeam.FindCheckContainersArgsForCall(0)
-					Expect(pipelineName).To(Equal("some-pipeline"))
+					_, pipelineRef, resourceName, variablesFactory, _ := dbTeam.FindCheckContainersArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 					Expect(resourceName).To(Equal("some-resource"))
 					Expect(variablesFactory).To(Equal(fakeVariablesFactory))
 				})
@@ -21,7 +21,7 @@ func (s *Server) ListContainers(team db.Team) http.Handler {
 			"params": params,
 		})
 
-		containerLocator, err := createContainerLocatorFromRequest(team, r, s.variablesFactory)
+		containerLocator, err := createContainerLocatorFromRequest(team, r, s.variablesFactory, s.baseDefaults)
 		if err != nil {
 			hLog.Error("failed-to-parse-request", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -59,7 +59,7 @@ type containerLocator interface {
 	Locate(logger lager.Logger) ([]db.Container, map[int]time.Time, error)
 }
 
-func createContainerLocatorFromRequest(team db.Team, r *http.Request, variablesFactory creds.VariablesFactory) (containerLocator, error) {
+func createContainerLocatorFromRequest(team db.Team, r *http.Request, variablesFactory creds.VariablesFactory, baseDefaults atc.ResourceTypeDefaults) (containerLocator, error) {
 	query := r.URL.Query()
 	delete(query, ":team_name")
 
@@ -70,11 +70,20 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, variablesF
 	}
 
 	if query.Get("type") == "check" {
+		instanceVars, err := atc.InstanceVarsFromQueryParams(query)
+		if err != nil {
+			return nil, err
+		}
+
 		return &checkContainerLocator{
-			team:             team,
-			pipelineName:     query.Get("pipeline_name"),
+			team: team,
+			pipelineRef: atc.PipelineRef{
+				Name:         query.Get("pipeline_name"),
+				InstanceVars: instanceVars,
+			},
 			resourceName:     query.Get("resource_name"),
 			variablesFactory: variablesFactory,
+			baseDefaults:     baseDefaults,
 		}, nil
 	}
 
@@ -133,13 +142,14 @@ func (l *allContainersLocator) Locate(logger lager.Logger) ([]db.Container, map[
 
 type checkContainerLocator struct {
 	team             db.Team
-	pipelineName     string
+	pipelineRef      atc.PipelineRef
 	resourceName     string
 	variablesFactory creds.VariablesFactory
+	baseDefaults     atc.ResourceTypeDefaults
 }
 
 func (l *checkContainerLocator) Locate(logger lager.Logger) ([]db.Container, map[int]time.Time, error) {
-	return l.team.FindCheckContainers(logger, l.pipelineName, l.resourceName, l.variablesFactory)
+	return l.team.FindCheckContainers(logger, l.pipelineRef, l.resourceName, l.variablesFactory, l.baseDefaults)
 }
 
 type stepContainerLocator struct {
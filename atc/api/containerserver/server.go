@@ -2,6 +2,7 @@ package containerserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/gc"
@@ -13,6 +14,7 @@ type Server struct {
 
 	workerClient            worker.Client
 	variablesFactory        creds.VariablesFactory
+	baseDefaults            atc.ResourceTypeDefaults
 	interceptTimeoutFactory InterceptTimeoutFactory
 	containerRepository     db.ContainerRepository
 	destroyer               gc.Destroyer
@@ -22,6 +24,7 @@ func NewServer(
 	logger lager.Logger,
 	workerClient worker.Client,
 	variablesFactory creds.VariablesFactory,
+	baseResourceTypeDefaults atc.ResourceTypeDefaults,
 	interceptTimeoutFactory InterceptTimeoutFactory,
 	containerRepository db.ContainerRepository,
 	destroyer gc.Destroyer,
@@ -30,6 +33,7 @@ func NewServer(
 		logger:                  logger,
 		workerClient:            workerClient,
 		variablesFactory:        variablesFactory,
+		baseDefaults:            baseResourceTypeDefaults,
 		interceptTimeoutFactory: interceptTimeoutFactory,
 		containerRepository:     containerRepository,
 		destroyer:               destroyer,
@@ -1598,8 +1598,8 @@ var _ = Describe("Jobs API", func() {
 
 			It("looked up the proper pipeline", func() {
 				Expect(dbTeam.PipelineCallCount()).To(Equal(1))
-				pipelineName := dbTeam.PipelineArgsForCall(0)
-				Expect(pipelineName).To(Equal("some-pipeline"))
+				pipelineRef := dbTeam.PipelineArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 			})
 
 			Context("when getting the job succeeds", func() {
@@ -2266,7 +2266,7 @@ func fakeDBResourceType(t atc.VersionedResourceType) *dbfakes.FakeResourceType {
 	fake := new(dbfakes.FakeResourceType)
 	fake.NameReturns(t.Name)
 	fake.TypeReturns(t.Type)
-	fake.SourceReturns(t.Source)
+	fake.SourceWithDefaultsReturns(t.Source)
 	fake.VersionReturns(t.Version)
 	return fake
 }
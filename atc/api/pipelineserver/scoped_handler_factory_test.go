@@ -5,7 +5,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/db"
@@ -26,6 +28,7 @@ var _ = Describe("Handler", func() {
 		fakePipeline  *dbfakes.FakePipeline
 
 		handler http.Handler
+		query   string
 	)
 
 	BeforeEach(func() {
@@ -37,12 +40,14 @@ var _ = Describe("Handler", func() {
 
 		handlerFactory := pipelineserver.NewScopedHandlerFactory(dbTeamFactory)
 		handler = handlerFactory.HandlerFor(delegate.GetHandler)
+
+		query = "?:team_name=some-team&:pipeline_name=some-pipeline"
 	})
 
 	JustBeforeEach(func() {
 		server = httptest.NewServer(handler)
 
-		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		request, err := http.NewRequest("POST", server.URL+query, nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		response, err = new(http.Client).Do(request)
@@ -115,7 +120,7 @@ var _ = Describe("Handler", func() {
 
 				It("looks up the pipeline by the right name", func() {
 					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
-					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 				})
 
 				It("returns 200", func() {
@@ -125,6 +130,34 @@ var _ = Describe("Handler", func() {
 				It("calls the scoped handler", func() {
 					Expect(delegate.IsCalled).To(BeTrue())
 				})
+
+				Context("when instance vars are given", func() {
+					BeforeEach(func() {
+						query += "&instance_vars=" + url.QueryEscape(`{"branch":"5.1"}`)
+					})
+
+					It("looks up the pipeline instance", func() {
+						Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
+						Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+							Name:         "some-pipeline",
+							InstanceVars: atc.InstanceVars{"branch": "5.1"},
+						}))
+					})
+				})
+
+				Context("when the instance vars are malformed", func() {
+					BeforeEach(func() {
+						query += "&instance_vars=nope"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not call the scoped handler", func() {
+						Expect(delegate.IsCalled).To(BeFalse())
+					})
+				})
 			})
 
 			Context("when the pipeline does not exist", func() {
@@ -9,6 +9,12 @@ import (
 func (s *Server) UnpausePipeline(pipelineDB db.Pipeline) http.Handler {
 	logger := s.logger.Session("unpause-pipeline")
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pipelineDB.Archived() {
+			logger.Debug("pipeline-is-archived")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		err := pipelineDB.Unpause()
 		if err != nil {
 			logger.Error("failed-to-unpause-pipeline", err)
@@ -0,0 +1,29 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func Check(check db.Check) atc.Check {
+	atcCheck := atc.Check{
+		ID:         check.ID(),
+		Status:     string(check.Status()),
+		ATCURL:     check.ATCURL(),
+		WorkerName: check.WorkerName(),
+	}
+
+	if check.CheckError() != nil {
+		atcCheck.CheckError = check.CheckError().Error()
+	}
+
+	if !check.CreateTime().IsZero() {
+		atcCheck.CreateTime = check.CreateTime().Unix()
+	}
+
+	if !check.EndTime().IsZero() {
+		atcCheck.EndTime = check.EndTime().Unix()
+	}
+
+	return atcCheck
+}
@@ -51,6 +51,7 @@ func Job(
 		ID: job.ID(),
 
 		Name:                 job.Name(),
+		PipelineID:           job.PipelineID(),
 		PipelineName:         job.PipelineName(),
 		TeamName:             teamName,
 		DisableManualTrigger: job.Config().DisableManualTrigger,
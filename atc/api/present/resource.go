@@ -22,6 +22,7 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 
 	atcResource := atc.Resource{
 		Name:         resource.Name(),
+		PipelineID:   resource.PipelineID(),
 		PipelineName: resource.PipelineName(),
 		TeamName:     teamName,
 		Type:         resource.Type(),
@@ -29,6 +30,7 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 		FailingToCheck:  failingToCheck,
 		CheckSetupError: checkErrString,
 		CheckError:      rcCheckErrString,
+		RateLimited:     resource.RateLimited(),
 		PinComment:      resource.PinComment(),
 	}
 
@@ -9,6 +9,9 @@ func VersionedResourceTypes(showCheckError bool, savedResourceTypes db.ResourceT
 	versionedResourceTypes := savedResourceTypes.Deserialize()
 
 	for i, resourceType := range savedResourceTypes {
+		// show the source as configured rather than with any defaults
+		versionedResourceTypes[i].Source = resourceType.Source()
+
 		if resourceType.CheckSetupError() != nil && showCheckError {
 			versionedResourceTypes[i].CheckSetupError = resourceType.CheckSetupError().Error()
 		} else {
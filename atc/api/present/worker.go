@@ -27,9 +27,12 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		NoProxy:          workerInfo.NoProxy(),
 		ActiveContainers: workerInfo.ActiveContainers(),
 		ActiveVolumes:    workerInfo.ActiveVolumes(),
+		Capacity:         workerInfo.Capacity(),
 		ResourceTypes:    workerInfo.ResourceTypes(),
 		Platform:         workerInfo.Platform(),
 		Tags:             workerInfo.Tags(),
+		Labels:           workerInfo.Labels(),
+		P2PURL:           workerInfo.P2PURL(),
 		Name:             workerInfo.Name(),
 		Team:             workerInfo.TeamName(),
 		State:            string(workerInfo.State()),
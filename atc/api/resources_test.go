@@ -2,6 +2,10 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,12 +17,14 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/cloudfoundry/bosh-cli/director/template"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/radar/radarfakes"
 	"github.com/concourse/concourse/atc/resource"
 )
@@ -582,8 +588,8 @@ var _ = Describe("Resources API", func() {
 
 			It("injects the proper pipelineDB", func() {
 				Expect(dbTeam.PipelineCallCount()).To(Equal(1))
-				pipelineName := dbTeam.PipelineArgsForCall(0)
-				Expect(pipelineName).To(Equal("a-pipeline"))
+				pipelineRef := dbTeam.PipelineArgsForCall(0)
+				Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 			})
 
 			It("tries to scan with no version specified", func() {
@@ -614,6 +620,53 @@ var _ = Describe("Resources API", func() {
 				})
 			})
 
+			Context("when checking asynchronously", func() {
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckRequestBody{Async: true}
+				})
+
+				Context("when the scanner records a check", func() {
+					BeforeEach(func() {
+						fakeCheck := new(dbfakes.FakeCheck)
+						fakeCheck.IDReturns(42)
+						fakeCheck.StatusReturns(db.CheckStatusStarted)
+						fakeCheck.ATCURLReturns("https://some-atc")
+
+						fakeScanner.ScanFromVersionNotifyingStub = func(_ lager.Logger, _ string, _ atc.Version, created chan<- db.Check) error {
+							created <- fakeCheck
+							return nil
+						}
+					})
+
+					It("does not scan synchronously", func() {
+						Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+					})
+
+					It("returns 201 with the check", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`{
+							"id": 42,
+							"status": "started",
+							"atc_url": "https://some-atc"
+						}`))
+					})
+				})
+
+				Context("when the scan finishes without recording a check", func() {
+					BeforeEach(func() {
+						fakeScanner.ScanFromVersionNotifyingReturns(db.ResourceNotFoundError{})
+					})
+
+					It("returns the outcome of the scan", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
 			Context("when checking fails with ResourceNotFoundError", func() {
 				BeforeEach(func() {
 					fakeScanner.ScanFromVersionReturns(db.ResourceNotFoundError{})
@@ -691,6 +744,182 @@ var _ = Describe("Resources API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks", func() {
+		var response *http.Response
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the resource has checks", func() {
+				BeforeEach(func() {
+					check1 := new(dbfakes.FakeCheck)
+					check1.IDReturns(2)
+					check1.StatusReturns(db.CheckStatusErrored)
+					check1.ATCURLReturns("http://some-atc")
+					check1.WorkerNameReturns("some-worker")
+					check1.CheckErrorReturns(errors.New("some-error"))
+					check1.CreateTimeReturns(time.Unix(100, 0))
+					check1.EndTimeReturns(time.Unix(200, 0))
+
+					check2 := new(dbfakes.FakeCheck)
+					check2.IDReturns(1)
+					check2.StatusReturns(db.CheckStatusSucceeded)
+					check2.CreateTimeReturns(time.Unix(50, 0))
+
+					fakeResource.ChecksReturns([]db.Check{check1, check2}, nil)
+				})
+
+				It("returns 200 with the checks", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"status": "errored",
+							"atc_url": "http://some-atc",
+							"worker_name": "some-worker",
+							"check_error": "some-error",
+							"create_time": 100,
+							"end_time": 200
+						},
+						{
+							"id": 1,
+							"status": "succeeded",
+							"create_time": 50
+						}
+					]`))
+				})
+			})
+
+			Context("when the resource cannot be found", func() {
+				BeforeEach(func() {
+					fakePipeline.ResourceReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the checks fails", func() {
+				BeforeEach(func() {
+					fakeResource.ChecksReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/checks/:check_id/events", func() {
+		var response *http.Response
+		var checkID string
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			checkID = "42"
+
+			fakeResource = new(dbfakes.FakeResource)
+			fakePipeline.ResourceReturns(fakeResource, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/checks/"+checkID+"/events", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the check exists", func() {
+				var fakeCheck *dbfakes.FakeCheck
+
+				BeforeEach(func() {
+					fakeCheck = new(dbfakes.FakeCheck)
+					fakeCheck.EventsReturns(nil, errors.New("nope"))
+					fakeResource.CheckReturns(fakeCheck, true, nil)
+				})
+
+				It("streams the check's events", func() {
+					Expect(fakeResource.CheckArgsForCall(0)).To(Equal(42))
+					Expect(fakeCheck.EventsCallCount()).To(Equal(1))
+					Expect(fakeCheck.EventsArgsForCall(0)).To(BeZero())
+				})
+			})
+
+			Context("when the check cannot be found", func() {
+				BeforeEach(func() {
+					fakeResource.CheckReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the check id is malformed", func() {
+				BeforeEach(func() {
+					checkID = "nope"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types", func() {
 		var response *http.Response
 
@@ -1065,6 +1294,34 @@ var _ = Describe("Resources API", func() {
 					})
 				})
 
+				Context("when the resource's checks are rate limited", func() {
+					BeforeEach(func() {
+						resource1 := new(dbfakes.FakeResource)
+						resource1.PipelineNameReturns("a-pipeline")
+						resource1.NameReturns("resource-1")
+						resource1.TypeReturns("type-1")
+						resource1.LastCheckedReturns(time.Unix(1513364881, 0))
+						resource1.RateLimitedReturns(true)
+
+						fakePipeline.ResourceReturns(resource1, true, nil)
+					})
+
+					It("returns the resource json saying so", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(body).To(MatchJSON(`
+							{
+								"name": "resource-1",
+								"pipeline_name": "a-pipeline",
+								"team_name": "a-team",
+								"type": "type-1",
+								"last_checked": 1513364881,
+								"rate_limited": true
+							}`))
+					})
+				})
+
 				Context("when the resource has a pin comment", func() {
 					BeforeEach(func() {
 						resource1 := new(dbfakes.FakeResource)
@@ -1250,6 +1507,68 @@ var _ = Describe("Resources API", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 				})
 			})
+
+			Context("when checking recursively", func() {
+				var fakeDependentScanner *radarfakes.FakeDependentScanner
+
+				BeforeEach(func() {
+					checkRequestBody = atc.CheckRequestBody{
+						From: atc.Version{
+							"some-version-key": "some-version-value",
+						},
+						Recursive: true,
+					}
+
+					fakeDependentScanner = new(radarfakes.FakeDependentScanner)
+					fakeScannerFactory.NewDependentScannerReturns(fakeDependentScanner)
+
+					fakeDependentScanner.ScanFromVersionReturns(radar.Dependents{
+						ResourceTypes: []string{"some-child-type"},
+						Resources:     []string{"some-resource", "some-other-resource"},
+					}, nil)
+				})
+
+				It("checks the resource type and its dependents from the version", func() {
+					Expect(fakeScanner.ScanFromVersionCallCount()).To(BeZero())
+					Expect(fakeDependentScanner.ScanFromVersionCallCount()).To(Equal(1))
+
+					_, actualResourceName, actualFromVersion := fakeDependentScanner.ScanFromVersionArgsForCall(0)
+					Expect(actualResourceName).To(Equal("resource-type-name"))
+					Expect(actualFromVersion).To(Equal(checkRequestBody.From))
+				})
+
+				It("returns 200 with the scheduled dependents", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"resource_types": ["some-child-type"],
+						"resources": ["some-resource", "some-other-resource"]
+					}`))
+				})
+
+				Context("when the resource type is not found", func() {
+					BeforeEach(func() {
+						fakeDependentScanner.ScanFromVersionReturns(radar.Dependents{}, db.ResourceTypeNotFoundError{})
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when checking fails", func() {
+					BeforeEach(func() {
+						fakeDependentScanner.ScanFromVersionReturns(radar.Dependents{}, errors.New("some-error"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
 		})
 	})
 
@@ -1262,6 +1581,10 @@ var _ = Describe("Resources API", func() {
 			fakeResourceConfig        *dbfakes.FakeResourceConfig
 			fakeResourceConfigVersion *dbfakes.FakeResourceConfigVersion
 			fakeResourceConfigScope   *dbfakes.FakeResourceConfigScope
+
+			webhookPayload  []byte
+			signature       string
+			signatureHeader string
 		)
 
 		BeforeEach(func() {
@@ -1274,16 +1597,28 @@ var _ = Describe("Resources API", func() {
 			fakeResourceConfig = new(dbfakes.FakeResourceConfig)
 			fakeResourceConfigVersion = new(dbfakes.FakeResourceConfigVersion)
 			fakeResourceConfigScope = new(dbfakes.FakeResourceConfigScope)
+
+			webhookPayload = nil
+			signature = ""
+			signatureHeader = "X-Hub-Signature-256"
 		})
 
 		JustBeforeEach(func() {
 			reqPayload, err := json.Marshal(checkRequestBody)
 			Expect(err).NotTo(HaveOccurred())
 
+			if webhookPayload != nil {
+				reqPayload = webhookPayload
+			}
+
 			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
 			request.Header.Set("Content-Type", "application/json")
 
+			if signature != "" {
+				request.Header.Set(signatureHeader, signature)
+			}
+
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -1447,5 +1782,140 @@ var _ = Describe("Resources API", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
 		})
+
+		Context("when the resource configures a webhook", func() {
+			var webhook *atc.WebhookConfig
+
+			sign := func(payload string) string {
+				mac := hmac.New(sha256.New, []byte("some-secret"))
+				mac.Write([]byte(payload))
+				return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+			}
+
+			BeforeEach(func() {
+				fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{
+					"webhook-secret": "some-secret",
+				})
+
+				webhook = &atc.WebhookConfig{
+					Secret: "((webhook-secret))",
+				}
+
+				fakeResource.WebhookReturns(webhook)
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+				dbResourceConfigFactory.FindResourceConfigByIDReturns(nil, false, nil)
+
+				webhookPayload = []byte(`{"ref":"refs/heads/master"}`)
+			})
+
+			Context("when the payload is signed with the secret", func() {
+				BeforeEach(func() {
+					signature = sign(`{"ref":"refs/heads/master"}`)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("scans without the payload", func() {
+					Eventually(fakeScanner.ScanFromVersionCallCount).Should(Equal(1))
+					Expect(fakeScanner.ScanFromPayloadCallCount()).To(BeZero())
+				})
+
+				Context("when the payload matches the filter", func() {
+					BeforeEach(func() {
+						webhook.Filter = `$.ref == "refs/heads/master"`
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("when the payload does not match the filter", func() {
+					BeforeEach(func() {
+						webhook.Filter = `$.ref == "refs/heads/develop"`
+					})
+
+					It("returns 204 without scanning", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+						Consistently(fakeScanner.ScanFromVersionCallCount).Should(BeZero())
+					})
+				})
+
+				Context("when the payload is to be passed to the check", func() {
+					BeforeEach(func() {
+						webhook.PassPayload = true
+					})
+
+					It("scans with the payload", func() {
+						Eventually(fakeScanner.ScanFromPayloadCallCount).Should(Equal(1))
+						_, actualResourceName, _, actualPayload := fakeScanner.ScanFromPayloadArgsForCall(0)
+						Expect(actualResourceName).To(Equal("resource-name"))
+						Expect(actualPayload).To(MatchJSON(`{"ref":"refs/heads/master"}`))
+					})
+				})
+			})
+
+			Context("when the payload is signed with SHA-1", func() {
+				BeforeEach(func() {
+					mac := hmac.New(sha1.New, []byte("some-secret"))
+					mac.Write(webhookPayload)
+					signature = "sha1=" + hex.EncodeToString(mac.Sum(nil))
+				})
+
+				It("returns 401 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Consistently(fakeScanner.ScanFromVersionCallCount).Should(BeZero())
+				})
+
+				Context("when the resource configures the SHA-1 signature header", func() {
+					BeforeEach(func() {
+						webhook.SignatureHeader = "X-Hub-Signature"
+						signatureHeader = "X-Hub-Signature"
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+			})
+
+			Context("when the secret evaluates to nothing", func() {
+				BeforeEach(func() {
+					fakeVariablesFactory.NewVariablesReturns(template.StaticVariables{
+						"webhook-secret": "",
+					})
+
+					mac := hmac.New(sha256.New, []byte(""))
+					mac.Write(webhookPayload)
+					signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+				})
+
+				It("returns 500 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					Consistently(fakeScanner.ScanFromVersionCallCount).Should(BeZero())
+				})
+			})
+
+			Context("when the payload is signed with another secret", func() {
+				BeforeEach(func() {
+					mac := hmac.New(sha256.New, []byte("wrong-secret"))
+					mac.Write(webhookPayload)
+					signature = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+				})
+
+				It("returns 401 without scanning", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Consistently(fakeScanner.ScanFromVersionCallCount).Should(BeZero())
+				})
+			})
+
+			Context("when the payload is not signed", func() {
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
 	})
 })
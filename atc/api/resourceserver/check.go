@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/google/jsonapi"
@@ -28,7 +29,32 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 
 		scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
 
-		err = scanner.ScanFromVersion(logger, resourceName, reqBody.From)
+		if reqBody.Async {
+			created := make(chan db.Check, 1)
+			scanned := make(chan error, 1)
+
+			go func() {
+				scanned <- scanner.ScanFromVersionNotifying(logger, resourceName, reqBody.From, created)
+			}()
+
+			select {
+			case check := <-created:
+				respondWithCheck(logger, w, check)
+				return
+			case err = <-scanned:
+			}
+
+			// the check may have been recorded just before the scan returned
+			select {
+			case check := <-created:
+				respondWithCheck(logger, w, check)
+				return
+			default:
+			}
+		} else {
+			err = scanner.ScanFromVersion(logger, resourceName, reqBody.From)
+		}
+
 		switch scanErr := err.(type) {
 		case resource.ErrResourceScriptFailed:
 			checkResponseBody := atc.CheckResponseBody{
@@ -62,3 +88,13 @@ func (s *Server) CheckResource(dbPipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+func respondWithCheck(logger lager.Logger, w http.ResponseWriter, check db.Check) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	err := json.NewEncoder(w).Encode(present.Check(check))
+	if err != nil {
+		logger.Error("failed-to-encode-check", err)
+	}
+}
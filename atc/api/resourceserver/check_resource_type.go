@@ -24,6 +24,11 @@ func (s *Server) CheckResourceType(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		if reqBody.Recursive {
+			s.checkResourceTypeRecursively(logger, dbPipeline, resourceName, reqBody.From, w)
+			return
+		}
+
 		scanner := s.scannerFactory.NewResourceTypeScanner(dbPipeline)
 
 		err = scanner.ScanFromVersion(logger, resourceName, reqBody.From)
@@ -38,3 +43,32 @@ func (s *Server) CheckResourceType(dbPipeline db.Pipeline) http.Handler {
 		}
 	})
 }
+
+// checkResourceTypeRecursively checks the resource type and then schedules
+// everything using it to be rechecked, responding with the resource types and
+// resources scheduled.
+func (s *Server) checkResourceTypeRecursively(logger lager.Logger, dbPipeline db.Pipeline, resourceTypeName string, fromVersion atc.Version, w http.ResponseWriter) {
+	scanner := s.scannerFactory.NewDependentScanner(dbPipeline)
+
+	dependents, err := scanner.ScanFromVersion(logger, resourceTypeName, fromVersion)
+	switch err.(type) {
+	case db.ResourceTypeNotFoundError:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case error:
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(atc.CheckResourceTypeResponseBody{
+		ResourceTypes: dependents.ResourceTypes,
+		Resources:     dependents.Resources,
+	})
+	if err != nil {
+		logger.Error("failed-to-encode-check-resource-type-response-body", err)
+	}
+}
@@ -1,17 +1,33 @@
 package resourceserver
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/condition"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
 
+// maxWebhookPayloadSize limits how much of a webhook's body is read.
+const maxWebhookPayloadSize = 10 * 1024 * 1024
+
 // CheckResourceWebHook defines a handler for process a check resource request via an access token.
+//
+// Resources which configure a webhook are instead authenticated by the HMAC
+// signature of the request body, and may filter which payloads trigger a
+// check.
 func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 	logger := s.logger.Session("check-resource-webhook")
 
@@ -19,12 +35,6 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 		resourceName := rata.Param(r, "resource_name")
 		webhookToken := r.URL.Query().Get("webhook_token")
 
-		if webhookToken == "" {
-			logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
 		pipelineResource, found, err := dbPipeline.Resource(resourceName)
 		if err != nil {
 			logger.Error("database-error", err, lager.Data{"resource-name": resourceName})
@@ -39,11 +49,85 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 		}
 
 		variables := s.variablesFactory.NewVariables(dbPipeline.TeamName(), dbPipeline.Name())
-		token, err := creds.NewString(variables, pipelineResource.WebhookToken()).Evaluate()
-		if token != webhookToken {
-			logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
-			w.WriteHeader(http.StatusUnauthorized)
-			return
+
+		var payload json.RawMessage
+
+		webhook := pipelineResource.Webhook()
+		if webhook != nil {
+			body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize))
+			if err != nil {
+				logger.Info("failed-to-read-payload", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			secret, err := creds.NewString(variables, webhook.Secret).Evaluate()
+			if err != nil {
+				logger.Error("failed-to-evaluate-webhook-secret", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if secret == "" {
+				logger.Info("empty-webhook-secret")
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			signatureHeader := webhook.SignatureHeader
+			if signatureHeader == "" {
+				signatureHeader = atc.DefaultWebhookSignatureHeader
+			}
+
+			if !validSignature(secret, signatureHeader, r.Header.Get(signatureHeader), body) {
+				logger.Info("invalid-signature", lager.Data{"header": signatureHeader})
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if webhook.Filter != "" {
+				matched, err := matchesFilter(webhook.Filter, body)
+				if err != nil {
+					logger.Info("failed-to-filter-payload", lager.Data{"error": err.Error()})
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				if !matched {
+					logger.Debug("payload-filtered")
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+
+			if webhook.PassPayload {
+				if !json.Valid(body) {
+					logger.Info("invalid-payload", lager.Data{"error": "payload is not JSON"})
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				payload = body
+			}
+		} else {
+			if webhookToken == "" {
+				logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			token, err := creds.NewString(variables, pipelineResource.WebhookToken()).Evaluate()
+			if err != nil {
+				logger.Error("failed-to-evaluate-webhook-token", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if token != webhookToken {
+				logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
 		}
 
 		go func() {
@@ -75,9 +159,55 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			}
 
 			scanner := s.scannerFactory.NewResourceScanner(dbPipeline)
-			scanner.ScanFromVersion(logger, resourceName, fromVersion)
+			if payload != nil {
+				scanner.ScanFromPayload(logger, resourceName, fromVersion, payload)
+			} else {
+				scanner.ScanFromVersion(logger, resourceName, fromVersion)
+			}
 		}()
 
 		w.WriteHeader(http.StatusOK)
 	})
 }
+
+// validSignature returns whether the signature is the HMAC of the body keyed
+// by the secret. The hash is chosen by the header carrying the signature
+// rather than by the signature itself: GitHub's legacy X-Hub-Signature uses
+// SHA-1 and every other header SHA-256. Signatures are given in hex,
+// optionally prefixed by the name of the hash as with GitHub's "sha256=...".
+func validSignature(secret string, header string, signature string, body []byte) bool {
+	hashName, newHash := "sha256", sha256.New
+	if http.CanonicalHeaderKey(header) == "X-Hub-Signature" {
+		hashName, newHash = "sha1", sha1.New
+	}
+
+	signature = strings.TrimPrefix(signature, hashName+"=")
+
+	given, err := hex.DecodeString(signature)
+	if err != nil || len(given) == 0 {
+		return false
+	}
+
+	mac := hmac.New(newHash, []byte(secret))
+	_, _ = mac.Write(body)
+
+	return hmac.Equal(given, mac.Sum(nil))
+}
+
+func matchesFilter(filter string, body []byte) (bool, error) {
+	cond, err := condition.Parse(filter)
+	if err != nil {
+		return false, err
+	}
+
+	var payload interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&payload)
+	if err != nil {
+		return false, fmt.Errorf("payload is not JSON: %s", err)
+	}
+
+	return cond.Evaluate(condition.Vars{Payload: payload})
+}
@@ -0,0 +1,98 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/buildserver"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListResourceChecks(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-resource-checks")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		dbResource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		checks, err := dbResource.Checks()
+		if err != nil {
+			logger.Error("failed-to-get-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.Check{}
+		for _, check := range checks {
+			presented = append(presented, present.Check(check))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-checks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) ResourceCheckEvents(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("resource-check-events")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := r.FormValue(":resource_name")
+
+		checkID, err := strconv.Atoi(r.FormValue(":check_id"))
+		if err != nil {
+			logger.Info("malformed-check-id", lager.Data{"check-id": r.FormValue(":check_id")})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		dbResource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		check, found, err := dbResource.Check(checkID)
+		if err != nil {
+			logger.Error("failed-to-get-check", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("check-not-found", lager.Data{"check-id": checkID})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		buildserver.NewEventStreamHandler(logger, check).ServeHTTP(w, r)
+	})
+}
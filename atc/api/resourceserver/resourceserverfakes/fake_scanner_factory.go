@@ -10,6 +10,17 @@ import (
 )
 
 type FakeScannerFactory struct {
+	NewDependentScannerStub        func(db.Pipeline) radar.DependentScanner
+	newDependentScannerMutex       sync.RWMutex
+	newDependentScannerArgsForCall []struct {
+		arg1 db.Pipeline
+	}
+	newDependentScannerReturns struct {
+		result1 radar.DependentScanner
+	}
+	newDependentScannerReturnsOnCall map[int]struct {
+		result1 radar.DependentScanner
+	}
 	NewResourceScannerStub        func(db.Pipeline) radar.Scanner
 	newResourceScannerMutex       sync.RWMutex
 	newResourceScannerArgsForCall []struct {
@@ -36,6 +47,66 @@ type FakeScannerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeScannerFactory) NewDependentScanner(arg1 db.Pipeline) radar.DependentScanner {
+	fake.newDependentScannerMutex.Lock()
+	ret, specificReturn := fake.newDependentScannerReturnsOnCall[len(fake.newDependentScannerArgsForCall)]
+	fake.newDependentScannerArgsForCall = append(fake.newDependentScannerArgsForCall, struct {
+		arg1 db.Pipeline
+	}{arg1})
+	fake.recordInvocation("NewDependentScanner", []interface{}{arg1})
+	fake.newDependentScannerMutex.Unlock()
+	if fake.NewDependentScannerStub != nil {
+		return fake.NewDependentScannerStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.newDependentScannerReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScannerFactory) NewDependentScannerCallCount() int {
+	fake.newDependentScannerMutex.RLock()
+	defer fake.newDependentScannerMutex.RUnlock()
+	return len(fake.newDependentScannerArgsForCall)
+}
+
+func (fake *FakeScannerFactory) NewDependentScannerCalls(stub func(db.Pipeline) radar.DependentScanner) {
+	fake.newDependentScannerMutex.Lock()
+	defer fake.newDependentScannerMutex.Unlock()
+	fake.NewDependentScannerStub = stub
+}
+
+func (fake *FakeScannerFactory) NewDependentScannerArgsForCall(i int) db.Pipeline {
+	fake.newDependentScannerMutex.RLock()
+	defer fake.newDependentScannerMutex.RUnlock()
+	argsForCall := fake.newDependentScannerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeScannerFactory) NewDependentScannerReturns(result1 radar.DependentScanner) {
+	fake.newDependentScannerMutex.Lock()
+	defer fake.newDependentScannerMutex.Unlock()
+	fake.NewDependentScannerStub = nil
+	fake.newDependentScannerReturns = struct {
+		result1 radar.DependentScanner
+	}{result1}
+}
+
+func (fake *FakeScannerFactory) NewDependentScannerReturnsOnCall(i int, result1 radar.DependentScanner) {
+	fake.newDependentScannerMutex.Lock()
+	defer fake.newDependentScannerMutex.Unlock()
+	fake.NewDependentScannerStub = nil
+	if fake.newDependentScannerReturnsOnCall == nil {
+		fake.newDependentScannerReturnsOnCall = make(map[int]struct {
+			result1 radar.DependentScanner
+		})
+	}
+	fake.newDependentScannerReturnsOnCall[i] = struct {
+		result1 radar.DependentScanner
+	}{result1}
+}
+
 func (fake *FakeScannerFactory) NewResourceScanner(arg1 db.Pipeline) radar.Scanner {
 	fake.newResourceScannerMutex.Lock()
 	ret, specificReturn := fake.newResourceScannerReturnsOnCall[len(fake.newResourceScannerArgsForCall)]
@@ -159,6 +230,8 @@ func (fake *FakeScannerFactory) NewResourceTypeScannerReturnsOnCall(i int, resul
 func (fake *FakeScannerFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.newDependentScannerMutex.RLock()
+	defer fake.newDependentScannerMutex.RUnlock()
 	fake.newResourceScannerMutex.RLock()
 	defer fake.newResourceScannerMutex.RUnlock()
 	fake.newResourceTypeScannerMutex.RLock()
@@ -12,6 +12,7 @@ import (
 type ScannerFactory interface {
 	NewResourceScanner(pipeline db.Pipeline) radar.Scanner
 	NewResourceTypeScanner(dbPipeline db.Pipeline) radar.Scanner
+	NewDependentScanner(dbPipeline db.Pipeline) radar.DependentScanner
 }
 
 type Server struct {
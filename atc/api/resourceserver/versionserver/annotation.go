@@ -0,0 +1,27 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// versionAnnotation is the annotation recorded for disabling, enabling or
+// pinning a version, by the requesting user and with the comment given in
+// the request, if any.
+func versionAnnotation(r *http.Request) (db.VersionAnnotation, error) {
+	var reqBody atc.VersionAnnotationRequest
+	err := json.NewDecoder(r.Body).Decode(&reqBody)
+	if err != nil && err != io.EOF {
+		return db.VersionAnnotation{}, err
+	}
+
+	return db.VersionAnnotation{
+		UserName: accessor.GetAccessor(r).UserName(),
+		Comment:  reqBody.Comment,
+	}, nil
+}
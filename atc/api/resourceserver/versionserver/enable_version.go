@@ -30,7 +30,14 @@ func (s *Server) EnableResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = resource.EnableVersion(resourceConfigVersionID)
+		annotation, err := versionAnnotation(r)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = resource.EnableVersion(resourceConfigVersionID, annotation)
 		if err != nil {
 			logger.Error("failed-to-enable-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -30,7 +30,14 @@ func (s *Server) PinResourceVersion(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		err = resource.PinVersion(resourceConfigVersionID)
+		annotation, err := versionAnnotation(r)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = resource.PinVersion(resourceConfigVersionID, annotation)
 		if err != nil {
 			logger.Error("failed-to-pin-resource-version", err)
 			w.WriteHeader(http.StatusInternalServerError)
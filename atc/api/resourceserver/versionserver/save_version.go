@@ -0,0 +1,66 @@
+package versionserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) SaveResourceVersion(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("save-resource-version")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request atc.SaveVersionRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if len(request.Version) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("version must not be empty"))
+			return
+		}
+
+		resourceName := r.FormValue(":resource_name")
+		resource, found, err := pipeline.Resource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if !found {
+			logger.Debug("resource-not-found", lager.Data{"resource": resourceName})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		created, err := resource.InjectVersion(request.Version, db.NewResourceConfigMetadataFields(request.Metadata))
+		if err == db.ErrResourceNotChecked {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		if err != nil {
+			logger.Error("failed-to-inject-resource-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		logger.Info("injected-version", lager.Data{
+			"resource": resourceName,
+			"version":  request.Version,
+			"created":  created,
+		})
+
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+}
@@ -1,8 +1,10 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"time"
@@ -176,6 +178,14 @@ var _ = Describe("Versions API", func() {
 										Value: "metadata",
 									},
 								},
+								Annotations: []atc.VersionAnnotation{
+									{
+										Action:    atc.VersionActionDisable,
+										UserName:  "some-user",
+										Comment:   "the image is broken",
+										CreatedAt: 42,
+									},
+								},
 							},
 						}
 
@@ -215,6 +225,14 @@ var _ = Describe("Versions API", func() {
 								"name":"some",
 								"value":"metadata"
 							}
+						],
+						"annotations": [
+							{
+								"action": "disable",
+								"user_name": "some-user",
+								"comment": "the image is broken",
+								"created_at": 42
+							}
 						]
 					}
 				]`))
@@ -318,7 +336,7 @@ var _ = Describe("Versions API", func() {
 					})
 
 					It("tries to enable the right resource config version", func() {
-						resourceConfigVersionID := fakeResource.EnableVersionArgsForCall(0)
+						resourceConfigVersionID, _ := fakeResource.EnableVersionArgsForCall(0)
 						Expect(resourceConfigVersionID).To(Equal(42))
 					})
 
@@ -388,11 +406,16 @@ var _ = Describe("Versions API", func() {
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", func() {
 		var response *http.Response
 		var fakeResource *dbfakes.FakeResource
+		var requestBody io.Reader
+
+		BeforeEach(func() {
+			requestBody = nil
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/disable", nil)
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions/42/disable", requestBody)
 			Expect(err).NotTo(HaveOccurred())
 
 			response, err = client.Do(request)
@@ -422,10 +445,36 @@ var _ = Describe("Versions API", func() {
 					})
 
 					It("tries to disable the right resource config version", func() {
-						resourceConfigVersionID := fakeResource.DisableVersionArgsForCall(0)
+						resourceConfigVersionID, _ := fakeResource.DisableVersionArgsForCall(0)
 						Expect(resourceConfigVersionID).To(Equal(42))
 					})
 
+					Context("when a comment is given", func() {
+						BeforeEach(func() {
+							fakeaccess.UserNameReturns("some-user")
+							requestBody = bytes.NewBufferString(`{"comment":"the image is broken"}`)
+						})
+
+						It("annotates the version with the user and comment", func() {
+							_, annotation := fakeResource.DisableVersionArgsForCall(0)
+							Expect(annotation).To(Equal(db.VersionAnnotation{
+								UserName: "some-user",
+								Comment:  "the image is broken",
+							}))
+						})
+					})
+
+					Context("when the request body is malformed", func() {
+						BeforeEach(func() {
+							requestBody = bytes.NewBufferString(`{`)
+						})
+
+						It("returns 400 without disabling the version", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							Expect(fakeResource.DisableVersionCallCount()).To(BeZero())
+						})
+					})
+
 					Context("when disabling the resource version succeeds", func() {
 						BeforeEach(func() {
 							fakeResource.DisableVersionReturns(nil)
@@ -488,6 +537,149 @@ var _ = Describe("Versions API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", func() {
+		var response *http.Response
+		var requestBody string
+		var fakeResource *dbfakes.FakeResource
+
+		BeforeEach(func() {
+			requestBody = `{"version":{"ref":"abcdef"},"metadata":[{"name":"author","value":"someone"}]}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/versions", bytes.NewBufferString(requestBody))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated ", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when finding the resource succeeds", func() {
+					BeforeEach(func() {
+						fakeResource = new(dbfakes.FakeResource)
+						fakePipeline.ResourceReturns(fakeResource, true, nil)
+					})
+
+					It("injects the version with its metadata", func() {
+						Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("resource-name"))
+
+						version, metadata := fakeResource.InjectVersionArgsForCall(0)
+						Expect(version).To(Equal(atc.Version{"ref": "abcdef"}))
+						Expect(metadata).To(Equal(db.ResourceConfigMetadataFields{
+							{Name: "author", Value: "someone"},
+						}))
+					})
+
+					Context("when the version is new", func() {
+						BeforeEach(func() {
+							fakeResource.InjectVersionReturns(true, nil)
+						})
+
+						It("returns 201", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusCreated))
+						})
+					})
+
+					Context("when the version already exists", func() {
+						BeforeEach(func() {
+							fakeResource.InjectVersionReturns(false, nil)
+						})
+
+						It("returns 200", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+					})
+
+					Context("when the resource has not been checked", func() {
+						BeforeEach(func() {
+							fakeResource.InjectVersionReturns(false, db.ErrResourceNotChecked)
+						})
+
+						It("returns 400 with the reason", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(body)).To(Equal("resource has not been checked yet"))
+						})
+					})
+
+					Context("when injecting the version fails", func() {
+						BeforeEach(func() {
+							fakeResource.InjectVersionReturns(false, errors.New("welp"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+				})
+
+				Context("when the version is empty", func() {
+					BeforeEach(func() {
+						requestBody = `{"version":{}}`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the request is malformed", func() {
+					BeforeEach(func() {
+						requestBody = `{`
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("returns not found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns Forbidden", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
 		var response *http.Response
 		var fakeResource *dbfakes.FakeResource
@@ -525,7 +717,7 @@ var _ = Describe("Versions API", func() {
 					})
 
 					It("tries to pin the right resource config version", func() {
-						resourceConfigVersionID := fakeResource.PinVersionArgsForCall(0)
+						resourceConfigVersionID, _ := fakeResource.PinVersionArgsForCall(0)
 						Expect(resourceConfigVersionID).To(Equal(42))
 					})
 
@@ -769,6 +769,7 @@ var _ = Describe("Workers API", func() {
 			fakeWorker.NameReturns(workerName)
 			fakeWorker.ActiveContainersReturns(2)
 			fakeWorker.ActiveVolumesReturns(10)
+			fakeWorker.CapacityReturns(atc.WorkerCapacity{MaxContainers: 250, CPU: 8})
 			fakeWorker.PlatformReturns("penguin")
 			fakeWorker.TagsReturns([]string{"some-tag"})
 			fakeWorker.StateReturns(db.WorkerStateRunning)
@@ -817,6 +818,7 @@ var _ = Describe("Workers API", func() {
 				"baggageclaim_url": "",
 				"active_containers": 2,
 				"active_volumes": 10,
+				"capacity": {"max_containers": 250, "cpu": 8},
 				"resource_types": null,
 				"platform": "penguin",
 				"ephemeral": true,
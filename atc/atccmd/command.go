@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -47,6 +48,7 @@ import (
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/concourse/concourse/skymarshal/storage"
 	"github.com/concourse/concourse/web"
+	"github.com/concourse/concourse/worker/p2p"
 	"github.com/concourse/flag"
 	"github.com/concourse/retryhttp"
 	"github.com/cppforlife/go-semi-semantic/version"
@@ -107,10 +109,22 @@ type RunCommand struct {
 	GlobalResourceCheckTimeout   time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval     time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceTypeCheckingInterval time.Duration `long:"resource-type-checking-interval" default:"1m" description:"Interval on which to check for new versions of resource types."`
+	CheckSchedulerInterval       time.Duration `long:"check-scheduler-interval" default:"10s" description:"Interval on which to look for resources and resource types which are due to be checked."`
+	MaxChecksInFlight            int           `long:"max-checks-in-flight" default:"32" description:"Maximum number of resource and resource type checks to run at once on this ATC."`
 
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" description:"Method by which a worker is selected during container placement."`
+	BaseResourceTypeDefaults flag.File `long:"base-resource-type-defaults" description:"Path to a YAML file of sources keyed by resource type, merged beneath the source of every resource, resource type and image resource of that type. Pipelines may override them with resource_type_defaults."`
+
+	CheckResourceTypeDependents bool `long:"check-resource-type-dependents" description:"Schedule every resource type and resource using a resource type to be rechecked as soon as it has a new version, rather than on their next interval."`
+
+	CheckRateLimits []atc.CheckRateLimitFlag `long:"resource-type-check-rate-limit" description:"Limit the periodic checks of resources of a type, shared by every ATC. Given as TYPE:CHECKS_PER_MINUTE[:BURST]. Can be specified multiple times."`
+
+	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" description:"Method by which a worker is selected during container placement. Given as a comma-separated list of volume-locality, fewest-build-containers, fewest-volumes, limit-active-tasks and random, each narrowing down the workers chosen by the previous ones."`
+	MaxActiveTasksPerWorker           int           `long:"max-active-tasks-per-worker" default:"0" description:"Maximum number of tasks of running builds on a worker, for the limit-active-tasks container placement strategy. The limit is best-effort, as tasks are counted every 10 seconds and concurrent placements may exceed it. 0 means no limit."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 
+	VolumeStreamEncoding p2p.Encoding `long:"volume-stream-encoding" default:"gzip" choice:"gzip" description:"Encoding preferred when streaming volumes between workers which have a --p2p-key, whether directly or through the ATC. Workers fall back on another encoding they support. Only gzip is supported until baggageclaim can stream other encodings. Volumes streamed from one baggageclaim to another through the ATC are always gzipped, regardless of this flag."`
+	VolumeStreamKey      string       `long:"volume-stream-key" description:"Key shared with the workers' --p2p-key, with which requests to stream volumes between workers are signed. Volumes are streamed from one baggageclaim to another through the ATC if not set."`
+
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
 	Developer struct {
@@ -140,6 +154,7 @@ type RunCommand struct {
 
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
 		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
+		CheckRetention         time.Duration `long:"check-retention" default:"24h" description:"Period after which the logs of finished resource checks are removed. The latest check of each resource is always kept."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -149,6 +164,9 @@ type RunCommand struct {
 	DefaultBuildLogsToRetain uint64 `long:"default-build-logs-to-retain" description:"Default build logs to retain, 0 means all"`
 	MaxBuildLogsToRetain     uint64 `long:"max-build-logs-to-retain" description:"Maximum build logs to retain, 0 means not specified. Will override values configured in jobs"`
 
+	DefaultVersionHistoryKeep    int           `long:"default-version-history-keep" description:"Default number of most recent versions of each resource to keep, 0 means all. Will be overridden by version_history configured in resources"`
+	DefaultVersionHistoryKeepFor time.Duration `long:"default-version-history-keep-for" description:"Default period for which the versions of each resource are kept, 0 means forever. Will be overridden by version_history configured in resources"`
+
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
 
@@ -353,6 +371,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnableGlobalResources = cmd.EnableGlobalResources
 
 	radar.GlobalResourceCheckTimeout = cmd.GlobalResourceCheckTimeout
+	radar.ATCURL = cmd.PeerURLOrDefault().String()
 	//FIXME: These only need to run once for the entire binary. At the moment,
 	//they rely on state of the command.
 	db.SetupConnectionRetryingDriver(
@@ -503,6 +522,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	resourceFetcherFactory := resource.NewFetcherFactory(lockFactory, clock.NewClock(), dbResourceCacheFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
+	dbCheckRateLimiter := db.NewCheckRateLimiter(dbConn, cmd.checkRateLimits())
 	imageResourceFetcherFactory := image.NewImageResourceFetcherFactory(
 		resourceFetcherFactory,
 		dbResourceCacheFactory,
@@ -530,12 +550,18 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		cmd.VolumeStreamEncoding,
+		[]byte(cmd.VolumeStreamKey),
 	)
 
-	workerClient := cmd.constructWorkerPool(
+	workerClient, err := cmd.constructWorkerPool(
 		logger,
 		workerProvider,
+		dbWorkerFactory,
 	)
+	if err != nil {
+		return nil, err
+	}
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resource.NewResourceFactory(workerClient)
@@ -544,28 +570,37 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	baseResourceTypeDefaults, err := cmd.parseBaseResourceTypeDefaults()
+	if err != nil {
+		return nil, err
+	}
+
 	variablesFactory, err := cmd.variablesFactory(logger)
 	if err != nil {
 		return nil, err
 	}
 
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbResourceConfigFactory, variablesFactory, defaultLimits)
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbResourceConfigFactory, teamFactory, variablesFactory, defaultLimits)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
 		dbResourceConfigFactory,
-		cmd.ResourceTypeCheckingInterval,
+		dbCheckRateLimiter,
 		cmd.ResourceCheckingInterval,
+		baseResourceTypeDefaults,
 		engine,
 	)
 
 	radarScannerFactory := radar.NewScannerFactory(
 		resourceFactory,
 		dbResourceConfigFactory,
+		dbCheckRateLimiter,
 		cmd.ResourceTypeCheckingInterval,
 		cmd.ResourceCheckingInterval,
 		cmd.ExternalURL.String(),
 		variablesFactory,
+		baseResourceTypeDefaults,
+		cmd.CheckResourceTypeDependents,
 	)
 
 	drain := make(chan struct{})
@@ -598,6 +633,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		radarSchedulerFactory,
 		radarScannerFactory,
 		variablesFactory,
+		baseResourceTypeDefaults,
 		credsManagers,
 		accessFactory,
 	)
@@ -701,6 +737,7 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
 	resourceFetcherFactory := resource.NewFetcherFactory(lockFactory, clock.NewClock(), dbResourceCacheFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
+	dbCheckRateLimiter := db.NewCheckRateLimiter(dbConn, cmd.checkRateLimits())
 	imageResourceFetcherFactory := image.NewImageResourceFetcherFactory(
 		resourceFetcherFactory,
 		dbResourceCacheFactory,
@@ -728,11 +765,17 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		cmd.VolumeStreamEncoding,
+		[]byte(cmd.VolumeStreamKey),
 	)
-	workerClient := cmd.constructWorkerPool(
+	workerClient, err := cmd.constructWorkerPool(
 		logger,
 		workerProvider,
+		dbWorkerFactory,
 	)
+	if err != nil {
+		return nil, err
+	}
 
 	resourceFetcher := resourceFetcherFactory.FetcherFor(workerClient)
 	resourceFactory := resource.NewResourceFactory(workerClient)
@@ -741,26 +784,46 @@ func (cmd *RunCommand) constructBackendMembers(
 		return nil, err
 	}
 
+	baseResourceTypeDefaults, err := cmd.parseBaseResourceTypeDefaults()
+	if err != nil {
+		return nil, err
+	}
+
 	variablesFactory, err := cmd.variablesFactory(logger)
 	if err != nil {
 		return nil, err
 	}
-	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbResourceConfigFactory, variablesFactory, defaultLimits)
+	engine := cmd.constructEngine(workerClient, resourceFetcher, resourceFactory, dbResourceCacheFactory, dbResourceConfigFactory, teamFactory, variablesFactory, defaultLimits)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
 		resourceFactory,
 		dbResourceConfigFactory,
-		cmd.ResourceTypeCheckingInterval,
+		dbCheckRateLimiter,
 		cmd.ResourceCheckingInterval,
+		baseResourceTypeDefaults,
 		engine,
 	)
+	radarScannerFactory := radar.NewScannerFactory(
+		resourceFactory,
+		dbResourceConfigFactory,
+		dbCheckRateLimiter,
+		cmd.ResourceTypeCheckingInterval,
+		cmd.ResourceCheckingInterval,
+		cmd.ExternalURL.String(),
+		variablesFactory,
+		baseResourceTypeDefaults,
+		cmd.CheckResourceTypeDependents,
+	)
 	dbWorkerLifecycle := db.NewWorkerLifecycle(dbConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(dbConn)
+	dbCheckLifecycle := db.NewCheckLifecycle(dbConn)
+	dbVersionHistoryLifecycle := db.NewVersionHistoryLifecycle(dbConn, lockFactory)
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(dbConn)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
 	bus := dbConn.Bus()
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
+	dbCheckableFactory := db.NewCheckableFactory(dbConn, lockFactory)
 	members := []grouper.Member{
 		{Name: "drainer", Runner: drainer{
 			logger: logger.Session("drain"),
@@ -782,6 +845,18 @@ func (cmd *RunCommand) constructBackendMembers(
 			Interval: 10 * time.Second,
 			Clock:    clock.NewClock(),
 		}},
+		{Name: "check-scheduler", Runner: radar.NewCheckScheduler(
+			logger.Session("check-scheduler"),
+			clock.NewClock(),
+			cmd.Developer.Noop,
+			dbPipelineFactory,
+			dbCheckableFactory,
+			radarScannerFactory,
+			cmd.ResourceTypeCheckingInterval,
+			cmd.ResourceCheckingInterval,
+			cmd.CheckSchedulerInterval,
+			cmd.MaxChecksInFlight,
+		)},
 		{Name: "builds", Runner: builds.TrackerRunner{
 			Tracker: builds.NewTracker(
 				logger.Session("build-tracker"),
@@ -818,6 +893,10 @@ func (cmd *RunCommand) constructBackendMembers(
 				gc.NewResourceConfigCheckSessionCollector(
 					resourceConfigCheckSessionLifecycle,
 				),
+				gc.NewCheckCollector(
+					dbCheckLifecycle,
+					cmd.GC.CheckRetention,
+				),
 			),
 			"collector",
 			lockFactory,
@@ -841,6 +920,19 @@ func (cmd *RunCommand) constructBackendMembers(
 			clock.NewClock(),
 			30*time.Second,
 		)},
+		// run separately so as to not preempt critical GC
+		{Name: "version-history-collector", Runner: lockrunner.NewRunner(
+			logger.Session("version-history-collector"),
+			gc.NewVersionHistoryCollector(
+				dbVersionHistoryLifecycle,
+				cmd.DefaultVersionHistoryKeep,
+				cmd.DefaultVersionHistoryKeepFor,
+			),
+			"version-history-collector",
+			lockFactory,
+			clock.NewClock(),
+			cmd.GC.Interval,
+		)},
 	}
 
 	//Syslog Drainer Configuration
@@ -1020,6 +1112,26 @@ func (cmd *RunCommand) parseDefaultLimits() (atc.ContainerLimits, error) {
 	})
 }
 
+// parseBaseResourceTypeDefaults reads the resource type defaults configured on
+// the ATC, if any.
+func (cmd *RunCommand) parseBaseResourceTypeDefaults() (atc.ResourceTypeDefaults, error) {
+	if cmd.BaseResourceTypeDefaults == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(cmd.BaseResourceTypeDefaults.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := atc.ParseResourceTypeDefaults(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base resource type defaults: %s", err)
+	}
+
+	return defaults, nil
+}
+
 func (cmd *RunCommand) defaultBindIP() net.IP {
 	URL := cmd.BindIP.String()
 	if URL == "0.0.0.0" {
@@ -1089,6 +1201,11 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	_, err := cmd.parseBaseResourceTypeDefaults()
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1104,6 +1221,18 @@ func (cmd *RunCommand) debugBindAddr() string {
 	return fmt.Sprintf("%s:%d", cmd.DebugBindIP, cmd.DebugBindPort)
 }
 
+func (cmd *RunCommand) checkRateLimits() map[string]db.CheckRateLimit {
+	limits := map[string]db.CheckRateLimit{}
+	for _, limit := range cmd.CheckRateLimits {
+		limits[limit.ResourceType] = db.CheckRateLimit{
+			PerMinute: limit.PerMinute,
+			Burst:     limit.Burst,
+		}
+	}
+
+	return limits
+}
+
 func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 	host := cmd.Metrics.HostName
 	if host == "" {
@@ -1160,22 +1289,24 @@ func (cmd *RunCommand) constructLockConn(driverName string) (*sql.DB, error) {
 func (cmd *RunCommand) constructWorkerPool(
 	logger lager.Logger,
 	workerProvider worker.WorkerProvider,
-) worker.Client {
-
-	var strategy worker.ContainerPlacementStrategy
-	switch cmd.ContainerPlacementStrategy {
-	case "random":
-		strategy = worker.NewRandomPlacementStrategy()
-	case "fewest-build-containers":
-		strategy = worker.NewFewestBuildContainersPlacementStrategy()
-	default:
-		strategy = worker.NewVolumeLocalityPlacementStrategy()
+	workerFactory db.WorkerFactory,
+) (worker.Client, error) {
+	strategy, err := worker.NewContainerPlacementStrategy(
+		worker.ContainerPlacementStrategyOptions{
+			Nodes:                   strings.Split(cmd.ContainerPlacementStrategy, ","),
+			MaxActiveTasksPerWorker: cmd.MaxActiveTasksPerWorker,
+		},
+		workerFactory,
+	)
+	if err != nil {
+		return nil, err
 	}
 
 	return worker.NewPool(
+		clock.NewClock(),
 		workerProvider,
 		strategy,
-	)
+	), nil
 }
 
 func (cmd *RunCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) error {
@@ -1207,6 +1338,7 @@ func (cmd *RunCommand) constructEngine(
 	resourceFactory resource.ResourceFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	teamFactory db.TeamFactory,
 	variablesFactory creds.VariablesFactory,
 	defaultLimits atc.ContainerLimits,
 ) engine.Engine {
@@ -1216,6 +1348,7 @@ func (cmd *RunCommand) constructEngine(
 		resourceFactory,
 		resourceCacheFactory,
 		resourceConfigFactory,
+		teamFactory,
 		variablesFactory,
 		defaultLimits,
 	)
@@ -1282,6 +1415,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	radarScannerFactory radar.ScannerFactory,
 	variablesFactory creds.VariablesFactory,
+	baseResourceTypeDefaults atc.ResourceTypeDefaults,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
 ) (http.Handler, error) {
@@ -1337,6 +1471,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		concourse.Version,
 		concourse.WorkerVersion,
 		variablesFactory,
+		baseResourceTypeDefaults,
 		credsManagers,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 	)
@@ -1375,19 +1510,6 @@ func (cmd *RunCommand) constructPipelineSyncer(
 		func(pipeline db.Pipeline) ifrit.Runner {
 			variables := variablesFactory.NewVariables(pipeline.TeamName(), pipeline.Name())
 			return grouper.NewParallel(os.Interrupt, grouper.Members{
-				{
-					Name: fmt.Sprintf("radar:%d", pipeline.ID()),
-					Runner: radar.NewRunner(
-						logger.Session("radar").WithData(lager.Data{
-							"team":     pipeline.TeamName(),
-							"pipeline": pipeline.Name(),
-						}),
-						cmd.Developer.Noop,
-						radarSchedulerFactory.BuildScanRunnerFactory(pipeline, cmd.ExternalURL.String(), variables),
-						pipeline,
-						1*time.Minute,
-					),
-				},
 				{
 					Name: fmt.Sprintf("scheduler:%d", pipeline.ID()),
 					Runner: &scheduler.Runner{
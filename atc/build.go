@@ -11,6 +11,10 @@ const (
 	StatusAborted   BuildStatus = "aborted"
 )
 
+// BuildStatusReasonTimedOut is the reason given for the status of a build
+// which was aborted because it ran for longer than its job's build_timeout.
+const BuildStatusReasonTimedOut = "timed out"
+
 type Build struct {
 	ID           int    `json:"id"`
 	TeamName     string `json:"team_name"`
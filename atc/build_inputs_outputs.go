@@ -22,4 +22,36 @@ type ResourceVersion struct {
 	Metadata []MetadataField `json:"metadata"`
 	Version  Version         `json:"version"`
 	Enabled  bool            `json:"enabled"`
+
+	// Annotations are the version's history of being disabled, enabled and
+	// pinned, oldest first.
+	Annotations []VersionAnnotation `json:"annotations,omitempty"`
+}
+
+const (
+	VersionActionDisable = "disable"
+	VersionActionEnable  = "enable"
+	VersionActionPin     = "pin"
+)
+
+// A VersionAnnotation records a version of a resource being disabled,
+// enabled or pinned, by whom and why.
+type VersionAnnotation struct {
+	Action    string `json:"action"`
+	UserName  string `json:"user_name,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// A VersionAnnotationRequest gives the reason for disabling, enabling or
+// pinning a version of a resource.
+type VersionAnnotationRequest struct {
+	Comment string `json:"comment"`
+}
+
+// A SaveVersionRequest injects a version into a resource without it being
+// found by a check.
+type SaveVersionRequest struct {
+	Version  Version         `json:"version"`
+	Metadata []MetadataField `json:"metadata,omitempty"`
 }
@@ -0,0 +1,46 @@
+package atc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CheckRateLimitFlag limits how often the resources of a type are checked,
+// given as TYPE:CHECKS_PER_MINUTE or TYPE:CHECKS_PER_MINUTE:BURST. The burst
+// defaults to the number of checks per minute.
+type CheckRateLimitFlag struct {
+	ResourceType string
+	PerMinute    float64
+	Burst        int
+}
+
+func (limit *CheckRateLimitFlag) UnmarshalFlag(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" {
+		return fmt.Errorf("invalid check rate limit '%s': expected TYPE:CHECKS_PER_MINUTE[:BURST]", value)
+	}
+
+	perMinute, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || perMinute <= 0 {
+		return fmt.Errorf("invalid check rate limit '%s': checks per minute must be a positive number", value)
+	}
+
+	burst := int(perMinute)
+	if len(parts) == 3 {
+		burst, err = strconv.Atoi(parts[2])
+		if err != nil {
+			return fmt.Errorf("invalid check rate limit '%s': burst must be a whole number", value)
+		}
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	limit.ResourceType = parts[0]
+	limit.PerMinute = perMinute
+	limit.Burst = burst
+
+	return nil
+}
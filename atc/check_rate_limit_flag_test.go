@@ -0,0 +1,49 @@
+package atc_test
+
+import (
+	. "github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRateLimitFlag", func() {
+	var limit CheckRateLimitFlag
+
+	BeforeEach(func() {
+		limit = CheckRateLimitFlag{}
+	})
+
+	It("parses a type and a number of checks per minute", func() {
+		Expect(limit.UnmarshalFlag("github-release:30")).To(Succeed())
+		Expect(limit).To(Equal(CheckRateLimitFlag{
+			ResourceType: "github-release",
+			PerMinute:    30,
+			Burst:        30,
+		}))
+	})
+
+	It("parses a burst", func() {
+		Expect(limit.UnmarshalFlag("registry-image:0.5:5")).To(Succeed())
+		Expect(limit).To(Equal(CheckRateLimitFlag{
+			ResourceType: "registry-image",
+			PerMinute:    0.5,
+			Burst:        5,
+		}))
+	})
+
+	It("allows at least one check at a time", func() {
+		Expect(limit.UnmarshalFlag("registry-image:0.5")).To(Succeed())
+		Expect(limit.Burst).To(Equal(1))
+	})
+
+	It("errors without a number of checks per minute", func() {
+		Expect(limit.UnmarshalFlag("github-release")).ToNot(Succeed())
+		Expect(limit.UnmarshalFlag("github-release:often")).ToNot(Succeed())
+		Expect(limit.UnmarshalFlag("github-release:-1")).ToNot(Succeed())
+	})
+
+	It("errors with an invalid burst", func() {
+		Expect(limit.UnmarshalFlag("github-release:30:lots")).ToNot(Succeed())
+	})
+})
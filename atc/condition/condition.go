@@ -0,0 +1,389 @@
+// Package condition implements the expressions used to decide whether a step
+// with an 'if' runs, e.g.
+//
+//	$BUILD_PIPELINE_NAME == "main" && ((.:deploy)) != false
+//
+// Expressions compare literals ("strings", 'strings', numbers, true, false
+// and null) with references to build-local vars (((.:name.field))), build
+// metadata ($BUILD_JOB_NAME) and the versions fetched by the build's get steps
+// (version.name.field). Values are compared with ==, != and =~ (which matches
+// a string against a regular expression), and comparisons are combined with
+// &&, || and !, grouped by parentheses.
+//
+// The same expressions filter the payloads received by resource webhooks, in
+// which case fields of the payload are referred to by JSONPath-style paths,
+// e.g.
+//
+//	$.ref == "refs/heads/master" && $.commits[0].author.name != "bot"
+package condition
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc/template"
+)
+
+var knownMetadata = map[string]bool{
+	"BUILD_ID":            true,
+	"BUILD_NAME":          true,
+	"BUILD_JOB_NAME":      true,
+	"BUILD_PIPELINE_NAME": true,
+	"BUILD_TEAM_NAME":     true,
+	"ATC_EXTERNAL_URL":    true,
+}
+
+// Vars are the values that a Condition's references are resolved against.
+type Vars struct {
+	// the build's local vars, by name
+	Local map[string]interface{}
+
+	// the build's metadata, by environment variable name, e.g. BUILD_ID
+	Metadata map[string]string
+
+	// the versions fetched by the build's get steps, by step name
+	Versions map[string]map[string]string
+
+	// the decoded JSON payload received by a webhook
+	Payload interface{}
+}
+
+// A Condition is a parsed expression.
+type Condition struct {
+	source string
+	expr   expression
+}
+
+// Parse parses an expression, returning an error if it is malformed or refers
+// to unknown build metadata.
+func Parse(source string) (Condition, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return Condition{}, err
+	}
+
+	if len(tokens) == 0 {
+		return Condition{}, fmt.Errorf("empty condition")
+	}
+
+	p := &parser{tokens: tokens}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return Condition{}, err
+	}
+
+	if !p.done() {
+		return Condition{}, fmt.Errorf("unexpected %s at position %d", p.describe(), p.peek().start)
+	}
+
+	return Condition{source: source, expr: expr}, nil
+}
+
+func (c Condition) String() string {
+	return c.source
+}
+
+// Evaluate resolves the condition's references and returns whether it holds.
+// It errors if a reference cannot be resolved or the condition does not
+// evaluate to true or false.
+func (c Condition) Evaluate(vars Vars) (bool, error) {
+	value, err := c.expr.evaluate(vars)
+	if err != nil {
+		return false, err
+	}
+
+	result, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition must evaluate to true or false, got %s", describeValue(value))
+	}
+
+	return result, nil
+}
+
+// Interpolate replaces references to the given local vars with literals of
+// their values, leaving any other references to be resolved when the
+// condition is evaluated. Only strings, numbers, booleans and null can be
+// interpolated.
+func Interpolate(source string, vars map[string]interface{}) (string, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return "", err
+	}
+
+	interpolated := ""
+	last := 0
+	for _, tok := range tokens {
+		if tok.kind != tokenLocalVar {
+			continue
+		}
+
+		val, found, err := template.LookupLocalVar(vars, tok.text, tok.path)
+		if err != nil {
+			return "", err
+		}
+
+		if !found {
+			continue
+		}
+
+		value := normalize(val)
+
+		switch value.(type) {
+		case string, float64, bool, nil:
+		default:
+			return "", fmt.Errorf("cannot use local var '%s' of type %T within a condition", strings.Trim(source[tok.start:tok.end], "()"), val)
+		}
+
+		payload, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+
+		interpolated += source[last:tok.start] + string(payload)
+		last = tok.end
+	}
+
+	return interpolated + source[last:], nil
+}
+
+type expression interface {
+	evaluate(Vars) (interface{}, error)
+}
+
+type literal struct {
+	value interface{}
+}
+
+func (e literal) evaluate(Vars) (interface{}, error) {
+	return normalize(e.value), nil
+}
+
+type localVar struct {
+	name string
+	path string
+}
+
+func (e localVar) evaluate(vars Vars) (interface{}, error) {
+	val, found, err := template.LookupLocalVar(vars.Local, e.name, e.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, template.UndefinedLocalVarsError{Vars: []string{e.name}}
+	}
+
+	return normalize(val), nil
+}
+
+type metadata struct {
+	name string
+}
+
+func (e metadata) evaluate(vars Vars) (interface{}, error) {
+	return vars.Metadata[e.name], nil
+}
+
+type version struct {
+	name  string
+	field string
+}
+
+func (e version) evaluate(vars Vars) (interface{}, error) {
+	fields, found := vars.Versions[e.name]
+	if !found {
+		return nil, fmt.Errorf("no version of '%s' has been fetched", e.name)
+	}
+
+	val, found := fields[e.field]
+	if !found {
+		return nil, fmt.Errorf("version of '%s' has no field '%s'", e.name, e.field)
+	}
+
+	return val, nil
+}
+
+type payload struct {
+	path string
+}
+
+var payloadSegmentRegex = regexp.MustCompile(`\.([-\w]+)|\[(\d+)\]`)
+
+// evaluate looks up the path within the payload. Fields which are missing
+// from the payload are null, so that a filter on them does not match rather
+// than erroring.
+func (e payload) evaluate(vars Vars) (interface{}, error) {
+	val := vars.Payload
+
+	for _, segment := range payloadSegmentRegex.FindAllStringSubmatch(e.path, -1) {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			if segment[1] == "" {
+				return nil, nil
+			}
+
+			val = v[segment[1]]
+		case []interface{}:
+			if segment[2] == "" {
+				return nil, nil
+			}
+
+			index, err := strconv.Atoi(segment[2])
+			if err != nil || index >= len(v) {
+				return nil, nil
+			}
+
+			val = v[index]
+		default:
+			return nil, nil
+		}
+	}
+
+	return normalize(val), nil
+}
+
+type not struct {
+	expr expression
+}
+
+func (e not) evaluate(vars Vars) (interface{}, error) {
+	val, err := evaluateBool(e.expr, vars, "!")
+	if err != nil {
+		return nil, err
+	}
+
+	return !val, nil
+}
+
+type and struct {
+	left, right expression
+}
+
+func (e and) evaluate(vars Vars) (interface{}, error) {
+	left, err := evaluateBool(e.left, vars, "&&")
+	if err != nil || !left {
+		return false, err
+	}
+
+	return evaluateBool(e.right, vars, "&&")
+}
+
+type or struct {
+	left, right expression
+}
+
+func (e or) evaluate(vars Vars) (interface{}, error) {
+	left, err := evaluateBool(e.left, vars, "||")
+	if err != nil || left {
+		return left, err
+	}
+
+	return evaluateBool(e.right, vars, "||")
+}
+
+type equals struct {
+	left, right expression
+	negated     bool
+}
+
+func (e equals) evaluate(vars Vars) (interface{}, error) {
+	left, err := e.left.evaluate(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := e.right.evaluate(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return reflect.DeepEqual(left, right) != e.negated, nil
+}
+
+type matches struct {
+	left    expression
+	right   expression
+	pattern *regexp.Regexp
+}
+
+func (e matches) evaluate(vars Vars) (interface{}, error) {
+	left, err := e.left.evaluate(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	str, ok := left.(string)
+	if !ok {
+		return nil, fmt.Errorf("=~ requires a string, got %s", describeValue(left))
+	}
+
+	pattern := e.pattern
+	if pattern == nil {
+		right, err := e.right.evaluate(vars)
+		if err != nil {
+			return nil, err
+		}
+
+		patternStr, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("=~ requires a regular expression string, got %s", describeValue(right))
+		}
+
+		pattern, err = regexp.Compile(patternStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pattern.MatchString(str), nil
+}
+
+func evaluateBool(expr expression, vars Vars, operator string) (bool, error) {
+	val, err := expr.evaluate(vars)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := val.(bool)
+	if !ok {
+		return false, fmt.Errorf("%s requires true or false, got %s", operator, describeValue(val))
+	}
+
+	return b, nil
+}
+
+// normalize converts numbers to float64 so that values compare equal
+// regardless of how they were decoded.
+func normalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return v.String()
+		}
+
+		return f
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+
+	return val
+}
+
+func describeValue(val interface{}) string {
+	payload, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+
+	return string(payload)
+}
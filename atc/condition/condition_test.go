@@ -0,0 +1,122 @@
+package condition_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc/condition"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Condition", func() {
+	var vars condition.Vars
+
+	BeforeEach(func() {
+		vars = condition.Vars{
+			Local: map[string]interface{}{
+				"branch": "main",
+				"deploy": true,
+				"count":  json.Number("3"),
+				"config": map[string]interface{}{"env": "prod"},
+			},
+			Metadata: map[string]string{
+				"BUILD_PIPELINE_NAME": "some-pipeline",
+				"BUILD_JOB_NAME":      "some-job",
+			},
+			Versions: map[string]map[string]string{
+				"some-repo": {"ref": "abcdef"},
+			},
+			Payload: map[string]interface{}{
+				"ref": "refs/heads/master",
+				"commits": []interface{}{
+					map[string]interface{}{"id": "abcdef", "distinct": true},
+				},
+			},
+		}
+	})
+
+	DescribeTable("evaluating",
+		func(source string, expected bool) {
+			cond, err := condition.Parse(source)
+			Expect(err).ToNot(HaveOccurred())
+
+			result, err := cond.Evaluate(vars)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("literal booleans", `true`, true),
+		Entry("comparing strings", `"a" == 'a'`, true),
+		Entry("comparing numbers regardless of their form", `((.:count)) == 3.0`, true),
+		Entry("comparing values of different types", `((.:count)) == "3"`, false),
+		Entry("local vars", `((.:branch)) == "main"`, true),
+		Entry("local var fields", `((.:config.env)) != "prod"`, false),
+		Entry("local vars which are booleans", `((.:deploy))`, true),
+		Entry("build metadata", `$BUILD_PIPELINE_NAME == "some-pipeline"`, true),
+		Entry("build metadata which is not set", `$BUILD_TEAM_NAME == ""`, true),
+		Entry("versions", `version.some-repo.ref == "abcdef"`, true),
+		Entry("payload fields", `$.ref == "refs/heads/master"`, true),
+		Entry("payload array elements", `$.commits[0].id == "abcdef" && $.commits[0].distinct`, true),
+		Entry("payload fields which are missing", `$.repository.name == null && $.commits[1] == null`, true),
+		Entry("regular expressions", `$BUILD_JOB_NAME =~ "^some-"`, true),
+		Entry("negation", `!((.:deploy))`, false),
+		Entry("&& binding tighter than ||", `true || false && false`, true),
+		Entry("parentheses", `(true || false) && false`, false),
+	)
+
+	DescribeTable("parsing invalid conditions",
+		func(source string, message string) {
+			_, err := condition.Parse(source)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("empty", ``, "empty condition"),
+		Entry("unknown metadata", `$BOGUS == "x"`, "unknown build metadata '$BOGUS'"),
+		Entry("bare words", `main == "main"`, "unexpected 'main' at position 0"),
+		Entry("unclosed strings", `"main`, `invalid string at position 0: missing closing "`),
+		Entry("unclosed parentheses", `(true`, "missing closing parenthesis for the one at position 0"),
+		Entry("trailing values", `true false`, "unexpected value at position 5"),
+		Entry("missing operands", `true &&`, "unexpected end of condition"),
+		Entry("invalid regular expressions", `"a" =~ "("`, "invalid regular expression '(': error parsing regexp: missing closing ): `(`"),
+	)
+
+	Context("when a reference cannot be resolved", func() {
+		It("errors for undefined local vars", func() {
+			cond, err := condition.Parse(`((.:bogus)) == "x"`)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = cond.Evaluate(vars)
+			Expect(err).To(MatchError("undefined local vars: bogus"))
+		})
+
+		It("errors for versions which have not been fetched", func() {
+			cond, err := condition.Parse(`version.bogus.ref == "x"`)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = cond.Evaluate(vars)
+			Expect(err).To(MatchError("no version of 'bogus' has been fetched"))
+		})
+	})
+
+	Context("when the condition does not evaluate to a boolean", func() {
+		It("errors", func() {
+			cond, err := condition.Parse(`((.:branch))`)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = cond.Evaluate(vars)
+			Expect(err).To(MatchError(`condition must evaluate to true or false, got "main"`))
+		})
+	})
+
+	Describe("Interpolate", func() {
+		It("replaces the given vars with literals, leaving others alone", func() {
+			interpolated, err := condition.Interpolate(`((.:branch)) == "main" && ((.:other)) && ((.:count)) == 3`, vars.Local)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(interpolated).To(Equal(`"main" == "main" && ((.:other)) && 3 == 3`))
+		})
+
+		It("errors for vars which are not scalars", func() {
+			_, err := condition.Interpolate(`((.:config)) == "x"`, vars.Local)
+			Expect(err).To(MatchError("cannot use local var '.:config' of type map[string]interface {} within a condition"))
+		})
+	})
+})
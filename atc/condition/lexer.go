@@ -0,0 +1,182 @@
+package condition
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenLiteral tokenKind = iota
+	tokenLocalVar
+	tokenMetadata
+	tokenVersion
+	tokenPayload
+	tokenOperator
+	tokenOpenParen
+	tokenCloseParen
+)
+
+type token struct {
+	kind tokenKind
+
+	// the position of the token within the source
+	start int
+	end   int
+
+	// set for literals
+	value interface{}
+
+	// set for operators, and the names of references
+	text string
+
+	// set for local vars (e.g. ".field"), versions (e.g. "field") and
+	// payloads (e.g. ".commits[0].id")
+	path string
+}
+
+var (
+	localVarRegex = regexp.MustCompile(`^\(\(\.:([-\w\p{L}]+)((?:\.[-\w\p{L}]+)*)\)\)`)
+	metadataRegex = regexp.MustCompile(`^\$(\w+)`)
+	payloadRegex  = regexp.MustCompile(`^\$((?:\.[-\w]+|\[\d+\])+)`)
+	versionRegex  = regexp.MustCompile(`^version\.([-\w]+)\.([-\w]+)`)
+	numberRegex   = regexp.MustCompile(`^-?\d+(\.\d+)?`)
+	wordRegex     = regexp.MustCompile(`^[-\w.]+`)
+)
+
+var operators = []string{"==", "!=", "=~", "&&", "||", "!"}
+
+func lex(source string) ([]token, error) {
+	tokens := []token{}
+
+	pos := 0
+	for pos < len(source) {
+		rest := source[pos:]
+
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r':
+			pos++
+			continue
+
+		case rest[0] == '(' && !strings.HasPrefix(rest, "(("):
+			tokens = append(tokens, token{kind: tokenOpenParen, start: pos, end: pos + 1})
+			pos++
+			continue
+
+		case rest[0] == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, start: pos, end: pos + 1})
+			pos++
+			continue
+
+		case rest[0] == '"' || rest[0] == '\'':
+			value, length, err := lexString(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %s", pos, err)
+			}
+
+			tokens = append(tokens, token{kind: tokenLiteral, start: pos, end: pos + length, value: value})
+			pos += length
+			continue
+		}
+
+		if match := localVarRegex.FindStringSubmatch(rest); match != nil {
+			tokens = append(tokens, token{kind: tokenLocalVar, start: pos, end: pos + len(match[0]), text: match[1], path: match[2]})
+			pos += len(match[0])
+			continue
+		}
+
+		if match := payloadRegex.FindStringSubmatch(rest); match != nil {
+			tokens = append(tokens, token{kind: tokenPayload, start: pos, end: pos + len(match[0]), path: match[1]})
+			pos += len(match[0])
+			continue
+		}
+
+		if match := metadataRegex.FindStringSubmatch(rest); match != nil {
+			if !knownMetadata[match[1]] {
+				return nil, fmt.Errorf("unknown build metadata '%s'", match[0])
+			}
+
+			tokens = append(tokens, token{kind: tokenMetadata, start: pos, end: pos + len(match[0]), text: match[1]})
+			pos += len(match[0])
+			continue
+		}
+
+		if match := numberRegex.FindString(rest); match != "" {
+			tokens = append(tokens, token{kind: tokenLiteral, start: pos, end: pos + len(match), value: json.Number(match)})
+			pos += len(match)
+			continue
+		}
+
+		operator := ""
+		for _, op := range operators {
+			if strings.HasPrefix(rest, op) {
+				operator = op
+				break
+			}
+		}
+
+		if operator != "" {
+			tokens = append(tokens, token{kind: tokenOperator, start: pos, end: pos + len(operator), text: operator})
+			pos += len(operator)
+			continue
+		}
+
+		if match := versionRegex.FindStringSubmatch(rest); match != nil && len(match[0]) == len(wordRegex.FindString(rest)) {
+			tokens = append(tokens, token{kind: tokenVersion, start: pos, end: pos + len(match[0]), text: match[1], path: match[2]})
+			pos += len(match[0])
+			continue
+		}
+
+		word := wordRegex.FindString(rest)
+		switch word {
+		case "true":
+			tokens = append(tokens, token{kind: tokenLiteral, start: pos, end: pos + len(word), value: true})
+		case "false":
+			tokens = append(tokens, token{kind: tokenLiteral, start: pos, end: pos + len(word), value: false})
+		case "null":
+			tokens = append(tokens, token{kind: tokenLiteral, start: pos, end: pos + len(word), value: nil})
+		case "":
+			return nil, fmt.Errorf("unexpected '%c' at position %d", rest[0], pos)
+		default:
+			return nil, fmt.Errorf("unexpected '%s' at position %d", word, pos)
+		}
+
+		pos += len(word)
+	}
+
+	return tokens, nil
+}
+
+// lexString reads a string literal from the start of the source. Double
+// quoted strings are read as JSON strings, while single quoted strings are
+// taken as-is.
+func lexString(source string) (string, int, error) {
+	quote := source[0]
+
+	for i := 1; i < len(source); i++ {
+		switch source[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+
+		case quote:
+			if quote == '\'' {
+				return source[1:i], i + 1, nil
+			}
+
+			var value string
+			err := json.Unmarshal([]byte(source[:i+1]), &value)
+			if err != nil {
+				return "", 0, err
+			}
+
+			return value, i + 1, nil
+		}
+	}
+
+	return "", 0, fmt.Errorf("missing closing %c", quote)
+}
@@ -0,0 +1,191 @@
+package condition
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// parser is a recursive descent parser over the tokens of an expression.
+// From lowest to highest precedence, expressions are made up of:
+//
+//	or:         and ("||" and)*
+//	and:        unary ("&&" unary)*
+//	unary:      "!" unary | comparison
+//	comparison: operand (("==" | "!=" | "=~") operand)?
+//	operand:    literal | reference | "(" or ")"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) parseOr() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.nextIsOperator("||") {
+		p.pos++
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = or{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.nextIsOperator("&&") {
+		p.pos++
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = and{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expression, error) {
+	if p.nextIsOperator("!") {
+		p.pos++
+
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return not{expr}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (expression, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.nextIsOperator("=="), p.nextIsOperator("!="):
+		negated := p.peek().text == "!="
+		p.pos++
+
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return equals{left: left, right: right, negated: negated}, nil
+
+	case p.nextIsOperator("=~"):
+		p.pos++
+
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		expr := matches{left: left, right: right}
+
+		// compile literal patterns up front so that invalid ones are caught
+		// when the condition is parsed
+		if lit, ok := right.(literal); ok {
+			str, ok := lit.value.(string)
+			if !ok {
+				return nil, fmt.Errorf("=~ requires a regular expression string, got %s", describeValue(lit.value))
+			}
+
+			expr.pattern, err = regexp.Compile(str)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regular expression '%s': %s", str, err)
+			}
+		}
+
+		return expr, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseOperand() (expression, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	tok := p.peek()
+	p.pos++
+
+	switch tok.kind {
+	case tokenLiteral:
+		return literal{tok.value}, nil
+
+	case tokenLocalVar:
+		return localVar{name: tok.text, path: tok.path}, nil
+
+	case tokenMetadata:
+		return metadata{name: tok.text}, nil
+
+	case tokenVersion:
+		return version{name: tok.text, field: tok.path}, nil
+
+	case tokenPayload:
+		return payload{path: tok.path}, nil
+
+	case tokenOpenParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.done() || p.peek().kind != tokenCloseParen {
+			return nil, fmt.Errorf("missing closing parenthesis for the one at position %d", tok.start)
+		}
+
+		p.pos++
+
+		return expr, nil
+	}
+
+	p.pos--
+
+	return nil, fmt.Errorf("unexpected %s at position %d", p.describe(), tok.start)
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) nextIsOperator(operator string) bool {
+	return !p.done() && p.peek().kind == tokenOperator && p.peek().text == operator
+}
+
+func (p *parser) describe() string {
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokenOperator:
+		return fmt.Sprintf("'%s'", tok.text)
+	case tokenCloseParen:
+		return "')'"
+	default:
+		return "value"
+	}
+}
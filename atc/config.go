@@ -1,10 +1,14 @@
 package atc
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const ConfigVersionHeader = "X-Concourse-Config-Version"
@@ -24,6 +28,8 @@ type Config struct {
 	Resources     ResourceConfigs `yaml:"resources" json:"resources" mapstructure:"resources"`
 	ResourceTypes ResourceTypes   `yaml:"resource_types" json:"resource_types" mapstructure:"resource_types"`
 	Jobs          JobConfigs      `yaml:"jobs" json:"jobs" mapstructure:"jobs"`
+
+	ResourceTypeDefaults ResourceTypeDefaults `yaml:"resource_type_defaults,omitempty" json:"resource_type_defaults,omitempty" mapstructure:"resource_type_defaults"`
 }
 
 type RawConfig string
@@ -51,14 +57,75 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, int, bool) {
 }
 
 type ResourceConfig struct {
-	Name         string  `yaml:"name" json:"name" mapstructure:"name"`
-	WebhookToken string  `yaml:"webhook_token,omitempty" json:"webhook_token" mapstructure:"webhook_token"`
-	Type         string  `yaml:"type" json:"type" mapstructure:"type"`
-	Source       Source  `yaml:"source" json:"source" mapstructure:"source"`
-	CheckEvery   string  `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
-	CheckTimeout string  `yaml:"check_timeout,omitempty" json:"check_timeout" mapstructure:"check_timeout"`
-	Tags         Tags    `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
-	Version      Version `yaml:"version,omitempty" json:"version" mapstructure:"version"`
+	Name         string         `yaml:"name" json:"name" mapstructure:"name"`
+	WebhookToken string         `yaml:"webhook_token,omitempty" json:"webhook_token" mapstructure:"webhook_token"`
+	Webhook      *WebhookConfig `yaml:"webhook,omitempty" json:"webhook,omitempty" mapstructure:"webhook"`
+	Type         string         `yaml:"type" json:"type" mapstructure:"type"`
+	Source       Source         `yaml:"source" json:"source" mapstructure:"source"`
+	CheckEvery   string         `yaml:"check_every,omitempty" json:"check_every" mapstructure:"check_every"`
+	CheckTimeout string         `yaml:"check_timeout,omitempty" json:"check_timeout" mapstructure:"check_timeout"`
+	Tags         Tags           `yaml:"tags,omitempty" json:"tags" mapstructure:"tags"`
+	Version      Version        `yaml:"version,omitempty" json:"version" mapstructure:"version"`
+
+	// selects the workers for the resource's checks, and for its get and put
+	// steps which do not have a worker selector of their own
+	WorkerSelector WorkerSelector `yaml:"worker_selector,omitempty" json:"worker_selector,omitempty" mapstructure:"worker_selector"`
+
+	VersionHistory *VersionHistoryConfig `yaml:"version_history,omitempty" json:"version_history,omitempty" mapstructure:"version_history"`
+}
+
+// VersionHistoryConfig configures how many of a resource's versions are
+// kept. Versions are only removed once they are beyond the newest Keep
+// versions and older than KeepFor; leaving either unset places no limit on
+// it. The latest version, and any version used by a build, pinned or
+// disabled, is always kept.
+type VersionHistoryConfig struct {
+	// the number of most recent versions to keep
+	Keep int `yaml:"keep,omitempty" json:"keep,omitempty" mapstructure:"keep"`
+
+	// how long to keep versions for, e.g. 30d or 12h
+	KeepFor string `yaml:"keep_for,omitempty" json:"keep_for,omitempty" mapstructure:"keep_for"`
+}
+
+// KeepForDuration parses KeepFor, which is either a duration as understood
+// by time.ParseDuration or a whole number of days such as "30d".
+func (config VersionHistoryConfig) KeepForDuration() (time.Duration, error) {
+	if config.KeepFor == "" {
+		return 0, nil
+	}
+
+	if strings.HasSuffix(config.KeepFor, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(config.KeepFor, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", config.KeepFor)
+		}
+
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(config.KeepFor)
+}
+
+// DefaultWebhookSignatureHeader is the header which carries a webhook
+// payload's signature unless the resource configures another.
+const DefaultWebhookSignatureHeader = "X-Hub-Signature-256"
+
+// WebhookConfig configures how a resource's webhook verifies and filters the
+// payloads it receives.
+type WebhookConfig struct {
+	// the key of the HMAC with which payloads are signed
+	Secret string `yaml:"secret" json:"secret" mapstructure:"secret"`
+
+	// the header carrying the signature, e.g. X-Hub-Signature-256; signatures
+	// in GitHub's legacy X-Hub-Signature header use SHA-1, all others SHA-256
+	SignatureHeader string `yaml:"signature_header,omitempty" json:"signature_header,omitempty" mapstructure:"signature_header"`
+
+	// a condition on the payload which must hold for a check to run, e.g.
+	// $.ref == "refs/heads/master"
+	Filter string `yaml:"filter,omitempty" json:"filter,omitempty" mapstructure:"filter"`
+
+	// whether to pass the payload to the check script
+	PassPayload bool `yaml:"pass_payload,omitempty" json:"pass_payload,omitempty" mapstructure:"pass_payload"`
 }
 
 type ResourceType struct {
@@ -72,6 +139,8 @@ type ResourceType struct {
 	CheckSetupError      string `yaml:"check_setup_error,omitempty" json:"check_setup_error,omitempty" mapstructure:"check_setup_error"`
 	CheckError           string `yaml:"check_error,omitempty" json:"check_error,omitempty" mapstructure:"check_error"`
 	UniqueVersionHistory bool   `yaml:"unique_version_history,omitempty" json:"unique_version_history" mapstructure:"unique_version_history"`
+
+	WorkerSelector WorkerSelector `yaml:"worker_selector,omitempty" json:"worker_selector,omitempty" mapstructure:"worker_selector"`
 }
 
 type ResourceTypes []ResourceType
@@ -108,12 +177,232 @@ type Hooks struct {
 // `on: [success]` after every Task plan.
 type PlanSequence []PlanConfig
 
+// An InParallelConfig is a set of steps to run in parallel. It is configured
+// either as a plain list of steps, or with a limit on how many of the steps
+// run at once and whether to stop as soon as one of them fails.
+type InParallelConfig struct {
+	Steps    PlanSequence `yaml:"steps,omitempty" json:"steps,omitempty" mapstructure:"steps"`
+	Limit    int          `yaml:"limit,omitempty" json:"limit,omitempty" mapstructure:"limit"`
+	FailFast bool         `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+}
+
+// inParallelConfig is an alias used to unmarshal the full form of an
+// InParallelConfig without recursing into its own unmarshalers.
+type inParallelConfig InParallelConfig
+
+func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(payload), []byte("[")) {
+		var steps PlanSequence
+		err := json.Unmarshal(payload, &steps)
+		if err != nil {
+			return err
+		}
+
+		*c = InParallelConfig{Steps: steps}
+		return nil
+	}
+
+	var config inParallelConfig
+	err := json.Unmarshal(payload, &config)
+	if err != nil {
+		return err
+	}
+
+	*c = InParallelConfig(config)
+	return nil
+}
+
+func (c *InParallelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var data interface{}
+
+	err := unmarshal(&data)
+	if err != nil {
+		return err
+	}
+
+	if _, isList := data.([]interface{}); isList {
+		var steps PlanSequence
+		err := unmarshal(&steps)
+		if err != nil {
+			return err
+		}
+
+		*c = InParallelConfig{Steps: steps}
+		return nil
+	}
+
+	var config inParallelConfig
+	err = unmarshal(&config)
+	if err != nil {
+		return err
+	}
+
+	*c = InParallelConfig(config)
+	return nil
+}
+
+const (
+	AttemptOnError   = "error"
+	AttemptOnFailure = "failure"
+)
+
+// An AttemptsConfig configures how many times a step is attempted, how long
+// to wait between attempts, and whether errors, failures, or both cause the
+// step to be attempted again. It may be configured as a plain count.
+type AttemptsConfig struct {
+	Count   int            `yaml:"count,omitempty" json:"count,omitempty" mapstructure:"count"`
+	Backoff *BackoffConfig `yaml:"backoff,omitempty" json:"backoff,omitempty" mapstructure:"backoff"`
+	On      []string       `yaml:"on,omitempty" json:"on,omitempty" mapstructure:"on"`
+}
+
+// A BackoffConfig configures the delay between attempts. The first retry
+// waits Initial, and each subsequent retry waits Multiplier times longer, up
+// to Max.
+type BackoffConfig struct {
+	Initial    string  `yaml:"initial,omitempty" json:"initial,omitempty" mapstructure:"initial"`
+	Max        string  `yaml:"max,omitempty" json:"max,omitempty" mapstructure:"max"`
+	Multiplier float64 `yaml:"multiplier,omitempty" json:"multiplier,omitempty" mapstructure:"multiplier"`
+}
+
+// attemptsConfig is an alias used to (un)marshal the full form of an
+// AttemptsConfig without recursing into its own (un)marshalers.
+type attemptsConfig AttemptsConfig
+
+func (c *AttemptsConfig) UnmarshalJSON(payload []byte) error {
+	var count int
+	if json.Unmarshal(payload, &count) == nil {
+		*c = AttemptsConfig{Count: count}
+		return nil
+	}
+
+	var config attemptsConfig
+	err := json.Unmarshal(payload, &config)
+	if err != nil {
+		return err
+	}
+
+	*c = AttemptsConfig(config)
+	return nil
+}
+
+func (c *AttemptsConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var count int
+	if unmarshal(&count) == nil {
+		*c = AttemptsConfig{Count: count}
+		return nil
+	}
+
+	var config attemptsConfig
+	err := unmarshal(&config)
+	if err != nil {
+		return err
+	}
+
+	*c = AttemptsConfig(config)
+	return nil
+}
+
+func (c AttemptsConfig) MarshalJSON() ([]byte, error) {
+	if c.isCountOnly() {
+		return json.Marshal(c.Count)
+	}
+
+	return json.Marshal(attemptsConfig(c))
+}
+
+func (c AttemptsConfig) MarshalYAML() (interface{}, error) {
+	if c.isCountOnly() {
+		return c.Count, nil
+	}
+
+	return attemptsConfig(c), nil
+}
+
+func (c AttemptsConfig) isCountOnly() bool {
+	return c.Backoff == nil && len(c.On) == 0
+}
+
 // A VersionConfig represents the choice to include every version of a
 // resource, the latest version of a resource, or a pinned (specific) one.
+// Every and latest may be narrowed down to the versions passing a filter.
 type VersionConfig struct {
 	Every  bool
 	Latest bool
 	Pinned Version
+
+	Match  map[string]string
+	Semver *VersionSemverConfig
+}
+
+// A VersionSemverConfig filters versions by checking a field of each version
+// against a semver constraint, e.g. ">=1.2 <2".
+type VersionSemverConfig struct {
+	Field      string `yaml:"field" json:"field" mapstructure:"field"`
+	Constraint string `yaml:"constraint" json:"constraint" mapstructure:"constraint"`
+}
+
+// versionFilterConfig is the form of a VersionConfig with a filter, e.g.
+// {match: {tag: "^v1\\."}, every: true}.
+type versionFilterConfig struct {
+	Every  bool                 `yaml:"every,omitempty" json:"every,omitempty"`
+	Match  map[string]string    `yaml:"match,omitempty" json:"match,omitempty"`
+	Semver *VersionSemverConfig `yaml:"semver,omitempty" json:"semver,omitempty"`
+}
+
+// HasFilter returns whether the versions are narrowed down by a filter.
+func (c VersionConfig) HasFilter() bool {
+	return len(c.Match) != 0 || c.Semver != nil
+}
+
+// Filter returns a function reporting whether a version passes the filter.
+// Every field given to match must match its regular expression, and the
+// semver field must parse as a version satisfying the constraint.
+func (c VersionConfig) Filter() (func(Version) bool, error) {
+	matches := map[string]*regexp.Regexp{}
+	for field, expr := range c.Match {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match for '%s': %s", field, err)
+		}
+
+		matches[field] = re
+	}
+
+	var constraint versionConstraint
+	if c.Semver != nil {
+		if c.Semver.Field == "" {
+			return nil, errors.New("semver filter has no field")
+		}
+
+		var err error
+		constraint, err = parseVersionConstraint(c.Semver.Constraint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return func(version Version) bool {
+		for field, re := range matches {
+			value, found := version[field]
+			if !found || !re.MatchString(value) {
+				return false
+			}
+		}
+
+		if c.Semver != nil {
+			value, found := version[c.Semver.Field]
+			if !found {
+				return false
+			}
+
+			v, err := parseSemver(value)
+			if err != nil || !constraint.check(v) {
+				return false
+			}
+		}
+
+		return true
+	}, nil
 }
 
 func (c *VersionConfig) UnmarshalJSON(version []byte) error {
@@ -129,15 +418,7 @@ func (c *VersionConfig) UnmarshalJSON(version []byte) error {
 		c.Every = actual == "every"
 		c.Latest = actual == "latest"
 	case map[string]interface{}:
-		version := Version{}
-
-		for k, v := range actual {
-			if s, ok := v.(string); ok {
-				version[k] = strings.TrimSpace(s)
-			}
-		}
-
-		c.Pinned = version
+		return c.unmarshalMap(actual)
 	default:
 		return errors.New("unknown type for version")
 	}
@@ -158,17 +439,12 @@ func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		c.Every = actual == "every"
 		c.Latest = actual == "latest"
 	case map[interface{}]interface{}:
-		version := Version{}
-
-		for k, v := range actual {
-			if ks, ok := k.(string); ok {
-				if vs, ok := v.(string); ok {
-					version[ks] = strings.TrimSpace(vs)
-				}
-			}
+		sanitized, err := sanitize(actual)
+		if err != nil {
+			return err
 		}
 
-		c.Pinned = version
+		return c.unmarshalMap(sanitized.(map[string]interface{}))
 	default:
 		return errors.New("unknown type for version")
 	}
@@ -176,7 +452,58 @@ func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// unmarshalMap parses either a filter or a pinned version. A map is a filter
+// if it configures match or semver with a map, and a pinned version
+// otherwise.
+func (c *VersionConfig) unmarshalMap(data map[string]interface{}) error {
+	if isVersionFilter(data) {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		var filter versionFilterConfig
+		err = json.Unmarshal(payload, &filter)
+		if err != nil {
+			return fmt.Errorf("invalid version filter: %s", err)
+		}
+
+		c.Every = filter.Every
+		c.Latest = !filter.Every
+		c.Match = filter.Match
+		c.Semver = filter.Semver
+
+		return nil
+	}
+
+	version := Version{}
+
+	for k, v := range data {
+		if s, ok := v.(string); ok {
+			version[k] = strings.TrimSpace(s)
+		}
+	}
+
+	c.Pinned = version
+
+	return nil
+}
+
+func isVersionFilter(data map[string]interface{}) bool {
+	for _, key := range []string{"match", "semver"} {
+		if _, isMap := data[key].(map[string]interface{}); isMap {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *VersionConfig) MarshalYAML() (interface{}, error) {
+	if c.HasFilter() {
+		return c.filterConfig(), nil
+	}
+
 	if c.Latest {
 		return VersionLatest, nil
 	}
@@ -193,6 +520,10 @@ func (c *VersionConfig) MarshalYAML() (interface{}, error) {
 }
 
 func (c *VersionConfig) MarshalJSON() ([]byte, error) {
+	if c.HasFilter() {
+		return json.Marshal(c.filterConfig())
+	}
+
 	if c.Latest {
 		return json.Marshal(VersionLatest)
 	}
@@ -208,6 +539,24 @@ func (c *VersionConfig) MarshalJSON() ([]byte, error) {
 	return json.Marshal("")
 }
 
+func (c *VersionConfig) filterConfig() versionFilterConfig {
+	return versionFilterConfig{
+		Every:  c.Every,
+		Match:  c.Match,
+		Semver: c.Semver,
+	}
+}
+
+// An AcrossVarConfig is a variable that a step is run across. Its values are
+// either listed in the config or loaded from a YAML or JSON file in an
+// artifact, and are referenced by the step as ((.:name)).
+type AcrossVarConfig struct {
+	Var         string        `yaml:"var" json:"var" mapstructure:"var"`
+	Values      []interface{} `yaml:"values,omitempty" json:"values,omitempty" mapstructure:"values"`
+	File        string        `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
+	MaxInFlight int           `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
+}
+
 // A InputsConfig represents the choice to include every artifact within the
 // job as an input to the put step or specific ones.
 type InputsConfig struct {
@@ -315,8 +664,12 @@ type PlanConfig struct {
 	Do *PlanSequence `yaml:"do,omitempty" json:"do,omitempty" mapstructure:"do"`
 
 	// corresponds to an Aggregate plan, keyed by the name of each sub-plan
+	// deprecated in favor of in_parallel, which it is run as
 	Aggregate *PlanSequence `yaml:"aggregate,omitempty" json:"aggregate,omitempty" mapstructure:"aggregate"`
 
+	// corresponds to an InParallel plan
+	InParallel *InParallelConfig `yaml:"in_parallel,omitempty" json:"in_parallel,omitempty" mapstructure:"in_parallel"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `yaml:"get,omitempty" json:"get,omitempty" mapstructure:"get"`
@@ -346,6 +699,21 @@ type PlanConfig struct {
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
 
+	// corresponds to a SetPipeline plan, configured by 'file' and 'vars'
+	// name of the pipeline to configure, e.g. child-pipeline
+	SetPipeline string `yaml:"set_pipeline,omitempty" json:"set_pipeline,omitempty" mapstructure:"set_pipeline"`
+	// files within artifacts providing variables for the pipeline config
+	VarFiles []string `yaml:"var_files,omitempty" json:"var_files,omitempty" mapstructure:"var_files"`
+	// vars identifying the instance of the pipeline to configure, which are
+	// also set as vars in the pipeline config
+	InstanceVars InstanceVars `yaml:"instance_vars,omitempty" json:"instance_vars,omitempty" mapstructure:"instance_vars"`
+
+	// corresponds to a LoadVar plan, configured by 'file' and 'format'
+	// name of the build-local var to set, e.g. version
+	LoadVar string `yaml:"load_var,omitempty" json:"load_var,omitempty" mapstructure:"load_var"`
+	// how to parse the file's contents: raw, trim, json or yaml
+	Format string `yaml:"format,omitempty" json:"format,omitempty" mapstructure:"format"`
+
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
 
@@ -362,6 +730,9 @@ type PlanConfig struct {
 	// used by any step to specify which workers are eligible to run the step
 	Tags Tags `yaml:"tags,omitempty" json:"tags,omitempty" mapstructure:"tags"`
 
+	// used by any step to select the eligible workers by their labels
+	WorkerSelector WorkerSelector `yaml:"worker_selector,omitempty" json:"worker_selector,omitempty" mapstructure:"worker_selector"`
+
 	// used by any step to run something when the build is aborted during execution of the step
 	Abort *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
 
@@ -384,7 +755,18 @@ type PlanConfig struct {
 	DependentGet string `yaml:"-" json:"-"`
 
 	// repeat the step up to N times, until it works
-	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
+	Attempts *AttemptsConfig `yaml:"attempts,omitempty" json:"attempts,omitempty" mapstructure:"attempts"`
+
+	// run the step once for every combination of the given variables' values
+	Across []AcrossVarConfig `yaml:"across,omitempty" json:"across,omitempty" mapstructure:"across"`
+
+	// used with across to stop running combinations as soon as one fails
+	FailFast bool `yaml:"fail_fast,omitempty" json:"fail_fast,omitempty" mapstructure:"fail_fast"`
+
+	// only run the step (along with its hooks) when the condition holds, e.g.
+	// $BUILD_PIPELINE_NAME == "main"; with across, it is evaluated for every
+	// combination
+	If string `yaml:"if,omitempty" json:"if,omitempty" mapstructure:"if"`
 
 	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 }
@@ -406,6 +788,14 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
+	if config.LoadVar != "" {
+		return config.LoadVar
+	}
+
 	return ""
 }
 
@@ -2,11 +2,13 @@ package atc_test
 
 import (
 	"encoding/json"
+	"time"
 
 	. "github.com/concourse/concourse/atc"
 	yaml "gopkg.in/yaml.v2"
 
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 )
 
@@ -45,5 +47,281 @@ var _ = Describe("Config", func() {
 				Expect(versionConfig).To(Equal(expected))
 			})
 		})
+
+		Context("when unmarshaling a filter from YAML", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`
+match: {tag: "^v1\\."}
+semver: {field: tag, constraint: ">=1.2 <2"}
+every: true
+`)
+				err := yaml.Unmarshal(bs, &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Every:  true,
+					Match:  map[string]string{"tag": `^v1\.`},
+					Semver: &VersionSemverConfig{Field: "tag", Constraint: ">=1.2 <2"},
+				}))
+			})
+		})
+
+		Context("when unmarshaling a filter from JSON", func() {
+			It("produces the correct version config without error", func() {
+				var versionConfig VersionConfig
+				bs := []byte(`{"semver": {"field": "tag", "constraint": ">=1.2 <2"}}`)
+				err := json.Unmarshal(bs, &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Latest: true,
+					Semver: &VersionSemverConfig{Field: "tag", Constraint: ">=1.2 <2"},
+				}))
+			})
+		})
+
+		Context("when unmarshaling a pinned version with a match field", func() {
+			It("produces a pinned version", func() {
+				var versionConfig VersionConfig
+				err := json.Unmarshal([]byte(`{"match": "some-value"}`), &versionConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(versionConfig).To(Equal(VersionConfig{
+					Pinned: Version{"match": "some-value"},
+				}))
+			})
+		})
+
+		Context("when marshaling a filter", func() {
+			It("uses the filter form", func() {
+				payload, err := json.Marshal(&VersionConfig{
+					Every: true,
+					Match: map[string]string{"tag": "^v1"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`{"every": true, "match": {"tag": "^v1"}}`))
+			})
+		})
+
+		Describe("Filter", func() {
+			It("passes versions matching every filter", func() {
+				filter, err := VersionConfig{
+					Match:  map[string]string{"tag": `^v`},
+					Semver: &VersionSemverConfig{Field: "tag", Constraint: ">=1.2 <2"},
+				}.Filter()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(filter(Version{"tag": "v1.4.0"})).To(BeTrue())
+				Expect(filter(Version{"tag": "1.4.0"})).To(BeFalse())
+				Expect(filter(Version{"tag": "v2.0.0"})).To(BeFalse())
+				Expect(filter(Version{"tag": "vnext"})).To(BeFalse())
+				Expect(filter(Version{"ref": "v1.4.0"})).To(BeFalse())
+			})
+
+			It("returns an error for an invalid regular expression", func() {
+				_, err := VersionConfig{Match: map[string]string{"tag": "("}}.Filter()
+				Expect(err).To(HaveOccurred())
+			})
+
+			DescribeTable("checking semver constraints",
+				func(constraint string, tag string, expected bool) {
+					filter, err := VersionConfig{
+						Semver: &VersionSemverConfig{Field: "tag", Constraint: constraint},
+					}.Filter()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(filter(Version{"tag": tag})).To(Equal(expected))
+				},
+				Entry("exact versions", "1.2.3", "1.2.3", true),
+				Entry("exact versions which differ", "=1.2.3", "1.2.4", false),
+				Entry("missing components as zeros", "=1.2", "v1.2.0", true),
+				Entry("inequality", "!=1.2", "1.3.0", true),
+				Entry("lower bounds", ">=1.2", "1.2.0", true),
+				Entry("exclusive lower bounds", ">1.2", "1.2.0", false),
+				Entry("upper bounds", "<2", "1.99.0", true),
+				Entry("inclusive upper bounds", "<=1.2", "1.2.0", true),
+				Entry("ranges", ">=1.2 <2", "1.4.0", true),
+				Entry("ranges with commas", ">=1.2, <2", "2.0.0", false),
+				Entry("a space after the operator", ">= 1.2", "1.2.0", true),
+				Entry("alternatives", "<1 || >=3", "3.1.0", true),
+				Entry("releases above their pre-releases", "<1.2.3", "1.2.3-rc.1", true),
+				Entry("versions which are not numbers", ">=1.2", "latest", false),
+			)
+
+			DescribeTable("returning an error for an invalid constraint",
+				func(constraint string) {
+					_, err := VersionConfig{Semver: &VersionSemverConfig{Field: "tag", Constraint: constraint}}.Filter()
+					Expect(err).To(HaveOccurred())
+				},
+				Entry("empty", ""),
+				Entry("empty alternatives", ">=1 ||"),
+				Entry("invalid versions", ">=latest"),
+				Entry("unknown operators", "=>1.2"),
+			)
+		})
+	})
+
+	Describe("InParallelConfig", func() {
+		Context("when unmarshaling a list of steps from YAML", func() {
+			It("produces an in_parallel config with just the steps", func() {
+				var inParallelConfig InParallelConfig
+				bs := []byte(`[{get: some-resource}, {task: some-task}]`)
+				err := yaml.Unmarshal(bs, &inParallelConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inParallelConfig).To(Equal(InParallelConfig{
+					Steps: PlanSequence{
+						{Get: "some-resource"},
+						{Task: "some-task"},
+					},
+				}))
+			})
+		})
+
+		Context("when unmarshaling the full form from YAML", func() {
+			It("produces the correct in_parallel config without error", func() {
+				var inParallelConfig InParallelConfig
+				bs := []byte(`{steps: [{get: some-resource}], limit: 3, fail_fast: true}`)
+				err := yaml.Unmarshal(bs, &inParallelConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inParallelConfig).To(Equal(InParallelConfig{
+					Steps:    PlanSequence{{Get: "some-resource"}},
+					Limit:    3,
+					FailFast: true,
+				}))
+			})
+		})
+
+		Context("when unmarshaling a list of steps from JSON", func() {
+			It("produces an in_parallel config with just the steps", func() {
+				var inParallelConfig InParallelConfig
+				bs := []byte(`[{"get": "some-resource"}]`)
+				err := json.Unmarshal(bs, &inParallelConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inParallelConfig).To(Equal(InParallelConfig{
+					Steps: PlanSequence{{Get: "some-resource"}},
+				}))
+			})
+		})
+
+		Context("when unmarshaling the full form from JSON", func() {
+			It("produces the correct in_parallel config without error", func() {
+				var inParallelConfig InParallelConfig
+				bs := []byte(`{"steps": [{"get": "some-resource"}], "limit": 3, "fail_fast": true}`)
+				err := json.Unmarshal(bs, &inParallelConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(inParallelConfig).To(Equal(InParallelConfig{
+					Steps:    PlanSequence{{Get: "some-resource"}},
+					Limit:    3,
+					FailFast: true,
+				}))
+			})
+		})
+	})
+
+	Describe("AttemptsConfig", func() {
+		Context("when unmarshaling a count from YAML", func() {
+			It("produces an attempts config with just the count", func() {
+				var attemptsConfig AttemptsConfig
+				err := yaml.Unmarshal([]byte(`3`), &attemptsConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attemptsConfig).To(Equal(AttemptsConfig{Count: 3}))
+			})
+		})
+
+		Context("when unmarshaling the full form from YAML", func() {
+			It("produces the correct attempts config without error", func() {
+				var attemptsConfig AttemptsConfig
+				bs := []byte(`{count: 3, backoff: {initial: 1s, max: 1m, multiplier: 3}, on: [failure]}`)
+				err := yaml.Unmarshal(bs, &attemptsConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attemptsConfig).To(Equal(AttemptsConfig{
+					Count: 3,
+					Backoff: &BackoffConfig{
+						Initial:    "1s",
+						Max:        "1m",
+						Multiplier: 3,
+					},
+					On: []string{AttemptOnFailure},
+				}))
+			})
+		})
+
+		Context("when unmarshaling a count from JSON", func() {
+			It("produces an attempts config with just the count", func() {
+				var attemptsConfig AttemptsConfig
+				err := json.Unmarshal([]byte(`3`), &attemptsConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attemptsConfig).To(Equal(AttemptsConfig{Count: 3}))
+			})
+		})
+
+		Context("when unmarshaling the full form from JSON", func() {
+			It("produces the correct attempts config without error", func() {
+				var attemptsConfig AttemptsConfig
+				bs := []byte(`{"count": 3, "backoff": {"initial": "1s"}, "on": ["error"]}`)
+				err := json.Unmarshal(bs, &attemptsConfig)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attemptsConfig).To(Equal(AttemptsConfig{
+					Count:   3,
+					Backoff: &BackoffConfig{Initial: "1s"},
+					On:      []string{AttemptOnError},
+				}))
+			})
+		})
+
+		Context("when marshaling", func() {
+			It("uses the count form when only a count is configured", func() {
+				payload, err := json.Marshal(AttemptsConfig{Count: 3})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`3`))
+
+				payload, err = yaml.Marshal(AttemptsConfig{Count: 3})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchYAML(`3`))
+			})
+
+			It("uses the full form otherwise", func() {
+				payload, err := json.Marshal(AttemptsConfig{
+					Count: 3,
+					On:    []string{AttemptOnError},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON(`{"count": 3, "on": ["error"]}`))
+			})
+		})
+	})
+
+	Describe("VersionHistoryConfig", func() {
+		It("parses keep_for as a number of days", func() {
+			keepFor, err := VersionHistoryConfig{KeepFor: "30d"}.KeepForDuration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keepFor).To(Equal(30 * 24 * time.Hour))
+		})
+
+		It("parses keep_for as a duration", func() {
+			keepFor, err := VersionHistoryConfig{KeepFor: "12h"}.KeepForDuration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keepFor).To(Equal(12 * time.Hour))
+		})
+
+		It("keeps versions forever when keep_for is unset", func() {
+			keepFor, err := VersionHistoryConfig{Keep: 10}.KeepForDuration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keepFor).To(BeZero())
+		})
+
+		It("errors when keep_for is invalid", func() {
+			_, err := VersionHistoryConfig{KeepFor: "eleventy d"}.KeepForDuration()
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
@@ -1,9 +1,11 @@
 package creds
 
 import (
+	"bytes"
 	"encoding/json"
 
 	"github.com/cloudfoundry/bosh-cli/director/template"
+	localtemplate "github.com/concourse/concourse/atc/template"
 	"gopkg.in/yaml.v2"
 )
 
@@ -13,6 +15,18 @@ func evaluate(variablesResolver Variables, in, out interface{}) error {
 		return err
 	}
 
+	if buildVars, ok := variablesResolver.(buildVariables); ok {
+		var localVars template.StaticVariables
+		byteParams, localVars, err = substituteLocalVars(byteParams, buildVars.local)
+		if err != nil {
+			return err
+		}
+
+		// the local vars are resolved in the same pass as the credential
+		// manager's, so that their values are never evaluated themselves
+		variablesResolver = template.NewMultiVars([]template.Variables{localVars, buildVars.Variables})
+	}
+
 	tpl := template.NewTemplate(byteParams)
 
 	bytes, err := tpl.Evaluate(variablesResolver, nil, template.EvaluateOpts{
@@ -24,3 +38,26 @@ func evaluate(variablesResolver Variables, in, out interface{}) error {
 
 	return yaml.Unmarshal(bytes, out)
 }
+
+func substituteLocalVars(byteParams []byte, local *LocalVariables) ([]byte, template.StaticVariables, error) {
+	decoder := json.NewDecoder(bytes.NewReader(byteParams))
+	decoder.UseNumber()
+
+	var untyped interface{}
+	err := decoder.Decode(&untyped)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	substituted, localVars, err := localtemplate.SubstituteLocalVars(untyped, local.Values())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byteParams, err = json.Marshal(substituted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return byteParams, localVars, nil
+}
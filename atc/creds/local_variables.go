@@ -0,0 +1,52 @@
+package creds
+
+import "sync"
+
+// LocalVariables holds the variables which are local to a single build, e.g.
+// the ones set by its load_var steps. They are referred to as ((.:name)).
+type LocalVariables struct {
+	lock sync.RWMutex
+	vars map[string]interface{}
+}
+
+func NewLocalVariables() *LocalVariables {
+	return &LocalVariables{
+		vars: map[string]interface{}{},
+	}
+}
+
+// Set sets the value of a local variable, replacing any previous value.
+func (local *LocalVariables) Set(name string, val interface{}) {
+	local.lock.Lock()
+	local.vars[name] = val
+	local.lock.Unlock()
+}
+
+// Values returns a copy of the local variables which are currently set.
+func (local *LocalVariables) Values() map[string]interface{} {
+	local.lock.RLock()
+	defer local.lock.RUnlock()
+
+	values := make(map[string]interface{}, len(local.vars))
+	for name, val := range local.vars {
+		values[name] = val
+	}
+
+	return values
+}
+
+type buildVariables struct {
+	Variables
+
+	local *LocalVariables
+}
+
+// NewBuildVariables returns Variables which resolve references to the
+// build's local variables before falling back on the given variables, which
+// are typically the ones from the credential manager.
+func NewBuildVariables(credVars Variables, local *LocalVariables) Variables {
+	return buildVariables{
+		Variables: credVars,
+		local:     local,
+	}
+}
@@ -0,0 +1,82 @@
+package creds_test
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildVariables", func() {
+	var (
+		localVars *creds.LocalVariables
+		variables creds.Variables
+	)
+
+	BeforeEach(func() {
+		localVars = creds.NewLocalVariables()
+
+		variables = creds.NewBuildVariables(template.StaticVariables{
+			"some-param": "lol",
+		}, localVars)
+	})
+
+	Context("when the local vars are set", func() {
+		BeforeEach(func() {
+			localVars.Set("version", "1.2.3")
+			localVars.Set("metadata", map[string]interface{}{"sha": "abcdef"})
+		})
+
+		It("resolves them along with the credential manager's vars", func() {
+			result, err := creds.NewSource(variables, atc.Source{
+				"version": "((.:version))",
+				"sha":     "((.:metadata.sha))",
+				"cred":    "((some-param))",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.Source{
+				"version": "1.2.3",
+				"sha":     "abcdef",
+				"cred":    "lol",
+			}))
+		})
+	})
+
+	Context("when a local var's value refers to a credential", func() {
+		BeforeEach(func() {
+			localVars.Set("loaded", "((some-param))")
+			localVars.Set("metadata", map[string]interface{}{"build": json.Number("42")})
+		})
+
+		It("does not resolve the credential within the value", func() {
+			result, err := creds.NewParams(variables, atc.Params{
+				"whole":    "((.:loaded))",
+				"embedded": "value: ((.:loaded))",
+				"build":    "((.:metadata.build))",
+				"cred":     "((some-param))",
+			}).Evaluate()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result).To(Equal(atc.Params{
+				"whole":    "((some-param))",
+				"embedded": "value: ((some-param))",
+				"build":    42,
+				"cred":     "lol",
+			}))
+		})
+	})
+
+	Context("when a local var is not set", func() {
+		It("errors", func() {
+			_, err := creds.NewParams(variables, atc.Params{
+				"version": "((.:version))",
+			}).Evaluate()
+			Expect(err).To(MatchError("undefined local vars: version"))
+		})
+	})
+})
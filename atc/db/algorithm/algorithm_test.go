@@ -819,4 +819,75 @@ var _ = DescribeTable("Input resolving",
 			},
 		},
 	}),
+
+	Entry("uses the latest version matching the version filter", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1.1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv1.2", CheckOrder: 2},
+				{Resource: "resource-x", Version: "rxv2.0", CheckOrder: 3},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Match: `^rxv1\.`},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1.2",
+			},
+		},
+	}),
+
+	Entry("fails when no version matches the version filter", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv2.0", CheckOrder: 1},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Version:  Version{Every: true, Match: `^rxv1\.`},
+			},
+		},
+
+		Result: Result{
+			OK:     false,
+			Values: map[string]string{},
+		},
+	}),
+
+	Entry("applies the version filter to versions which passed constraints", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "some-job", BuildID: 1, Resource: "resource-x", Version: "rxv1.1", CheckOrder: 1},
+				{Job: "some-job", BuildID: 2, Resource: "resource-x", Version: "rxv2.0", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"some-job"},
+				Version:  Version{Match: `^rxv1\.`},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1.1",
+			},
+		},
+	}),
 )
@@ -8,6 +8,10 @@ type VersionsDB struct {
 	ResourceIDs      map[string]int
 }
 
+// A VersionFilter reports whether the version with the given ID may be used
+// by an input. A nil filter allows every version.
+type VersionFilter func(versionID int) bool
+
 type ResourceVersion struct {
 	VersionID  int
 	ResourceID int
@@ -38,10 +42,14 @@ func (db VersionsDB) IsVersionFirstOccurrence(versionID int, jobID int, inputNam
 	return true
 }
 
-func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
+func (db VersionsDB) allows(filter VersionFilter, versionID int) bool {
+	return filter == nil || filter(versionID)
+}
+
+func (db VersionsDB) AllVersionsOfResource(resourceID int, filter VersionFilter) VersionCandidates {
 	candidates := VersionCandidates{}
 	for _, output := range db.ResourceVersions {
-		if output.ResourceID == resourceID {
+		if output.ResourceID == resourceID && db.allows(filter, output.VersionID) {
 			candidates.Add(VersionCandidate{
 				VersionID:  output.VersionID,
 				CheckOrder: output.CheckOrder,
@@ -52,12 +60,12 @@ func (db VersionsDB) AllVersionsOfResource(resourceID int) VersionCandidates {
 	return candidates
 }
 
-func (db VersionsDB) LatestVersionOfResource(resourceID int) (VersionCandidate, bool) {
+func (db VersionsDB) LatestVersionOfResource(resourceID int, filter VersionFilter) (VersionCandidate, bool) {
 	var candidate VersionCandidate
 	var found bool
 
 	for _, v := range db.ResourceVersions {
-		if v.ResourceID == resourceID && v.CheckOrder > candidate.CheckOrder {
+		if v.ResourceID == resourceID && v.CheckOrder > candidate.CheckOrder && db.allows(filter, v.VersionID) {
 			candidate = VersionCandidate{
 				VersionID:  v.VersionID,
 				CheckOrder: v.CheckOrder,
@@ -88,7 +96,7 @@ func (db VersionsDB) FindVersionOfResource(resourceID int, versionID int) (Versi
 	return candidate, found
 }
 
-func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet) VersionCandidates {
+func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet, filter VersionFilter) VersionCandidates {
 	candidates := VersionCandidates{}
 
 	firstTick := true
@@ -96,7 +104,7 @@ func (db VersionsDB) VersionsOfResourcePassedJobs(resourceID int, passed JobSet)
 		versions := VersionCandidates{}
 
 		for _, output := range db.BuildOutputs {
-			if output.ResourceID == resourceID && output.JobID == jobID {
+			if output.ResourceID == resourceID && output.JobID == jobID && db.allows(filter, output.VersionID) {
 				versions.Add(VersionCandidate{
 					VersionID:  output.VersionID,
 					CheckOrder: output.CheckOrder,
@@ -10,6 +10,9 @@ type InputConfig struct {
 	PinnedVersionID int
 	ResourceID      int
 	JobID           int
+
+	// narrows down the versions used when the input is not pinned
+	VersionFilter VersionFilter
 }
 
 func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
@@ -21,7 +24,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 
 		if len(inputConfig.Passed) == 0 {
 			if inputConfig.UseEveryVersion {
-				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID)
+				versionCandidates = db.AllVersionsOfResource(inputConfig.ResourceID, inputConfig.VersionFilter)
 			} else {
 				var versionCandidate VersionCandidate
 				var found bool
@@ -29,7 +32,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 				if inputConfig.PinnedVersionID != 0 {
 					versionCandidate, found = db.FindVersionOfResource(inputConfig.ResourceID, inputConfig.PinnedVersionID)
 				} else {
-					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID)
+					versionCandidate, found = db.LatestVersionOfResource(inputConfig.ResourceID, inputConfig.VersionFilter)
 				}
 
 				if found {
@@ -46,6 +49,7 @@ func (configs InputConfigs) Resolve(db *VersionsDB) (InputMapping, bool) {
 			versionCandidates = db.VersionsOfResourcePassedJobs(
 				inputConfig.ResourceID,
 				inputConfig.Passed,
+				inputConfig.VersionFilter,
 			)
 
 			if versionCandidates.IsEmpty() {
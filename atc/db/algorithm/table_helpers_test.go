@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 
 	"github.com/concourse/concourse/atc/db/algorithm"
 	. "github.com/onsi/gomega"
@@ -45,6 +46,9 @@ type Version struct {
 	Every  bool
 	Latest bool
 	Pinned string
+
+	// a regular expression the version names must match
+	Match string
 }
 
 type Result struct {
@@ -152,6 +156,14 @@ func (example Example) Run() {
 			versionID = versionIDs.ID(input.Version.Pinned)
 		}
 
+		var filter algorithm.VersionFilter
+		if input.Version.Match != "" {
+			re := regexp.MustCompile(input.Version.Match)
+			filter = func(versionID int) bool {
+				return re.MatchString(versionIDs.Name(versionID))
+			}
+		}
+
 		inputConfigs[i] = algorithm.InputConfig{
 			Name:            input.Name,
 			Passed:          passed,
@@ -159,6 +171,7 @@ func (example Example) Run() {
 			UseEveryVersion: input.Version.Every,
 			PinnedVersionID: versionID,
 			JobID:           jobIDs.ID(CurrentJobName),
+			VersionFilter:   filter,
 		}
 	}
 
@@ -86,6 +86,7 @@ type Build interface {
 	Start(string, string, atc.Plan) (bool, error)
 	FinishWithError(cause error) error
 	Finish(BuildStatus) error
+	FinishWithReason(BuildStatus, string) error
 
 	SetInterceptible(bool) error
 
@@ -316,6 +317,12 @@ func (b *build) FinishWithError(cause error) error {
 }
 
 func (b *build) Finish(status BuildStatus) error {
+	return b.FinishWithReason(status, "")
+}
+
+// FinishWithReason finishes the build like Finish, explaining the status with
+// the given reason in the final status event.
+func (b *build) FinishWithReason(status BuildStatus, reason string) error {
 	tx, err := b.conn.Begin()
 	if err != nil {
 		return err
@@ -343,6 +350,7 @@ func (b *build) Finish(status BuildStatus) error {
 	err = b.saveEvent(tx, event.Status{
 		Status: atc.BuildStatus(status),
 		Time:   endTime.Unix(),
+		Reason: reason,
 	})
 	if err != nil {
 		return err
@@ -621,17 +629,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	tf := NewTeamFactory(b.conn, b.lockFactory)
-	t, found, err := tf.FindTeam(b.teamName)
-	if err != nil {
-		return BuildPreparation{}, false, err
-	}
-
-	if !found {
-		return BuildPreparation{}, false, nil
-	}
-
-	pipeline, found, err := t.Pipeline(b.pipelineName)
+	pipeline, found, err := b.Pipeline()
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
@@ -709,6 +707,13 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 						} else {
 							missingInputReasons.RegisterPinnedVersionUnavailable(configInput.Name, string(versionJSON))
 						}
+					} else if configInput.Version != nil && configInput.Version.HasFilter() {
+						filterJSON, err := json.Marshal(configInput.Version)
+						if err != nil {
+							return BuildPreparation{}, false, err
+						}
+
+						missingInputReasons.RegisterPassedVersionFilter(configInput.Name, string(filterJSON))
 					} else {
 						missingInputReasons.RegisterPassedConstraint(configInput.Name)
 					}
@@ -720,6 +725,13 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 						}
 
 						missingInputReasons.RegisterPinnedVersionUnavailable(configInput.Name, string(versionJSON))
+					} else if configInput.Version != nil && configInput.Version.HasFilter() {
+						filterJSON, err := json.Marshal(configInput.Version)
+						if err != nil {
+							return BuildPreparation{}, false, err
+						}
+
+						missingInputReasons.RegisterVersionFilter(configInput.Name, string(filterJSON))
 					} else {
 						missingInputReasons.RegisterNoVersions(configInput.Name)
 					}
@@ -25,12 +25,37 @@ func newBuildEventSource(
 	conn Conn,
 	notifier Notifier,
 	from uint,
-) *buildEventSource {
+) *eventSource {
+	return newEventSource(
+		buildID,
+		table,
+		"build_id",
+		`SELECT builds.completed FROM builds WHERE builds.id = $1`,
+		conn,
+		notifier,
+		from,
+	)
+}
+
+// newEventSource streams the events in table belonging to the given id, as
+// identified by idColumn, until completedQuery reports that no more events
+// will be saved.
+func newEventSource(
+	id int,
+	table string,
+	idColumn string,
+	completedQuery string,
+	conn Conn,
+	notifier Notifier,
+	from uint,
+) *eventSource {
 	wg := new(sync.WaitGroup)
 
-	source := &buildEventSource{
-		buildID: buildID,
-		table:   table,
+	source := &eventSource{
+		id:             id,
+		table:          table,
+		idColumn:       idColumn,
+		completedQuery: completedQuery,
 
 		conn: conn,
 
@@ -47,9 +72,11 @@ func newBuildEventSource(
 	return source
 }
 
-type buildEventSource struct {
-	buildID int
-	table   string
+type eventSource struct {
+	id             int
+	table          string
+	idColumn       string
+	completedQuery string
 
 	conn     Conn
 	notifier Notifier
@@ -60,7 +87,7 @@ type buildEventSource struct {
 	wg     *sync.WaitGroup
 }
 
-func (source *buildEventSource) Next() (event.Envelope, error) {
+func (source *eventSource) Next() (event.Envelope, error) {
 	e, ok := <-source.events
 	if !ok {
 		return event.Envelope{}, source.err
@@ -69,7 +96,7 @@ func (source *buildEventSource) Next() (event.Envelope, error) {
 	return e, nil
 }
 
-func (source *buildEventSource) Close() error {
+func (source *eventSource) Close() error {
 	select {
 	case <-source.stop:
 		return nil
@@ -82,7 +109,7 @@ func (source *buildEventSource) Close() error {
 	return source.notifier.Close()
 }
 
-func (source *buildEventSource) collectEvents(cursor uint) {
+func (source *eventSource) collectEvents(cursor uint) {
 	defer source.wg.Done()
 
 	var batchSize = cap(source.events)
@@ -98,11 +125,7 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 
 		completed := false
 
-		err := source.conn.QueryRow(`
-			SELECT builds.completed
-			FROM builds
-			WHERE builds.id = $1
-		`, source.buildID).Scan(&completed)
+		err := source.conn.QueryRow(source.completedQuery, source.id).Scan(&completed)
 		if err != nil {
 			source.err = err
 			close(source.events)
@@ -112,11 +135,11 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		rows, err := source.conn.Query(`
 			SELECT type, version, payload
 			FROM `+source.table+`
-			WHERE build_id = $1
+			WHERE `+source.idColumn+` = $1
 			ORDER BY event_id ASC
 			OFFSET $2
 			LIMIT $3
-		`, source.buildID, cursor, batchSize)
+		`, source.id, cursor, batchSize)
 		if err != nil {
 			source.err = err
 			close(source.events)
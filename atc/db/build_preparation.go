@@ -16,6 +16,8 @@ const (
 	NoVersionsSatisfiedPassedConstraints string = "no versions satisfy passed constraints"
 	NoVersionsAvailable                  string = "no versions available"
 	PinnedVersionUnavailable             string = "pinned version %s is not available"
+	NoVersionsMatchFilter                string = "no versions match version filter %s"
+	NoPassedVersionsMatchFilter          string = "no versions satisfying passed constraints match version filter %s"
 )
 
 func (mir MissingInputReasons) RegisterPassedConstraint(inputName string) {
@@ -30,6 +32,14 @@ func (mir MissingInputReasons) RegisterPinnedVersionUnavailable(inputName string
 	mir[inputName] = fmt.Sprintf(PinnedVersionUnavailable, version)
 }
 
+func (mir MissingInputReasons) RegisterVersionFilter(inputName string, filter string) {
+	mir[inputName] = fmt.Sprintf(NoVersionsMatchFilter, filter)
+}
+
+func (mir MissingInputReasons) RegisterPassedVersionFilter(inputName string, filter string) {
+	mir[inputName] = fmt.Sprintf(NoPassedVersionsMatchFilter, filter)
+}
+
 type BuildPreparation struct {
 	BuildID             int
 	PausedPipeline      BuildPreparationStatus
@@ -368,7 +368,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -507,7 +507,7 @@ var _ = Describe("Build", func() {
 				},
 			}
 
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -603,7 +603,7 @@ var _ = Describe("Build", func() {
 		Context("when a job build", func() {
 			BeforeEach(func() {
 				var err error
-				createdPipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+				createdPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -701,7 +701,7 @@ var _ = Describe("Build", func() {
 
 			BeforeEach(func() {
 				var err error
-				pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Resources: atc.ResourceConfigs{
 						{
 							Name: "some-resource",
@@ -898,6 +898,15 @@ var _ = Describe("Build", func() {
 										Passed:  []string{"some-upstream-job"},
 										Version: &atc.VersionConfig{Pinned: atc.Version{"version": "v6"}},
 									},
+									{
+										Get:     "input7",
+										Version: &atc.VersionConfig{Match: map[string]string{"version": "^v1"}},
+									},
+									{
+										Get:     "input8",
+										Passed:  []string{"some-upstream-job"},
+										Version: &atc.VersionConfig{Match: map[string]string{"version": "^v1"}},
+									},
 								},
 							},
 						},
@@ -908,10 +917,12 @@ var _ = Describe("Build", func() {
 							{Name: "input4", Type: "some-type", Source: atc.Source{"some": "source-4"}},
 							{Name: "input5", Type: "some-type", Source: atc.Source{"some": "source-5"}},
 							{Name: "input6", Type: "some-type", Source: atc.Source{"some": "source-6"}},
+							{Name: "input7", Type: "some-type", Source: atc.Source{"some": "source-7"}},
+							{Name: "input8", Type: "some-type", Source: atc.Source{"some": "source-8"}},
 						},
 					}
 
-					pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(2), db.PipelineUnpaused)
+					pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(2), db.PipelineUnpaused)
 					Expect(err).ToNot(HaveOccurred())
 
 					setupTx, err := dbConn.Begin()
@@ -966,6 +977,8 @@ var _ = Describe("Build", func() {
 						"input4": db.BuildPreparationStatusBlocking,
 						"input5": db.BuildPreparationStatusBlocking,
 						"input6": db.BuildPreparationStatusBlocking,
+						"input7": db.BuildPreparationStatusBlocking,
+						"input8": db.BuildPreparationStatusBlocking,
 					}
 					expectedBuildPrep.InputsSatisfied = db.BuildPreparationStatusBlocking
 					expectedBuildPrep.MissingInputReasons = db.MissingInputReasons{
@@ -974,6 +987,8 @@ var _ = Describe("Build", func() {
 						"input4": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v4"}`),
 						"input5": fmt.Sprintf(db.PinnedVersionUnavailable, `{"version":"v5"}`),
 						"input6": db.NoVersionsSatisfiedPassedConstraints,
+						"input7": fmt.Sprintf(db.NoVersionsMatchFilter, `{"match":{"version":"^v1"}}`),
+						"input8": fmt.Sprintf(db.NoPassedVersionsMatchFilter, `{"match":{"version":"^v1"}}`),
 					}
 				})
 
@@ -994,7 +1009,7 @@ var _ = Describe("Build", func() {
 			)
 
 			BeforeEach(func() {
-				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -1076,7 +1091,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1184,6 +1199,36 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("FinishWithReason", func() {
+		var build db.Build
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.FinishWithReason(db.BuildStatusAborted, "timed out")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("includes the reason in the Finish event", func() {
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Status()).To(Equal(db.BuildStatusAborted))
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusAborted,
+				Time:   build.EndTime().Unix(),
+				Reason: "timed out",
+			})))
+		})
+	})
+
 	Describe("FinishWithError", func() {
 		var cause error
 		var build db.Build
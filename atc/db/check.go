@@ -0,0 +1,229 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/lib/pq"
+)
+
+type CheckStatus string
+
+const (
+	CheckStatusStarted   CheckStatus = "started"
+	CheckStatusSucceeded CheckStatus = "succeeded"
+	CheckStatusErrored   CheckStatus = "errored"
+
+	// the check was deferred by the rate limit of the resource's type
+	CheckStatusRateLimited CheckStatus = "rate_limited"
+)
+
+//go:generate counterfeiter . Check
+
+// A Check records a single run of a resource's check: where it ran, how it
+// went and, through its events, what it logged.
+type Check interface {
+	ID() int
+	ResourceID() int
+	Status() CheckStatus
+	ATCURL() string
+	WorkerName() string
+	CheckError() error
+	CreateTime() time.Time
+	EndTime() time.Time
+
+	SetWorkerName(string) error
+	SaveEvent(atc.Event) error
+	Finish(newVersions int, checkErr error) error
+
+	Events(from uint) (EventSource, error)
+}
+
+var checksQuery = psql.Select("c.id, c.resource_id, c.status, c.atc_url, c.worker_name, c.check_error, c.create_time, c.end_time").
+	From("checks c")
+
+type check struct {
+	id         int
+	resourceID int
+	status     CheckStatus
+	atcURL     string
+	workerName string
+	checkError error
+	createTime time.Time
+	endTime    time.Time
+
+	conn Conn
+}
+
+func (c *check) ID() int               { return c.id }
+func (c *check) ResourceID() int       { return c.resourceID }
+func (c *check) Status() CheckStatus   { return c.status }
+func (c *check) ATCURL() string        { return c.atcURL }
+func (c *check) WorkerName() string    { return c.workerName }
+func (c *check) CheckError() error     { return c.checkError }
+func (c *check) CreateTime() time.Time { return c.createTime }
+func (c *check) EndTime() time.Time    { return c.endTime }
+
+func (c *check) SetWorkerName(workerName string) error {
+	_, err := psql.Update("checks").
+		Set("worker_name", workerName).
+		Where(sq.Eq{"id": c.id}).
+		RunWith(c.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	c.workerName = workerName
+
+	return nil
+}
+
+func (c *check) SaveEvent(ev atc.Event) error {
+	tx, err := c.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	err = c.saveEvent(tx, ev)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return c.conn.Bus().Notify(checkEventsChannel(c.id))
+}
+
+// Finish marks the check as succeeded, or errored if checkErr is given, and
+// saves the events which end its event stream.
+func (c *check) Finish(newVersions int, checkErr error) error {
+	tx, err := c.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	status := CheckStatusSucceeded
+	var checkErrMessage sql.NullString
+	if checkErr != nil {
+		status = CheckStatusErrored
+		checkErrMessage = sql.NullString{String: checkErr.Error(), Valid: true}
+	}
+
+	var endTime time.Time
+	err = psql.Update("checks").
+		Set("status", status).
+		Set("check_error", checkErrMessage).
+		Set("end_time", sq.Expr("now()")).
+		Where(sq.Eq{"id": c.id}).
+		Suffix("RETURNING end_time").
+		RunWith(tx).
+		QueryRow().
+		Scan(&endTime)
+	if err != nil {
+		return err
+	}
+
+	if checkErr != nil {
+		err = c.saveEvent(tx, event.Error{
+			Message: checkErr.Error(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.saveEvent(tx, event.FinishCheck{
+		Time:        endTime.Unix(),
+		Succeeded:   checkErr == nil,
+		NewVersions: newVersions,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	c.status = status
+	c.checkError = checkErr
+	c.endTime = endTime
+
+	return c.conn.Bus().Notify(checkEventsChannel(c.id))
+}
+
+func (c *check) Events(from uint) (EventSource, error) {
+	notifier, err := newConditionNotifier(c.conn.Bus(), checkEventsChannel(c.id), func() (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newEventSource(
+		c.id,
+		"check_events",
+		"check_id",
+		`SELECT end_time IS NOT NULL FROM checks WHERE id = $1`,
+		c.conn,
+		notifier,
+		from,
+	), nil
+}
+
+func (c *check) saveEvent(tx Tx, ev atc.Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("check_events").
+		Columns("check_id", "type", "version", "payload").
+		Values(c.id, string(ev.EventType()), string(ev.Version()), payload).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func checkEventsChannel(checkID int) string {
+	return fmt.Sprintf("check_events_%d", checkID)
+}
+
+func scanCheck(c *check, row scannable) error {
+	var (
+		atcURL, workerName, checkError sql.NullString
+		endTime                        pq.NullTime
+		status                         string
+	)
+
+	err := row.Scan(&c.id, &c.resourceID, &status, &atcURL, &workerName, &checkError, &c.createTime, &endTime)
+	if err != nil {
+		return err
+	}
+
+	c.status = CheckStatus(status)
+	c.atcURL = atcURL.String
+	c.workerName = workerName.String
+	c.endTime = endTime.Time
+
+	if checkError.Valid {
+		c.checkError = errors.New(checkError.String)
+	}
+
+	return nil
+}
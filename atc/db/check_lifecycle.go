@@ -0,0 +1,36 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . CheckLifecycle
+
+type CheckLifecycle interface {
+	RemoveExpiredChecks(retention time.Duration) error
+}
+
+type checkLifecycle struct {
+	conn Conn
+}
+
+func NewCheckLifecycle(conn Conn) CheckLifecycle {
+	return checkLifecycle{
+		conn: conn,
+	}
+}
+
+// RemoveExpiredChecks removes the checks which finished longer ago than the
+// retention, along with their events. The most recent check of each resource
+// is always kept so that its last outcome can still be inspected.
+func (lifecycle checkLifecycle) RemoveExpiredChecks(retention time.Duration) error {
+	_, err := psql.Delete("checks").
+		Where(sq.Expr("end_time < NOW() - ? * INTERVAL '1 second'", int(retention.Seconds()))).
+		Where(sq.Expr("id NOT IN (SELECT MAX(id) FROM checks GROUP BY resource_id)")).
+		RunWith(lifecycle.conn).
+		Exec()
+
+	return err
+}
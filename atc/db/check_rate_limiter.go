@@ -0,0 +1,63 @@
+package db
+
+import (
+	"database/sql"
+)
+
+// CheckRateLimit is how many checks of a resource type may run per minute,
+// and how many may run at once after a quiet period.
+type CheckRateLimit struct {
+	PerMinute float64
+	Burst     int
+}
+
+//go:generate counterfeiter . CheckRateLimiter
+
+// A CheckRateLimiter limits how often the resources of each type are checked.
+// Each resource type has a bucket of tokens in the database, so the limit is
+// shared between every ATC.
+type CheckRateLimiter interface {
+	Allow(resourceType string) (bool, error)
+}
+
+type checkRateLimiter struct {
+	conn   Conn
+	limits map[string]CheckRateLimit
+}
+
+func NewCheckRateLimiter(conn Conn, limits map[string]CheckRateLimit) CheckRateLimiter {
+	return &checkRateLimiter{
+		conn:   conn,
+		limits: limits,
+	}
+}
+
+// Allow takes a token from the resource type's bucket, returning false if
+// there is none left. Buckets refill at the rate limit up to the burst.
+// Resource types without a limit are always allowed.
+func (limiter *checkRateLimiter) Allow(resourceType string) (bool, error) {
+	limit, found := limiter.limits[resourceType]
+	if !found {
+		return true, nil
+	}
+
+	var tokens float64
+	err := limiter.conn.QueryRow(`
+		INSERT INTO check_rate_limits AS l (resource_type, tokens, updated_at)
+		VALUES ($1, $3::float8 - 1, now())
+		ON CONFLICT (resource_type) DO UPDATE SET
+			tokens = LEAST($3::float8, l.tokens + EXTRACT(EPOCH FROM now() - l.updated_at) * $2::float8 / 60) - 1,
+			updated_at = now()
+		WHERE LEAST($3::float8, l.tokens + EXTRACT(EPOCH FROM now() - l.updated_at) * $2::float8 / 60) >= 1
+		RETURNING tokens
+	`, resourceType, limit.PerMinute, limit.Burst).Scan(&tokens)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
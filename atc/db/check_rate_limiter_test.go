@@ -0,0 +1,41 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRateLimiter", func() {
+	var limiter db.CheckRateLimiter
+
+	BeforeEach(func() {
+		limiter = db.NewCheckRateLimiter(dbConn, map[string]db.CheckRateLimit{
+			"some-type": {PerMinute: 1, Burst: 2},
+		})
+	})
+
+	It("allows checks of types without a limit", func() {
+		for i := 0; i < 5; i++ {
+			Expect(limiter.Allow("some-other-type")).To(BeTrue())
+		}
+	})
+
+	It("allows a burst of checks before limiting them", func() {
+		Expect(limiter.Allow("some-type")).To(BeTrue())
+		Expect(limiter.Allow("some-type")).To(BeTrue())
+		Expect(limiter.Allow("some-type")).To(BeFalse())
+	})
+
+	It("shares the limit with other limiters", func() {
+		otherLimiter := db.NewCheckRateLimiter(dbConn, map[string]db.CheckRateLimit{
+			"some-type": {PerMinute: 1, Burst: 2},
+		})
+
+		Expect(limiter.Allow("some-type")).To(BeTrue())
+		Expect(otherLimiter.Allow("some-type")).To(BeTrue())
+		Expect(limiter.Allow("some-type")).To(BeFalse())
+		Expect(otherLimiter.Allow("some-type")).To(BeFalse())
+	})
+})
@@ -0,0 +1,169 @@
+package db_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Check", func() {
+	var check db.Check
+
+	BeforeEach(func() {
+		var err error
+		check, err = defaultResource.CreateCheck("http://some-atc")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("is started", func() {
+		Expect(check.ResourceID()).To(Equal(defaultResource.ID()))
+		Expect(check.Status()).To(Equal(db.CheckStatusStarted))
+		Expect(check.ATCURL()).To(Equal("http://some-atc"))
+		Expect(check.CreateTime()).ToNot(BeZero())
+		Expect(check.EndTime()).To(BeZero())
+	})
+
+	Describe("SetWorkerName", func() {
+		It("records the worker", func() {
+			err := check.SetWorkerName("some-worker")
+			Expect(err).ToNot(HaveOccurred())
+
+			reloaded, found, err := defaultResource.Check(check.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.WorkerName()).To(Equal("some-worker"))
+		})
+	})
+
+	Describe("Events", func() {
+		It("streams the saved events until the check finishes", func() {
+			events, err := check.Events(0)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer db.Close(events)
+
+			err = check.SaveEvent(event.Log{Payload: "some output"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Log{Payload: "some output"})))
+
+			err = check.Finish(0, errors.New("some-error"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(events.Next()).To(Equal(envelope(event.Error{Message: "some-error"})))
+			Expect(events.Next()).To(Equal(envelope(event.FinishCheck{
+				Time:      check.EndTime().Unix(),
+				Succeeded: false,
+			})))
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
+	Describe("Finish", func() {
+		It("records the outcome", func() {
+			err := check.Finish(2, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			reloaded, found, err := defaultResource.Check(check.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.Status()).To(Equal(db.CheckStatusSucceeded))
+			Expect(reloaded.CheckError()).To(BeNil())
+			Expect(reloaded.EndTime()).ToNot(BeZero())
+		})
+
+		It("records the error", func() {
+			err := check.Finish(0, errors.New("some-error"))
+			Expect(err).ToNot(HaveOccurred())
+
+			reloaded, found, err := defaultResource.Check(check.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(reloaded.Status()).To(Equal(db.CheckStatusErrored))
+			Expect(reloaded.CheckError()).To(Equal(errors.New("some-error")))
+		})
+	})
+
+	Describe("(Resource).Checks", func() {
+		It("returns the checks, most recent first", func() {
+			otherCheck, err := defaultResource.CreateCheck("http://some-other-atc")
+			Expect(err).ToNot(HaveOccurred())
+
+			checks, err := defaultResource.Checks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checks).To(HaveLen(2))
+			Expect(checks[0].ID()).To(Equal(otherCheck.ID()))
+			Expect(checks[1].ID()).To(Equal(check.ID()))
+		})
+	})
+
+	Describe("(Resource).CreateRateLimitedCheck", func() {
+		It("records a finished rate limited check once until the resource is checked again", func() {
+			resourceConfigScope, err := defaultResource.SetResourceConfig(logger, defaultResource.Source(), creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = defaultResource.CreateRateLimitedCheck("http://some-atc")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = defaultResource.CreateRateLimitedCheck("http://some-atc")
+			Expect(err).ToNot(HaveOccurred())
+
+			checks, err := defaultResource.Checks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(checks).To(HaveLen(2))
+			Expect(checks[0].Status()).To(Equal(db.CheckStatusRateLimited))
+			Expect(checks[0].EndTime()).ToNot(BeZero())
+
+			found, err := defaultResource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(defaultResource.RateLimited()).To(BeTrue())
+
+			updated, err := resourceConfigScope.UpdateLastChecked(time.Minute, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(updated).To(BeTrue())
+
+			_, err = defaultResource.CreateCheck("http://some-atc")
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err = defaultResource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(defaultResource.RateLimited()).To(BeFalse())
+		})
+	})
+
+	Describe("(CheckLifecycle).RemoveExpiredChecks", func() {
+		It("removes finished checks older than the retention, keeping the latest", func() {
+			err := check.Finish(0, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			latestCheck, err := defaultResource.CreateCheck("http://some-atc")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = latestCheck.Finish(0, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			time.Sleep(time.Second)
+
+			err = db.NewCheckLifecycle(dbConn).RemoveExpiredChecks(time.Second)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, found, err := defaultResource.Check(check.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = defaultResource.Check(latestCheck.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+//go:generate counterfeiter . CheckableFactory
+
+// A CheckableFactory finds the resources and resource types which are due to
+// be checked, i.e. those which are active in pipelines which are neither
+// paused nor archived and whose check interval has elapsed since they were
+// last checked or last failed to set up their check. The default interval
+// applies to those without a valid check_every.
+//
+// Resources sharing a resource config scope are checked together, so only one
+// resource is returned per scope; likewise for resource types.
+type CheckableFactory interface {
+	DueResources(defaultInterval time.Duration) ([]Resource, error)
+	DueResourceTypes(defaultInterval time.Duration) ([]ResourceType, error)
+}
+
+type checkableFactory struct {
+	conn        Conn
+	lockFactory lock.LockFactory
+}
+
+func NewCheckableFactory(conn Conn, lockFactory lock.LockFactory) CheckableFactory {
+	return &checkableFactory{
+		conn:        conn,
+		lockFactory: lockFactory,
+	}
+}
+
+func (f *checkableFactory) DueResources(defaultInterval time.Duration) ([]Resource, error) {
+	rows, err := resourcesQuery.
+		Options("DISTINCT ON (COALESCE(r.resource_config_scope_id, -r.id))").
+		Where(sq.Eq{
+			"p.paused":   false,
+			"p.archived": false,
+		}).
+		Where(`COALESCE(GREATEST(rs.last_checked, r.last_check_setup_failed), 'epoch') + COALESCE(r.check_interval, (? || ' SECONDS')::INTERVAL) <= now()`, defaultInterval.Seconds()).
+		OrderBy("COALESCE(r.resource_config_scope_id, -r.id)", "r.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var resources []Resource
+
+	for rows.Next() {
+		resource := &resource{conn: f.conn, lockFactory: f.lockFactory}
+
+		err := scanResource(resource, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, nil
+}
+
+func (f *checkableFactory) DueResourceTypes(defaultInterval time.Duration) ([]ResourceType, error) {
+	rows, err := resourceTypesQuery.
+		Options("DISTINCT ON (COALESCE(ro.id, -r.id))").
+		Where(sq.Eq{
+			"p.paused":   false,
+			"p.archived": false,
+		}).
+		Where(`COALESCE(GREATEST(ro.last_checked, r.last_check_setup_failed), 'epoch') + COALESCE(r.check_interval, (? || ' SECONDS')::INTERVAL) <= now()`, defaultInterval.Seconds()).
+		OrderBy("COALESCE(ro.id, -r.id)", "r.id ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var resourceTypes []ResourceType
+
+	for rows.Next() {
+		resourceType := &resourceType{conn: f.conn, lockFactory: f.lockFactory}
+
+		err := scanResourceType(resourceType, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+
+	return resourceTypes, nil
+}
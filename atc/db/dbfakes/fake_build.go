@@ -119,6 +119,18 @@ type FakeBuild struct {
 	finishWithErrorReturnsOnCall map[int]struct {
 		result1 error
 	}
+	FinishWithReasonStub        func(db.BuildStatus, string) error
+	finishWithReasonMutex       sync.RWMutex
+	finishWithReasonArgsForCall []struct {
+		arg1 db.BuildStatus
+		arg2 string
+	}
+	finishWithReasonReturns struct {
+		result1 error
+	}
+	finishWithReasonReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -996,6 +1008,67 @@ func (fake *FakeBuild) FinishWithErrorReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) FinishWithReason(arg1 db.BuildStatus, arg2 string) error {
+	fake.finishWithReasonMutex.Lock()
+	ret, specificReturn := fake.finishWithReasonReturnsOnCall[len(fake.finishWithReasonArgsForCall)]
+	fake.finishWithReasonArgsForCall = append(fake.finishWithReasonArgsForCall, struct {
+		arg1 db.BuildStatus
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("FinishWithReason", []interface{}{arg1, arg2})
+	fake.finishWithReasonMutex.Unlock()
+	if fake.FinishWithReasonStub != nil {
+		return fake.FinishWithReasonStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.finishWithReasonReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) FinishWithReasonCallCount() int {
+	fake.finishWithReasonMutex.RLock()
+	defer fake.finishWithReasonMutex.RUnlock()
+	return len(fake.finishWithReasonArgsForCall)
+}
+
+func (fake *FakeBuild) FinishWithReasonCalls(stub func(db.BuildStatus, string) error) {
+	fake.finishWithReasonMutex.Lock()
+	defer fake.finishWithReasonMutex.Unlock()
+	fake.FinishWithReasonStub = stub
+}
+
+func (fake *FakeBuild) FinishWithReasonArgsForCall(i int) (db.BuildStatus, string) {
+	fake.finishWithReasonMutex.RLock()
+	defer fake.finishWithReasonMutex.RUnlock()
+	argsForCall := fake.finishWithReasonArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) FinishWithReasonReturns(result1 error) {
+	fake.finishWithReasonMutex.Lock()
+	defer fake.finishWithReasonMutex.Unlock()
+	fake.FinishWithReasonStub = nil
+	fake.finishWithReasonReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) FinishWithReasonReturnsOnCall(i int, result1 error) {
+	fake.finishWithReasonMutex.Lock()
+	defer fake.finishWithReasonMutex.Unlock()
+	fake.FinishWithReasonStub = nil
+	if fake.finishWithReasonReturnsOnCall == nil {
+		fake.finishWithReasonReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishWithReasonReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -2789,6 +2862,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.finishMutex.RUnlock()
 	fake.finishWithErrorMutex.RLock()
 	defer fake.finishWithErrorMutex.RUnlock()
+	fake.finishWithReasonMutex.RLock()
+	defer fake.finishWithReasonMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
 	fake.interceptibleMutex.RLock()
@@ -0,0 +1,850 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	sync "sync"
+	time "time"
+
+	atc "github.com/concourse/concourse/atc"
+	db "github.com/concourse/concourse/atc/db"
+)
+
+type FakeCheck struct {
+	ATCURLStub        func() string
+	aTCURLMutex       sync.RWMutex
+	aTCURLArgsForCall []struct {
+	}
+	aTCURLReturns struct {
+		result1 string
+	}
+	aTCURLReturnsOnCall map[int]struct {
+		result1 string
+	}
+	CheckErrorStub        func() error
+	checkErrorMutex       sync.RWMutex
+	checkErrorArgsForCall []struct {
+	}
+	checkErrorReturns struct {
+		result1 error
+	}
+	checkErrorReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateTimeStub        func() time.Time
+	createTimeMutex       sync.RWMutex
+	createTimeArgsForCall []struct {
+	}
+	createTimeReturns struct {
+		result1 time.Time
+	}
+	createTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	EndTimeStub        func() time.Time
+	endTimeMutex       sync.RWMutex
+	endTimeArgsForCall []struct {
+	}
+	endTimeReturns struct {
+		result1 time.Time
+	}
+	endTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
+	EventsStub        func(uint) (db.EventSource, error)
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		arg1 uint
+	}
+	eventsReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	eventsReturnsOnCall map[int]struct {
+		result1 db.EventSource
+		result2 error
+	}
+	FinishStub        func(int, error) error
+	finishMutex       sync.RWMutex
+	finishArgsForCall []struct {
+		arg1 int
+		arg2 error
+	}
+	finishReturns struct {
+		result1 error
+	}
+	finishReturnsOnCall map[int]struct {
+		result1 error
+	}
+	IDStub        func() int
+	iDMutex       sync.RWMutex
+	iDArgsForCall []struct {
+	}
+	iDReturns struct {
+		result1 int
+	}
+	iDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	ResourceIDStub        func() int
+	resourceIDMutex       sync.RWMutex
+	resourceIDArgsForCall []struct {
+	}
+	resourceIDReturns struct {
+		result1 int
+	}
+	resourceIDReturnsOnCall map[int]struct {
+		result1 int
+	}
+	SaveEventStub        func(atc.Event) error
+	saveEventMutex       sync.RWMutex
+	saveEventArgsForCall []struct {
+		arg1 atc.Event
+	}
+	saveEventReturns struct {
+		result1 error
+	}
+	saveEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetWorkerNameStub        func(string) error
+	setWorkerNameMutex       sync.RWMutex
+	setWorkerNameArgsForCall []struct {
+		arg1 string
+	}
+	setWorkerNameReturns struct {
+		result1 error
+	}
+	setWorkerNameReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StatusStub        func() db.CheckStatus
+	statusMutex       sync.RWMutex
+	statusArgsForCall []struct {
+	}
+	statusReturns struct {
+		result1 db.CheckStatus
+	}
+	statusReturnsOnCall map[int]struct {
+		result1 db.CheckStatus
+	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct {
+	}
+	workerNameReturns struct {
+		result1 string
+	}
+	workerNameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheck) ATCURL() string {
+	fake.aTCURLMutex.Lock()
+	ret, specificReturn := fake.aTCURLReturnsOnCall[len(fake.aTCURLArgsForCall)]
+	fake.aTCURLArgsForCall = append(fake.aTCURLArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ATCURL", []interface{}{})
+	fake.aTCURLMutex.Unlock()
+	if fake.ATCURLStub != nil {
+		return fake.ATCURLStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.aTCURLReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) ATCURLCallCount() int {
+	fake.aTCURLMutex.RLock()
+	defer fake.aTCURLMutex.RUnlock()
+	return len(fake.aTCURLArgsForCall)
+}
+
+func (fake *FakeCheck) ATCURLCalls(stub func() string) {
+	fake.aTCURLMutex.Lock()
+	defer fake.aTCURLMutex.Unlock()
+	fake.ATCURLStub = stub
+}
+
+func (fake *FakeCheck) ATCURLReturns(result1 string) {
+	fake.aTCURLMutex.Lock()
+	defer fake.aTCURLMutex.Unlock()
+	fake.ATCURLStub = nil
+	fake.aTCURLReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCheck) ATCURLReturnsOnCall(i int, result1 string) {
+	fake.aTCURLMutex.Lock()
+	defer fake.aTCURLMutex.Unlock()
+	fake.ATCURLStub = nil
+	if fake.aTCURLReturnsOnCall == nil {
+		fake.aTCURLReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.aTCURLReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCheck) CheckError() error {
+	fake.checkErrorMutex.Lock()
+	ret, specificReturn := fake.checkErrorReturnsOnCall[len(fake.checkErrorArgsForCall)]
+	fake.checkErrorArgsForCall = append(fake.checkErrorArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CheckError", []interface{}{})
+	fake.checkErrorMutex.Unlock()
+	if fake.CheckErrorStub != nil {
+		return fake.CheckErrorStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.checkErrorReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) CheckErrorCallCount() int {
+	fake.checkErrorMutex.RLock()
+	defer fake.checkErrorMutex.RUnlock()
+	return len(fake.checkErrorArgsForCall)
+}
+
+func (fake *FakeCheck) CheckErrorCalls(stub func() error) {
+	fake.checkErrorMutex.Lock()
+	defer fake.checkErrorMutex.Unlock()
+	fake.CheckErrorStub = stub
+}
+
+func (fake *FakeCheck) CheckErrorReturns(result1 error) {
+	fake.checkErrorMutex.Lock()
+	defer fake.checkErrorMutex.Unlock()
+	fake.CheckErrorStub = nil
+	fake.checkErrorReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) CheckErrorReturnsOnCall(i int, result1 error) {
+	fake.checkErrorMutex.Lock()
+	defer fake.checkErrorMutex.Unlock()
+	fake.CheckErrorStub = nil
+	if fake.checkErrorReturnsOnCall == nil {
+		fake.checkErrorReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkErrorReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) CreateTime() time.Time {
+	fake.createTimeMutex.Lock()
+	ret, specificReturn := fake.createTimeReturnsOnCall[len(fake.createTimeArgsForCall)]
+	fake.createTimeArgsForCall = append(fake.createTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreateTime", []interface{}{})
+	fake.createTimeMutex.Unlock()
+	if fake.CreateTimeStub != nil {
+		return fake.CreateTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) CreateTimeCallCount() int {
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
+	return len(fake.createTimeArgsForCall)
+}
+
+func (fake *FakeCheck) CreateTimeCalls(stub func() time.Time) {
+	fake.createTimeMutex.Lock()
+	defer fake.createTimeMutex.Unlock()
+	fake.CreateTimeStub = stub
+}
+
+func (fake *FakeCheck) CreateTimeReturns(result1 time.Time) {
+	fake.createTimeMutex.Lock()
+	defer fake.createTimeMutex.Unlock()
+	fake.CreateTimeStub = nil
+	fake.createTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheck) CreateTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.createTimeMutex.Lock()
+	defer fake.createTimeMutex.Unlock()
+	fake.CreateTimeStub = nil
+	if fake.createTimeReturnsOnCall == nil {
+		fake.createTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.createTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheck) EndTime() time.Time {
+	fake.endTimeMutex.Lock()
+	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
+	fake.endTimeArgsForCall = append(fake.endTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EndTime", []interface{}{})
+	fake.endTimeMutex.Unlock()
+	if fake.EndTimeStub != nil {
+		return fake.EndTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.endTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) EndTimeCallCount() int {
+	fake.endTimeMutex.RLock()
+	defer fake.endTimeMutex.RUnlock()
+	return len(fake.endTimeArgsForCall)
+}
+
+func (fake *FakeCheck) EndTimeCalls(stub func() time.Time) {
+	fake.endTimeMutex.Lock()
+	defer fake.endTimeMutex.Unlock()
+	fake.EndTimeStub = stub
+}
+
+func (fake *FakeCheck) EndTimeReturns(result1 time.Time) {
+	fake.endTimeMutex.Lock()
+	defer fake.endTimeMutex.Unlock()
+	fake.EndTimeStub = nil
+	fake.endTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheck) EndTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.endTimeMutex.Lock()
+	defer fake.endTimeMutex.Unlock()
+	fake.EndTimeStub = nil
+	if fake.endTimeReturnsOnCall == nil {
+		fake.endTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.endTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheck) Events(arg1 uint) (db.EventSource, error) {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.recordInvocation("Events", []interface{}{arg1})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheck) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeCheck) EventsCalls(stub func(uint) (db.EventSource, error)) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = stub
+}
+
+func (fake *FakeCheck) EventsArgsForCall(i int) uint {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	argsForCall := fake.eventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheck) EventsReturns(result1 db.EventSource, result2 error) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheck) EventsReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	if fake.eventsReturnsOnCall == nil {
+		fake.eventsReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.eventsReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheck) Finish(arg1 int, arg2 error) error {
+	fake.finishMutex.Lock()
+	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
+	fake.finishArgsForCall = append(fake.finishArgsForCall, struct {
+		arg1 int
+		arg2 error
+	}{arg1, arg2})
+	fake.recordInvocation("Finish", []interface{}{arg1, arg2})
+	fake.finishMutex.Unlock()
+	if fake.FinishStub != nil {
+		return fake.FinishStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.finishReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) FinishCallCount() int {
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	return len(fake.finishArgsForCall)
+}
+
+func (fake *FakeCheck) FinishCalls(stub func(int, error) error) {
+	fake.finishMutex.Lock()
+	defer fake.finishMutex.Unlock()
+	fake.FinishStub = stub
+}
+
+func (fake *FakeCheck) FinishArgsForCall(i int) (int, error) {
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	argsForCall := fake.finishArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeCheck) FinishReturns(result1 error) {
+	fake.finishMutex.Lock()
+	defer fake.finishMutex.Unlock()
+	fake.FinishStub = nil
+	fake.finishReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) FinishReturnsOnCall(i int, result1 error) {
+	fake.finishMutex.Lock()
+	defer fake.finishMutex.Unlock()
+	fake.FinishStub = nil
+	if fake.finishReturnsOnCall == nil {
+		fake.finishReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) ID() int {
+	fake.iDMutex.Lock()
+	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
+	fake.iDArgsForCall = append(fake.iDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ID", []interface{}{})
+	fake.iDMutex.Unlock()
+	if fake.IDStub != nil {
+		return fake.IDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.iDReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) IDCallCount() int {
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	return len(fake.iDArgsForCall)
+}
+
+func (fake *FakeCheck) IDCalls(stub func() int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = stub
+}
+
+func (fake *FakeCheck) IDReturns(result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	fake.iDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeCheck) IDReturnsOnCall(i int, result1 int) {
+	fake.iDMutex.Lock()
+	defer fake.iDMutex.Unlock()
+	fake.IDStub = nil
+	if fake.iDReturnsOnCall == nil {
+		fake.iDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.iDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeCheck) ResourceID() int {
+	fake.resourceIDMutex.Lock()
+	ret, specificReturn := fake.resourceIDReturnsOnCall[len(fake.resourceIDArgsForCall)]
+	fake.resourceIDArgsForCall = append(fake.resourceIDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceID", []interface{}{})
+	fake.resourceIDMutex.Unlock()
+	if fake.ResourceIDStub != nil {
+		return fake.ResourceIDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceIDReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) ResourceIDCallCount() int {
+	fake.resourceIDMutex.RLock()
+	defer fake.resourceIDMutex.RUnlock()
+	return len(fake.resourceIDArgsForCall)
+}
+
+func (fake *FakeCheck) ResourceIDCalls(stub func() int) {
+	fake.resourceIDMutex.Lock()
+	defer fake.resourceIDMutex.Unlock()
+	fake.ResourceIDStub = stub
+}
+
+func (fake *FakeCheck) ResourceIDReturns(result1 int) {
+	fake.resourceIDMutex.Lock()
+	defer fake.resourceIDMutex.Unlock()
+	fake.ResourceIDStub = nil
+	fake.resourceIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeCheck) ResourceIDReturnsOnCall(i int, result1 int) {
+	fake.resourceIDMutex.Lock()
+	defer fake.resourceIDMutex.Unlock()
+	fake.ResourceIDStub = nil
+	if fake.resourceIDReturnsOnCall == nil {
+		fake.resourceIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.resourceIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeCheck) SaveEvent(arg1 atc.Event) error {
+	fake.saveEventMutex.Lock()
+	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
+	fake.saveEventArgsForCall = append(fake.saveEventArgsForCall, struct {
+		arg1 atc.Event
+	}{arg1})
+	fake.recordInvocation("SaveEvent", []interface{}{arg1})
+	fake.saveEventMutex.Unlock()
+	if fake.SaveEventStub != nil {
+		return fake.SaveEventStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveEventReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) SaveEventCallCount() int {
+	fake.saveEventMutex.RLock()
+	defer fake.saveEventMutex.RUnlock()
+	return len(fake.saveEventArgsForCall)
+}
+
+func (fake *FakeCheck) SaveEventCalls(stub func(atc.Event) error) {
+	fake.saveEventMutex.Lock()
+	defer fake.saveEventMutex.Unlock()
+	fake.SaveEventStub = stub
+}
+
+func (fake *FakeCheck) SaveEventArgsForCall(i int) atc.Event {
+	fake.saveEventMutex.RLock()
+	defer fake.saveEventMutex.RUnlock()
+	argsForCall := fake.saveEventArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheck) SaveEventReturns(result1 error) {
+	fake.saveEventMutex.Lock()
+	defer fake.saveEventMutex.Unlock()
+	fake.SaveEventStub = nil
+	fake.saveEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SaveEventReturnsOnCall(i int, result1 error) {
+	fake.saveEventMutex.Lock()
+	defer fake.saveEventMutex.Unlock()
+	fake.SaveEventStub = nil
+	if fake.saveEventReturnsOnCall == nil {
+		fake.saveEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SetWorkerName(arg1 string) error {
+	fake.setWorkerNameMutex.Lock()
+	ret, specificReturn := fake.setWorkerNameReturnsOnCall[len(fake.setWorkerNameArgsForCall)]
+	fake.setWorkerNameArgsForCall = append(fake.setWorkerNameArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetWorkerName", []interface{}{arg1})
+	fake.setWorkerNameMutex.Unlock()
+	if fake.SetWorkerNameStub != nil {
+		return fake.SetWorkerNameStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setWorkerNameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) SetWorkerNameCallCount() int {
+	fake.setWorkerNameMutex.RLock()
+	defer fake.setWorkerNameMutex.RUnlock()
+	return len(fake.setWorkerNameArgsForCall)
+}
+
+func (fake *FakeCheck) SetWorkerNameCalls(stub func(string) error) {
+	fake.setWorkerNameMutex.Lock()
+	defer fake.setWorkerNameMutex.Unlock()
+	fake.SetWorkerNameStub = stub
+}
+
+func (fake *FakeCheck) SetWorkerNameArgsForCall(i int) string {
+	fake.setWorkerNameMutex.RLock()
+	defer fake.setWorkerNameMutex.RUnlock()
+	argsForCall := fake.setWorkerNameArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheck) SetWorkerNameReturns(result1 error) {
+	fake.setWorkerNameMutex.Lock()
+	defer fake.setWorkerNameMutex.Unlock()
+	fake.SetWorkerNameStub = nil
+	fake.setWorkerNameReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SetWorkerNameReturnsOnCall(i int, result1 error) {
+	fake.setWorkerNameMutex.Lock()
+	defer fake.setWorkerNameMutex.Unlock()
+	fake.SetWorkerNameStub = nil
+	if fake.setWorkerNameReturnsOnCall == nil {
+		fake.setWorkerNameReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setWorkerNameReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) Status() db.CheckStatus {
+	fake.statusMutex.Lock()
+	ret, specificReturn := fake.statusReturnsOnCall[len(fake.statusArgsForCall)]
+	fake.statusArgsForCall = append(fake.statusArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Status", []interface{}{})
+	fake.statusMutex.Unlock()
+	if fake.StatusStub != nil {
+		return fake.StatusStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.statusReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) StatusCallCount() int {
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	return len(fake.statusArgsForCall)
+}
+
+func (fake *FakeCheck) StatusCalls(stub func() db.CheckStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = stub
+}
+
+func (fake *FakeCheck) StatusReturns(result1 db.CheckStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	fake.statusReturns = struct {
+		result1 db.CheckStatus
+	}{result1}
+}
+
+func (fake *FakeCheck) StatusReturnsOnCall(i int, result1 db.CheckStatus) {
+	fake.statusMutex.Lock()
+	defer fake.statusMutex.Unlock()
+	fake.StatusStub = nil
+	if fake.statusReturnsOnCall == nil {
+		fake.statusReturnsOnCall = make(map[int]struct {
+			result1 db.CheckStatus
+		})
+	}
+	fake.statusReturnsOnCall[i] = struct {
+		result1 db.CheckStatus
+	}{result1}
+}
+
+func (fake *FakeCheck) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	ret, specificReturn := fake.workerNameReturnsOnCall[len(fake.workerNameArgsForCall)]
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WorkerName", []interface{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.workerNameReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeCheck) WorkerNameCalls(stub func() string) {
+	fake.workerNameMutex.Lock()
+	defer fake.workerNameMutex.Unlock()
+	fake.WorkerNameStub = stub
+}
+
+func (fake *FakeCheck) WorkerNameReturns(result1 string) {
+	fake.workerNameMutex.Lock()
+	defer fake.workerNameMutex.Unlock()
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCheck) WorkerNameReturnsOnCall(i int, result1 string) {
+	fake.workerNameMutex.Lock()
+	defer fake.workerNameMutex.Unlock()
+	fake.WorkerNameStub = nil
+	if fake.workerNameReturnsOnCall == nil {
+		fake.workerNameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.workerNameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeCheck) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.aTCURLMutex.RLock()
+	defer fake.aTCURLMutex.RUnlock()
+	fake.checkErrorMutex.RLock()
+	defer fake.checkErrorMutex.RUnlock()
+	fake.createTimeMutex.RLock()
+	defer fake.createTimeMutex.RUnlock()
+	fake.endTimeMutex.RLock()
+	defer fake.endTimeMutex.RUnlock()
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	fake.finishMutex.RLock()
+	defer fake.finishMutex.RUnlock()
+	fake.iDMutex.RLock()
+	defer fake.iDMutex.RUnlock()
+	fake.resourceIDMutex.RLock()
+	defer fake.resourceIDMutex.RUnlock()
+	fake.saveEventMutex.RLock()
+	defer fake.saveEventMutex.RUnlock()
+	fake.setWorkerNameMutex.RLock()
+	defer fake.setWorkerNameMutex.RUnlock()
+	fake.statusMutex.RLock()
+	defer fake.statusMutex.RUnlock()
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCheck) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.Check = new(FakeCheck)
@@ -0,0 +1,115 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	sync "sync"
+
+	db "github.com/concourse/concourse/atc/db"
+)
+
+type FakeCheckRateLimiter struct {
+	AllowStub        func(string) (bool, error)
+	allowMutex       sync.RWMutex
+	allowArgsForCall []struct {
+		arg1 string
+	}
+	allowReturns struct {
+		result1 bool
+		result2 error
+	}
+	allowReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheckRateLimiter) Allow(arg1 string) (bool, error) {
+	fake.allowMutex.Lock()
+	ret, specificReturn := fake.allowReturnsOnCall[len(fake.allowArgsForCall)]
+	fake.allowArgsForCall = append(fake.allowArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("Allow", []interface{}{arg1})
+	fake.allowMutex.Unlock()
+	if fake.AllowStub != nil {
+		return fake.AllowStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.allowReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckRateLimiter) AllowCallCount() int {
+	fake.allowMutex.RLock()
+	defer fake.allowMutex.RUnlock()
+	return len(fake.allowArgsForCall)
+}
+
+func (fake *FakeCheckRateLimiter) AllowCalls(stub func(string) (bool, error)) {
+	fake.allowMutex.Lock()
+	defer fake.allowMutex.Unlock()
+	fake.AllowStub = stub
+}
+
+func (fake *FakeCheckRateLimiter) AllowArgsForCall(i int) string {
+	fake.allowMutex.RLock()
+	defer fake.allowMutex.RUnlock()
+	argsForCall := fake.allowArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckRateLimiter) AllowReturns(result1 bool, result2 error) {
+	fake.allowMutex.Lock()
+	defer fake.allowMutex.Unlock()
+	fake.AllowStub = nil
+	fake.allowReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckRateLimiter) AllowReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.allowMutex.Lock()
+	defer fake.allowMutex.Unlock()
+	fake.AllowStub = nil
+	if fake.allowReturnsOnCall == nil {
+		fake.allowReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.allowReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckRateLimiter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allowMutex.RLock()
+	defer fake.allowMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCheckRateLimiter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.CheckRateLimiter = new(FakeCheckRateLimiter)
@@ -0,0 +1,194 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	sync "sync"
+	time "time"
+
+	db "github.com/concourse/concourse/atc/db"
+)
+
+type FakeCheckableFactory struct {
+	DueResourceTypesStub        func(time.Duration) ([]db.ResourceType, error)
+	dueResourceTypesMutex       sync.RWMutex
+	dueResourceTypesArgsForCall []struct {
+		arg1 time.Duration
+	}
+	dueResourceTypesReturns struct {
+		result1 []db.ResourceType
+		result2 error
+	}
+	dueResourceTypesReturnsOnCall map[int]struct {
+		result1 []db.ResourceType
+		result2 error
+	}
+	DueResourcesStub        func(time.Duration) ([]db.Resource, error)
+	dueResourcesMutex       sync.RWMutex
+	dueResourcesArgsForCall []struct {
+		arg1 time.Duration
+	}
+	dueResourcesReturns struct {
+		result1 []db.Resource
+		result2 error
+	}
+	dueResourcesReturnsOnCall map[int]struct {
+		result1 []db.Resource
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCheckableFactory) DueResourceTypes(arg1 time.Duration) ([]db.ResourceType, error) {
+	fake.dueResourceTypesMutex.Lock()
+	ret, specificReturn := fake.dueResourceTypesReturnsOnCall[len(fake.dueResourceTypesArgsForCall)]
+	fake.dueResourceTypesArgsForCall = append(fake.dueResourceTypesArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("DueResourceTypes", []interface{}{arg1})
+	fake.dueResourceTypesMutex.Unlock()
+	if fake.DueResourceTypesStub != nil {
+		return fake.DueResourceTypesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.dueResourceTypesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckableFactory) DueResourceTypesCallCount() int {
+	fake.dueResourceTypesMutex.RLock()
+	defer fake.dueResourceTypesMutex.RUnlock()
+	return len(fake.dueResourceTypesArgsForCall)
+}
+
+func (fake *FakeCheckableFactory) DueResourceTypesCalls(stub func(time.Duration) ([]db.ResourceType, error)) {
+	fake.dueResourceTypesMutex.Lock()
+	defer fake.dueResourceTypesMutex.Unlock()
+	fake.DueResourceTypesStub = stub
+}
+
+func (fake *FakeCheckableFactory) DueResourceTypesArgsForCall(i int) time.Duration {
+	fake.dueResourceTypesMutex.RLock()
+	defer fake.dueResourceTypesMutex.RUnlock()
+	argsForCall := fake.dueResourceTypesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckableFactory) DueResourceTypesReturns(result1 []db.ResourceType, result2 error) {
+	fake.dueResourceTypesMutex.Lock()
+	defer fake.dueResourceTypesMutex.Unlock()
+	fake.DueResourceTypesStub = nil
+	fake.dueResourceTypesReturns = struct {
+		result1 []db.ResourceType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckableFactory) DueResourceTypesReturnsOnCall(i int, result1 []db.ResourceType, result2 error) {
+	fake.dueResourceTypesMutex.Lock()
+	defer fake.dueResourceTypesMutex.Unlock()
+	fake.DueResourceTypesStub = nil
+	if fake.dueResourceTypesReturnsOnCall == nil {
+		fake.dueResourceTypesReturnsOnCall = make(map[int]struct {
+			result1 []db.ResourceType
+			result2 error
+		})
+	}
+	fake.dueResourceTypesReturnsOnCall[i] = struct {
+		result1 []db.ResourceType
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckableFactory) DueResources(arg1 time.Duration) ([]db.Resource, error) {
+	fake.dueResourcesMutex.Lock()
+	ret, specificReturn := fake.dueResourcesReturnsOnCall[len(fake.dueResourcesArgsForCall)]
+	fake.dueResourcesArgsForCall = append(fake.dueResourcesArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("DueResources", []interface{}{arg1})
+	fake.dueResourcesMutex.Unlock()
+	if fake.DueResourcesStub != nil {
+		return fake.DueResourcesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.dueResourcesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeCheckableFactory) DueResourcesCallCount() int {
+	fake.dueResourcesMutex.RLock()
+	defer fake.dueResourcesMutex.RUnlock()
+	return len(fake.dueResourcesArgsForCall)
+}
+
+func (fake *FakeCheckableFactory) DueResourcesCalls(stub func(time.Duration) ([]db.Resource, error)) {
+	fake.dueResourcesMutex.Lock()
+	defer fake.dueResourcesMutex.Unlock()
+	fake.DueResourcesStub = stub
+}
+
+func (fake *FakeCheckableFactory) DueResourcesArgsForCall(i int) time.Duration {
+	fake.dueResourcesMutex.RLock()
+	defer fake.dueResourcesMutex.RUnlock()
+	argsForCall := fake.dueResourcesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheckableFactory) DueResourcesReturns(result1 []db.Resource, result2 error) {
+	fake.dueResourcesMutex.Lock()
+	defer fake.dueResourcesMutex.Unlock()
+	fake.DueResourcesStub = nil
+	fake.dueResourcesReturns = struct {
+		result1 []db.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckableFactory) DueResourcesReturnsOnCall(i int, result1 []db.Resource, result2 error) {
+	fake.dueResourcesMutex.Lock()
+	defer fake.dueResourcesMutex.Unlock()
+	fake.DueResourcesStub = nil
+	if fake.dueResourcesReturnsOnCall == nil {
+		fake.dueResourcesReturnsOnCall = make(map[int]struct {
+			result1 []db.Resource
+			result2 error
+		})
+	}
+	fake.dueResourcesReturnsOnCall[i] = struct {
+		result1 []db.Resource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCheckableFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.dueResourceTypesMutex.RLock()
+	defer fake.dueResourceTypesMutex.RUnlock()
+	fake.dueResourcesMutex.RLock()
+	defer fake.dueResourcesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCheckableFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.CheckableFactory = new(FakeCheckableFactory)
@@ -29,6 +29,26 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	ArchiveStub        func() error
+	archiveMutex       sync.RWMutex
+	archiveArgsForCall []struct {
+	}
+	archiveReturns struct {
+		result1 error
+	}
+	archiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ArchivedStub        func() bool
+	archivedMutex       sync.RWMutex
+	archivedArgsForCall []struct {
+	}
+	archivedReturns struct {
+		result1 bool
+	}
+	archivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	BuildsStub        func(db.Page) ([]db.Build, db.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -219,6 +239,16 @@ type FakePipeline struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	InstanceVarsStub        func() atc.InstanceVars
+	instanceVarsMutex       sync.RWMutex
+	instanceVarsArgsForCall []struct {
+	}
+	instanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	instanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	JobStub        func(string) (db.Job, bool, error)
 	jobMutex       sync.RWMutex
 	jobArgsForCall []struct {
@@ -298,6 +328,16 @@ type FakePipeline struct {
 	publicReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	RefStub        func() atc.PipelineRef
+	refMutex       sync.RWMutex
+	refArgsForCall []struct {
+	}
+	refReturns struct {
+		result1 atc.PipelineRef
+	}
+	refReturnsOnCall map[int]struct {
+		result1 atc.PipelineRef
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -321,6 +361,18 @@ type FakePipeline struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ResetLastCheckedStub        func([]string, []string) error
+	resetLastCheckedMutex       sync.RWMutex
+	resetLastCheckedArgsForCall []struct {
+		arg1 []string
+		arg2 []string
+	}
+	resetLastCheckedReturns struct {
+		result1 error
+	}
+	resetLastCheckedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ResourceStub        func(string) (db.Resource, bool, error)
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
@@ -351,6 +403,16 @@ type FakePipeline struct {
 		result2 bool
 		result3 error
 	}
+	ResourceTypeDefaultsStub        func() atc.ResourceTypeDefaults
+	resourceTypeDefaultsMutex       sync.RWMutex
+	resourceTypeDefaultsArgsForCall []struct {
+	}
+	resourceTypeDefaultsReturns struct {
+		result1 atc.ResourceTypeDefaults
+	}
+	resourceTypeDefaultsReturnsOnCall map[int]struct {
+		result1 atc.ResourceTypeDefaults
+	}
 	ResourceTypesStub        func() (db.ResourceTypes, error)
 	resourceTypesMutex       sync.RWMutex
 	resourceTypesArgsForCall []struct {
@@ -390,6 +452,18 @@ type FakePipeline struct {
 		result1 db.Resources
 		result2 error
 	}
+	SchedulingNotifierStub        func() (db.Notifier, error)
+	schedulingNotifierMutex       sync.RWMutex
+	schedulingNotifierArgsForCall []struct {
+	}
+	schedulingNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	schedulingNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
 	TeamIDStub        func() int
 	teamIDMutex       sync.RWMutex
 	teamIDArgsForCall []struct {
@@ -491,6 +565,110 @@ func (fake *FakePipeline) AcquireSchedulingLockReturnsOnCall(i int, result1 lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) Archive() error {
+	fake.archiveMutex.Lock()
+	ret, specificReturn := fake.archiveReturnsOnCall[len(fake.archiveArgsForCall)]
+	fake.archiveArgsForCall = append(fake.archiveArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Archive", []interface{}{})
+	fake.archiveMutex.Unlock()
+	if fake.ArchiveStub != nil {
+		return fake.ArchiveStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.archiveReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ArchiveCallCount() int {
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	return len(fake.archiveArgsForCall)
+}
+
+func (fake *FakePipeline) ArchiveCalls(stub func() error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = stub
+}
+
+func (fake *FakePipeline) ArchiveReturns(result1 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	fake.archiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ArchiveReturnsOnCall(i int, result1 error) {
+	fake.archiveMutex.Lock()
+	defer fake.archiveMutex.Unlock()
+	fake.ArchiveStub = nil
+	if fake.archiveReturnsOnCall == nil {
+		fake.archiveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.archiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) Archived() bool {
+	fake.archivedMutex.Lock()
+	ret, specificReturn := fake.archivedReturnsOnCall[len(fake.archivedArgsForCall)]
+	fake.archivedArgsForCall = append(fake.archivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Archived", []interface{}{})
+	fake.archivedMutex.Unlock()
+	if fake.ArchivedStub != nil {
+		return fake.ArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.archivedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ArchivedCallCount() int {
+	fake.archivedMutex.RLock()
+	defer fake.archivedMutex.RUnlock()
+	return len(fake.archivedArgsForCall)
+}
+
+func (fake *FakePipeline) ArchivedCalls(stub func() bool) {
+	fake.archivedMutex.Lock()
+	defer fake.archivedMutex.Unlock()
+	fake.ArchivedStub = stub
+}
+
+func (fake *FakePipeline) ArchivedReturns(result1 bool) {
+	fake.archivedMutex.Lock()
+	defer fake.archivedMutex.Unlock()
+	fake.ArchivedStub = nil
+	fake.archivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePipeline) ArchivedReturnsOnCall(i int, result1 bool) {
+	fake.archivedMutex.Lock()
+	defer fake.archivedMutex.Unlock()
+	fake.ArchivedStub = nil
+	if fake.archivedReturnsOnCall == nil {
+		fake.archivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.archivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakePipeline) Builds(arg1 db.Page) ([]db.Build, db.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -1411,6 +1589,58 @@ func (fake *FakePipeline) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakePipeline) InstanceVars() atc.InstanceVars {
+	fake.instanceVarsMutex.Lock()
+	ret, specificReturn := fake.instanceVarsReturnsOnCall[len(fake.instanceVarsArgsForCall)]
+	fake.instanceVarsArgsForCall = append(fake.instanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("InstanceVars", []interface{}{})
+	fake.instanceVarsMutex.Unlock()
+	if fake.InstanceVarsStub != nil {
+		return fake.InstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.instanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) InstanceVarsCallCount() int {
+	fake.instanceVarsMutex.RLock()
+	defer fake.instanceVarsMutex.RUnlock()
+	return len(fake.instanceVarsArgsForCall)
+}
+
+func (fake *FakePipeline) InstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.instanceVarsMutex.Lock()
+	defer fake.instanceVarsMutex.Unlock()
+	fake.InstanceVarsStub = stub
+}
+
+func (fake *FakePipeline) InstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.instanceVarsMutex.Lock()
+	defer fake.instanceVarsMutex.Unlock()
+	fake.InstanceVarsStub = nil
+	fake.instanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakePipeline) InstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.instanceVarsMutex.Lock()
+	defer fake.instanceVarsMutex.Unlock()
+	fake.InstanceVarsStub = nil
+	if fake.instanceVarsReturnsOnCall == nil {
+		fake.instanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.instanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakePipeline) Job(arg1 string) (db.Job, bool, error) {
 	fake.jobMutex.Lock()
 	ret, specificReturn := fake.jobReturnsOnCall[len(fake.jobArgsForCall)]
@@ -1795,6 +2025,58 @@ func (fake *FakePipeline) PublicReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakePipeline) Ref() atc.PipelineRef {
+	fake.refMutex.Lock()
+	ret, specificReturn := fake.refReturnsOnCall[len(fake.refArgsForCall)]
+	fake.refArgsForCall = append(fake.refArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Ref", []interface{}{})
+	fake.refMutex.Unlock()
+	if fake.RefStub != nil {
+		return fake.RefStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.refReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) RefCallCount() int {
+	fake.refMutex.RLock()
+	defer fake.refMutex.RUnlock()
+	return len(fake.refArgsForCall)
+}
+
+func (fake *FakePipeline) RefCalls(stub func() atc.PipelineRef) {
+	fake.refMutex.Lock()
+	defer fake.refMutex.Unlock()
+	fake.RefStub = stub
+}
+
+func (fake *FakePipeline) RefReturns(result1 atc.PipelineRef) {
+	fake.refMutex.Lock()
+	defer fake.refMutex.Unlock()
+	fake.RefStub = nil
+	fake.refReturns = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
+func (fake *FakePipeline) RefReturnsOnCall(i int, result1 atc.PipelineRef) {
+	fake.refMutex.Lock()
+	defer fake.refMutex.Unlock()
+	fake.RefStub = nil
+	if fake.refReturnsOnCall == nil {
+		fake.refReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineRef
+		})
+	}
+	fake.refReturnsOnCall[i] = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
 func (fake *FakePipeline) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1910,6 +2192,77 @@ func (fake *FakePipeline) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipeline) ResetLastChecked(arg1 []string, arg2 []string) error {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.resetLastCheckedMutex.Lock()
+	ret, specificReturn := fake.resetLastCheckedReturnsOnCall[len(fake.resetLastCheckedArgsForCall)]
+	fake.resetLastCheckedArgsForCall = append(fake.resetLastCheckedArgsForCall, struct {
+		arg1 []string
+		arg2 []string
+	}{arg1Copy, arg2Copy})
+	fake.recordInvocation("ResetLastChecked", []interface{}{arg1Copy, arg2Copy})
+	fake.resetLastCheckedMutex.Unlock()
+	if fake.ResetLastCheckedStub != nil {
+		return fake.ResetLastCheckedStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resetLastCheckedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ResetLastCheckedCallCount() int {
+	fake.resetLastCheckedMutex.RLock()
+	defer fake.resetLastCheckedMutex.RUnlock()
+	return len(fake.resetLastCheckedArgsForCall)
+}
+
+func (fake *FakePipeline) ResetLastCheckedCalls(stub func([]string, []string) error) {
+	fake.resetLastCheckedMutex.Lock()
+	defer fake.resetLastCheckedMutex.Unlock()
+	fake.ResetLastCheckedStub = stub
+}
+
+func (fake *FakePipeline) ResetLastCheckedArgsForCall(i int) ([]string, []string) {
+	fake.resetLastCheckedMutex.RLock()
+	defer fake.resetLastCheckedMutex.RUnlock()
+	argsForCall := fake.resetLastCheckedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePipeline) ResetLastCheckedReturns(result1 error) {
+	fake.resetLastCheckedMutex.Lock()
+	defer fake.resetLastCheckedMutex.Unlock()
+	fake.ResetLastCheckedStub = nil
+	fake.resetLastCheckedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipeline) ResetLastCheckedReturnsOnCall(i int, result1 error) {
+	fake.resetLastCheckedMutex.Lock()
+	defer fake.resetLastCheckedMutex.Unlock()
+	fake.ResetLastCheckedStub = nil
+	if fake.resetLastCheckedReturnsOnCall == nil {
+		fake.resetLastCheckedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resetLastCheckedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipeline) Resource(arg1 string) (db.Resource, bool, error) {
 	fake.resourceMutex.Lock()
 	ret, specificReturn := fake.resourceReturnsOnCall[len(fake.resourceArgsForCall)]
@@ -2042,6 +2395,58 @@ func (fake *FakePipeline) ResourceTypeReturnsOnCall(i int, result1 db.ResourceTy
 	}{result1, result2, result3}
 }
 
+func (fake *FakePipeline) ResourceTypeDefaults() atc.ResourceTypeDefaults {
+	fake.resourceTypeDefaultsMutex.Lock()
+	ret, specificReturn := fake.resourceTypeDefaultsReturnsOnCall[len(fake.resourceTypeDefaultsArgsForCall)]
+	fake.resourceTypeDefaultsArgsForCall = append(fake.resourceTypeDefaultsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceTypeDefaults", []interface{}{})
+	fake.resourceTypeDefaultsMutex.Unlock()
+	if fake.ResourceTypeDefaultsStub != nil {
+		return fake.ResourceTypeDefaultsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceTypeDefaultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) ResourceTypeDefaultsCallCount() int {
+	fake.resourceTypeDefaultsMutex.RLock()
+	defer fake.resourceTypeDefaultsMutex.RUnlock()
+	return len(fake.resourceTypeDefaultsArgsForCall)
+}
+
+func (fake *FakePipeline) ResourceTypeDefaultsCalls(stub func() atc.ResourceTypeDefaults) {
+	fake.resourceTypeDefaultsMutex.Lock()
+	defer fake.resourceTypeDefaultsMutex.Unlock()
+	fake.ResourceTypeDefaultsStub = stub
+}
+
+func (fake *FakePipeline) ResourceTypeDefaultsReturns(result1 atc.ResourceTypeDefaults) {
+	fake.resourceTypeDefaultsMutex.Lock()
+	defer fake.resourceTypeDefaultsMutex.Unlock()
+	fake.ResourceTypeDefaultsStub = nil
+	fake.resourceTypeDefaultsReturns = struct {
+		result1 atc.ResourceTypeDefaults
+	}{result1}
+}
+
+func (fake *FakePipeline) ResourceTypeDefaultsReturnsOnCall(i int, result1 atc.ResourceTypeDefaults) {
+	fake.resourceTypeDefaultsMutex.Lock()
+	defer fake.resourceTypeDefaultsMutex.Unlock()
+	fake.ResourceTypeDefaultsStub = nil
+	if fake.resourceTypeDefaultsReturnsOnCall == nil {
+		fake.resourceTypeDefaultsReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceTypeDefaults
+		})
+	}
+	fake.resourceTypeDefaultsReturnsOnCall[i] = struct {
+		result1 atc.ResourceTypeDefaults
+	}{result1}
+}
+
 func (fake *FakePipeline) ResourceTypes() (db.ResourceTypes, error) {
 	fake.resourceTypesMutex.Lock()
 	ret, specificReturn := fake.resourceTypesReturnsOnCall[len(fake.resourceTypesArgsForCall)]
@@ -2218,6 +2623,61 @@ func (fake *FakePipeline) ResourcesReturnsOnCall(i int, result1 db.Resources, re
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) SchedulingNotifier() (db.Notifier, error) {
+	fake.schedulingNotifierMutex.Lock()
+	ret, specificReturn := fake.schedulingNotifierReturnsOnCall[len(fake.schedulingNotifierArgsForCall)]
+	fake.schedulingNotifierArgsForCall = append(fake.schedulingNotifierArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SchedulingNotifier", []interface{}{})
+	fake.schedulingNotifierMutex.Unlock()
+	if fake.SchedulingNotifierStub != nil {
+		return fake.SchedulingNotifierStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.schedulingNotifierReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) SchedulingNotifierCallCount() int {
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
+	return len(fake.schedulingNotifierArgsForCall)
+}
+
+func (fake *FakePipeline) SchedulingNotifierCalls(stub func() (db.Notifier, error)) {
+	fake.schedulingNotifierMutex.Lock()
+	defer fake.schedulingNotifierMutex.Unlock()
+	fake.SchedulingNotifierStub = stub
+}
+
+func (fake *FakePipeline) SchedulingNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.schedulingNotifierMutex.Lock()
+	defer fake.schedulingNotifierMutex.Unlock()
+	fake.SchedulingNotifierStub = nil
+	fake.schedulingNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) SchedulingNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.schedulingNotifierMutex.Lock()
+	defer fake.schedulingNotifierMutex.Unlock()
+	fake.SchedulingNotifierStub = nil
+	if fake.schedulingNotifierReturnsOnCall == nil {
+		fake.schedulingNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.schedulingNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) TeamID() int {
 	fake.teamIDMutex.Lock()
 	ret, specificReturn := fake.teamIDReturnsOnCall[len(fake.teamIDArgsForCall)]
@@ -2379,6 +2839,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.acquireSchedulingLockMutex.RLock()
 	defer fake.acquireSchedulingLockMutex.RUnlock()
+	fake.archiveMutex.RLock()
+	defer fake.archiveMutex.RUnlock()
+	fake.archivedMutex.RLock()
+	defer fake.archivedMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.buildsWithTimeMutex.RLock()
@@ -2411,6 +2875,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.hideMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.instanceVarsMutex.RLock()
+	defer fake.instanceVarsMutex.RUnlock()
 	fake.jobMutex.RLock()
 	defer fake.jobMutex.RUnlock()
 	fake.jobsMutex.RLock()
@@ -2425,20 +2891,28 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.pausedMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
+	fake.refMutex.RLock()
+	defer fake.refMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
+	fake.resetLastCheckedMutex.RLock()
+	defer fake.resetLastCheckedMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceTypeMutex.RLock()
 	defer fake.resourceTypeMutex.RUnlock()
+	fake.resourceTypeDefaultsMutex.RLock()
+	defer fake.resourceTypeDefaultsMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.resourceVersionMutex.RLock()
 	defer fake.resourceVersionMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.schedulingNotifierMutex.RLock()
+	defer fake.schedulingNotifierMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
@@ -20,6 +20,19 @@ type FakePipelineFactory struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	PipelinesByIDStub        func([]int) ([]db.Pipeline, error)
+	pipelinesByIDMutex       sync.RWMutex
+	pipelinesByIDArgsForCall []struct {
+		arg1 []int
+	}
+	pipelinesByIDReturns struct {
+		result1 []db.Pipeline
+		result2 error
+	}
+	pipelinesByIDReturnsOnCall map[int]struct {
+		result1 []db.Pipeline
+		result2 error
+	}
 	VisiblePipelinesStub        func([]string) ([]db.Pipeline, error)
 	visiblePipelinesMutex       sync.RWMutex
 	visiblePipelinesArgsForCall []struct {
@@ -92,6 +105,74 @@ func (fake *FakePipelineFactory) AllPipelinesReturnsOnCall(i int, result1 []db.P
 	}{result1, result2}
 }
 
+func (fake *FakePipelineFactory) PipelinesByID(arg1 []int) ([]db.Pipeline, error) {
+	var arg1Copy []int
+	if arg1 != nil {
+		arg1Copy = make([]int, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.pipelinesByIDMutex.Lock()
+	ret, specificReturn := fake.pipelinesByIDReturnsOnCall[len(fake.pipelinesByIDArgsForCall)]
+	fake.pipelinesByIDArgsForCall = append(fake.pipelinesByIDArgsForCall, struct {
+		arg1 []int
+	}{arg1Copy})
+	fake.recordInvocation("PipelinesByID", []interface{}{arg1Copy})
+	fake.pipelinesByIDMutex.Unlock()
+	if fake.PipelinesByIDStub != nil {
+		return fake.PipelinesByIDStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pipelinesByIDReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipelineFactory) PipelinesByIDCallCount() int {
+	fake.pipelinesByIDMutex.RLock()
+	defer fake.pipelinesByIDMutex.RUnlock()
+	return len(fake.pipelinesByIDArgsForCall)
+}
+
+func (fake *FakePipelineFactory) PipelinesByIDCalls(stub func([]int) ([]db.Pipeline, error)) {
+	fake.pipelinesByIDMutex.Lock()
+	defer fake.pipelinesByIDMutex.Unlock()
+	fake.PipelinesByIDStub = stub
+}
+
+func (fake *FakePipelineFactory) PipelinesByIDArgsForCall(i int) []int {
+	fake.pipelinesByIDMutex.RLock()
+	defer fake.pipelinesByIDMutex.RUnlock()
+	argsForCall := fake.pipelinesByIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipelineFactory) PipelinesByIDReturns(result1 []db.Pipeline, result2 error) {
+	fake.pipelinesByIDMutex.Lock()
+	defer fake.pipelinesByIDMutex.Unlock()
+	fake.PipelinesByIDStub = nil
+	fake.pipelinesByIDReturns = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineFactory) PipelinesByIDReturnsOnCall(i int, result1 []db.Pipeline, result2 error) {
+	fake.pipelinesByIDMutex.Lock()
+	defer fake.pipelinesByIDMutex.Unlock()
+	fake.PipelinesByIDStub = nil
+	if fake.pipelinesByIDReturnsOnCall == nil {
+		fake.pipelinesByIDReturnsOnCall = make(map[int]struct {
+			result1 []db.Pipeline
+			result2 error
+		})
+	}
+	fake.pipelinesByIDReturnsOnCall[i] = struct {
+		result1 []db.Pipeline
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipelineFactory) VisiblePipelines(arg1 []string) ([]db.Pipeline, error) {
 	var arg1Copy []string
 	if arg1 != nil {
@@ -165,6 +246,8 @@ func (fake *FakePipelineFactory) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.allPipelinesMutex.RLock()
 	defer fake.allPipelinesMutex.RUnlock()
+	fake.pipelinesByIDMutex.RLock()
+	defer fake.pipelinesByIDMutex.RUnlock()
 	fake.visiblePipelinesMutex.RLock()
 	defer fake.visiblePipelinesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
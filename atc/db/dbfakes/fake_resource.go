@@ -22,6 +22,21 @@ type FakeResource struct {
 	aPIPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	CheckStub        func(int) (db.Check, bool, error)
+	checkMutex       sync.RWMutex
+	checkArgsForCall []struct {
+		arg1 int
+	}
+	checkReturns struct {
+		result1 db.Check
+		result2 bool
+		result3 error
+	}
+	checkReturnsOnCall map[int]struct {
+		result1 db.Check
+		result2 bool
+		result3 error
+	}
 	CheckErrorStub        func() error
 	checkErrorMutex       sync.RWMutex
 	checkErrorArgsForCall []struct {
@@ -62,6 +77,18 @@ type FakeResource struct {
 	checkTimeoutReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ChecksStub        func() ([]db.Check, error)
+	checksMutex       sync.RWMutex
+	checksArgsForCall []struct {
+	}
+	checksReturns struct {
+		result1 []db.Check
+		result2 error
+	}
+	checksReturnsOnCall map[int]struct {
+		result1 []db.Check
+		result2 error
+	}
 	ConfigPinnedVersionStub        func() atc.Version
 	configPinnedVersionMutex       sync.RWMutex
 	configPinnedVersionArgsForCall []struct {
@@ -72,6 +99,30 @@ type FakeResource struct {
 	configPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	CreateCheckStub        func(string) (db.Check, error)
+	createCheckMutex       sync.RWMutex
+	createCheckArgsForCall []struct {
+		arg1 string
+	}
+	createCheckReturns struct {
+		result1 db.Check
+		result2 error
+	}
+	createCheckReturnsOnCall map[int]struct {
+		result1 db.Check
+		result2 error
+	}
+	CreateRateLimitedCheckStub        func(string) error
+	createRateLimitedCheckMutex       sync.RWMutex
+	createRateLimitedCheckArgsForCall []struct {
+		arg1 string
+	}
+	createRateLimitedCheckReturns struct {
+		result1 error
+	}
+	createRateLimitedCheckReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CurrentPinnedVersionStub        func() atc.Version
 	currentPinnedVersionMutex       sync.RWMutex
 	currentPinnedVersionArgsForCall []struct {
@@ -82,10 +133,11 @@ type FakeResource struct {
 	currentPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
-	DisableVersionStub        func(int) error
+	DisableVersionStub        func(int, db.VersionAnnotation) error
 	disableVersionMutex       sync.RWMutex
 	disableVersionArgsForCall []struct {
 		arg1 int
+		arg2 db.VersionAnnotation
 	}
 	disableVersionReturns struct {
 		result1 error
@@ -93,10 +145,11 @@ type FakeResource struct {
 	disableVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EnableVersionStub        func(int) error
+	EnableVersionStub        func(int, db.VersionAnnotation) error
 	enableVersionMutex       sync.RWMutex
 	enableVersionArgsForCall []struct {
 		arg1 int
+		arg2 db.VersionAnnotation
 	}
 	enableVersionReturns struct {
 		result1 error
@@ -104,6 +157,18 @@ type FakeResource struct {
 	enableVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EnabledVersionsStub        func() (map[int]atc.Version, error)
+	enabledVersionsMutex       sync.RWMutex
+	enabledVersionsArgsForCall []struct {
+	}
+	enabledVersionsReturns struct {
+		result1 map[int]atc.Version
+		result2 error
+	}
+	enabledVersionsReturnsOnCall map[int]struct {
+		result1 map[int]atc.Version
+		result2 error
+	}
 	IDStub        func() int
 	iDMutex       sync.RWMutex
 	iDArgsForCall []struct {
@@ -114,6 +179,20 @@ type FakeResource struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	InjectVersionStub        func(atc.Version, db.ResourceConfigMetadataFields) (bool, error)
+	injectVersionMutex       sync.RWMutex
+	injectVersionArgsForCall []struct {
+		arg1 atc.Version
+		arg2 db.ResourceConfigMetadataFields
+	}
+	injectVersionReturns struct {
+		result1 bool
+		result2 error
+	}
+	injectVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	LastCheckedStub        func() time.Time
 	lastCheckedMutex       sync.RWMutex
 	lastCheckedArgsForCall []struct {
@@ -144,10 +223,11 @@ type FakeResource struct {
 	pinCommentReturnsOnCall map[int]struct {
 		result1 string
 	}
-	PinVersionStub        func(int) error
+	PinVersionStub        func(int, db.VersionAnnotation) error
 	pinVersionMutex       sync.RWMutex
 	pinVersionArgsForCall []struct {
 		arg1 int
+		arg2 db.VersionAnnotation
 	}
 	pinVersionReturns struct {
 		result1 error
@@ -175,6 +255,16 @@ type FakeResource struct {
 	pipelineNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	RateLimitedStub        func() bool
+	rateLimitedMutex       sync.RWMutex
+	rateLimitedArgsForCall []struct {
+	}
+	rateLimitedReturns struct {
+		result1 bool
+	}
+	rateLimitedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ReloadStub        func() (bool, error)
 	reloadMutex       sync.RWMutex
 	reloadArgsForCall []struct {
@@ -285,6 +375,16 @@ type FakeResource struct {
 	sourceReturnsOnCall map[int]struct {
 		result1 atc.Source
 	}
+	SourceWithDefaultsStub        func() atc.Source
+	sourceWithDefaultsMutex       sync.RWMutex
+	sourceWithDefaultsArgsForCall []struct {
+	}
+	sourceWithDefaultsReturns struct {
+		result1 atc.Source
+	}
+	sourceWithDefaultsReturnsOnCall map[int]struct {
+		result1 atc.Source
+	}
 	TagsStub        func() atc.Tags
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -325,6 +425,16 @@ type FakeResource struct {
 	unpinVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VersionHistoryStub        func() *atc.VersionHistoryConfig
+	versionHistoryMutex       sync.RWMutex
+	versionHistoryArgsForCall []struct {
+	}
+	versionHistoryReturns struct {
+		result1 *atc.VersionHistoryConfig
+	}
+	versionHistoryReturnsOnCall map[int]struct {
+		result1 *atc.VersionHistoryConfig
+	}
 	VersionsStub        func(db.Page) ([]atc.ResourceVersion, db.Pagination, bool, error)
 	versionsMutex       sync.RWMutex
 	versionsArgsForCall []struct {
@@ -342,6 +452,16 @@ type FakeResource struct {
 		result3 bool
 		result4 error
 	}
+	WebhookStub        func() *atc.WebhookConfig
+	webhookMutex       sync.RWMutex
+	webhookArgsForCall []struct {
+	}
+	webhookReturns struct {
+		result1 *atc.WebhookConfig
+	}
+	webhookReturnsOnCall map[int]struct {
+		result1 *atc.WebhookConfig
+	}
 	WebhookTokenStub        func() string
 	webhookTokenMutex       sync.RWMutex
 	webhookTokenArgsForCall []struct {
@@ -352,6 +472,16 @@ type FakeResource struct {
 	webhookTokenReturnsOnCall map[int]struct {
 		result1 string
 	}
+	WorkerSelectorStub        func() atc.WorkerSelector
+	workerSelectorMutex       sync.RWMutex
+	workerSelectorArgsForCall []struct {
+	}
+	workerSelectorReturns struct {
+		result1 atc.WorkerSelector
+	}
+	workerSelectorReturnsOnCall map[int]struct {
+		result1 atc.WorkerSelector
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -408,6 +538,72 @@ func (fake *FakeResource) APIPinnedVersionReturnsOnCall(i int, result1 atc.Versi
 	}{result1}
 }
 
+func (fake *FakeResource) Check(arg1 int) (db.Check, bool, error) {
+	fake.checkMutex.Lock()
+	ret, specificReturn := fake.checkReturnsOnCall[len(fake.checkArgsForCall)]
+	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Check", []interface{}{arg1})
+	fake.checkMutex.Unlock()
+	if fake.CheckStub != nil {
+		return fake.CheckStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.checkReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeResource) CheckCallCount() int {
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
+	return len(fake.checkArgsForCall)
+}
+
+func (fake *FakeResource) CheckCalls(stub func(int) (db.Check, bool, error)) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = stub
+}
+
+func (fake *FakeResource) CheckArgsForCall(i int) int {
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
+	argsForCall := fake.checkArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) CheckReturns(result1 db.Check, result2 bool, result3 error) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = nil
+	fake.checkReturns = struct {
+		result1 db.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeResource) CheckReturnsOnCall(i int, result1 db.Check, result2 bool, result3 error) {
+	fake.checkMutex.Lock()
+	defer fake.checkMutex.Unlock()
+	fake.CheckStub = nil
+	if fake.checkReturnsOnCall == nil {
+		fake.checkReturnsOnCall = make(map[int]struct {
+			result1 db.Check
+			result2 bool
+			result3 error
+		})
+	}
+	fake.checkReturnsOnCall[i] = struct {
+		result1 db.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeResource) CheckError() error {
 	fake.checkErrorMutex.Lock()
 	ret, specificReturn := fake.checkErrorReturnsOnCall[len(fake.checkErrorArgsForCall)]
@@ -616,6 +812,61 @@ func (fake *FakeResource) CheckTimeoutReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) Checks() ([]db.Check, error) {
+	fake.checksMutex.Lock()
+	ret, specificReturn := fake.checksReturnsOnCall[len(fake.checksArgsForCall)]
+	fake.checksArgsForCall = append(fake.checksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Checks", []interface{}{})
+	fake.checksMutex.Unlock()
+	if fake.ChecksStub != nil {
+		return fake.ChecksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.checksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) ChecksCallCount() int {
+	fake.checksMutex.RLock()
+	defer fake.checksMutex.RUnlock()
+	return len(fake.checksArgsForCall)
+}
+
+func (fake *FakeResource) ChecksCalls(stub func() ([]db.Check, error)) {
+	fake.checksMutex.Lock()
+	defer fake.checksMutex.Unlock()
+	fake.ChecksStub = stub
+}
+
+func (fake *FakeResource) ChecksReturns(result1 []db.Check, result2 error) {
+	fake.checksMutex.Lock()
+	defer fake.checksMutex.Unlock()
+	fake.ChecksStub = nil
+	fake.checksReturns = struct {
+		result1 []db.Check
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) ChecksReturnsOnCall(i int, result1 []db.Check, result2 error) {
+	fake.checksMutex.Lock()
+	defer fake.checksMutex.Unlock()
+	fake.ChecksStub = nil
+	if fake.checksReturnsOnCall == nil {
+		fake.checksReturnsOnCall = make(map[int]struct {
+			result1 []db.Check
+			result2 error
+		})
+	}
+	fake.checksReturnsOnCall[i] = struct {
+		result1 []db.Check
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) ConfigPinnedVersion() atc.Version {
 	fake.configPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.configPinnedVersionReturnsOnCall[len(fake.configPinnedVersionArgsForCall)]
@@ -668,6 +919,129 @@ func (fake *FakeResource) ConfigPinnedVersionReturnsOnCall(i int, result1 atc.Ve
 	}{result1}
 }
 
+func (fake *FakeResource) CreateCheck(arg1 string) (db.Check, error) {
+	fake.createCheckMutex.Lock()
+	ret, specificReturn := fake.createCheckReturnsOnCall[len(fake.createCheckArgsForCall)]
+	fake.createCheckArgsForCall = append(fake.createCheckArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("CreateCheck", []interface{}{arg1})
+	fake.createCheckMutex.Unlock()
+	if fake.CreateCheckStub != nil {
+		return fake.CreateCheckStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createCheckReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) CreateCheckCallCount() int {
+	fake.createCheckMutex.RLock()
+	defer fake.createCheckMutex.RUnlock()
+	return len(fake.createCheckArgsForCall)
+}
+
+func (fake *FakeResource) CreateCheckCalls(stub func(string) (db.Check, error)) {
+	fake.createCheckMutex.Lock()
+	defer fake.createCheckMutex.Unlock()
+	fake.CreateCheckStub = stub
+}
+
+func (fake *FakeResource) CreateCheckArgsForCall(i int) string {
+	fake.createCheckMutex.RLock()
+	defer fake.createCheckMutex.RUnlock()
+	argsForCall := fake.createCheckArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) CreateCheckReturns(result1 db.Check, result2 error) {
+	fake.createCheckMutex.Lock()
+	defer fake.createCheckMutex.Unlock()
+	fake.CreateCheckStub = nil
+	fake.createCheckReturns = struct {
+		result1 db.Check
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) CreateCheckReturnsOnCall(i int, result1 db.Check, result2 error) {
+	fake.createCheckMutex.Lock()
+	defer fake.createCheckMutex.Unlock()
+	fake.CreateCheckStub = nil
+	if fake.createCheckReturnsOnCall == nil {
+		fake.createCheckReturnsOnCall = make(map[int]struct {
+			result1 db.Check
+			result2 error
+		})
+	}
+	fake.createCheckReturnsOnCall[i] = struct {
+		result1 db.Check
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) CreateRateLimitedCheck(arg1 string) error {
+	fake.createRateLimitedCheckMutex.Lock()
+	ret, specificReturn := fake.createRateLimitedCheckReturnsOnCall[len(fake.createRateLimitedCheckArgsForCall)]
+	fake.createRateLimitedCheckArgsForCall = append(fake.createRateLimitedCheckArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("CreateRateLimitedCheck", []interface{}{arg1})
+	fake.createRateLimitedCheckMutex.Unlock()
+	if fake.CreateRateLimitedCheckStub != nil {
+		return fake.CreateRateLimitedCheckStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createRateLimitedCheckReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) CreateRateLimitedCheckCallCount() int {
+	fake.createRateLimitedCheckMutex.RLock()
+	defer fake.createRateLimitedCheckMutex.RUnlock()
+	return len(fake.createRateLimitedCheckArgsForCall)
+}
+
+func (fake *FakeResource) CreateRateLimitedCheckCalls(stub func(string) error) {
+	fake.createRateLimitedCheckMutex.Lock()
+	defer fake.createRateLimitedCheckMutex.Unlock()
+	fake.CreateRateLimitedCheckStub = stub
+}
+
+func (fake *FakeResource) CreateRateLimitedCheckArgsForCall(i int) string {
+	fake.createRateLimitedCheckMutex.RLock()
+	defer fake.createRateLimitedCheckMutex.RUnlock()
+	argsForCall := fake.createRateLimitedCheckArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) CreateRateLimitedCheckReturns(result1 error) {
+	fake.createRateLimitedCheckMutex.Lock()
+	defer fake.createRateLimitedCheckMutex.Unlock()
+	fake.CreateRateLimitedCheckStub = nil
+	fake.createRateLimitedCheckReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) CreateRateLimitedCheckReturnsOnCall(i int, result1 error) {
+	fake.createRateLimitedCheckMutex.Lock()
+	defer fake.createRateLimitedCheckMutex.Unlock()
+	fake.CreateRateLimitedCheckStub = nil
+	if fake.createRateLimitedCheckReturnsOnCall == nil {
+		fake.createRateLimitedCheckReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createRateLimitedCheckReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) CurrentPinnedVersion() atc.Version {
 	fake.currentPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.currentPinnedVersionReturnsOnCall[len(fake.currentPinnedVersionArgsForCall)]
@@ -720,16 +1094,17 @@ func (fake *FakeResource) CurrentPinnedVersionReturnsOnCall(i int, result1 atc.V
 	}{result1}
 }
 
-func (fake *FakeResource) DisableVersion(arg1 int) error {
+func (fake *FakeResource) DisableVersion(arg1 int, arg2 db.VersionAnnotation) error {
 	fake.disableVersionMutex.Lock()
 	ret, specificReturn := fake.disableVersionReturnsOnCall[len(fake.disableVersionArgsForCall)]
 	fake.disableVersionArgsForCall = append(fake.disableVersionArgsForCall, struct {
 		arg1 int
-	}{arg1})
-	fake.recordInvocation("DisableVersion", []interface{}{arg1})
+		arg2 db.VersionAnnotation
+	}{arg1, arg2})
+	fake.recordInvocation("DisableVersion", []interface{}{arg1, arg2})
 	fake.disableVersionMutex.Unlock()
 	if fake.DisableVersionStub != nil {
-		return fake.DisableVersionStub(arg1)
+		return fake.DisableVersionStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -744,17 +1119,17 @@ func (fake *FakeResource) DisableVersionCallCount() int {
 	return len(fake.disableVersionArgsForCall)
 }
 
-func (fake *FakeResource) DisableVersionCalls(stub func(int) error) {
+func (fake *FakeResource) DisableVersionCalls(stub func(int, db.VersionAnnotation) error) {
 	fake.disableVersionMutex.Lock()
 	defer fake.disableVersionMutex.Unlock()
 	fake.DisableVersionStub = stub
 }
 
-func (fake *FakeResource) DisableVersionArgsForCall(i int) int {
+func (fake *FakeResource) DisableVersionArgsForCall(i int) (int, db.VersionAnnotation) {
 	fake.disableVersionMutex.RLock()
 	defer fake.disableVersionMutex.RUnlock()
 	argsForCall := fake.disableVersionArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeResource) DisableVersionReturns(result1 error) {
@@ -780,16 +1155,17 @@ func (fake *FakeResource) DisableVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeResource) EnableVersion(arg1 int) error {
+func (fake *FakeResource) EnableVersion(arg1 int, arg2 db.VersionAnnotation) error {
 	fake.enableVersionMutex.Lock()
 	ret, specificReturn := fake.enableVersionReturnsOnCall[len(fake.enableVersionArgsForCall)]
 	fake.enableVersionArgsForCall = append(fake.enableVersionArgsForCall, struct {
 		arg1 int
-	}{arg1})
-	fake.recordInvocation("EnableVersion", []interface{}{arg1})
+		arg2 db.VersionAnnotation
+	}{arg1, arg2})
+	fake.recordInvocation("EnableVersion", []interface{}{arg1, arg2})
 	fake.enableVersionMutex.Unlock()
 	if fake.EnableVersionStub != nil {
-		return fake.EnableVersionStub(arg1)
+		return fake.EnableVersionStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -804,17 +1180,17 @@ func (fake *FakeResource) EnableVersionCallCount() int {
 	return len(fake.enableVersionArgsForCall)
 }
 
-func (fake *FakeResource) EnableVersionCalls(stub func(int) error) {
+func (fake *FakeResource) EnableVersionCalls(stub func(int, db.VersionAnnotation) error) {
 	fake.enableVersionMutex.Lock()
 	defer fake.enableVersionMutex.Unlock()
 	fake.EnableVersionStub = stub
 }
 
-func (fake *FakeResource) EnableVersionArgsForCall(i int) int {
+func (fake *FakeResource) EnableVersionArgsForCall(i int) (int, db.VersionAnnotation) {
 	fake.enableVersionMutex.RLock()
 	defer fake.enableVersionMutex.RUnlock()
 	argsForCall := fake.enableVersionArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeResource) EnableVersionReturns(result1 error) {
@@ -840,6 +1216,61 @@ func (fake *FakeResource) EnableVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) EnabledVersions() (map[int]atc.Version, error) {
+	fake.enabledVersionsMutex.Lock()
+	ret, specificReturn := fake.enabledVersionsReturnsOnCall[len(fake.enabledVersionsArgsForCall)]
+	fake.enabledVersionsArgsForCall = append(fake.enabledVersionsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EnabledVersions", []interface{}{})
+	fake.enabledVersionsMutex.Unlock()
+	if fake.EnabledVersionsStub != nil {
+		return fake.EnabledVersionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.enabledVersionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) EnabledVersionsCallCount() int {
+	fake.enabledVersionsMutex.RLock()
+	defer fake.enabledVersionsMutex.RUnlock()
+	return len(fake.enabledVersionsArgsForCall)
+}
+
+func (fake *FakeResource) EnabledVersionsCalls(stub func() (map[int]atc.Version, error)) {
+	fake.enabledVersionsMutex.Lock()
+	defer fake.enabledVersionsMutex.Unlock()
+	fake.EnabledVersionsStub = stub
+}
+
+func (fake *FakeResource) EnabledVersionsReturns(result1 map[int]atc.Version, result2 error) {
+	fake.enabledVersionsMutex.Lock()
+	defer fake.enabledVersionsMutex.Unlock()
+	fake.EnabledVersionsStub = nil
+	fake.enabledVersionsReturns = struct {
+		result1 map[int]atc.Version
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) EnabledVersionsReturnsOnCall(i int, result1 map[int]atc.Version, result2 error) {
+	fake.enabledVersionsMutex.Lock()
+	defer fake.enabledVersionsMutex.Unlock()
+	fake.EnabledVersionsStub = nil
+	if fake.enabledVersionsReturnsOnCall == nil {
+		fake.enabledVersionsReturnsOnCall = make(map[int]struct {
+			result1 map[int]atc.Version
+			result2 error
+		})
+	}
+	fake.enabledVersionsReturnsOnCall[i] = struct {
+		result1 map[int]atc.Version
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) ID() int {
 	fake.iDMutex.Lock()
 	ret, specificReturn := fake.iDReturnsOnCall[len(fake.iDArgsForCall)]
@@ -892,6 +1323,70 @@ func (fake *FakeResource) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeResource) InjectVersion(arg1 atc.Version, arg2 db.ResourceConfigMetadataFields) (bool, error) {
+	fake.injectVersionMutex.Lock()
+	ret, specificReturn := fake.injectVersionReturnsOnCall[len(fake.injectVersionArgsForCall)]
+	fake.injectVersionArgsForCall = append(fake.injectVersionArgsForCall, struct {
+		arg1 atc.Version
+		arg2 db.ResourceConfigMetadataFields
+	}{arg1, arg2})
+	fake.recordInvocation("InjectVersion", []interface{}{arg1, arg2})
+	fake.injectVersionMutex.Unlock()
+	if fake.InjectVersionStub != nil {
+		return fake.InjectVersionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.injectVersionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResource) InjectVersionCallCount() int {
+	fake.injectVersionMutex.RLock()
+	defer fake.injectVersionMutex.RUnlock()
+	return len(fake.injectVersionArgsForCall)
+}
+
+func (fake *FakeResource) InjectVersionCalls(stub func(atc.Version, db.ResourceConfigMetadataFields) (bool, error)) {
+	fake.injectVersionMutex.Lock()
+	defer fake.injectVersionMutex.Unlock()
+	fake.InjectVersionStub = stub
+}
+
+func (fake *FakeResource) InjectVersionArgsForCall(i int) (atc.Version, db.ResourceConfigMetadataFields) {
+	fake.injectVersionMutex.RLock()
+	defer fake.injectVersionMutex.RUnlock()
+	argsForCall := fake.injectVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) InjectVersionReturns(result1 bool, result2 error) {
+	fake.injectVersionMutex.Lock()
+	defer fake.injectVersionMutex.Unlock()
+	fake.InjectVersionStub = nil
+	fake.injectVersionReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) InjectVersionReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.injectVersionMutex.Lock()
+	defer fake.injectVersionMutex.Unlock()
+	fake.InjectVersionStub = nil
+	if fake.injectVersionReturnsOnCall == nil {
+		fake.injectVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.injectVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResource) LastChecked() time.Time {
 	fake.lastCheckedMutex.Lock()
 	ret, specificReturn := fake.lastCheckedReturnsOnCall[len(fake.lastCheckedArgsForCall)]
@@ -1048,16 +1543,17 @@ func (fake *FakeResource) PinCommentReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
-func (fake *FakeResource) PinVersion(arg1 int) error {
+func (fake *FakeResource) PinVersion(arg1 int, arg2 db.VersionAnnotation) error {
 	fake.pinVersionMutex.Lock()
 	ret, specificReturn := fake.pinVersionReturnsOnCall[len(fake.pinVersionArgsForCall)]
 	fake.pinVersionArgsForCall = append(fake.pinVersionArgsForCall, struct {
 		arg1 int
-	}{arg1})
-	fake.recordInvocation("PinVersion", []interface{}{arg1})
+		arg2 db.VersionAnnotation
+	}{arg1, arg2})
+	fake.recordInvocation("PinVersion", []interface{}{arg1, arg2})
 	fake.pinVersionMutex.Unlock()
 	if fake.PinVersionStub != nil {
-		return fake.PinVersionStub(arg1)
+		return fake.PinVersionStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -1072,17 +1568,17 @@ func (fake *FakeResource) PinVersionCallCount() int {
 	return len(fake.pinVersionArgsForCall)
 }
 
-func (fake *FakeResource) PinVersionCalls(stub func(int) error) {
+func (fake *FakeResource) PinVersionCalls(stub func(int, db.VersionAnnotation) error) {
 	fake.pinVersionMutex.Lock()
 	defer fake.pinVersionMutex.Unlock()
 	fake.PinVersionStub = stub
 }
 
-func (fake *FakeResource) PinVersionArgsForCall(i int) int {
+func (fake *FakeResource) PinVersionArgsForCall(i int) (int, db.VersionAnnotation) {
 	fake.pinVersionMutex.RLock()
 	defer fake.pinVersionMutex.RUnlock()
 	argsForCall := fake.pinVersionArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeResource) PinVersionReturns(result1 error) {
@@ -1212,6 +1708,58 @@ func (fake *FakeResource) PipelineNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) RateLimited() bool {
+	fake.rateLimitedMutex.Lock()
+	ret, specificReturn := fake.rateLimitedReturnsOnCall[len(fake.rateLimitedArgsForCall)]
+	fake.rateLimitedArgsForCall = append(fake.rateLimitedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RateLimited", []interface{}{})
+	fake.rateLimitedMutex.Unlock()
+	if fake.RateLimitedStub != nil {
+		return fake.RateLimitedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rateLimitedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) RateLimitedCallCount() int {
+	fake.rateLimitedMutex.RLock()
+	defer fake.rateLimitedMutex.RUnlock()
+	return len(fake.rateLimitedArgsForCall)
+}
+
+func (fake *FakeResource) RateLimitedCalls(stub func() bool) {
+	fake.rateLimitedMutex.Lock()
+	defer fake.rateLimitedMutex.Unlock()
+	fake.RateLimitedStub = stub
+}
+
+func (fake *FakeResource) RateLimitedReturns(result1 bool) {
+	fake.rateLimitedMutex.Lock()
+	defer fake.rateLimitedMutex.Unlock()
+	fake.RateLimitedStub = nil
+	fake.rateLimitedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeResource) RateLimitedReturnsOnCall(i int, result1 bool) {
+	fake.rateLimitedMutex.Lock()
+	defer fake.rateLimitedMutex.Unlock()
+	fake.RateLimitedStub = nil
+	if fake.rateLimitedReturnsOnCall == nil {
+		fake.rateLimitedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.rateLimitedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeResource) Reload() (bool, error) {
 	fake.reloadMutex.Lock()
 	ret, specificReturn := fake.reloadReturnsOnCall[len(fake.reloadArgsForCall)]
@@ -1740,6 +2288,58 @@ func (fake *FakeResource) SourceReturnsOnCall(i int, result1 atc.Source) {
 	}{result1}
 }
 
+func (fake *FakeResource) SourceWithDefaults() atc.Source {
+	fake.sourceWithDefaultsMutex.Lock()
+	ret, specificReturn := fake.sourceWithDefaultsReturnsOnCall[len(fake.sourceWithDefaultsArgsForCall)]
+	fake.sourceWithDefaultsArgsForCall = append(fake.sourceWithDefaultsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SourceWithDefaults", []interface{}{})
+	fake.sourceWithDefaultsMutex.Unlock()
+	if fake.SourceWithDefaultsStub != nil {
+		return fake.SourceWithDefaultsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sourceWithDefaultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) SourceWithDefaultsCallCount() int {
+	fake.sourceWithDefaultsMutex.RLock()
+	defer fake.sourceWithDefaultsMutex.RUnlock()
+	return len(fake.sourceWithDefaultsArgsForCall)
+}
+
+func (fake *FakeResource) SourceWithDefaultsCalls(stub func() atc.Source) {
+	fake.sourceWithDefaultsMutex.Lock()
+	defer fake.sourceWithDefaultsMutex.Unlock()
+	fake.SourceWithDefaultsStub = stub
+}
+
+func (fake *FakeResource) SourceWithDefaultsReturns(result1 atc.Source) {
+	fake.sourceWithDefaultsMutex.Lock()
+	defer fake.sourceWithDefaultsMutex.Unlock()
+	fake.SourceWithDefaultsStub = nil
+	fake.sourceWithDefaultsReturns = struct {
+		result1 atc.Source
+	}{result1}
+}
+
+func (fake *FakeResource) SourceWithDefaultsReturnsOnCall(i int, result1 atc.Source) {
+	fake.sourceWithDefaultsMutex.Lock()
+	defer fake.sourceWithDefaultsMutex.Unlock()
+	fake.SourceWithDefaultsStub = nil
+	if fake.sourceWithDefaultsReturnsOnCall == nil {
+		fake.sourceWithDefaultsReturnsOnCall = make(map[int]struct {
+			result1 atc.Source
+		})
+	}
+	fake.sourceWithDefaultsReturnsOnCall[i] = struct {
+		result1 atc.Source
+	}{result1}
+}
+
 func (fake *FakeResource) Tags() atc.Tags {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -1948,6 +2548,58 @@ func (fake *FakeResource) UnpinVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) VersionHistory() *atc.VersionHistoryConfig {
+	fake.versionHistoryMutex.Lock()
+	ret, specificReturn := fake.versionHistoryReturnsOnCall[len(fake.versionHistoryArgsForCall)]
+	fake.versionHistoryArgsForCall = append(fake.versionHistoryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("VersionHistory", []interface{}{})
+	fake.versionHistoryMutex.Unlock()
+	if fake.VersionHistoryStub != nil {
+		return fake.VersionHistoryStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.versionHistoryReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) VersionHistoryCallCount() int {
+	fake.versionHistoryMutex.RLock()
+	defer fake.versionHistoryMutex.RUnlock()
+	return len(fake.versionHistoryArgsForCall)
+}
+
+func (fake *FakeResource) VersionHistoryCalls(stub func() *atc.VersionHistoryConfig) {
+	fake.versionHistoryMutex.Lock()
+	defer fake.versionHistoryMutex.Unlock()
+	fake.VersionHistoryStub = stub
+}
+
+func (fake *FakeResource) VersionHistoryReturns(result1 *atc.VersionHistoryConfig) {
+	fake.versionHistoryMutex.Lock()
+	defer fake.versionHistoryMutex.Unlock()
+	fake.VersionHistoryStub = nil
+	fake.versionHistoryReturns = struct {
+		result1 *atc.VersionHistoryConfig
+	}{result1}
+}
+
+func (fake *FakeResource) VersionHistoryReturnsOnCall(i int, result1 *atc.VersionHistoryConfig) {
+	fake.versionHistoryMutex.Lock()
+	defer fake.versionHistoryMutex.Unlock()
+	fake.VersionHistoryStub = nil
+	if fake.versionHistoryReturnsOnCall == nil {
+		fake.versionHistoryReturnsOnCall = make(map[int]struct {
+			result1 *atc.VersionHistoryConfig
+		})
+	}
+	fake.versionHistoryReturnsOnCall[i] = struct {
+		result1 *atc.VersionHistoryConfig
+	}{result1}
+}
+
 func (fake *FakeResource) Versions(arg1 db.Page) ([]atc.ResourceVersion, db.Pagination, bool, error) {
 	fake.versionsMutex.Lock()
 	ret, specificReturn := fake.versionsReturnsOnCall[len(fake.versionsArgsForCall)]
@@ -2017,6 +2669,58 @@ func (fake *FakeResource) VersionsReturnsOnCall(i int, result1 []atc.ResourceVer
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeResource) Webhook() *atc.WebhookConfig {
+	fake.webhookMutex.Lock()
+	ret, specificReturn := fake.webhookReturnsOnCall[len(fake.webhookArgsForCall)]
+	fake.webhookArgsForCall = append(fake.webhookArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Webhook", []interface{}{})
+	fake.webhookMutex.Unlock()
+	if fake.WebhookStub != nil {
+		return fake.WebhookStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.webhookReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) WebhookCallCount() int {
+	fake.webhookMutex.RLock()
+	defer fake.webhookMutex.RUnlock()
+	return len(fake.webhookArgsForCall)
+}
+
+func (fake *FakeResource) WebhookCalls(stub func() *atc.WebhookConfig) {
+	fake.webhookMutex.Lock()
+	defer fake.webhookMutex.Unlock()
+	fake.WebhookStub = stub
+}
+
+func (fake *FakeResource) WebhookReturns(result1 *atc.WebhookConfig) {
+	fake.webhookMutex.Lock()
+	defer fake.webhookMutex.Unlock()
+	fake.WebhookStub = nil
+	fake.webhookReturns = struct {
+		result1 *atc.WebhookConfig
+	}{result1}
+}
+
+func (fake *FakeResource) WebhookReturnsOnCall(i int, result1 *atc.WebhookConfig) {
+	fake.webhookMutex.Lock()
+	defer fake.webhookMutex.Unlock()
+	fake.WebhookStub = nil
+	if fake.webhookReturnsOnCall == nil {
+		fake.webhookReturnsOnCall = make(map[int]struct {
+			result1 *atc.WebhookConfig
+		})
+	}
+	fake.webhookReturnsOnCall[i] = struct {
+		result1 *atc.WebhookConfig
+	}{result1}
+}
+
 func (fake *FakeResource) WebhookToken() string {
 	fake.webhookTokenMutex.Lock()
 	ret, specificReturn := fake.webhookTokenReturnsOnCall[len(fake.webhookTokenArgsForCall)]
@@ -2069,11 +2773,65 @@ func (fake *FakeResource) WebhookTokenReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) WorkerSelector() atc.WorkerSelector {
+	fake.workerSelectorMutex.Lock()
+	ret, specificReturn := fake.workerSelectorReturnsOnCall[len(fake.workerSelectorArgsForCall)]
+	fake.workerSelectorArgsForCall = append(fake.workerSelectorArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WorkerSelector", []interface{}{})
+	fake.workerSelectorMutex.Unlock()
+	if fake.WorkerSelectorStub != nil {
+		return fake.WorkerSelectorStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.workerSelectorReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) WorkerSelectorCallCount() int {
+	fake.workerSelectorMutex.RLock()
+	defer fake.workerSelectorMutex.RUnlock()
+	return len(fake.workerSelectorArgsForCall)
+}
+
+func (fake *FakeResource) WorkerSelectorCalls(stub func() atc.WorkerSelector) {
+	fake.workerSelectorMutex.Lock()
+	defer fake.workerSelectorMutex.Unlock()
+	fake.WorkerSelectorStub = stub
+}
+
+func (fake *FakeResource) WorkerSelectorReturns(result1 atc.WorkerSelector) {
+	fake.workerSelectorMutex.Lock()
+	defer fake.workerSelectorMutex.Unlock()
+	fake.WorkerSelectorStub = nil
+	fake.workerSelectorReturns = struct {
+		result1 atc.WorkerSelector
+	}{result1}
+}
+
+func (fake *FakeResource) WorkerSelectorReturnsOnCall(i int, result1 atc.WorkerSelector) {
+	fake.workerSelectorMutex.Lock()
+	defer fake.workerSelectorMutex.Unlock()
+	fake.WorkerSelectorStub = nil
+	if fake.workerSelectorReturnsOnCall == nil {
+		fake.workerSelectorReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerSelector
+		})
+	}
+	fake.workerSelectorReturnsOnCall[i] = struct {
+		result1 atc.WorkerSelector
+	}{result1}
+}
+
 func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.aPIPinnedVersionMutex.RLock()
 	defer fake.aPIPinnedVersionMutex.RUnlock()
+	fake.checkMutex.RLock()
+	defer fake.checkMutex.RUnlock()
 	fake.checkErrorMutex.RLock()
 	defer fake.checkErrorMutex.RUnlock()
 	fake.checkEveryMutex.RLock()
@@ -2082,16 +2840,26 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.checkSetupErrorMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
+	fake.checksMutex.RLock()
+	defer fake.checksMutex.RUnlock()
 	fake.configPinnedVersionMutex.RLock()
 	defer fake.configPinnedVersionMutex.RUnlock()
+	fake.createCheckMutex.RLock()
+	defer fake.createCheckMutex.RUnlock()
+	fake.createRateLimitedCheckMutex.RLock()
+	defer fake.createRateLimitedCheckMutex.RUnlock()
 	fake.currentPinnedVersionMutex.RLock()
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.disableVersionMutex.RLock()
 	defer fake.disableVersionMutex.RUnlock()
 	fake.enableVersionMutex.RLock()
 	defer fake.enableVersionMutex.RUnlock()
+	fake.enabledVersionsMutex.RLock()
+	defer fake.enabledVersionsMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.injectVersionMutex.RLock()
+	defer fake.injectVersionMutex.RUnlock()
 	fake.lastCheckedMutex.RLock()
 	defer fake.lastCheckedMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -2104,6 +2872,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.pipelineIDMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
+	fake.rateLimitedMutex.RLock()
+	defer fake.rateLimitedMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
 	fake.resourceConfigIDMutex.RLock()
@@ -2122,6 +2892,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.setResourceConfigMutex.RUnlock()
 	fake.sourceMutex.RLock()
 	defer fake.sourceMutex.RUnlock()
+	fake.sourceWithDefaultsMutex.RLock()
+	defer fake.sourceWithDefaultsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamNameMutex.RLock()
@@ -2130,10 +2902,16 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.typeMutex.RUnlock()
 	fake.unpinVersionMutex.RLock()
 	defer fake.unpinVersionMutex.RUnlock()
+	fake.versionHistoryMutex.RLock()
+	defer fake.versionHistoryMutex.RUnlock()
 	fake.versionsMutex.RLock()
 	defer fake.versionsMutex.RUnlock()
+	fake.webhookMutex.RLock()
+	defer fake.webhookMutex.RUnlock()
 	fake.webhookTokenMutex.RLock()
 	defer fake.webhookTokenMutex.RUnlock()
+	fake.workerSelectorMutex.RLock()
+	defer fake.workerSelectorMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
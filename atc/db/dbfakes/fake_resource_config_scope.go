@@ -28,6 +28,19 @@ type FakeResourceConfigScope struct {
 		result2 bool
 		result3 error
 	}
+	CheckDueStub        func(time.Duration) (bool, error)
+	checkDueMutex       sync.RWMutex
+	checkDueArgsForCall []struct {
+		arg1 time.Duration
+	}
+	checkDueReturns struct {
+		result1 bool
+		result2 error
+	}
+	checkDueReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	CheckErrorStub        func() error
 	checkErrorMutex       sync.RWMutex
 	checkErrorArgsForCall []struct {
@@ -204,6 +217,69 @@ func (fake *FakeResourceConfigScope) AcquireResourceCheckingLockReturnsOnCall(i
 	}{result1, result2, result3}
 }
 
+func (fake *FakeResourceConfigScope) CheckDue(arg1 time.Duration) (bool, error) {
+	fake.checkDueMutex.Lock()
+	ret, specificReturn := fake.checkDueReturnsOnCall[len(fake.checkDueArgsForCall)]
+	fake.checkDueArgsForCall = append(fake.checkDueArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("CheckDue", []interface{}{arg1})
+	fake.checkDueMutex.Unlock()
+	if fake.CheckDueStub != nil {
+		return fake.CheckDueStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.checkDueReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceConfigScope) CheckDueCallCount() int {
+	fake.checkDueMutex.RLock()
+	defer fake.checkDueMutex.RUnlock()
+	return len(fake.checkDueArgsForCall)
+}
+
+func (fake *FakeResourceConfigScope) CheckDueCalls(stub func(time.Duration) (bool, error)) {
+	fake.checkDueMutex.Lock()
+	defer fake.checkDueMutex.Unlock()
+	fake.CheckDueStub = stub
+}
+
+func (fake *FakeResourceConfigScope) CheckDueArgsForCall(i int) time.Duration {
+	fake.checkDueMutex.RLock()
+	defer fake.checkDueMutex.RUnlock()
+	argsForCall := fake.checkDueArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResourceConfigScope) CheckDueReturns(result1 bool, result2 error) {
+	fake.checkDueMutex.Lock()
+	defer fake.checkDueMutex.Unlock()
+	fake.CheckDueStub = nil
+	fake.checkDueReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceConfigScope) CheckDueReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.checkDueMutex.Lock()
+	defer fake.checkDueMutex.Unlock()
+	fake.CheckDueStub = nil
+	if fake.checkDueReturnsOnCall == nil {
+		fake.checkDueReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.checkDueReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceConfigScope) CheckError() error {
 	fake.checkErrorMutex.Lock()
 	ret, specificReturn := fake.checkErrorReturnsOnCall[len(fake.checkErrorArgsForCall)]
@@ -730,6 +806,8 @@ func (fake *FakeResourceConfigScope) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.acquireResourceCheckingLockMutex.RLock()
 	defer fake.acquireResourceCheckingLockMutex.RUnlock()
+	fake.checkDueMutex.RLock()
+	defer fake.checkDueMutex.RUnlock()
 	fake.checkErrorMutex.RLock()
 	defer fake.checkErrorMutex.RUnlock()
 	fake.findVersionMutex.RLock()
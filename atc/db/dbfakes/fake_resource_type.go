@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	sync "sync"
+	time "time"
 
 	lager "code.cloudfoundry.org/lager"
 	atc "github.com/concourse/concourse/atc"
@@ -51,6 +52,16 @@ type FakeResourceType struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	LastCheckedStub        func() time.Time
+	lastCheckedMutex       sync.RWMutex
+	lastCheckedArgsForCall []struct {
+	}
+	lastCheckedReturns struct {
+		result1 time.Time
+	}
+	lastCheckedReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -71,6 +82,16 @@ type FakeResourceType struct {
 	paramsReturnsOnCall map[int]struct {
 		result1 atc.Params
 	}
+	PipelineIDStub        func() int
+	pipelineIDMutex       sync.RWMutex
+	pipelineIDArgsForCall []struct {
+	}
+	pipelineIDReturns struct {
+		result1 int
+	}
+	pipelineIDReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PrivilegedStub        func() bool
 	privilegedMutex       sync.RWMutex
 	privilegedArgsForCall []struct {
@@ -129,6 +150,16 @@ type FakeResourceType struct {
 	sourceReturnsOnCall map[int]struct {
 		result1 atc.Source
 	}
+	SourceWithDefaultsStub        func() atc.Source
+	sourceWithDefaultsMutex       sync.RWMutex
+	sourceWithDefaultsArgsForCall []struct {
+	}
+	sourceWithDefaultsReturns struct {
+		result1 atc.Source
+	}
+	sourceWithDefaultsReturnsOnCall map[int]struct {
+		result1 atc.Source
+	}
 	TagsStub        func() atc.Tags
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -169,6 +200,16 @@ type FakeResourceType struct {
 	versionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	WorkerSelectorStub        func() atc.WorkerSelector
+	workerSelectorMutex       sync.RWMutex
+	workerSelectorArgsForCall []struct {
+	}
+	workerSelectorReturns struct {
+		result1 atc.WorkerSelector
+	}
+	workerSelectorReturnsOnCall map[int]struct {
+		result1 atc.WorkerSelector
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -381,6 +422,58 @@ func (fake *FakeResourceType) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) LastChecked() time.Time {
+	fake.lastCheckedMutex.Lock()
+	ret, specificReturn := fake.lastCheckedReturnsOnCall[len(fake.lastCheckedArgsForCall)]
+	fake.lastCheckedArgsForCall = append(fake.lastCheckedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LastChecked", []interface{}{})
+	fake.lastCheckedMutex.Unlock()
+	if fake.LastCheckedStub != nil {
+		return fake.LastCheckedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lastCheckedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) LastCheckedCallCount() int {
+	fake.lastCheckedMutex.RLock()
+	defer fake.lastCheckedMutex.RUnlock()
+	return len(fake.lastCheckedArgsForCall)
+}
+
+func (fake *FakeResourceType) LastCheckedCalls(stub func() time.Time) {
+	fake.lastCheckedMutex.Lock()
+	defer fake.lastCheckedMutex.Unlock()
+	fake.LastCheckedStub = stub
+}
+
+func (fake *FakeResourceType) LastCheckedReturns(result1 time.Time) {
+	fake.lastCheckedMutex.Lock()
+	defer fake.lastCheckedMutex.Unlock()
+	fake.LastCheckedStub = nil
+	fake.lastCheckedReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResourceType) LastCheckedReturnsOnCall(i int, result1 time.Time) {
+	fake.lastCheckedMutex.Lock()
+	defer fake.lastCheckedMutex.Unlock()
+	fake.LastCheckedStub = nil
+	if fake.lastCheckedReturnsOnCall == nil {
+		fake.lastCheckedReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.lastCheckedReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeResourceType) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -485,6 +578,58 @@ func (fake *FakeResourceType) ParamsReturnsOnCall(i int, result1 atc.Params) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) PipelineID() int {
+	fake.pipelineIDMutex.Lock()
+	ret, specificReturn := fake.pipelineIDReturnsOnCall[len(fake.pipelineIDArgsForCall)]
+	fake.pipelineIDArgsForCall = append(fake.pipelineIDArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineID", []interface{}{})
+	fake.pipelineIDMutex.Unlock()
+	if fake.PipelineIDStub != nil {
+		return fake.PipelineIDStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineIDReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) PipelineIDCallCount() int {
+	fake.pipelineIDMutex.RLock()
+	defer fake.pipelineIDMutex.RUnlock()
+	return len(fake.pipelineIDArgsForCall)
+}
+
+func (fake *FakeResourceType) PipelineIDCalls(stub func() int) {
+	fake.pipelineIDMutex.Lock()
+	defer fake.pipelineIDMutex.Unlock()
+	fake.PipelineIDStub = stub
+}
+
+func (fake *FakeResourceType) PipelineIDReturns(result1 int) {
+	fake.pipelineIDMutex.Lock()
+	defer fake.pipelineIDMutex.Unlock()
+	fake.PipelineIDStub = nil
+	fake.pipelineIDReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResourceType) PipelineIDReturnsOnCall(i int, result1 int) {
+	fake.pipelineIDMutex.Lock()
+	defer fake.pipelineIDMutex.Unlock()
+	fake.PipelineIDStub = nil
+	if fake.pipelineIDReturnsOnCall == nil {
+		fake.pipelineIDReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.pipelineIDReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeResourceType) Privileged() bool {
 	fake.privilegedMutex.Lock()
 	ret, specificReturn := fake.privilegedReturnsOnCall[len(fake.privilegedArgsForCall)]
@@ -769,6 +914,58 @@ func (fake *FakeResourceType) SourceReturnsOnCall(i int, result1 atc.Source) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) SourceWithDefaults() atc.Source {
+	fake.sourceWithDefaultsMutex.Lock()
+	ret, specificReturn := fake.sourceWithDefaultsReturnsOnCall[len(fake.sourceWithDefaultsArgsForCall)]
+	fake.sourceWithDefaultsArgsForCall = append(fake.sourceWithDefaultsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SourceWithDefaults", []interface{}{})
+	fake.sourceWithDefaultsMutex.Unlock()
+	if fake.SourceWithDefaultsStub != nil {
+		return fake.SourceWithDefaultsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.sourceWithDefaultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) SourceWithDefaultsCallCount() int {
+	fake.sourceWithDefaultsMutex.RLock()
+	defer fake.sourceWithDefaultsMutex.RUnlock()
+	return len(fake.sourceWithDefaultsArgsForCall)
+}
+
+func (fake *FakeResourceType) SourceWithDefaultsCalls(stub func() atc.Source) {
+	fake.sourceWithDefaultsMutex.Lock()
+	defer fake.sourceWithDefaultsMutex.Unlock()
+	fake.SourceWithDefaultsStub = stub
+}
+
+func (fake *FakeResourceType) SourceWithDefaultsReturns(result1 atc.Source) {
+	fake.sourceWithDefaultsMutex.Lock()
+	defer fake.sourceWithDefaultsMutex.Unlock()
+	fake.SourceWithDefaultsStub = nil
+	fake.sourceWithDefaultsReturns = struct {
+		result1 atc.Source
+	}{result1}
+}
+
+func (fake *FakeResourceType) SourceWithDefaultsReturnsOnCall(i int, result1 atc.Source) {
+	fake.sourceWithDefaultsMutex.Lock()
+	defer fake.sourceWithDefaultsMutex.Unlock()
+	fake.SourceWithDefaultsStub = nil
+	if fake.sourceWithDefaultsReturnsOnCall == nil {
+		fake.sourceWithDefaultsReturnsOnCall = make(map[int]struct {
+			result1 atc.Source
+		})
+	}
+	fake.sourceWithDefaultsReturnsOnCall[i] = struct {
+		result1 atc.Source
+	}{result1}
+}
+
 func (fake *FakeResourceType) Tags() atc.Tags {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -977,6 +1174,58 @@ func (fake *FakeResourceType) VersionReturnsOnCall(i int, result1 atc.Version) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) WorkerSelector() atc.WorkerSelector {
+	fake.workerSelectorMutex.Lock()
+	ret, specificReturn := fake.workerSelectorReturnsOnCall[len(fake.workerSelectorArgsForCall)]
+	fake.workerSelectorArgsForCall = append(fake.workerSelectorArgsForCall, struct {
+	}{})
+	fake.recordInvocation("WorkerSelector", []interface{}{})
+	fake.workerSelectorMutex.Unlock()
+	if fake.WorkerSelectorStub != nil {
+		return fake.WorkerSelectorStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.workerSelectorReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) WorkerSelectorCallCount() int {
+	fake.workerSelectorMutex.RLock()
+	defer fake.workerSelectorMutex.RUnlock()
+	return len(fake.workerSelectorArgsForCall)
+}
+
+func (fake *FakeResourceType) WorkerSelectorCalls(stub func() atc.WorkerSelector) {
+	fake.workerSelectorMutex.Lock()
+	defer fake.workerSelectorMutex.Unlock()
+	fake.WorkerSelectorStub = stub
+}
+
+func (fake *FakeResourceType) WorkerSelectorReturns(result1 atc.WorkerSelector) {
+	fake.workerSelectorMutex.Lock()
+	defer fake.workerSelectorMutex.Unlock()
+	fake.WorkerSelectorStub = nil
+	fake.workerSelectorReturns = struct {
+		result1 atc.WorkerSelector
+	}{result1}
+}
+
+func (fake *FakeResourceType) WorkerSelectorReturnsOnCall(i int, result1 atc.WorkerSelector) {
+	fake.workerSelectorMutex.Lock()
+	defer fake.workerSelectorMutex.Unlock()
+	fake.WorkerSelectorStub = nil
+	if fake.workerSelectorReturnsOnCall == nil {
+		fake.workerSelectorReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerSelector
+		})
+	}
+	fake.workerSelectorReturnsOnCall[i] = struct {
+		result1 atc.WorkerSelector
+	}{result1}
+}
+
 func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -988,10 +1237,14 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.checkSetupErrorMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.lastCheckedMutex.RLock()
+	defer fake.lastCheckedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.paramsMutex.RLock()
 	defer fake.paramsMutex.RUnlock()
+	fake.pipelineIDMutex.RLock()
+	defer fake.pipelineIDMutex.RUnlock()
 	fake.privilegedMutex.RLock()
 	defer fake.privilegedMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -1002,6 +1255,8 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.setResourceConfigMutex.RUnlock()
 	fake.sourceMutex.RLock()
 	defer fake.sourceMutex.RUnlock()
+	fake.sourceWithDefaultsMutex.RLock()
+	defer fake.sourceWithDefaultsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.typeMutex.RLock()
@@ -1010,6 +1265,8 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.uniqueVersionHistoryMutex.RUnlock()
 	fake.versionMutex.RLock()
 	defer fake.versionMutex.RUnlock()
+	fake.workerSelectorMutex.RLock()
+	defer fake.workerSelectorMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
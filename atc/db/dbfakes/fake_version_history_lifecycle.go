@@ -0,0 +1,113 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	sync "sync"
+	time "time"
+
+	db "github.com/concourse/concourse/atc/db"
+)
+
+type FakeVersionHistoryLifecycle struct {
+	RemoveExpiredVersionsStub        func(int, time.Duration) error
+	removeExpiredVersionsMutex       sync.RWMutex
+	removeExpiredVersionsArgsForCall []struct {
+		arg1 int
+		arg2 time.Duration
+	}
+	removeExpiredVersionsReturns struct {
+		result1 error
+	}
+	removeExpiredVersionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeVersionHistoryLifecycle) RemoveExpiredVersions(arg1 int, arg2 time.Duration) error {
+	fake.removeExpiredVersionsMutex.Lock()
+	ret, specificReturn := fake.removeExpiredVersionsReturnsOnCall[len(fake.removeExpiredVersionsArgsForCall)]
+	fake.removeExpiredVersionsArgsForCall = append(fake.removeExpiredVersionsArgsForCall, struct {
+		arg1 int
+		arg2 time.Duration
+	}{arg1, arg2})
+	fake.recordInvocation("RemoveExpiredVersions", []interface{}{arg1, arg2})
+	fake.removeExpiredVersionsMutex.Unlock()
+	if fake.RemoveExpiredVersionsStub != nil {
+		return fake.RemoveExpiredVersionsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.removeExpiredVersionsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeVersionHistoryLifecycle) RemoveExpiredVersionsCallCount() int {
+	fake.removeExpiredVersionsMutex.RLock()
+	defer fake.removeExpiredVersionsMutex.RUnlock()
+	return len(fake.removeExpiredVersionsArgsForCall)
+}
+
+func (fake *FakeVersionHistoryLifecycle) RemoveExpiredVersionsCalls(stub func(int, time.Duration) error) {
+	fake.removeExpiredVersionsMutex.Lock()
+	defer fake.removeExpiredVersionsMutex.Unlock()
+	fake.RemoveExpiredVersionsStub = stub
+}
+
+func (fake *FakeVersionHistoryLifecycle) RemoveExpiredVersionsArgsForCall(i int) (int, time.Duration) {
+	fake.removeExpiredVersionsMutex.RLock()
+	defer fake.removeExpiredVersionsMutex.RUnlock()
+	argsForCall := fake.removeExpiredVersionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVersionHistoryLifecycle) RemoveExpiredVersionsReturns(result1 error) {
+	fake.removeExpiredVersionsMutex.Lock()
+	defer fake.removeExpiredVersionsMutex.Unlock()
+	fake.RemoveExpiredVersionsStub = nil
+	fake.removeExpiredVersionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVersionHistoryLifecycle) RemoveExpiredVersionsReturnsOnCall(i int, result1 error) {
+	fake.removeExpiredVersionsMutex.Lock()
+	defer fake.removeExpiredVersionsMutex.Unlock()
+	fake.RemoveExpiredVersionsStub = nil
+	if fake.removeExpiredVersionsReturnsOnCall == nil {
+		fake.removeExpiredVersionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeExpiredVersionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVersionHistoryLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.removeExpiredVersionsMutex.RLock()
+	defer fake.removeExpiredVersionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeVersionHistoryLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.VersionHistoryLifecycle = new(FakeVersionHistoryLifecycle)
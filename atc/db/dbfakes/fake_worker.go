@@ -40,6 +40,16 @@ type FakeWorker struct {
 	baggageclaimURLReturnsOnCall map[int]struct {
 		result1 *string
 	}
+	CapacityStub        func() atc.WorkerCapacity
+	capacityMutex       sync.RWMutex
+	capacityArgsForCall []struct {
+	}
+	capacityReturns struct {
+		result1 atc.WorkerCapacity
+	}
+	capacityReturnsOnCall map[int]struct {
+		result1 atc.WorkerCapacity
+	}
 	CertsPathStub        func() *string
 	certsPathMutex       sync.RWMutex
 	certsPathArgsForCall []struct {
@@ -139,6 +149,16 @@ type FakeWorker struct {
 	hTTPSProxyURLReturnsOnCall map[int]struct {
 		result1 string
 	}
+	LabelsStub        func() map[string]string
+	labelsMutex       sync.RWMutex
+	labelsArgsForCall []struct {
+	}
+	labelsReturns struct {
+		result1 map[string]string
+	}
+	labelsReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
 	LandStub        func() error
 	landMutex       sync.RWMutex
 	landArgsForCall []struct {
@@ -169,6 +189,16 @@ type FakeWorker struct {
 	noProxyReturnsOnCall map[int]struct {
 		result1 string
 	}
+	P2PURLStub        func() string
+	p2PURLMutex       sync.RWMutex
+	p2PURLArgsForCall []struct {
+	}
+	p2PURLReturns struct {
+		result1 string
+	}
+	p2PURLReturnsOnCall map[int]struct {
+		result1 string
+	}
 	PlatformStub        func() string
 	platformMutex       sync.RWMutex
 	platformArgsForCall []struct {
@@ -455,6 +485,58 @@ func (fake *FakeWorker) BaggageclaimURLReturnsOnCall(i int, result1 *string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Capacity() atc.WorkerCapacity {
+	fake.capacityMutex.Lock()
+	ret, specificReturn := fake.capacityReturnsOnCall[len(fake.capacityArgsForCall)]
+	fake.capacityArgsForCall = append(fake.capacityArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Capacity", []interface{}{})
+	fake.capacityMutex.Unlock()
+	if fake.CapacityStub != nil {
+		return fake.CapacityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.capacityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapacityCallCount() int {
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
+	return len(fake.capacityArgsForCall)
+}
+
+func (fake *FakeWorker) CapacityCalls(stub func() atc.WorkerCapacity) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = stub
+}
+
+func (fake *FakeWorker) CapacityReturns(result1 atc.WorkerCapacity) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	fake.capacityReturns = struct {
+		result1 atc.WorkerCapacity
+	}{result1}
+}
+
+func (fake *FakeWorker) CapacityReturnsOnCall(i int, result1 atc.WorkerCapacity) {
+	fake.capacityMutex.Lock()
+	defer fake.capacityMutex.Unlock()
+	fake.CapacityStub = nil
+	if fake.capacityReturnsOnCall == nil {
+		fake.capacityReturnsOnCall = make(map[int]struct {
+			result1 atc.WorkerCapacity
+		})
+	}
+	fake.capacityReturnsOnCall[i] = struct {
+		result1 atc.WorkerCapacity
+	}{result1}
+}
+
 func (fake *FakeWorker) CertsPath() *string {
 	fake.certsPathMutex.Lock()
 	ret, specificReturn := fake.certsPathReturnsOnCall[len(fake.certsPathArgsForCall)]
@@ -949,6 +1031,58 @@ func (fake *FakeWorker) HTTPSProxyURLReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Labels() map[string]string {
+	fake.labelsMutex.Lock()
+	ret, specificReturn := fake.labelsReturnsOnCall[len(fake.labelsArgsForCall)]
+	fake.labelsArgsForCall = append(fake.labelsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Labels", []interface{}{})
+	fake.labelsMutex.Unlock()
+	if fake.LabelsStub != nil {
+		return fake.LabelsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.labelsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) LabelsCallCount() int {
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
+	return len(fake.labelsArgsForCall)
+}
+
+func (fake *FakeWorker) LabelsCalls(stub func() map[string]string) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = stub
+}
+
+func (fake *FakeWorker) LabelsReturns(result1 map[string]string) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	fake.labelsReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeWorker) LabelsReturnsOnCall(i int, result1 map[string]string) {
+	fake.labelsMutex.Lock()
+	defer fake.labelsMutex.Unlock()
+	fake.LabelsStub = nil
+	if fake.labelsReturnsOnCall == nil {
+		fake.labelsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.labelsReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
 func (fake *FakeWorker) Land() error {
 	fake.landMutex.Lock()
 	ret, specificReturn := fake.landReturnsOnCall[len(fake.landArgsForCall)]
@@ -1105,6 +1239,58 @@ func (fake *FakeWorker) NoProxyReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) P2PURL() string {
+	fake.p2PURLMutex.Lock()
+	ret, specificReturn := fake.p2PURLReturnsOnCall[len(fake.p2PURLArgsForCall)]
+	fake.p2PURLArgsForCall = append(fake.p2PURLArgsForCall, struct {
+	}{})
+	fake.recordInvocation("P2PURL", []interface{}{})
+	fake.p2PURLMutex.Unlock()
+	if fake.P2PURLStub != nil {
+		return fake.P2PURLStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.p2PURLReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) P2PURLCallCount() int {
+	fake.p2PURLMutex.RLock()
+	defer fake.p2PURLMutex.RUnlock()
+	return len(fake.p2PURLArgsForCall)
+}
+
+func (fake *FakeWorker) P2PURLCalls(stub func() string) {
+	fake.p2PURLMutex.Lock()
+	defer fake.p2PURLMutex.Unlock()
+	fake.P2PURLStub = stub
+}
+
+func (fake *FakeWorker) P2PURLReturns(result1 string) {
+	fake.p2PURLMutex.Lock()
+	defer fake.p2PURLMutex.Unlock()
+	fake.P2PURLStub = nil
+	fake.p2PURLReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) P2PURLReturnsOnCall(i int, result1 string) {
+	fake.p2PURLMutex.Lock()
+	defer fake.p2PURLMutex.Unlock()
+	fake.P2PURLStub = nil
+	if fake.p2PURLReturnsOnCall == nil {
+		fake.p2PURLReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.p2PURLReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) Platform() string {
 	fake.platformMutex.Lock()
 	ret, specificReturn := fake.platformReturnsOnCall[len(fake.platformArgsForCall)]
@@ -1747,6 +1933,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeVolumesMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
 	defer fake.baggageclaimURLMutex.RUnlock()
+	fake.capacityMutex.RLock()
+	defer fake.capacityMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
 	fake.createContainerMutex.RLock()
@@ -1765,12 +1953,16 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.hTTPProxyURLMutex.RUnlock()
 	fake.hTTPSProxyURLMutex.RLock()
 	defer fake.hTTPSProxyURLMutex.RUnlock()
+	fake.labelsMutex.RLock()
+	defer fake.labelsMutex.RUnlock()
 	fake.landMutex.RLock()
 	defer fake.landMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.noProxyMutex.RLock()
 	defer fake.noProxyMutex.RUnlock()
+	fake.p2PURLMutex.RLock()
+	defer fake.p2PURLMutex.RUnlock()
 	fake.platformMutex.RLock()
 	defer fake.platformMutex.RUnlock()
 	fake.pruneMutex.RLock()
@@ -10,6 +10,18 @@ import (
 )
 
 type FakeWorkerFactory struct {
+	ActiveTasksCountPerWorkerStub        func() (map[string]int, error)
+	activeTasksCountPerWorkerMutex       sync.RWMutex
+	activeTasksCountPerWorkerArgsForCall []struct {
+	}
+	activeTasksCountPerWorkerReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	activeTasksCountPerWorkerReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
+	}
 	BuildContainersCountPerWorkerStub        func() (map[string]int, error)
 	buildContainersCountPerWorkerMutex       sync.RWMutex
 	buildContainersCountPerWorkerArgsForCall []struct {
@@ -107,6 +119,61 @@ type FakeWorkerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeWorkerFactory) ActiveTasksCountPerWorker() (map[string]int, error) {
+	fake.activeTasksCountPerWorkerMutex.Lock()
+	ret, specificReturn := fake.activeTasksCountPerWorkerReturnsOnCall[len(fake.activeTasksCountPerWorkerArgsForCall)]
+	fake.activeTasksCountPerWorkerArgsForCall = append(fake.activeTasksCountPerWorkerArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ActiveTasksCountPerWorker", []interface{}{})
+	fake.activeTasksCountPerWorkerMutex.Unlock()
+	if fake.ActiveTasksCountPerWorkerStub != nil {
+		return fake.ActiveTasksCountPerWorkerStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.activeTasksCountPerWorkerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksCountPerWorkerCallCount() int {
+	fake.activeTasksCountPerWorkerMutex.RLock()
+	defer fake.activeTasksCountPerWorkerMutex.RUnlock()
+	return len(fake.activeTasksCountPerWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksCountPerWorkerCalls(stub func() (map[string]int, error)) {
+	fake.activeTasksCountPerWorkerMutex.Lock()
+	defer fake.activeTasksCountPerWorkerMutex.Unlock()
+	fake.ActiveTasksCountPerWorkerStub = stub
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksCountPerWorkerReturns(result1 map[string]int, result2 error) {
+	fake.activeTasksCountPerWorkerMutex.Lock()
+	defer fake.activeTasksCountPerWorkerMutex.Unlock()
+	fake.ActiveTasksCountPerWorkerStub = nil
+	fake.activeTasksCountPerWorkerReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerFactory) ActiveTasksCountPerWorkerReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.activeTasksCountPerWorkerMutex.Lock()
+	defer fake.activeTasksCountPerWorkerMutex.Unlock()
+	fake.ActiveTasksCountPerWorkerStub = nil
+	if fake.activeTasksCountPerWorkerReturnsOnCall == nil {
+		fake.activeTasksCountPerWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.activeTasksCountPerWorkerReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerFactory) BuildContainersCountPerWorker() (map[string]int, error) {
 	fake.buildContainersCountPerWorkerMutex.Lock()
 	ret, specificReturn := fake.buildContainersCountPerWorkerReturnsOnCall[len(fake.buildContainersCountPerWorkerArgsForCall)]
@@ -545,6 +612,8 @@ func (fake *FakeWorkerFactory) WorkersReturnsOnCall(i int, result1 []db.Worker,
 func (fake *FakeWorkerFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.activeTasksCountPerWorkerMutex.RLock()
+	defer fake.activeTasksCountPerWorkerMutex.RUnlock()
 	fake.buildContainersCountPerWorkerMutex.RLock()
 	defer fake.buildContainersCountPerWorkerMutex.RUnlock()
 	fake.findWorkersForContainerByOwnerMutex.RLock()
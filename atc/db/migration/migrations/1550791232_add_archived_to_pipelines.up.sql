@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE pipelines
+    ADD COLUMN archived boolean NOT NULL DEFAULT false;
+COMMIT;
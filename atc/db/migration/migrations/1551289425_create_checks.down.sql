@@ -0,0 +1,7 @@
+BEGIN;
+
+  DROP TABLE check_events;
+
+  DROP TABLE checks;
+
+COMMIT;
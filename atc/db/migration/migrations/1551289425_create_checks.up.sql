@@ -0,0 +1,28 @@
+BEGIN;
+
+  CREATE TABLE checks (
+    "id" serial NOT NULL PRIMARY KEY,
+    "resource_id" integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    "status" text NOT NULL,
+    "atc_url" text,
+    "worker_name" text,
+    "check_error" text,
+    "create_time" timestamp with time zone NOT NULL DEFAULT now(),
+    "end_time" timestamp with time zone
+  );
+
+  CREATE INDEX checks_resource_id_idx ON checks (resource_id);
+
+  CREATE INDEX checks_end_time_idx ON checks (end_time);
+
+  CREATE TABLE check_events (
+    "event_id" bigserial NOT NULL PRIMARY KEY,
+    "check_id" integer NOT NULL REFERENCES checks (id) ON DELETE CASCADE,
+    "type" text NOT NULL,
+    "version" text NOT NULL,
+    "payload" text NOT NULL
+  );
+
+  CREATE INDEX check_events_check_id_idx ON check_events (check_id);
+
+COMMIT;
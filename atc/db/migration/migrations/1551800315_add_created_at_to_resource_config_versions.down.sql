@@ -0,0 +1,10 @@
+BEGIN;
+
+  DROP INDEX build_resource_config_version_outputs_resource_id_version_md5_idx;
+
+  DROP INDEX build_resource_config_version_inputs_resource_id_version_md5_idx;
+
+  ALTER TABLE resource_config_versions
+    DROP COLUMN "created_at";
+
+COMMIT;
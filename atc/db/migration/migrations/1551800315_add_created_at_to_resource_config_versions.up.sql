@@ -0,0 +1,17 @@
+BEGIN;
+
+  -- existing versions are left without a creation time rather than appearing
+  -- to be created by the migration
+  ALTER TABLE resource_config_versions
+    ADD COLUMN "created_at" timestamp with time zone;
+
+  ALTER TABLE resource_config_versions
+    ALTER COLUMN "created_at" SET DEFAULT now();
+
+  CREATE INDEX build_resource_config_version_inputs_resource_id_version_md5_idx
+  ON build_resource_config_version_inputs (resource_id, version_md5);
+
+  CREATE INDEX build_resource_config_version_outputs_resource_id_version_md5_idx
+  ON build_resource_config_version_outputs (resource_id, version_md5);
+
+COMMIT;
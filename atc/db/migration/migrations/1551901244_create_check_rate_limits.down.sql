@@ -0,0 +1,5 @@
+BEGIN;
+
+  DROP TABLE check_rate_limits;
+
+COMMIT;
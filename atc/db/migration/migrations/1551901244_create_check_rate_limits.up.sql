@@ -0,0 +1,9 @@
+BEGIN;
+
+  CREATE TABLE check_rate_limits (
+    "resource_type" text NOT NULL PRIMARY KEY,
+    "tokens" double precision NOT NULL,
+    "updated_at" timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+COMMIT;
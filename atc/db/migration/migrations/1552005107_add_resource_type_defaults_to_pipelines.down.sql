@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    DROP COLUMN "resource_type_defaults",
+    DROP COLUMN "nonce";
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+  ALTER TABLE pipelines
+    ADD COLUMN "resource_type_defaults" text,
+    ADD COLUMN "nonce" text;
+
+COMMIT;
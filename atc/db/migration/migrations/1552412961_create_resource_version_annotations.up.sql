@@ -0,0 +1,16 @@
+BEGIN;
+
+  CREATE TABLE resource_version_annotations (
+    "id" serial NOT NULL PRIMARY KEY,
+    "resource_id" integer NOT NULL REFERENCES resources (id) ON DELETE CASCADE,
+    "version_md5" text NOT NULL,
+    "action" text NOT NULL,
+    "user_name" text NOT NULL DEFAULT '',
+    "comment" text NOT NULL DEFAULT '',
+    "created_at" timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX resource_version_annotations_resource_id_version_md5_idx
+  ON resource_version_annotations (resource_id, version_md5);
+
+COMMIT;
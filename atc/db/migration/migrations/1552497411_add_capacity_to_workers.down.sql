@@ -0,0 +1,8 @@
+BEGIN;
+
+  ALTER TABLE workers
+    DROP COLUMN "max_containers",
+    DROP COLUMN "cpu",
+    DROP COLUMN "memory";
+
+COMMIT;
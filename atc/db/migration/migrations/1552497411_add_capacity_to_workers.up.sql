@@ -0,0 +1,8 @@
+BEGIN;
+
+  ALTER TABLE workers
+    ADD COLUMN "max_containers" integer NOT NULL DEFAULT 0,
+    ADD COLUMN "cpu" integer NOT NULL DEFAULT 0,
+    ADD COLUMN "memory" bigint NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE workers ADD COLUMN "labels" text NOT NULL DEFAULT '{}';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE workers DROP COLUMN "p2p_url";
+
+COMMIT;
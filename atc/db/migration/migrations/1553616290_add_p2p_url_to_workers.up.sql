@@ -0,0 +1,5 @@
+BEGIN;
+
+  ALTER TABLE workers ADD COLUMN "p2p_url" text;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+  ALTER TABLE resources
+    DROP COLUMN "check_interval",
+    DROP COLUMN "last_check_setup_failed";
+
+  ALTER TABLE resource_types
+    DROP COLUMN "check_interval",
+    DROP COLUMN "last_check_setup_failed";
+
+COMMIT;
@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+func (self *migrations) Up_1553709583() error {
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, table := range []string{"resources", "resource_types"} {
+		_, err = tx.Exec(`
+			ALTER TABLE ` + table + `
+				ADD COLUMN check_interval interval,
+				ADD COLUMN last_check_setup_failed timestamp with time zone
+		`)
+		if err != nil {
+			return err
+		}
+
+		intervals, err := self.checkIntervals(tx, table)
+		if err != nil {
+			return err
+		}
+
+		for id, interval := range intervals {
+			_, err = tx.Exec(`
+				UPDATE `+table+`
+				SET check_interval = ($2 || ' SECONDS')::INTERVAL
+				WHERE id = $1
+			`, id, interval.Seconds())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// checkIntervals parses the check_every of the active rows of the table,
+// skipping any which are unset or invalid.
+func (self *migrations) checkIntervals(tx *sql.Tx, table string) (map[int]time.Duration, error) {
+	rows, err := tx.Query(`SELECT id, config, nonce FROM ` + table + ` WHERE active`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	intervals := map[int]time.Duration{}
+	for rows.Next() {
+		var (
+			id         int
+			configBlob string
+			nonce      sql.NullString
+		)
+
+		err = rows.Scan(&id, &configBlob, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedConfig, err := self.Decrypt(configBlob, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		var config struct {
+			CheckEvery string `json:"check_every"`
+		}
+
+		err = json.Unmarshal(decryptedConfig, &config)
+		if err != nil {
+			return nil, err
+		}
+
+		if config.CheckEvery == "" {
+			continue
+		}
+
+		interval, err := time.ParseDuration(config.CheckEvery)
+		if err != nil {
+			continue
+		}
+
+		intervals[id] = interval
+	}
+
+	return intervals, rows.Err()
+}
@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE resource_config_scopes
+    DROP COLUMN "rate_limited";
+
+COMMIT;
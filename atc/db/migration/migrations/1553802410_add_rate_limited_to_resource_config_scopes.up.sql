@@ -0,0 +1,6 @@
+BEGIN;
+
+  ALTER TABLE resource_config_scopes
+    ADD COLUMN "rate_limited" boolean NOT NULL DEFAULT false;
+
+COMMIT;
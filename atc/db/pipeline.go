@@ -13,7 +13,9 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/db/encryption"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 type ErrResourceNotFound struct {
@@ -34,12 +36,16 @@ type Cause struct {
 type Pipeline interface {
 	ID() int
 	Name() string
+	InstanceVars() atc.InstanceVars
+	Ref() atc.PipelineRef
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
+	ResourceTypeDefaults() atc.ResourceTypeDefaults
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	Archived() bool
 
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
@@ -57,6 +63,7 @@ type Pipeline interface {
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
 	AcquireSchedulingLock(lager.Logger, time.Duration) (lock.Lock, bool, error)
+	SchedulingNotifier() (Notifier, error)
 
 	LoadVersionsDB() (*algorithm.VersionsDB, error)
 
@@ -66,6 +73,8 @@ type Pipeline interface {
 	ResourceTypes() (ResourceTypes, error)
 	ResourceType(name string) (ResourceType, bool, error)
 
+	ResetLastChecked(resourceTypeNames []string, resourceNames []string) error
+
 	Job(name string) (Job, bool, error)
 	Jobs() (Jobs, error)
 	Dashboard() (Dashboard, error)
@@ -76,6 +85,8 @@ type Pipeline interface {
 	Pause() error
 	Unpause() error
 
+	Archive() error
+
 	Destroy() error
 	Rename(string) error
 }
@@ -83,12 +94,16 @@ type Pipeline interface {
 type pipeline struct {
 	id            int
 	name          string
+	instanceVars  atc.InstanceVars
 	teamID        int
 	teamName      string
 	groups        atc.GroupConfigs
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
+	archived      bool
+
+	resourceTypeDefaults atc.ResourceTypeDefaults
 
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
@@ -105,16 +120,46 @@ type PipelinePausedState string
 var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
+		p.instance_vars,
 		p.groups,
+		p.resource_type_defaults,
+		p.nonce,
 		p.version,
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		p.archived
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
 
+// decryptResourceTypeDefaults decrypts the resource_type_defaults of a
+// pipeline, which are encrypted as they are likely to contain credentials.
+func decryptResourceTypeDefaults(es encryption.Strategy, defaults sql.NullString, nonce sql.NullString) (atc.ResourceTypeDefaults, error) {
+	if !defaults.Valid {
+		return nil, nil
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := es.Decrypt(defaults.String, noncense)
+	if err != nil {
+		return nil, err
+	}
+
+	var resourceTypeDefaults atc.ResourceTypeDefaults
+	err = json.Unmarshal(decrypted, &resourceTypeDefaults)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceTypeDefaults, nil
+}
+
 const (
 	PipelinePaused   PipelinePausedState = "paused"
 	PipelineUnpaused PipelinePausedState = "unpaused"
@@ -144,14 +189,27 @@ func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                      { return p.id }
-func (p *pipeline) Name() string                 { return p.name }
-func (p *pipeline) TeamID() int                  { return p.teamID }
-func (p *pipeline) TeamName() string             { return p.teamName }
-func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
-func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
-func (p *pipeline) Public() bool                 { return p.public }
-func (p *pipeline) Paused() bool                 { return p.paused }
+func (p *pipeline) ID() int                        { return p.id }
+func (p *pipeline) Name() string                   { return p.name }
+func (p *pipeline) InstanceVars() atc.InstanceVars { return p.instanceVars }
+func (p *pipeline) TeamID() int                    { return p.teamID }
+func (p *pipeline) TeamName() string               { return p.teamName }
+func (p *pipeline) Groups() atc.GroupConfigs       { return p.groups }
+func (p *pipeline) ConfigVersion() ConfigVersion   { return p.configVersion }
+func (p *pipeline) Public() bool                   { return p.public }
+func (p *pipeline) Paused() bool                   { return p.paused }
+func (p *pipeline) Archived() bool                 { return p.archived }
+
+func (p *pipeline) ResourceTypeDefaults() atc.ResourceTypeDefaults {
+	return p.resourceTypeDefaults
+}
+
+func (p *pipeline) Ref() atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         p.name,
+		InstanceVars: p.instanceVars,
+	}
+}
 
 // IMPORTANT: This method is broken with the new resource config versions changes
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
@@ -497,6 +555,74 @@ func (p *pipeline) ResourceType(name string) (ResourceType, bool, error) {
 	return resourceType, true, nil
 }
 
+// ResetLastChecked makes the named resource types and resources due to be
+// checked by the check scheduler by forgetting when they were last checked
+// and when their check last failed to set up.
+func (p *pipeline) ResetLastChecked(resourceTypeNames []string, resourceNames []string) error {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	if len(resourceTypeNames) != 0 {
+		_, err = psql.Update("resource_config_scopes").
+			Set("last_checked", "epoch").
+			Where(sq.Expr(`resource_config_id IN (
+				SELECT resource_config_id
+				FROM resource_types
+				WHERE pipeline_id = ? AND name = ANY(?)
+			)`, p.id, pq.Array(resourceTypeNames))).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Update("resource_types").
+			Set("last_check_setup_failed", nil).
+			Where(sq.Eq{
+				"pipeline_id": p.id,
+				"name":        resourceTypeNames,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(resourceNames) != 0 {
+		_, err = psql.Update("resource_config_scopes").
+			Set("last_checked", "epoch").
+			Where(sq.Expr(`id IN (
+				SELECT resource_config_scope_id
+				FROM resources
+				WHERE pipeline_id = ? AND name = ANY(?)
+			)`, p.id, pq.Array(resourceNames))).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Update("resources").
+			Set("last_check_setup_failed", nil).
+			Where(sq.Eq{
+				"pipeline_id": p.id,
+				"name":        resourceNames,
+			}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (p *pipeline) Job(name string) (Job, bool, error) {
 	row := jobsQuery.Where(sq.Eq{
 		"j.name":        name,
@@ -620,6 +746,87 @@ func (p *pipeline) Unpause() error {
 	return err
 }
 
+// Archive pauses the pipeline and drops its config, deactivating its jobs,
+// resources, and resource types and releasing their resource configs. Its
+// builds are kept. The pipeline is unarchived by saving its config again.
+func (p *pipeline) Archive() error {
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = psql.Update("pipelines").
+		Set("archived", true).
+		Set("paused", true).
+		Set("groups", nil).
+		Set("version", sq.Expr("nextval('config_version_seq')")).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM jobs_serial_groups
+		WHERE job_id in (
+			SELECT j.id
+			FROM jobs j
+			WHERE j.pipeline_id = $1
+		)
+	`, p.id)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("jobs").
+		Set("active", false).
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resources").
+		Set("active", false).
+		Set("resource_config_id", nil).
+		Set("resource_config_scope_id", nil).
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("resource_types").
+		Set("active", false).
+		Set("resource_config_id", nil).
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = bumpCacheIndex(tx, p.id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (p *pipeline) Hide() error {
 	_, err := psql.Update("pipelines").
 		Set("public", false).
@@ -945,6 +1152,15 @@ func (p *pipeline) AcquireSchedulingLock(logger lager.Logger, interval time.Dura
 	return lock, true, nil
 }
 
+// SchedulingNotifier notifies when the jobs of the pipeline should be
+// scheduled without waiting for the next interval, e.g. when a version was
+// injected through the API.
+func (p *pipeline) SchedulingNotifier() (Notifier, error) {
+	return newConditionNotifier(p.conn.Bus(), pipelineSchedulingChannel(p.id), func() (bool, error) {
+		return false, nil
+	})
+}
+
 func (p *pipeline) CreateOneOffBuild() (Build, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
@@ -1018,6 +1234,10 @@ func (p *pipeline) getBuildsFrom(col string) (map[string]Build, error) {
 	return nextBuilds, nil
 }
 
+func pipelineSchedulingChannel(pipelineID int) string {
+	return fmt.Sprintf("pipeline_scheduling_%d", pipelineID)
+}
+
 func bumpCacheIndex(tx Tx, pipelineID int) error {
 	res, err := psql.Update("pipelines").
 		Set("cache_index", sq.Expr("cache_index + 1")).
@@ -117,7 +117,7 @@ var _ = Describe("Pipeline", func() {
 			},
 		}
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
+		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, db.ConfigVersion(0), db.PipelineUnpaused)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -219,13 +219,99 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("Archive", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = resource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err = job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			Expect(pipeline.Archive()).To(Succeed())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("archives and pauses the pipeline", func() {
+			Expect(pipeline.Archived()).To(BeTrue())
+			Expect(pipeline.Paused()).To(BeTrue())
+		})
+
+		It("drops its config", func() {
+			Expect(pipeline.Groups()).To(BeEmpty())
+
+			jobs, err := pipeline.Jobs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(jobs).To(BeEmpty())
+
+			resources, err := pipeline.Resources()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resources).To(BeEmpty())
+
+			resourceTypes, err := pipeline.ResourceTypes()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceTypes).To(BeEmpty())
+		})
+
+		It("releases the resource configs of its resources", func() {
+			var resourceConfigID *int
+			err := dbConn.QueryRow(`
+				SELECT resource_config_id
+				FROM resources
+				WHERE pipeline_id = $1
+				AND name = 'some-resource'
+			`, pipeline.ID()).Scan(&resourceConfigID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceConfigID).To(BeNil())
+		})
+
+		It("keeps its builds", func() {
+			found, err := build.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			builds, _, err := pipeline.Builds(db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(builds).To(HaveLen(1))
+		})
+
+		Context("when the pipeline is saved again", func() {
+			JustBeforeEach(func() {
+				var err error
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("unarchives the pipeline, leaving it paused", func() {
+				Expect(pipeline.Archived()).To(BeFalse())
+				Expect(pipeline.Paused()).To(BeTrue())
+			})
+
+			It("restores its config", func() {
+				jobs, err := pipeline.Jobs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(jobs).To(HaveLen(len(pipelineConfig.Jobs)))
+			})
+		})
+	})
+
 	Describe("Rename", func() {
 		JustBeforeEach(func() {
 			Expect(pipeline.Rename("oopsies")).To(Succeed())
 		})
 
 		It("renames the pipeline", func() {
-			pipeline, found, err := team.Pipeline("oopsies")
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: "oopsies"})
 			Expect(pipeline.Name()).To(Equal("oopsies"))
 			Expect(found).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
@@ -398,10 +484,10 @@ var _ = Describe("Pipeline", func() {
 			}
 
 			var err error
-			dbPipeline, _, err = team.SavePipeline("pipeline-name", pipelineConfig, 0, db.PipelineUnpaused)
+			dbPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-name"}, pipelineConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherDBPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherDBPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline-name"}, otherPipelineConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, _, err = dbPipeline.Resource(resourceName)
@@ -792,7 +878,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(otherPipelineSavedVR.Version()).To(Equal(db.Version{"version": "3"}))
 
 			By("including disabled versions")
-			err = resource.DisableVersion(savedVR2.ID())
+			err = resource.DisableVersion(savedVR2.ID(), db.VersionAnnotation{})
 			Expect(err).ToNot(HaveOccurred())
 
 			latestVR, found, err := resourceConfigScope.LatestVersion()
@@ -804,10 +890,10 @@ var _ = Describe("Pipeline", func() {
 
 		Describe("enabling and disabling versioned resources", func() {
 			It("returns an error if the version is bogus", func() {
-				err := resource.EnableVersion(42)
+				err := resource.EnableVersion(42, db.VersionAnnotation{})
 				Expect(err).To(HaveOccurred())
 
-				err = resource.DisableVersion(42)
+				err = resource.DisableVersion(42, db.VersionAnnotation{})
 				Expect(err).To(HaveOccurred())
 			})
 
@@ -821,7 +907,7 @@ var _ = Describe("Pipeline", func() {
 
 				Expect(savedRCV.Version()).To(Equal(db.Version{"version": "1"}))
 
-				err = resource.DisableVersion(savedRCV.ID())
+				err = resource.DisableVersion(savedRCV.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				latestVR, found, err := resourceConfigScope.LatestVersion()
@@ -829,7 +915,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(found).To(BeTrue())
 				Expect(latestVR.Version()).To(Equal(db.Version{"version": "1"}))
 
-				err = resource.EnableVersion(savedRCV.ID())
+				err = resource.EnableVersion(savedRCV.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				latestVR, found, err = resourceConfigScope.LatestVersion()
@@ -969,7 +1055,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			pipelineDB, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipelineDB, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -1036,13 +1122,13 @@ var _ = Describe("Pipeline", func() {
 				err = build1.Finish(db.BuildStatusSucceeded)
 				Expect(err).ToNot(HaveOccurred())
 
-				err = resource.DisableVersion(disabledVersion.ID())
+				err = resource.DisableVersion(disabledVersion.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
-				err = resource.DisableVersion(enabledVersion.ID())
+				err = resource.DisableVersion(enabledVersion.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
-				err = resource.EnableVersion(enabledVersion.ID())
+				err = resource.EnableVersion(enabledVersion.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				versions, err := pipelineDB.LoadVersionsDB()
@@ -1164,7 +1250,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeFalse())
 
-			_, found, err = team.Pipeline(pipeline.Name())
+			_, found, err = team.Pipeline(atc.PipelineRef{Name: pipeline.Name()})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
@@ -1210,7 +1296,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, db.PipelineUnpaused)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline-name"}, otherPipelineConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1278,14 +1364,14 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				err = savedResource.DisableVersion(rcv.ID())
+				err = savedResource.DisableVersion(rcv.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				cachedVersionsDB, err := pipeline.LoadVersionsDB()
 				Expect(err).ToNot(HaveOccurred())
 				Expect(versionsDB != cachedVersionsDB).To(BeTrue(), "Expected VersionsDB to be different objects")
 
-				err = savedResource.EnableVersion(rcv.ID())
+				err = savedResource.EnableVersion(rcv.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				cachedVersionsDB2, err := pipeline.LoadVersionsDB()
@@ -2015,6 +2101,48 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("ResetLastChecked", func() {
+		var (
+			resourceScope      db.ResourceConfigScope
+			otherResourceScope db.ResourceConfigScope
+		)
+
+		BeforeEach(func() {
+			resource, found, err := pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			otherResource, found, err := pipeline.Resource("some-other-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceScope, err = resource.SetResourceConfig(logger, atc.Source{"some": "source"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			otherResourceScope, err = otherResource.SetResourceConfig(logger, atc.Source{"some": "other-source"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, scope := range []db.ResourceConfigScope{resourceScope, otherResourceScope} {
+				updated, err := scope.UpdateLastChecked(time.Hour, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(updated).To(BeTrue())
+			}
+		})
+
+		It("makes only the named resources due to be checked", func() {
+			err := pipeline.ResetLastChecked(nil, []string{"some-resource"})
+			Expect(err).ToNot(HaveOccurred())
+
+			due, err := resourceScope.CheckDue(time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeTrue())
+
+			due, err = otherResourceScope.CheckDue(time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(due).To(BeFalse())
+		})
+	})
+
 	Describe("ResourceVersion", func() {
 		var (
 			resourceVersion, rv   atc.ResourceVersion
@@ -2066,7 +2194,7 @@ var _ = Describe("Pipeline", func() {
 
 		Context("when a resource is not enabled", func() {
 			BeforeEach(func() {
-				err := resource.DisableVersion(resourceConfigVersion.ID())
+				err := resource.DisableVersion(resourceConfigVersion.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				resourceVersion.Enabled = false
@@ -2101,7 +2229,7 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -2121,7 +2249,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(found).To(BeTrue())
 			}
 
-			otherPipeline, _, err := team.SavePipeline("another-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "another-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			otherJob, found, err := otherPipeline.Job("some-job")
@@ -16,6 +16,19 @@ import (
 	"github.com/lib/pq"
 )
 
+var ErrResourceNotChecked = errors.New("resource has not been checked yet")
+
+// ManuallyInjectedMetadataField is added to the metadata of versions which
+// were saved through the API rather than found by a check.
+const ManuallyInjectedMetadataField = "manually_injected"
+
+// A VersionAnnotation is who disabled, enabled or pinned a version of a
+// resource, and why. Each is kept in the version's annotation history.
+type VersionAnnotation struct {
+	UserName string
+	Comment  string
+}
+
 //go:generate counterfeiter . Resource
 
 type Resource interface {
@@ -26,13 +39,18 @@ type Resource interface {
 	TeamName() string
 	Type() string
 	Source() atc.Source
+	SourceWithDefaults() atc.Source
 	CheckEvery() string
 	CheckTimeout() string
 	LastChecked() time.Time
 	Tags() atc.Tags
+	WorkerSelector() atc.WorkerSelector
 	CheckSetupError() error
 	CheckError() error
+	RateLimited() bool
 	WebhookToken() string
+	Webhook() *atc.WebhookConfig
+	VersionHistory() *atc.VersionHistoryConfig
 	ConfigPinnedVersion() atc.Version
 	APIPinnedVersion() atc.Version
 	PinComment() string
@@ -43,22 +61,30 @@ type Resource interface {
 	CurrentPinnedVersion() atc.Version
 
 	ResourceConfigVersionID(atc.Version) (int, bool, error)
+	EnabledVersions() (map[int]atc.Version, error)
 	Versions(page Page) ([]atc.ResourceVersion, Pagination, bool, error)
 	SaveUncheckedVersion(atc.Version, ResourceConfigMetadataFields, ResourceConfig, creds.VersionedResourceTypes) (bool, error)
+	InjectVersion(atc.Version, ResourceConfigMetadataFields) (bool, error)
 
-	EnableVersion(rcvID int) error
-	DisableVersion(rcvID int) error
+	EnableVersion(rcvID int, annotation VersionAnnotation) error
+	DisableVersion(rcvID int, annotation VersionAnnotation) error
 
-	PinVersion(rcvID int) error
+	PinVersion(rcvID int, annotation VersionAnnotation) error
 	UnpinVersion() error
 
 	SetResourceConfig(lager.Logger, atc.Source, creds.VersionedResourceTypes) (ResourceConfigScope, error)
 	SetCheckSetupError(error) error
 
+	CreateCheck(atcURL string) (Check, error)
+	CreateRateLimitedCheck(atcURL string) error
+	Checks() ([]Check, error)
+	Check(id int) (Check, bool, error)
+
 	Reload() (bool, error)
 }
 
-var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, rs.last_checked, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text").
+var resourcesQuery = psql.Select("r.id, r.name, r.config, r.check_error, rs.last_checked, r.pipeline_id, r.nonce, r.resource_config_id, r.resource_config_scope_id, p.name, t.name, rs.check_error, rp.version, rp.comment_text, p.resource_type_defaults, p.nonce",
+	"COALESCE(rs.rate_limited, false)").
 	From("resources r").
 	Join("pipelines p ON p.id = r.pipeline_id").
 	Join("teams t ON t.id = p.team_id").
@@ -74,13 +100,18 @@ type resource struct {
 	teamName              string
 	type_                 string
 	source                atc.Source
+	resourceTypeDefaults  atc.ResourceTypeDefaults
 	checkEvery            string
 	checkTimeout          string
 	lastChecked           time.Time
 	tags                  atc.Tags
+	workerSelector        atc.WorkerSelector
 	checkSetupError       error
 	checkError            error
+	rateLimited           bool
 	webhookToken          string
+	webhook               *atc.WebhookConfig
+	versionHistory        *atc.VersionHistoryConfig
 	configPinnedVersion   atc.Version
 	apiPinnedVersion      atc.Version
 	pinComment            string
@@ -118,36 +149,52 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 		configs = append(configs, atc.ResourceConfig{
 			Name:         r.Name(),
 			WebhookToken: r.WebhookToken(),
+			Webhook:      r.Webhook(),
 			Type:         r.Type(),
 			Source:       r.Source(),
 			CheckEvery:   r.CheckEvery(),
 			Tags:         r.Tags(),
 			Version:      r.ConfigPinnedVersion(),
+
+			WorkerSelector: r.WorkerSelector(),
+			VersionHistory: r.VersionHistory(),
 		})
 	}
 
 	return configs
 }
 
-func (r *resource) ID() int                          { return r.id }
-func (r *resource) Name() string                     { return r.name }
-func (r *resource) PipelineID() int                  { return r.pipelineID }
-func (r *resource) PipelineName() string             { return r.pipelineName }
-func (r *resource) TeamName() string                 { return r.teamName }
-func (r *resource) Type() string                     { return r.type_ }
-func (r *resource) Source() atc.Source               { return r.source }
-func (r *resource) CheckEvery() string               { return r.checkEvery }
-func (r *resource) CheckTimeout() string             { return r.checkTimeout }
-func (r *resource) LastChecked() time.Time           { return r.lastChecked }
-func (r *resource) Tags() atc.Tags                   { return r.tags }
-func (r *resource) CheckSetupError() error           { return r.checkSetupError }
-func (r *resource) CheckError() error                { return r.checkError }
-func (r *resource) WebhookToken() string             { return r.webhookToken }
-func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
-func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
-func (r *resource) PinComment() string               { return r.pinComment }
-func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
-func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
+func (r *resource) ID() int                                   { return r.id }
+func (r *resource) Name() string                              { return r.name }
+func (r *resource) PipelineID() int                           { return r.pipelineID }
+func (r *resource) PipelineName() string                      { return r.pipelineName }
+func (r *resource) TeamName() string                          { return r.teamName }
+func (r *resource) Type() string                              { return r.type_ }
+func (r *resource) Source() atc.Source                        { return r.source }
+func (r *resource) CheckEvery() string                        { return r.checkEvery }
+func (r *resource) CheckTimeout() string                      { return r.checkTimeout }
+func (r *resource) LastChecked() time.Time                    { return r.lastChecked }
+func (r *resource) Tags() atc.Tags                            { return r.tags }
+func (r *resource) WorkerSelector() atc.WorkerSelector        { return r.workerSelector }
+func (r *resource) CheckSetupError() error                    { return r.checkSetupError }
+func (r *resource) CheckError() error                         { return r.checkError }
+func (r *resource) RateLimited() bool                         { return r.rateLimited }
+func (r *resource) WebhookToken() string                      { return r.webhookToken }
+func (r *resource) Webhook() *atc.WebhookConfig               { return r.webhook }
+func (r *resource) VersionHistory() *atc.VersionHistoryConfig { return r.versionHistory }
+func (r *resource) ConfigPinnedVersion() atc.Version          { return r.configPinnedVersion }
+func (r *resource) APIPinnedVersion() atc.Version             { return r.apiPinnedVersion }
+func (r *resource) PinComment() string                        { return r.pinComment }
+func (r *resource) ResourceConfigID() int                     { return r.resourceConfigID }
+func (r *resource) ResourceConfigScopeID() int                { return r.resourceConfigScopeID }
+
+// SourceWithDefaults returns the source with the resource_type_defaults of
+// the pipeline for the resource's type merged beneath it. Those configured on
+// the ATC are applied beneath these by whatever runs the checks, gets and
+// puts.
+func (r *resource) SourceWithDefaults() atc.Source {
+	return r.resourceTypeDefaults.Apply(r.type_, r.source)
+}
 
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
@@ -246,6 +293,7 @@ func (r *resource) SetCheckSetupError(cause error) error {
 	} else {
 		_, err = psql.Update("resources").
 			Set("check_error", cause.Error()).
+			Set("last_check_setup_failed", sq.Expr("now()")).
 			Where(sq.Eq{"id": r.ID()}).
 			RunWith(r.conn).
 			Exec()
@@ -284,6 +332,83 @@ func (r *resource) SaveUncheckedVersion(version atc.Version, metadata ResourceCo
 	return newVersion, tx.Commit()
 }
 
+// InjectVersion saves a version which was not found by a check, e.g. one
+// reported by an external system, into the resource's config scope. The
+// version is ordered as the latest one as if it were found by a check, and
+// the scheduler of the pipeline is woken up so that jobs can use it straight
+// away. Versions the resource already has are left unchanged, and it returns
+// whether the version was new to the resource.
+func (r *resource) InjectVersion(version atc.Version, metadata ResourceConfigMetadataFields) (bool, error) {
+	if r.resourceConfigScopeID == 0 {
+		return false, ErrResourceNotChecked
+	}
+
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	scope := &resourceConfigScope{id: r.resourceConfigScopeID}
+
+	injectedMetadata := append(ResourceConfigMetadataFields{}, metadata...)
+	injectedMetadata = append(injectedMetadata, ResourceConfigMetadataField{
+		Name:  ManuallyInjectedMetadataField,
+		Value: "true",
+	})
+
+	metadataJSON, err := json.Marshal(injectedMetadata)
+	if err != nil {
+		return false, err
+	}
+
+	// versions which have already been checked are left as they are, so that
+	// injecting one neither overwrites its metadata nor reorders it
+	var checkOrder int
+	err = tx.QueryRow(`
+		INSERT INTO resource_config_versions (resource_config_scope_id, version, version_md5, metadata)
+		SELECT $1, $2, md5($3), $4
+		ON CONFLICT (resource_config_scope_id, version_md5) DO UPDATE SET metadata = $4
+		WHERE resource_config_versions.check_order = 0
+		RETURNING check_order
+		`, scope.ID(), string(versionJSON), string(versionJSON), string(metadataJSON)).Scan(&checkOrder)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	err = incrementCheckOrder(tx, scope, string(versionJSON))
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	err = bumpCacheIndexForPipelinesUsingResourceConfigScope(r.conn, scope.id)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.conn.Bus().Notify(pipelineSchedulingChannel(r.pipelineID))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *resource) ResourceConfigVersionID(version atc.Version) (int, bool, error) {
 	requestedVersion, err := json.Marshal(version)
 	if err != nil {
@@ -309,6 +434,50 @@ func (r *resource) ResourceConfigVersionID(version atc.Version) (int, bool, erro
 	return id, true, nil
 }
 
+// EnabledVersions returns the checked versions of the resource which have not
+// been disabled, by their ID.
+func (r *resource) EnabledVersions() (map[int]atc.Version, error) {
+	rows, err := psql.Select("v.id, v.version").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		LeftJoin("resource_disabled_versions d ON d.resource_id = r.id AND d.version_md5 = v.version_md5").
+		Where(sq.NotEq{
+			"v.check_order": 0,
+		}).
+		Where(sq.Eq{
+			"r.id":          r.id,
+			"d.resource_id": nil,
+			"d.version_md5": nil,
+		}).
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := map[int]atc.Version{}
+	for rows.Next() {
+		var id int
+		var versionJSON string
+		err = rows.Scan(&id, &versionJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal([]byte(versionJSON), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[id] = version
+	}
+
+	return versions, nil
+}
+
 func (r *resource) SetPinComment(comment string) error {
 	_, err := psql.Update("resource_pins").
 		Set("comment_text", comment).
@@ -450,6 +619,11 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 		return nil, Pagination{}, true, nil
 	}
 
+	err = r.loadVersionAnnotations(rvs)
+	if err != nil {
+		return nil, Pagination{}, false, err
+	}
+
 	var minCheckOrder int
 	var maxCheckOrder int
 
@@ -485,16 +659,23 @@ func (r *resource) Versions(page Page) ([]atc.ResourceVersion, Pagination, bool,
 	return rvs, pagination, true, nil
 }
 
-func (r *resource) EnableVersion(rcvID int) error {
-	return r.toggleVersion(rcvID, true)
+func (r *resource) EnableVersion(rcvID int, annotation VersionAnnotation) error {
+	return r.toggleVersion(rcvID, true, annotation)
 }
 
-func (r *resource) DisableVersion(rcvID int) error {
-	return r.toggleVersion(rcvID, false)
+func (r *resource) DisableVersion(rcvID int, annotation VersionAnnotation) error {
+	return r.toggleVersion(rcvID, false, annotation)
 }
 
-func (r *resource) PinVersion(rcvID int) error {
-	results, err := r.conn.Exec(`
+func (r *resource) PinVersion(rcvID int, annotation VersionAnnotation) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	results, err := tx.Exec(`
 	    INSERT INTO resource_pins(resource_id, version, comment_text)
 			VALUES ($1,
 				( SELECT rcv.version
@@ -514,7 +695,12 @@ func (r *resource) PinVersion(rcvID int) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
-	return nil
+	err = annotateVersion(tx, r.id, rcvID, atc.VersionActionPin, annotation)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 func (r *resource) UnpinVersion() error {
@@ -538,7 +724,113 @@ func (r *resource) UnpinVersion() error {
 	return nil
 }
 
-func (r *resource) toggleVersion(rcvID int, enable bool) error {
+func (r *resource) CreateCheck(atcURL string) (Check, error) {
+	c := &check{conn: r.conn}
+
+	row := psql.Insert("checks").
+		Columns("resource_id", "status", "atc_url").
+		Values(r.id, CheckStatusStarted, atcURL).
+		Suffix("RETURNING id, resource_id, status, atc_url, worker_name, check_error, create_time, end_time").
+		RunWith(r.conn).
+		QueryRow()
+
+	err := scanCheck(c, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// CreateRateLimitedCheck records a check which was deferred by the rate limit
+// of the resource's type, and marks the resource's config scope as rate
+// limited until it is next checked. The check is finished straight away, and
+// is not recorded again while it is still the resource's latest check.
+func (r *resource) CreateRateLimitedCheck(atcURL string) error {
+	tx, err := r.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	_, err = tx.Exec(`
+		UPDATE resource_config_scopes
+		SET rate_limited = true
+		WHERE id = (SELECT resource_config_scope_id FROM resources WHERE id = $1)
+	`, r.id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO checks (resource_id, status, atc_url, end_time)
+		SELECT $1, $2, $3, now()
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM checks c
+			WHERE c.id = (SELECT MAX(id) FROM checks WHERE resource_id = $1)
+			AND c.status = $2
+		)
+	`, r.id, CheckStatusRateLimited, atcURL)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Checks returns the resource's recorded checks, most recent first.
+func (r *resource) Checks() ([]Check, error) {
+	rows, err := checksQuery.
+		Where(sq.Eq{"c.resource_id": r.id}).
+		OrderBy("c.id DESC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var checks []Check
+	for rows.Next() {
+		c := &check{conn: r.conn}
+
+		err = scanCheck(c, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		checks = append(checks, c)
+	}
+
+	return checks, nil
+}
+
+func (r *resource) Check(id int) (Check, bool, error) {
+	c := &check{conn: r.conn}
+
+	row := checksQuery.
+		Where(sq.Eq{
+			"c.id":          id,
+			"c.resource_id": r.id,
+		}).
+		RunWith(r.conn).
+		QueryRow()
+
+	err := scanCheck(c, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return c, true, nil
+}
+
+func (r *resource) toggleVersion(rcvID int, enable bool, annotation VersionAnnotation) error {
 	tx, err := r.conn.Begin()
 	if err != nil {
 		return err
@@ -574,6 +866,16 @@ func (r *resource) toggleVersion(rcvID int, enable bool) error {
 		return nonOneRowAffectedError{rowsAffected}
 	}
 
+	action := atc.VersionActionDisable
+	if enable {
+		action = atc.VersionActionEnable
+	}
+
+	err = annotateVersion(tx, r.id, rcvID, action, annotation)
+	if err != nil {
+		return err
+	}
+
 	err = bumpCacheIndex(tx, r.pipelineID)
 	if err != nil {
 		return err
@@ -582,14 +884,72 @@ func (r *resource) toggleVersion(rcvID int, enable bool) error {
 	return tx.Commit()
 }
 
+func (r *resource) loadVersionAnnotations(rvs []atc.ResourceVersion) error {
+	rvIndexes := map[int]int{}
+	rvIDs := []int{}
+	for i, rv := range rvs {
+		rvIndexes[rv.ID] = i
+		rvIDs = append(rvIDs, rv.ID)
+	}
+
+	rows, err := psql.Select("v.id, a.action, a.user_name, a.comment, a.created_at").
+		From("resource_version_annotations a").
+		Join("resource_config_versions v ON v.version_md5 = a.version_md5").
+		Where(sq.Eq{
+			"a.resource_id": r.id,
+			"v.id":          rvIDs,
+		}).
+		OrderBy("a.id ASC").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			rvID       int
+			annotation atc.VersionAnnotation
+			createdAt  time.Time
+		)
+
+		err = rows.Scan(&rvID, &annotation.Action, &annotation.UserName, &annotation.Comment, &createdAt)
+		if err != nil {
+			return err
+		}
+
+		annotation.CreatedAt = createdAt.Unix()
+
+		i := rvIndexes[rvID]
+		rvs[i].Annotations = append(rvs[i].Annotations, annotation)
+	}
+
+	return rows.Err()
+}
+
+// annotateVersion adds to the annotation history of the version, which is
+// kept by its md5 so that it follows the version across resource configs.
+func annotateVersion(tx Tx, resourceID int, rcvID int, action string, annotation VersionAnnotation) error {
+	_, err := tx.Exec(`
+		INSERT INTO resource_version_annotations (resource_id, version_md5, action, user_name, comment)
+		SELECT $1, rcv.version_md5, $3, $4, $5
+		FROM resource_config_versions rcv
+		WHERE rcv.id = $2
+	`, resourceID, rcvID, action, annotation.UserName, annotation.Comment)
+	return err
+}
+
 func scanResource(r *resource, row scannable) error {
 	var (
 		configBlob                                                                  []byte
 		checkErr, rcsCheckErr, nonce, rcID, rcScopeID, apiPinnedVersion, pinComment sql.NullString
+		resourceTypeDefaults, pipelineNonce                                         sql.NullString
 		lastChecked                                                                 pq.NullTime
 	)
 
-	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &lastChecked, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment)
+	err := row.Scan(&r.id, &r.name, &configBlob, &checkErr, &lastChecked, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamName, &rcsCheckErr, &apiPinnedVersion, &pinComment, &resourceTypeDefaults, &pipelineNonce, &r.rateLimited)
 	if err != nil {
 		return err
 	}
@@ -616,10 +976,18 @@ func scanResource(r *resource, row scannable) error {
 
 	r.type_ = config.Type
 	r.source = config.Source
+
+	r.resourceTypeDefaults, err = decryptResourceTypeDefaults(es, resourceTypeDefaults, pipelineNonce)
+	if err != nil {
+		return err
+	}
 	r.checkEvery = config.CheckEvery
 	r.checkTimeout = config.CheckTimeout
 	r.tags = config.Tags
+	r.workerSelector = config.WorkerSelector
 	r.webhookToken = config.WebhookToken
+	r.webhook = config.Webhook
+	r.versionHistory = config.VersionHistory
 	r.configPinnedVersion = config.Version
 
 	if apiPinnedVersion.Valid {
@@ -38,6 +38,8 @@ type ResourceConfigScope interface {
 		interval time.Duration,
 	) (lock.Lock, bool, error)
 
+	CheckDue(interval time.Duration) (bool, error)
+
 	UpdateLastChecked(
 		interval time.Duration,
 		immediate bool,
@@ -188,6 +190,23 @@ func (r *resourceConfigScope) AcquireResourceCheckingLock(
 	)
 }
 
+// CheckDue returns whether the interval has elapsed since the scope was last
+// checked, without updating it.
+func (r *resourceConfigScope) CheckDue(interval time.Duration) (bool, error) {
+	var due bool
+	err := psql.Select("now() - last_checked > (? || ' SECONDS')::INTERVAL").
+		From("resource_config_scopes").
+		Where(sq.Eq{"id": r.id}).
+		RunWith(r.conn).
+		QueryRow().
+		Scan(&due)
+	if err != nil {
+		return false, err
+	}
+
+	return due, nil
+}
+
 func (r *resourceConfigScope) UpdateLastChecked(
 	interval time.Duration,
 	immediate bool,
@@ -209,7 +228,7 @@ func (r *resourceConfigScope) UpdateLastChecked(
 
 	updated, err := checkIfRowsUpdated(tx, `
 			UPDATE resource_config_scopes
-			SET last_checked = now()
+			SET last_checked = now(), rate_limited = false
 			WHERE id = $1
 		`+condition, params...)
 	if err != nil {
@@ -27,7 +27,7 @@ var _ = Describe("Resource Config Scope", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(setupTx.Commit()).To(Succeed())
 
-		pipeline, _, err := defaultTeam.SavePipeline("scope-pipeline", atc.Config{
+		pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "scope-pipeline"}, atc.Config{
 			Resources: atc.ResourceConfigs{
 				{
 					Name: "some-resource",
@@ -210,6 +210,12 @@ var _ = Describe("Resource Config Scope", func() {
 		})
 
 		Context("when there has not been a check", func() {
+			It("is due", func() {
+				due, err := resourceConfigScope.CheckDue(1 * time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(due).To(BeTrue())
+			})
+
 			It("should update the last checked", func() {
 				updated, err := resourceConfigScope.UpdateLastChecked(1*time.Second, false)
 				Expect(err).ToNot(HaveOccurred())
@@ -232,6 +238,18 @@ var _ = Describe("Resource Config Scope", func() {
 				Expect(updated).To(BeTrue())
 			})
 
+			It("is not due until the interval has elapsed", func() {
+				due, err := resourceConfigScope.CheckDue(1 * time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(due).To(BeFalse())
+
+				time.Sleep(time.Second)
+
+				due, err = resourceConfigScope.CheckDue(1 * time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(due).To(BeTrue())
+			})
+
 			Context("when not immediate", func() {
 				It("does not update the last checked", func() {
 					updated, err := resourceConfigScope.UpdateLastChecked(1*time.Second, false)
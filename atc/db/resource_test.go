@@ -23,7 +23,7 @@ var _ = Describe("Resource", func() {
 		)
 
 		pipeline, created, err = defaultTeam.SavePipeline(
-			"pipeline-with-resources",
+			atc.PipelineRef{Name: "pipeline-with-resources"},
 			atc.Config{
 				Resources: atc.ResourceConfigs{
 					{
@@ -115,6 +115,45 @@ var _ = Describe("Resource", func() {
 				Expect(resource.Name()).To(Equal("some-resource"))
 				Expect(resource.Type()).To(Equal("registry-image"))
 				Expect(resource.Source()).To(Equal(atc.Source{"some": "repository"}))
+				Expect(resource.SourceWithDefaults()).To(Equal(atc.Source{"some": "repository"}))
+			})
+
+			Context("when the pipeline has defaults for the resource's type", func() {
+				BeforeEach(func() {
+					config := atc.Config{
+						Resources: atc.ResourceConfigs{
+							{
+								Name:   "some-resource",
+								Type:   "registry-image",
+								Source: atc.Source{"some": "repository"},
+							},
+						},
+						ResourceTypeDefaults: atc.ResourceTypeDefaults{
+							"registry-image": atc.Source{"some": "default", "mirror": "some-mirror"},
+						},
+					}
+
+					pipeline, _, err = defaultTeam.SavePipeline(pipeline.Ref(), config, pipeline.ConfigVersion(), db.PipelineNoChange)
+					Expect(err).ToNot(HaveOccurred())
+
+					resource, found, err = pipeline.Resource("some-resource")
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("keeps the pipeline's defaults", func() {
+					Expect(pipeline.ResourceTypeDefaults()).To(Equal(atc.ResourceTypeDefaults{
+						"registry-image": atc.Source{"some": "default", "mirror": "some-mirror"},
+					}))
+				})
+
+				It("merges the defaults beneath the resource's source", func() {
+					Expect(found).To(BeTrue())
+					Expect(resource.Source()).To(Equal(atc.Source{"some": "repository"}))
+					Expect(resource.SourceWithDefaults()).To(Equal(atc.Source{
+						"some":   "repository",
+						"mirror": "some-mirror",
+					}))
+				})
 			})
 
 			Context("when the resource config id is set on the resource for the first time", func() {
@@ -236,7 +275,7 @@ var _ = Describe("Resource", func() {
 			}
 
 			pipeline, created, err = defaultTeam.SavePipeline(
-				"pipeline-with-same-resources",
+				atc.PipelineRef{Name: "pipeline-with-same-resources"},
 				config,
 				0,
 				db.PipelineUnpaused,
@@ -456,7 +495,7 @@ var _ = Describe("Resource", func() {
 					BeforeEach(func() {
 						config.Resources[2].Source = atc.Source{"some": "other-repo"}
 						newPipeline, _, err := defaultTeam.SavePipeline(
-							"pipeline-with-same-resources",
+							atc.PipelineRef{Name: "pipeline-with-same-resources"},
 							config,
 							pipeline.ConfigVersion(),
 							db.PipelineUnpaused,
@@ -488,7 +527,7 @@ var _ = Describe("Resource", func() {
 					BeforeEach(func() {
 						config.ResourceTypes[0].UniqueVersionHistory = false
 						newPipeline, _, err := defaultTeam.SavePipeline(
-							"pipeline-with-same-resources",
+							atc.PipelineRef{Name: "pipeline-with-same-resources"},
 							config,
 							pipeline.ConfigVersion(),
 							db.PipelineUnpaused,
@@ -874,7 +913,10 @@ var _ = Describe("Resource", func() {
 
 			Context("when a version is disabled", func() {
 				BeforeEach(func() {
-					err := resource.DisableVersion(resourceVersions[9].ID)
+					err := resource.DisableVersion(resourceVersions[9].ID, db.VersionAnnotation{
+						UserName: "some-user",
+						Comment:  "the version is broken",
+					})
 					Expect(err).ToNot(HaveOccurred())
 
 					resourceVersions[9].Enabled = false
@@ -884,7 +926,48 @@ var _ = Describe("Resource", func() {
 					historyPage, _, found, err := resource.Versions(db.Page{Limit: 1})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
-					Expect(historyPage).To(ConsistOf([]atc.ResourceVersion{resourceVersions[9]}))
+					Expect(historyPage).To(HaveLen(1))
+					Expect(historyPage[0].ID).To(Equal(resourceVersions[9].ID))
+					Expect(historyPage[0].Enabled).To(BeFalse())
+				})
+
+				It("returns the annotation for disabling it", func() {
+					historyPage, _, _, err := resource.Versions(db.Page{Limit: 1})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(historyPage[0].Annotations).To(HaveLen(1))
+
+					annotation := historyPage[0].Annotations[0]
+					Expect(annotation.Action).To(Equal(atc.VersionActionDisable))
+					Expect(annotation.UserName).To(Equal("some-user"))
+					Expect(annotation.Comment).To(Equal("the version is broken"))
+					Expect(annotation.CreatedAt).ToNot(BeZero())
+				})
+
+				Context("when the version is enabled again", func() {
+					BeforeEach(func() {
+						err := resource.EnableVersion(resourceVersions[9].ID, db.VersionAnnotation{
+							UserName: "some-other-user",
+							Comment:  "the version was fixed",
+						})
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("returns the history of annotations, oldest first", func() {
+						historyPage, _, _, err := resource.Versions(db.Page{Limit: 2})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(historyPage[0].Enabled).To(BeTrue())
+						Expect(historyPage[0].Annotations).To(HaveLen(2))
+						Expect(historyPage[0].Annotations[0].Action).To(Equal(atc.VersionActionDisable))
+						Expect(historyPage[0].Annotations[1].Action).To(Equal(atc.VersionActionEnable))
+						Expect(historyPage[0].Annotations[1].UserName).To(Equal("some-other-user"))
+						Expect(historyPage[0].Annotations[1].Comment).To(Equal("the version was fixed"))
+					})
+
+					It("does not annotate other versions", func() {
+						historyPage, _, _, err := resource.Versions(db.Page{Limit: 2})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(historyPage[1].Annotations).To(BeEmpty())
+					})
 				})
 			})
 		})
@@ -1060,6 +1143,149 @@ var _ = Describe("Resource", func() {
 		})
 	})
 
+	Describe("EnabledVersions", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			var err error
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "registry-image",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			resourceScope, err := resource.SetResourceConfig(logger, atc.Source{"some": "repository"}, creds.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceScope.SaveVersions([]atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = resource.Reload()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the versions which have not been disabled by their ID", func() {
+			v1, found, err := resource.ResourceConfigVersionID(atc.Version{"ref": "v1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			v2, found, err := resource.ResourceConfigVersionID(atc.Version{"ref": "v2"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = resource.DisableVersion(v1, db.VersionAnnotation{})
+			Expect(err).ToNot(HaveOccurred())
+
+			versions, err := resource.EnabledVersions()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(Equal(map[int]atc.Version{
+				v2: {"ref": "v2"},
+			}))
+		})
+	})
+
+	Describe("InjectVersion", func() {
+		var resource db.Resource
+
+		BeforeEach(func() {
+			var err error
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when the resource has not been checked", func() {
+			It("returns an error", func() {
+				_, err := resource.InjectVersion(atc.Version{"ref": "v1"}, nil)
+				Expect(err).To(Equal(db.ErrResourceNotChecked))
+			})
+		})
+
+		Context("when the resource has a config scope", func() {
+			var resourceScope db.ResourceConfigScope
+
+			BeforeEach(func() {
+				setupTx, err := dbConn.Begin()
+				Expect(err).ToNot(HaveOccurred())
+
+				brt := db.BaseResourceType{
+					Name: "registry-image",
+				}
+
+				_, err = brt.FindOrCreate(setupTx, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(setupTx.Commit()).To(Succeed())
+
+				resourceScope, err = resource.SetResourceConfig(logger, atc.Source{"some": "repository"}, creds.VersionedResourceTypes{})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resourceScope.SaveVersions([]atc.Version{{"ref": "v1"}, {"ref": "v2"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = resource.Reload()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("saves the version as the latest one, marked as injected", func() {
+				created, err := resource.InjectVersion(atc.Version{"ref": "v3"}, db.ResourceConfigMetadataFields{
+					{Name: "author", Value: "someone"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				latest, found, err := resourceScope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(latest.Version()).To(Equal(db.Version{"ref": "v3"}))
+				Expect(latest.Metadata()).To(Equal(db.ResourceConfigMetadataFields{
+					{Name: "author", Value: "someone"},
+					{Name: db.ManuallyInjectedMetadataField, Value: "true"},
+				}))
+			})
+
+			It("leaves the metadata and order of an existing version unchanged", func() {
+				created, err := resource.InjectVersion(atc.Version{"ref": "v1"}, db.ResourceConfigMetadataFields{
+					{Name: "author", Value: "someone"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+
+				latest, found, err := resourceScope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(latest.Version()).To(Equal(db.Version{"ref": "v2"}))
+
+				existing, found, err := resourceScope.FindVersion(atc.Version{"ref": "v1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(existing.Metadata()).To(BeNil())
+			})
+
+			It("notifies the scheduler of the pipeline", func() {
+				notifier, err := pipeline.SchedulingNotifier()
+				Expect(err).ToNot(HaveOccurred())
+
+				defer notifier.Close()
+
+				_, err = resource.InjectVersion(atc.Version{"ref": "v3"}, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+		})
+	})
+
 	Describe("PinVersion/UnpinVersion", func() {
 		var resource db.Resource
 		var resID int
@@ -1100,7 +1326,7 @@ var _ = Describe("Resource", func() {
 
 		Context("when we pin a resource to a version", func() {
 			BeforeEach(func() {
-				err := resource.PinVersion(resID)
+				err := resource.PinVersion(resID, db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				found, err := resource.Reload()
@@ -1113,6 +1339,16 @@ var _ = Describe("Resource", func() {
 				Expect(resource.CurrentPinnedVersion()).To(Equal(resource.APIPinnedVersion()))
 			})
 
+			It("annotates the version as pinned", func() {
+				versions, _, found, err := resource.Versions(db.Page{Limit: 3})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(versions[2].ID).To(Equal(resID))
+				Expect(versions[2].Annotations).To(HaveLen(1))
+				Expect(versions[2].Annotations[0].Action).To(Equal(atc.VersionActionPin))
+			})
+
 			Context("when we set the pin comment on a resource", func() {
 				BeforeEach(func() {
 					err := resource.SetPinComment("foo")
@@ -1180,7 +1416,7 @@ var _ = Describe("Resource", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				err = resource.PinVersion(resConf.ID())
+				err = resource.PinVersion(resConf.ID(), db.VersionAnnotation{})
 				Expect(err).ToNot(HaveOccurred())
 
 				found, err = resource.Reload()
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db/lock"
+	"github.com/lib/pq"
 )
 
 type ResourceTypeNotFoundError struct {
@@ -25,16 +27,20 @@ func (e ResourceTypeNotFoundError) Error() string {
 
 type ResourceType interface {
 	ID() int
+	PipelineID() int
 	Name() string
 	Type() string
 	Privileged() bool
 	Source() atc.Source
+	SourceWithDefaults() atc.Source
 	Params() atc.Params
 	Tags() atc.Tags
+	WorkerSelector() atc.WorkerSelector
 	CheckEvery() string
 	CheckSetupError() error
 	CheckError() error
 	UniqueVersionHistory() bool
+	LastChecked() time.Time
 
 	SetResourceConfig(lager.Logger, atc.Source, creds.VersionedResourceTypes) (ResourceConfigScope, error)
 	SetCheckSetupError(error) error
@@ -54,12 +60,13 @@ func (resourceTypes ResourceTypes) Deserialize() atc.VersionedResourceTypes {
 			ResourceType: atc.ResourceType{
 				Name:                 t.Name(),
 				Type:                 t.Type(),
-				Source:               t.Source(),
+				Source:               t.SourceWithDefaults(),
 				Privileged:           t.Privileged(),
 				CheckEvery:           t.CheckEvery(),
 				Tags:                 t.Tags(),
 				Params:               t.Params(),
 				UniqueVersionHistory: t.UniqueVersionHistory(),
+				WorkerSelector:       t.WorkerSelector(),
 			},
 			Version: t.Version(),
 		})
@@ -81,14 +88,16 @@ func (resourceTypes ResourceTypes) Configs() atc.ResourceTypes {
 			Tags:                 r.Tags(),
 			Params:               r.Params(),
 			UniqueVersionHistory: r.UniqueVersionHistory(),
+			WorkerSelector:       r.WorkerSelector(),
 		})
 	}
 
 	return configs
 }
 
-var resourceTypesQuery = psql.Select("r.id, r.name, r.type, r.config, rcv.version, r.nonce, r.check_error, ro.check_error").
+var resourceTypesQuery = psql.Select("r.id, r.pipeline_id, r.name, r.type, r.config, rcv.version, r.nonce, r.check_error, ro.check_error, ro.last_checked, p.resource_type_defaults, p.nonce").
 	From("resource_types r").
+	Join("pipelines p ON p.id = r.pipeline_id").
 	LeftJoin("resource_configs c ON c.id = r.resource_config_id").
 	LeftJoin("resource_config_scopes ro ON ro.resource_config_id = c.id").
 	LeftJoin(`LATERAL (
@@ -102,23 +111,28 @@ var resourceTypesQuery = psql.Select("r.id, r.name, r.type, r.config, rcv.versio
 
 type resourceType struct {
 	id                   int
+	pipelineID           int
 	name                 string
 	type_                string
 	privileged           bool
 	source               atc.Source
+	resourceTypeDefaults atc.ResourceTypeDefaults
 	params               atc.Params
 	tags                 atc.Tags
+	workerSelector       atc.WorkerSelector
 	version              atc.Version
 	checkEvery           string
 	checkSetupError      error
 	checkError           error
 	uniqueVersionHistory bool
+	lastChecked          time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
 }
 
 func (t *resourceType) ID() int                    { return t.id }
+func (t *resourceType) PipelineID() int            { return t.pipelineID }
 func (t *resourceType) Name() string               { return t.name }
 func (t *resourceType) Type() string               { return t.type_ }
 func (t *resourceType) Privileged() bool           { return t.privileged }
@@ -129,9 +143,18 @@ func (t *resourceType) Tags() atc.Tags             { return t.tags }
 func (t *resourceType) CheckSetupError() error     { return t.checkSetupError }
 func (t *resourceType) CheckError() error          { return t.checkError }
 func (t *resourceType) UniqueVersionHistory() bool { return t.uniqueVersionHistory }
+func (t *resourceType) LastChecked() time.Time     { return t.lastChecked }
 
 func (t *resourceType) Version() atc.Version { return t.version }
 
+func (t *resourceType) WorkerSelector() atc.WorkerSelector { return t.workerSelector }
+
+// SourceWithDefaults returns the source with the resource_type_defaults of
+// the pipeline for the resource type's own type merged beneath it.
+func (t *resourceType) SourceWithDefaults() atc.Source {
+	return t.resourceTypeDefaults.Apply(t.type_, t.source)
+}
+
 func (t *resourceType) Reload() (bool, error) {
 	row := resourceTypesQuery.Where(sq.Eq{"r.id": t.id}).RunWith(t.conn).QueryRow()
 
@@ -201,6 +224,7 @@ func (t *resourceType) SetCheckSetupError(cause error) error {
 	} else {
 		_, err = psql.Update("resource_types").
 			Set("check_error", cause.Error()).
+			Set("last_check_setup_failed", sq.Expr("now()")).
 			Where(sq.Eq{"id": t.id}).
 			RunWith(t.conn).
 			Exec()
@@ -213,13 +237,17 @@ func scanResourceType(t *resourceType, row scannable) error {
 	var (
 		configJSON                            []byte
 		checkErr, rcsCheckErr, version, nonce sql.NullString
+		resourceTypeDefaults, pipelineNonce   sql.NullString
+		lastChecked                           pq.NullTime
 	)
 
-	err := row.Scan(&t.id, &t.name, &t.type_, &configJSON, &version, &nonce, &checkErr, &rcsCheckErr)
+	err := row.Scan(&t.id, &t.pipelineID, &t.name, &t.type_, &configJSON, &version, &nonce, &checkErr, &rcsCheckErr, &lastChecked, &resourceTypeDefaults, &pipelineNonce)
 	if err != nil {
 		return err
 	}
 
+	t.lastChecked = lastChecked.Time
+
 	if version.Valid {
 		err = json.Unmarshal([]byte(version.String), &t.version)
 		if err != nil {
@@ -246,9 +274,15 @@ func scanResourceType(t *resourceType, row scannable) error {
 	}
 
 	t.source = config.Source
+
+	t.resourceTypeDefaults, err = decryptResourceTypeDefaults(es, resourceTypeDefaults, pipelineNonce)
+	if err != nil {
+		return err
+	}
 	t.params = config.Params
 	t.privileged = config.Privileged
 	t.tags = config.Tags
+	t.workerSelector = config.WorkerSelector
 	t.checkEvery = config.CheckEvery
 	t.uniqueVersionHistory = config.UniqueVersionHistory
 
@@ -20,7 +20,7 @@ var _ = Describe("ResourceType", func() {
 		)
 
 		pipeline, created, err = defaultTeam.SavePipeline(
-			"pipeline-with-types",
+			atc.PipelineRef{Name: "pipeline-with-types"},
 			atc.Config{
 				ResourceTypes: atc.ResourceTypes{
 					{
@@ -108,7 +108,7 @@ var _ = Describe("ResourceType", func() {
 				)
 
 				pipeline, created, err = defaultTeam.SavePipeline(
-					"pipeline-with-types",
+					atc.PipelineRef{Name: "pipeline-with-types"},
 					atc.Config{
 						ResourceTypes: atc.ResourceTypes{
 							{
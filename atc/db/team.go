@@ -30,13 +30,13 @@ type Team interface {
 	Rename(string) error
 
 	SavePipeline(
-		pipelineName string,
+		pipelineRef atc.PipelineRef,
 		config atc.Config,
 		from ConfigVersion,
 		pausedState PipelinePausedState,
 	) (Pipeline, bool, error)
 
-	Pipeline(pipelineName string) (Pipeline, bool, error)
+	Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
 	VisiblePipelines() ([]Pipeline, error)
@@ -54,7 +54,7 @@ type Team interface {
 	IsCheckContainer(string) (bool, error)
 	IsContainerWithinTeam(string, bool) (bool, error)
 	FindContainerByHandle(string) (Container, bool, error)
-	FindCheckContainers(lager.Logger, string, string, creds.VariablesFactory) ([]Container, map[int]time.Time, error)
+	FindCheckContainers(lager.Logger, atc.PipelineRef, string, creds.VariablesFactory, atc.ResourceTypeDefaults) ([]Container, map[int]time.Time, error)
 	FindContainersByMetadata(ContainerMetadata) ([]Container, error)
 	FindCreatedContainerByHandle(string) (CreatedContainer, bool, error)
 	FindWorkerForContainer(handle string) (Worker, bool, error)
@@ -304,7 +304,7 @@ func (t *team) FindCreatedContainerByHandle(
 }
 
 func (t *team) SavePipeline(
-	pipelineName string,
+	pipelineRef atc.PipelineRef,
 	config atc.Config,
 	from ConfigVersion,
 	pausedState PipelinePausedState,
@@ -314,6 +314,30 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	var resourceTypeDefaultsPayload, resourceTypeDefaultsNonce *string
+	if len(config.ResourceTypeDefaults) != 0 {
+		defaultsPayload, err := json.Marshal(config.ResourceTypeDefaults)
+		if err != nil {
+			return nil, false, err
+		}
+
+		encryptedPayload, nonce, err := t.conn.EncryptionStrategy().Encrypt(defaultsPayload)
+		if err != nil {
+			return nil, false, err
+		}
+
+		resourceTypeDefaultsPayload = &encryptedPayload
+		resourceTypeDefaultsNonce = nonce
+	}
+
+	var instanceVarsPayload []byte
+	if len(pipelineRef.InstanceVars) != 0 {
+		instanceVarsPayload, err = json.Marshal(pipelineRef.InstanceVars)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	jobGroups := make(map[string][]string)
 	for _, group := range config.Groups {
 		for _, job := range group.Jobs {
@@ -331,12 +355,16 @@ func (t *team) SavePipeline(
 
 	defer Rollback(tx)
 
-	err = tx.QueryRow(`
-		SELECT COUNT(1)
-		FROM pipelines
-		WHERE name = $1
-	  AND team_id = $2
-	`, pipelineName, t.id).Scan(&existingConfig)
+	err = psql.Select("COUNT(1)").
+		From("pipelines").
+		Where(sq.Eq{
+			"name":    pipelineRef.Name,
+			"team_id": t.id,
+		}).
+		Where(instanceVarsEq("instance_vars", pipelineRef.InstanceVars)).
+		RunWith(tx).
+		QueryRow().
+		Scan(&existingConfig)
 	if err != nil {
 		return nil, false, err
 	}
@@ -349,12 +377,20 @@ func (t *team) SavePipeline(
 
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"groups":   groupsPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("currval('pipelines_id_seq')"),
-				"paused":   pausedState.Bool(),
-				"team_id":  t.id,
+				"name":                   pipelineRef.Name,
+				"instance_vars":          instanceVarsPayload,
+				"groups":                 groupsPayload,
+				"resource_type_defaults": resourceTypeDefaultsPayload,
+				"nonce":                  resourceTypeDefaultsNonce,
+				"version":                sq.Expr("nextval('config_version_seq')"),
+				// instances are ordered alongside the other instances of the
+				// same pipeline
+				"ordering": sq.Expr(`COALESCE(
+					(SELECT MIN(ordering) FROM pipelines WHERE name = ? AND team_id = ?),
+					currval('pipelines_id_seq')
+				)`, pipelineRef.Name, t.id),
+				"paused":  pausedState.Bool(),
+				"team_id": t.id,
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -367,12 +403,16 @@ func (t *team) SavePipeline(
 	} else {
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
+			Set("resource_type_defaults", resourceTypeDefaultsPayload).
+			Set("nonce", resourceTypeDefaultsNonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
+			Set("archived", false).
 			Where(sq.Eq{
-				"name":    pipelineName,
+				"name":    pipelineRef.Name,
 				"version": from,
 				"team_id": t.id,
 			}).
+			Where(instanceVarsEq("instance_vars", pipelineRef.InstanceVars)).
 			Suffix("RETURNING id")
 
 		if pausedState != PipelineNoChange {
@@ -492,7 +532,7 @@ func (t *team) SavePipeline(
 	return pipeline, created, nil
 }
 
-func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
+func (t *team) Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error) {
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
 	err := scanPipeline(
@@ -500,8 +540,9 @@ func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
 		pipelinesQuery.
 			Where(sq.Eq{
 				"p.team_id": t.id,
-				"p.name":    pipelineName,
+				"p.name":    pipelineRef.Name,
 			}).
+			Where(instanceVarsEq("p.instance_vars", pipelineRef.InstanceVars)).
 			RunWith(t.conn).
 			QueryRow(),
 	)
@@ -520,7 +561,7 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
-		OrderBy("ordering").
+		OrderBy("ordering", "p.id").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -541,7 +582,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 			"team_id": t.id,
 			"public":  true,
 		}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -559,7 +600,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 func (t *team) VisiblePipelines() ([]Pipeline, error) {
 	rows, err := pipelinesQuery.
 		Where(sq.Eq{"team_id": t.id}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -574,7 +615,7 @@ func (t *team) VisiblePipelines() ([]Pipeline, error) {
 	rows, err = pipelinesQuery.
 		Where(sq.NotEq{"team_id": t.id}).
 		Where(sq.Eq{"public": true}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -709,8 +750,8 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
-func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, resourceName string, variablesFactory creds.VariablesFactory) ([]Container, map[int]time.Time, error) {
-	pipeline, found, err := t.Pipeline(pipelineName)
+func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string, variablesFactory creds.VariablesFactory, baseDefaults atc.ResourceTypeDefaults) ([]Container, map[int]time.Time, error) {
+	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -733,9 +774,9 @@ func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, res
 
 	variables := variablesFactory.NewVariables(t.name, pipeline.Name())
 
-	versionedResourceTypes := pipelineResourceTypes.Deserialize()
+	versionedResourceTypes := pipelineResourceTypes.Deserialize().WithDefaults(baseDefaults)
 
-	source, err := creds.NewSource(variables, resource.Source()).Evaluate()
+	source, err := creds.NewSource(variables, baseDefaults.Apply(resource.Type(), resource.SourceWithDefaults())).Evaluate()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -864,11 +905,13 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 		return err
 	}
 
+	checkInterval := checkIntervalSeconds(resource.CheckEvery)
+
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resources
-		SET config = $3, active = true, nonce = $4
+		SET config = $3, active = true, nonce = $4, check_interval = ($5 || ' SECONDS')::INTERVAL, last_check_setup_failed = NULL
 		WHERE name = $1 AND pipeline_id = $2
-	`, resource.Name, pipelineID, encryptedPayload, nonce)
+	`, resource.Name, pipelineID, encryptedPayload, nonce, checkInterval)
 	if err != nil {
 		return err
 	}
@@ -893,9 +936,9 @@ func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int)
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO resources (name, pipeline_id, config, active, nonce)
-		VALUES ($1, $2, $3, true, $4)
-	`, resource.Name, pipelineID, encryptedPayload, nonce)
+		INSERT INTO resources (name, pipeline_id, config, active, nonce, check_interval)
+		VALUES ($1, $2, $3, true, $4, ($5 || ' SECONDS')::INTERVAL)
+	`, resource.Name, pipelineID, encryptedPayload, nonce, checkInterval)
 
 	return swallowUniqueViolation(err)
 }
@@ -912,11 +955,13 @@ func (t *team) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID
 		return err
 	}
 
+	checkInterval := checkIntervalSeconds(resourceType.CheckEvery)
+
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE resource_types
-		SET config = $3, type = $4, active = true, nonce = $5
+		SET config = $3, type = $4, active = true, nonce = $5, check_interval = ($6 || ' SECONDS')::INTERVAL, last_check_setup_failed = NULL
 		WHERE name = $1 AND pipeline_id = $2
-	`, resourceType.Name, pipelineID, encryptedPayload, resourceType.Type, nonce)
+	`, resourceType.Name, pipelineID, encryptedPayload, resourceType.Type, nonce, checkInterval)
 	if err != nil {
 		return err
 	}
@@ -926,13 +971,30 @@ func (t *team) saveResourceType(tx Tx, resourceType atc.ResourceType, pipelineID
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO resource_types (name, type, pipeline_id, config, active, nonce)
-		VALUES ($1, $2, $3, $4, true, $5)
-	`, resourceType.Name, resourceType.Type, pipelineID, encryptedPayload, nonce)
+		INSERT INTO resource_types (name, type, pipeline_id, config, active, nonce, check_interval)
+		VALUES ($1, $2, $3, $4, true, $5, ($6 || ' SECONDS')::INTERVAL)
+	`, resourceType.Name, resourceType.Type, pipelineID, encryptedPayload, nonce, checkInterval)
 
 	return swallowUniqueViolation(err)
 }
 
+// checkIntervalSeconds returns the check_every to save alongside a resource or
+// resource type so that the checks which are due can be found by a query. It
+// is nil if check_every is unset or invalid, in which case the default
+// interval applies; the scanner reports an invalid check_every.
+func checkIntervalSeconds(checkEvery string) interface{} {
+	if checkEvery == "" {
+		return nil
+	}
+
+	interval, err := time.ParseDuration(checkEvery)
+	if err != nil {
+		return nil
+	}
+
+	return interval.Seconds()
+}
+
 func checkIfRowsUpdated(tx Tx, query string, params ...interface{}) (bool, error) {
 	result, err := tx.Exec(query, params...)
 	if err != nil {
@@ -988,12 +1050,24 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 }
 
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var groups, instanceVars, resourceTypeDefaults, nonce sql.NullString
+	err := scan.Scan(&p.id, &p.name, &instanceVars, &groups, &resourceTypeDefaults, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived)
+	if err != nil {
+		return err
+	}
+
+	p.resourceTypeDefaults, err = decryptResourceTypeDefaults(p.conn.EncryptionStrategy(), resourceTypeDefaults, nonce)
 	if err != nil {
 		return err
 	}
 
+	if instanceVars.Valid {
+		err = json.Unmarshal([]byte(instanceVars.String), &p.instanceVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	if groups.Valid {
 		var pipelineGroups atc.GroupConfigs
 		err = json.Unmarshal([]byte(groups.String), &pipelineGroups)
@@ -1007,6 +1081,17 @@ func scanPipeline(p *pipeline, scan scannable) error {
 	return nil
 }
 
+// instanceVarsEq matches pipelines with exactly the given instance vars,
+// treating pipelines without any as having an empty set.
+func instanceVarsEq(column string, instanceVars atc.InstanceVars) sq.Sqlizer {
+	payload := []byte("{}")
+	if len(instanceVars) != 0 {
+		payload, _ = json.Marshal(instanceVars)
+	}
+
+	return sq.Expr("COALESCE("+column+", '{}'::jsonb) = ?::jsonb", string(payload))
+}
+
 func scanPipelines(conn Conn, lockFactory lock.LockFactory, rows *sql.Rows) ([]Pipeline, error) {
 	defer Close(rows)
 
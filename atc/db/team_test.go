@@ -42,7 +42,7 @@ var _ = Describe("Team", func() {
 		var otherTeamPipeline db.Pipeline
 
 		BeforeEach(func() {
-			otherTeamPipeline, _, err = otherTeam.SavePipeline("fake-pipeline", atc.Config{
+			otherTeamPipeline, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{Name: "job-name"},
 				},
@@ -518,7 +518,7 @@ var _ = Describe("Team", func() {
 					otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
 					Expect(err).NotTo(HaveOccurred())
 
-					otherPipeline, _, err := otherTeam.SavePipeline("other-pipeline", atc.Config{
+					otherPipeline, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{
 								Name: "some-job",
@@ -822,14 +822,14 @@ var _ = Describe("Team", func() {
 		Context("when the team has configured pipelines", func() {
 			BeforeEach(func() {
 				var err error
-				pipeline1, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+				pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline2, _, err = team.SavePipeline("fake-pipeline-two", atc.Config{
+				pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-two"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
@@ -863,14 +863,14 @@ var _ = Describe("Team", func() {
 		Context("when the team has configured pipelines", func() {
 			BeforeEach(func() {
 				var err error
-				_, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline2, _, err = team.SavePipeline("fake-pipeline-two", atc.Config{
+				pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-two"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
@@ -912,14 +912,14 @@ var _ = Describe("Team", func() {
 		Context("when the team has configured pipelines", func() {
 			BeforeEach(func() {
 				var err error
-				pipeline1, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+				pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline2, _, err = otherTeam.SavePipeline("fake-pipeline-two", atc.Config{
+				pipeline2, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-two"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
@@ -937,7 +937,7 @@ var _ = Describe("Team", func() {
 			Context("when the other team has a private pipeline", func() {
 				BeforeEach(func() {
 					var err error
-					_, _, err = otherTeam.SavePipeline("fake-pipeline-three", atc.Config{
+					_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-three"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{Name: "job-fake-again"},
 						},
@@ -966,14 +966,14 @@ var _ = Describe("Team", func() {
 
 		BeforeEach(func() {
 			var err error
-			pipeline1, _, err = team.SavePipeline("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			pipeline2, _, err = team.SavePipeline("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline1, _, err = otherTeam.SavePipeline("pipeline-name-a", atc.Config{}, 0, db.PipelineUnpaused)
+			otherPipeline1, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			otherPipeline2, _, err = otherTeam.SavePipeline("pipeline-name-b", atc.Config{}, 0, db.PipelineUnpaused)
+			otherPipeline2, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1059,7 +1059,7 @@ var _ = Describe("Team", func() {
 					},
 				}
 				var err error
-				pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := pipeline.Job("some-job")
@@ -1210,7 +1210,7 @@ var _ = Describe("Team", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1316,7 +1316,7 @@ var _ = Describe("Team", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1568,26 +1568,26 @@ var _ = Describe("Team", func() {
 		})
 
 		It("returns true for created", func() {
-			_, created, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			_, created, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
 		})
 
 		It("caches the team id", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.TeamID()).To(Equal(team.ID()))
 		})
 
 		It("can be saved as paused", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelinePaused)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelinePaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
@@ -1595,10 +1595,10 @@ var _ = Describe("Team", func() {
 		})
 
 		It("can be saved as unpaused", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineUnpaused)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
@@ -1606,18 +1606,96 @@ var _ = Describe("Team", func() {
 		})
 
 		It("defaults to paused", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
 			Expect(pipeline.Paused()).To(BeTrue())
 		})
 
+		Context("when instance vars are given", func() {
+			var instanceRef atc.PipelineRef
+
+			BeforeEach(func() {
+				instanceRef = atc.PipelineRef{
+					Name:         pipelineName,
+					InstanceVars: atc.InstanceVars{"branch": "5.1"},
+				}
+			})
+
+			It("creates an instance separate from the pipeline without instance vars", func() {
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				instance, created, err := team.SavePipeline(instanceRef, otherConfig, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(instance.ID()).ToNot(Equal(pipeline.ID()))
+				Expect(instance.Name()).To(Equal(pipelineName))
+				Expect(instance.InstanceVars()).To(Equal(instanceRef.InstanceVars))
+
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.InstanceVars()).To(BeNil())
+			})
+
+			It("updates the instance with the same instance vars", func() {
+				instance, _, err := team.SavePipeline(instanceRef, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				updated, created, err := team.SavePipeline(instanceRef, otherConfig, instance.ConfigVersion(), db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+				Expect(updated.ID()).To(Equal(instance.ID()))
+			})
+
+			It("can be found by its instance vars", func() {
+				instance, _, err := team.SavePipeline(instanceRef, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipeline, found, err := team.Pipeline(instanceRef)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.ID()).To(Equal(instance.ID()))
+				Expect(pipeline.Ref()).To(Equal(instanceRef))
+
+				_, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("lists the instances of a pipeline next to each other", func() {
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = team.SavePipeline(instanceRef, config, 0, db.PipelineNoChange)
+				Expect(err).ToNot(HaveOccurred())
+
+				pipelines, err := team.Pipelines()
+				Expect(err).ToNot(HaveOccurred())
+
+				refs := []atc.PipelineRef{}
+				for _, p := range pipelines {
+					refs = append(refs, p.Ref())
+				}
+
+				Expect(refs).To(Equal([]atc.PipelineRef{
+					{Name: pipelineName},
+					instanceRef,
+					{Name: "other-pipeline"},
+				}))
+			})
+		})
+
 		It("creates all of the resources from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := savedPipeline.Resource("some-resource")
@@ -1630,14 +1708,14 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates resource config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Resources[0].Source = atc.Source{
 				"source-other-config": "some-other-value",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := savedPipeline.Resource("some-resource")
@@ -1650,7 +1728,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("clears out api pinned version when resaving a pinned version on the pipeline config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := pipeline.Resource("some-resource")
@@ -1681,7 +1759,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			err = resource.PinVersion(rcv.ID())
+			err = resource.PinVersion(rcv.ID(), db.VersionAnnotation{})
 			Expect(err).ToNot(HaveOccurred())
 
 			reloaded, err := resource.Reload()
@@ -1693,7 +1771,7 @@ var _ = Describe("Team", func() {
 				"version": "v2",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err = savedPipeline.Resource("some-resource")
@@ -1704,7 +1782,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("does not clear the api pinned version when resaving pipeline config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := pipeline.Resource("some-resource")
@@ -1735,7 +1813,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			err = resource.PinVersion(rcv.ID())
+			err = resource.PinVersion(rcv.ID(), db.VersionAnnotation{})
 			Expect(err).ToNot(HaveOccurred())
 
 			reloaded, err := resource.Reload()
@@ -1743,7 +1821,7 @@ var _ = Describe("Team", func() {
 			Expect(reloaded).To(BeTrue())
 			Expect(resource.APIPinnedVersion()).To(Equal(atc.Version{"version": "v1"}))
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err = savedPipeline.Resource("some-resource")
@@ -1753,12 +1831,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks resource as inactive if it is no longer in config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Resources = []atc.ResourceConfig{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.Resource("some-resource")
@@ -1767,7 +1845,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the resource types from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceType, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -1780,14 +1858,14 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates resource type config from the pipeline in the database", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.ResourceTypes[0].Source = atc.Source{
 				"source-other-config": "some-other-value",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceType, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -1800,12 +1878,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks resource type as inactive if it is no longer in config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.ResourceTypes = []atc.ResourceType{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -1814,7 +1892,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the jobs from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-job")
@@ -1824,12 +1902,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates job config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Jobs[0].Public = false
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1839,12 +1917,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks job inactive when it is no longer in pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Jobs = []atc.JobConfig{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.Job("some-job")
@@ -1853,7 +1931,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("removes worker task caches for jobs that are no longer in pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1869,7 +1947,7 @@ var _ = Describe("Team", func() {
 
 			config.Jobs = []atc.JobConfig{}
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = workerTaskCacheFactory.Find(job.ID(), "some-task", "some-path", defaultWorker.Name())
@@ -1878,7 +1956,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("removes worker task caches for tasks that are no longer exist", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1904,7 +1982,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = workerTaskCacheFactory.Find(job.ID(), "some-task", "some-path", defaultWorker.Name())
@@ -1913,7 +1991,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the serial groups from the jobs in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			serialGroups := []SerialGroup{}
@@ -1944,7 +2022,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("saves tags in the jobs table", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, otherConfig, 0, db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, otherConfig, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-other-job")
@@ -1955,7 +2033,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates tags in the jobs table", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, otherConfig, 0, db.PipelineNoChange)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, otherConfig, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-other-job")
@@ -1975,7 +2053,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			savedPipeline, _, err = team.SavePipeline(pipelineName, otherConfig, savedPipeline.ConfigVersion(), db.PipelineNoChange)
+			savedPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, otherConfig, savedPipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = savedPipeline.Job("some-other-job")
@@ -1986,45 +2064,45 @@ var _ = Describe("Team", func() {
 		})
 
 		It("it returns created as false when updated", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineNoChange)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 
-			_, created, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+			_, created, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeFalse())
 		})
 
 		It("updating from paused to unpaused", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelinePaused)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelinePaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.Paused()).To(BeTrue())
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineUnpaused)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err = team.Pipeline(pipelineName)
+			pipeline, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.Paused()).To(BeFalse())
 		})
 
 		It("updating from unpaused to paused", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineUnpaused)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.Paused()).To(BeFalse())
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelinePaused)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelinePaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err = team.Pipeline(pipelineName)
+			pipeline, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.Paused()).To(BeTrue())
@@ -2032,36 +2110,36 @@ var _ = Describe("Team", func() {
 
 		Context("updating with no change", func() {
 			It("maintains paused if the pipeline is paused", func() {
-				_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelinePaused)
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelinePaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err := team.Pipeline(pipelineName)
+				pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeTrue())
 
-				_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err = team.Pipeline(pipelineName)
+				pipeline, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeTrue())
 			})
 
 			It("maintains unpaused if the pipeline is unpaused", func() {
-				_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineUnpaused)
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err := team.Pipeline(pipelineName)
+				pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeFalse())
 
-				_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), db.PipelineNoChange)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), db.PipelineNoChange)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err = team.Pipeline(pipelineName)
+				pipeline, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeFalse())
@@ -2072,12 +2150,12 @@ var _ = Describe("Team", func() {
 			pipelineName := "a-pipeline-name"
 			otherPipelineName := "an-other-pipeline-name"
 
-			_, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineUnpaused)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			_, _, err = team.SavePipeline(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, otherConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.Name()).To(Equal(pipelineName))
@@ -2095,7 +2173,7 @@ var _ = Describe("Team", func() {
 				Jobs:          jobs.Configs(),
 			}, config)
 
-			otherPipeline, found, err := team.Pipeline(otherPipelineName)
+			otherPipeline, found, err := team.Pipeline(atc.PipelineRef{Name: otherPipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(otherPipeline.Name()).To(Equal(otherPipelineName))
@@ -2120,10 +2198,10 @@ var _ = Describe("Team", func() {
 			otherPipelineName := "an-other-pipeline-name"
 
 			By("being able to save the config")
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineUnpaused)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline, _, err := team.SavePipeline(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, otherConfig, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("returning the saved config to later gets")
@@ -2193,22 +2271,22 @@ var _ = Describe("Team", func() {
 			})
 
 			By("not allowing non-sequential updates")
-			_, _, err = team.SavePipeline(pipelineName, updatedConfig, pipeline.ConfigVersion()-1, db.PipelineUnpaused)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, updatedConfig, pipeline.ConfigVersion()-1, db.PipelineUnpaused)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(pipelineName, updatedConfig, pipeline.ConfigVersion()+10, db.PipelineUnpaused)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, updatedConfig, pipeline.ConfigVersion()+10, db.PipelineUnpaused)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(otherPipelineName, updatedConfig, otherPipeline.ConfigVersion()-1, db.PipelineUnpaused)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, otherPipeline.ConfigVersion()-1, db.PipelineUnpaused)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(otherPipelineName, updatedConfig, otherPipeline.ConfigVersion()+10, db.PipelineUnpaused)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, otherPipeline.ConfigVersion()+10, db.PipelineUnpaused)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
 			By("being able to update the config with a valid con")
-			pipeline, _, err = team.SavePipeline(pipelineName, updatedConfig, pipeline.ConfigVersion(), db.PipelineUnpaused)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, updatedConfig, pipeline.ConfigVersion(), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
-			otherPipeline, _, err = team.SavePipeline(otherPipelineName, updatedConfig, otherPipeline.ConfigVersion(), db.PipelineUnpaused)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, otherPipeline.ConfigVersion(), db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("returning the updated config")
@@ -2265,7 +2343,7 @@ var _ = Describe("Team", func() {
 
 			pipelineName := "a-pipeline-name"
 
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, db.PipelineUnpaused)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, db.PipelineUnpaused)
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceTypes, err := pipeline.ResourceTypes()
@@ -2283,29 +2361,29 @@ var _ = Describe("Team", func() {
 
 		Context("when there are multiple teams", func() {
 			It("can allow pipelines with the same name across teams", func() {
-				teamPipeline, _, err := team.SavePipeline("steve", config, 0, db.PipelineUnpaused)
+				teamPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "steve"}, config, 0, db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("allowing you to save a pipeline with the same name in another team")
-				otherTeamPipeline, _, err := otherTeam.SavePipeline("steve", otherConfig, 0, db.PipelineUnpaused)
+				otherTeamPipeline, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, 0, db.PipelineUnpaused)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("updating the pipeline config for the correct team's pipeline")
-				teamPipeline, _, err = team.SavePipeline("steve", otherConfig, teamPipeline.ConfigVersion(), db.PipelineNoChange)
+				teamPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, teamPipeline.ConfigVersion(), db.PipelineNoChange)
 				Expect(err).ToNot(HaveOccurred())
 
-				_, _, err = otherTeam.SavePipeline("steve", config, otherTeamPipeline.ConfigVersion(), db.PipelineNoChange)
+				_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "steve"}, config, otherTeamPipeline.ConfigVersion(), db.PipelineNoChange)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("pausing the correct team's pipeline")
-				_, _, err = team.SavePipeline("steve", otherConfig, teamPipeline.ConfigVersion(), db.PipelinePaused)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, teamPipeline.ConfigVersion(), db.PipelinePaused)
 				Expect(err).ToNot(HaveOccurred())
 
-				pausedPipeline, found, err := team.Pipeline("steve")
+				pausedPipeline, found, err := team.Pipeline(atc.PipelineRef{Name: "steve"})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				unpausedPipeline, found, err := otherTeam.Pipeline("steve")
+				unpausedPipeline, found, err := otherTeam.Pipeline(atc.PipelineRef{Name: "steve"})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
@@ -2313,10 +2391,10 @@ var _ = Describe("Team", func() {
 				Expect(unpausedPipeline.Paused()).To(BeFalse())
 
 				By("cannot cross update configs")
-				_, _, err = team.SavePipeline("steve", otherConfig, otherTeamPipeline.ConfigVersion(), db.PipelineNoChange)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, otherTeamPipeline.ConfigVersion(), db.PipelineNoChange)
 				Expect(err).To(HaveOccurred())
 
-				_, _, err = team.SavePipeline("steve", otherConfig, otherTeamPipeline.ConfigVersion(), db.PipelinePaused)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, otherTeamPipeline.ConfigVersion(), db.PipelinePaused)
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -2366,7 +2444,7 @@ var _ = Describe("Team", func() {
 					})
 
 					It("returns check container for resource", func() {
-						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", "some-resource", fakeVariablesFactory)
+						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, atc.PipelineRef{Name: "default-pipeline"}, "some-resource", fakeVariablesFactory, nil)
 						Expect(err).ToNot(HaveOccurred())
 						Expect(containers).To(HaveLen(1))
 						Expect(containers[0].ID()).To(Equal(resourceContainer.ID()))
@@ -2384,7 +2462,7 @@ var _ = Describe("Team", func() {
 						)
 
 						BeforeEach(func() {
-							otherPipeline, _, err = defaultTeam.SavePipeline("other-pipeline", atc.Config{
+							otherPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 								Resources: atc.ResourceConfigs{
 									{
 										Name: "some-resource",
@@ -2416,7 +2494,7 @@ var _ = Describe("Team", func() {
 						})
 
 						It("returns the same check container", func() {
-							containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, "other-pipeline", "some-resource", fakeVariablesFactory)
+							containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, atc.PipelineRef{Name: "other-pipeline"}, "some-resource", fakeVariablesFactory, nil)
 							Expect(err).ToNot(HaveOccurred())
 							Expect(containers).To(HaveLen(1))
 							Expect(containers[0].ID()).To(Equal(otherResourceContainer.ID()))
@@ -2429,7 +2507,7 @@ var _ = Describe("Team", func() {
 
 				Context("when check container does not exist", func() {
 					It("returns empty list", func() {
-						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", "some-resource", fakeVariablesFactory)
+						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, atc.PipelineRef{Name: "default-pipeline"}, "some-resource", fakeVariablesFactory, nil)
 						Expect(err).ToNot(HaveOccurred())
 						Expect(containers).To(BeEmpty())
 						Expect(checkContainersExpiresAt).To(BeEmpty())
@@ -2439,7 +2517,7 @@ var _ = Describe("Team", func() {
 
 			Context("when resource does not exist", func() {
 				It("returns empty list", func() {
-					containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, "default-pipeline", "non-existent-resource", fakeVariablesFactory)
+					containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, atc.PipelineRef{Name: "default-pipeline"}, "non-existent-resource", fakeVariablesFactory, nil)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(containers).To(BeEmpty())
 					Expect(checkContainersExpiresAt).To(BeEmpty())
@@ -2449,7 +2527,7 @@ var _ = Describe("Team", func() {
 
 		Context("when pipeline does not exist", func() {
 			It("returns empty list", func() {
-				containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, "non-existent-pipeline", "some-resource", fakeVariablesFactory)
+				containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(logger, atc.PipelineRef{Name: "non-existent-pipeline"}, "some-resource", fakeVariablesFactory, nil)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(containers).To(BeEmpty())
 				Expect(checkContainersExpiresAt).To(BeEmpty())
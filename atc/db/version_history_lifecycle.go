@@ -0,0 +1,209 @@
+package db
+
+import (
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+//go:generate counterfeiter . VersionHistoryLifecycle
+
+type VersionHistoryLifecycle interface {
+	RemoveExpiredVersions(defaultKeep int, defaultKeepFor time.Duration) error
+}
+
+type versionHistoryLifecycle struct {
+	conn        Conn
+	lockFactory lock.LockFactory
+}
+
+func NewVersionHistoryLifecycle(conn Conn, lockFactory lock.LockFactory) VersionHistoryLifecycle {
+	return versionHistoryLifecycle{
+		conn:        conn,
+		lockFactory: lockFactory,
+	}
+}
+
+// versionRetention is what must be kept of a resource config scope's
+// versions to satisfy every resource using it.
+type versionRetention struct {
+	keepAll        bool
+	keep           int
+	keepFor        time.Duration
+	pinnedVersions []atc.Version
+}
+
+// RemoveExpiredVersions removes the versions of each resource config scope
+// which are beyond the newest versions to keep and older than the period to
+// keep them for, as configured by the version_history of the resources using
+// the scope or else by the defaults. A default of zero places no limit.
+//
+// Versions which are used as the input or output of a build, pinned or
+// disabled are never removed, nor is the latest version of a scope. The
+// pipelines using a scope have their cache index bumped once its versions are
+// removed so that the algorithm reloads them.
+func (lifecycle versionHistoryLifecycle) RemoveExpiredVersions(defaultKeep int, defaultKeepFor time.Duration) error {
+	retentions, err := lifecycle.versionRetentions(defaultKeep, defaultKeepFor)
+	if err != nil {
+		return err
+	}
+
+	for scopeID, retention := range retentions {
+		if retention.keepAll {
+			continue
+		}
+
+		err := lifecycle.removeExpiredVersionsOfScope(scopeID, retention)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// versionRetentions combines the version history of every active resource
+// sharing a resource config scope, keeping a version if any of them would.
+func (lifecycle versionHistoryLifecycle) versionRetentions(defaultKeep int, defaultKeepFor time.Duration) (map[int]*versionRetention, error) {
+	rows, err := resourcesQuery.
+		RunWith(lifecycle.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	retentions := map[int]*versionRetention{}
+
+	for rows.Next() {
+		r := &resource{conn: lifecycle.conn, lockFactory: lifecycle.lockFactory}
+
+		err := scanResource(r, rows)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.ResourceConfigScopeID() == 0 {
+			continue
+		}
+
+		keep, keepFor := defaultKeep, defaultKeepFor
+		if history := r.VersionHistory(); history != nil {
+			keep = history.Keep
+
+			keepFor, err = history.KeepForDuration()
+			if err != nil {
+				// an invalid history is rejected when setting the pipeline, but
+				// err on the side of keeping everything
+				keep, keepFor = 0, 0
+			}
+		}
+
+		retention, found := retentions[r.ResourceConfigScopeID()]
+		if !found {
+			retention = &versionRetention{}
+			retentions[r.ResourceConfigScopeID()] = retention
+		}
+
+		if keep <= 0 && keepFor <= 0 {
+			retention.keepAll = true
+		}
+
+		if keep > retention.keep {
+			retention.keep = keep
+		}
+
+		if keepFor > retention.keepFor {
+			retention.keepFor = keepFor
+		}
+
+		if r.ConfigPinnedVersion() != nil {
+			retention.pinnedVersions = append(retention.pinnedVersions, r.ConfigPinnedVersion())
+		}
+	}
+
+	return retentions, nil
+}
+
+func (lifecycle versionHistoryLifecycle) removeExpiredVersionsOfScope(scopeID int, retention *versionRetention) error {
+	keep := retention.keep
+	if keep < 1 {
+		keep = 1
+	}
+
+	conditions := sq.And{
+		sq.Eq{"v.resource_config_scope_id": scopeID},
+		sq.Expr(`v.id NOT IN (
+			SELECT id
+			FROM resource_config_versions
+			WHERE resource_config_scope_id = ?
+			ORDER BY check_order DESC
+			LIMIT ?
+		)`, scopeID, keep),
+		sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM build_resource_config_version_inputs i
+			JOIN resources r ON r.id = i.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND i.version_md5 = v.version_md5
+		)`),
+		sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM build_resource_config_version_outputs o
+			JOIN resources r ON r.id = o.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND o.version_md5 = v.version_md5
+		)`),
+		sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM resource_disabled_versions d
+			JOIN resources r ON r.id = d.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND d.version_md5 = v.version_md5
+		)`),
+		sq.Expr(`NOT EXISTS (
+			SELECT 1
+			FROM resource_pins p
+			JOIN resources r ON r.id = p.resource_id
+			WHERE r.resource_config_scope_id = v.resource_config_scope_id
+			AND p.version = v.version
+		)`),
+	}
+
+	if retention.keepFor > 0 {
+		// versions saved before creation times were recorded are old enough
+		conditions = append(conditions, sq.Expr("(v.created_at IS NULL OR v.created_at < NOW() - ? * INTERVAL '1 second')", int(retention.keepFor.Seconds())))
+	}
+
+	for _, version := range retention.pinnedVersions {
+		versionJSON, err := json.Marshal(version)
+		if err != nil {
+			return err
+		}
+
+		conditions = append(conditions, sq.Expr("NOT v.version @> ?", string(versionJSON)))
+	}
+
+	result, err := psql.Delete("resource_config_versions v").
+		Where(conditions).
+		RunWith(lifecycle.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if removed == 0 {
+		return nil
+	}
+
+	return bumpCacheIndexForPipelinesUsingResourceConfigScope(lifecycle.conn, scopeID)
+}
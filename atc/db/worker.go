@@ -49,11 +49,14 @@ type Worker interface {
 	HTTPProxyURL() string
 	HTTPSProxyURL() string
 	NoProxy() string
+	P2PURL() string
 	ActiveContainers() int
 	ActiveVolumes() int
+	Capacity() atc.WorkerCapacity
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
+	Labels() map[string]string
 	TeamID() int
 	TeamName() string
 	StartTime() int64
@@ -84,9 +87,12 @@ type worker struct {
 	noProxy          string
 	activeContainers int
 	activeVolumes    int
+	capacity         atc.WorkerCapacity
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
+	labels           map[string]string
+	p2pURL           string
 	teamID           int
 	teamName         string
 	startTime        int64
@@ -105,11 +111,14 @@ func (worker *worker) BaggageclaimURL() *string { return worker.baggageclaimURL
 func (worker *worker) HTTPProxyURL() string                    { return worker.httpProxyURL }
 func (worker *worker) HTTPSProxyURL() string                   { return worker.httpsProxyURL }
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
+func (worker *worker) P2PURL() string                          { return worker.p2pURL }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
 func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
+func (worker *worker) Capacity() atc.WorkerCapacity            { return worker.capacity }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
+func (worker *worker) Labels() map[string]string               { return worker.labels }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
@@ -317,11 +326,27 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 		return nil, err
 	}
 
+	// count the container straight away rather than once the worker next
+	// reports its containers, so that placement sees how full it is. This is
+	// best-effort: the count is only reconciled when the worker heartbeats,
+	// and placements made at the same time may have chosen the worker from the
+	// same earlier count, so --max-containers can be briefly exceeded.
+	_, err = psql.Update("workers").
+		Set("active_containers", sq.Expr("active_containers + 1")).
+		Where(sq.Eq{"name": worker.name}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return nil, err
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, err
 	}
 
+	worker.activeContainers++
+
 	return newCreatingContainer(
 		containerID,
 		handle.String(),
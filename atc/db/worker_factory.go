@@ -22,6 +22,7 @@ type WorkerFactory interface {
 
 	FindWorkersForContainerByOwner(ContainerOwner) ([]Worker, error)
 	BuildContainersCountPerWorker() (map[string]int, error)
+	ActiveTasksCountPerWorker() (map[string]int, error)
 }
 
 type workerFactory struct {
@@ -46,9 +47,14 @@ var workersQuery = psql.Select(`
 		w.no_proxy,
 		w.active_containers,
 		w.active_volumes,
+		w.max_containers,
+		w.cpu,
+		w.memory,
 		w.resource_types,
 		w.platform,
 		w.tags,
+		w.labels,
+		w.p2p_url,
 		t.name,
 		w.team_id,
 		w.start_time,
@@ -141,6 +147,8 @@ func scanWorker(worker *worker, row scannable) error {
 		resourceTypes []byte
 		platform      sql.NullString
 		tags          []byte
+		labels        []byte
+		p2pURL        sql.NullString
 		teamName      sql.NullString
 		teamID        sql.NullInt64
 		startTime     sql.NullInt64
@@ -160,9 +168,14 @@ func scanWorker(worker *worker, row scannable) error {
 		&noProxy,
 		&worker.activeContainers,
 		&worker.activeVolumes,
+		&worker.capacity.MaxContainers,
+		&worker.capacity.CPU,
+		&worker.capacity.Memory,
 		&resourceTypes,
 		&platform,
 		&tags,
+		&labels,
+		&p2pURL,
 		&teamName,
 		&teamID,
 		&startTime,
@@ -199,6 +212,10 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.expiresAt = *expiresAt
 	}
 
+	if p2pURL.Valid {
+		worker.p2pURL = p2pURL.String
+	}
+
 	if httpProxyURL.Valid {
 		worker.httpProxyURL = httpProxyURL.String
 	}
@@ -232,7 +249,12 @@ func scanWorker(worker *worker, row scannable) error {
 		return err
 	}
 
-	return json.Unmarshal(tags, &worker.tags)
+	err = json.Unmarshal(tags, &worker.tags)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(labels, &worker.labels)
 }
 
 func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error) {
@@ -268,6 +290,9 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("expires", sq.Expr(expires)).
 		Set("active_containers", atcWorker.ActiveContainers).
 		Set("active_volumes", atcWorker.ActiveVolumes).
+		Set("max_containers", atcWorker.Capacity.MaxContainers).
+		Set("cpu", atcWorker.Capacity.CPU).
+		Set("memory", atcWorker.Capacity.Memory).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -376,6 +401,40 @@ func (f *workerFactory) BuildContainersCountPerWorker() (map[string]int, error)
 	return countByWorker, nil
 }
 
+func (f *workerFactory) ActiveTasksCountPerWorker() (map[string]int, error) {
+	rows, err := psql.Select("c.worker_name, COUNT(*)").
+		From("containers c").
+		Join("builds b ON b.id = c.build_id").
+		Where(sq.Eq{
+			"c.meta_type": string(ContainerTypeTask),
+			"b.status":    string(BuildStatusStarted),
+		}).
+		GroupBy("c.worker_name").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	countByWorker := make(map[string]int)
+
+	for rows.Next() {
+		var workerName string
+		var tasksCount int
+
+		err = rows.Scan(&workerName, &tasksCount)
+		if err != nil {
+			return nil, err
+		}
+
+		countByWorker[workerName] = tasksCount
+	}
+
+	return countByWorker, nil
+}
+
 func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, conn Conn) (Worker, error) {
 	resourceTypes, err := json.Marshal(atcWorker.ResourceTypes)
 	if err != nil {
@@ -387,6 +446,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	labels, err := json.Marshal(atcWorker.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -417,8 +481,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
 		atcWorker.ActiveVolumes,
+		atcWorker.Capacity.MaxContainers,
+		atcWorker.Capacity.CPU,
+		atcWorker.Capacity.Memory,
 		resourceTypes,
 		tags,
+		labels,
+		atcWorker.P2PURL,
 		atcWorker.Platform,
 		atcWorker.BaggageclaimURL,
 		atcWorker.CertsPath,
@@ -448,8 +517,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"addr",
 			"active_containers",
 			"active_volumes",
+			"max_containers",
+			"cpu",
+			"memory",
 			"resource_types",
 			"tags",
+			"labels",
+			"p2p_url",
 			"platform",
 			"baggageclaim_url",
 			"certs_path",
@@ -470,8 +544,13 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				addr = ?,
 				active_containers = ?,
 				active_volumes = ?,
+				max_containers = ?,
+				cpu = ?,
+				memory = ?,
 				resource_types = ?,
 				tags = ?,
+				labels = ?,
+				p2p_url = ?,
 				platform = ?,
 				baggageclaim_url = ?,
 				certs_path = ?,
@@ -519,9 +598,12 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		noProxy:          atcWorker.NoProxy,
 		activeContainers: atcWorker.ActiveContainers,
 		activeVolumes:    atcWorker.ActiveVolumes,
+		capacity:         atcWorker.Capacity,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
+		labels:           atcWorker.Labels,
+		p2pURL:           atcWorker.P2PURL,
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
 		startTime:        atcWorker.StartTime,
@@ -29,6 +29,11 @@ var _ = Describe("WorkerFactory", func() {
 			Ephemeral:        true,
 			ActiveContainers: 140,
 			ActiveVolumes:    550,
+			Capacity: atc.WorkerCapacity{
+				MaxContainers: 250,
+				CPU:           8,
+				Memory:        16 * 1024 * 1024 * 1024,
+			},
 			ResourceTypes: []atc.WorkerResourceType{
 				{
 					Type:       "some-resource-type",
@@ -47,6 +52,8 @@ var _ = Describe("WorkerFactory", func() {
 			Tags:      atc.Tags{"some", "tags"},
 			Name:      "some-name",
 			StartTime: 55,
+			Labels:    map[string]string{"zone": "some-zone"},
+			P2PURL:    "http://some-p2p-url",
 		}
 	})
 
@@ -257,9 +264,15 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.HTTPProxyURL()).To(Equal("some-http-proxy-url"))
 				Expect(foundWorker.HTTPSProxyURL()).To(Equal("some-https-proxy-url"))
 				Expect(foundWorker.NoProxy()).To(Equal("some-no-proxy"))
+				Expect(foundWorker.P2PURL()).To(Equal("http://some-p2p-url"))
 				Expect(foundWorker.Ephemeral()).To(Equal(true))
 				Expect(foundWorker.ActiveContainers()).To(Equal(140))
 				Expect(foundWorker.ActiveVolumes()).To(Equal(550))
+				Expect(foundWorker.Capacity()).To(Equal(atc.WorkerCapacity{
+					MaxContainers: 250,
+					CPU:           8,
+					Memory:        16 * 1024 * 1024 * 1024,
+				}))
 				Expect(foundWorker.ResourceTypes()).To(Equal([]atc.WorkerResourceType{
 					{
 						Type:       "some-resource-type",
@@ -275,6 +288,7 @@ var _ = Describe("WorkerFactory", func() {
 				}))
 				Expect(foundWorker.Platform()).To(Equal("some-platform"))
 				Expect(foundWorker.Tags()).To(Equal([]string{"some", "tags"}))
+				Expect(foundWorker.Labels()).To(Equal(map[string]string{"zone": "some-zone"}))
 				Expect(foundWorker.StartTime()).To(Equal(int64(55)))
 				Expect(foundWorker.State()).To(Equal(db.WorkerStateRunning))
 			})
@@ -445,9 +459,10 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 
-			It("updates the expires field, and the number of active containers and volumes", func() {
+			It("updates the expires field, the number of active containers and volumes, and the capacity", func() {
 				atcWorker.ActiveContainers = 1
 				atcWorker.ActiveVolumes = 3
+				atcWorker.Capacity.MaxContainers = 100
 
 				now := time.Now()
 				By("current time")
@@ -464,6 +479,7 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(foundWorker.ExpiresAt()).To(BeTemporally("~", later, epsilon))
 				Expect(foundWorker.ActiveContainers()).To(And(Not(Equal(activeContainers)), Equal(1)))
 				Expect(foundWorker.ActiveVolumes()).To(And(Not(Equal(activeVolumes)), Equal(3)))
+				Expect(foundWorker.Capacity().MaxContainers).To(Equal(100))
 				Expect(*foundWorker.GardenAddr()).To(Equal("some-garden-addr"))
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
@@ -604,7 +620,7 @@ var _ = Describe("WorkerFactory", func() {
 					}
 
 					var err error
-					otherPipeline, _, err := defaultTeam.SavePipeline("other-pipeline", atc.Config{
+					otherPipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 						Resources: atc.ResourceConfigs{
 							{
 								Name: "some-resource",
@@ -828,4 +844,75 @@ var _ = Describe("WorkerFactory", func() {
 			Expect(containersCountByWorker[worker.Name()]).To(Equal(1))
 		})
 	})
+
+	Describe("ActiveTasksCountPerWorker", func() {
+		var (
+			startedBuild  db.Build
+			finishedBuild db.Build
+		)
+
+		ownedBy := func(build db.Build, planID string) *dbfakes.FakeContainerOwner {
+			fakeOwner := new(dbfakes.FakeContainerOwner)
+			fakeOwner.FindReturns(sq.Eq{
+				"build_id": build.ID(),
+				"plan_id":  planID,
+				"team_id":  1,
+			}, true, nil)
+			fakeOwner.CreateReturns(map[string]interface{}{
+				"build_id": build.ID(),
+				"plan_id":  planID,
+				"team_id":  1,
+			}, nil)
+			return fakeOwner
+		}
+
+		BeforeEach(func() {
+			var err error
+
+			startedBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := startedBuild.Start("exec.v2", "{}", atc.Plan{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			finishedBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = finishedBuild.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultWorker.CreateContainer(ownedBy(startedBuild, "some-task"), db.ContainerMetadata{
+				Type: "task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultWorker.CreateContainer(ownedBy(startedBuild, "other-task"), db.ContainerMetadata{
+				Type: "task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = defaultWorker.CreateContainer(ownedBy(startedBuild, "some-get"), db.ContainerMetadata{
+				Type: "get",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = worker.CreateContainer(ownedBy(finishedBuild, "some-task"), db.ContainerMetadata{
+				Type: "task",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns a map of worker to number of tasks of started builds", func() {
+			tasksCountByWorker, err := workerFactory.ActiveTasksCountPerWorker()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(tasksCountByWorker).To(Equal(map[string]int{
+				defaultWorker.Name(): 2,
+			}))
+		})
+	})
 })
@@ -268,6 +268,19 @@ var _ = Describe("Worker", func() {
 				Expect(foundCreatingContainer).ToNot(BeNil())
 			})
 
+			It("counts it as active before the worker next heartbeats", func() {
+				_, err := worker.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(worker.ActiveContainers()).To(Equal(141))
+
+				_, err = workerFactory.HeartbeatWorker(atcWorker, 5*time.Minute)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = worker.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(worker.ActiveContainers()).To(Equal(140))
+			})
+
 			Context("when finding on another worker", func() {
 				BeforeEach(func() {
 					worker = otherWorker
@@ -33,19 +33,19 @@ var VersionConfigDecodeHook = func(
 			}, nil
 		}
 	case srcType.Kind() == reflect.Map:
-		version := Version{}
-		if versionConfig, ok := data.(map[interface{}]interface{}); ok {
-			for key, val := range versionConfig {
-				if sKey, ok := key.(string); ok {
-					if sVal, ok := val.(string); ok {
-						version[sKey] = strings.TrimSpace(sVal)
-					}
-				}
+		sanitized, err := sanitize(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if versionConfig, ok := sanitized.(map[string]interface{}); ok {
+			var config VersionConfig
+			err := config.unmarshalMap(versionConfig)
+			if err != nil {
+				return nil, err
 			}
 
-			return VersionConfig{
-				Pinned: version,
-			}, nil
+			return config, nil
 		}
 	}
 
@@ -74,7 +74,7 @@ var SanitizeDecodeHook = func(
 	valKind reflect.Kind,
 	data interface{},
 ) (interface{}, error) {
-	if valKind == reflect.Map {
+	if valKind == reflect.Map || valKind == reflect.Interface {
 		if dataKind == reflect.Map {
 			return sanitize(data)
 		}
@@ -203,6 +203,62 @@ var InputsConfigDecodeHook = func(
 	return data, nil
 }
 
+var InParallelConfigDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(InParallelConfig{}) {
+		return data, nil
+	}
+
+	// a plain list of steps is shorthand for the full form
+	if srcType.Kind() == reflect.Slice {
+		return map[string]interface{}{"steps": data}, nil
+	}
+
+	return data, nil
+}
+
+var AttemptsConfigDecodeHook = func(
+	srcType reflect.Type,
+	dstType reflect.Type,
+	data interface{},
+) (interface{}, error) {
+	if dstType != reflect.TypeOf(AttemptsConfig{}) {
+		return data, nil
+	}
+
+	// a plain number is shorthand for the count
+	switch srcType.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return map[string]interface{}{"count": data}, nil
+	}
+
+	// YAML 1.1 parses an 'on' key as the boolean true
+	if attempts, ok := data.(map[interface{}]interface{}); ok {
+		config := map[string]interface{}{}
+		for key, val := range attempts {
+			switch k := key.(type) {
+			case string:
+				config[k] = val
+			case bool:
+				if k {
+					config["on"] = val
+				} else {
+					config["off"] = val
+				}
+			default:
+				return nil, errors.New("non-string key")
+			}
+		}
+
+		return config, nil
+	}
+
+	return data, nil
+}
+
 func sanitize(root interface{}) (interface{}, error) {
 	switch rootVal := root.(type) {
 	case map[interface{}]interface{}:
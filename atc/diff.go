@@ -1,4 +1,4 @@
-package setpipelinehelpers
+package atc
 
 import (
 	"bytes"
@@ -8,12 +8,70 @@ import (
 	"strings"
 
 	"github.com/aryann/difflib"
-	"github.com/concourse/concourse/atc"
 	"github.com/mgutz/ansi"
-	"github.com/onsi/gomega/gexec"
 	"gopkg.in/yaml.v2"
 )
 
+// Diff renders the differences between the config and newConfig to out,
+// returning whether there were any.
+func (c Config) Diff(out io.Writer, newConfig Config) bool {
+	var diffExists bool
+
+	indent := newPrefixedWriter("  ", out)
+
+	groupDiffs := groupDiffIndices(GroupIndex(c.Groups), GroupIndex(newConfig.Groups))
+	if len(groupDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "groups:")
+
+		for _, diff := range groupDiffs {
+			diff.Render(indent, "group")
+		}
+	}
+
+	resourceDiffs := diffIndices(ResourceIndex(c.Resources), ResourceIndex(newConfig.Resources))
+	if len(resourceDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "resources:")
+
+		for _, diff := range resourceDiffs {
+			diff.Render(indent, "resource")
+		}
+	}
+
+	resourceTypeDiffs := diffIndices(ResourceTypeIndex(c.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))
+	if len(resourceTypeDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "resource types:")
+
+		for _, diff := range resourceTypeDiffs {
+			diff.Render(indent, "resource type")
+		}
+	}
+
+	if practicallyDifferent(c.ResourceTypeDefaults, newConfig.ResourceTypeDefaults) {
+		diffExists = true
+		fmt.Fprintln(out, "resource type defaults:")
+
+		payloadA, _ := yaml.Marshal(c.ResourceTypeDefaults)
+		payloadB, _ := yaml.Marshal(newConfig.ResourceTypeDefaults)
+
+		renderDiff(indent, string(payloadA), string(payloadB))
+	}
+
+	jobDiffs := diffIndices(JobIndex(c.Jobs), JobIndex(newConfig.Jobs))
+	if len(jobDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "jobs:")
+
+		for _, diff := range jobDiffs {
+			diff.Render(indent, "job")
+		}
+	}
+
+	return diffExists
+}
+
 type Index interface {
 	FindEquivalent(interface{}) (interface{}, bool)
 	Slice() []interface{}
@@ -54,7 +112,7 @@ func (diff Diff) Render(to io.Writer, label string) {
 	}
 }
 
-type GroupIndex atc.GroupConfigs
+type GroupIndex GroupConfigs
 
 func (index GroupIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -66,10 +124,10 @@ func (index GroupIndex) Slice() []interface{} {
 }
 
 func (index GroupIndex) FindEquivalentWithOrder(obj interface{}) (interface{}, int, bool) {
-	return atc.GroupConfigs(index).Lookup(name(obj))
+	return GroupConfigs(index).Lookup(name(obj))
 }
 
-type JobIndex atc.JobConfigs
+type JobIndex JobConfigs
 
 func (index JobIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -81,10 +139,10 @@ func (index JobIndex) Slice() []interface{} {
 }
 
 func (index JobIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.JobConfigs(index).Lookup(name(obj))
+	return JobConfigs(index).Lookup(name(obj))
 }
 
-type ResourceIndex atc.ResourceConfigs
+type ResourceIndex ResourceConfigs
 
 func (index ResourceIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -96,10 +154,10 @@ func (index ResourceIndex) Slice() []interface{} {
 }
 
 func (index ResourceIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.ResourceConfigs(index).Lookup(name(obj))
+	return ResourceConfigs(index).Lookup(name(obj))
 }
 
-type ResourceTypeIndex atc.ResourceTypes
+type ResourceTypeIndex ResourceTypes
 
 func (index ResourceTypeIndex) Slice() []interface{} {
 	slice := make([]interface{}, len(index))
@@ -111,7 +169,7 @@ func (index ResourceTypeIndex) Slice() []interface{} {
 }
 
 func (index ResourceTypeIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.ResourceTypes(index).Lookup(name(obj))
+	return ResourceTypes(index).Lookup(name(obj))
 }
 
 func groupDiffIndices(oldIndex GroupIndex, newIndex GroupIndex) Diffs {
@@ -193,7 +251,7 @@ func diffIndices(oldIndex Index, newIndex Index) Diffs {
 
 func renderDiff(to io.Writer, a, b string) {
 	diffs := difflib.Diff(strings.Split(a, "\n"), strings.Split(b, "\n"))
-	indent := gexec.NewPrefixedWriter("\b\b", to)
+	indent := newPrefixedWriter("\b\b", to)
 
 	for _, diff := range diffs {
 		text := diff.Payload
@@ -222,3 +280,40 @@ func practicallyDifferent(a, b interface{}) bool {
 
 	return !bytes.Equal(marshalledA, marshalledB)
 }
+
+// prefixedWriter writes the prefix at the start of every line written to the
+// underlying writer.
+type prefixedWriter struct {
+	prefix        []byte
+	writer        io.Writer
+	atStartOfLine bool
+}
+
+func newPrefixedWriter(prefix string, writer io.Writer) *prefixedWriter {
+	return &prefixedWriter{
+		prefix:        []byte(prefix),
+		writer:        writer,
+		atStartOfLine: true,
+	}
+}
+
+func (w *prefixedWriter) Write(b []byte) (int, error) {
+	toWrite := []byte{}
+
+	for _, c := range b {
+		if w.atStartOfLine {
+			toWrite = append(toWrite, w.prefix...)
+		}
+
+		toWrite = append(toWrite, c)
+
+		w.atStartOfLine = c == '\n'
+	}
+
+	_, err := w.writer.Write(toWrite)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
@@ -0,0 +1,88 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config", func() {
+	Describe("Diff", func() {
+		var (
+			existingConfig atc.Config
+			newConfig      atc.Config
+
+			out        *gbytes.Buffer
+			diffExists bool
+		)
+
+		BeforeEach(func() {
+			existingConfig = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-uri"}},
+					{Name: "removed-resource", Type: "git"},
+				},
+				Jobs: atc.JobConfigs{
+					{Name: "some-job"},
+				},
+			}
+
+			newConfig = existingConfig
+		})
+
+		JustBeforeEach(func() {
+			out = gbytes.NewBuffer()
+			diffExists = existingConfig.Diff(out, newConfig)
+		})
+
+		Context("when the configs are the same", func() {
+			It("renders nothing", func() {
+				Expect(diffExists).To(BeFalse())
+				Expect(out.Contents()).To(BeEmpty())
+			})
+		})
+
+		Context("when the configs differ", func() {
+			BeforeEach(func() {
+				newConfig = atc.Config{
+					Resources: atc.ResourceConfigs{
+						{Name: "some-resource", Type: "git", Source: atc.Source{"uri": "some-other-uri"}},
+					},
+					Jobs: atc.JobConfigs{
+						{Name: "some-job"},
+						{Name: "added-job"},
+					},
+				}
+			})
+
+			It("renders the differences", func() {
+				Expect(diffExists).To(BeTrue())
+
+				Expect(out).To(gbytes.Say("resources:"))
+				Expect(out).To(gbytes.Say("resource some-resource has changed:"))
+				Expect(out).To(gbytes.Say("some-uri"))
+				Expect(out).To(gbytes.Say("some-other-uri"))
+				Expect(out).To(gbytes.Say("resource removed-resource has been removed:"))
+				Expect(out).To(gbytes.Say("jobs:"))
+				Expect(out).To(gbytes.Say("job added-job has been added:"))
+			})
+		})
+
+		Context("when the resource type defaults differ", func() {
+			BeforeEach(func() {
+				newConfig.ResourceTypeDefaults = atc.ResourceTypeDefaults{
+					"git": atc.Source{"private_key": "some-key"},
+				}
+			})
+
+			It("renders the differences", func() {
+				Expect(diffExists).To(BeTrue())
+
+				Expect(out).To(gbytes.Say("resource type defaults:"))
+				Expect(out).To(gbytes.Say("private_key: some-key"))
+			})
+		})
+	})
+})
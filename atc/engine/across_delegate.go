@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type acrossDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewAcrossDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.AcrossDelegate {
+	return &acrossDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *acrossDelegate) StartingCombination(logger lager.Logger, index int, planID atc.PlanID, vars map[string]interface{}) {
+	err := d.build.SaveEvent(event.StartCombination{
+		Time:   d.clock.Now().Unix(),
+		Origin: d.eventOrigin,
+		Index:  index,
+		PlanID: planID,
+		Vars:   vars,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-combination-event", err)
+		return
+	}
+
+	logger.Debug("starting-combination", lager.Data{"index": index})
+}
+
+func (d *acrossDelegate) FinishedCombination(logger lager.Logger, index int, succeeded bool) {
+	err := d.build.SaveEvent(event.FinishCombination{
+		Time:      d.clock.Now().Unix(),
+		Origin:    d.eventOrigin,
+		Index:     index,
+		Succeeded: succeeded,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-combination-event", err)
+		return
+	}
+
+	logger.Info("finished-combination", lager.Data{"index": index, "succeeded": succeeded})
+}
@@ -22,6 +22,20 @@ func (build *execBuild) buildAggregateStep(logger lager.Logger, plan atc.Plan) e
 	return agg
 }
 
+func (build *execBuild) buildInParallelStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("in-parallel")
+
+	steps := []exec.Step{}
+
+	for _, innerPlan := range plan.InParallel.Steps {
+		innerPlan.Attempts = plan.Attempts
+		step := build.buildStep(logger, innerPlan)
+		steps = append(steps, step)
+	}
+
+	return exec.InParallel(steps, plan.InParallel.Limit, plan.InParallel.FailFast)
+}
+
 func (build *execBuild) buildDoStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("do")
 
@@ -96,6 +110,7 @@ func (build *execBuild) buildTaskStep(logger lager.Logger, plan atc.Plan) exec.S
 		logger,
 		plan,
 		build.dbBuild,
+		build.runState().LocalVariables(),
 		containerMetadata,
 		build.delegate.TaskDelegate(plan.ID),
 	)
@@ -116,6 +131,7 @@ func (build *execBuild) buildGetStep(logger lager.Logger, plan atc.Plan) exec.St
 		logger,
 		plan,
 		build.dbBuild,
+		build.runState().LocalVariables(),
 		build.stepMetadata,
 		containerMetadata,
 		build.delegate.GetDelegate(plan.ID),
@@ -137,25 +153,71 @@ func (build *execBuild) buildPutStep(logger lager.Logger, plan atc.Plan) exec.St
 		logger,
 		plan,
 		build.dbBuild,
+		build.runState().LocalVariables(),
 		build.stepMetadata,
 		containerMetadata,
 		build.delegate.PutDelegate(plan.ID),
 	)
 }
 
+func (build *execBuild) buildSetPipelineStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("set-pipeline", lager.Data{
+		"name": plan.SetPipeline.Name,
+	})
+
+	return build.factory.SetPipeline(
+		logger,
+		plan,
+		build.dbBuild,
+		build.delegate.SetPipelineDelegate(plan.ID),
+	)
+}
+
+func (build *execBuild) buildLoadVarStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("load-var", lager.Data{
+		"name": plan.LoadVar.Name,
+	})
+
+	return build.factory.LoadVar(
+		logger,
+		plan,
+		build.delegate.LoadVarDelegate(plan.ID),
+	)
+}
+
 func (build *execBuild) buildRetryStep(logger lager.Logger, plan atc.Plan) exec.Step {
 	logger = logger.Session("retry")
 
 	steps := []exec.Step{}
 
-	for index, innerPlan := range *plan.Retry {
+	for index, innerPlan := range plan.Retry.Attempts {
 		innerPlan.Attempts = append(plan.Attempts, index+1)
 
 		step := build.buildStep(logger, innerPlan)
 		steps = append(steps, step)
 	}
 
-	return exec.Retry(steps...)
+	return exec.Retry(*plan.Retry, build.delegate.RetryDelegate(plan.ID), steps...)
+}
+
+func (build *execBuild) buildAcrossStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	logger = logger.Session("across")
+
+	return exec.Across(
+		*plan.Across,
+		build.delegate.AcrossDelegate(plan.ID),
+		func(combinationPlan atc.Plan) exec.Step {
+			combinationPlan.Attempts = plan.Attempts
+			return build.buildStep(logger, combinationPlan)
+		},
+	)
+}
+
+func (build *execBuild) buildIfStep(logger lager.Logger, plan atc.Plan) exec.Step {
+	innerPlan := plan.If.Step
+	innerPlan.Attempts = plan.Attempts
+	step := build.buildStep(logger, innerPlan)
+	return exec.If(*plan.If, build.stepMetadata, build.delegate.IfDelegate(plan.ID), step)
 }
 
 func (build *execBuild) buildUserArtifactStep(logger lager.Logger, plan atc.Plan) exec.Step {
@@ -68,6 +68,18 @@ func (delegate *BuildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+func (delegate *BuildStepDelegate) WaitingForWorker(logger lager.Logger) {
+	err := delegate.build.SaveEvent(event.WaitingForWorker{
+		Time: delegate.clock.Now().Unix(),
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-worker-event", err)
+	}
+}
+
 func newDBEventWriter(build db.Build, origin event.Origin, clock clock.Clock) io.Writer {
 	return &dbEventWriter{
 		build:  build,
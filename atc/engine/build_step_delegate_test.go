@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine"
@@ -151,4 +152,20 @@ var _ = Describe("BuildStepDelegate", func() {
 			})
 		})
 	})
+
+	Describe("WaitingForWorker", func() {
+		JustBeforeEach(func() {
+			delegate.WaitingForWorker(lagertest.NewTestLogger("test"))
+		})
+
+		It("saves a waiting-for-worker event", func() {
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.WaitingForWorker{
+				Time: 123456789,
+				Origin: event.Origin{
+					ID: "some-plan-id",
+				},
+			}))
+		})
+	})
 })
@@ -11,6 +11,17 @@ import (
 )
 
 type FakeBuildDelegate struct {
+	AcrossDelegateStub        func(atc.PlanID) exec.AcrossDelegate
+	acrossDelegateMutex       sync.RWMutex
+	acrossDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	acrossDelegateReturns struct {
+		result1 exec.AcrossDelegate
+	}
+	acrossDelegateReturnsOnCall map[int]struct {
+		result1 exec.AcrossDelegate
+	}
 	BuildStepDelegateStub        func(atc.PlanID) exec.BuildStepDelegate
 	buildStepDelegateMutex       sync.RWMutex
 	buildStepDelegateArgsForCall []struct {
@@ -40,6 +51,28 @@ type FakeBuildDelegate struct {
 	getDelegateReturnsOnCall map[int]struct {
 		result1 exec.GetDelegate
 	}
+	IfDelegateStub        func(atc.PlanID) exec.IfDelegate
+	ifDelegateMutex       sync.RWMutex
+	ifDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	ifDelegateReturns struct {
+		result1 exec.IfDelegate
+	}
+	ifDelegateReturnsOnCall map[int]struct {
+		result1 exec.IfDelegate
+	}
+	LoadVarDelegateStub        func(atc.PlanID) exec.LoadVarDelegate
+	loadVarDelegateMutex       sync.RWMutex
+	loadVarDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	loadVarDelegateReturns struct {
+		result1 exec.LoadVarDelegate
+	}
+	loadVarDelegateReturnsOnCall map[int]struct {
+		result1 exec.LoadVarDelegate
+	}
 	PutDelegateStub        func(atc.PlanID) exec.PutDelegate
 	putDelegateMutex       sync.RWMutex
 	putDelegateArgsForCall []struct {
@@ -51,6 +84,28 @@ type FakeBuildDelegate struct {
 	putDelegateReturnsOnCall map[int]struct {
 		result1 exec.PutDelegate
 	}
+	RetryDelegateStub        func(atc.PlanID) exec.RetryDelegate
+	retryDelegateMutex       sync.RWMutex
+	retryDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	retryDelegateReturns struct {
+		result1 exec.RetryDelegate
+	}
+	retryDelegateReturnsOnCall map[int]struct {
+		result1 exec.RetryDelegate
+	}
+	SetPipelineDelegateStub        func(atc.PlanID) exec.SetPipelineDelegate
+	setPipelineDelegateMutex       sync.RWMutex
+	setPipelineDelegateArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	setPipelineDelegateReturns struct {
+		result1 exec.SetPipelineDelegate
+	}
+	setPipelineDelegateReturnsOnCall map[int]struct {
+		result1 exec.SetPipelineDelegate
+	}
 	TaskDelegateStub        func(atc.PlanID) exec.TaskDelegate
 	taskDelegateMutex       sync.RWMutex
 	taskDelegateArgsForCall []struct {
@@ -66,6 +121,66 @@ type FakeBuildDelegate struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeBuildDelegate) AcrossDelegate(arg1 atc.PlanID) exec.AcrossDelegate {
+	fake.acrossDelegateMutex.Lock()
+	ret, specificReturn := fake.acrossDelegateReturnsOnCall[len(fake.acrossDelegateArgsForCall)]
+	fake.acrossDelegateArgsForCall = append(fake.acrossDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("AcrossDelegate", []interface{}{arg1})
+	fake.acrossDelegateMutex.Unlock()
+	if fake.AcrossDelegateStub != nil {
+		return fake.AcrossDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.acrossDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildDelegate) AcrossDelegateCallCount() int {
+	fake.acrossDelegateMutex.RLock()
+	defer fake.acrossDelegateMutex.RUnlock()
+	return len(fake.acrossDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) AcrossDelegateCalls(stub func(atc.PlanID) exec.AcrossDelegate) {
+	fake.acrossDelegateMutex.Lock()
+	defer fake.acrossDelegateMutex.Unlock()
+	fake.AcrossDelegateStub = stub
+}
+
+func (fake *FakeBuildDelegate) AcrossDelegateArgsForCall(i int) atc.PlanID {
+	fake.acrossDelegateMutex.RLock()
+	defer fake.acrossDelegateMutex.RUnlock()
+	argsForCall := fake.acrossDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildDelegate) AcrossDelegateReturns(result1 exec.AcrossDelegate) {
+	fake.acrossDelegateMutex.Lock()
+	defer fake.acrossDelegateMutex.Unlock()
+	fake.AcrossDelegateStub = nil
+	fake.acrossDelegateReturns = struct {
+		result1 exec.AcrossDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) AcrossDelegateReturnsOnCall(i int, result1 exec.AcrossDelegate) {
+	fake.acrossDelegateMutex.Lock()
+	defer fake.acrossDelegateMutex.Unlock()
+	fake.AcrossDelegateStub = nil
+	if fake.acrossDelegateReturnsOnCall == nil {
+		fake.acrossDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.AcrossDelegate
+		})
+	}
+	fake.acrossDelegateReturnsOnCall[i] = struct {
+		result1 exec.AcrossDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) BuildStepDelegate(arg1 atc.PlanID) exec.BuildStepDelegate {
 	fake.buildStepDelegateMutex.Lock()
 	ret, specificReturn := fake.buildStepDelegateReturnsOnCall[len(fake.buildStepDelegateArgsForCall)]
@@ -219,6 +334,126 @@ func (fake *FakeBuildDelegate) GetDelegateReturnsOnCall(i int, result1 exec.GetD
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) IfDelegate(arg1 atc.PlanID) exec.IfDelegate {
+	fake.ifDelegateMutex.Lock()
+	ret, specificReturn := fake.ifDelegateReturnsOnCall[len(fake.ifDelegateArgsForCall)]
+	fake.ifDelegateArgsForCall = append(fake.ifDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("IfDelegate", []interface{}{arg1})
+	fake.ifDelegateMutex.Unlock()
+	if fake.IfDelegateStub != nil {
+		return fake.IfDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.ifDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildDelegate) IfDelegateCallCount() int {
+	fake.ifDelegateMutex.RLock()
+	defer fake.ifDelegateMutex.RUnlock()
+	return len(fake.ifDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) IfDelegateCalls(stub func(atc.PlanID) exec.IfDelegate) {
+	fake.ifDelegateMutex.Lock()
+	defer fake.ifDelegateMutex.Unlock()
+	fake.IfDelegateStub = stub
+}
+
+func (fake *FakeBuildDelegate) IfDelegateArgsForCall(i int) atc.PlanID {
+	fake.ifDelegateMutex.RLock()
+	defer fake.ifDelegateMutex.RUnlock()
+	argsForCall := fake.ifDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildDelegate) IfDelegateReturns(result1 exec.IfDelegate) {
+	fake.ifDelegateMutex.Lock()
+	defer fake.ifDelegateMutex.Unlock()
+	fake.IfDelegateStub = nil
+	fake.ifDelegateReturns = struct {
+		result1 exec.IfDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) IfDelegateReturnsOnCall(i int, result1 exec.IfDelegate) {
+	fake.ifDelegateMutex.Lock()
+	defer fake.ifDelegateMutex.Unlock()
+	fake.IfDelegateStub = nil
+	if fake.ifDelegateReturnsOnCall == nil {
+		fake.ifDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.IfDelegate
+		})
+	}
+	fake.ifDelegateReturnsOnCall[i] = struct {
+		result1 exec.IfDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegate(arg1 atc.PlanID) exec.LoadVarDelegate {
+	fake.loadVarDelegateMutex.Lock()
+	ret, specificReturn := fake.loadVarDelegateReturnsOnCall[len(fake.loadVarDelegateArgsForCall)]
+	fake.loadVarDelegateArgsForCall = append(fake.loadVarDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("LoadVarDelegate", []interface{}{arg1})
+	fake.loadVarDelegateMutex.Unlock()
+	if fake.LoadVarDelegateStub != nil {
+		return fake.LoadVarDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.loadVarDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateCallCount() int {
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
+	return len(fake.loadVarDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateCalls(stub func(atc.PlanID) exec.LoadVarDelegate) {
+	fake.loadVarDelegateMutex.Lock()
+	defer fake.loadVarDelegateMutex.Unlock()
+	fake.LoadVarDelegateStub = stub
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateArgsForCall(i int) atc.PlanID {
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
+	argsForCall := fake.loadVarDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateReturns(result1 exec.LoadVarDelegate) {
+	fake.loadVarDelegateMutex.Lock()
+	defer fake.loadVarDelegateMutex.Unlock()
+	fake.LoadVarDelegateStub = nil
+	fake.loadVarDelegateReturns = struct {
+		result1 exec.LoadVarDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) LoadVarDelegateReturnsOnCall(i int, result1 exec.LoadVarDelegate) {
+	fake.loadVarDelegateMutex.Lock()
+	defer fake.loadVarDelegateMutex.Unlock()
+	fake.LoadVarDelegateStub = nil
+	if fake.loadVarDelegateReturnsOnCall == nil {
+		fake.loadVarDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.LoadVarDelegate
+		})
+	}
+	fake.loadVarDelegateReturnsOnCall[i] = struct {
+		result1 exec.LoadVarDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) PutDelegate(arg1 atc.PlanID) exec.PutDelegate {
 	fake.putDelegateMutex.Lock()
 	ret, specificReturn := fake.putDelegateReturnsOnCall[len(fake.putDelegateArgsForCall)]
@@ -279,6 +514,126 @@ func (fake *FakeBuildDelegate) PutDelegateReturnsOnCall(i int, result1 exec.PutD
 	}{result1}
 }
 
+func (fake *FakeBuildDelegate) RetryDelegate(arg1 atc.PlanID) exec.RetryDelegate {
+	fake.retryDelegateMutex.Lock()
+	ret, specificReturn := fake.retryDelegateReturnsOnCall[len(fake.retryDelegateArgsForCall)]
+	fake.retryDelegateArgsForCall = append(fake.retryDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("RetryDelegate", []interface{}{arg1})
+	fake.retryDelegateMutex.Unlock()
+	if fake.RetryDelegateStub != nil {
+		return fake.RetryDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.retryDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCallCount() int {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	return len(fake.retryDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateCalls(stub func(atc.PlanID) exec.RetryDelegate) {
+	fake.retryDelegateMutex.Lock()
+	defer fake.retryDelegateMutex.Unlock()
+	fake.RetryDelegateStub = stub
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateArgsForCall(i int) atc.PlanID {
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	argsForCall := fake.retryDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturns(result1 exec.RetryDelegate) {
+	fake.retryDelegateMutex.Lock()
+	defer fake.retryDelegateMutex.Unlock()
+	fake.RetryDelegateStub = nil
+	fake.retryDelegateReturns = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) RetryDelegateReturnsOnCall(i int, result1 exec.RetryDelegate) {
+	fake.retryDelegateMutex.Lock()
+	defer fake.retryDelegateMutex.Unlock()
+	fake.RetryDelegateStub = nil
+	if fake.retryDelegateReturnsOnCall == nil {
+		fake.retryDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.RetryDelegate
+		})
+	}
+	fake.retryDelegateReturnsOnCall[i] = struct {
+		result1 exec.RetryDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegate(arg1 atc.PlanID) exec.SetPipelineDelegate {
+	fake.setPipelineDelegateMutex.Lock()
+	ret, specificReturn := fake.setPipelineDelegateReturnsOnCall[len(fake.setPipelineDelegateArgsForCall)]
+	fake.setPipelineDelegateArgsForCall = append(fake.setPipelineDelegateArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("SetPipelineDelegate", []interface{}{arg1})
+	fake.setPipelineDelegateMutex.Unlock()
+	if fake.SetPipelineDelegateStub != nil {
+		return fake.SetPipelineDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setPipelineDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateCallCount() int {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return len(fake.setPipelineDelegateArgsForCall)
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateCalls(stub func(atc.PlanID) exec.SetPipelineDelegate) {
+	fake.setPipelineDelegateMutex.Lock()
+	defer fake.setPipelineDelegateMutex.Unlock()
+	fake.SetPipelineDelegateStub = stub
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateArgsForCall(i int) atc.PlanID {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	argsForCall := fake.setPipelineDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturns(result1 exec.SetPipelineDelegate) {
+	fake.setPipelineDelegateMutex.Lock()
+	defer fake.setPipelineDelegateMutex.Unlock()
+	fake.SetPipelineDelegateStub = nil
+	fake.setPipelineDelegateReturns = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
+func (fake *FakeBuildDelegate) SetPipelineDelegateReturnsOnCall(i int, result1 exec.SetPipelineDelegate) {
+	fake.setPipelineDelegateMutex.Lock()
+	defer fake.setPipelineDelegateMutex.Unlock()
+	fake.SetPipelineDelegateStub = nil
+	if fake.setPipelineDelegateReturnsOnCall == nil {
+		fake.setPipelineDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.SetPipelineDelegate
+		})
+	}
+	fake.setPipelineDelegateReturnsOnCall[i] = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
 func (fake *FakeBuildDelegate) TaskDelegate(arg1 atc.PlanID) exec.TaskDelegate {
 	fake.taskDelegateMutex.Lock()
 	ret, specificReturn := fake.taskDelegateReturnsOnCall[len(fake.taskDelegateArgsForCall)]
@@ -342,14 +697,24 @@ func (fake *FakeBuildDelegate) TaskDelegateReturnsOnCall(i int, result1 exec.Tas
 func (fake *FakeBuildDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.acrossDelegateMutex.RLock()
+	defer fake.acrossDelegateMutex.RUnlock()
 	fake.buildStepDelegateMutex.RLock()
 	defer fake.buildStepDelegateMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	fake.getDelegateMutex.RLock()
 	defer fake.getDelegateMutex.RUnlock()
+	fake.ifDelegateMutex.RLock()
+	defer fake.ifDelegateMutex.RUnlock()
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
 	fake.putDelegateMutex.RLock()
 	defer fake.putDelegateMutex.RUnlock()
+	fake.retryDelegateMutex.RLock()
+	defer fake.retryDelegateMutex.RUnlock()
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
 	fake.taskDelegateMutex.RLock()
 	defer fake.taskDelegateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
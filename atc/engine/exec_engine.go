@@ -3,10 +3,12 @@ package engine
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -15,6 +17,10 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 )
 
+// ErrBuildTimedOut is the error a build finishes with when it is aborted for
+// running longer than its job's build_timeout.
+var ErrBuildTimedOut = errors.New("build timed out")
+
 type execMetadata struct {
 	Plan atc.Plan
 }
@@ -153,6 +159,9 @@ func (build *execBuild) Resume(logger lager.Logger) {
 	state := build.runState()
 	defer build.clearRunState()
 
+	timedOut, stopTimeout := build.enforceTimeout(logger)
+	defer stopTimeout()
+
 	done := make(chan error, 1)
 	go func() {
 		done <- step.Run(runCtx, state)
@@ -164,12 +173,73 @@ func (build *execBuild) Resume(logger lager.Logger) {
 			logger.Info("releasing")
 			return
 		case err := <-done:
+			select {
+			case <-timedOut:
+				err = ErrBuildTimedOut
+			default:
+			}
+
 			build.delegate.Finish(logger.Session("finish"), err, step.Succeeded())
 			return
 		}
 	}
 }
 
+// enforceTimeout aborts the build once it has been running for longer than
+// its job's build_timeout, closing the returned channel beforehand. The
+// deadline is relative to the build's start time, so that it still holds when
+// the build is resumed by another ATC.
+//
+// The build is aborted rather than given a deadline so that any on_abort hooks
+// run, and so that timeout steps within the build do not mistake it for their
+// own.
+func (build *execBuild) enforceTimeout(logger lager.Logger) (<-chan struct{}, func()) {
+	timedOut := make(chan struct{})
+
+	timeout, err := build.buildTimeout()
+	if err != nil {
+		logger.Error("failed-to-determine-build-timeout", err)
+		return timedOut, func() {}
+	}
+
+	if timeout == 0 || build.dbBuild.StartTime().IsZero() {
+		return timedOut, func() {}
+	}
+
+	deadline := build.dbBuild.StartTime().Add(timeout)
+
+	timer := time.AfterFunc(time.Until(deadline), func() {
+		logger.Info("timed-out", lager.Data{"timeout": timeout.String()})
+		close(timedOut)
+		build.cancel()
+	})
+
+	return timedOut, func() { timer.Stop() }
+}
+
+func (build *execBuild) buildTimeout() (time.Duration, error) {
+	if build.dbBuild.JobName() == "" {
+		return 0, nil
+	}
+
+	pipeline, found, err := build.dbBuild.Pipeline()
+	if err != nil || !found {
+		return 0, err
+	}
+
+	job, found, err := pipeline.Job(build.dbBuild.JobName())
+	if err != nil || !found {
+		return 0, err
+	}
+
+	timeout := job.Config().BuildTimeout
+	if timeout == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(timeout)
+}
+
 func (build *execBuild) ReceiveInput(logger lager.Logger, plan atc.PlanID, stream io.ReadCloser) {
 	build.runState().SendUserInput(plan, stream)
 }
@@ -192,6 +262,10 @@ func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step
 		return build.buildAggregateStep(logger, plan)
 	}
 
+	if plan.InParallel != nil {
+		return build.buildInParallelStep(logger, plan)
+	}
+
 	if plan.Do != nil {
 		return build.buildDoStep(logger, plan)
 	}
@@ -232,10 +306,26 @@ func (build *execBuild) buildStep(logger lager.Logger, plan atc.Plan) exec.Step
 		return build.buildPutStep(logger, plan)
 	}
 
+	if plan.SetPipeline != nil {
+		return build.buildSetPipelineStep(logger, plan)
+	}
+
+	if plan.LoadVar != nil {
+		return build.buildLoadVarStep(logger, plan)
+	}
+
 	if plan.Retry != nil {
 		return build.buildRetryStep(logger, plan)
 	}
 
+	if plan.Across != nil {
+		return build.buildAcrossStep(logger, plan)
+	}
+
+	if plan.If != nil {
+		return build.buildIfStep(logger, plan)
+	}
+
 	if plan.UserArtifact != nil {
 		return build.buildUserArtifactStep(logger, plan)
 	}
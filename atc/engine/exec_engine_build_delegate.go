@@ -16,6 +16,11 @@ type BuildDelegate interface {
 	GetDelegate(atc.PlanID) exec.GetDelegate
 	PutDelegate(atc.PlanID) exec.PutDelegate
 	TaskDelegate(atc.PlanID) exec.TaskDelegate
+	AcrossDelegate(atc.PlanID) exec.AcrossDelegate
+	SetPipelineDelegate(atc.PlanID) exec.SetPipelineDelegate
+	LoadVarDelegate(atc.PlanID) exec.LoadVarDelegate
+	RetryDelegate(atc.PlanID) exec.RetryDelegate
+	IfDelegate(atc.PlanID) exec.IfDelegate
 
 	BuildStepDelegate(atc.PlanID) exec.BuildStepDelegate
 
@@ -60,12 +65,35 @@ func (delegate *delegate) TaskDelegate(planID atc.PlanID) exec.TaskDelegate {
 	return NewTaskDelegate(delegate.build, planID, clock.NewClock())
 }
 
+func (delegate *delegate) AcrossDelegate(planID atc.PlanID) exec.AcrossDelegate {
+	return NewAcrossDelegate(delegate.build, planID, clock.NewClock())
+}
+
+func (delegate *delegate) SetPipelineDelegate(planID atc.PlanID) exec.SetPipelineDelegate {
+	return NewSetPipelineDelegate(delegate.build, planID, clock.NewClock())
+}
+
+func (delegate *delegate) LoadVarDelegate(planID atc.PlanID) exec.LoadVarDelegate {
+	return NewLoadVarDelegate(delegate.build, planID, clock.NewClock())
+}
+
+func (delegate *delegate) RetryDelegate(planID atc.PlanID) exec.RetryDelegate {
+	return NewRetryDelegate(delegate.build, planID, clock.NewClock())
+}
+
+func (delegate *delegate) IfDelegate(planID atc.PlanID) exec.IfDelegate {
+	return NewIfDelegate(delegate.build, planID, clock.NewClock())
+}
+
 func (delegate *delegate) BuildStepDelegate(planID atc.PlanID) exec.BuildStepDelegate {
 	return NewBuildStepDelegate(delegate.build, planID, clock.NewClock())
 }
 
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded bool) {
-	if err == context.Canceled {
+	if err == ErrBuildTimedOut {
+		delegate.saveStatusWithReason(logger, atc.StatusAborted, atc.BuildStatusReasonTimedOut)
+		logger.Info("timed-out")
+	} else if err == context.Canceled {
 		delegate.saveStatus(logger, atc.StatusAborted)
 		logger.Info("aborted")
 	} else if err != nil {
@@ -86,3 +114,10 @@ func (delegate *delegate) saveStatus(logger lager.Logger, status atc.BuildStatus
 		logger.Error("failed-to-finish-build", err)
 	}
 }
+
+func (delegate *delegate) saveStatusWithReason(logger lager.Logger, status atc.BuildStatus, reason string) {
+	err := delegate.build.FinishWithReason(db.BuildStatus(status), reason)
+	if err != nil {
+		logger.Error("failed-to-finish-build", err)
+	}
+}
@@ -45,6 +45,18 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Context("when build timed out", func() {
+			BeforeEach(func() {
+				delegate.Finish(logger, ErrBuildTimedOut, false)
+			})
+
+			It("updates build status to aborted, with the reason", func() {
+				finishedStatus, reason := fakeBuild.FinishWithReasonArgsForCall(0)
+				Expect(finishedStatus).To(Equal(db.BuildStatusAborted))
+				Expect(reason).To(Equal("timed out"))
+			})
+		})
+
 		Context("when build had error", func() {
 			BeforeEach(func() {
 				delegate.Finish(logger, errors.New("disaster"), false)
@@ -1,12 +1,16 @@
 package engine_test
 
 import (
+	"context"
+	"time"
+
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/enginefakes"
+	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -80,6 +84,8 @@ var _ = Describe("ExecEngine", func() {
 			}
 
 			fakeDelegate = new(enginefakes.FakeBuildDelegate)
+			fakeDelegate.RetryDelegateReturns(new(execfakes.FakeRetryDelegate))
+			fakeDelegate.IfDelegateReturns(new(execfakes.FakeIfDelegate))
 			fakeDelegateFactory.DelegateReturns(fakeDelegate)
 
 			inputStep = new(execfakes.FakeStep)
@@ -141,7 +147,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(2))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(putPlan))
@@ -157,7 +163,7 @@ var _ = Describe("ExecEngine", func() {
 						BuildName:    "42",
 					}))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ = fakeFactory.PutArgsForCall(1)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ = fakeFactory.PutArgsForCall(1)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(otherPutPlan))
@@ -204,8 +210,10 @@ var _ = Describe("ExecEngine", func() {
 				})
 
 				retryPlanTwo = planFactory.NewPlan(atc.RetryPlan{
-					taskPlan,
-					taskPlan,
+					Attempts: []atc.Plan{
+						taskPlan,
+						taskPlan,
+					},
 				})
 
 				aggregatePlan = planFactory.NewPlan(atc.AggregatePlan{retryPlanTwo})
@@ -218,9 +226,11 @@ var _ = Describe("ExecEngine", func() {
 				})
 
 				retryPlan = planFactory.NewPlan(atc.RetryPlan{
-					getPlan,
-					timeoutPlan,
-					getPlan,
+					Attempts: []atc.Plan{
+						getPlan,
+						timeoutPlan,
+						getPlan,
+					},
 				})
 
 				build, err = execEngine.CreateBuild(logger, dbBuild, retryPlan)
@@ -231,11 +241,17 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the retry correctly", func() {
-				Expect(*retryPlan.Retry).To(HaveLen(3))
+				Expect(retryPlan.Retry.Attempts).To(HaveLen(3))
+			})
+
+			It("constructs a retry delegate for each retry", func() {
+				Expect(fakeDelegate.RetryDelegateCallCount()).To(Equal(2))
+				Expect(fakeDelegate.RetryDelegateArgsForCall(0)).To(Equal(retryPlanTwo.ID))
+				Expect(fakeDelegate.RetryDelegateArgsForCall(1)).To(Equal(retryPlan.ID))
 			})
 
 			It("constructs the first get correctly", func() {
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+				logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := getPlan
@@ -256,7 +272,7 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs the second get correctly", func() {
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(1)
+				logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := getPlan
@@ -277,11 +293,11 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested retries correctly", func() {
-				Expect(*retryPlanTwo.Retry).To(HaveLen(2))
+				Expect(retryPlanTwo.Retry.Attempts).To(HaveLen(2))
 			})
 
 			It("constructs nested steps correctly", func() {
-				logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				logger, plan, build, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan := taskPlan
@@ -299,7 +315,7 @@ var _ = Describe("ExecEngine", func() {
 					Attempt:      "2.1",
 				}))
 
-				logger, plan, build, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				logger, plan, build, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				expectedPlan = taskPlan
@@ -358,7 +374,9 @@ var _ = Describe("ExecEngine", func() {
 				})
 
 				retryPlan = planFactory.NewPlan(atc.RetryPlan{
-					ensurePlan,
+					Attempts: []atc.Plan{
+						ensurePlan,
+					},
 				})
 
 				build, err = execEngine.CreateBuild(logger, dbBuild, retryPlan)
@@ -368,15 +386,15 @@ var _ = Describe("ExecEngine", func() {
 			})
 
 			It("constructs nested steps correctly", func() {
-				_, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(1)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(2)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(3)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
-				_, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
+				_, _, _, _, containerMetadata, _ = fakeFactory.TaskArgsForCall(4)
 				Expect(containerMetadata.Attempt).To(Equal("1"))
 			})
 		})
@@ -405,7 +423,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, plan, dBuild, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+					logger, plan, dBuild, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(dBuild).To(Equal(dbBuild))
 					Expect(plan).To(Equal(expectedPlan))
@@ -454,7 +472,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.TaskCallCount()).To(Equal(1))
 
-					logger, plan, build, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
+					logger, plan, build, _, containerMetadata, _ := fakeFactory.TaskArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(expectedPlan))
@@ -471,6 +489,168 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 
+			Context("that contains a set_pipeline step", func() {
+				var setPipelineStep *execfakes.FakeStep
+
+				BeforeEach(func() {
+					setPipelineStep = new(execfakes.FakeStep)
+					setPipelineStep.SucceededReturns(true)
+					fakeFactory.SetPipelineReturns(setPipelineStep)
+
+					expectedPlan = planFactory.NewPlan(atc.SetPipelinePlan{
+						Name: "some-pipeline",
+						File: "some-input/pipeline.yml",
+					})
+				})
+
+				It("constructs the step correctly", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, expectedPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.SetPipelineCallCount()).To(Equal(1))
+
+					logger, plan, build, _ := fakeFactory.SetPipelineArgsForCall(0)
+					Expect(logger).NotTo(BeNil())
+					Expect(build).To(Equal(dbBuild))
+					Expect(plan).To(Equal(expectedPlan))
+
+					Expect(fakeDelegate.SetPipelineDelegateCallCount()).To(Equal(1))
+					Expect(fakeDelegate.SetPipelineDelegateArgsForCall(0)).To(Equal(expectedPlan.ID))
+
+					Expect(setPipelineStep.RunCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("that contains an in_parallel step", func() {
+				var taskPlan, otherTaskPlan atc.Plan
+
+				BeforeEach(func() {
+					taskPlan = planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-task",
+						ConfigPath: "some-input/task.yml",
+					})
+
+					otherTaskPlan = planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-other-task",
+						ConfigPath: "some-input/task.yml",
+					})
+
+					expectedPlan = planFactory.NewPlan(atc.InParallelPlan{
+						Steps:    []atc.Plan{taskPlan, otherTaskPlan},
+						Limit:    1,
+						FailFast: true,
+					})
+				})
+
+				It("constructs each of its steps", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, expectedPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.TaskCallCount()).To(Equal(2))
+
+					plans := []atc.Plan{}
+					for i := 0; i < fakeFactory.TaskCallCount(); i++ {
+						_, plan, _, _, _, _ := fakeFactory.TaskArgsForCall(i)
+						plans = append(plans, plan)
+					}
+
+					Expect(plans).To(ConsistOf(taskPlan, otherTaskPlan))
+				})
+			})
+
+			Context("that contains a load_var step", func() {
+				var loadVarStep *execfakes.FakeStep
+
+				BeforeEach(func() {
+					loadVarStep = new(execfakes.FakeStep)
+					loadVarStep.SucceededReturns(true)
+					fakeFactory.LoadVarReturns(loadVarStep)
+
+					expectedPlan = planFactory.NewPlan(atc.LoadVarPlan{
+						Name: "some-var",
+						File: "some-input/version",
+					})
+				})
+
+				It("constructs the step correctly", func() {
+					var err error
+					build, err = execEngine.CreateBuild(logger, dbBuild, expectedPlan)
+					Expect(err).NotTo(HaveOccurred())
+
+					build.Resume(logger)
+					Expect(fakeFactory.LoadVarCallCount()).To(Equal(1))
+
+					logger, plan, _ := fakeFactory.LoadVarArgsForCall(0)
+					Expect(logger).NotTo(BeNil())
+					Expect(plan).To(Equal(expectedPlan))
+
+					Expect(fakeDelegate.LoadVarDelegateCallCount()).To(Equal(1))
+					Expect(fakeDelegate.LoadVarDelegateArgsForCall(0)).To(Equal(expectedPlan.ID))
+
+					Expect(loadVarStep.RunCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("that contains a step with a condition", func() {
+				var fakeIfDelegate *execfakes.FakeIfDelegate
+
+				BeforeEach(func() {
+					fakeIfDelegate = new(execfakes.FakeIfDelegate)
+					fakeDelegate.IfDelegateReturns(fakeIfDelegate)
+				})
+
+				Context("when the condition holds for the build", func() {
+					BeforeEach(func() {
+						expectedPlan = planFactory.NewPlan(atc.IfPlan{
+							Condition: `$BUILD_PIPELINE_NAME == "some-pipeline"`,
+							Step: planFactory.NewPlan(atc.TaskPlan{
+								Name:       "some-task",
+								ConfigPath: "some-input/task.yml",
+							}),
+						})
+					})
+
+					It("runs the step", func() {
+						var err error
+						build, err = execEngine.CreateBuild(logger, dbBuild, expectedPlan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(fakeDelegate.IfDelegateCallCount()).To(Equal(1))
+						Expect(fakeDelegate.IfDelegateArgsForCall(0)).To(Equal(expectedPlan.ID))
+
+						Expect(taskStep.RunCallCount()).To(Equal(1))
+						Expect(fakeIfDelegate.SkippedCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the condition does not hold for the build", func() {
+					BeforeEach(func() {
+						expectedPlan = planFactory.NewPlan(atc.IfPlan{
+							Condition: `$BUILD_JOB_NAME != "some-job"`,
+							Step: planFactory.NewPlan(atc.TaskPlan{
+								Name:       "some-task",
+								ConfigPath: "some-input/task.yml",
+							}),
+						})
+					})
+
+					It("skips the step", func() {
+						var err error
+						build, err = execEngine.CreateBuild(logger, dbBuild, expectedPlan)
+						Expect(err).NotTo(HaveOccurred())
+
+						build.Resume(logger)
+						Expect(taskStep.RunCallCount()).To(BeZero())
+						Expect(fakeIfDelegate.SkippedCallCount()).To(Equal(1))
+					})
+				})
+			})
+
 			Context("that contains outputs", func() {
 				var (
 					expectedPlan     atc.Plan
@@ -511,7 +691,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.PutCallCount()).To(Equal(1))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.PutArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(putPlan))
@@ -536,7 +716,7 @@ var _ = Describe("ExecEngine", func() {
 					build.Resume(logger)
 					Expect(fakeFactory.GetCallCount()).To(Equal(1))
 
-					logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+					logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 					Expect(logger).NotTo(BeNil())
 					Expect(build).To(Equal(dbBuild))
 					Expect(plan).To(Equal(dependentGetPlan))
@@ -554,6 +734,83 @@ var _ = Describe("ExecEngine", func() {
 				})
 			})
 		})
+
+		Context("when the build's job has a build timeout", func() {
+			var (
+				fakeJob   *dbfakes.FakeJob
+				abortStep *execfakes.FakeStep
+				plan      atc.Plan
+			)
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job", BuildTimeout: "1h"})
+
+				fakePipeline := new(dbfakes.FakePipeline)
+				fakePipeline.JobReturns(fakeJob, true, nil)
+				dbBuild.PipelineReturns(fakePipeline, true, nil)
+
+				taskStep.RunStub = func(ctx context.Context, _ exec.RunState) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				taskStep.SucceededReturns(false)
+
+				abortStep = new(execfakes.FakeStep)
+				fakeFactory.TaskReturnsOnCall(1, abortStep)
+
+				plan = planFactory.NewPlan(atc.OnAbortPlan{
+					Step: planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-task",
+						ConfigPath: "some-input/task.yml",
+					}),
+					Next: planFactory.NewPlan(atc.TaskPlan{
+						Name:       "some-abort-task",
+						ConfigPath: "some-input/abort.yml",
+					}),
+				})
+
+				fakeFactory.TaskReturnsOnCall(0, taskStep)
+			})
+
+			JustBeforeEach(func() {
+				var err error
+				build, err = execEngine.CreateBuild(logger, dbBuild, plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				build.Resume(logger)
+			})
+
+			Context("when the build has run for longer than the timeout", func() {
+				BeforeEach(func() {
+					dbBuild.StartTimeReturns(time.Now().Add(-2 * time.Hour))
+				})
+
+				It("aborts the build, running its on_abort hooks", func() {
+					Expect(abortStep.RunCallCount()).To(Equal(1))
+				})
+
+				It("finishes the build as timed out", func() {
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, _ := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).To(Equal(engine.ErrBuildTimedOut))
+				})
+			})
+
+			Context("when the build is within the timeout", func() {
+				BeforeEach(func() {
+					dbBuild.StartTimeReturns(time.Now())
+					taskStep.RunStub = nil
+				})
+
+				It("finishes the build normally", func() {
+					Expect(fakeDelegate.FinishCallCount()).To(Equal(1))
+					_, err, _ := fakeDelegate.FinishArgsForCall(0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(abortStep.RunCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("LookupBuild", func() {
@@ -603,7 +860,7 @@ var _ = Describe("ExecEngine", func() {
 
 				foundBuild.Resume(logger)
 				Expect(fakeFactory.GetCallCount()).To(Equal(1))
-				logger, plan, build, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
+				logger, plan, build, _, stepMetadata, containerMetadata, _ := fakeFactory.GetArgsForCall(0)
 				Expect(logger).NotTo(BeNil())
 				Expect(build).To(Equal(dbBuild))
 				Expect(plan.ID).To(Equal(atc.PlanID("47")))
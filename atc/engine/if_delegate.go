@@ -0,0 +1,41 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type ifDelegate struct {
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewIfDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.IfDelegate {
+	return &ifDelegate{
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *ifDelegate) Skipped(logger lager.Logger, condition string) {
+	err := d.build.SaveEvent(event.Skipped{
+		Time:      d.clock.Now().Unix(),
+		Origin:    d.eventOrigin,
+		Condition: condition,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-skipped-event", err)
+		return
+	}
+
+	logger.Info("skipped", lager.Data{"condition": condition})
+}
@@ -0,0 +1,15 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+// NewLoadVarDelegate returns a delegate which saves the step's lifecycle as
+// initialize, start and finish events, the same as a set_pipeline step's.
+func NewLoadVarDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.LoadVarDelegate {
+	return NewSetPipelineDelegate(build, planID, clock)
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type retryDelegate struct {
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewRetryDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.RetryDelegate {
+	return &retryDelegate{
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *retryDelegate) Retrying(logger lager.Logger, attempt int, planID atc.PlanID, cause string, delay time.Duration) {
+	retry := event.Retry{
+		Time:    d.clock.Now().Unix(),
+		Origin:  d.eventOrigin,
+		Attempt: attempt,
+		PlanID:  planID,
+		Cause:   cause,
+	}
+
+	if delay > 0 {
+		retry.Delay = delay.String()
+	}
+
+	err := d.build.SaveEvent(retry)
+	if err != nil {
+		logger.Error("failed-to-save-retry-event", err)
+		return
+	}
+
+	logger.Info("retrying", lager.Data{"attempt": attempt, "cause": cause, "delay": delay.String()})
+}
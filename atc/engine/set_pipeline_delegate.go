@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type setPipelineDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func NewSetPipelineDelegate(build db.Build, planID atc.PlanID, clock clock.Clock) exec.SetPipelineDelegate {
+	return &setPipelineDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, clock),
+
+		build: build,
+		eventOrigin: event.Origin{
+			ID: event.OriginID(planID),
+		},
+		clock: clock,
+	}
+}
+
+func (d *setPipelineDelegate) Initializing(logger lager.Logger) {
+	err := d.build.SaveEvent(event.Initialize{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-event", err)
+		return
+	}
+
+	logger.Debug("initializing")
+}
+
+func (d *setPipelineDelegate) Starting(logger lager.Logger) {
+	err := d.build.SaveEvent(event.Start{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-event", err)
+		return
+	}
+
+	logger.Debug("starting")
+}
+
+func (d *setPipelineDelegate) Finished(logger lager.Logger, succeeded bool) {
+	err := d.build.SaveEvent(event.Finish{
+		Origin:    d.eventOrigin,
+		Time:      d.clock.Now().Unix(),
+		Succeeded: succeeded,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{"succeeded": succeeded})
+}
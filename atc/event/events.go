@@ -82,6 +82,7 @@ func (StartTask) Version() atc.EventVersion { return "5.0" }
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
+	Reason string          `json:"reason,omitempty"`
 }
 
 func (Status) EventType() atc.EventType  { return EventTypeStatus }
@@ -129,3 +130,87 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.0" }
+
+type StartCombination struct {
+	Time   int64                  `json:"time"`
+	Origin Origin                 `json:"origin"`
+	Index  int                    `json:"index"`
+	PlanID atc.PlanID             `json:"plan_id"`
+	Vars   map[string]interface{} `json:"vars"`
+}
+
+func (StartCombination) EventType() atc.EventType  { return EventTypeStartCombination }
+func (StartCombination) Version() atc.EventVersion { return "1.0" }
+
+type FinishCombination struct {
+	Time      int64  `json:"time"`
+	Origin    Origin `json:"origin"`
+	Index     int    `json:"index"`
+	Succeeded bool   `json:"succeeded"`
+}
+
+func (FinishCombination) EventType() atc.EventType  { return EventTypeFinishCombination }
+func (FinishCombination) Version() atc.EventVersion { return "1.0" }
+
+type Retry struct {
+	Time    int64      `json:"time"`
+	Origin  Origin     `json:"origin"`
+	Attempt int        `json:"attempt"`
+	PlanID  atc.PlanID `json:"plan_id"`
+	Cause   string     `json:"cause"`
+	Delay   string     `json:"delay,omitempty"`
+}
+
+func (Retry) EventType() atc.EventType  { return EventTypeRetry }
+func (Retry) Version() atc.EventVersion { return "1.0" }
+
+type Skipped struct {
+	Time      int64  `json:"time"`
+	Origin    Origin `json:"origin"`
+	Condition string `json:"condition"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
+
+type WaitingForWorker struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
+func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
+
+type FinishCheck struct {
+	Time        int64 `json:"time"`
+	Succeeded   bool  `json:"succeeded"`
+	NewVersions int   `json:"new_versions"`
+}
+
+func (FinishCheck) EventType() atc.EventType  { return EventTypeFinishCheck }
+func (FinishCheck) Version() atc.EventVersion { return "1.0" }
+
+type Initialize struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (Initialize) EventType() atc.EventType  { return EventTypeInitialize }
+func (Initialize) Version() atc.EventVersion { return "2.0" }
+
+type Start struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (Start) EventType() atc.EventType  { return EventTypeStart }
+func (Start) Version() atc.EventVersion { return "2.0" }
+
+type Finish struct {
+	Time      int64  `json:"time"`
+	Origin    Origin `json:"origin"`
+	Succeeded bool   `json:"succeeded"`
+}
+
+func (Finish) EventType() atc.EventType  { return EventTypeFinish }
+func (Finish) Version() atc.EventVersion { return "2.0" }
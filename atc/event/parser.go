@@ -41,6 +41,15 @@ func init() {
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(StartCombination{})
+	registerEvent(FinishCombination{})
+	registerEvent(Retry{})
+	registerEvent(Skipped{})
+	registerEvent(FinishCheck{})
+	registerEvent(WaitingForWorker{})
+	registerEvent(Initialize{})
+	registerEvent(Start{})
+	registerEvent(Finish{})
 
 	// deprecated:
 	registerEvent(InitializeV10{})
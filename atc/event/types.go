@@ -12,6 +12,12 @@ const (
 	// step initializing
 	EventTypeInitialize atc.EventType = "initialize"
 
+	// step execution started
+	EventTypeStart atc.EventType = "start"
+
+	// step execution finished
+	EventTypeFinish atc.EventType = "finish"
+
 	// task execution started
 	EventTypeStartTask atc.EventType = "start-task"
 
@@ -27,6 +33,24 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// started running a combination of an across step's vars
+	EventTypeStartCombination atc.EventType = "start-combination"
+
+	// finished running a combination of an across step's vars
+	EventTypeFinishCombination atc.EventType = "finish-combination"
+
+	// a step errored or failed and is about to be attempted again
+	EventTypeRetry atc.EventType = "retry"
+
+	// a step was skipped as its condition did not hold
+	EventTypeSkipped atc.EventType = "skipped"
+
+	// finished checking a resource for new versions
+	EventTypeFinishCheck atc.EventType = "finish-check"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// step is waiting for a worker with room for its container
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
 )
@@ -0,0 +1,263 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/condition"
+	"github.com/concourse/concourse/atc/template"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . AcrossDelegate
+
+type AcrossDelegate interface {
+	BuildStepDelegate
+
+	StartingCombination(lager.Logger, int, atc.PlanID, map[string]interface{})
+	FinishedCombination(lager.Logger, int, bool)
+}
+
+// StepBuilder constructs the Step for a plan.
+type StepBuilder func(atc.Plan) Step
+
+// AcrossStep runs a step once for every combination of the values of its
+// vars, with each combination's values interpolated into the step's plan.
+type AcrossStep struct {
+	plan        atc.AcrossPlan
+	delegate    AcrossDelegate
+	stepBuilder StepBuilder
+
+	succeeded bool
+}
+
+func Across(plan atc.AcrossPlan, delegate AcrossDelegate, stepBuilder StepBuilder) Step {
+	return &AcrossStep{
+		plan:        plan,
+		delegate:    delegate,
+		stepBuilder: stepBuilder,
+	}
+}
+
+// Run resolves the values of each var, loading them from a file in the
+// worker.ArtifactRepository if configured to, and then runs the step for
+// every combination of them.
+//
+// Each var's values are run in parallel, up to the var's max in flight,
+// nesting the vars in the order they were configured. If the plan is
+// configured to fail fast, no more combinations are started once one of them
+// fails, and the ones that are running are interrupted.
+func (step *AcrossStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
+	values := make([][]interface{}, len(step.plan.Vars))
+	for i, acrossVar := range step.plan.Vars {
+		varValues, err := step.varValues(logger, state.Artifacts(), acrossVar)
+		if err != nil {
+			return err
+		}
+
+		values[i] = varValues
+	}
+
+	succeeded, err := step.runVar(ctx, state, values, []int{})
+	if err != nil {
+		return err
+	}
+
+	step.succeeded = succeeded
+
+	return nil
+}
+
+// Succeeded is true if the step succeeded for every combination.
+func (step *AcrossStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *AcrossStep) varValues(logger lager.Logger, repo *worker.ArtifactRepository, acrossVar atc.AcrossVar) ([]interface{}, error) {
+	if acrossVar.File == "" {
+		return acrossVar.Values, nil
+	}
+
+	payload, err := readArtifactFile(logger, repo, acrossVar.File)
+	if err != nil {
+		return nil, err
+	}
+
+	var untypedValues interface{}
+	err = yaml.Unmarshal(payload, &untypedValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse values of var '%s' from '%s': %s", acrossVar.Var, acrossVar.File, err)
+	}
+
+	var values []interface{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:     &values,
+		DecodeHook: atc.SanitizeDecodeHook,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(untypedValues)
+	if err != nil {
+		return nil, fmt.Errorf("values of var '%s' in '%s' must be a list: %s", acrossVar.Var, acrossVar.File, err)
+	}
+
+	return values, nil
+}
+
+func (step *AcrossStep) runVar(ctx context.Context, state RunState, values [][]interface{}, positions []int) (bool, error) {
+	varIndex := len(positions)
+	if varIndex == len(values) {
+		return step.runCombination(ctx, state, values, positions)
+	}
+
+	return runInParallel(
+		ctx,
+		len(values[varIndex]),
+		step.maxInFlight(varIndex),
+		step.plan.FailFast,
+		func(ctx context.Context, i int) (bool, error) {
+			return step.runVar(ctx, state, values, append(append([]int{}, positions...), i))
+		},
+	)
+}
+
+func (step *AcrossStep) maxInFlight(varIndex int) int {
+	maxInFlight := step.plan.Vars[varIndex].MaxInFlight
+	if maxInFlight == 0 {
+		return 1
+	}
+
+	return maxInFlight
+}
+
+func (step *AcrossStep) runCombination(ctx context.Context, state RunState, values [][]interface{}, positions []int) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+
+	index := 0
+	vars := map[string]interface{}{}
+	for i, acrossVar := range step.plan.Vars {
+		index = index*len(values[i]) + positions[i]
+		vars[acrossVar.Var] = values[i][positions[i]]
+	}
+
+	plan, err := step.combinationPlan(index, vars)
+	if err != nil {
+		return false, err
+	}
+
+	step.delegate.StartingCombination(logger, index, plan.ID, vars)
+
+	combination := step.stepBuilder(plan)
+
+	err = combination.Run(ctx, state)
+	succeeded := err == nil && combination.Succeeded()
+
+	step.delegate.FinishedCombination(logger, index, succeeded)
+
+	return succeeded, err
+}
+
+// combinationPlan interpolates the vars of a combination into the step's
+// plan. Every plan ID is suffixed with the combination's index so that each
+// combination's steps are distinct from the others'.
+func (step *AcrossStep) combinationPlan(index int, vars map[string]interface{}) (atc.Plan, error) {
+	payload, err := json.Marshal(step.plan.Step)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var untypedPlan interface{}
+	err = decoder.Decode(&untypedPlan)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	err = interpolateConditions(untypedPlan, vars)
+	if err != nil {
+		return atc.Plan{}, fmt.Errorf("failed to interpolate across vars: %s", err)
+	}
+
+	untypedPlan, err = template.InterpolateLocalVars(untypedPlan, vars)
+	if err != nil {
+		return atc.Plan{}, fmt.Errorf("failed to interpolate across vars: %s", err)
+	}
+
+	payload, err = json.Marshal(untypedPlan)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	var plan atc.Plan
+	err = json.Unmarshal(payload, &plan)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	plan.Each(func(p *atc.Plan) {
+		p.ID = combinationPlanID(p.ID, index)
+
+		if p.Get != nil && p.Get.VersionFrom != nil {
+			versionFrom := combinationPlanID(*p.Get.VersionFrom, index)
+			p.Get.VersionFrom = &versionFrom
+		}
+	})
+
+	return plan, nil
+}
+
+// interpolateConditions interpolates the vars of a combination into the
+// conditions of any if plans within a JSON-decoded plan. Their values are
+// interpolated as literals, e.g. "linux" rather than linux, so that they are
+// compared as values rather than becoming part of the condition.
+func interpolateConditions(node interface{}, vars map[string]interface{}) error {
+	switch typedNode := node.(type) {
+	case map[string]interface{}:
+		_, isPlan := typedNode["id"]
+		ifPlan, isIf := typedNode["if"].(map[string]interface{})
+		if isPlan && isIf {
+			if source, ok := ifPlan["condition"].(string); ok {
+				interpolated, err := condition.Interpolate(source, vars)
+				if err != nil {
+					return err
+				}
+
+				ifPlan["condition"] = interpolated
+			}
+		}
+
+		for _, val := range typedNode {
+			err := interpolateConditions(val, vars)
+			if err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for _, val := range typedNode {
+			err := interpolateConditions(val, vars)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func combinationPlanID(id atc.PlanID, index int) atc.PlanID {
+	return atc.PlanID(fmt.Sprintf("%s/%d", id, index))
+}
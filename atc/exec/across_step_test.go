@@ -0,0 +1,237 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Across", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		plan         atc.AcrossPlan
+		fakeDelegate *execfakes.FakeAcrossDelegate
+
+		combinationSucceeded func(int) bool
+		combinationErr       error
+
+		builtPlans []atc.Plan
+		fakeSteps  []*execfakes.FakeStep
+		buildLock  sync.Mutex
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		plan = atc.AcrossPlan{
+			Vars: []atc.AcrossVar{
+				{Var: "go_version", Values: []interface{}{"1.11", "1.12"}},
+				{Var: "platform", Values: []interface{}{"linux", "darwin"}},
+			},
+			Step: atc.Plan{
+				ID: "some-id",
+				OnSuccess: &atc.OnSuccessPlan{
+					Step: atc.Plan{
+						ID: "put-id",
+						Put: &atc.PutPlan{
+							Name:   "some-put",
+							Params: atc.Params{"version": "((.:go_version))", "file": "out/((.:platform))"},
+						},
+					},
+					Next: atc.Plan{
+						ID: "get-id",
+						Get: &atc.GetPlan{
+							Name:        "some-put",
+							VersionFrom: planIDPtr("put-id"),
+						},
+					},
+				},
+			},
+		}
+
+		fakeDelegate = new(execfakes.FakeAcrossDelegate)
+
+		combinationSucceeded = func(int) bool { return true }
+		combinationErr = nil
+
+		builtPlans = nil
+		fakeSteps = nil
+
+		repo = worker.NewArtifactRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = Across(plan, fakeDelegate, func(p atc.Plan) Step {
+			buildLock.Lock()
+			defer buildLock.Unlock()
+
+			fakeStep := new(execfakes.FakeStep)
+			fakeStep.SucceededReturns(combinationSucceeded(len(builtPlans)))
+			fakeStep.RunReturns(combinationErr)
+
+			builtPlans = append(builtPlans, p)
+			fakeSteps = append(fakeSteps, fakeStep)
+
+			return fakeStep
+		})
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("runs the step for every combination, in order", func() {
+		Expect(builtPlans).To(HaveLen(4))
+
+		Expect(builtPlans[0].OnSuccess.Step.Put.Params).To(Equal(atc.Params{"version": "1.11", "file": "out/linux"}))
+		Expect(builtPlans[1].OnSuccess.Step.Put.Params).To(Equal(atc.Params{"version": "1.11", "file": "out/darwin"}))
+		Expect(builtPlans[2].OnSuccess.Step.Put.Params).To(Equal(atc.Params{"version": "1.12", "file": "out/linux"}))
+		Expect(builtPlans[3].OnSuccess.Step.Put.Params).To(Equal(atc.Params{"version": "1.12", "file": "out/darwin"}))
+
+		for _, fakeStep := range fakeSteps {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+		}
+	})
+
+	It("gives each combination's plans distinct IDs", func() {
+		Expect(builtPlans[1].ID).To(Equal(atc.PlanID("some-id/1")))
+		Expect(builtPlans[1].OnSuccess.Step.ID).To(Equal(atc.PlanID("put-id/1")))
+		Expect(builtPlans[1].OnSuccess.Next.ID).To(Equal(atc.PlanID("get-id/1")))
+		Expect(*builtPlans[1].OnSuccess.Next.Get.VersionFrom).To(Equal(atc.PlanID("put-id/1")))
+	})
+
+	It("reports each combination", func() {
+		Expect(fakeDelegate.StartingCombinationCallCount()).To(Equal(4))
+		_, index, planID, vars := fakeDelegate.StartingCombinationArgsForCall(2)
+		Expect(index).To(Equal(2))
+		Expect(planID).To(Equal(atc.PlanID("some-id/2")))
+		Expect(vars).To(Equal(map[string]interface{}{"go_version": "1.12", "platform": "linux"}))
+
+		Expect(fakeDelegate.FinishedCombinationCallCount()).To(Equal(4))
+		_, index, succeeded := fakeDelegate.FinishedCombinationArgsForCall(2)
+		Expect(index).To(Equal(2))
+		Expect(succeeded).To(BeTrue())
+	})
+
+	Context("when a var is loaded from a file", func() {
+		var fakeArtifactSource *workerfakes.FakeArtifactSource
+
+		BeforeEach(func() {
+			plan.Vars[1] = atc.AcrossVar{Var: "platform", File: "some-artifact/platforms.yml"}
+
+			fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+			fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte("[windows]")), nil)
+			repo.RegisterSource("some-artifact", fakeArtifactSource)
+		})
+
+		It("runs across the file's values", func() {
+			_, path := fakeArtifactSource.StreamFileArgsForCall(0)
+			Expect(path).To(Equal("platforms.yml"))
+
+			Expect(builtPlans).To(HaveLen(2))
+			Expect(builtPlans[0].OnSuccess.Step.Put.Params).To(Equal(atc.Params{"version": "1.11", "file": "out/windows"}))
+			Expect(builtPlans[1].OnSuccess.Step.Put.Params).To(Equal(atc.Params{"version": "1.12", "file": "out/windows"}))
+		})
+
+		Context("when the file does not contain a list", func() {
+			BeforeEach(func() {
+				fakeArtifactSource.StreamFileReturns(gbytes.BufferWithBytes([]byte("windows")), nil)
+			})
+
+			It("errors without running anything", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("must be a list")))
+				Expect(builtPlans).To(BeEmpty())
+			})
+		})
+	})
+
+	Context("when the step has a condition", func() {
+		BeforeEach(func() {
+			plan.Step = atc.Plan{
+				ID: "if-id",
+				If: &atc.IfPlan{
+					Condition: `((.:platform)) == "linux" && ((.:deploy))`,
+					Step:      plan.Step,
+				},
+			}
+		})
+
+		It("interpolates the vars into the condition as literals", func() {
+			Expect(builtPlans[1].If.Condition).To(Equal(`"darwin" == "linux" && ((.:deploy))`))
+			Expect(builtPlans[1].If.Step.OnSuccess.Step.Put.Params).To(Equal(atc.Params{"version": "1.11", "file": "out/darwin"}))
+		})
+	})
+
+	Context("when a combination fails", func() {
+		BeforeEach(func() {
+			plan.Vars = plan.Vars[:1]
+
+			combinationSucceeded = func(index int) bool { return index > 0 }
+		})
+
+		It("runs the remaining combinations and fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(builtPlans).To(HaveLen(2))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		Context("when failing fast", func() {
+			BeforeEach(func() {
+				plan.FailFast = true
+			})
+
+			It("does not run the remaining combinations", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(builtPlans).To(HaveLen(1))
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when a combination errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			plan.Vars = plan.Vars[:1]
+			plan.Vars[0].MaxInFlight = 2
+
+			combinationErr = disaster
+		})
+
+		It("returns the errors", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("nope")))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
+
+func planIDPtr(id atc.PlanID) *atc.PlanID {
+	return &id
+}
@@ -0,0 +1,41 @@
+package exec
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+// readArtifactFile reads a file out of the worker.ArtifactRepository. The path
+// must be in the format SOURCE_NAME/FILE/PATH, where SOURCE_NAME is the name
+// of the artifact containing the file.
+func readArtifactFile(logger lager.Logger, repo *worker.ArtifactRepository, path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := worker.ArtifactName(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, fmt.Errorf("unknown artifact source: '%s' in file path '%s'", sourceName, path)
+	}
+
+	stream, err := source.StreamFile(logger, filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, FileNotFoundError{Path: path}
+		}
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
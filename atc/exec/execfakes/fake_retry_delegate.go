@@ -0,0 +1,86 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	sync "sync"
+	time "time"
+
+	lager "code.cloudfoundry.org/lager"
+	atc "github.com/concourse/concourse/atc"
+	exec "github.com/concourse/concourse/atc/exec"
+)
+
+type FakeRetryDelegate struct {
+	RetryingStub        func(lager.Logger, int, atc.PlanID, string, time.Duration)
+	retryingMutex       sync.RWMutex
+	retryingArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.PlanID
+		arg4 string
+		arg5 time.Duration
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRetryDelegate) Retrying(arg1 lager.Logger, arg2 int, arg3 atc.PlanID, arg4 string, arg5 time.Duration) {
+	fake.retryingMutex.Lock()
+	fake.retryingArgsForCall = append(fake.retryingArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+		arg3 atc.PlanID
+		arg4 string
+		arg5 time.Duration
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("Retrying", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.retryingMutex.Unlock()
+	if fake.RetryingStub != nil {
+		fake.RetryingStub(arg1, arg2, arg3, arg4, arg5)
+	}
+}
+
+func (fake *FakeRetryDelegate) RetryingCallCount() int {
+	fake.retryingMutex.RLock()
+	defer fake.retryingMutex.RUnlock()
+	return len(fake.retryingArgsForCall)
+}
+
+func (fake *FakeRetryDelegate) RetryingCalls(stub func(lager.Logger, int, atc.PlanID, string, time.Duration)) {
+	fake.retryingMutex.Lock()
+	defer fake.retryingMutex.Unlock()
+	fake.RetryingStub = stub
+}
+
+func (fake *FakeRetryDelegate) RetryingArgsForCall(i int) (lager.Logger, int, atc.PlanID, string, time.Duration) {
+	fake.retryingMutex.RLock()
+	defer fake.retryingMutex.RUnlock()
+	argsForCall := fake.retryingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeRetryDelegate) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.retryingMutex.RLock()
+	defer fake.retryingMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeRetryDelegate) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.RetryDelegate = new(FakeRetryDelegate)
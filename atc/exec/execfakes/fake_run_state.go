@@ -6,6 +6,7 @@ import (
 	sync "sync"
 
 	atc "github.com/concourse/concourse/atc"
+	creds "github.com/concourse/concourse/atc/creds"
 	exec "github.com/concourse/concourse/atc/exec"
 	worker "github.com/concourse/concourse/atc/worker"
 )
@@ -21,6 +22,16 @@ type FakeRunState struct {
 	artifactsReturnsOnCall map[int]struct {
 		result1 *worker.ArtifactRepository
 	}
+	LocalVariablesStub        func() *creds.LocalVariables
+	localVariablesMutex       sync.RWMutex
+	localVariablesArgsForCall []struct {
+	}
+	localVariablesReturns struct {
+		result1 *creds.LocalVariables
+	}
+	localVariablesReturnsOnCall map[int]struct {
+		result1 *creds.LocalVariables
+	}
 	ReadPlanOutputStub        func(atc.PlanID, io.Writer)
 	readPlanOutputMutex       sync.RWMutex
 	readPlanOutputArgsForCall []struct {
@@ -131,6 +142,58 @@ func (fake *FakeRunState) ArtifactsReturnsOnCall(i int, result1 *worker.Artifact
 	}{result1}
 }
 
+func (fake *FakeRunState) LocalVariables() *creds.LocalVariables {
+	fake.localVariablesMutex.Lock()
+	ret, specificReturn := fake.localVariablesReturnsOnCall[len(fake.localVariablesArgsForCall)]
+	fake.localVariablesArgsForCall = append(fake.localVariablesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LocalVariables", []interface{}{})
+	fake.localVariablesMutex.Unlock()
+	if fake.LocalVariablesStub != nil {
+		return fake.LocalVariablesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.localVariablesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeRunState) LocalVariablesCallCount() int {
+	fake.localVariablesMutex.RLock()
+	defer fake.localVariablesMutex.RUnlock()
+	return len(fake.localVariablesArgsForCall)
+}
+
+func (fake *FakeRunState) LocalVariablesCalls(stub func() *creds.LocalVariables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = stub
+}
+
+func (fake *FakeRunState) LocalVariablesReturns(result1 *creds.LocalVariables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = nil
+	fake.localVariablesReturns = struct {
+		result1 *creds.LocalVariables
+	}{result1}
+}
+
+func (fake *FakeRunState) LocalVariablesReturnsOnCall(i int, result1 *creds.LocalVariables) {
+	fake.localVariablesMutex.Lock()
+	defer fake.localVariablesMutex.Unlock()
+	fake.LocalVariablesStub = nil
+	if fake.localVariablesReturnsOnCall == nil {
+		fake.localVariablesReturnsOnCall = make(map[int]struct {
+			result1 *creds.LocalVariables
+		})
+	}
+	fake.localVariablesReturnsOnCall[i] = struct {
+		result1 *creds.LocalVariables
+	}{result1}
+}
+
 func (fake *FakeRunState) ReadPlanOutput(arg1 atc.PlanID, arg2 io.Writer) {
 	fake.readPlanOutputMutex.Lock()
 	fake.readPlanOutputArgsForCall = append(fake.readPlanOutputArgsForCall, struct {
@@ -415,6 +478,8 @@ func (fake *FakeRunState) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.localVariablesMutex.RLock()
+	defer fake.localVariablesMutex.RUnlock()
 	fake.readPlanOutputMutex.RLock()
 	defer fake.readPlanOutputMutex.RUnlock()
 	fake.readUserInputMutex.RLock()
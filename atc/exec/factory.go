@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -17,6 +18,7 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*creds.LocalVariables,
 		StepMetadata,
 		db.ContainerMetadata,
 		GetDelegate,
@@ -27,6 +29,7 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*creds.LocalVariables,
 		StepMetadata,
 		db.ContainerMetadata,
 		PutDelegate,
@@ -37,9 +40,25 @@ type Factory interface {
 		lager.Logger,
 		atc.Plan,
 		db.Build,
+		*creds.LocalVariables,
 		db.ContainerMetadata,
 		TaskDelegate,
 	) Step
+
+	// SetPipeline constructs a SetPipeline step.
+	SetPipeline(
+		lager.Logger,
+		atc.Plan,
+		db.Build,
+		SetPipelineDelegate,
+	) Step
+
+	// LoadVar constructs a LoadVar step.
+	LoadVar(
+		lager.Logger,
+		atc.Plan,
+		LoadVarDelegate,
+	) Step
 }
 
 // StepMetadata is used to inject metadata to make available to the step when
@@ -57,6 +76,7 @@ type BuildStepDelegate interface {
 	Stderr() io.Writer
 
 	Errored(lager.Logger, string)
+	WaitingForWorker(lager.Logger)
 }
 
 // Privileged is used to indicate whether the given step should run with
@@ -21,6 +21,7 @@ type gardenFactory struct {
 	resourceFactory       resource.ResourceFactory
 	resourceCacheFactory  db.ResourceCacheFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	teamFactory           db.TeamFactory
 	variablesFactory      creds.VariablesFactory
 	defaultLimits         atc.ContainerLimits
 }
@@ -31,6 +32,7 @@ func NewGardenFactory(
 	resourceFactory resource.ResourceFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	teamFactory db.TeamFactory,
 	variablesFactory creds.VariablesFactory,
 	defaultLimits atc.ContainerLimits,
 ) Factory {
@@ -40,6 +42,7 @@ func NewGardenFactory(
 		resourceFactory:       resourceFactory,
 		resourceCacheFactory:  resourceCacheFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		teamFactory:           teamFactory,
 		variablesFactory:      variablesFactory,
 		defaultLimits:         defaultLimits,
 	}
@@ -49,13 +52,17 @@ func (factory *gardenFactory) Get(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	localVars *creds.LocalVariables,
 	stepMetadata StepMetadata,
 	workerMetadata db.ContainerMetadata,
 	delegate GetDelegate,
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("get")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := creds.NewBuildVariables(
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		localVars,
+	)
 
 	getStep := NewGetStep(
 		build,
@@ -67,6 +74,7 @@ func (factory *gardenFactory) Get(
 		creds.NewParams(variables, plan.Get.Params),
 		NewVersionSourceFromPlan(plan.Get),
 		plan.Get.Tags,
+		plan.Get.WorkerSelector,
 
 		delegate,
 		factory.resourceFetcher,
@@ -87,13 +95,17 @@ func (factory *gardenFactory) Put(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	localVars *creds.LocalVariables,
 	stepMetadata StepMetadata,
 	workerMetadata db.ContainerMetadata,
 	delegate PutDelegate,
 ) Step {
 	workerMetadata.WorkingDirectory = resource.ResourcesDir("put")
 
-	variables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	variables := creds.NewBuildVariables(
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		localVars,
+	)
 
 	var putInputs PutInputs
 	if plan.Put.Inputs == nil {
@@ -117,6 +129,7 @@ func (factory *gardenFactory) Put(
 		creds.NewSource(variables, plan.Put.Source),
 		creds.NewParams(variables, plan.Put.Params),
 		plan.Put.Tags,
+		plan.Put.WorkerSelector,
 		putInputs,
 
 		delegate,
@@ -136,13 +149,17 @@ func (factory *gardenFactory) Task(
 	logger lager.Logger,
 	plan atc.Plan,
 	build db.Build,
+	localVars *creds.LocalVariables,
 	containerMetadata db.ContainerMetadata,
 	delegate TaskDelegate,
 ) Step {
 	workingDirectory := factory.taskWorkingDirectory(worker.ArtifactName(plan.Task.Name))
 	containerMetadata.WorkingDirectory = workingDirectory
 
-	credMgrVariables := factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName())
+	credMgrVariables := creds.NewBuildVariables(
+		factory.variablesFactory.NewVariables(build.TeamName(), build.PipelineName()),
+		localVars,
+	)
 
 	var taskConfigSource TaskConfigSource
 	var taskVars []boshtemplate.Variables
@@ -163,8 +180,11 @@ func (factory *gardenFactory) Task(
 	// override params
 	taskConfigSource = &OverrideParamsConfigSource{ConfigSource: taskConfigSource, Params: plan.Task.Params}
 
+	// apply resource type defaults to the image resource
+	taskConfigSource = ResourceTypeDefaultsConfigSource{ConfigSource: taskConfigSource, Defaults: plan.Task.ResourceTypeDefaults}
+
 	// interpolate template vars
-	taskConfigSource = InterpolateTemplateConfigSource{ConfigSource: taskConfigSource, Vars: taskVars}
+	taskConfigSource = InterpolateTemplateConfigSource{ConfigSource: taskConfigSource, Vars: taskVars, LocalVars: localVars}
 
 	// validate
 	taskConfigSource = ValidatingConfigSource{ConfigSource: taskConfigSource}
@@ -173,6 +193,7 @@ func (factory *gardenFactory) Task(
 		Privileged(plan.Task.Privileged),
 		taskConfigSource,
 		plan.Task.Tags,
+		plan.Task.WorkerSelector,
 		plan.Task.InputMapping,
 		plan.Task.OutputMapping,
 
@@ -196,6 +217,37 @@ func (factory *gardenFactory) Task(
 	return LogError(taskStep, delegate)
 }
 
+func (factory *gardenFactory) SetPipeline(
+	logger lager.Logger,
+	plan atc.Plan,
+	build db.Build,
+	delegate SetPipelineDelegate,
+) Step {
+	setPipelineStep := NewSetPipelineStep(
+		plan.ID,
+		*plan.SetPipeline,
+		build,
+		delegate,
+		factory.teamFactory,
+	)
+
+	return LogError(setPipelineStep, delegate)
+}
+
+func (factory *gardenFactory) LoadVar(
+	logger lager.Logger,
+	plan atc.Plan,
+	delegate LoadVarDelegate,
+) Step {
+	loadVarStep := NewLoadVarStep(
+		plan.ID,
+		*plan.LoadVar,
+		delegate,
+	)
+
+	return LogError(loadVarStep, delegate)
+}
+
 func (factory *gardenFactory) taskWorkingDirectory(sourceName worker.ArtifactName) string {
 	sum := sha1.Sum([]byte(sourceName))
 	return filepath.Join("/tmp", "build", fmt.Sprintf("%x", sum[:4]))
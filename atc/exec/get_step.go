@@ -53,6 +53,8 @@ type GetStep struct {
 	versionSource VersionSource
 	tags          atc.Tags
 
+	workerSelector atc.WorkerSelector
+
 	delegate GetDelegate
 
 	resourceFetcher        resource.Fetcher
@@ -78,6 +80,7 @@ func NewGetStep(
 	params creds.Params,
 	versionSource VersionSource,
 	tags atc.Tags,
+	workerSelector atc.WorkerSelector,
 
 	delegate GetDelegate,
 
@@ -102,6 +105,8 @@ func NewGetStep(
 		versionSource: versionSource,
 		tags:          tags,
 
+		workerSelector: workerSelector,
+
 		delegate: delegate,
 
 		resourceFetcher:        resourceFetcher,
@@ -188,6 +193,7 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 			Metadata: step.containerMetadata,
 		},
 		step.tags,
+		step.workerSelector,
 		step.teamID,
 		step.resourceTypes,
 		resourceInstance,
@@ -263,6 +269,11 @@ type getArtifactSource struct {
 	versionedSource  resource.VersionedSource
 }
 
+// Version returns the version of the resource that was fetched.
+func (s *getArtifactSource) Version() atc.Version {
+	return s.versionedSource.Version()
+}
+
 // VolumeOn locates the cache for the GetStep's resource and version on the
 // given worker.
 func (s *getArtifactSource) VolumeOn(logger lager.Logger, worker worker.Worker) (worker.Volume, bool, error) {
@@ -271,6 +282,10 @@ func (s *getArtifactSource) VolumeOn(logger lager.Logger, worker worker.Worker)
 
 // StreamTo streams the resource's data to the destination.
 func (s *getArtifactSource) StreamTo(logger lager.Logger, destination worker.ArtifactDestination) error {
+	if volume := s.versionedSource.Volume(); volume != nil {
+		return worker.StreamVolume(logger, volume, ".", destination, ".")
+	}
+
 	out, err := s.versionedSource.StreamOut(".")
 	if err != nil {
 		return err
@@ -23,6 +23,7 @@ import (
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/worker/p2p"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
@@ -40,6 +41,7 @@ var _ = Describe("GetStep", func() {
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
 		fakeVariablesFactory      *credsfakes.FakeVariablesFactory
 		variables                 creds.Variables
+		localVars                 *creds.LocalVariables
 		fakeBuild                 *dbfakes.FakeBuild
 		fakeDelegate              *execfakes.FakeGetDelegate
 		getPlan                   *atc.GetPlan
@@ -80,6 +82,8 @@ var _ = Describe("GetStep", func() {
 		}
 		fakeVariablesFactory.NewVariablesReturns(variables)
 
+		localVars = creds.NewLocalVariables()
+
 		artifactRepository = worker.NewArtifactRepository()
 		state = new(execfakes.FakeRunState)
 		state.ArtifactsReturns(artifactRepository)
@@ -111,11 +115,12 @@ var _ = Describe("GetStep", func() {
 			Source:                 atc.Source{"some": "((source-param))"},
 			Params:                 atc.Params{"some-param": "some-value"},
 			Tags:                   []string{"some", "tags"},
+			WorkerSelector:         "zone=us-east",
 			Version:                &atc.Version{"some-version": "some-value"},
 			VersionedResourceTypes: resourceTypes,
 		}
 
-		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeResourceCacheFactory, fakeResourceConfigFactory, fakeVariablesFactory, atc.ContainerLimits{})
+		factory = exec.NewGardenFactory(fakeWorkerClient, fakeResourceFetcher, fakeResourceFactory, fakeResourceCacheFactory, fakeResourceConfigFactory, new(dbfakes.FakeTeamFactory), fakeVariablesFactory, atc.ContainerLimits{})
 
 		fakeDelegate = new(execfakes.FakeGetDelegate)
 	})
@@ -133,6 +138,7 @@ var _ = Describe("GetStep", func() {
 				Get: getPlan,
 			},
 			fakeBuild,
+			localVars,
 			stepMetadata,
 			containerMetadata,
 			fakeDelegate,
@@ -145,7 +151,7 @@ var _ = Describe("GetStep", func() {
 		Expect(stepErr).ToNot(HaveOccurred())
 
 		Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
-		fctx, _, sid, tags, actualTeamID, actualResourceTypes, resourceInstance, sm, delegate := fakeResourceFetcher.FetchArgsForCall(0)
+		fctx, _, sid, tags, workerSelector, actualTeamID, actualResourceTypes, resourceInstance, sm, delegate := fakeResourceFetcher.FetchArgsForCall(0)
 		Expect(fctx).To(Equal(ctx))
 		Expect(sm).To(Equal(stepMetadata))
 		Expect(sid).To(Equal(resource.Session{
@@ -157,17 +163,18 @@ var _ = Describe("GetStep", func() {
 			},
 		}))
 		Expect(tags).To(ConsistOf("some", "tags"))
+		Expect(workerSelector).To(Equal(atc.WorkerSelector("zone=us-east")))
 		Expect(actualTeamID).To(Equal(teamID))
 		Expect(resourceInstance).To(Equal(resource.NewResourceInstance(
 			"some-resource-type",
 			atc.Version{"some-version": "some-value"},
 			atc.Source{"some": "super-secret-source"},
 			atc.Params{"some-param": "some-value"},
-			creds.NewVersionedResourceTypes(variables, resourceTypes),
+			creds.NewVersionedResourceTypes(creds.NewBuildVariables(variables, localVars), resourceTypes),
 			nil,
 			db.NewBuildStepContainerOwner(buildID, atc.PlanID(planID), teamID),
 		)))
-		Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, resourceTypes)))
+		Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(creds.NewBuildVariables(variables, localVars), resourceTypes)))
 		Expect(delegate).To(Equal(fakeDelegate))
 		expectedLockName := fmt.Sprintf("%x",
 			sha256.Sum256([]byte(
@@ -246,7 +253,7 @@ var _ = Describe("GetStep", func() {
 						Expect(version).To(Equal(atc.Version{"some": "version"}))
 						Expect(metadata).To(Equal(db.NewResourceConfigMetadataFields([]atc.MetadataField{{"some", "metadata"}})))
 						Expect(resourceConfig).To(Equal(fakeResourceConfig))
-						Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, resourceTypes)))
+						Expect(actualResourceTypes).To(Equal(creds.NewVersionedResourceTypes(creds.NewBuildVariables(variables, localVars), resourceTypes)))
 					})
 
 					Context("when it fails to save the version", func() {
@@ -401,6 +408,38 @@ var _ = Describe("GetStep", func() {
 						Expect(artifactSource.StreamTo(testLogger, fakeDestination)).To(Equal(disaster))
 					})
 				})
+
+				Context("when the resource is fetched into a volume", func() {
+					var (
+						fakeVolume            *workerfakes.FakeVolume
+						fakeDestinationVolume *workerfakes.FakeVolume
+					)
+
+					BeforeEach(func() {
+						fakeVolume = new(workerfakes.FakeVolume)
+						fakeVolume.P2PStreamOutURLReturns("some-stream-out-url", p2p.EncodingGzip, nil)
+						fakeVersionedSource.VolumeReturns(fakeVolume)
+
+						fakeDestinationVolume = new(workerfakes.FakeVolume)
+					})
+
+					It("streams the volume directly to the destination volume's worker", func() {
+						err := artifactSource.StreamTo(testLogger, fakeDestinationVolume)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakeVolume.P2PStreamOutURLCallCount()).To(Equal(1))
+						_, path := fakeVolume.P2PStreamOutURLArgsForCall(0)
+						Expect(path).To(Equal("."))
+
+						Expect(fakeDestinationVolume.P2PStreamInCallCount()).To(Equal(1))
+						_, path, url, encoding := fakeDestinationVolume.P2PStreamInArgsForCall(0)
+						Expect(path).To(Equal("."))
+						Expect(url).To(Equal("some-stream-out-url"))
+						Expect(encoding).To(Equal(p2p.EncodingGzip))
+
+						Expect(fakeVersionedSource.StreamOutCallCount()).To(BeZero())
+					})
+				})
 			})
 
 			Describe("streaming a file out", func() {
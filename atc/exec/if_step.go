@@ -0,0 +1,97 @@
+package exec
+
+import (
+	"context"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/condition"
+)
+
+//go:generate counterfeiter . IfDelegate
+
+// An IfDelegate is notified when an IfStep skips its step.
+type IfDelegate interface {
+	Skipped(logger lager.Logger, condition string)
+}
+
+// IfStep only runs its step if its condition holds, evaluating it against the
+// build's local vars, its metadata and the versions fetched so far.
+type IfStep struct {
+	plan     atc.IfPlan
+	metadata StepMetadata
+	delegate IfDelegate
+	step     Step
+
+	skipped bool
+}
+
+func If(plan atc.IfPlan, metadata StepMetadata, delegate IfDelegate, step Step) Step {
+	return &IfStep{
+		plan:     plan,
+		metadata: metadata,
+		delegate: delegate,
+		step:     step,
+	}
+}
+
+// Run evaluates the condition and runs the step if it holds. Otherwise the
+// delegate is notified that the step was skipped.
+func (step *IfStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
+	cond, err := condition.Parse(step.plan.Condition)
+	if err != nil {
+		return err
+	}
+
+	holds, err := cond.Evaluate(step.vars(state))
+	if err != nil {
+		return err
+	}
+
+	if !holds {
+		step.skipped = true
+		step.delegate.Skipped(logger, step.plan.Condition)
+		return nil
+	}
+
+	return step.step.Run(ctx, state)
+}
+
+// Succeeded is true if the step was skipped, so that any steps after it still
+// run. Otherwise it delegates to the step.
+func (step *IfStep) Succeeded() bool {
+	return step.skipped || step.step.Succeeded()
+}
+
+func (step *IfStep) vars(state RunState) condition.Vars {
+	metadata := map[string]string{}
+	for _, env := range step.metadata.Env() {
+		segs := strings.SplitN(env, "=", 2)
+		if len(segs) == 2 {
+			metadata[segs[0]] = segs[1]
+		}
+	}
+
+	versions := map[string]map[string]string{}
+	for name, source := range state.Artifacts().AsMap() {
+		if versioned, ok := source.(versionedArtifactSource); ok {
+			versions[string(name)] = versioned.Version()
+		}
+	}
+
+	return condition.Vars{
+		Local:    state.LocalVariables().Values(),
+		Metadata: metadata,
+		Versions: versions,
+	}
+}
+
+// versionedArtifactSource is implemented by the artifacts of get steps, which
+// are a version of a resource.
+type versionedArtifactSource interface {
+	Version() atc.Version
+}
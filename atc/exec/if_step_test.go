@@ -0,0 +1,144 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type versionedArtifactSource struct {
+	*workerfakes.FakeArtifactSource
+
+	version atc.Version
+}
+
+func (s versionedArtifactSource) Version() atc.Version {
+	return s.version
+}
+
+var _ = Describe("If Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStep *execfakes.FakeStep
+
+		repo      *worker.ArtifactRepository
+		localVars *creds.LocalVariables
+		state     *execfakes.FakeRunState
+
+		plan     atc.IfPlan
+		metadata testMetadata
+		delegate *execfakes.FakeIfDelegate
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStep = new(execfakes.FakeStep)
+		fakeStep.SucceededReturns(true)
+
+		repo = worker.NewArtifactRepository()
+		localVars = creds.NewLocalVariables()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+		state.LocalVariablesReturns(localVars)
+
+		metadata = testMetadata{"BUILD_ID=1", "BUILD_PIPELINE_NAME=some-pipeline"}
+		delegate = new(execfakes.FakeIfDelegate)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = If(plan, metadata, delegate, fakeStep)
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the condition holds", func() {
+		BeforeEach(func() {
+			localVars.Set("branch", "main")
+
+			repo.RegisterSource("some-repo", versionedArtifactSource{
+				FakeArtifactSource: new(workerfakes.FakeArtifactSource),
+				version:            atc.Version{"ref": "abcdef"},
+			})
+
+			plan = atc.IfPlan{
+				Condition: `((.:branch)) == "main" && $BUILD_PIPELINE_NAME == "some-pipeline" && version.some-repo.ref == "abcdef"`,
+			}
+		})
+
+		It("runs the step", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			Expect(delegate.SkippedCallCount()).To(BeZero())
+		})
+
+		It("delegates Succeeded to the step", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+
+			fakeStep.SucceededReturns(false)
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		Context("when the step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeStep.RunReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the condition does not hold", func() {
+		BeforeEach(func() {
+			plan = atc.IfPlan{Condition: `$BUILD_PIPELINE_NAME == "main"`}
+		})
+
+		It("skips the step", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+		})
+
+		It("notifies the delegate", func() {
+			Expect(delegate.SkippedCallCount()).To(Equal(1))
+			_, condition := delegate.SkippedArgsForCall(0)
+			Expect(condition).To(Equal(`$BUILD_PIPELINE_NAME == "main"`))
+		})
+
+		It("succeeds so that later steps still run", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when the condition cannot be evaluated", func() {
+		BeforeEach(func() {
+			plan = atc.IfPlan{Condition: `((.:bogus)) == "main"`}
+		})
+
+		It("errors without running the step", func() {
+			Expect(stepErr).To(MatchError("undefined local vars: bogus"))
+			Expect(fakeStep.RunCallCount()).To(BeZero())
+			Expect(delegate.SkippedCallCount()).To(BeZero())
+		})
+	})
+})
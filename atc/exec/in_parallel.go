@@ -0,0 +1,54 @@
+package exec
+
+import (
+	"context"
+)
+
+// InParallelStep is a step of steps to run in parallel, with a limit on how
+// many of them run at once.
+type InParallelStep struct {
+	steps    []Step
+	limit    int
+	failFast bool
+
+	succeeded bool
+}
+
+func InParallel(steps []Step, limit int, failFast bool) Step {
+	return &InParallelStep{
+		steps:    steps,
+		limit:    limit,
+		failFast: failFast,
+	}
+}
+
+// Run executes the steps in parallel, starting each step as soon as fewer
+// than the limit of steps are running. A limit of 0 runs every step at once.
+//
+// Unless the step is configured to fail fast, it waits for every step to
+// exit, even if one of them fails or errors. When failing fast, no more steps
+// are started once one of them fails or errors, and the steps that are still
+// running are interrupted by canceling their context.
+//
+// Errors from the steps are aggregated and returned as a single error.
+func (step *InParallelStep) Run(ctx context.Context, state RunState) error {
+	succeeded, err := runInParallel(
+		ctx,
+		len(step.steps),
+		step.limit,
+		step.failFast,
+		func(ctx context.Context, i int) (bool, error) {
+			err := step.steps[i].Run(ctx, state)
+			return step.steps[i].Succeeded(), err
+		},
+	)
+
+	step.succeeded = succeeded
+
+	return err
+}
+
+// Succeeded is true if every step was run and succeeded.
+func (step *InParallelStep) Succeeded() bool {
+	return step.succeeded
+}
@@ -0,0 +1,226 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/worker"
+
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InParallel", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeStepA *execfakes.FakeStep
+		fakeStepB *execfakes.FakeStep
+		fakeStepC *execfakes.FakeStep
+
+		limit    int
+		failFast bool
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeStepA = new(execfakes.FakeStep)
+		fakeStepA.SucceededReturns(true)
+		fakeStepB = new(execfakes.FakeStep)
+		fakeStepB.SucceededReturns(true)
+		fakeStepC = new(execfakes.FakeStep)
+		fakeStepC.SucceededReturns(true)
+
+		limit = 0
+		failFast = false
+
+		repo = worker.NewArtifactRepository()
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = InParallel([]Step{fakeStepA, fakeStepB, fakeStepC}, limit, failFast)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("runs every step and succeeds", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(step.Succeeded()).To(BeTrue())
+
+		Expect(fakeStepA.RunCallCount()).To(Equal(1))
+		Expect(fakeStepB.RunCallCount()).To(Equal(1))
+		Expect(fakeStepC.RunCallCount()).To(Equal(1))
+
+		_, runState := fakeStepA.RunArgsForCall(0)
+		Expect(runState).To(Equal(state))
+	})
+
+	Context("when there is no limit", func() {
+		BeforeEach(func() {
+			wg := new(sync.WaitGroup)
+			wg.Add(3)
+
+			stub := func(context.Context, RunState) error {
+				wg.Done()
+				wg.Wait()
+				return nil
+			}
+
+			fakeStepA.RunStub = stub
+			fakeStepB.RunStub = stub
+			fakeStepC.RunStub = stub
+		})
+
+		It("runs every step concurrently", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when there is a limit", func() {
+		var maxRunning int
+
+		BeforeEach(func() {
+			limit = 2
+
+			lock := new(sync.Mutex)
+			running := 0
+			maxRunning = 0
+
+			stub := func(context.Context, RunState) error {
+				lock.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				lock.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				lock.Lock()
+				running--
+				lock.Unlock()
+
+				return nil
+			}
+
+			fakeStepA.RunStub = stub
+			fakeStepB.RunStub = stub
+			fakeStepC.RunStub = stub
+		})
+
+		It("runs at most that many steps at once", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(maxRunning).To(Equal(2))
+
+			Expect(fakeStepA.RunCallCount()).To(Equal(1))
+			Expect(fakeStepB.RunCallCount()).To(Equal(1))
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a step fails", func() {
+		BeforeEach(func() {
+			limit = 1
+			fakeStepA.SucceededReturns(false)
+		})
+
+		It("runs the remaining steps and fails", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeFalse())
+
+			Expect(fakeStepB.RunCallCount()).To(Equal(1))
+			Expect(fakeStepC.RunCallCount()).To(Equal(1))
+		})
+
+		Context("when failing fast", func() {
+			BeforeEach(func() {
+				failFast = true
+			})
+
+			It("does not start the remaining steps", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(step.Succeeded()).To(BeFalse())
+
+				Expect(fakeStepB.RunCallCount()).To(BeZero())
+				Expect(fakeStepC.RunCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when failing fast and a step fails while others are running", func() {
+		BeforeEach(func() {
+			failFast = true
+
+			started := make(chan struct{}, 2)
+
+			waitForCancel := func(ctx context.Context, _ RunState) error {
+				started <- struct{}{}
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			fakeStepA.RunStub = waitForCancel
+			fakeStepB.RunStub = waitForCancel
+
+			fakeStepC.RunStub = func(context.Context, RunState) error {
+				<-started
+				<-started
+				return nil
+			}
+			fakeStepC.SucceededReturns(false)
+		})
+
+		It("cancels the running steps without erroring", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeFalse())
+
+			ctx, _ := fakeStepA.RunArgsForCall(0)
+			Expect(ctx.Err()).To(Equal(context.Canceled))
+			ctx, _ = fakeStepB.RunArgsForCall(0)
+			Expect(ctx.Err()).To(Equal(context.Canceled))
+		})
+	})
+
+	Context("when steps error", func() {
+		BeforeEach(func() {
+			fakeStepA.RunReturns(errors.New("nope A"))
+			fakeStepB.RunReturns(errors.New("nope B"))
+		})
+
+		It("exits with an error including the original messages", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("nope A"))
+			Expect(stepErr.Error()).To(ContainSubstring("nope B"))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when canceled", func() {
+		BeforeEach(func() {
+			cancel()
+		})
+
+		It("returns ctx.Err()", func() {
+			Expect(stepErr).To(Equal(context.Canceled))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,164 @@
+package exec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/mitchellh/mapstructure"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . LoadVarDelegate
+
+type LoadVarDelegate interface {
+	BuildStepDelegate
+
+	Initializing(lager.Logger)
+	Starting(lager.Logger)
+	Finished(lager.Logger, bool)
+}
+
+// LoadVarStep sets a build-local variable to the contents of a file from one
+// of the build's artifacts, making it available to later steps as
+// ((.:name)).
+type LoadVarStep struct {
+	planID   atc.PlanID
+	plan     atc.LoadVarPlan
+	delegate LoadVarDelegate
+
+	succeeded bool
+}
+
+func NewLoadVarStep(
+	planID atc.PlanID,
+	plan atc.LoadVarPlan,
+	delegate LoadVarDelegate,
+) Step {
+	return &LoadVarStep{
+		planID:   planID,
+		plan:     plan,
+		delegate: delegate,
+	}
+}
+
+// Run reads the file out of the worker.ArtifactRepository, parses it
+// according to the plan's format and sets the build-local variable.
+//
+// If no format is configured, it is determined by the file's extension:
+// .json files are parsed as JSON, .yml and .yaml files as YAML, and anything
+// else is used as a string with surrounding whitespace trimmed.
+func (step *LoadVarStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id": step.planID,
+		"var":     step.plan.Name,
+	})
+
+	step.delegate.Initializing(logger)
+
+	payload, err := readArtifactFile(logger, state.Artifacts(), step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	step.delegate.Starting(logger)
+
+	format := step.format()
+
+	value, err := parseVarValue(format, payload)
+	if err != nil {
+		return fmt.Errorf("failed to parse '%s' as %s: %s", step.plan.File, format, err)
+	}
+
+	state.LocalVariables().Set(step.plan.Name, value)
+
+	fmt.Fprintf(step.delegate.Stdout(), "loaded var '%s' from '%s' (%s)\n", step.plan.Name, step.plan.File, format)
+
+	step.succeeded = true
+	step.delegate.Finished(logger, true)
+
+	return nil
+}
+
+// Succeeded is true if the variable has been set.
+func (step *LoadVarStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *LoadVarStep) format() string {
+	if step.plan.Format != "" {
+		return step.plan.Format
+	}
+
+	switch filepath.Ext(step.plan.File) {
+	case ".json":
+		return atc.LoadVarFormatJSON
+	case ".yml", ".yaml":
+		return atc.LoadVarFormatYAML
+	default:
+		return atc.LoadVarFormatTrim
+	}
+}
+
+func parseVarValue(format string, payload []byte) (interface{}, error) {
+	switch format {
+	case atc.LoadVarFormatRaw:
+		return string(payload), nil
+
+	case atc.LoadVarFormatTrim:
+		return strings.TrimSpace(string(payload)), nil
+
+	case atc.LoadVarFormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(payload))
+		decoder.UseNumber()
+
+		var value interface{}
+		err := decoder.Decode(&value)
+		if err != nil {
+			return nil, err
+		}
+
+		var trailing interface{}
+		if decoder.Decode(&trailing) != io.EOF {
+			return nil, errors.New("invalid trailing data")
+		}
+
+		return value, nil
+
+	case atc.LoadVarFormatYAML:
+		var untypedValue interface{}
+		err := yaml.Unmarshal(payload, &untypedValue)
+		if err != nil {
+			return nil, err
+		}
+
+		// decode through a map so that every nested map is sanitized, as
+		// YAML decodes them with interface{} keys
+		var sanitized map[string]interface{}
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:     &sanitized,
+			DecodeHook: atc.SanitizeDecodeHook,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = decoder.Decode(map[interface{}]interface{}{"value": untypedValue})
+		if err != nil {
+			return nil, err
+		}
+
+		return sanitized["value"], nil
+
+	default:
+		return nil, fmt.Errorf("unknown format '%s'", format)
+	}
+}
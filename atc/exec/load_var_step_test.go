@@ -0,0 +1,190 @@
+package exec_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoadVarStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		plan atc.LoadVarPlan
+
+		fakeDelegate       *execfakes.FakeLoadVarDelegate
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+
+		files map[string]string
+
+		stdout *gbytes.Buffer
+
+		localVars *creds.LocalVariables
+		state     *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("load-var-step-test"))
+
+		plan = atc.LoadVarPlan{
+			Name: "some-var",
+			File: "some-artifact/version",
+		}
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeLoadVarDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+
+		files = map[string]string{
+			"version":    "1.2.3\n",
+			"meta.json":  `{"sha": "abcdef", "number": 12}`,
+			"meta.yml":   "sha: abcdef\nplatforms: [{os: linux}]\n",
+			"notes.yaml": "- one\n- two\n",
+		}
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileStub = func(_ lager.Logger, path string) (io.ReadCloser, error) {
+			content, found := files[path]
+			if !found {
+				return nil, errors.New("file not found")
+			}
+
+			return ioutil.NopCloser(strings.NewReader(content)), nil
+		}
+
+		repo := worker.NewArtifactRepository()
+		repo.RegisterSource("some-artifact", fakeArtifactSource)
+
+		localVars = creds.NewLocalVariables()
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+		state.LocalVariablesReturns(localVars)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = NewLoadVarStep("some-plan-id", plan, fakeDelegate)
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when no format is configured", func() {
+		It("trims the contents of files without a known extension", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+
+			Expect(localVars.Values()).To(Equal(map[string]interface{}{"some-var": "1.2.3"}))
+			Expect(stdout).To(gbytes.Say("loaded var 'some-var' from 'some-artifact/version' \\(trim\\)"))
+		})
+
+		Context("when the file has a .json extension", func() {
+			BeforeEach(func() {
+				plan.File = "some-artifact/meta.json"
+			})
+
+			It("parses it as JSON", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(localVars.Values()["some-var"]).To(Equal(map[string]interface{}{
+					"sha":    "abcdef",
+					"number": json.Number("12"),
+				}))
+			})
+		})
+
+		Context("when the file has a .yml extension", func() {
+			BeforeEach(func() {
+				plan.File = "some-artifact/meta.yml"
+			})
+
+			It("parses it as YAML", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(localVars.Values()["some-var"]).To(Equal(map[string]interface{}{
+					"sha": "abcdef",
+					"platforms": []interface{}{
+						map[string]interface{}{"os": "linux"},
+					},
+				}))
+			})
+		})
+
+		Context("when the file has a .yaml extension", func() {
+			BeforeEach(func() {
+				plan.File = "some-artifact/notes.yaml"
+			})
+
+			It("parses it as YAML", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(localVars.Values()["some-var"]).To(Equal([]interface{}{"one", "two"}))
+			})
+		})
+	})
+
+	Context("when the format is raw", func() {
+		BeforeEach(func() {
+			plan.Format = "raw"
+		})
+
+		It("uses the contents as they are", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(localVars.Values()["some-var"]).To(Equal("1.2.3\n"))
+		})
+	})
+
+	Context("when the contents cannot be parsed in the format", func() {
+		BeforeEach(func() {
+			plan.Format = "json"
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("failed to parse 'some-artifact/version' as json")))
+			Expect(step.Succeeded()).To(BeFalse())
+			Expect(localVars.Values()).To(BeEmpty())
+		})
+	})
+
+	Context("when the file cannot be read", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/missing"
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(MatchError("file not found"))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	It("emits the step's lifecycle through the delegate", func() {
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+	})
+})
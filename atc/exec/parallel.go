@@ -0,0 +1,86 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type parallelResult struct {
+	succeeded bool
+	err       error
+}
+
+// runInParallel calls run for each index in [0, count), with at most limit
+// calls in flight at once. A limit of 0 runs all of them at once.
+//
+// If failFast is true, no more calls are started once one of them fails or
+// errors, and the context passed to the calls that are still running is
+// canceled.
+//
+// It returns whether every call was made and succeeded. Errors are aggregated
+// and returned as a single error, except for those caused by failing fast.
+func runInParallel(ctx context.Context, count int, limit int, failFast bool, run func(context.Context, int) (bool, error)) (bool, error) {
+	if limit <= 0 || limit > count {
+		limit = count
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan parallelResult, count)
+
+	succeeded := true
+	var errorMessages []string
+
+	started := 0
+	running := 0
+	for started < count || running > 0 {
+		if started < count && running < limit && runCtx.Err() == nil {
+			i := started
+			go func() {
+				succeeded, err := run(runCtx, i)
+				results <- parallelResult{succeeded, err}
+			}()
+
+			started++
+			running++
+			continue
+		}
+
+		if running == 0 {
+			break
+		}
+
+		result := <-results
+		running--
+
+		if result.err != nil {
+			if result.err != context.Canceled || ctx.Err() != nil {
+				errorMessages = append(errorMessages, result.err.Error())
+			}
+		}
+
+		if result.err != nil || !result.succeeded {
+			succeeded = false
+
+			if failFast {
+				cancel()
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if started < count {
+		succeeded = false
+	}
+
+	if len(errorMessages) > 0 {
+		return false, fmt.Errorf("one or more parallel steps errored:\n%s", strings.Join(errorMessages, "\n"))
+	}
+
+	return succeeded, nil
+}
@@ -33,6 +33,8 @@ type PutStep struct {
 	tags         atc.Tags
 	inputs       PutInputs
 
+	workerSelector atc.WorkerSelector
+
 	delegate              PutDelegate
 	resourceFactory       resource.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
@@ -54,6 +56,7 @@ func NewPutStep(
 	source creds.Source,
 	params creds.Params,
 	tags atc.Tags,
+	workerSelector atc.WorkerSelector,
 	inputs PutInputs,
 	delegate PutDelegate,
 	resourceFactory resource.ResourceFactory,
@@ -72,6 +75,7 @@ func NewPutStep(
 		source:                source,
 		params:                params,
 		tags:                  tags,
+		workerSelector:        workerSelector,
 		inputs:                inputs,
 		delegate:              delegate,
 		resourceFactory:       resourceFactory,
@@ -114,10 +118,11 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 	}
 
 	workerSpec := worker.WorkerSpec{
-		ResourceType:  step.resourceType,
-		Tags:          step.tags,
-		TeamID:        step.build.TeamID(),
-		ResourceTypes: step.resourceTypes,
+		ResourceType:   step.resourceType,
+		Tags:           step.tags,
+		WorkerSelector: step.workerSelector,
+		TeamID:         step.build.TeamID(),
+		ResourceTypes:  step.resourceTypes,
 	}
 
 	putResource, err := step.resourceFactory.NewResource(
@@ -114,6 +114,7 @@ var _ = Describe("PutStep", func() {
 			creds.NewSource(variables, atc.Source{"some": "((source-param))"}),
 			creds.NewParams(variables, atc.Params{"some-param": "some-value"}),
 			[]string{"some", "tags"},
+			"zone=us-east",
 			putInputs,
 			fakeDelegate,
 			fakeResourceFactory,
@@ -184,10 +185,11 @@ var _ = Describe("PutStep", func() {
 				Expect(containerSpec.Inputs).To(HaveLen(3))
 
 				Expect(workerSpec).To(Equal(worker.WorkerSpec{
-					TeamID:        123,
-					Tags:          []string{"some", "tags"},
-					ResourceType:  "some-resource-type",
-					ResourceTypes: resourceTypes,
+					TeamID:         123,
+					Tags:           []string{"some", "tags"},
+					WorkerSelector: "zone=us-east",
+					ResourceType:   "some-resource-type",
+					ResourceTypes:  resourceTypes,
 				}))
 
 				Expect([]worker.ArtifactSource{
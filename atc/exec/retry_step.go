@@ -2,27 +2,57 @@ package exec
 
 import (
 	"context"
+	"math"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 )
 
+// DefaultBackoffMultiplier is used when a backoff does not configure its own
+// multiplier.
+const DefaultBackoffMultiplier = 2
+
+//go:generate counterfeiter . RetryDelegate
+
+// A RetryDelegate is notified whenever a RetryStep is about to start another
+// attempt.
+type RetryDelegate interface {
+	Retrying(logger lager.Logger, attempt int, planID atc.PlanID, cause string, delay time.Duration)
+}
+
 // RetryStep is a step that will run the steps in order until one of them
 // succeeds.
 type RetryStep struct {
 	Attempts    []Step
 	LastAttempt Step
+
+	plan     atc.RetryPlan
+	delegate RetryDelegate
 }
 
-func Retry(attempts ...Step) Step {
+func Retry(plan atc.RetryPlan, delegate RetryDelegate, attempts ...Step) Step {
 	return &RetryStep{
 		Attempts: attempts,
+
+		plan:     plan,
+		delegate: delegate,
 	}
 }
 
 // Run iterates through each step, stopping once a step succeeds. If all steps
 // fail, the RetryStep will fail.
+//
+// An attempt which errors or fails is only retried if the plan retries on
+// that cause, which it does for both by default. Before each retry the
+// delegate is notified and the step waits for the backoff delay, if any.
 func (step *RetryStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+
 	var attemptErr error
 
-	for _, attempt := range step.Attempts {
+	for i, attempt := range step.Attempts {
 		step.LastAttempt = attempt
 
 		attemptErr = attempt.Run(ctx, state)
@@ -30,13 +60,36 @@ func (step *RetryStep) Run(ctx context.Context, state RunState) error {
 			return ctx.Err()
 		}
 
+		var cause string
 		if attemptErr != nil {
-			continue
+			cause = atc.AttemptOnError
+		} else if attempt.Succeeded() {
+			break
+		} else {
+			cause = atc.AttemptOnFailure
 		}
 
-		if attempt.Succeeded() {
+		if i == len(step.Attempts)-1 || !step.retriesOn(cause) {
 			break
 		}
+
+		delay, err := step.backoff(i + 1)
+		if err != nil {
+			return err
+		}
+
+		step.delegate.Retrying(logger, i+2, step.plan.Attempts[i+1].ID, cause, delay)
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
 	}
 
 	return attemptErr
@@ -46,3 +99,49 @@ func (step *RetryStep) Run(ctx context.Context, state RunState) error {
 func (step *RetryStep) Succeeded() bool {
 	return step.LastAttempt.Succeeded()
 }
+
+func (step *RetryStep) retriesOn(cause string) bool {
+	if len(step.plan.On) == 0 {
+		return true
+	}
+
+	for _, on := range step.plan.On {
+		if on == cause {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff determines how long to wait before the given retry, starting at 1.
+func (step *RetryStep) backoff(retry int) (time.Duration, error) {
+	if step.plan.Backoff == nil {
+		return 0, nil
+	}
+
+	initial, err := time.ParseDuration(step.plan.Backoff.Initial)
+	if err != nil {
+		return 0, err
+	}
+
+	multiplier := step.plan.Backoff.Multiplier
+	if multiplier == 0 {
+		multiplier = DefaultBackoffMultiplier
+	}
+
+	delay := time.Duration(float64(initial) * math.Pow(multiplier, float64(retry-1)))
+
+	if step.plan.Backoff.Max != "" {
+		max, err := time.ParseDuration(step.plan.Backoff.Max)
+		if err != nil {
+			return 0, err
+		}
+
+		if delay > max || delay < 0 {
+			delay = max
+		}
+	}
+
+	return delay, nil
+}
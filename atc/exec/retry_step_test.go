@@ -3,10 +3,13 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/worker"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -24,6 +27,9 @@ var _ = Describe("Retry Step", func() {
 		repo  *worker.ArtifactRepository
 		state *execfakes.FakeRunState
 
+		plan     atc.RetryPlan
+		delegate *execfakes.FakeRetryDelegate
+
 		step Step
 	)
 
@@ -38,7 +44,19 @@ var _ = Describe("Retry Step", func() {
 		state = new(execfakes.FakeRunState)
 		state.ArtifactsReturns(repo)
 
-		step = Retry(attempt1, attempt2, attempt3)
+		plan = atc.RetryPlan{
+			Attempts: []atc.Plan{
+				{ID: "attempt-1"},
+				{ID: "attempt-2"},
+				{ID: "attempt-3"},
+			},
+		}
+
+		delegate = new(execfakes.FakeRetryDelegate)
+	})
+
+	JustBeforeEach(func() {
+		step = Retry(plan, delegate, attempt1, attempt2, attempt3)
 	})
 
 	Context("when attempt 1 succeeds", func() {
@@ -148,6 +166,147 @@ var _ = Describe("Retry Step", func() {
 		})
 	})
 
+	Context("when attempt 1 errors, attempt 2 fails, and attempt 3 succeeds", func() {
+		BeforeEach(func() {
+			attempt1.RunReturns(errors.New("nope"))
+			attempt2.SucceededReturns(false)
+			attempt3.SucceededReturns(true)
+		})
+
+		Describe("Run", func() {
+			var stepErr error
+
+			JustBeforeEach(func() {
+				stepErr = step.Run(ctx, state)
+			})
+
+			It("notifies the delegate of each retry and its cause", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				Expect(delegate.RetryingCallCount()).To(Equal(2))
+
+				_, attempt, planID, cause, delay := delegate.RetryingArgsForCall(0)
+				Expect(attempt).To(Equal(2))
+				Expect(planID).To(Equal(atc.PlanID("attempt-2")))
+				Expect(cause).To(Equal(atc.AttemptOnError))
+				Expect(delay).To(BeZero())
+
+				_, attempt, planID, cause, delay = delegate.RetryingArgsForCall(1)
+				Expect(attempt).To(Equal(3))
+				Expect(planID).To(Equal(atc.PlanID("attempt-3")))
+				Expect(cause).To(Equal(atc.AttemptOnFailure))
+				Expect(delay).To(BeZero())
+			})
+
+			Context("when configured to back off", func() {
+				BeforeEach(func() {
+					plan.Backoff = &atc.RetryBackoff{
+						Initial:    "10ms",
+						Multiplier: 3,
+					}
+				})
+
+				It("waits longer before each retry", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+
+					Expect(delegate.RetryingCallCount()).To(Equal(2))
+
+					_, _, _, _, delay := delegate.RetryingArgsForCall(0)
+					Expect(delay).To(Equal(10 * time.Millisecond))
+
+					_, _, _, _, delay = delegate.RetryingArgsForCall(1)
+					Expect(delay).To(Equal(30 * time.Millisecond))
+				})
+
+				Context("with a max", func() {
+					BeforeEach(func() {
+						plan.Backoff.Max = "20ms"
+					})
+
+					It("does not wait longer than the max", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+
+						_, _, _, _, delay := delegate.RetryingArgsForCall(1)
+						Expect(delay).To(Equal(20 * time.Millisecond))
+					})
+				})
+
+				Context("without a multiplier", func() {
+					BeforeEach(func() {
+						plan.Backoff.Multiplier = 0
+					})
+
+					It("doubles the delay", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+
+						_, _, _, _, delay := delegate.RetryingArgsForCall(1)
+						Expect(delay).To(Equal(20 * time.Millisecond))
+					})
+				})
+
+				Context("when the backoff cannot be parsed", func() {
+					BeforeEach(func() {
+						plan.Backoff.Initial = "bogus"
+					})
+
+					It("returns an error without retrying", func() {
+						Expect(stepErr).To(HaveOccurred())
+
+						Expect(attempt2.RunCallCount()).To(Equal(0))
+						Expect(delegate.RetryingCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when interrupted while waiting", func() {
+					BeforeEach(func() {
+						plan.Backoff.Initial = "1h"
+
+						delegate.RetryingStub = func(lager.Logger, int, atc.PlanID, string, time.Duration) {
+							cancel()
+						}
+					})
+
+					It("returns the context error without retrying", func() {
+						Expect(stepErr).To(Equal(context.Canceled))
+
+						Expect(attempt2.RunCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			Context("when configured to only retry on failure", func() {
+				BeforeEach(func() {
+					plan.On = []string{atc.AttemptOnFailure}
+				})
+
+				It("returns the error without retrying", func() {
+					Expect(stepErr).To(MatchError("nope"))
+
+					Expect(attempt1.RunCallCount()).To(Equal(1))
+					Expect(attempt2.RunCallCount()).To(Equal(0))
+					Expect(delegate.RetryingCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when configured to only retry on error", func() {
+				BeforeEach(func() {
+					plan.On = []string{atc.AttemptOnError}
+				})
+
+				It("stops retrying once an attempt fails", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+
+					Expect(attempt1.RunCallCount()).To(Equal(1))
+					Expect(attempt2.RunCallCount()).To(Equal(1))
+					Expect(attempt3.RunCallCount()).To(Equal(0))
+					Expect(delegate.RetryingCallCount()).To(Equal(1))
+
+					Expect(step.Succeeded()).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Context("when attempt 1 errors, and attempt 2 is interrupted", func() {
 		BeforeEach(func() {
 			attempt1.RunReturns(errors.New("nope"))
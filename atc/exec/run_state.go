@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -14,6 +15,7 @@ type runState struct {
 	results   *sync.Map
 	inputs    *sync.Map
 	outputs   *sync.Map
+	localVars *creds.LocalVariables
 }
 
 func NewRunState() RunState {
@@ -22,6 +24,7 @@ func NewRunState() RunState {
 		results:   &sync.Map{},
 		inputs:    &sync.Map{},
 		outputs:   &sync.Map{},
+		localVars: creds.NewLocalVariables(),
 	}
 }
 
@@ -29,6 +32,10 @@ func (state *runState) Artifacts() *worker.ArtifactRepository {
 	return state.artifacts
 }
 
+func (state *runState) LocalVariables() *creds.LocalVariables {
+	return state.localVars
+}
+
 func (state *runState) Result(id atc.PlanID, to interface{}) bool {
 	val, ok := state.results.Load(id)
 	if !ok {
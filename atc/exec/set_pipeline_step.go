@@ -0,0 +1,226 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/template"
+	"gopkg.in/yaml.v2"
+)
+
+//go:generate counterfeiter . SetPipelineDelegate
+
+type SetPipelineDelegate interface {
+	BuildStepDelegate
+
+	Initializing(lager.Logger)
+	Starting(lager.Logger)
+	Finished(lager.Logger, bool)
+}
+
+// SetPipelineStep configures a pipeline belonging to the build's team using
+// a config file from one of the build's artifacts.
+type SetPipelineStep struct {
+	planID      atc.PlanID
+	plan        atc.SetPipelinePlan
+	build       db.Build
+	delegate    SetPipelineDelegate
+	teamFactory db.TeamFactory
+
+	succeeded bool
+}
+
+func NewSetPipelineStep(
+	planID atc.PlanID,
+	plan atc.SetPipelinePlan,
+	build db.Build,
+	delegate SetPipelineDelegate,
+	teamFactory db.TeamFactory,
+) Step {
+	return &SetPipelineStep{
+		planID:      planID,
+		plan:        plan,
+		build:       build,
+		delegate:    delegate,
+		teamFactory: teamFactory,
+	}
+}
+
+// Run reads the pipeline config and var files out of the
+// worker.ArtifactRepository and interpolates the vars into the config.
+//
+// If the resulting config is invalid, the validation errors are written to
+// stderr and the step fails. Otherwise the differences from the pipeline's
+// current config are written to stdout and the config is saved, creating
+// the pipeline if it does not exist yet.
+func (step *SetPipelineStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx).WithData(lager.Data{
+		"plan-id":  step.planID,
+		"pipeline": step.plan.Name,
+	})
+
+	step.delegate.Initializing(logger)
+
+	stdout := step.delegate.Stdout()
+	stderr := step.delegate.Stderr()
+
+	config, err := step.loadConfig(logger, state)
+	if err != nil {
+		return err
+	}
+
+	warnings, errorMessages := config.Validate()
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintln(stderr, "invalid pipeline:")
+
+		for _, message := range errorMessages {
+			fmt.Fprintf(stderr, "- %s\n", message)
+		}
+
+		step.delegate.Finished(logger, false)
+		return nil
+	}
+
+	team := step.teamFactory.GetByID(step.build.TeamID())
+
+	existingConfig := atc.Config{}
+	var fromVersion db.ConfigVersion
+
+	// pipelines created by a build start out unpaused so that they can be
+	// run without further intervention, but a pipeline that is already
+	// paused stays paused
+	pausedState := db.PipelineUnpaused
+
+	pipelineRef := atc.PipelineRef{
+		Name:         step.plan.Name,
+		InstanceVars: step.plan.InstanceVars,
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		existingConfig, err = pipelineConfig(pipeline)
+		if err != nil {
+			return err
+		}
+
+		fromVersion = pipeline.ConfigVersion()
+		pausedState = db.PipelineNoChange
+	}
+
+	step.delegate.Starting(logger)
+
+	if !existingConfig.Diff(stdout, config) {
+		fmt.Fprintln(stdout, "no changes to apply")
+
+		step.succeeded = true
+		step.delegate.Finished(logger, true)
+		return nil
+	}
+
+	_, created, err := team.SavePipeline(pipelineRef, config, fromVersion, pausedState)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Fprintf(stdout, "pipeline '%s' created\n", pipelineRef)
+	} else {
+		fmt.Fprintf(stdout, "pipeline '%s' updated\n", pipelineRef)
+	}
+
+	logger.Info("saved", lager.Data{"created": created})
+
+	step.succeeded = true
+	step.delegate.Finished(logger, true)
+
+	return nil
+}
+
+// Succeeded is true if the pipeline config was valid and has been saved.
+func (step *SetPipelineStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *SetPipelineStep) loadConfig(logger lager.Logger, state RunState) (atc.Config, error) {
+	configPayload, err := readArtifactFile(logger, state.Artifacts(), step.plan.File)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	// instance vars take precedence over vars configured on the step, which
+	// take precedence over var files, and var files listed later take
+	// precedence over the ones listed before them
+	params := []boshtemplate.Variables{
+		boshtemplate.StaticVariables(step.plan.InstanceVars),
+		boshtemplate.StaticVariables(step.plan.Vars),
+	}
+
+	for i := len(step.plan.VarFiles) - 1; i >= 0; i-- {
+		path := step.plan.VarFiles[i]
+
+		payload, err := readArtifactFile(logger, state.Artifacts(), path)
+		if err != nil {
+			return atc.Config{}, err
+		}
+
+		var staticVars boshtemplate.StaticVariables
+		err = yaml.Unmarshal(payload, &staticVars)
+		if err != nil {
+			return atc.Config{}, fmt.Errorf("failed to parse var file '%s': %s", path, err)
+		}
+
+		params = append(params, staticVars)
+	}
+
+	evaluated, err := template.NewTemplateResolver(configPayload, params).Resolve(false, false)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var config atc.Config
+	err = yaml.Unmarshal(evaluated, &config)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to parse pipeline config '%s': %s", step.plan.File, err)
+	}
+
+	return config, nil
+}
+
+func pipelineConfig(pipeline db.Pipeline) (atc.Config, error) {
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resources, err := pipeline.Resources()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return atc.Config{
+		Groups:        pipeline.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+
+		ResourceTypeDefaults: pipeline.ResourceTypeDefaults(),
+	}, nil
+}
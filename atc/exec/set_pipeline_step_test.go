@@ -0,0 +1,276 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	const pipelineConfig = `
+resources:
+- name: some-resource
+  type: git
+  source: {uri: ((uri))}
+
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+    trigger: ((trigger))
+  - task: some-task
+    file: some-resource/((task_file))
+`
+
+	var (
+		ctx    context.Context
+		cancel func()
+
+		plan atc.SetPipelinePlan
+
+		fakeBuild          *dbfakes.FakeBuild
+		fakeTeamFactory    *dbfakes.FakeTeamFactory
+		fakeTeam           *dbfakes.FakeTeam
+		fakeDelegate       *execfakes.FakeSetPipelineDelegate
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+
+		files map[string]string
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		repo  *worker.ArtifactRepository
+		state *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("set-pipeline-step-test"))
+
+		plan = atc.SetPipelinePlan{
+			Name:     "some-pipeline",
+			File:     "some-artifact/pipeline.yml",
+			Vars:     map[string]interface{}{"trigger": true},
+			VarFiles: []string{"some-artifact/vars-1.yml", "some-artifact/vars-2.yml"},
+		}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.TeamIDReturns(42)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.SavePipelineReturns(nil, true, nil)
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeSetPipelineDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+
+		files = map[string]string{
+			"pipeline.yml": pipelineConfig,
+			"vars-1.yml":   "uri: https://example.com/some-repo\ntask_file: overridden.yml\n",
+			"vars-2.yml":   "task_file: task.yml\ntrigger: false\n",
+		}
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileStub = func(_ lager.Logger, path string) (io.ReadCloser, error) {
+			content, found := files[path]
+			if !found {
+				return nil, errors.New("file not found")
+			}
+
+			return ioutil.NopCloser(strings.NewReader(content)), nil
+		}
+
+		repo = worker.NewArtifactRepository()
+		repo.RegisterSource("some-artifact", fakeArtifactSource)
+
+		state = new(execfakes.FakeRunState)
+		state.ArtifactsReturns(repo)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = NewSetPipelineStep("some-plan-id", plan, fakeBuild, fakeDelegate, fakeTeamFactory)
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the pipeline does not exist yet", func() {
+		It("saves the interpolated config as the build's team", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+
+			Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(42))
+
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+			pipelineRef, config, fromVersion, pausedState := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(fromVersion).To(Equal(db.ConfigVersion(0)))
+			Expect(pausedState).To(Equal(db.PipelineUnpaused))
+
+			Expect(config.Resources).To(Equal(atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "git",
+					Source: atc.Source{"uri": "https://example.com/some-repo"},
+				},
+			}))
+
+			Expect(config.Jobs).To(HaveLen(1))
+			Expect(config.Jobs[0].Plan[0].Trigger).To(BeTrue())
+			Expect(config.Jobs[0].Plan[1].TaskConfigPath).To(Equal("some-resource/task.yml"))
+		})
+
+		It("shows the added config", func() {
+			Expect(stdout).To(gbytes.Say("resources:"))
+			Expect(stdout).To(gbytes.Say("resource some-resource has been added:"))
+			Expect(stdout).To(gbytes.Say("jobs:"))
+			Expect(stdout).To(gbytes.Say("job some-job has been added:"))
+			Expect(stdout).To(gbytes.Say("pipeline 'some-pipeline' created"))
+		})
+
+		It("emits the step's lifecycle through the delegate", func() {
+			Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+		})
+	})
+
+	Context("when instance vars are given", func() {
+		BeforeEach(func() {
+			plan.InstanceVars = atc.InstanceVars{"branch": "5.10", "trigger": false}
+		})
+
+		It("saves the config for the pipeline instance, setting the instance vars as vars", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+
+			Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "5.10", "trigger": false},
+			}))
+
+			pipelineRef, config, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(pipelineRef).To(Equal(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "5.10", "trigger": false},
+			}))
+
+			Expect(config.Jobs[0].Plan[0].Trigger).To(BeFalse())
+		})
+
+		It("names the pipeline instance", func() {
+			Expect(stdout).To(gbytes.Say("pipeline 'some-pipeline/branch:\"5.10\",trigger:false' created"))
+		})
+	})
+
+	Context("when the pipeline already exists", func() {
+		var fakePipeline *dbfakes.FakePipeline
+
+		BeforeEach(func() {
+			fakePipeline = new(dbfakes.FakePipeline)
+			fakePipeline.ConfigVersionReturns(7)
+			fakePipeline.ResourcesReturns(db.Resources{}, nil)
+			fakePipeline.ResourceTypesReturns(db.ResourceTypes{}, nil)
+			fakePipeline.JobsReturns(db.Jobs{}, nil)
+
+			fakeTeam.PipelineReturns(fakePipeline, true, nil)
+		})
+
+		It("updates it from its current config version, leaving it paused or unpaused", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+
+			Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+
+			_, _, fromVersion, pausedState := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(fromVersion).To(Equal(db.ConfigVersion(7)))
+			Expect(pausedState).To(Equal(db.PipelineNoChange))
+		})
+
+		Context("when the config has not changed", func() {
+			BeforeEach(func() {
+				files["pipeline.yml"] = "{}"
+			})
+
+			It("does not save the config", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(step.Succeeded()).To(BeTrue())
+
+				Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+				Expect(stdout).To(gbytes.Say("no changes to apply"))
+			})
+		})
+	})
+
+	Context("when the config is invalid", func() {
+		BeforeEach(func() {
+			files["pipeline.yml"] = "jobs: [{name: some-job, plan: [{get: some-missing-resource}]}]"
+		})
+
+		It("fails without saving the config", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeFalse())
+
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+			Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+			Expect(stderr).To(gbytes.Say("jobs.some-job.plan\\[0\\].get.some-missing-resource refers to a resource that does not exist"))
+
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the config file cannot be read", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/missing.yml"
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(MatchError("file not found"))
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when saving the config fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeTeam.SavePipelineReturns(nil, false, disaster)
+		})
+
+		It("errors", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
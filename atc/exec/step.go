@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -45,6 +46,8 @@ type RunState interface {
 
 	ReadPlanOutput(atc.PlanID, io.Writer)
 	SendPlanOutput(atc.PlanID, OutputHandler) error
+
+	LocalVariables() *creds.LocalVariables
 }
 
 // ExitStatus is the resulting exit code from the process that the step ran.
@@ -1,6 +1,7 @@
 package exec
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
@@ -13,6 +14,7 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/worker"
 )
 
@@ -155,10 +157,39 @@ func (configSource OverrideParamsConfigSource) Warnings() []string {
 	return configSource.WarningList
 }
 
+// ResourceTypeDefaultsConfigSource applies resource type defaults to the
+// image resource of a config source.
+type ResourceTypeDefaultsConfigSource struct {
+	ConfigSource TaskConfigSource
+	Defaults     atc.ResourceTypeDefaults
+}
+
+// FetchConfig merges the defaults for the image resource's type beneath its
+// source, so that they also apply to tasks loaded from a file.
+func (configSource ResourceTypeDefaultsConfigSource) FetchConfig(logger lager.Logger, source *worker.ArtifactRepository) (atc.TaskConfig, error) {
+	taskConfig, err := configSource.ConfigSource.FetchConfig(logger, source)
+	if err != nil {
+		return atc.TaskConfig{}, err
+	}
+
+	if taskConfig.ImageResource != nil {
+		imageResource := *taskConfig.ImageResource
+		imageResource.Source = configSource.Defaults.Apply(imageResource.Type, imageResource.Source)
+		taskConfig.ImageResource = &imageResource
+	}
+
+	return taskConfig, nil
+}
+
+func (configSource ResourceTypeDefaultsConfigSource) Warnings() []string {
+	return configSource.ConfigSource.Warnings()
+}
+
 // InterpolateTemplateConfigSource represents a config source interpolated by template vars
 type InterpolateTemplateConfigSource struct {
 	ConfigSource TaskConfigSource
 	Vars         []boshtemplate.Variables
+	LocalVars    *creds.LocalVariables
 }
 
 // FetchConfig returns the interpolated configuration
@@ -173,8 +204,19 @@ func (configSource InterpolateTemplateConfigSource) FetchConfig(logger lager.Log
 		return atc.TaskConfig{}, fmt.Errorf("failed to marshal task config: %s", err)
 	}
 
+	vars := configSource.Vars
+
+	if configSource.LocalVars != nil {
+		// build-local vars are resolved along with the other vars, and first in
+		// the values of any static vars which refer to them
+		byteConfig, vars, err = configSource.interpolateLocalVars(byteConfig)
+		if err != nil {
+			return atc.TaskConfig{}, fmt.Errorf("failed to interpolate task config: %s", err)
+		}
+	}
+
 	// process task config using the provided variables
-	byteConfig, err = template.NewTemplateResolver(byteConfig, configSource.Vars).Resolve(true, true)
+	byteConfig, err = template.NewTemplateResolver(byteConfig, vars).Resolve(true, true)
 	if err != nil {
 		return atc.TaskConfig{}, fmt.Errorf("failed to interpolate task config: %s", err)
 	}
@@ -191,6 +233,87 @@ func (configSource InterpolateTemplateConfigSource) Warnings() []string {
 	return []string{}
 }
 
+func (configSource InterpolateTemplateConfigSource) interpolateLocalVars(byteConfig []byte) ([]byte, []boshtemplate.Variables, error) {
+	localVars := configSource.LocalVars.Values()
+
+	byteConfig, substitutes, err := substituteLocalVarsInJSON(byteConfig, localVars)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the substituted local vars come first, so that their values are never
+	// evaluated themselves
+	vars := []boshtemplate.Variables{substitutes}
+	for _, v := range configSource.Vars {
+		staticVars, ok := v.(boshtemplate.StaticVariables)
+		if !ok {
+			vars = append(vars, v)
+			continue
+		}
+
+		payload, err := json.Marshal(staticVars)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		payload, err = resolveLocalVarsInJSON(payload, localVars)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var resolvedVars boshtemplate.StaticVariables
+		err = json.Unmarshal(payload, &resolvedVars)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		vars = append(vars, resolvedVars)
+	}
+
+	return byteConfig, vars, nil
+}
+
+func resolveLocalVarsInJSON(payload []byte, localVars map[string]interface{}) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var untyped interface{}
+	err := decoder.Decode(&untyped)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := template.ResolveLocalVars(untyped, localVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(resolved)
+}
+
+func substituteLocalVarsInJSON(payload []byte, localVars map[string]interface{}) ([]byte, boshtemplate.StaticVariables, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var untyped interface{}
+	err := decoder.Decode(&untyped)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	substituted, substitutes, err := template.SubstituteLocalVars(untyped, localVars)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload, err = json.Marshal(substituted)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return payload, substitutes, nil
+}
+
 // ValidatingConfigSource delegates to another ConfigSource, and validates its
 // task config.
 type ValidatingConfigSource struct {
@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	. "github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/worker"
@@ -405,6 +406,75 @@ run: {path: a/file}
 		})
 	})
 
+	Describe("ResourceTypeDefaultsConfigSource", func() {
+		var (
+			fakeConfigSource *execfakes.FakeTaskConfigSource
+
+			configSource TaskConfigSource
+
+			fetchedConfig atc.TaskConfig
+			fetchErr      error
+		)
+
+		BeforeEach(func() {
+			fakeConfigSource = new(execfakes.FakeTaskConfigSource)
+
+			configSource = ResourceTypeDefaultsConfigSource{
+				ConfigSource: fakeConfigSource,
+				Defaults: atc.ResourceTypeDefaults{
+					"docker": atc.Source{"a": "default", "mirror": "some-mirror"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			fetchedConfig, fetchErr = configSource.FetchConfig(logger, repo)
+		})
+
+		Context("when the config has an image resource", func() {
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(taskConfig, nil)
+			})
+
+			It("merges the defaults beneath the image resource's source", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig.ImageResource.Source).To(Equal(atc.Source{
+					"a":               "b",
+					"evaluated-value": "((task-variable-name))",
+					"mirror":          "some-mirror",
+				}))
+			})
+
+			It("does not modify the fetched config's image resource", func() {
+				Expect(taskConfig.ImageResource.Source).ToNot(HaveKey("mirror"))
+			})
+		})
+
+		Context("when the config has no image resource", func() {
+			BeforeEach(func() {
+				taskConfig.ImageResource = nil
+				fakeConfigSource.FetchConfigReturns(taskConfig, nil)
+			})
+
+			It("returns the config as-is", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig).To(Equal(taskConfig))
+			})
+		})
+
+		Context("when fetching the config fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeConfigSource.FetchConfigReturns(atc.TaskConfig{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(fetchErr).To(Equal(disaster))
+			})
+		})
+	})
+
 	Describe("InterpolateTemplateConfigSource", func() {
 		var (
 			configSource  TaskConfigSource
@@ -433,5 +503,53 @@ run: {path: a/file}
 				"evaluated-value": "task-variable-value",
 			}))
 		})
+
+		Context("when build-local vars are given", func() {
+			var localVars *creds.LocalVariables
+
+			BeforeEach(func() {
+				localVars = creds.NewLocalVariables()
+				localVars.Set("version", "1.2.3")
+
+				taskConfig.Run.Args = []string{"((.:version))", "((task-variable-name))"}
+				taskVars["task-variable-name"] = "v((.:version))"
+			})
+
+			JustBeforeEach(func() {
+				configSource = StaticConfigSource{Config: &taskConfig}
+				configSource = InterpolateTemplateConfigSource{
+					ConfigSource: configSource,
+					Vars:         []boshtemplate.Variables{boshtemplate.StaticVariables(taskVars)},
+					LocalVars:    localVars,
+				}
+				fetchedConfig, fetchErr = configSource.FetchConfig(logger, repo)
+			})
+
+			It("resolves them in the config and in the values of the vars", func() {
+				Expect(fetchErr).ToNot(HaveOccurred())
+				Expect(fetchedConfig.Run.Args).To(Equal([]string{"1.2.3", "v1.2.3"}))
+			})
+
+			Context("when a build-local var's value refers to another var", func() {
+				BeforeEach(func() {
+					localVars.Set("version", "((task-variable-name))")
+				})
+
+				It("does not resolve the reference within the value", func() {
+					Expect(fetchErr).ToNot(HaveOccurred())
+					Expect(fetchedConfig.Run.Args[0]).To(Equal("((task-variable-name))"))
+				})
+			})
+
+			Context("when a build-local var is not set", func() {
+				BeforeEach(func() {
+					taskConfig.Run.Args = []string{"((.:missing))"}
+				})
+
+				It("errors", func() {
+					Expect(fetchErr).To(MatchError(ContainSubstring("undefined local vars: missing")))
+				})
+			})
+		})
 	})
 })
@@ -74,6 +74,8 @@ type TaskStep struct {
 	inputMapping  map[string]string
 	outputMapping map[string]string
 
+	workerSelector atc.WorkerSelector
+
 	artifactsRoot     string
 	imageArtifactName string
 
@@ -98,6 +100,7 @@ func NewTaskStep(
 	privileged Privileged,
 	configSource TaskConfigSource,
 	tags atc.Tags,
+	workerSelector atc.WorkerSelector,
 	inputMapping map[string]string,
 	outputMapping map[string]string,
 	artifactsRoot string,
@@ -117,6 +120,7 @@ func NewTaskStep(
 		privileged:        privileged,
 		configSource:      configSource,
 		tags:              tags,
+		workerSelector:    workerSelector,
 		inputMapping:      inputMapping,
 		outputMapping:     outputMapping,
 		artifactsRoot:     artifactsRoot,
@@ -407,10 +411,11 @@ func (action *TaskStep) containerSpec(logger lager.Logger, repository *worker.Ar
 
 func (action *TaskStep) workerSpec(logger lager.Logger, resourceTypes creds.VersionedResourceTypes, repository *worker.ArtifactRepository, config atc.TaskConfig) (worker.WorkerSpec, error) {
 	workerSpec := worker.WorkerSpec{
-		Platform:      config.Platform,
-		Tags:          action.tags,
-		TeamID:        action.teamID,
-		ResourceTypes: resourceTypes,
+		Platform:       config.Platform,
+		Tags:           action.tags,
+		WorkerSelector: action.workerSelector,
+		TeamID:         action.teamID,
+		ResourceTypes:  resourceTypes,
 	}
 
 	imageSpec, err := action.imageSpec(logger, repository, config)
@@ -501,15 +506,7 @@ func (src *taskArtifactSource) StreamTo(logger lager.Logger, destination worker.
 
 	defer logger.Debug("end")
 
-	out, err := src.StreamOut(".")
-	if err != nil {
-		logger.Error("failed", err)
-		return err
-	}
-
-	defer out.Close()
-
-	err = destination.StreamIn(".", out)
+	err := worker.StreamVolume(logger, src.Volume, ".", destination, ".")
 	if err != nil {
 		logger.Error("failed", err)
 		return err
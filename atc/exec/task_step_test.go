@@ -43,8 +43,10 @@ var _ = Describe("TaskStep", func() {
 
 		fakeDelegate *execfakes.FakeTaskDelegate
 
-		privileged    exec.Privileged
-		tags          []string
+		privileged     exec.Privileged
+		tags           []string
+		workerSelector atc.WorkerSelector
+
 		teamID        int
 		buildID       int
 		planID        atc.PlanID
@@ -77,6 +79,7 @@ var _ = Describe("TaskStep", func() {
 
 		privileged = false
 		tags = []string{"step", "tags"}
+		workerSelector = "zone=us-east"
 		teamID = 123
 		planID = atc.PlanID(42)
 		buildID = 1234
@@ -116,6 +119,7 @@ var _ = Describe("TaskStep", func() {
 			privileged,
 			configSource,
 			tags,
+			workerSelector,
 			inputMapping,
 			outputMapping,
 			"some-artifact-root",
@@ -227,11 +231,12 @@ var _ = Describe("TaskStep", func() {
 				}))
 
 				Expect(workerSpec).To(Equal(worker.WorkerSpec{
-					Platform:      "some-platform",
-					Tags:          []string{"step", "tags"},
-					TeamID:        teamID,
-					ResourceType:  "docker",
-					ResourceTypes: resourceTypes,
+					Platform:       "some-platform",
+					Tags:           []string{"step", "tags"},
+					WorkerSelector: "zone=us-east",
+					TeamID:         teamID,
+					ResourceType:   "docker",
+					ResourceTypes:  resourceTypes,
 				}))
 				Expect(actualResourceTypes).To(Equal(resourceTypes))
 			})
@@ -278,10 +283,11 @@ var _ = Describe("TaskStep", func() {
 					}))
 
 					Expect(workerSpec).To(Equal(worker.WorkerSpec{
-						Platform:      "some-platform",
-						Tags:          []string{"step", "tags"},
-						TeamID:        teamID,
-						ResourceTypes: resourceTypes,
+						Platform:       "some-platform",
+						Tags:           []string{"step", "tags"},
+						WorkerSelector: "zone=us-east",
+						TeamID:         teamID,
+						ResourceTypes:  resourceTypes,
 					}))
 
 					Expect(actualResourceTypes).To(Equal(resourceTypes))
@@ -836,7 +842,7 @@ var _ = Describe("TaskStep", func() {
 								BeforeEach(func() {
 									fakeDestination = new(workerfakes.FakeArtifactDestination)
 
-									streamedOut = gbytes.NewBuffer()
+									streamedOut = ioutil.NopCloser(strings.NewReader("some-tarball"))
 									fakeVolume1.StreamOutReturns(streamedOut, nil)
 								})
 
@@ -860,7 +866,7 @@ var _ = Describe("TaskStep", func() {
 									Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
 									dest, src := fakeDestination.StreamInArgsForCall(0)
 									Expect(dest).To(Equal("."))
-									Expect(src).To(Equal(streamedOut))
+									Expect(ioutil.ReadAll(src)).To(Equal([]byte("some-tarball")))
 								})
 							})
 
@@ -1308,11 +1314,12 @@ var _ = Describe("TaskStep", func() {
 						}))
 
 						Expect(workerSpec).To(Equal(worker.WorkerSpec{
-							TeamID:        123,
-							Platform:      "some-platform",
-							ResourceTypes: resourceTypes,
-							Tags:          []string{"step", "tags"},
-							ResourceType:  "docker",
+							TeamID:         123,
+							Platform:       "some-platform",
+							ResourceTypes:  resourceTypes,
+							Tags:           []string{"step", "tags"},
+							WorkerSelector: "zone=us-east",
+							ResourceType:   "docker",
 						}))
 					})
 				})
@@ -1337,10 +1344,11 @@ var _ = Describe("TaskStep", func() {
 						Expect(containerSpec.ImageSpec.ImageURL).To(Equal("some-image"))
 
 						Expect(workerSpec).To(Equal(worker.WorkerSpec{
-							TeamID:        123,
-							Platform:      "some-platform",
-							ResourceTypes: resourceTypes,
-							Tags:          []string{"step", "tags"},
+							TeamID:         123,
+							Platform:       "some-platform",
+							ResourceTypes:  resourceTypes,
+							Tags:           []string{"step", "tags"},
+							WorkerSelector: "zone=us-east",
 						}))
 					})
 				})
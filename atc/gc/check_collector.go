@@ -0,0 +1,39 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type checkCollector struct {
+	checkLifecycle db.CheckLifecycle
+	retention      time.Duration
+}
+
+func NewCheckCollector(
+	checkLifecycle db.CheckLifecycle,
+	retention time.Duration,
+) Collector {
+	return &checkCollector{
+		checkLifecycle: checkLifecycle,
+		retention:      retention,
+	}
+}
+
+func (cc *checkCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("check-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := cc.checkLifecycle.RemoveExpiredChecks(cc.retention)
+	if err != nil {
+		logger.Error("failed-to-remove-expired-checks", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package gc_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckCollector", func() {
+	var (
+		collector gc.Collector
+		resource  db.Resource
+		oldCheck  db.Check
+		newCheck  db.Check
+	)
+
+	BeforeEach(func() {
+		collector = gc.NewCheckCollector(db.NewCheckLifecycle(dbConn), time.Second)
+
+		var found bool
+		resource, found, err = defaultPipeline.Resource("some-resource")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		oldCheck, err = resource.CreateCheck("http://some-atc")
+		Expect(err).ToNot(HaveOccurred())
+
+		err = oldCheck.Finish(0, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		newCheck, err = resource.CreateCheck("http://some-atc")
+		Expect(err).ToNot(HaveOccurred())
+
+		err = newCheck.Finish(0, nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		Expect(collector.Run(context.TODO())).To(Succeed())
+	})
+
+	Context("when the checks finished within the retention", func() {
+		It("keeps them", func() {
+			_, found, err := resource.Check(oldCheck.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Context("when the checks finished before the retention", func() {
+		BeforeEach(func() {
+			time.Sleep(2 * time.Second)
+		})
+
+		It("removes them", func() {
+			_, found, err := resource.Check(oldCheck.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("keeps the latest check of the resource", func() {
+			_, found, err := resource.Check(newCheck.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
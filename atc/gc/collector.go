@@ -21,6 +21,7 @@ type aggregateCollector struct {
 	volumeCollector                     Collector
 	containerCollector                  Collector
 	resourceConfigCheckSessionCollector Collector
+	checkCollector                      Collector
 }
 
 func NewCollector(
@@ -32,6 +33,7 @@ func NewCollector(
 	volumes Collector,
 	containers Collector,
 	resourceConfigCheckSessionCollector Collector,
+	checkCollector Collector,
 ) Collector {
 	return &aggregateCollector{
 		buildCollector:                      buildCollector,
@@ -42,6 +44,7 @@ func NewCollector(
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
 		resourceConfigCheckSessionCollector: resourceConfigCheckSessionCollector,
+		checkCollector:                      checkCollector,
 	}
 }
 
@@ -80,6 +83,11 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("resource-config-check-session-collector", err)
 	}
 
+	err = c.checkCollector.Run(ctx)
+	if err != nil {
+		logger.Error("check-collector", err)
+	}
+
 	err = c.containerCollector.Run(ctx)
 	if err != nil {
 		logger.Error("container-collector", err)
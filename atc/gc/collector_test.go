@@ -23,6 +23,7 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
 		fakeResourceConfigCheckSessionCollector *gcfakes.FakeCollector
+		fakeCheckCollector                      *gcfakes.FakeCollector
 
 		err      error
 		disaster error
@@ -37,6 +38,7 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCheckSessionCollector = new(gcfakes.FakeCollector)
+		fakeCheckCollector = new(gcfakes.FakeCollector)
 
 		subject = NewCollector(
 			fakeBuildCollector,
@@ -47,6 +49,7 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeVolumeCollector,
 			fakeContainerCollector,
 			fakeResourceConfigCheckSessionCollector,
+			fakeCheckCollector,
 		)
 
 		disaster = errors.New("disaster")
@@ -78,6 +81,7 @@ var _ = Describe("Aggregate Collector", func() {
 				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 			})
 		})
 
@@ -102,6 +106,7 @@ var _ = Describe("Aggregate Collector", func() {
 					Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 					Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 					Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+					Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 				})
 			})
 
@@ -126,6 +131,7 @@ var _ = Describe("Aggregate Collector", func() {
 						Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 						Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 						Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+						Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 					})
 				})
 
@@ -150,6 +156,7 @@ var _ = Describe("Aggregate Collector", func() {
 							Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 							Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 							Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+							Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 						})
 					})
 
@@ -174,6 +181,7 @@ var _ = Describe("Aggregate Collector", func() {
 								Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 								Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 								Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+								Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 							})
 						})
 
@@ -198,6 +206,7 @@ var _ = Describe("Aggregate Collector", func() {
 									Expect(fakeResourceCacheCollector.RunCallCount()).To(Equal(1))
 									Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 									Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+									Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 								})
 							})
 
@@ -222,11 +231,13 @@ var _ = Describe("Aggregate Collector", func() {
 										Expect(fakeResourceCacheCollector.RunCallCount()).To(Equal(1))
 										Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 										Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+										Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 									})
 								})
 								Context("when the resource config check session collector succeeds", func() {
 									It("attempts to collect containers", func() {
 										Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
+										Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
 									})
 
 									Context("when the container collector errors", func() {
@@ -0,0 +1,42 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type versionHistoryCollector struct {
+	versionHistoryLifecycle db.VersionHistoryLifecycle
+	defaultKeep             int
+	defaultKeepFor          time.Duration
+}
+
+func NewVersionHistoryCollector(
+	versionHistoryLifecycle db.VersionHistoryLifecycle,
+	defaultKeep int,
+	defaultKeepFor time.Duration,
+) Collector {
+	return &versionHistoryCollector{
+		versionHistoryLifecycle: versionHistoryLifecycle,
+		defaultKeep:             defaultKeep,
+		defaultKeepFor:          defaultKeepFor,
+	}
+}
+
+func (vc *versionHistoryCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("version-history-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	err := vc.versionHistoryLifecycle.RemoveExpiredVersions(vc.defaultKeep, vc.defaultKeepFor)
+	if err != nil {
+		logger.Error("failed-to-remove-expired-versions", err)
+		return err
+	}
+
+	return nil
+}
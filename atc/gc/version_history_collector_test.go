@@ -0,0 +1,143 @@
+package gc_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/gc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionHistoryCollector", func() {
+	var (
+		collector gc.Collector
+
+		defaultKeep    int
+		defaultKeepFor time.Duration
+
+		resource            db.Resource
+		resourceConfigScope db.ResourceConfigScope
+		versions            []atc.Version
+	)
+
+	versionExists := func(version atc.Version) bool {
+		_, found, err := resourceConfigScope.FindVersion(version)
+		Expect(err).ToNot(HaveOccurred())
+		return found
+	}
+
+	cacheIndex := func() int {
+		var index int
+		err := psql.Select("cache_index").
+			From("pipelines").
+			Where("id = ?", defaultPipeline.ID()).
+			RunWith(dbConn).
+			QueryRow().
+			Scan(&index)
+		Expect(err).ToNot(HaveOccurred())
+		return index
+	}
+
+	BeforeEach(func() {
+		defaultKeep = 0
+		defaultKeepFor = 0
+
+		var found bool
+		resource, found, err = defaultPipeline.Resource("some-resource")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		resourceConfigScope, err = resource.SetResourceConfig(
+			logger,
+			atc.Source{"some": "source"},
+			creds.VersionedResourceTypes{},
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		versions = []atc.Version{
+			{"ref": "v1"},
+			{"ref": "v2"},
+			{"ref": "v3"},
+			{"ref": "v4"},
+			{"ref": "v5"},
+		}
+
+		err = resourceConfigScope.SaveVersions(versions)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		collector = gc.NewVersionHistoryCollector(
+			db.NewVersionHistoryLifecycle(dbConn, lockFactory),
+			defaultKeep,
+			defaultKeepFor,
+		)
+
+		Expect(collector.Run(context.TODO())).To(Succeed())
+	})
+
+	Context("when no version history is configured", func() {
+		It("keeps every version", func() {
+			for _, version := range versions {
+				Expect(versionExists(version)).To(BeTrue())
+			}
+		})
+	})
+
+	Context("when the default keeps the newest versions", func() {
+		var originalCacheIndex int
+
+		BeforeEach(func() {
+			defaultKeep = 2
+
+			rcv, found, err := resourceConfigScope.FindVersion(versions[0])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = resource.DisableVersion(rcv.ID(), db.VersionAnnotation{})
+			Expect(err).ToNot(HaveOccurred())
+
+			rcv, found, err = resourceConfigScope.FindVersion(versions[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = resource.PinVersion(rcv.ID(), db.VersionAnnotation{})
+			Expect(err).ToNot(HaveOccurred())
+
+			originalCacheIndex = cacheIndex()
+		})
+
+		It("keeps the newest versions", func() {
+			Expect(versionExists(versions[3])).To(BeTrue())
+			Expect(versionExists(versions[4])).To(BeTrue())
+		})
+
+		It("keeps disabled and pinned versions", func() {
+			Expect(versionExists(versions[0])).To(BeTrue())
+			Expect(versionExists(versions[1])).To(BeTrue())
+		})
+
+		It("removes the other versions", func() {
+			Expect(versionExists(versions[2])).To(BeFalse())
+		})
+
+		It("bumps the cache index of the pipeline", func() {
+			Expect(cacheIndex()).To(BeNumerically(">", originalCacheIndex))
+		})
+
+		Context("when the versions are newer than the default period to keep them for", func() {
+			BeforeEach(func() {
+				defaultKeepFor = time.Hour
+			})
+
+			It("keeps them", func() {
+				Expect(versionExists(versions[2])).To(BeTrue())
+			})
+		})
+	})
+})
@@ -4,6 +4,7 @@ type Job struct {
 	ID int `json:"id"`
 
 	Name                 string `json:"name"`
+	PipelineID           int    `json:"pipeline_id,omitempty"`
 	PipelineName         string `json:"pipeline_name"`
 	TeamName             string `json:"team_name"`
 	Paused               bool   `json:"paused,omitempty"`
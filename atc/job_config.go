@@ -11,6 +11,11 @@ type JobConfig struct {
 	RawMaxInFlight       int      `yaml:"max_in_flight,omitempty" json:"max_in_flight,omitempty" mapstructure:"max_in_flight"`
 	BuildLogsToRetain    int      `yaml:"build_logs_to_retain,omitempty" json:"build_logs_to_retain,omitempty" mapstructure:"build_logs_to_retain"`
 
+	// abort builds which are still running after this duration
+	BuildTimeout string `yaml:"build_timeout,omitempty" json:"build_timeout,omitempty" mapstructure:"build_timeout"`
+	// the timeout of every step which does not configure its own
+	DefaultStepTimeout string `yaml:"default_step_timeout,omitempty" json:"default_step_timeout,omitempty" mapstructure:"default_step_timeout"`
+
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
 
 	Abort   *PlanConfig `yaml:"on_abort,omitempty" json:"on_abort,omitempty" mapstructure:"on_abort"`
@@ -94,6 +99,12 @@ func collectPlans(plan PlanConfig) []PlanConfig {
 		}
 	}
 
+	if plan.InParallel != nil {
+		for _, p := range plan.InParallel.Steps {
+			plans = append(plans, collectPlans(p)...)
+		}
+	}
+
 	return append(plans, plan)
 }
 
@@ -41,6 +41,9 @@ type PrometheusEmitter struct {
 	schedulingFullDuration    *prometheus.CounterVec
 	schedulingLoadingDuration *prometheus.CounterVec
 
+	volumesStreamedBytes     *prometheus.CounterVec
+	volumesStreamingDuration *prometheus.HistogramVec
+
 	workerContainers *prometheus.GaugeVec
 	workerInfo       *prometheus.GaugeVec
 	workerVolumes    *prometheus.GaugeVec
@@ -269,6 +272,30 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(resourceChecksVec)
 
+	// volume streaming metrics
+	volumesStreamedBytes := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "streamed_bytes_total",
+			Help:      "Total number of bytes of volumes streamed to other workers",
+		},
+		[]string{"method", "encoding"},
+	)
+	prometheus.MustRegister(volumesStreamedBytes)
+
+	volumesStreamingDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "volumes",
+			Name:      "streaming_duration_seconds",
+			Help:      "Time taken to stream a volume to another worker",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+		[]string{"method", "encoding"},
+	)
+	prometheus.MustRegister(volumesStreamingDuration)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -302,6 +329,9 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		schedulingFullDuration:    schedulingFullDuration,
 		schedulingLoadingDuration: schedulingLoadingDuration,
 
+		volumesStreamedBytes:     volumesStreamedBytes,
+		volumesStreamingDuration: volumesStreamingDuration,
+
 		workerContainers: workerContainers,
 		workerInfo:       workerInfo,
 		workerLastSeen:   map[string]time.Time{},
@@ -350,6 +380,10 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "volume streamed (bytes)":
+		emitter.volumeStreamingMetrics(logger, event)
+	case "volume streaming duration (ms)":
+		emitter.volumeStreamingMetrics(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -585,6 +619,39 @@ func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metr
 	emitter.resourceChecksVec.WithLabelValues(team, pipeline).Inc()
 }
 
+func (emitter *PrometheusEmitter) volumeStreamingMetrics(logger lager.Logger, event metric.Event) {
+	method, exists := event.Attributes["method"]
+	if !exists {
+		logger.Error("failed-to-find-method-in-event", fmt.Errorf("expected method to exist in event.Attributes"))
+		return
+	}
+
+	encoding, exists := event.Attributes["encoding"]
+	if !exists {
+		logger.Error("failed-to-find-encoding-in-event", fmt.Errorf("expected encoding to exist in event.Attributes"))
+		return
+	}
+
+	switch event.Name {
+	case "volume streamed (bytes)":
+		bytes, ok := event.Value.(int64)
+		if !ok {
+			logger.Error("volume-streamed-event-value-type-mismatch", fmt.Errorf("expected event.Value to be an int64"))
+			return
+		}
+
+		emitter.volumesStreamedBytes.WithLabelValues(method, encoding).Add(float64(bytes))
+	case "volume streaming duration (ms)":
+		duration, ok := event.Value.(float64)
+		if !ok {
+			logger.Error("volume-streaming-duration-event-value-type-mismatch", fmt.Errorf("expected event.Value to be a float64"))
+			return
+		}
+
+		emitter.volumesStreamingDuration.WithLabelValues(method, encoding).Observe(duration / 1000)
+	}
+}
+
 // updateLastSeen tracks for each worker when it last received a metric event.
 func (emitter *PrometheusEmitter) updateLastSeen(event metric.Event) {
 	emitter.mu.Lock()
@@ -22,6 +22,8 @@ var FailedVolumes = Meter(0)
 var ContainersDeleted = Meter(0)
 var VolumesDeleted = Meter(0)
 
+var ChecksLockContended = Meter(0)
+
 type SchedulingFullDuration struct {
 	PipelineName string
 	Duration     time.Duration
@@ -439,6 +441,114 @@ func (event ResourceCheck) Emit(logger lager.Logger) {
 	)
 }
 
+type CheckRateLimited struct {
+	PipelineName string
+	ResourceName string
+	ResourceType string
+	TeamName     string
+}
+
+func (event CheckRateLimited) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("check-rate-limited"),
+		Event{
+			Name:  "check rate limited",
+			Value: 1,
+			State: EventStateWarning,
+			Attributes: map[string]string{
+				"pipeline":      event.PipelineName,
+				"resource":      event.ResourceName,
+				"resource_type": event.ResourceType,
+				"team":          event.TeamName,
+			},
+		},
+	)
+}
+
+type CheckQueueDepth struct {
+	Depth int
+}
+
+func (event CheckQueueDepth) Emit(logger lager.Logger) {
+	emit(
+		logger.Session("check-queue-depth"),
+		Event{
+			Name:  "checks queued",
+			Value: event.Depth,
+			State: EventStateOK,
+		},
+	)
+}
+
+type CheckLag struct {
+	PipelineName string
+	ResourceName string
+	TeamName     string
+	Lag          time.Duration
+}
+
+func (event CheckLag) Emit(logger lager.Logger) {
+	state := EventStateOK
+
+	if event.Lag > time.Minute {
+		state = EventStateWarning
+	}
+
+	if event.Lag > 5*time.Minute {
+		state = EventStateCritical
+	}
+
+	emit(
+		logger.Session("check-lag"),
+		Event{
+			Name:  "check lag (ms)",
+			Value: ms(event.Lag),
+			State: state,
+			Attributes: map[string]string{
+				"pipeline": event.PipelineName,
+				"resource": event.ResourceName,
+				"team":     event.TeamName,
+			},
+		},
+	)
+}
+
+type VolumeStreamed struct {
+	// "p2p" when streamed directly between workers, "atc" when streamed
+	// through the ATC
+	Method   string
+	Encoding string
+	Bytes    int64
+	Duration time.Duration
+}
+
+func (event VolumeStreamed) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"method":   event.Method,
+		"encoding": event.Encoding,
+	}
+
+	emit(
+		logger.Session("volume-streamed"),
+		Event{
+			Name:       "volume streamed (bytes)",
+			Value:      event.Bytes,
+			State:      EventStateOK,
+			Attributes: attributes,
+		},
+	)
+
+	emit(
+		logger.Session("volume-streaming-duration"),
+		Event{
+			Name:       "volume streaming duration (ms)",
+			Value:      ms(event.Duration),
+			State:      EventStateOK,
+			Attributes: attributes,
+		},
+	)
+}
+
 var lockTypeNames = map[int]string{
 	lock.LockTypeResourceConfigChecking: "ResourceConfigChecking",
 	lock.LockTypeBuildTracking:          "BuildTracking",
@@ -107,6 +107,15 @@ func tick(logger lager.Logger) {
 		},
 	)
 
+	emit(
+		logger.Session("checks-lock-contended"),
+		Event{
+			Name:  "checks lock contended",
+			Value: ChecksLockContended.Delta(),
+			State: EventStateOK,
+		},
+	)
+
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
 
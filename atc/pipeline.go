@@ -1,14 +1,114 @@
 package atc
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
 type Pipeline struct {
-	ID       int          `json:"id"`
-	Name     string       `json:"name"`
-	Paused   bool         `json:"paused"`
-	Public   bool         `json:"public"`
-	Groups   GroupConfigs `json:"groups,omitempty"`
-	TeamName string       `json:"team_name"`
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	Paused       bool         `json:"paused"`
+	Public       bool         `json:"public"`
+	Archived     bool         `json:"archived"`
+	Groups       GroupConfigs `json:"groups,omitempty"`
+	TeamName     string       `json:"team_name"`
+}
+
+// Ref returns the reference identifying the pipeline within its team.
+func (p Pipeline) Ref() PipelineRef {
+	return PipelineRef{
+		Name:         p.Name,
+		InstanceVars: p.InstanceVars,
+	}
 }
 
 type RenameRequest struct {
 	NewName string `json:"name"`
 }
+
+// InstanceVars distinguish the instances of a pipeline that share a name.
+type InstanceVars map[string]interface{}
+
+// PipelineRef identifies a pipeline within a team. Pipelines configured
+// without instance vars are referred to by name alone.
+type PipelineRef struct {
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+}
+
+// String renders the reference as 'name' or, for pipeline instances,
+// 'name/key:value,other-key:value' with the keys in sorted order.
+func (ref PipelineRef) String() string {
+	if len(ref.InstanceVars) == 0 {
+		return ref.Name
+	}
+
+	keys := make([]string, 0, len(ref.InstanceVars))
+	for k := range ref.InstanceVars {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + ":" + instanceVarString(ref.InstanceVars[k])
+	}
+
+	return ref.Name + "/" + strings.Join(pairs, ",")
+}
+
+// QueryParams returns the query parameters used to identify a pipeline
+// instance in API requests, in addition to the pipeline name in the path.
+func (ref PipelineRef) QueryParams() url.Values {
+	if len(ref.InstanceVars) == 0 {
+		return nil
+	}
+
+	payload, _ := json.Marshal(ref.InstanceVars)
+
+	return url.Values{"instance_vars": []string{string(payload)}}
+}
+
+// InstanceVarsFromQueryParams parses the instance vars given by
+// PipelineRef.QueryParams, if any.
+func InstanceVarsFromQueryParams(query url.Values) (InstanceVars, error) {
+	payload := query.Get("instance_vars")
+	if payload == "" {
+		return nil, nil
+	}
+
+	var instanceVars InstanceVars
+	err := json.Unmarshal([]byte(payload), &instanceVars)
+	if err != nil {
+		return nil, fmt.Errorf("malformed instance vars: %s", err)
+	}
+
+	return instanceVars, nil
+}
+
+func instanceVarString(value interface{}) string {
+	if str, ok := value.(string); ok {
+		// quote strings which would otherwise be read back as another type,
+		// e.g. "5.1" or "true"
+		var parsed interface{}
+		err := yaml.Unmarshal([]byte(str), &parsed)
+		if _, isString := parsed.(string); err == nil && isString {
+			return str
+		}
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(payload)
+}
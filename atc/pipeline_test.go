@@ -0,0 +1,62 @@
+package atc_test
+
+import (
+	"net/url"
+
+	. "github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineRef", func() {
+	Describe("String", func() {
+		It("renders just the name without instance vars", func() {
+			Expect(PipelineRef{Name: "some-pipeline"}.String()).To(Equal("some-pipeline"))
+		})
+
+		It("renders the instance vars sorted by key", func() {
+			ref := PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: InstanceVars{
+					"os":     "linux",
+					"branch": "5.1",
+					"number": 3,
+				},
+			}
+
+			Expect(ref.String()).To(Equal(`some-pipeline/branch:"5.1",number:3,os:linux`))
+		})
+
+		It("quotes strings which would be read as another type", func() {
+			ref := PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: InstanceVars{"enabled": "true", "empty": ""},
+			}
+
+			Expect(ref.String()).To(Equal(`some-pipeline/empty:"",enabled:"true"`))
+		})
+	})
+
+	Describe("QueryParams", func() {
+		It("is empty without instance vars", func() {
+			Expect(PipelineRef{Name: "some-pipeline"}.QueryParams()).To(BeEmpty())
+		})
+
+		It("round-trips the instance vars", func() {
+			ref := PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: InstanceVars{"branch": "5.1"},
+			}
+
+			instanceVars, err := InstanceVarsFromQueryParams(ref.QueryParams())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instanceVars).To(Equal(ref.InstanceVars))
+		})
+
+		It("fails on malformed instance vars", func() {
+			_, err := InstanceVarsFromQueryParams(url.Values{"instance_vars": []string{"nope"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
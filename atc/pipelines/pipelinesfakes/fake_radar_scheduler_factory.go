@@ -7,24 +7,10 @@ import (
 	creds "github.com/concourse/concourse/atc/creds"
 	db "github.com/concourse/concourse/atc/db"
 	pipelines "github.com/concourse/concourse/atc/pipelines"
-	radar "github.com/concourse/concourse/atc/radar"
 	scheduler "github.com/concourse/concourse/atc/scheduler"
 )
 
 type FakeRadarSchedulerFactory struct {
-	BuildScanRunnerFactoryStub        func(db.Pipeline, string, creds.Variables) radar.ScanRunnerFactory
-	buildScanRunnerFactoryMutex       sync.RWMutex
-	buildScanRunnerFactoryArgsForCall []struct {
-		arg1 db.Pipeline
-		arg2 string
-		arg3 creds.Variables
-	}
-	buildScanRunnerFactoryReturns struct {
-		result1 radar.ScanRunnerFactory
-	}
-	buildScanRunnerFactoryReturnsOnCall map[int]struct {
-		result1 radar.ScanRunnerFactory
-	}
 	BuildSchedulerStub        func(db.Pipeline, string, creds.Variables) scheduler.BuildScheduler
 	buildSchedulerMutex       sync.RWMutex
 	buildSchedulerArgsForCall []struct {
@@ -42,68 +28,6 @@ type FakeRadarSchedulerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeRadarSchedulerFactory) BuildScanRunnerFactory(arg1 db.Pipeline, arg2 string, arg3 creds.Variables) radar.ScanRunnerFactory {
-	fake.buildScanRunnerFactoryMutex.Lock()
-	ret, specificReturn := fake.buildScanRunnerFactoryReturnsOnCall[len(fake.buildScanRunnerFactoryArgsForCall)]
-	fake.buildScanRunnerFactoryArgsForCall = append(fake.buildScanRunnerFactoryArgsForCall, struct {
-		arg1 db.Pipeline
-		arg2 string
-		arg3 creds.Variables
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("BuildScanRunnerFactory", []interface{}{arg1, arg2, arg3})
-	fake.buildScanRunnerFactoryMutex.Unlock()
-	if fake.BuildScanRunnerFactoryStub != nil {
-		return fake.BuildScanRunnerFactoryStub(arg1, arg2, arg3)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	fakeReturns := fake.buildScanRunnerFactoryReturns
-	return fakeReturns.result1
-}
-
-func (fake *FakeRadarSchedulerFactory) BuildScanRunnerFactoryCallCount() int {
-	fake.buildScanRunnerFactoryMutex.RLock()
-	defer fake.buildScanRunnerFactoryMutex.RUnlock()
-	return len(fake.buildScanRunnerFactoryArgsForCall)
-}
-
-func (fake *FakeRadarSchedulerFactory) BuildScanRunnerFactoryCalls(stub func(db.Pipeline, string, creds.Variables) radar.ScanRunnerFactory) {
-	fake.buildScanRunnerFactoryMutex.Lock()
-	defer fake.buildScanRunnerFactoryMutex.Unlock()
-	fake.BuildScanRunnerFactoryStub = stub
-}
-
-func (fake *FakeRadarSchedulerFactory) BuildScanRunnerFactoryArgsForCall(i int) (db.Pipeline, string, creds.Variables) {
-	fake.buildScanRunnerFactoryMutex.RLock()
-	defer fake.buildScanRunnerFactoryMutex.RUnlock()
-	argsForCall := fake.buildScanRunnerFactoryArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
-}
-
-func (fake *FakeRadarSchedulerFactory) BuildScanRunnerFactoryReturns(result1 radar.ScanRunnerFactory) {
-	fake.buildScanRunnerFactoryMutex.Lock()
-	defer fake.buildScanRunnerFactoryMutex.Unlock()
-	fake.BuildScanRunnerFactoryStub = nil
-	fake.buildScanRunnerFactoryReturns = struct {
-		result1 radar.ScanRunnerFactory
-	}{result1}
-}
-
-func (fake *FakeRadarSchedulerFactory) BuildScanRunnerFactoryReturnsOnCall(i int, result1 radar.ScanRunnerFactory) {
-	fake.buildScanRunnerFactoryMutex.Lock()
-	defer fake.buildScanRunnerFactoryMutex.Unlock()
-	fake.BuildScanRunnerFactoryStub = nil
-	if fake.buildScanRunnerFactoryReturnsOnCall == nil {
-		fake.buildScanRunnerFactoryReturnsOnCall = make(map[int]struct {
-			result1 radar.ScanRunnerFactory
-		})
-	}
-	fake.buildScanRunnerFactoryReturnsOnCall[i] = struct {
-		result1 radar.ScanRunnerFactory
-	}{result1}
-}
-
 func (fake *FakeRadarSchedulerFactory) BuildScheduler(arg1 db.Pipeline, arg2 string, arg3 creds.Variables) scheduler.BuildScheduler {
 	fake.buildSchedulerMutex.Lock()
 	ret, specificReturn := fake.buildSchedulerReturnsOnCall[len(fake.buildSchedulerArgsForCall)]
@@ -169,8 +93,6 @@ func (fake *FakeRadarSchedulerFactory) BuildSchedulerReturnsOnCall(i int, result
 func (fake *FakeRadarSchedulerFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.buildScanRunnerFactoryMutex.RLock()
-	defer fake.buildScanRunnerFactoryMutex.RUnlock()
 	fake.buildSchedulerMutex.RLock()
 	defer fake.buildSchedulerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
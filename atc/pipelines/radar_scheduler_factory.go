@@ -20,48 +20,48 @@ import (
 //go:generate counterfeiter . RadarSchedulerFactory
 
 type RadarSchedulerFactory interface {
-	BuildScanRunnerFactory(dbPipeline db.Pipeline, externalURL string, variables creds.Variables) radar.ScanRunnerFactory
 	BuildScheduler(pipeline db.Pipeline, externalURL string, variables creds.Variables) scheduler.BuildScheduler
 }
 
 type radarSchedulerFactory struct {
-	resourceFactory              resource.ResourceFactory
-	resourceConfigFactory        db.ResourceConfigFactory
-	resourceTypeCheckingInterval time.Duration
-	resourceCheckingInterval     time.Duration
-	engine                       engine.Engine
+	resourceFactory          resource.ResourceFactory
+	resourceConfigFactory    db.ResourceConfigFactory
+	checkRateLimiter         db.CheckRateLimiter
+	resourceCheckingInterval time.Duration
+	baseResourceTypeDefaults atc.ResourceTypeDefaults
+	engine                   engine.Engine
 }
 
 func NewRadarSchedulerFactory(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
-	resourceTypeCheckingInterval time.Duration,
+	checkRateLimiter db.CheckRateLimiter,
 	resourceCheckingInterval time.Duration,
+	baseResourceTypeDefaults atc.ResourceTypeDefaults,
 	engine engine.Engine,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
-		resourceFactory:              resourceFactory,
-		resourceConfigFactory:        resourceConfigFactory,
-		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
-		resourceCheckingInterval:     resourceCheckingInterval,
-		engine:                       engine,
+		resourceFactory:          resourceFactory,
+		resourceConfigFactory:    resourceConfigFactory,
+		checkRateLimiter:         checkRateLimiter,
+		resourceCheckingInterval: resourceCheckingInterval,
+		baseResourceTypeDefaults: baseResourceTypeDefaults,
+		engine:                   engine,
 	}
 }
 
-func (rsf *radarSchedulerFactory) BuildScanRunnerFactory(dbPipeline db.Pipeline, externalURL string, variables creds.Variables) radar.ScanRunnerFactory {
-	return radar.NewScanRunnerFactory(rsf.resourceFactory, rsf.resourceConfigFactory, rsf.resourceTypeCheckingInterval, rsf.resourceCheckingInterval, dbPipeline, clock.NewClock(), externalURL, variables)
-}
-
 func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalURL string, variables creds.Variables) scheduler.BuildScheduler {
 
 	scanner := radar.NewResourceScanner(
 		clock.NewClock(),
 		rsf.resourceFactory,
 		rsf.resourceConfigFactory,
+		rsf.checkRateLimiter,
 		rsf.resourceCheckingInterval,
 		pipeline,
 		externalURL,
 		variables,
+		rsf.baseResourceTypeDefaults,
 	)
 
 	inputMapper := inputmapper.NewInputMapper(
@@ -81,6 +81,7 @@ func (rsf *radarSchedulerFactory) BuildScheduler(pipeline db.Pipeline, externalU
 			scanner,
 			inputMapper,
 			rsf.engine,
+			rsf.baseResourceTypeDefaults,
 		),
 		Scanner: scanner,
 	}
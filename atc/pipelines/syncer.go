@@ -58,7 +58,7 @@ func (syncer *Syncer) Sync() {
 
 		var found bool
 		for _, pipeline := range pipelines {
-			if pipeline.Paused() {
+			if pipeline.Paused() || pipeline.Archived() {
 				continue
 			}
 
@@ -75,7 +75,7 @@ func (syncer *Syncer) Sync() {
 	}
 
 	for _, pipeline := range pipelines {
-		if pipeline.Paused() || syncer.isPipelineRunning(pipeline.ID()) {
+		if pipeline.Paused() || pipeline.Archived() || syncer.isPipelineRunning(pipeline.ID()) {
 			continue
 		}
 
@@ -1,21 +1,31 @@
 package atc
 
+import (
+	"bytes"
+	"encoding/json"
+)
+
 type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
-	Aggregate *AggregatePlan `json:"aggregate,omitempty"`
-	Do        *DoPlan        `json:"do,omitempty"`
-	Get       *GetPlan       `json:"get,omitempty"`
-	Put       *PutPlan       `json:"put,omitempty"`
-	Task      *TaskPlan      `json:"task,omitempty"`
-	OnAbort   *OnAbortPlan   `json:"on_abort,ommitempty"`
-	Ensure    *EnsurePlan    `json:"ensure,omitempty"`
-	OnSuccess *OnSuccessPlan `json:"on_success,omitempty"`
-	OnFailure *OnFailurePlan `json:"on_failure,omitempty"`
-	Try       *TryPlan       `json:"try,omitempty"`
-	Timeout   *TimeoutPlan   `json:"timeout,omitempty"`
-	Retry     *RetryPlan     `json:"retry,omitempty"`
+	Aggregate   *AggregatePlan   `json:"aggregate,omitempty"`
+	InParallel  *InParallelPlan  `json:"in_parallel,omitempty"`
+	Do          *DoPlan          `json:"do,omitempty"`
+	Get         *GetPlan         `json:"get,omitempty"`
+	Put         *PutPlan         `json:"put,omitempty"`
+	Task        *TaskPlan        `json:"task,omitempty"`
+	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
+	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	OnAbort     *OnAbortPlan     `json:"on_abort,ommitempty"`
+	Ensure      *EnsurePlan      `json:"ensure,omitempty"`
+	OnSuccess   *OnSuccessPlan   `json:"on_success,omitempty"`
+	OnFailure   *OnFailurePlan   `json:"on_failure,omitempty"`
+	Try         *TryPlan         `json:"try,omitempty"`
+	Timeout     *TimeoutPlan     `json:"timeout,omitempty"`
+	Retry       *RetryPlan       `json:"retry,omitempty"`
+	Across      *AcrossPlan      `json:"across,omitempty"`
+	If          *IfPlan          `json:"if,omitempty"`
 
 	// used for 'fly execute'
 	UserArtifact   *UserArtifactPlan   `json:"user_artifact,omitempty"`
@@ -27,6 +37,71 @@ type Plan struct {
 
 type PlanID string
 
+// Each calls f with the plan and then with every plan nested within it.
+func (plan *Plan) Each(f func(*Plan)) {
+	f(plan)
+
+	if plan.Aggregate != nil {
+		for i := range *plan.Aggregate {
+			(*plan.Aggregate)[i].Each(f)
+		}
+	}
+
+	if plan.InParallel != nil {
+		for i := range plan.InParallel.Steps {
+			plan.InParallel.Steps[i].Each(f)
+		}
+	}
+
+	if plan.Do != nil {
+		for i := range *plan.Do {
+			(*plan.Do)[i].Each(f)
+		}
+	}
+
+	if plan.Retry != nil {
+		for i := range plan.Retry.Attempts {
+			plan.Retry.Attempts[i].Each(f)
+		}
+	}
+
+	if plan.OnAbort != nil {
+		plan.OnAbort.Step.Each(f)
+		plan.OnAbort.Next.Each(f)
+	}
+
+	if plan.Ensure != nil {
+		plan.Ensure.Step.Each(f)
+		plan.Ensure.Next.Each(f)
+	}
+
+	if plan.OnSuccess != nil {
+		plan.OnSuccess.Step.Each(f)
+		plan.OnSuccess.Next.Each(f)
+	}
+
+	if plan.OnFailure != nil {
+		plan.OnFailure.Step.Each(f)
+		plan.OnFailure.Next.Each(f)
+	}
+
+	if plan.Try != nil {
+		plan.Try.Step.Each(f)
+	}
+
+	if plan.Timeout != nil {
+		plan.Timeout.Step.Each(f)
+	}
+
+	if plan.Across != nil {
+		plan.Across.Step.Each(f)
+	}
+
+	if plan.If != nil {
+		plan.If.Step.Each(f)
+	}
+}
+
 type UserArtifactPlan struct {
 	Name string `json:"name"`
 }
@@ -66,6 +141,12 @@ type TryPlan struct {
 
 type AggregatePlan []Plan
 
+type InParallelPlan struct {
+	Steps    []Plan `json:"steps"`
+	Limit    int    `json:"limit,omitempty"`
+	FailFast bool   `json:"fail_fast,omitempty"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -78,6 +159,8 @@ type GetPlan struct {
 	VersionFrom *PlanID  `json:"version_from,omitempty"`
 	Tags        Tags     `json:"tags,omitempty"`
 
+	WorkerSelector WorkerSelector `json:"worker_selector,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -90,6 +173,8 @@ type PutPlan struct {
 	Tags     Tags          `json:"tags,omitempty"`
 	Inputs   *InputsConfig `json:"inputs,omitempty"`
 
+	WorkerSelector WorkerSelector `json:"worker_selector,omitempty"`
+
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
@@ -99,6 +184,8 @@ type TaskPlan struct {
 	Privileged bool `json:"privileged"`
 	Tags       Tags `json:"tags,omitempty"`
 
+	WorkerSelector WorkerSelector `json:"worker_selector,omitempty"`
+
 	ConfigPath string      `json:"config_path,omitempty"`
 	Config     *TaskConfig `json:"config,omitempty"`
 	Vars       Params      `json:"vars,omitempty"`
@@ -109,9 +196,92 @@ type TaskPlan struct {
 	ImageArtifactName string            `json:"image,omitempty"`
 
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
+	ResourceTypeDefaults   ResourceTypeDefaults   `json:"resource_type_defaults,omitempty"`
 }
 
-type RetryPlan []Plan
+type SetPipelinePlan struct {
+	Name         string                 `json:"name"`
+	File         string                 `json:"file"`
+	Vars         map[string]interface{} `json:"vars,omitempty"`
+	VarFiles     []string               `json:"var_files,omitempty"`
+	InstanceVars InstanceVars           `json:"instance_vars,omitempty"`
+}
+
+type LoadVarPlan struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Format string `json:"format,omitempty"`
+}
+
+const (
+	LoadVarFormatRaw  = "raw"
+	LoadVarFormatTrim = "trim"
+	LoadVarFormatJSON = "json"
+	LoadVarFormatYAML = "yaml"
+)
+
+// A RetryPlan runs each of its attempts in order until one succeeds, waiting
+// between attempts according to Backoff. If On is set, only the given causes
+// (AttemptOnError, AttemptOnFailure) result in another attempt.
+type RetryPlan struct {
+	Attempts []Plan        `json:"attempts"`
+	Backoff  *RetryBackoff `json:"backoff,omitempty"`
+	On       []string      `json:"on,omitempty"`
+}
+
+type RetryBackoff struct {
+	Initial    string  `json:"initial"`
+	Max        string  `json:"max,omitempty"`
+	Multiplier float64 `json:"multiplier,omitempty"`
+}
+
+// retryPlan is an alias used to unmarshal the full form of a RetryPlan without
+// recursing into its own unmarshaler.
+type retryPlan RetryPlan
+
+// UnmarshalJSON accepts the full form as well as a plain list of attempts,
+// which is how retry plans were saved by older versions.
+func (plan *RetryPlan) UnmarshalJSON(payload []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(payload), []byte("[")) {
+		var attempts []Plan
+		err := json.Unmarshal(payload, &attempts)
+		if err != nil {
+			return err
+		}
+
+		*plan = RetryPlan{Attempts: attempts}
+		return nil
+	}
+
+	var full retryPlan
+	err := json.Unmarshal(payload, &full)
+	if err != nil {
+		return err
+	}
+
+	*plan = RetryPlan(full)
+	return nil
+}
+
+type AcrossPlan struct {
+	Vars     []AcrossVar `json:"vars"`
+	Step     Plan        `json:"step"`
+	FailFast bool        `json:"fail_fast,omitempty"`
+}
+
+type AcrossVar struct {
+	Var         string        `json:"name"`
+	Values      []interface{} `json:"values,omitempty"`
+	File        string        `json:"file,omitempty"`
+	MaxInFlight int           `json:"max_in_flight,omitempty"`
+}
+
+// An IfPlan only runs its step if its condition evaluates to true when the
+// plan is run.
+type IfPlan struct {
+	Condition string `json:"condition"`
+	Step      Plan   `json:"step"`
+}
 
 type DependentGetPlan struct {
 	Type     string `json:"type"`
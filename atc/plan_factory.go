@@ -27,6 +27,8 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 	switch t := step.(type) {
 	case AggregatePlan:
 		plan.Aggregate = &t
+	case InParallelPlan:
+		plan.InParallel = &t
 	case DoPlan:
 		plan.Do = &t
 	case GetPlan:
@@ -35,6 +37,10 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Put = &t
 	case TaskPlan:
 		plan.Task = &t
+	case SetPipelinePlan:
+		plan.SetPipeline = &t
+	case LoadVarPlan:
+		plan.LoadVar = &t
 	case OnAbortPlan:
 		plan.OnAbort = &t
 	case EnsurePlan:
@@ -49,6 +55,10 @@ func (factory PlanFactory) NewPlan(step Step) Plan {
 		plan.Timeout = &t
 	case RetryPlan:
 		plan.Retry = &t
+	case AcrossPlan:
+		plan.Across = &t
+	case IfPlan:
+		plan.If = &t
 	case UserArtifactPlan:
 		plan.UserArtifact = &t
 	case ArtifactOutputPlan:
@@ -0,0 +1,50 @@
+package atc_test
+
+import (
+	"encoding/json"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plan", func() {
+	Describe("RetryPlan", func() {
+		Context("when unmarshaling a plain list of attempts", func() {
+			It("produces a retry plan with just the attempts", func() {
+				var plan atc.Plan
+				err := json.Unmarshal([]byte(`{"id": "1", "retry": [{"id": "2"}, {"id": "3"}]}`), &plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(plan.Retry).To(Equal(&atc.RetryPlan{
+					Attempts: []atc.Plan{{ID: "2"}, {ID: "3"}},
+				}))
+			})
+		})
+
+		Context("when unmarshaling the full form", func() {
+			It("produces the correct retry plan without error", func() {
+				var plan atc.Plan
+				err := json.Unmarshal([]byte(`{
+					"id": "1",
+					"retry": {
+						"attempts": [{"id": "2"}],
+						"backoff": {"initial": "1s", "multiplier": 2},
+						"on": ["failure"]
+					}
+				}`), &plan)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(plan.Retry).To(Equal(&atc.RetryPlan{
+					Attempts: []atc.Plan{{ID: "2"}},
+					Backoff: &atc.RetryBackoff{
+						Initial:    "1s",
+						Multiplier: 2,
+					},
+					On: []string{atc.AttemptOnFailure},
+				}))
+			})
+		})
+	})
+})
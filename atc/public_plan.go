@@ -7,10 +7,13 @@ func (plan Plan) Public() *json.RawMessage {
 		ID PlanID `json:"id"`
 
 		Aggregate      *json.RawMessage `json:"aggregate,omitempty"`
+		InParallel     *json.RawMessage `json:"in_parallel,omitempty"`
 		Do             *json.RawMessage `json:"do,omitempty"`
 		Get            *json.RawMessage `json:"get,omitempty"`
 		Put            *json.RawMessage `json:"put,omitempty"`
 		Task           *json.RawMessage `json:"task,omitempty"`
+		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
+		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
 		OnSuccess      *json.RawMessage `json:"on_success,omitempty"`
@@ -19,6 +22,8 @@ func (plan Plan) Public() *json.RawMessage {
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
 		Retry          *json.RawMessage `json:"retry,omitempty"`
+		Across         *json.RawMessage `json:"across,omitempty"`
+		If             *json.RawMessage `json:"if,omitempty"`
 		UserArtifact   *json.RawMessage `json:"user_artifact,omitempty"`
 		ArtifactOutput *json.RawMessage `json:"artifact_output,omitempty"`
 	}
@@ -29,6 +34,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Aggregate = plan.Aggregate.Public()
 	}
 
+	if plan.InParallel != nil {
+		public.InParallel = plan.InParallel.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -45,6 +54,14 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Task = plan.Task.Public()
 	}
 
+	if plan.SetPipeline != nil {
+		public.SetPipeline = plan.SetPipeline.Public()
+	}
+
+	if plan.LoadVar != nil {
+		public.LoadVar = plan.LoadVar.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -73,6 +90,14 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Retry = plan.Retry.Public()
 	}
 
+	if plan.Across != nil {
+		public.Across = plan.Across.Public()
+	}
+
+	if plan.If != nil {
+		public.If = plan.If.Public()
+	}
+
 	if plan.UserArtifact != nil {
 		public.UserArtifact = plan.UserArtifact.Public()
 	}
@@ -98,6 +123,24 @@ func (plan AggregatePlan) Public() *json.RawMessage {
 	return enc(public)
 }
 
+func (plan InParallelPlan) Public() *json.RawMessage {
+	steps := make([]*json.RawMessage, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = plan.Steps[i].Public()
+	}
+
+	return enc(struct {
+		Steps    []*json.RawMessage `json:"steps"`
+		Limit    int                `json:"limit,omitempty"`
+		FailFast bool               `json:"fail_fast,omitempty"`
+	}{
+		Steps:    steps,
+		Limit:    plan.Limit,
+		FailFast: plan.FailFast,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -196,6 +239,22 @@ func (plan TaskPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan SetPipelinePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
+func (plan LoadVarPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -215,15 +274,37 @@ func (plan TryPlan) Public() *json.RawMessage {
 }
 
 func (plan RetryPlan) Public() *json.RawMessage {
-	public := make([]*json.RawMessage, len(plan))
+	public := make([]*json.RawMessage, len(plan.Attempts))
 
-	for i := 0; i < len(plan); i++ {
-		public[i] = plan[i].Public()
+	for i := 0; i < len(plan.Attempts); i++ {
+		public[i] = plan.Attempts[i].Public()
 	}
 
 	return enc(public)
 }
 
+func (plan AcrossPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Vars     []AcrossVar      `json:"vars"`
+		Step     *json.RawMessage `json:"step"`
+		FailFast bool             `json:"fail_fast,omitempty"`
+	}{
+		Vars:     plan.Vars,
+		Step:     plan.Step.Public(),
+		FailFast: plan.FailFast,
+	})
+}
+
+func (plan IfPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Condition string           `json:"condition"`
+		Step      *json.RawMessage `json:"step"`
+	}{
+		Condition: plan.Condition,
+		Step:      plan.Step.Public(),
+	})
+}
+
 func (plan UserArtifactPlan) Public() *json.RawMessage {
 	return enc(plan)
 }
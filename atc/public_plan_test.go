@@ -223,33 +223,35 @@ var _ = Describe("Plan", func() {
 					atc.Plan{
 						ID: "24",
 						Retry: &atc.RetryPlan{
-							atc.Plan{
-								ID: "25",
-								Task: &atc.TaskPlan{
-									Name:       "name",
-									ConfigPath: "some/config/path.yml",
-									Config: &atc.TaskConfig{
-										Params: map[string]string{"some": "secret"},
+							Attempts: []atc.Plan{
+								atc.Plan{
+									ID: "25",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: map[string]string{"some": "secret"},
+										},
 									},
 								},
-							},
-							atc.Plan{
-								ID: "26",
-								Task: &atc.TaskPlan{
-									Name:       "name",
-									ConfigPath: "some/config/path.yml",
-									Config: &atc.TaskConfig{
-										Params: map[string]string{"some": "secret"},
+								atc.Plan{
+									ID: "26",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: map[string]string{"some": "secret"},
+										},
 									},
 								},
-							},
-							atc.Plan{
-								ID: "27",
-								Task: &atc.TaskPlan{
-									Name:       "name",
-									ConfigPath: "some/config/path.yml",
-									Config: &atc.TaskConfig{
-										Params: map[string]string{"some": "secret"},
+								atc.Plan{
+									ID: "27",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+										Config: &atc.TaskConfig{
+											Params: map[string]string{"some": "secret"},
+										},
 									},
 								},
 							},
@@ -295,6 +297,42 @@ var _ = Describe("Plan", func() {
 							Name: "some-name",
 						},
 					},
+
+					atc.Plan{
+						ID: "33",
+						SetPipeline: &atc.SetPipelinePlan{
+							Name:     "some-pipeline",
+							File:     "some-artifact/pipeline.yml",
+							Vars:     map[string]interface{}{"some": "secret"},
+							VarFiles: []string{"some-artifact/vars.yml"},
+						},
+					},
+
+					atc.Plan{
+						ID: "34",
+						LoadVar: &atc.LoadVarPlan{
+							Name:   "some-var",
+							File:   "some-artifact/version",
+							Format: "trim",
+						},
+					},
+
+					atc.Plan{
+						ID: "35",
+						InParallel: &atc.InParallelPlan{
+							Steps: []atc.Plan{
+								{
+									ID: "36",
+									Task: &atc.TaskPlan{
+										Name:       "name",
+										ConfigPath: "some/config/path.yml",
+									},
+								},
+							},
+							Limit:    2,
+							FailFast: true,
+						},
+					},
 				},
 			}
 
@@ -510,6 +548,34 @@ var _ = Describe("Plan", func() {
 			"artifact_output": {
 				"name": "some-name"
 			}
+		},
+		{
+			"id": "33",
+			"set_pipeline": {
+				"name": "some-pipeline"
+			}
+		},
+		{
+			"id": "34",
+			"load_var": {
+				"name": "some-var"
+			}
+		},
+		{
+			"id": "35",
+			"in_parallel": {
+				"steps": [
+					{
+						"id": "36",
+						"task": {
+							"name": "name",
+							"privileged": false
+						}
+					}
+				],
+				"limit": 2,
+				"fail_fast": true
+			}
 		}
   ]
 }
@@ -0,0 +1,310 @@
+package radar
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+// CheckScheduler periodically looks for the resources and resource types of
+// every pipeline which are due to be checked and checks them, running no
+// more than maxInFlight checks at a time.
+//
+// A resource or resource type is due once its check interval has elapsed
+// since it was last checked or last failed to set up its check, so that
+// failing checks back off rather than being retried on every tick. Only one
+// resource is checked per resource config scope. The scanner still acquires
+// the checking lock before running the check, so several ATCs may run a
+// scheduler at once.
+type CheckScheduler struct {
+	logger lager.Logger
+	clock  clock.Clock
+
+	noop bool
+
+	pipelineFactory  db.PipelineFactory
+	checkableFactory db.CheckableFactory
+	scannerFactory   ScannerFactory
+
+	resourceTypeCheckingInterval time.Duration
+	resourceCheckingInterval     time.Duration
+
+	interval    time.Duration
+	maxInFlight int
+
+	queue chan dueCheck
+
+	// running is whether each queued check has been picked up by a worker
+	running   map[string]bool
+	runningLk sync.Mutex
+}
+
+type dueCheck struct {
+	key          string
+	name         string
+	pipeline     db.Pipeline
+	resourceType bool
+	dueAt        time.Time
+}
+
+func NewCheckScheduler(
+	logger lager.Logger,
+	clock clock.Clock,
+	noop bool,
+	pipelineFactory db.PipelineFactory,
+	checkableFactory db.CheckableFactory,
+	scannerFactory ScannerFactory,
+	resourceTypeCheckingInterval time.Duration,
+	resourceCheckingInterval time.Duration,
+	interval time.Duration,
+	maxInFlight int,
+) *CheckScheduler {
+	return &CheckScheduler{
+		logger:                       logger,
+		clock:                        clock,
+		noop:                         noop,
+		pipelineFactory:              pipelineFactory,
+		checkableFactory:             checkableFactory,
+		scannerFactory:               scannerFactory,
+		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
+		resourceCheckingInterval:     resourceCheckingInterval,
+		interval:                     interval,
+		maxInFlight:                  maxInFlight,
+		running:                      map[string]bool{},
+	}
+}
+
+func (s *CheckScheduler) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	s.logger.Info("start")
+	defer s.logger.Info("done")
+
+	close(ready)
+
+	if s.noop {
+		<-signals
+		return nil
+	}
+
+	s.queue = make(chan dueCheck, s.maxInFlight)
+
+	stop := make(chan struct{})
+	wg := new(sync.WaitGroup)
+
+	for i := 0; i < s.maxInFlight; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(stop)
+		}()
+	}
+
+	ticker := s.clock.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.tick()
+
+	for {
+		select {
+		case <-ticker.C():
+			s.tick()
+		case <-signals:
+			close(stop)
+			wg.Wait()
+			return nil
+		}
+	}
+}
+
+func (s *CheckScheduler) tick() {
+	logger := s.logger.Session("tick")
+
+	resourceTypes, err := s.checkableFactory.DueResourceTypes(s.resourceTypeCheckingInterval)
+	if err != nil {
+		logger.Error("failed-to-get-resource-types", err)
+		return
+	}
+
+	resources, err := s.checkableFactory.DueResources(s.resourceCheckingInterval)
+	if err != nil {
+		logger.Error("failed-to-get-resources", err)
+		return
+	}
+
+	pipelineIDs := map[int]bool{}
+	for _, resourceType := range resourceTypes {
+		pipelineIDs[resourceType.PipelineID()] = true
+	}
+	for _, resource := range resources {
+		pipelineIDs[resource.PipelineID()] = true
+	}
+
+	pipelinesByID := map[int]db.Pipeline{}
+	if len(pipelineIDs) > 0 {
+		ids := make([]int, 0, len(pipelineIDs))
+		for id := range pipelineIDs {
+			ids = append(ids, id)
+		}
+
+		pipelines, err := s.pipelineFactory.PipelinesByID(ids)
+		if err != nil {
+			logger.Error("failed-to-get-pipelines", err)
+			return
+		}
+
+		for _, pipeline := range pipelines {
+			pipelinesByID[pipeline.ID()] = pipeline
+		}
+	}
+
+	waiting := 0
+
+	for _, resourceType := range resourceTypes {
+		pipeline, found := pipelinesByID[resourceType.PipelineID()]
+		if !found {
+			continue
+		}
+
+		if s.enqueue(logger, dueCheck{
+			key:          "resource-type:" + strconv.Itoa(resourceType.ID()),
+			name:         resourceType.Name(),
+			pipeline:     pipeline,
+			resourceType: true,
+			dueAt:        s.dueAt(resourceType.LastChecked(), resourceType.CheckEvery(), s.resourceTypeCheckingInterval),
+		}) {
+			waiting++
+		}
+	}
+
+	for _, resource := range resources {
+		pipeline, found := pipelinesByID[resource.PipelineID()]
+		if !found {
+			continue
+		}
+
+		if s.enqueue(logger, dueCheck{
+			key:      "resource:" + strconv.Itoa(resource.ID()),
+			name:     resource.Name(),
+			pipeline: pipeline,
+			dueAt:    s.dueAt(resource.LastChecked(), resource.CheckEvery(), s.resourceCheckingInterval),
+		}) {
+			waiting++
+		}
+	}
+
+	metric.CheckQueueDepth{
+		Depth: waiting,
+	}.Emit(logger)
+}
+
+// dueAt returns when a check last run at lastChecked became due, for
+// reporting how long it waited. It is zero for a check which has never run.
+func (s *CheckScheduler) dueAt(lastChecked time.Time, checkEvery string, defaultInterval time.Duration) time.Time {
+	if lastChecked.IsZero() {
+		return lastChecked
+	}
+
+	interval := defaultInterval
+	if checkEvery != "" {
+		configuredInterval, err := time.ParseDuration(checkEvery)
+		if err == nil {
+			interval = configuredInterval
+		}
+	}
+
+	return lastChecked.Add(interval)
+}
+
+// enqueue queues the check unless it is already queued or running, returning
+// whether it is left waiting for a worker.
+func (s *CheckScheduler) enqueue(logger lager.Logger, check dueCheck) bool {
+	s.runningLk.Lock()
+	defer s.runningLk.Unlock()
+
+	running, queued := s.running[check.key]
+	if queued {
+		return !running
+	}
+
+	select {
+	case s.queue <- check:
+		s.running[check.key] = false
+	default:
+		// the queue is full; the check is still due on the next tick
+		logger.Debug("queue-full", lager.Data{"check": check.key})
+	}
+
+	return true
+}
+
+func (s *CheckScheduler) work(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case check := <-s.queue:
+			s.runningLk.Lock()
+			s.running[check.key] = true
+			s.runningLk.Unlock()
+
+			s.check(check)
+
+			s.runningLk.Lock()
+			delete(s.running, check.key)
+			s.runningLk.Unlock()
+		}
+	}
+}
+
+func (s *CheckScheduler) check(check dueCheck) {
+	var logger lager.Logger
+	var scanner Scanner
+	if check.resourceType {
+		logger = s.logger.Session("scan-resource-type", lager.Data{
+			"pipeline":      check.pipeline.Name(),
+			"resource-type": check.name,
+		})
+		scanner = s.scannerFactory.NewResourceTypeScanner(check.pipeline)
+	} else {
+		logger = s.logger.Session("scan-resource", lager.Data{
+			"pipeline": check.pipeline.Name(),
+			"resource": check.name,
+		})
+		scanner = s.scannerFactory.NewResourceScanner(check.pipeline)
+	}
+
+	if !check.dueAt.IsZero() {
+		metric.CheckLag{
+			PipelineName: check.pipeline.Name(),
+			ResourceName: check.name,
+			TeamName:     check.pipeline.TeamName(),
+			Lag:          s.clock.Now().Sub(check.dueAt),
+		}.Emit(logger)
+	}
+
+	_, err := scanner.Run(logger, check.name)
+	if err == ErrCheckLockContended {
+		// another ATC is running the check, so the worker moves on rather
+		// than waiting for it
+		metric.ChecksLockContended.Inc()
+		return
+	}
+
+	if err == ErrCheckNotDue {
+		return
+	}
+
+	if err == ErrCheckRateLimited {
+		// the check stays due, so it runs once the rate limit allows
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-check", err)
+	}
+}
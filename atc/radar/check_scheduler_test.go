@@ -0,0 +1,233 @@
+package radar_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/metric"
+	. "github.com/concourse/concourse/atc/radar"
+	"github.com/concourse/concourse/atc/radar/radarfakes"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckScheduler", func() {
+	var (
+		epoch     time.Time
+		fakeClock *fakeclock.FakeClock
+
+		fakePipelineFactory  *dbfakes.FakePipelineFactory
+		fakeCheckableFactory *dbfakes.FakeCheckableFactory
+		fakeScannerFactory   *radarfakes.FakeScannerFactory
+
+		fakePipeline            *dbfakes.FakePipeline
+		fakeResource            *dbfakes.FakeResource
+		fakeResourceType        *dbfakes.FakeResourceType
+		fakeResourceScanner     *radarfakes.FakeScanner
+		fakeResourceTypeScanner *radarfakes.FakeScanner
+
+		noop        bool
+		maxInFlight int
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		epoch = time.Unix(123, 456).UTC()
+		fakeClock = fakeclock.NewFakeClock(epoch)
+
+		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
+		fakeCheckableFactory = new(dbfakes.FakeCheckableFactory)
+		fakeScannerFactory = new(radarfakes.FakeScannerFactory)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.IDReturns(42)
+		fakePipeline.NameReturns("some-pipeline")
+		fakePipeline.TeamNameReturns("some-team")
+		fakePipelineFactory.PipelinesByIDReturns([]db.Pipeline{fakePipeline}, nil)
+
+		fakeResource = new(dbfakes.FakeResource)
+		fakeResource.IDReturns(1)
+		fakeResource.NameReturns("some-resource")
+		fakeResource.PipelineIDReturns(42)
+		fakeCheckableFactory.DueResourcesReturns([]db.Resource{fakeResource}, nil)
+
+		fakeResourceType = new(dbfakes.FakeResourceType)
+		fakeResourceType.IDReturns(2)
+		fakeResourceType.NameReturns("some-resource-type")
+		fakeResourceType.PipelineIDReturns(42)
+		fakeCheckableFactory.DueResourceTypesReturns([]db.ResourceType{fakeResourceType}, nil)
+
+		fakeResourceScanner = new(radarfakes.FakeScanner)
+		fakeScannerFactory.NewResourceScannerReturns(fakeResourceScanner)
+		fakeResourceTypeScanner = new(radarfakes.FakeScanner)
+		fakeScannerFactory.NewResourceTypeScannerReturns(fakeResourceTypeScanner)
+
+		noop = false
+		maxInFlight = 2
+	})
+
+	JustBeforeEach(func() {
+		process = ginkgomon.Invoke(NewCheckScheduler(
+			lagertest.NewTestLogger("test"),
+			fakeClock,
+			noop,
+			fakePipelineFactory,
+			fakeCheckableFactory,
+			fakeScannerFactory,
+			time.Minute,
+			2*time.Minute,
+			10*time.Second,
+			maxInFlight,
+		))
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	Context("when resources and resource types are due", func() {
+		It("looks for them with the default checking intervals", func() {
+			Eventually(fakeCheckableFactory.DueResourceTypesCallCount).Should(Equal(1))
+			Expect(fakeCheckableFactory.DueResourceTypesArgsForCall(0)).To(Equal(time.Minute))
+
+			Eventually(fakeCheckableFactory.DueResourcesCallCount).Should(Equal(1))
+			Expect(fakeCheckableFactory.DueResourcesArgsForCall(0)).To(Equal(2 * time.Minute))
+		})
+
+		It("loads only their pipelines", func() {
+			Eventually(fakePipelineFactory.PipelinesByIDCallCount).Should(Equal(1))
+			Expect(fakePipelineFactory.PipelinesByIDArgsForCall(0)).To(Equal([]int{42}))
+		})
+
+		It("checks the resource type in its pipeline", func() {
+			Eventually(fakeResourceTypeScanner.RunCallCount).Should(Equal(1))
+			_, name := fakeResourceTypeScanner.RunArgsForCall(0)
+			Expect(name).To(Equal("some-resource-type"))
+			Expect(fakeScannerFactory.NewResourceTypeScannerArgsForCall(0)).To(Equal(fakePipeline))
+		})
+
+		It("checks the resource in its pipeline", func() {
+			Eventually(fakeResourceScanner.RunCallCount).Should(Equal(1))
+			_, name := fakeResourceScanner.RunArgsForCall(0)
+			Expect(name).To(Equal("some-resource"))
+			Expect(fakeScannerFactory.NewResourceScannerArgsForCall(0)).To(Equal(fakePipeline))
+		})
+	})
+
+	Context("when nothing is due", func() {
+		BeforeEach(func() {
+			fakeCheckableFactory.DueResourcesReturns(nil, nil)
+			fakeCheckableFactory.DueResourceTypesReturns(nil, nil)
+		})
+
+		It("does not load any pipelines", func() {
+			Eventually(fakeCheckableFactory.DueResourcesCallCount).Should(Equal(1))
+			Consistently(fakePipelineFactory.PipelinesByIDCallCount).Should(BeZero())
+		})
+	})
+
+	Context("when the pipeline has gone away", func() {
+		BeforeEach(func() {
+			fakePipelineFactory.PipelinesByIDReturns([]db.Pipeline{}, nil)
+		})
+
+		It("does not check anything", func() {
+			Eventually(fakePipelineFactory.PipelinesByIDCallCount).Should(Equal(1))
+			Consistently(fakeResourceScanner.RunCallCount).Should(BeZero())
+			Consistently(fakeResourceTypeScanner.RunCallCount).Should(BeZero())
+		})
+	})
+
+	Context("when a check is still running on the next tick", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			release = make(chan struct{})
+
+			fakeResourceScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+				<-release
+				return 0, nil
+			}
+		})
+
+		AfterEach(func() {
+			close(release)
+		})
+
+		It("does not check it again", func() {
+			Eventually(fakeResourceScanner.RunCallCount).Should(Equal(1))
+
+			fakeClock.WaitForWatcherAndIncrement(10 * time.Second)
+			Eventually(fakeCheckableFactory.DueResourcesCallCount).Should(Equal(2))
+
+			Consistently(fakeResourceScanner.RunCallCount).Should(Equal(1))
+		})
+	})
+
+	Context("when more checks are due than may run at once", func() {
+		var release chan struct{}
+
+		BeforeEach(func() {
+			maxInFlight = 1
+			release = make(chan struct{})
+
+			fakeResourceTypeScanner.RunStub = func(lager.Logger, string) (time.Duration, error) {
+				<-release
+				return 0, nil
+			}
+		})
+
+		It("runs them one after the other", func() {
+			Eventually(fakeResourceTypeScanner.RunCallCount).Should(Equal(1))
+			Consistently(fakeResourceScanner.RunCallCount).Should(BeZero())
+
+			close(release)
+			fakeClock.WaitForWatcherAndIncrement(10 * time.Second)
+
+			Eventually(fakeResourceScanner.RunCallCount).ShouldNot(BeZero())
+		})
+	})
+
+	Context("when another ATC holds the check lock", func() {
+		BeforeEach(func() {
+			metric.ChecksLockContended.Delta()
+			fakeResourceScanner.RunReturns(0, ErrCheckLockContended)
+		})
+
+		It("counts the contention", func() {
+			Eventually(fakeResourceScanner.RunCallCount).Should(Equal(1))
+			Eventually(metric.ChecksLockContended.Delta).Should(Equal(1))
+		})
+	})
+
+	Context("when the check is no longer due", func() {
+		BeforeEach(func() {
+			metric.ChecksLockContended.Delta()
+			fakeResourceScanner.RunReturns(0, ErrCheckNotDue)
+		})
+
+		It("does not count it as contention", func() {
+			Eventually(fakeResourceScanner.RunCallCount).Should(Equal(1))
+			Consistently(metric.ChecksLockContended.Delta).Should(BeZero())
+		})
+	})
+
+	Context("when running in noop mode", func() {
+		BeforeEach(func() {
+			noop = true
+		})
+
+		It("does not check anything", func() {
+			Consistently(fakeCheckableFactory.DueResourcesCallCount).Should(BeZero())
+			Consistently(fakeResourceScanner.RunCallCount).Should(BeZero())
+		})
+	})
+})
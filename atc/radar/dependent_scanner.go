@@ -0,0 +1,119 @@
+package radar
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Dependents are the names of the resource types and resources which use a
+// resource type, directly or through other resource types.
+type Dependents struct {
+	ResourceTypes []string
+	Resources     []string
+}
+
+//go:generate counterfeiter . DependentScanner
+
+// A DependentScanner has everything using a resource type rechecked, e.g.
+// once it has a new version. The resource types and resources get a new
+// resource config for the new version, so their check containers and version
+// history are no longer those of the previous version.
+//
+// Dependents are not checked by the DependentScanner itself; they are made
+// due so that the check scheduler runs them, subject to its rate limit.
+type DependentScanner interface {
+	ScanFromVersion(lager.Logger, string, atc.Version) (Dependents, error)
+	ScheduleDependents(lager.Logger, string) (Dependents, error)
+}
+
+type dependentScanner struct {
+	dbPipeline          db.Pipeline
+	resourceTypeScanner Scanner
+}
+
+// NewDependentScanner returns a DependentScanner which checks the resource
+// type itself with the given scanner. The resource type scanner should not
+// schedule dependents itself, as every dependent is found up front.
+func NewDependentScanner(
+	dbPipeline db.Pipeline,
+	resourceTypeScanner Scanner,
+) DependentScanner {
+	return &dependentScanner{
+		dbPipeline:          dbPipeline,
+		resourceTypeScanner: resourceTypeScanner,
+	}
+}
+
+// ScanFromVersion checks the resource type from the given version and then
+// schedules its dependents, whether or not it has a new version.
+func (scanner *dependentScanner) ScanFromVersion(logger lager.Logger, resourceTypeName string, fromVersion atc.Version) (Dependents, error) {
+	err := scanner.resourceTypeScanner.ScanFromVersion(logger, resourceTypeName, fromVersion)
+	if err != nil {
+		return Dependents{}, err
+	}
+
+	return scanner.ScheduleDependents(logger, resourceTypeName)
+}
+
+// ScheduleDependents makes the resource types using the resource type, those
+// using them and so on, and every resource using any of them due to be
+// checked.
+func (scanner *dependentScanner) ScheduleDependents(logger lager.Logger, resourceTypeName string) (Dependents, error) {
+	logger = logger.Session("schedule-dependents", lager.Data{
+		"resource-type": resourceTypeName,
+	})
+
+	resourceTypes, err := scanner.dbPipeline.ResourceTypes()
+	if err != nil {
+		logger.Error("failed-to-get-resource-types", err)
+		return Dependents{}, err
+	}
+
+	resources, err := scanner.dbPipeline.Resources()
+	if err != nil {
+		logger.Error("failed-to-get-resources", err)
+		return Dependents{}, err
+	}
+
+	dependents := Dependents{
+		ResourceTypes: []string{},
+		Resources:     []string{},
+	}
+
+	affected := map[string]bool{resourceTypeName: true}
+	queue := []string{resourceTypeName}
+	for len(queue) > 0 {
+		parentType := queue[0]
+		queue = queue[1:]
+
+		for _, resourceType := range resourceTypes {
+			if resourceType.Type() != parentType || affected[resourceType.Name()] {
+				continue
+			}
+
+			affected[resourceType.Name()] = true
+			queue = append(queue, resourceType.Name())
+			dependents.ResourceTypes = append(dependents.ResourceTypes, resourceType.Name())
+		}
+	}
+
+	for _, resource := range resources {
+		if affected[resource.Type()] {
+			dependents.Resources = append(dependents.Resources, resource.Name())
+		}
+	}
+
+	err = scanner.dbPipeline.ResetLastChecked(dependents.ResourceTypes, dependents.Resources)
+	if err != nil {
+		logger.Error("failed-to-reset-last-checked", err)
+		return Dependents{}, err
+	}
+
+	logger.Info("scheduled", lager.Data{
+		"resource-types": dependents.ResourceTypes,
+		"resources":      dependents.Resources,
+	})
+
+	return dependents, nil
+}
@@ -0,0 +1,169 @@
+package radar_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	. "github.com/concourse/concourse/atc/radar"
+	"github.com/concourse/concourse/atc/radar/radarfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DependentScanner", func() {
+	var (
+		fakeDBPipeline          *dbfakes.FakePipeline
+		fakeResourceTypeScanner *radarfakes.FakeScanner
+		scanner                 DependentScanner
+		dependents              Dependents
+		scanErr                 error
+		fakeResourceTypes       db.ResourceTypes
+		fakeResources           db.Resources
+		resourceTypesErr        error
+	)
+
+	resourceType := func(name string, type_ string) db.ResourceType {
+		fakeResourceType := new(dbfakes.FakeResourceType)
+		fakeResourceType.NameReturns(name)
+		fakeResourceType.TypeReturns(type_)
+		return fakeResourceType
+	}
+
+	resource := func(name string, type_ string) db.Resource {
+		fakeResource := new(dbfakes.FakeResource)
+		fakeResource.NameReturns(name)
+		fakeResource.TypeReturns(type_)
+		return fakeResource
+	}
+
+	BeforeEach(func() {
+		fakeDBPipeline = new(dbfakes.FakePipeline)
+		fakeResourceTypeScanner = new(radarfakes.FakeScanner)
+
+		fakeResourceTypes = db.ResourceTypes{
+			resourceType("grandchild-type", "child-type"),
+			resourceType("some-type", "registry-image"),
+			resourceType("child-type", "some-type"),
+			resourceType("other-type", "registry-image"),
+		}
+
+		fakeResources = db.Resources{
+			resource("some-resource", "some-type"),
+			resource("grandchild-resource", "grandchild-type"),
+			resource("other-resource", "other-type"),
+			resource("git-resource", "git"),
+		}
+
+		resourceTypesErr = nil
+
+		scanner = NewDependentScanner(fakeDBPipeline, fakeResourceTypeScanner)
+	})
+
+	JustBeforeEach(func() {
+		fakeDBPipeline.ResourceTypesReturns(fakeResourceTypes, resourceTypesErr)
+		fakeDBPipeline.ResourcesReturns(fakeResources, nil)
+	})
+
+	Describe("ScheduleDependents", func() {
+		JustBeforeEach(func() {
+			dependents, scanErr = scanner.ScheduleDependents(lagertest.NewTestLogger("test"), "some-type")
+		})
+
+		It("makes the resource types and resources using it due to be checked", func() {
+			Expect(scanErr).ToNot(HaveOccurred())
+			Expect(fakeDBPipeline.ResetLastCheckedCallCount()).To(Equal(1))
+
+			resourceTypeNames, resourceNames := fakeDBPipeline.ResetLastCheckedArgsForCall(0)
+			Expect(resourceTypeNames).To(Equal([]string{"child-type", "grandchild-type"}))
+			Expect(resourceNames).To(Equal([]string{"some-resource", "grandchild-resource"}))
+		})
+
+		It("does not check anything itself", func() {
+			Expect(fakeResourceTypeScanner.ScanCallCount()).To(BeZero())
+			Expect(fakeResourceTypeScanner.ScanFromVersionCallCount()).To(BeZero())
+		})
+
+		It("returns what was scheduled", func() {
+			Expect(dependents).To(Equal(Dependents{
+				ResourceTypes: []string{"child-type", "grandchild-type"},
+				Resources:     []string{"some-resource", "grandchild-resource"},
+			}))
+		})
+
+		Context("when nothing uses it", func() {
+			BeforeEach(func() {
+				fakeResourceTypes = db.ResourceTypes{resourceType("some-type", "registry-image")}
+				fakeResources = db.Resources{resource("git-resource", "git")}
+			})
+
+			It("returns no dependents", func() {
+				Expect(dependents).To(Equal(Dependents{
+					ResourceTypes: []string{},
+					Resources:     []string{},
+				}))
+			})
+		})
+
+		Context("when getting the resource types fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				resourceTypesErr = disaster
+			})
+
+			It("returns the error", func() {
+				Expect(scanErr).To(Equal(disaster))
+				Expect(fakeDBPipeline.ResetLastCheckedCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when making the dependents due fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeDBPipeline.ResetLastCheckedReturns(disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(scanErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("ScanFromVersion", func() {
+		JustBeforeEach(func() {
+			dependents, scanErr = scanner.ScanFromVersion(lagertest.NewTestLogger("test"), "some-type", atc.Version{"some": "version"})
+		})
+
+		It("checks the resource type from the version", func() {
+			Expect(fakeResourceTypeScanner.ScanFromVersionCallCount()).To(Equal(1))
+
+			_, name, version := fakeResourceTypeScanner.ScanFromVersionArgsForCall(0)
+			Expect(name).To(Equal("some-type"))
+			Expect(version).To(Equal(atc.Version{"some": "version"}))
+		})
+
+		It("schedules its dependents", func() {
+			Expect(scanErr).ToNot(HaveOccurred())
+			Expect(dependents.Resources).To(Equal([]string{"some-resource", "grandchild-resource"}))
+			Expect(fakeDBPipeline.ResetLastCheckedCallCount()).To(Equal(1))
+		})
+
+		Context("when checking the resource type fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeResourceTypeScanner.ScanFromVersionReturns(disaster)
+			})
+
+			It("returns the error without scheduling its dependents", func() {
+				Expect(scanErr).To(Equal(disaster))
+				Expect(fakeDBPipeline.ResetLastCheckedCallCount()).To(BeZero())
+			})
+		})
+	})
+})
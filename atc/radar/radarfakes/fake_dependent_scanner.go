@@ -0,0 +1,201 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package radarfakes
+
+import (
+	sync "sync"
+
+	lager "code.cloudfoundry.org/lager"
+	atc "github.com/concourse/concourse/atc"
+	radar "github.com/concourse/concourse/atc/radar"
+)
+
+type FakeDependentScanner struct {
+	ScanFromVersionStub        func(lager.Logger, string, atc.Version) (radar.Dependents, error)
+	scanFromVersionMutex       sync.RWMutex
+	scanFromVersionArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+	}
+	scanFromVersionReturns struct {
+		result1 radar.Dependents
+		result2 error
+	}
+	scanFromVersionReturnsOnCall map[int]struct {
+		result1 radar.Dependents
+		result2 error
+	}
+	ScheduleDependentsStub        func(lager.Logger, string) (radar.Dependents, error)
+	scheduleDependentsMutex       sync.RWMutex
+	scheduleDependentsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	scheduleDependentsReturns struct {
+		result1 radar.Dependents
+		result2 error
+	}
+	scheduleDependentsReturnsOnCall map[int]struct {
+		result1 radar.Dependents
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDependentScanner) ScanFromVersion(arg1 lager.Logger, arg2 string, arg3 atc.Version) (radar.Dependents, error) {
+	fake.scanFromVersionMutex.Lock()
+	ret, specificReturn := fake.scanFromVersionReturnsOnCall[len(fake.scanFromVersionArgsForCall)]
+	fake.scanFromVersionArgsForCall = append(fake.scanFromVersionArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ScanFromVersion", []interface{}{arg1, arg2, arg3})
+	fake.scanFromVersionMutex.Unlock()
+	if fake.ScanFromVersionStub != nil {
+		return fake.ScanFromVersionStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.scanFromVersionReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDependentScanner) ScanFromVersionCallCount() int {
+	fake.scanFromVersionMutex.RLock()
+	defer fake.scanFromVersionMutex.RUnlock()
+	return len(fake.scanFromVersionArgsForCall)
+}
+
+func (fake *FakeDependentScanner) ScanFromVersionCalls(stub func(lager.Logger, string, atc.Version) (radar.Dependents, error)) {
+	fake.scanFromVersionMutex.Lock()
+	defer fake.scanFromVersionMutex.Unlock()
+	fake.ScanFromVersionStub = stub
+}
+
+func (fake *FakeDependentScanner) ScanFromVersionArgsForCall(i int) (lager.Logger, string, atc.Version) {
+	fake.scanFromVersionMutex.RLock()
+	defer fake.scanFromVersionMutex.RUnlock()
+	argsForCall := fake.scanFromVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDependentScanner) ScanFromVersionReturns(result1 radar.Dependents, result2 error) {
+	fake.scanFromVersionMutex.Lock()
+	defer fake.scanFromVersionMutex.Unlock()
+	fake.ScanFromVersionStub = nil
+	fake.scanFromVersionReturns = struct {
+		result1 radar.Dependents
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependentScanner) ScanFromVersionReturnsOnCall(i int, result1 radar.Dependents, result2 error) {
+	fake.scanFromVersionMutex.Lock()
+	defer fake.scanFromVersionMutex.Unlock()
+	fake.ScanFromVersionStub = nil
+	if fake.scanFromVersionReturnsOnCall == nil {
+		fake.scanFromVersionReturnsOnCall = make(map[int]struct {
+			result1 radar.Dependents
+			result2 error
+		})
+	}
+	fake.scanFromVersionReturnsOnCall[i] = struct {
+		result1 radar.Dependents
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependentScanner) ScheduleDependents(arg1 lager.Logger, arg2 string) (radar.Dependents, error) {
+	fake.scheduleDependentsMutex.Lock()
+	ret, specificReturn := fake.scheduleDependentsReturnsOnCall[len(fake.scheduleDependentsArgsForCall)]
+	fake.scheduleDependentsArgsForCall = append(fake.scheduleDependentsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ScheduleDependents", []interface{}{arg1, arg2})
+	fake.scheduleDependentsMutex.Unlock()
+	if fake.ScheduleDependentsStub != nil {
+		return fake.ScheduleDependentsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.scheduleDependentsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDependentScanner) ScheduleDependentsCallCount() int {
+	fake.scheduleDependentsMutex.RLock()
+	defer fake.scheduleDependentsMutex.RUnlock()
+	return len(fake.scheduleDependentsArgsForCall)
+}
+
+func (fake *FakeDependentScanner) ScheduleDependentsCalls(stub func(lager.Logger, string) (radar.Dependents, error)) {
+	fake.scheduleDependentsMutex.Lock()
+	defer fake.scheduleDependentsMutex.Unlock()
+	fake.ScheduleDependentsStub = stub
+}
+
+func (fake *FakeDependentScanner) ScheduleDependentsArgsForCall(i int) (lager.Logger, string) {
+	fake.scheduleDependentsMutex.RLock()
+	defer fake.scheduleDependentsMutex.RUnlock()
+	argsForCall := fake.scheduleDependentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDependentScanner) ScheduleDependentsReturns(result1 radar.Dependents, result2 error) {
+	fake.scheduleDependentsMutex.Lock()
+	defer fake.scheduleDependentsMutex.Unlock()
+	fake.ScheduleDependentsStub = nil
+	fake.scheduleDependentsReturns = struct {
+		result1 radar.Dependents
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependentScanner) ScheduleDependentsReturnsOnCall(i int, result1 radar.Dependents, result2 error) {
+	fake.scheduleDependentsMutex.Lock()
+	defer fake.scheduleDependentsMutex.Unlock()
+	fake.ScheduleDependentsStub = nil
+	if fake.scheduleDependentsReturnsOnCall == nil {
+		fake.scheduleDependentsReturnsOnCall = make(map[int]struct {
+			result1 radar.Dependents
+			result2 error
+		})
+	}
+	fake.scheduleDependentsReturnsOnCall[i] = struct {
+		result1 radar.Dependents
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDependentScanner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.scanFromVersionMutex.RLock()
+	defer fake.scanFromVersionMutex.RUnlock()
+	fake.scheduleDependentsMutex.RLock()
+	defer fake.scheduleDependentsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDependentScanner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ radar.DependentScanner = new(FakeDependentScanner)
@@ -2,11 +2,13 @@
 package radarfakes
 
 import (
+	json "encoding/json"
 	sync "sync"
 	time "time"
 
 	lager "code.cloudfoundry.org/lager"
 	atc "github.com/concourse/concourse/atc"
+	db "github.com/concourse/concourse/atc/db"
 	radar "github.com/concourse/concourse/atc/radar"
 )
 
@@ -37,6 +39,20 @@ type FakeScanner struct {
 	scanReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScanFromPayloadStub        func(lager.Logger, string, atc.Version, json.RawMessage) error
+	scanFromPayloadMutex       sync.RWMutex
+	scanFromPayloadArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 json.RawMessage
+	}
+	scanFromPayloadReturns struct {
+		result1 error
+	}
+	scanFromPayloadReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ScanFromVersionStub        func(lager.Logger, string, atc.Version) error
 	scanFromVersionMutex       sync.RWMutex
 	scanFromVersionArgsForCall []struct {
@@ -50,6 +66,20 @@ type FakeScanner struct {
 	scanFromVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScanFromVersionNotifyingStub        func(lager.Logger, string, atc.Version, chan<- db.Check) error
+	scanFromVersionNotifyingMutex       sync.RWMutex
+	scanFromVersionNotifyingArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 chan<- db.Check
+	}
+	scanFromVersionNotifyingReturns struct {
+		result1 error
+	}
+	scanFromVersionNotifyingReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -179,6 +209,69 @@ func (fake *FakeScanner) ScanReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeScanner) ScanFromPayload(arg1 lager.Logger, arg2 string, arg3 atc.Version, arg4 json.RawMessage) error {
+	fake.scanFromPayloadMutex.Lock()
+	ret, specificReturn := fake.scanFromPayloadReturnsOnCall[len(fake.scanFromPayloadArgsForCall)]
+	fake.scanFromPayloadArgsForCall = append(fake.scanFromPayloadArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 json.RawMessage
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ScanFromPayload", []interface{}{arg1, arg2, arg3, arg4})
+	fake.scanFromPayloadMutex.Unlock()
+	if fake.ScanFromPayloadStub != nil {
+		return fake.ScanFromPayloadStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scanFromPayloadReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScanner) ScanFromPayloadCallCount() int {
+	fake.scanFromPayloadMutex.RLock()
+	defer fake.scanFromPayloadMutex.RUnlock()
+	return len(fake.scanFromPayloadArgsForCall)
+}
+
+func (fake *FakeScanner) ScanFromPayloadCalls(stub func(lager.Logger, string, atc.Version, json.RawMessage) error) {
+	fake.scanFromPayloadMutex.Lock()
+	defer fake.scanFromPayloadMutex.Unlock()
+	fake.ScanFromPayloadStub = stub
+}
+
+func (fake *FakeScanner) ScanFromPayloadArgsForCall(i int) (lager.Logger, string, atc.Version, json.RawMessage) {
+	fake.scanFromPayloadMutex.RLock()
+	defer fake.scanFromPayloadMutex.RUnlock()
+	argsForCall := fake.scanFromPayloadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeScanner) ScanFromPayloadReturns(result1 error) {
+	fake.scanFromPayloadMutex.Lock()
+	defer fake.scanFromPayloadMutex.Unlock()
+	fake.ScanFromPayloadStub = nil
+	fake.scanFromPayloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScanner) ScanFromPayloadReturnsOnCall(i int, result1 error) {
+	fake.scanFromPayloadMutex.Lock()
+	defer fake.scanFromPayloadMutex.Unlock()
+	fake.ScanFromPayloadStub = nil
+	if fake.scanFromPayloadReturnsOnCall == nil {
+		fake.scanFromPayloadReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanFromPayloadReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScanner) ScanFromVersion(arg1 lager.Logger, arg2 string, arg3 atc.Version) error {
 	fake.scanFromVersionMutex.Lock()
 	ret, specificReturn := fake.scanFromVersionReturnsOnCall[len(fake.scanFromVersionArgsForCall)]
@@ -241,6 +334,69 @@ func (fake *FakeScanner) ScanFromVersionReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeScanner) ScanFromVersionNotifying(arg1 lager.Logger, arg2 string, arg3 atc.Version, arg4 chan<- db.Check) error {
+	fake.scanFromVersionNotifyingMutex.Lock()
+	ret, specificReturn := fake.scanFromVersionNotifyingReturnsOnCall[len(fake.scanFromVersionNotifyingArgsForCall)]
+	fake.scanFromVersionNotifyingArgsForCall = append(fake.scanFromVersionNotifyingArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 atc.Version
+		arg4 chan<- db.Check
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("ScanFromVersionNotifying", []interface{}{arg1, arg2, arg3, arg4})
+	fake.scanFromVersionNotifyingMutex.Unlock()
+	if fake.ScanFromVersionNotifyingStub != nil {
+		return fake.ScanFromVersionNotifyingStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scanFromVersionNotifyingReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeScanner) ScanFromVersionNotifyingCallCount() int {
+	fake.scanFromVersionNotifyingMutex.RLock()
+	defer fake.scanFromVersionNotifyingMutex.RUnlock()
+	return len(fake.scanFromVersionNotifyingArgsForCall)
+}
+
+func (fake *FakeScanner) ScanFromVersionNotifyingCalls(stub func(lager.Logger, string, atc.Version, chan<- db.Check) error) {
+	fake.scanFromVersionNotifyingMutex.Lock()
+	defer fake.scanFromVersionNotifyingMutex.Unlock()
+	fake.ScanFromVersionNotifyingStub = stub
+}
+
+func (fake *FakeScanner) ScanFromVersionNotifyingArgsForCall(i int) (lager.Logger, string, atc.Version, chan<- db.Check) {
+	fake.scanFromVersionNotifyingMutex.RLock()
+	defer fake.scanFromVersionNotifyingMutex.RUnlock()
+	argsForCall := fake.scanFromVersionNotifyingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeScanner) ScanFromVersionNotifyingReturns(result1 error) {
+	fake.scanFromVersionNotifyingMutex.Lock()
+	defer fake.scanFromVersionNotifyingMutex.Unlock()
+	fake.ScanFromVersionNotifyingStub = nil
+	fake.scanFromVersionNotifyingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScanner) ScanFromVersionNotifyingReturnsOnCall(i int, result1 error) {
+	fake.scanFromVersionNotifyingMutex.Lock()
+	defer fake.scanFromVersionNotifyingMutex.Unlock()
+	fake.ScanFromVersionNotifyingStub = nil
+	if fake.scanFromVersionNotifyingReturnsOnCall == nil {
+		fake.scanFromVersionNotifyingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.scanFromVersionNotifyingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeScanner) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -248,8 +404,12 @@ func (fake *FakeScanner) Invocations() map[string][][]interface{} {
 	defer fake.runMutex.RUnlock()
 	fake.scanMutex.RLock()
 	defer fake.scanMutex.RUnlock()
+	fake.scanFromPayloadMutex.RLock()
+	defer fake.scanFromPayloadMutex.RUnlock()
 	fake.scanFromVersionMutex.RLock()
 	defer fake.scanFromVersionMutex.RUnlock()
+	fake.scanFromVersionNotifyingMutex.RLock()
+	defer fake.scanFromVersionNotifyingMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
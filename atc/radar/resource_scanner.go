@@ -2,6 +2,7 @@ package radar
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -12,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
@@ -19,42 +21,60 @@ import (
 
 var GlobalResourceCheckTimeout time.Duration
 
+// ATCURL identifies the ATC running the checks, recorded with each check.
+var ATCURL string
+
 type resourceScanner struct {
 	clock                 clock.Clock
 	resourceFactory       resource.ResourceFactory
 	resourceConfigFactory db.ResourceConfigFactory
+	checkRateLimiter      db.CheckRateLimiter
 	defaultInterval       time.Duration
 	dbPipeline            db.Pipeline
 	externalURL           string
 	variables             creds.Variables
+	baseDefaults          atc.ResourceTypeDefaults
 }
 
 func NewResourceScanner(
 	clock clock.Clock,
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	checkRateLimiter db.CheckRateLimiter,
 	defaultInterval time.Duration,
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables creds.Variables,
+	baseDefaults atc.ResourceTypeDefaults,
 ) Scanner {
 	return &resourceScanner{
 		clock:                 clock,
 		resourceFactory:       resourceFactory,
 		resourceConfigFactory: resourceConfigFactory,
+		checkRateLimiter:      checkRateLimiter,
 		defaultInterval:       defaultInterval,
 		dbPipeline:            dbPipeline,
 		externalURL:           externalURL,
 		variables:             variables,
+		baseDefaults:          baseDefaults,
 	}
 }
 
 var ErrFailedToAcquireLock = errors.New("failed to acquire lock")
+
+// ErrCheckLockContended is returned by a periodic check when another ATC
+// holds the checking lock, rather than waiting for the lock to be released.
+var ErrCheckLockContended = errors.New("check lock held by another ATC")
+
+// ErrCheckNotDue is returned by a periodic check when the check interval has
+// not elapsed since the last check, e.g. when another ATC has just run it.
+var ErrCheckNotDue = errors.New("check not due")
 var ErrResourceTypeNotFound = errors.New("resource type not found")
 var ErrResourceTypeCheckError = errors.New("resource type failed to check")
+var ErrCheckRateLimited = errors.New("check rate limited")
 
 func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (time.Duration, error) {
-	interval, err := scanner.scan(logger.Session("tick"), resourceName, nil, false, false)
+	interval, err := scanner.scan(logger.Session("tick"), resourceName, nil, nil, false, false, nil)
 
 	err = swallowErrResourceScriptFailed(err)
 
@@ -62,20 +82,36 @@ func (scanner *resourceScanner) Run(logger lager.Logger, resourceName string) (t
 }
 
 func (scanner *resourceScanner) ScanFromVersion(logger lager.Logger, resourceName string, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceName, fromVersion, true, true)
+	_, err := scanner.scan(logger, resourceName, fromVersion, nil, true, true, nil)
+
+	return err
+}
+
+// ScanFromVersionNotifying is ScanFromVersion, sending the check it records,
+// if any, on the given channel as soon as the check has been created.
+func (scanner *resourceScanner) ScanFromVersionNotifying(logger lager.Logger, resourceName string, fromVersion atc.Version, created chan<- db.Check) error {
+	_, err := scanner.scan(logger, resourceName, fromVersion, nil, true, true, created)
+
+	return err
+}
+
+// ScanFromPayload is ScanFromVersion, additionally passing the payload
+// received by the resource's webhook to its check script.
+func (scanner *resourceScanner) ScanFromPayload(logger lager.Logger, resourceName string, fromVersion atc.Version, payload json.RawMessage) error {
+	_, err := scanner.scan(logger, resourceName, fromVersion, payload, true, true, nil)
 
 	return err
 }
 
 func (scanner *resourceScanner) Scan(logger lager.Logger, resourceName string) error {
-	_, err := scanner.scan(logger, resourceName, nil, true, false)
+	_, err := scanner.scan(logger, resourceName, nil, nil, true, false, nil)
 
 	err = swallowErrResourceScriptFailed(err)
 
 	return err
 }
 
-func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, fromVersion atc.Version, mustComplete bool, saveGiven bool) (time.Duration, error) {
+func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, fromVersion atc.Version, payload json.RawMessage, mustComplete bool, saveGiven bool, created chan<- db.Check) (time.Duration, error) {
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource": resourceName,
 	})
@@ -150,10 +186,10 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 
 	versionedResourceTypes := creds.NewVersionedResourceTypes(
 		scanner.variables,
-		resourceTypes.Deserialize(),
+		resourceTypes.Deserialize().WithDefaults(scanner.baseDefaults),
 	)
 
-	source, err := creds.NewSource(scanner.variables, savedResource.Source()).Evaluate()
+	source, err := creds.NewSource(scanner.variables, scanner.baseDefaults.Apply(savedResource.Type(), savedResource.SourceWithDefaults())).Evaluate()
 	if err != nil {
 		logger.Error("failed-to-evaluate-resource-source", err)
 		scanner.setResourceCheckError(logger, savedResource, err)
@@ -209,12 +245,63 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 
 		if !acquired {
 			lockLogger.Debug("did-not-get-lock")
+
+			// only checks which were asked for wait for the lock; a periodic
+			// check is already being run by whoever holds it
+			if !mustComplete {
+				return interval, ErrCheckLockContended
+			}
+
 			scanner.clock.Sleep(time.Second)
 			continue
 		}
 
 		defer lock.Release()
 
+		// only periodic checks are deferred; checks which were asked for run
+		// regardless of the rate limit. A token is only taken once the check
+		// is known to be due, so that ATCs which find it already done by
+		// another do not use up the limit.
+		if !mustComplete {
+			due, err := resourceConfigScope.CheckDue(interval)
+			if err != nil {
+				return interval, err
+			}
+
+			if !due {
+				logger.Debug("interval-not-reached", lager.Data{
+					"interval": interval,
+				})
+				return interval, ErrCheckNotDue
+			}
+
+			allowed, err := scanner.checkRateLimiter.Allow(savedResource.Type())
+			if err != nil {
+				logger.Error("failed-to-check-rate-limit", err)
+				return interval, err
+			}
+
+			if !allowed {
+				logger.Debug("check-rate-limited", lager.Data{
+					"resource-type": savedResource.Type(),
+				})
+
+				metric.CheckRateLimited{
+					PipelineName: scanner.dbPipeline.Name(),
+					ResourceName: savedResource.Name(),
+					ResourceType: savedResource.Type(),
+					TeamName:     scanner.dbPipeline.TeamName(),
+				}.Emit(logger)
+
+				err := savedResource.CreateRateLimitedCheck(ATCURL)
+				if err != nil {
+					logger.Error("failed-to-create-rate-limited-check", err)
+				}
+
+				return interval, ErrCheckRateLimited
+			}
+		}
+
 		updated, err := resourceConfigScope.UpdateLastChecked(interval, mustComplete)
 		if err != nil {
 			return interval, err
@@ -224,7 +311,7 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 			logger.Debug("interval-not-reached", lager.Data{
 				"interval": interval,
 			})
-			return interval, ErrFailedToAcquireLock
+			return interval, ErrCheckNotDue
 		}
 
 		break
@@ -247,10 +334,12 @@ func (scanner *resourceScanner) scan(logger lager.Logger, resourceName string, f
 		savedResource,
 		resourceConfigScope,
 		fromVersion,
+		payload,
 		versionedResourceTypes,
 		source,
 		saveGiven,
 		timeout,
+		created,
 	)
 }
 
@@ -259,10 +348,12 @@ func (scanner *resourceScanner) check(
 	savedResource db.Resource,
 	resourceConfigScope db.ResourceConfigScope,
 	fromVersion atc.Version,
+	payload json.RawMessage,
 	resourceTypes creds.VersionedResourceTypes,
 	source atc.Source,
 	saveGiven bool,
 	timeout time.Duration,
+	created chan<- db.Check,
 ) error {
 	pipelinePaused, err := scanner.dbPipeline.CheckPaused()
 	if err != nil {
@@ -285,6 +376,58 @@ func (scanner *resourceScanner) check(
 		return errPipelineRemoved
 	}
 
+	dbCheck, err := savedResource.CreateCheck(ATCURL)
+	if err != nil {
+		logger.Error("failed-to-create-check", err)
+		return err
+	}
+
+	if created != nil {
+		created <- dbCheck
+	}
+
+	newVersions, err := scanner.runCheck(
+		logger,
+		dbCheck,
+		savedResource,
+		resourceConfigScope,
+		fromVersion,
+		payload,
+		resourceTypes,
+		source,
+		saveGiven,
+		timeout,
+	)
+
+	checkErr := err
+	if scriptErr, ok := err.(resource.ErrResourceScriptFailed); ok {
+		// the script's stderr has already been saved as log events
+		scriptErr.Stderr = ""
+		checkErr = scriptErr
+	}
+
+	finishErr := dbCheck.Finish(newVersions, checkErr)
+	if finishErr != nil {
+		logger.Error("failed-to-finish-check", finishErr)
+	}
+
+	return err
+}
+
+// runCheck runs the resource's check script, saving its stderr as events of
+// the given check, and returns the number of new versions saved.
+func (scanner *resourceScanner) runCheck(
+	logger lager.Logger,
+	dbCheck db.Check,
+	savedResource db.Resource,
+	resourceConfigScope db.ResourceConfigScope,
+	fromVersion atc.Version,
+	payload json.RawMessage,
+	resourceTypes creds.VersionedResourceTypes,
+	source atc.Source,
+	saveGiven bool,
+	timeout time.Duration,
+) (int, error) {
 	metadata := resource.TrackerMetadata{
 		ResourceName: savedResource.Name(),
 		PipelineName: savedResource.PipelineName(),
@@ -301,10 +444,11 @@ func (scanner *resourceScanner) check(
 	}
 
 	workerSpec := worker.WorkerSpec{
-		ResourceType:  savedResource.Type(),
-		Tags:          savedResource.Tags(),
-		ResourceTypes: resourceTypes,
-		TeamID:        scanner.dbPipeline.TeamID(),
+		ResourceType:   savedResource.Type(),
+		Tags:           savedResource.Tags(),
+		WorkerSelector: savedResource.WorkerSelector(),
+		ResourceTypes:  resourceTypes,
+		TeamID:         scanner.dbPipeline.TeamID(),
 	}
 
 	res, err := scanner.resourceFactory.NewResource(
@@ -326,7 +470,14 @@ func (scanner *resourceScanner) check(
 		if chkErr != nil {
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
 		}
-		return err
+		return 0, err
+	}
+
+	if container := res.Container(); container != nil {
+		err = dbCheck.SetWorkerName(container.WorkerName())
+		if err != nil {
+			logger.Error("failed-to-set-check-worker-name", err)
+		}
 	}
 
 	logger.Debug("checking", lager.Data{
@@ -336,7 +487,12 @@ func (scanner *resourceScanner) check(
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	newVersions, err := res.Check(ctx, source, fromVersion)
+	newVersions, err := res.Check(ctx, resource.IOConfig{
+		Stderr: checkEventWriter{
+			check:  dbCheck,
+			origin: event.Origin{Source: event.OriginSourceStderr},
+		},
+	}, source, fromVersion, payload)
 	if err == context.DeadlineExceeded {
 		err = fmt.Errorf("Timed out after %v while checking for new versions - perhaps increase your resource check timeout?", timeout)
 	}
@@ -352,16 +508,16 @@ func (scanner *resourceScanner) check(
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
-			return rErr
+			return 0, rErr
 		}
 
 		logger.Error("failed-to-check", err)
-		return err
+		return 0, err
 	}
 
 	if len(newVersions) == 0 || (!saveGiven && reflect.DeepEqual(newVersions, []atc.Version{fromVersion})) {
 		logger.Debug("no-new-versions")
-		return nil
+		return 0, nil
 	}
 
 	logger.Info("versions-found", lager.Data{
@@ -376,7 +532,26 @@ func (scanner *resourceScanner) check(
 		})
 	}
 
-	return nil
+	return len(newVersions), nil
+}
+
+// checkEventWriter saves everything written to it as log events of a check.
+type checkEventWriter struct {
+	check  db.Check
+	origin event.Origin
+}
+
+func (writer checkEventWriter) Write(data []byte) (int, error) {
+	err := writer.check.SaveEvent(event.Log{
+		Time:    time.Now().Unix(),
+		Payload: string(data),
+		Origin:  writer.origin,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
 }
 
 func swallowErrResourceScriptFailed(err error) error {
@@ -2,7 +2,9 @@ package radar_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	"code.cloudfoundry.org/clock/fakeclock"
@@ -15,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/worker"
 
@@ -31,6 +34,7 @@ var _ = Describe("ResourceScanner", func() {
 
 		fakeResourceFactory       *rfakes.FakeResourceFactory
 		fakeResourceConfigFactory *dbfakes.FakeResourceConfigFactory
+		fakeCheckRateLimiter      *dbfakes.FakeCheckRateLimiter
 		fakeDBPipeline            *dbfakes.FakePipeline
 		fakeClock                 *fakeclock.FakeClock
 		interval                  time.Duration
@@ -45,6 +49,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeDBResource          *dbfakes.FakeResource
 		fakeResourceConfig      *dbfakes.FakeResourceConfig
 		fakeResourceConfigScope *dbfakes.FakeResourceConfigScope
+		fakeCheck               *dbfakes.FakeCheck
 
 		fakeLock *lockfakes.FakeLock
 		teamID   = 123
@@ -77,6 +82,8 @@ var _ = Describe("ResourceScanner", func() {
 
 		fakeResourceFactory = new(rfakes.FakeResourceFactory)
 		fakeResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
+		fakeCheckRateLimiter = new(dbfakes.FakeCheckRateLimiter)
+		fakeCheckRateLimiter.AllowReturns(true, nil)
 		fakeResourceType = new(dbfakes.FakeResourceType)
 		fakeDBResource = new(dbfakes.FakeResource)
 		fakeDBPipeline = new(dbfakes.FakePipeline)
@@ -85,6 +92,7 @@ var _ = Describe("ResourceScanner", func() {
 		fakeResourceConfigScope = new(dbfakes.FakeResourceConfigScope)
 		fakeResourceConfigScope.IDReturns(456)
 		fakeResourceConfigScope.ResourceConfigReturns(fakeResourceConfig)
+		fakeResourceConfigScope.CheckDueReturns(true, nil)
 
 		fakeDBPipeline.IDReturns(42)
 		fakeDBPipeline.NameReturns("some-pipeline")
@@ -97,27 +105,33 @@ var _ = Describe("ResourceScanner", func() {
 		fakeResourceType.IDReturns(1)
 		fakeResourceType.NameReturns("some-custom-resource")
 		fakeResourceType.TypeReturns("registry-image")
-		fakeResourceType.SourceReturns(atc.Source{"custom": "((source-params))"})
+		fakeResourceType.SourceWithDefaultsReturns(atc.Source{"custom": "((source-params))"})
 		fakeResourceType.VersionReturns(atc.Version{"custom": "version"})
 
 		fakeDBResource.IDReturns(39)
 		fakeDBResource.NameReturns("some-resource")
 		fakeDBResource.PipelineNameReturns("some-pipeline")
 		fakeDBResource.TypeReturns("git")
-		fakeDBResource.SourceReturns(atc.Source{"uri": "((source-params))"})
+		fakeDBResource.SourceWithDefaultsReturns(atc.Source{"uri": "((source-params))"})
 		fakeDBResource.TagsReturns(atc.Tags{"some-tag"})
+		fakeDBResource.WorkerSelectorReturns("zone=us-east")
 		fakeDBResource.SetResourceConfigReturns(fakeResourceConfigScope, nil)
 
+		fakeCheck = new(dbfakes.FakeCheck)
+		fakeDBResource.CreateCheckReturns(fakeCheck, nil)
+
 		fakeDBPipeline.ResourceReturns(fakeDBResource, true, nil)
 
 		scanner = NewResourceScanner(
 			fakeClock,
 			fakeResourceFactory,
 			fakeResourceConfigFactory,
+			fakeCheckRateLimiter,
 			interval,
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
+			nil,
 		)
 	})
 
@@ -139,37 +153,15 @@ var _ = Describe("ResourceScanner", func() {
 
 		Context("when the lock cannot be acquired", func() {
 			BeforeEach(func() {
-				results := make(chan bool, 4)
-				results <- false
-				results <- false
-				results <- true
-				results <- true
-				close(results)
-
-				fakeResourceConfigScope.AcquireResourceCheckingLockStub = func(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
-					if <-results {
-						return fakeLock, true, nil
-					} else {
-						// allow the sleep to continue
-						go fakeClock.WaitForWatcherAndIncrement(time.Second)
-						return nil, false, nil
-					}
-				}
+				fakeResourceConfigScope.AcquireResourceCheckingLockReturns(nil, false, nil)
 			})
 
-			It("retries every second until it is", func() {
-				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(3))
-
-				_, leaseInterval := fakeResourceConfigScope.AcquireResourceCheckingLockArgsForCall(0)
-				Expect(leaseInterval).To(Equal(interval))
-
-				_, leaseInterval = fakeResourceConfigScope.AcquireResourceCheckingLockArgsForCall(1)
-				Expect(leaseInterval).To(Equal(interval))
-
-				_, leaseInterval = fakeResourceConfigScope.AcquireResourceCheckingLockArgsForCall(2)
-				Expect(leaseInterval).To(Equal(interval))
+			It("returns that the lock is contended without waiting for it", func() {
+				Expect(runErr).To(Equal(ErrCheckLockContended))
+				Expect(actualInterval).To(Equal(interval))
 
-				Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(1))
+				Expect(fakeClock.WatcherCount()).To(BeZero())
 			})
 		})
 
@@ -178,6 +170,65 @@ var _ = Describe("ResourceScanner", func() {
 				fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
 			})
 
+			Context("when the resource type's check rate limit is exceeded", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.UpdateLastCheckedReturns(true, nil)
+					fakeCheckRateLimiter.AllowReturns(false, nil)
+				})
+
+				It("consults the rate limit of the resource's type", func() {
+					Expect(fakeCheckRateLimiter.AllowCallCount()).To(Equal(1))
+					Expect(fakeCheckRateLimiter.AllowArgsForCall(0)).To(Equal("git"))
+				})
+
+				It("defers the check", func() {
+					Expect(fakeResourceConfigScope.UpdateLastCheckedCallCount()).To(Equal(0))
+					Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					Expect(runErr).To(Equal(ErrCheckRateLimited))
+				})
+
+				It("records a rate limited check", func() {
+					Expect(fakeDBResource.CreateRateLimitedCheckCallCount()).To(Equal(1))
+					Expect(fakeDBResource.CreateCheckCallCount()).To(Equal(0))
+				})
+
+				It("releases the lock", func() {
+					Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the check has already been run by another ATC", func() {
+				BeforeEach(func() {
+					fakeResourceConfigScope.CheckDueReturns(false, nil)
+				})
+
+				It("asks whether the check is due with the interval", func() {
+					Expect(fakeResourceConfigScope.CheckDueCallCount()).To(Equal(1))
+					Expect(fakeResourceConfigScope.CheckDueArgsForCall(0)).To(Equal(interval))
+				})
+
+				It("does not take a token from the rate limit", func() {
+					Expect(fakeCheckRateLimiter.AllowCallCount()).To(Equal(0))
+				})
+
+				It("does not check", func() {
+					Expect(fakeResourceConfigScope.UpdateLastCheckedCallCount()).To(Equal(0))
+					Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					Expect(runErr).To(Equal(ErrCheckNotDue))
+				})
+			})
+
+			Context("when the rate limit fails to be consulted", func() {
+				BeforeEach(func() {
+					fakeCheckRateLimiter.AllowReturns(false, errors.New("nope"))
+				})
+
+				It("does not check", func() {
+					Expect(fakeResource.CheckCallCount()).To(Equal(0))
+					Expect(runErr).To(Equal(errors.New("nope")))
+				})
+			})
+
 			Context("when the last checked is not able to be updated", func() {
 				BeforeEach(func() {
 					fakeResourceConfigScope.UpdateLastCheckedReturns(false, nil)
@@ -188,7 +239,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("returns the configured interval", func() {
-					Expect(runErr).To(Equal(ErrFailedToAcquireLock))
+					Expect(runErr).To(Equal(ErrCheckNotDue))
 					Expect(actualInterval).To(Equal(interval))
 				})
 			})
@@ -213,6 +264,36 @@ var _ = Describe("ResourceScanner", func() {
 					fakeResourceConfigScope.UpdateLastCheckedReturns(true, nil)
 				})
 
+				Context("when the ATC configures resource type defaults", func() {
+					BeforeEach(func() {
+						scanner = NewResourceScanner(
+							fakeClock,
+							fakeResourceFactory,
+							fakeResourceConfigFactory,
+							fakeCheckRateLimiter,
+							interval,
+							fakeDBPipeline,
+							"https://www.example.com",
+							variables,
+							atc.ResourceTypeDefaults{
+								"git":            atc.Source{"branch": "master"},
+								"registry-image": atc.Source{"mirror": "some-mirror"},
+							},
+						)
+					})
+
+					It("merges them beneath the sources of the resource and its resource types", func() {
+						Expect(fakeDBResource.SetResourceConfigCallCount()).To(Equal(1))
+						_, resourceSource, resourceTypes := fakeDBResource.SetResourceConfigArgsForCall(0)
+						Expect(resourceSource).To(Equal(atc.Source{"uri": "some-secret-sauce", "branch": "master"}))
+
+						versionedResourceType.Source = atc.Source{"custom": "((source-params))", "mirror": "some-mirror"}
+						Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
+							versionedResourceType,
+						})))
+					})
+				})
+
 				It("checks immediately", func() {
 					Expect(fakeResource.CheckCallCount()).To(Equal(1))
 				})
@@ -247,10 +328,11 @@ var _ = Describe("ResourceScanner", func() {
 						},
 					}))
 					Expect(workerSpec).To(Equal(worker.WorkerSpec{
-						ResourceType:  "git",
-						Tags:          atc.Tags{"some-tag"},
-						ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
-						TeamID:        123,
+						ResourceType:   "git",
+						Tags:           atc.Tags{"some-tag"},
+						WorkerSelector: "zone=us-east",
+						ResourceTypes:  creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
+						TeamID:         123,
 					}))
 					Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 						versionedResourceType,
@@ -399,7 +481,7 @@ var _ = Describe("ResourceScanner", func() {
 
 				Context("when there is no current version", func() {
 					It("checks from nil", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(BeNil())
 					})
 				})
@@ -414,7 +496,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("checks from it", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "1"}))
 					})
 				})
@@ -438,7 +520,7 @@ var _ = Describe("ResourceScanner", func() {
 						}
 
 						check := 0
-						fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+						fakeResource.CheckStub = func(ctx context.Context, _ resource.IOConfig, source atc.Source, from atc.Version, _ json.RawMessage) ([]atc.Version, error) {
 							defer GinkgoRecover()
 
 							Expect(source).To(Equal(resourceConfig.Source))
@@ -572,12 +654,63 @@ var _ = Describe("ResourceScanner", func() {
 			scanErr = scanner.Scan(lagertest.NewTestLogger("test"), "some-resource")
 		})
 
+		Context("when the lock cannot be acquired", func() {
+			BeforeEach(func() {
+				fakeResourceConfigScope.UpdateLastCheckedReturns(true, nil)
+
+				results := make(chan bool, 4)
+				results <- false
+				results <- false
+				results <- true
+				results <- true
+				close(results)
+
+				fakeResourceConfigScope.AcquireResourceCheckingLockStub = func(logger lager.Logger, interval time.Duration) (lock.Lock, bool, error) {
+					if <-results {
+						return fakeLock, true, nil
+					} else {
+						// allow the sleep to continue
+						go fakeClock.WaitForWatcherAndIncrement(time.Second)
+						return nil, false, nil
+					}
+				}
+			})
+
+			It("retries every second until it is", func() {
+				Expect(scanErr).ToNot(HaveOccurred())
+
+				Expect(fakeResourceConfigScope.AcquireResourceCheckingLockCallCount()).To(Equal(3))
+
+				_, leaseInterval := fakeResourceConfigScope.AcquireResourceCheckingLockArgsForCall(0)
+				Expect(leaseInterval).To(Equal(interval))
+
+				_, leaseInterval = fakeResourceConfigScope.AcquireResourceCheckingLockArgsForCall(1)
+				Expect(leaseInterval).To(Equal(interval))
+
+				_, leaseInterval = fakeResourceConfigScope.AcquireResourceCheckingLockArgsForCall(2)
+				Expect(leaseInterval).To(Equal(interval))
+
+				Expect(fakeLock.ReleaseCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("if the lock can be acquired and last checked updated", func() {
 			BeforeEach(func() {
 				fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
 				fakeResourceConfigScope.UpdateLastCheckedReturns(true, nil)
 			})
 
+			Context("when the resource type's check rate limit is exceeded", func() {
+				BeforeEach(func() {
+					fakeCheckRateLimiter.AllowReturns(false, nil)
+				})
+
+				It("checks regardless", func() {
+					Expect(fakeCheckRateLimiter.AllowCallCount()).To(Equal(0))
+					Expect(fakeResource.CheckCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("Parent resource has no version and check fails", func() {
 				BeforeEach(func() {
 					var fakeGitResourceType *dbfakes.FakeResourceType
@@ -588,7 +721,7 @@ var _ = Describe("ResourceScanner", func() {
 					fakeGitResourceType.IDReturns(5)
 					fakeGitResourceType.NameReturns("git")
 					fakeGitResourceType.TypeReturns("registry-image")
-					fakeGitResourceType.SourceReturns(atc.Source{"custom": "((source-params))"})
+					fakeGitResourceType.SourceWithDefaultsReturns(atc.Source{"custom": "((source-params))"})
 					fakeGitResourceType.VersionReturns(nil)
 					fakeGitResourceType.CheckErrorReturns(errors.New("oops"))
 				})
@@ -617,7 +750,7 @@ var _ = Describe("ResourceScanner", func() {
 					fakeGitResourceType.IDReturns(5)
 					fakeGitResourceType.NameReturns("git")
 					fakeGitResourceType.TypeReturns("registry-image")
-					fakeGitResourceType.SourceReturns(atc.Source{"custom": "((source-params))"})
+					fakeGitResourceType.SourceWithDefaultsReturns(atc.Source{"custom": "((source-params))"})
 					fakeGitResourceType.VersionReturns(atc.Version{"version": "1"})
 					fakeGitResourceType.CheckErrorReturns(errors.New("oops"))
 				})
@@ -661,10 +794,11 @@ var _ = Describe("ResourceScanner", func() {
 					},
 				}))
 				Expect(workerSpec).To(Equal(worker.WorkerSpec{
-					ResourceType:  "git",
-					Tags:          atc.Tags{"some-tag"},
-					ResourceTypes: creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
-					TeamID:        123,
+					ResourceType:   "git",
+					Tags:           atc.Tags{"some-tag"},
+					WorkerSelector: "zone=us-east",
+					ResourceTypes:  creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{versionedResourceType}),
+					TeamID:         123,
 				}))
 				Expect(resourceTypes).To(Equal(creds.NewVersionedResourceTypes(variables, atc.VersionedResourceTypes{
 					versionedResourceType,
@@ -757,7 +891,7 @@ var _ = Describe("ResourceScanner", func() {
 
 				It("times out after the specified timeout", func() {
 					now := time.Now()
-					ctx, _, _ := fakeResource.CheckArgsForCall(0)
+					ctx, _, _, _, _ := fakeResource.CheckArgsForCall(0)
 					deadline, _ := ctx.Deadline()
 					Expect(deadline).Should(BeTemporally("~", now.Add(10*time.Second), time.Second))
 				})
@@ -807,7 +941,7 @@ var _ = Describe("ResourceScanner", func() {
 					})
 
 					It("checks from the pinned version", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "1"}))
 					})
 				})
@@ -839,7 +973,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -872,7 +1006,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
@@ -906,7 +1040,7 @@ var _ = Describe("ResourceScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, _ resource.IOConfig, source atc.Source, from atc.Version, _ json.RawMessage) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(resourceConfig.Source))
@@ -929,6 +1063,32 @@ var _ = Describe("ResourceScanner", func() {
 						{"version": "3"},
 					}))
 				})
+
+				It("records the check as succeeded", func() {
+					Expect(fakeDBResource.CreateCheckCallCount()).To(Equal(1))
+
+					Expect(fakeCheck.FinishCallCount()).To(Equal(1))
+					newVersions, checkErr := fakeCheck.FinishArgsForCall(0)
+					Expect(newVersions).To(Equal(3))
+					Expect(checkErr).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when the check writes to stderr", func() {
+				BeforeEach(func() {
+					fakeResource.CheckStub = func(ctx context.Context, ioConfig resource.IOConfig, source atc.Source, from atc.Version, _ json.RawMessage) ([]atc.Version, error) {
+						fmt.Fprint(ioConfig.Stderr, "some output")
+						return nil, nil
+					}
+				})
+
+				It("saves it as a log event of the check", func() {
+					Expect(fakeCheck.SaveEventCallCount()).To(Equal(1))
+
+					ev := fakeCheck.SaveEventArgsForCall(0).(event.Log)
+					Expect(ev.Payload).To(Equal("some output"))
+					Expect(ev.Origin.Source).To(Equal(event.OriginSourceStderr))
+				})
 			})
 
 			Context("when checking fails internally", func() {
@@ -948,6 +1108,12 @@ var _ = Describe("ResourceScanner", func() {
 					err := fakeResourceConfigScope.SetCheckErrorArgsForCall(0)
 					Expect(err).To(Equal(disaster))
 				})
+
+				It("records the check as errored", func() {
+					Expect(fakeCheck.FinishCallCount()).To(Equal(1))
+					_, checkErr := fakeCheck.FinishArgsForCall(0)
+					Expect(checkErr).To(Equal(disaster))
+				})
 			})
 
 			Context("when checking fails with ErrResourceScriptFailed", func() {
@@ -997,7 +1163,7 @@ var _ = Describe("ResourceScanner", func() {
 
 			Context("when fromVersion is nil", func() {
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -1010,7 +1176,7 @@ var _ = Describe("ResourceScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
@@ -1051,4 +1217,43 @@ var _ = Describe("ResourceScanner", func() {
 			})
 		})
 	})
+
+	Describe("ScanFromVersionNotifying", func() {
+		BeforeEach(func() {
+			fakeResourceFactory.NewResourceReturns(new(rfakes.FakeResource), nil)
+
+			fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
+			fakeResourceConfigScope.UpdateLastCheckedReturns(true, nil)
+		})
+
+		It("sends the check it records", func() {
+			created := make(chan db.Check, 1)
+
+			err := scanner.ScanFromVersionNotifying(lagertest.NewTestLogger("test"), "some-resource", nil, created)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(created).To(Receive(Equal(fakeCheck)))
+		})
+	})
+
+	Describe("ScanFromPayload", func() {
+		var fakeResource *rfakes.FakeResource
+
+		BeforeEach(func() {
+			fakeResource = new(rfakes.FakeResource)
+			fakeResourceFactory.NewResourceReturns(fakeResource, nil)
+
+			fakeResourceConfigScope.AcquireResourceCheckingLockReturns(fakeLock, true, nil)
+			fakeResourceConfigScope.UpdateLastCheckedReturns(true, nil)
+		})
+
+		It("passes the payload to the check", func() {
+			err := scanner.ScanFromPayload(lagertest.NewTestLogger("test"), "some-resource", atc.Version{"version": "1"}, json.RawMessage(`{"ref":"refs/heads/master"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, version, payload := fakeResource.CheckArgsForCall(0)
+			Expect(version).To(Equal(atc.Version{"version": "1"}))
+			Expect(payload).To(MatchJSON(`{"ref":"refs/heads/master"}`))
+		})
+	})
 })
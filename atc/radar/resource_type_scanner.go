@@ -2,6 +2,7 @@ package radar
 
 import (
 	"context"
+	"encoding/json"
 	"reflect"
 	"time"
 
@@ -22,6 +23,11 @@ type resourceTypeScanner struct {
 	dbPipeline            db.Pipeline
 	externalURL           string
 	variables             creds.Variables
+	baseDefaults          atc.ResourceTypeDefaults
+
+	// dependentScanner schedules everything using the resource type once it
+	// has a new version; nil leaves them to be checked on their interval
+	dependentScanner DependentScanner
 }
 
 func NewResourceTypeScanner(
@@ -32,6 +38,8 @@ func NewResourceTypeScanner(
 	dbPipeline db.Pipeline,
 	externalURL string,
 	variables creds.Variables,
+	baseDefaults atc.ResourceTypeDefaults,
+	dependentScanner DependentScanner,
 ) Scanner {
 	return &resourceTypeScanner{
 		clock:                 clock,
@@ -41,24 +49,55 @@ func NewResourceTypeScanner(
 		dbPipeline:            dbPipeline,
 		externalURL:           externalURL,
 		variables:             variables,
+		baseDefaults:          baseDefaults,
+		dependentScanner:      dependentScanner,
 	}
 }
 
 func (scanner *resourceTypeScanner) Run(logger lager.Logger, resourceTypeName string) (time.Duration, error) {
-	return scanner.scan(logger.Session("tick"), resourceTypeName, nil, false, false)
+	return scanner.scan(logger.Session("tick"), resourceTypeName, nil, nil, false, false)
 }
 
 func (scanner *resourceTypeScanner) ScanFromVersion(logger lager.Logger, resourceTypeName string, fromVersion atc.Version) error {
-	_, err := scanner.scan(logger, resourceTypeName, fromVersion, true, true)
+	_, err := scanner.scan(logger, resourceTypeName, fromVersion, nil, true, true)
+	return err
+}
+
+// ScanFromVersionNotifying is ScanFromVersion; checks of resource types are not
+// recorded, so nothing is ever sent on the channel.
+func (scanner *resourceTypeScanner) ScanFromVersionNotifying(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, created chan<- db.Check) error {
+	return scanner.ScanFromVersion(logger, resourceTypeName, fromVersion)
+}
+
+func (scanner *resourceTypeScanner) ScanFromPayload(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, payload json.RawMessage) error {
+	_, err := scanner.scan(logger, resourceTypeName, fromVersion, payload, true, true)
 	return err
 }
 
 func (scanner *resourceTypeScanner) Scan(logger lager.Logger, resourceTypeName string) error {
-	_, err := scanner.scan(logger, resourceTypeName, nil, true, false)
+	_, err := scanner.scan(logger, resourceTypeName, nil, nil, true, false)
 	return err
 }
 
-func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, mustComplete bool, saveGiven bool) (time.Duration, error) {
+func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, payload json.RawMessage, mustComplete bool, saveGiven bool) (time.Duration, error) {
+	interval, newVersion, err := scanner.scanWithLock(logger, resourceTypeName, fromVersion, payload, mustComplete, saveGiven)
+	if err != nil {
+		return interval, err
+	}
+
+	if newVersion && scanner.dependentScanner != nil {
+		// the dependents are scheduled once the lock has been released; the
+		// resource type's check is done regardless of whether they could be
+		_, err := scanner.dependentScanner.ScheduleDependents(logger, resourceTypeName)
+		if err != nil {
+			logger.Error("failed-to-schedule-dependents", err)
+		}
+	}
+
+	return interval, nil
+}
+
+func (scanner *resourceTypeScanner) scanWithLock(logger lager.Logger, resourceTypeName string, fromVersion atc.Version, payload json.RawMessage, mustComplete bool, saveGiven bool) (time.Duration, bool, error) {
 	lockLogger := logger.Session("lock", lager.Data{
 		"resource-type": resourceTypeName,
 	})
@@ -66,23 +105,23 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 	savedResourceType, found, err := scanner.dbPipeline.ResourceType(resourceTypeName)
 	if err != nil {
 		logger.Error("failed-to-find-resource-type-in-db", err)
-		return 0, err
+		return 0, false, err
 	}
 
 	if !found {
-		return 0, db.ResourceTypeNotFoundError{Name: resourceTypeName}
+		return 0, false, db.ResourceTypeNotFoundError{Name: resourceTypeName}
 	}
 
 	interval, err := scanner.checkInterval(savedResourceType.CheckEvery())
 	if err != nil {
 		scanner.setCheckError(logger, savedResourceType, err)
-		return 0, err
+		return 0, false, err
 	}
 
 	resourceTypes, err := scanner.dbPipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resource-types", err)
-		return 0, err
+		return 0, false, err
 	}
 
 	for _, parentType := range resourceTypes {
@@ -99,26 +138,26 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		if err = scanner.Scan(logger, parentType.Name()); err != nil {
 			logger.Error("failed-to-scan-parent-resource-type-version", err)
 			scanner.setCheckError(logger, savedResourceType, err)
-			return 0, err
+			return 0, false, err
 		}
 	}
 
 	resourceTypes, err = scanner.dbPipeline.ResourceTypes()
 	if err != nil {
 		logger.Error("failed-to-get-resource-types", err)
-		return 0, err
+		return 0, false, err
 	}
 
 	versionedResourceTypes := creds.NewVersionedResourceTypes(
 		scanner.variables,
-		resourceTypes.Deserialize(),
+		resourceTypes.Deserialize().WithDefaults(scanner.baseDefaults),
 	)
 
-	source, err := creds.NewSource(scanner.variables, savedResourceType.Source()).Evaluate()
+	source, err := creds.NewSource(scanner.variables, scanner.baseDefaults.Apply(savedResourceType.Type(), savedResourceType.SourceWithDefaults())).Evaluate()
 	if err != nil {
 		logger.Error("failed-to-evaluate-resource-type-source", err)
 		scanner.setCheckError(logger, savedResourceType, err)
-		return 0, err
+		return 0, false, err
 	}
 
 	resourceConfigScope, err := savedResourceType.SetResourceConfig(
@@ -129,7 +168,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 	if err != nil {
 		logger.Error("failed-to-set-resource-config-id-on-resource-type", err)
 		scanner.setCheckError(logger, savedResourceType, err)
-		return 0, err
+		return 0, false, err
 	}
 
 	// Clear out the check error on the resource type
@@ -147,7 +186,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 				"resource-type":      resourceTypeName,
 				"resource-config-id": resourceConfigScope.ResourceConfig().ID(),
 			})
-			return interval, ErrFailedToAcquireLock
+			return interval, false, ErrFailedToAcquireLock
 		}
 
 		if !acquired {
@@ -156,7 +195,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 				scanner.clock.Sleep(time.Second)
 				continue
 			} else {
-				return interval, ErrFailedToAcquireLock
+				return interval, false, ErrCheckLockContended
 			}
 		}
 
@@ -168,7 +207,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 				"resource-type":      resourceTypeName,
 				"resource-config-id": resourceConfigScope.ResourceConfig().ID(),
 			})
-			return interval, ErrFailedToAcquireLock
+			return interval, false, ErrFailedToAcquireLock
 		}
 
 		if !updated {
@@ -177,7 +216,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 				scanner.clock.Sleep(time.Second)
 				continue
 			} else {
-				return interval, ErrFailedToAcquireLock
+				return interval, false, ErrCheckNotDue
 			}
 		}
 
@@ -188,7 +227,7 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		rcv, found, err := resourceConfigScope.LatestVersion()
 		if err != nil {
 			logger.Error("failed-to-get-current-version", err)
-			return interval, err
+			return interval, false, err
 		}
 
 		if found {
@@ -196,15 +235,21 @@ func (scanner *resourceTypeScanner) scan(logger lager.Logger, resourceTypeName s
 		}
 	}
 
-	return interval, scanner.check(
+	newVersion, err := scanner.check(
 		logger,
 		savedResourceType,
 		resourceConfigScope,
 		fromVersion,
+		payload,
 		versionedResourceTypes,
 		source,
 		saveGiven,
 	)
+	if err != nil {
+		return interval, false, err
+	}
+
+	return interval, newVersion, nil
 }
 
 func (scanner *resourceTypeScanner) check(
@@ -212,19 +257,20 @@ func (scanner *resourceTypeScanner) check(
 	savedResourceType db.ResourceType,
 	resourceConfigScope db.ResourceConfigScope,
 	fromVersion atc.Version,
+	payload json.RawMessage,
 	versionedResourceTypes creds.VersionedResourceTypes,
 	source atc.Source,
 	saveGiven bool,
-) error {
+) (bool, error) {
 	pipelinePaused, err := scanner.dbPipeline.CheckPaused()
 	if err != nil {
 		logger.Error("failed-to-check-if-pipeline-paused", err)
-		return err
+		return false, err
 	}
 
 	if pipelinePaused {
 		logger.Debug("pipeline-paused")
-		return nil
+		return false, nil
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -236,10 +282,11 @@ func (scanner *resourceTypeScanner) check(
 	}
 
 	workerSpec := worker.WorkerSpec{
-		ResourceType:  savedResourceType.Type(),
-		Tags:          savedResourceType.Tags(),
-		ResourceTypes: versionedResourceTypes.Without(savedResourceType.Name()),
-		TeamID:        scanner.dbPipeline.TeamID(),
+		ResourceType:   savedResourceType.Type(),
+		Tags:           savedResourceType.Tags(),
+		WorkerSelector: savedResourceType.WorkerSelector(),
+		ResourceTypes:  versionedResourceTypes.Without(savedResourceType.Name()),
+		TeamID:         scanner.dbPipeline.TeamID(),
 	}
 
 	res, err := scanner.resourceFactory.NewResource(
@@ -260,24 +307,24 @@ func (scanner *resourceTypeScanner) check(
 			logger.Error("failed-to-set-check-error-on-resource-config", chkErr)
 		}
 		logger.Error("failed-to-initialize-new-container", err)
-		return err
+		return false, err
 	}
 
-	newVersions, err := res.Check(context.TODO(), source, fromVersion)
+	newVersions, err := res.Check(context.TODO(), resource.IOConfig{}, source, fromVersion, payload)
 	resourceConfigScope.SetCheckError(err)
 	if err != nil {
 		if rErr, ok := err.(resource.ErrResourceScriptFailed); ok {
 			logger.Info("check-failed", lager.Data{"exit-status": rErr.ExitStatus})
-			return rErr
+			return false, rErr
 		}
 
 		logger.Error("failed-to-check", err)
-		return err
+		return false, err
 	}
 
 	if len(newVersions) == 0 || (!saveGiven && reflect.DeepEqual(newVersions, []atc.Version{fromVersion})) {
 		logger.Debug("no-new-versions")
-		return nil
+		return false, nil
 	}
 
 	logger.Info("versions-found", lager.Data{
@@ -285,15 +332,31 @@ func (scanner *resourceTypeScanner) check(
 		"total":    len(newVersions),
 	})
 
+	latestVersion, hadVersion, err := resourceConfigScope.LatestVersion()
+	if err != nil {
+		logger.Error("failed-to-get-latest-version", err)
+		return false, err
+	}
+
 	err = resourceConfigScope.SaveVersions(newVersions)
 	if err != nil {
 		logger.Error("failed-to-save-resource-config-versions", err, lager.Data{
 			"versions": newVersions,
 		})
-		return err
+		return false, err
+	}
+
+	newLatestVersion, found, err := resourceConfigScope.LatestVersion()
+	if err != nil {
+		logger.Error("failed-to-get-latest-version", err)
+		return false, err
+	}
+
+	if !found || (hadVersion && latestVersion.ID() == newLatestVersion.ID()) {
+		return false, nil
 	}
 
-	return nil
+	return true, nil
 }
 
 func (scanner *resourceTypeScanner) checkInterval(checkEvery string) (time.Duration, error) {
@@ -2,6 +2,7 @@ package radar_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	. "github.com/concourse/concourse/atc/radar"
+	"github.com/concourse/concourse/atc/radar/radarfakes"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 
@@ -40,6 +42,9 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeResourceType      *dbfakes.FakeResourceType
 		versionedResourceType atc.VersionedResourceType
 
+		fakeDependentScanner *radarfakes.FakeDependentScanner
+		dependentScanner     DependentScanner
+
 		scanner Scanner
 
 		fakeLock *lockfakes.FakeLock
@@ -59,6 +64,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 				Type:   "registry-image",
 				Source: atc.Source{"custom": "((source-params))"},
 				Tags:   atc.Tags{"some-tag"},
+
+				WorkerSelector: "zone=us-east",
 			},
 			Version: atc.Version{"custom": "version"},
 		}
@@ -78,9 +85,10 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeResourceType.IDReturns(39)
 		fakeResourceType.NameReturns("some-custom-resource")
 		fakeResourceType.TypeReturns("registry-image")
-		fakeResourceType.SourceReturns(atc.Source{"custom": "((source-params))"})
+		fakeResourceType.SourceWithDefaultsReturns(atc.Source{"custom": "((source-params))"})
 		fakeResourceType.VersionReturns(atc.Version{"custom": "version"})
 		fakeResourceType.TagsReturns(atc.Tags{"some-tag"})
+		fakeResourceType.WorkerSelectorReturns("zone=us-east")
 		fakeResourceType.SetResourceConfigReturns(fakeResourceConfigScope, nil)
 
 		fakeDBPipeline.IDReturns(42)
@@ -90,6 +98,11 @@ var _ = Describe("ResourceTypeScanner", func() {
 		fakeDBPipeline.ResourceTypesReturns([]db.ResourceType{fakeResourceType}, nil)
 		fakeDBPipeline.ResourceTypeReturns(fakeResourceType, true, nil)
 
+		fakeDependentScanner = new(radarfakes.FakeDependentScanner)
+		dependentScanner = nil
+	})
+
+	JustBeforeEach(func() {
 		scanner = NewResourceTypeScanner(
 			fakeClock,
 			fakeResourceFactory,
@@ -98,6 +111,8 @@ var _ = Describe("ResourceTypeScanner", func() {
 			fakeDBPipeline,
 			"https://www.example.com",
 			variables,
+			nil,
+			dependentScanner,
 		)
 	})
 
@@ -127,7 +142,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 			})
 
 			It("returns the configured interval", func() {
-				Expect(runErr).To(Equal(ErrFailedToAcquireLock))
+				Expect(runErr).To(Equal(ErrCheckLockContended))
 				Expect(actualInterval).To(Equal(interval))
 			})
 		})
@@ -147,7 +162,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("returns the configured interval", func() {
-					Expect(runErr).To(Equal(ErrFailedToAcquireLock))
+					Expect(runErr).To(Equal(ErrCheckNotDue))
 					Expect(actualInterval).To(Equal(interval))
 				})
 			})
@@ -181,10 +196,11 @@ var _ = Describe("ResourceTypeScanner", func() {
 						TeamID: 123,
 					}))
 					Expect(workerSpec).To(Equal(worker.WorkerSpec{
-						ResourceType:  "registry-image",
-						Tags:          []string{"some-tag"},
-						ResourceTypes: creds.VersionedResourceTypes{},
-						TeamID:        123,
+						ResourceType:   "registry-image",
+						Tags:           []string{"some-tag"},
+						WorkerSelector: "zone=us-east",
+						ResourceTypes:  creds.VersionedResourceTypes{},
+						TeamID:         123,
 					}))
 					Expect(resourceTypes).To(Equal(creds.VersionedResourceTypes{}))
 				})
@@ -197,7 +213,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						fakeResourceType.IDReturns(40)
 						fakeResourceType.NameReturns("registry-image")
 						fakeResourceType.TypeReturns("registry-image")
-						fakeResourceType.SourceReturns(atc.Source{"custom": "((source-params))"})
+						fakeResourceType.SourceWithDefaultsReturns(atc.Source{"custom": "((source-params))"})
 						fakeResourceType.VersionReturns(atc.Version{"custom": "image-version"})
 						fakeResourceType.SetResourceConfigReturns(fakeResourceConfigScope, nil)
 
@@ -306,7 +322,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					})
 
 					It("checks from nil", func() {
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(BeNil())
 					})
 				})
@@ -322,7 +338,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 					It("checks with it", func() {
 						Expect(fakeResource.CheckCallCount()).To(Equal(1))
-						_, _, version := fakeResource.CheckArgsForCall(0)
+						_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 						Expect(version).To(Equal(atc.Version{"version": "42"}))
 					})
 				})
@@ -346,7 +362,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 						}
 
 						check := 0
-						fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+						fakeResource.CheckStub = func(ctx context.Context, _ resource.IOConfig, source atc.Source, from atc.Version, _ json.RawMessage) ([]atc.Version, error) {
 							defer GinkgoRecover()
 
 							Expect(source).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
@@ -365,6 +381,71 @@ var _ = Describe("ResourceTypeScanner", func() {
 						version := fakeResourceConfigScope.SaveVersionsArgsForCall(0)
 						Expect(version).To(Equal(nextVersions))
 					})
+
+					Context("when dependents are scheduled", func() {
+						BeforeEach(func() {
+							dependentScanner = fakeDependentScanner
+						})
+
+						Context("when the latest version changes", func() {
+							BeforeEach(func() {
+								oldVersion := new(dbfakes.FakeResourceConfigVersion)
+								oldVersion.IDReturns(1)
+								newVersion := new(dbfakes.FakeResourceConfigVersion)
+								newVersion.IDReturns(4)
+
+								fakeResourceConfigScope.LatestVersionReturnsOnCall(0, oldVersion, true, nil)
+								fakeResourceConfigScope.LatestVersionReturnsOnCall(1, oldVersion, true, nil)
+								fakeResourceConfigScope.LatestVersionReturnsOnCall(2, newVersion, true, nil)
+							})
+
+							It("schedules the dependents of the resource type", func() {
+								Expect(fakeDependentScanner.ScheduleDependentsCallCount()).To(Equal(1))
+
+								_, resourceTypeName := fakeDependentScanner.ScheduleDependentsArgsForCall(0)
+								Expect(resourceTypeName).To(Equal("some-custom-resource"))
+							})
+
+							Context("when the dependents are scheduled", func() {
+								var releasedBefore int
+
+								BeforeEach(func() {
+									releasedBefore = 0
+									fakeDependentScanner.ScheduleDependentsStub = func(lager.Logger, string) (Dependents, error) {
+										releasedBefore = fakeLock.ReleaseCallCount()
+										return Dependents{}, nil
+									}
+								})
+
+								It("has already released the lock", func() {
+									Expect(releasedBefore).To(Equal(1))
+								})
+							})
+
+							Context("when scheduling the dependents fails", func() {
+								BeforeEach(func() {
+									fakeDependentScanner.ScheduleDependentsReturns(Dependents{}, errors.New("nope"))
+								})
+
+								It("does not return an error", func() {
+									Expect(runErr).ToNot(HaveOccurred())
+								})
+							})
+						})
+
+						Context("when the latest version is unchanged", func() {
+							BeforeEach(func() {
+								latestVersion := new(dbfakes.FakeResourceConfigVersion)
+								latestVersion.IDReturns(1)
+
+								fakeResourceConfigScope.LatestVersionReturns(latestVersion, true, nil)
+							})
+
+							It("does not schedule the dependents", func() {
+								Expect(fakeDependentScanner.ScheduleDependentsCallCount()).To(BeZero())
+							})
+						})
+					})
 				})
 
 				Context("when checking fails", func() {
@@ -457,10 +538,11 @@ var _ = Describe("ResourceTypeScanner", func() {
 					TeamID: 123,
 				}))
 				Expect(workerSpec).To(Equal(worker.WorkerSpec{
-					ResourceType:  "registry-image",
-					Tags:          []string{"some-tag"},
-					ResourceTypes: creds.VersionedResourceTypes{},
-					TeamID:        123,
+					ResourceType:   "registry-image",
+					Tags:           []string{"some-tag"},
+					WorkerSelector: "zone=us-east",
+					ResourceTypes:  creds.VersionedResourceTypes{},
+					TeamID:         123,
 				}))
 				Expect(resourceTypes).To(Equal(creds.VersionedResourceTypes{}))
 			})
@@ -545,7 +627,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					fakeResourceType.IDReturns(40)
 					fakeResourceType.NameReturns("registry-image")
 					fakeResourceType.TypeReturns("registry-image")
-					fakeResourceType.SourceReturns(atc.Source{"custom": "((source-params))"})
+					fakeResourceType.SourceWithDefaultsReturns(atc.Source{"custom": "((source-params))"})
 					fakeResourceType.SetResourceConfigReturns(fakeResourceConfigScope, nil)
 
 					fakeDBPipeline.ResourceTypesReturns([]db.ResourceType{
@@ -634,7 +716,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks from nil", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(BeNil())
 				})
 			})
@@ -650,7 +732,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 				It("checks with it", func() {
 					Expect(fakeResource.CheckCallCount()).To(Equal(1))
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "42"}))
 				})
 			})
@@ -674,7 +756,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 					}
 
 					check := 0
-					fakeResource.CheckStub = func(ctx context.Context, source atc.Source, from atc.Version) ([]atc.Version, error) {
+					fakeResource.CheckStub = func(ctx context.Context, _ resource.IOConfig, source atc.Source, from atc.Version, _ json.RawMessage) ([]atc.Version, error) {
 						defer GinkgoRecover()
 
 						Expect(source).To(Equal(atc.Source{"custom": "some-secret-sauce"}))
@@ -838,7 +920,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 
 			Context("when fromVersion is nil", func() {
 				It("checks from the current version", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"custom": "version"}))
 				})
 			})
@@ -851,7 +933,7 @@ var _ = Describe("ResourceTypeScanner", func() {
 				})
 
 				It("checks from it", func() {
-					_, _, version := fakeResource.CheckArgsForCall(0)
+					_, _, _, version, _ := fakeResource.CheckArgsForCall(0)
 					Expect(version).To(Equal(atc.Version{"version": "1"}))
 				})
 
@@ -1,30 +1,48 @@
 package radar
 
 import (
+	"encoding/json"
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
 )
 
+//go:generate counterfeiter . Scanner
+
+type Scanner interface {
+	Run(lager.Logger, string) (time.Duration, error)
+	Scan(lager.Logger, string) error
+	ScanFromVersion(lager.Logger, string, atc.Version) error
+	ScanFromVersionNotifying(lager.Logger, string, atc.Version, chan<- db.Check) error
+	ScanFromPayload(lager.Logger, string, atc.Version, json.RawMessage) error
+}
+
 // ScannerFactory is the same interface as resourceserver/server.go
 // They are in two places because there would be cyclic dependencies otherwise
 
-// go:generate counterfeiter . ScannerFactory
+//go:generate counterfeiter . ScannerFactory
+
 type ScannerFactory interface {
 	NewResourceScanner(dbPipeline db.Pipeline) Scanner
 	NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner
+	NewDependentScanner(dbPipeline db.Pipeline) DependentScanner
 }
 
 type scannerFactory struct {
 	resourceFactory              resource.ResourceFactory
 	resourceConfigFactory        db.ResourceConfigFactory
+	checkRateLimiter             db.CheckRateLimiter
 	resourceTypeCheckingInterval time.Duration
 	resourceCheckingInterval     time.Duration
 	externalURL                  string
 	variablesFactory             creds.VariablesFactory
+	baseResourceTypeDefaults     atc.ResourceTypeDefaults
+	checkDependents              bool
 }
 
 var ContainerExpiries = db.ContainerOwnerExpiries{
@@ -36,18 +54,24 @@ var ContainerExpiries = db.ContainerOwnerExpiries{
 func NewScannerFactory(
 	resourceFactory resource.ResourceFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	checkRateLimiter db.CheckRateLimiter,
 	resourceTypeCheckingInterval time.Duration,
 	resourceCheckingInterval time.Duration,
 	externalURL string,
 	variablesFactory creds.VariablesFactory,
+	baseResourceTypeDefaults atc.ResourceTypeDefaults,
+	checkDependents bool,
 ) ScannerFactory {
 	return &scannerFactory{
 		resourceFactory:              resourceFactory,
 		resourceConfigFactory:        resourceConfigFactory,
+		checkRateLimiter:             checkRateLimiter,
 		resourceCheckingInterval:     resourceCheckingInterval,
 		resourceTypeCheckingInterval: resourceTypeCheckingInterval,
 		externalURL:                  externalURL,
 		variablesFactory:             variablesFactory,
+		baseResourceTypeDefaults:     baseResourceTypeDefaults,
+		checkDependents:              checkDependents,
 	}
 }
 
@@ -58,14 +82,34 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 		clock.NewClock(),
 		f.resourceFactory,
 		f.resourceConfigFactory,
+		f.checkRateLimiter,
 		f.resourceCheckingInterval,
 		dbPipeline,
 		f.externalURL,
 		variables,
+		f.baseResourceTypeDefaults,
 	)
 }
 
 func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner {
+	var dependentScanner DependentScanner
+	if f.checkDependents {
+		dependentScanner = f.NewDependentScanner(dbPipeline)
+	}
+
+	return f.newResourceTypeScanner(dbPipeline, dependentScanner)
+}
+
+// NewDependentScanner returns a DependentScanner whose resource type scanner
+// leaves scheduling dependents to it.
+func (f *scannerFactory) NewDependentScanner(dbPipeline db.Pipeline) DependentScanner {
+	return NewDependentScanner(
+		dbPipeline,
+		f.newResourceTypeScanner(dbPipeline, nil),
+	)
+}
+
+func (f *scannerFactory) newResourceTypeScanner(dbPipeline db.Pipeline, dependentScanner DependentScanner) Scanner {
 	variables := f.variablesFactory.NewVariables(dbPipeline.TeamName(), dbPipeline.Name())
 
 	return NewResourceTypeScanner(
@@ -76,5 +120,7 @@ func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner
 		dbPipeline,
 		f.externalURL,
 		variables,
+		f.baseResourceTypeDefaults,
+		dependentScanner,
 	)
 }
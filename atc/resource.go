@@ -2,6 +2,7 @@ package atc
 
 type Resource struct {
 	Name         string `json:"name"`
+	PipelineID   int    `json:"pipeline_id,omitempty"`
 	PipelineName string `json:"pipeline_name"`
 	TeamName     string `json:"team_name"`
 	Type         string `json:"type"`
@@ -10,6 +11,7 @@ type Resource struct {
 	FailingToCheck  bool   `json:"failing_to_check,omitempty"`
 	CheckSetupError string `json:"check_setup_error,omitempty"`
 	CheckError      string `json:"check_error,omitempty"`
+	RateLimited     bool   `json:"rate_limited,omitempty"`
 
 	PinnedVersion  Version `json:"pinned_version,omitempty"`
 	PinnedInConfig bool    `json:"pinned_in_config,omitempty"`
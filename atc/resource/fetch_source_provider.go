@@ -18,6 +18,7 @@ type FetchSourceProviderFactory interface {
 		session Session,
 		metadata Metadata,
 		tags atc.Tags,
+		workerSelector atc.WorkerSelector,
 		teamID int,
 		resourceTypes creds.VersionedResourceTypes,
 		resourceInstance ResourceInstance,
@@ -59,6 +60,7 @@ func (f *fetchSourceProviderFactory) NewFetchSourceProvider(
 	session Session,
 	metadata Metadata,
 	tags atc.Tags,
+	workerSelector atc.WorkerSelector,
 	teamID int,
 	resourceTypes creds.VersionedResourceTypes,
 	resourceInstance ResourceInstance,
@@ -69,6 +71,7 @@ func (f *fetchSourceProviderFactory) NewFetchSourceProvider(
 		session:                session,
 		metadata:               metadata,
 		tags:                   tags,
+		workerSelector:         workerSelector,
 		teamID:                 teamID,
 		resourceTypes:          resourceTypes,
 		resourceInstance:       resourceInstance,
@@ -83,6 +86,7 @@ type fetchSourceProvider struct {
 	session                Session
 	metadata               Metadata
 	tags                   atc.Tags
+	workerSelector         atc.WorkerSelector
 	teamID                 int
 	resourceTypes          creds.VersionedResourceTypes
 	resourceInstance       ResourceInstance
@@ -93,10 +97,11 @@ type fetchSourceProvider struct {
 
 func (f *fetchSourceProvider) Get() (FetchSource, error) {
 	resourceSpec := worker.WorkerSpec{
-		ResourceType:  string(f.resourceInstance.ResourceType()),
-		Tags:          f.tags,
-		TeamID:        f.teamID,
-		ResourceTypes: f.resourceTypes,
+		ResourceType:   string(f.resourceInstance.ResourceType()),
+		Tags:           f.tags,
+		WorkerSelector: f.workerSelector,
+		TeamID:         f.teamID,
+		ResourceTypes:  f.resourceTypes,
 	}
 
 	chosenWorker, err := f.workerClient.Satisfying(f.logger.Session("fetch-source-provider"), resourceSpec)
@@ -111,6 +116,7 @@ func (f *fetchSourceProvider) Get() (FetchSource, error) {
 		chosenWorker,
 		f.resourceTypes,
 		f.tags,
+		f.workerSelector,
 		f.teamID,
 		f.session,
 		f.metadata,
@@ -29,6 +29,7 @@ var _ = Describe("FetchSourceProvider", func() {
 		metadata                 = resource.EmptyMetadata{}
 		session                  = resource.Session{}
 		tags                     atc.Tags
+		workerSelector           atc.WorkerSelector
 		resourceTypes            creds.VersionedResourceTypes
 		teamID                   = 3
 		fakeResourceCacheFactory *dbfakes.FakeResourceCacheFactory
@@ -43,6 +44,7 @@ var _ = Describe("FetchSourceProvider", func() {
 		logger = lagertest.NewTestLogger("test")
 		resourceInstance = new(resourcefakes.FakeResourceInstance)
 		tags = atc.Tags{"some", "tags"}
+		workerSelector = "zone=us-east"
 
 		variables := template.StaticVariables{
 			"secret-repository": "repository",
@@ -69,6 +71,7 @@ var _ = Describe("FetchSourceProvider", func() {
 			session,
 			metadata,
 			tags,
+			workerSelector,
 			teamID,
 			resourceTypes,
 			resourceInstance,
@@ -83,10 +86,11 @@ var _ = Describe("FetchSourceProvider", func() {
 			Expect(fakeWorkerClient.SatisfyingCallCount()).To(Equal(1))
 			_, workerSpec := fakeWorkerClient.SatisfyingArgsForCall(0)
 			Expect(workerSpec).To(Equal(worker.WorkerSpec{
-				ResourceType:  "some-resource-type",
-				Tags:          tags,
-				TeamID:        teamID,
-				ResourceTypes: resourceTypes,
+				ResourceType:   "some-resource-type",
+				Tags:           tags,
+				WorkerSelector: workerSelector,
+				TeamID:         teamID,
+				ResourceTypes:  resourceTypes,
 			}))
 		})
 
@@ -108,6 +112,7 @@ var _ = Describe("FetchSourceProvider", func() {
 					fakeWorker,
 					resourceTypes,
 					tags,
+					workerSelector,
 					teamID,
 					session,
 					metadata,
@@ -27,6 +27,7 @@ type Fetcher interface {
 		logger lager.Logger,
 		session Session,
 		tags atc.Tags,
+		workerSelector atc.WorkerSelector,
 		teamID int,
 		resourceTypes creds.VersionedResourceTypes,
 		resourceInstance ResourceInstance,
@@ -58,6 +59,7 @@ func (f *fetcher) Fetch(
 	logger lager.Logger,
 	session Session,
 	tags atc.Tags,
+	workerSelector atc.WorkerSelector,
 	teamID int,
 	resourceTypes creds.VersionedResourceTypes,
 	resourceInstance ResourceInstance,
@@ -69,13 +71,14 @@ func (f *fetcher) Fetch(
 		session,
 		metadata,
 		tags,
+		workerSelector,
 		teamID,
 		resourceTypes,
 		resourceInstance,
 		imageFetchingDelegate,
 	)
 
-	source, err := sourceProvider.Get()
+	source, err := f.getSource(ctx, logger, sourceProvider, imageFetchingDelegate)
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +110,39 @@ func (f *fetcher) Fetch(
 	}
 }
 
+// getSource waits for a worker with room to fetch on if every worker which
+// could fetch the resource is full.
+func (f *fetcher) getSource(
+	ctx context.Context,
+	logger lager.Logger,
+	sourceProvider FetchSourceProvider,
+	imageFetchingDelegate worker.ImageFetchingDelegate,
+) (FetchSource, error) {
+	waiting := false
+
+	for {
+		source, err := sourceProvider.Get()
+		if _, full := err.(worker.FullWorkersError); !full {
+			return source, err
+		}
+
+		if !waiting {
+			logger.Info("waiting-for-worker")
+			imageFetchingDelegate.WaitingForWorker(logger)
+			waiting = true
+		}
+
+		timer := f.clock.NewTimer(worker.WaitForWorkerInterval)
+
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (f *fetcher) fetchWithLock(
 	ctx context.Context,
 	logger lager.Logger,
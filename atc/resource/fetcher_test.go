@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
+	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
@@ -62,6 +63,7 @@ var _ = Describe("Fetcher", func() {
 			lagertest.NewTestLogger("test"),
 			resource.Session{},
 			atc.Tags{},
+			"",
 			teamID,
 			creds.VersionedResourceTypes{},
 			new(resourcefakes.FakeResourceInstance),
@@ -195,4 +197,36 @@ var _ = Describe("Fetcher", func() {
 			})
 		})
 	})
+
+	Context("when every worker which could fetch is full", func() {
+		var fakeFetchSource *resourcefakes.FakeFetchSource
+
+		BeforeEach(func() {
+			fakeFetchSource = new(resourcefakes.FakeFetchSource)
+			fakeFetchSource.FindReturns(fakeVersionedSource, true, nil)
+
+			fakeFetchSourceProvider.GetReturnsOnCall(0, nil, worker.FullWorkersError{})
+			fakeFetchSourceProvider.GetReturnsOnCall(1, nil, worker.FullWorkersError{})
+			fakeFetchSourceProvider.GetReturnsOnCall(2, fakeFetchSource, nil)
+
+			go func() {
+				defer GinkgoRecover()
+
+				for i := 0; i < 2; i++ {
+					Eventually(fakeClock.WatcherCount).Should(Equal(1))
+					fakeClock.Increment(worker.WaitForWorkerInterval)
+				}
+			}()
+		})
+
+		It("waits for a worker with room", func() {
+			Expect(fetchErr).ToNot(HaveOccurred())
+			Expect(fakeFetchSourceProvider.GetCallCount()).To(Equal(3))
+			Expect(versionedSource).To(Equal(fakeVersionedSource))
+		})
+
+		It("tells the delegate that it is waiting, once", func() {
+			Expect(fakeBuildStepDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+		})
+	})
 })
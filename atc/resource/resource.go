@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"path/filepath"
 
@@ -15,7 +16,7 @@ import (
 type Resource interface {
 	Get(context.Context, worker.Volume, IOConfig, atc.Source, atc.Params, atc.Version) (VersionedSource, error)
 	Put(context.Context, IOConfig, atc.Source, atc.Params) (VersionedSource, error)
-	Check(context.Context, atc.Source, atc.Version) ([]atc.Version, error)
+	Check(context.Context, IOConfig, atc.Source, atc.Version, json.RawMessage) ([]atc.Version, error)
 	Container() worker.Container
 }
 
@@ -1,29 +1,53 @@
 package resource
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 
 	"github.com/concourse/concourse/atc"
 )
 
 type checkRequest struct {
-	Source  atc.Source  `json:"source"`
-	Version atc.Version `json:"version"`
+	Source  atc.Source      `json:"source"`
+	Version atc.Version     `json:"version"`
+	Payload json.RawMessage `json:"payload,omitempty"`
 }
 
-func (resource *resource) Check(ctx context.Context, source atc.Source, fromVersion atc.Version) ([]atc.Version, error) {
+func (resource *resource) Check(
+	ctx context.Context,
+	ioConfig IOConfig,
+	source atc.Source,
+	fromVersion atc.Version,
+	payload json.RawMessage,
+) ([]atc.Version, error) {
 	var versions []atc.Version
 
+	// stderr is kept regardless of where it is written to so that it is still
+	// part of the error when the script fails
+	stderr := new(bytes.Buffer)
+
+	var logDest io.Writer = stderr
+	if ioConfig.Stderr != nil {
+		logDest = io.MultiWriter(stderr, ioConfig.Stderr)
+	}
+
 	err := resource.runScript(
 		ctx,
 		"/opt/resource/check",
 		nil,
-		checkRequest{source, fromVersion},
+		checkRequest{source, fromVersion, payload},
 		&versions,
-		nil,
+		logDest,
 		false,
 	)
 	if err != nil {
+		if scriptErr, ok := err.(ErrResourceScriptFailed); ok {
+			scriptErr.Stderr = stderr.String()
+			return nil, scriptErr
+		}
+
 		return nil, err
 	}
 
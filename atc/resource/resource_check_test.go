@@ -2,21 +2,25 @@ package resource_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/resource"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Resource Check", func() {
 	var (
 		source  atc.Source
 		version atc.Version
+		payload json.RawMessage
 
 		checkScriptStdout     string
 		checkScriptStderr     string
@@ -25,6 +29,9 @@ var _ = Describe("Resource Check", func() {
 
 		checkScriptProcess *gardenfakes.FakeProcess
 
+		ioConfig resource.IOConfig
+		stderr   *gbytes.Buffer
+
 		checkResult []atc.Version
 		checkErr    error
 	)
@@ -32,6 +39,7 @@ var _ = Describe("Resource Check", func() {
 	BeforeEach(func() {
 		source = atc.Source{"some": "source"}
 		version = atc.Version{"some": "version"}
+		payload = nil
 
 		checkScriptStdout = "[]"
 		checkScriptStderr = ""
@@ -43,6 +51,9 @@ var _ = Describe("Resource Check", func() {
 			return checkScriptExitStatus, nil
 		}
 
+		stderr = gbytes.NewBuffer()
+		ioConfig = resource.IOConfig{Stderr: stderr}
+
 		checkResult = nil
 		checkErr = nil
 	})
@@ -62,7 +73,7 @@ var _ = Describe("Resource Check", func() {
 			return checkScriptProcess, nil
 		}
 
-		checkResult, checkErr = resourceForContainer.Check(context.TODO(), source, version)
+		checkResult, checkErr = resourceForContainer.Check(context.TODO(), ioConfig, source, version, payload)
 	})
 
 	It("runs /opt/resource/check the request on stdin", func() {
@@ -78,6 +89,23 @@ var _ = Describe("Resource Check", func() {
 		Expect(string(request)).To(Equal(`{"source":{"some":"source"},"version":{"some":"version"}}`))
 	})
 
+	Context("when given a webhook payload", func() {
+		BeforeEach(func() {
+			payload = json.RawMessage(`{"ref":"refs/heads/master"}`)
+		})
+
+		It("includes it in the request", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+
+			_, io := fakeContainer.RunArgsForCall(0)
+
+			request, err := ioutil.ReadAll(io.Stdin)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(request)).To(Equal(`{"source":{"some":"source"},"version":{"some":"version"},"payload":{"ref":"refs/heads/master"}}`))
+		})
+	})
+
 	Context("when /check outputs versions", func() {
 		BeforeEach(func() {
 			checkScriptStdout = `[{"ver":"abc"}, {"ver":"def"}, {"ver":"ghi"}]`
@@ -119,6 +147,10 @@ var _ = Describe("Resource Check", func() {
 			Expect(checkErr.Error()).To(ContainSubstring("exit status 9"))
 			Expect(checkErr.Error()).To(ContainSubstring("some-stderr"))
 		})
+
+		It("also writes stderr to the given writer", func() {
+			Expect(stderr).To(gbytes.Say("some-stderr"))
+		})
 	})
 
 	Context("when the output of /opt/resource/check is malformed", func() {
@@ -16,6 +16,7 @@ type resourceInstanceFetchSource struct {
 	worker                 worker.Worker
 	resourceTypes          creds.VersionedResourceTypes
 	tags                   atc.Tags
+	workerSelector         atc.WorkerSelector
 	teamID                 int
 	session                Session
 	metadata               Metadata
@@ -29,6 +30,7 @@ func NewResourceInstanceFetchSource(
 	worker worker.Worker,
 	resourceTypes creds.VersionedResourceTypes,
 	tags atc.Tags,
+	workerSelector atc.WorkerSelector,
 	teamID int,
 	session Session,
 	metadata Metadata,
@@ -41,6 +43,7 @@ func NewResourceInstanceFetchSource(
 		worker:                 worker,
 		resourceTypes:          resourceTypes,
 		tags:                   tags,
+		workerSelector:         workerSelector,
 		teamID:                 teamID,
 		session:                session,
 		metadata:               metadata,
@@ -111,10 +114,11 @@ func (s *resourceInstanceFetchSource) Create(ctx context.Context) (VersionedSour
 	}
 
 	workerSpec := worker.WorkerSpec{
-		ResourceType:  string(s.resourceInstance.ResourceType()),
-		Tags:          s.tags,
-		TeamID:        s.teamID,
-		ResourceTypes: s.resourceTypes,
+		ResourceType:   string(s.resourceInstance.ResourceType()),
+		Tags:           s.tags,
+		WorkerSelector: s.workerSelector,
+		TeamID:         s.teamID,
+		ResourceTypes:  s.resourceTypes,
 	}
 
 	resourceFactory := NewResourceFactory(s.worker)
@@ -3,6 +3,7 @@ package resourcefakes
 
 import (
 	context "context"
+	json "encoding/json"
 	sync "sync"
 
 	atc "github.com/concourse/concourse/atc"
@@ -11,12 +12,14 @@ import (
 )
 
 type FakeResource struct {
-	CheckStub        func(context.Context, atc.Source, atc.Version) ([]atc.Version, error)
+	CheckStub        func(context.Context, resource.IOConfig, atc.Source, atc.Version, json.RawMessage) ([]atc.Version, error)
 	checkMutex       sync.RWMutex
 	checkArgsForCall []struct {
 		arg1 context.Context
-		arg2 atc.Source
-		arg3 atc.Version
+		arg2 resource.IOConfig
+		arg3 atc.Source
+		arg4 atc.Version
+		arg5 json.RawMessage
 	}
 	checkReturns struct {
 		result1 []atc.Version
@@ -74,18 +77,20 @@ type FakeResource struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeResource) Check(arg1 context.Context, arg2 atc.Source, arg3 atc.Version) ([]atc.Version, error) {
+func (fake *FakeResource) Check(arg1 context.Context, arg2 resource.IOConfig, arg3 atc.Source, arg4 atc.Version, arg5 json.RawMessage) ([]atc.Version, error) {
 	fake.checkMutex.Lock()
 	ret, specificReturn := fake.checkReturnsOnCall[len(fake.checkArgsForCall)]
 	fake.checkArgsForCall = append(fake.checkArgsForCall, struct {
 		arg1 context.Context
-		arg2 atc.Source
-		arg3 atc.Version
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("Check", []interface{}{arg1, arg2, arg3})
+		arg2 resource.IOConfig
+		arg3 atc.Source
+		arg4 atc.Version
+		arg5 json.RawMessage
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("Check", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.checkMutex.Unlock()
 	if fake.CheckStub != nil {
-		return fake.CheckStub(arg1, arg2, arg3)
+		return fake.CheckStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -100,17 +105,17 @@ func (fake *FakeResource) CheckCallCount() int {
 	return len(fake.checkArgsForCall)
 }
 
-func (fake *FakeResource) CheckCalls(stub func(context.Context, atc.Source, atc.Version) ([]atc.Version, error)) {
+func (fake *FakeResource) CheckCalls(stub func(context.Context, resource.IOConfig, atc.Source, atc.Version, json.RawMessage) ([]atc.Version, error)) {
 	fake.checkMutex.Lock()
 	defer fake.checkMutex.Unlock()
 	fake.CheckStub = stub
 }
 
-func (fake *FakeResource) CheckArgsForCall(i int) (context.Context, atc.Source, atc.Version) {
+func (fake *FakeResource) CheckArgsForCall(i int) (context.Context, resource.IOConfig, atc.Source, atc.Version, json.RawMessage) {
 	fake.checkMutex.RLock()
 	defer fake.checkMutex.RUnlock()
 	argsForCall := fake.checkArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
 func (fake *FakeResource) CheckReturns(result1 []atc.Version, result2 error) {
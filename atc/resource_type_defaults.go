@@ -0,0 +1,103 @@
+package atc
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ResourceTypeDefaults maps a resource type to the source shared by every
+// resource, resource type and image resource of that type.
+type ResourceTypeDefaults map[string]Source
+
+// ParseResourceTypeDefaults parses defaults from YAML or JSON, e.g. from the
+// file given to the ATC.
+func ParseResourceTypeDefaults(payload []byte) (ResourceTypeDefaults, error) {
+	var untyped interface{}
+	err := yaml.Unmarshal(payload, &untyped)
+	if err != nil {
+		return nil, err
+	}
+
+	sanitized, err := sanitize(untyped)
+	if err != nil {
+		return nil, err
+	}
+
+	// round-trip through JSON so that nested sources are decoded the same way
+	// as those of a pipeline saved through the API
+	sanitizedJSON, err := json.Marshal(sanitized)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaults ResourceTypeDefaults
+	err = json.Unmarshal(sanitizedJSON, &defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	return defaults, nil
+}
+
+// Apply deep-merges the defaults for the resource type beneath the given
+// source, which takes precedence wherever both configure a key.
+func (defaults ResourceTypeDefaults) Apply(resourceType string, source Source) Source {
+	typeDefaults, found := defaults[resourceType]
+	if !found {
+		return source
+	}
+
+	return mergeSource(typeDefaults, source)
+}
+
+// Over deep-merges the defaults over the base defaults, e.g. a pipeline's
+// resource_type_defaults over those configured on the ATC.
+func (defaults ResourceTypeDefaults) Over(base ResourceTypeDefaults) ResourceTypeDefaults {
+	if len(base) == 0 {
+		return defaults
+	}
+
+	merged := ResourceTypeDefaults{}
+	for resourceType, source := range base {
+		merged[resourceType] = source
+	}
+
+	for resourceType, source := range defaults {
+		merged[resourceType] = base.Apply(resourceType, source)
+	}
+
+	return merged
+}
+
+// mergeSource returns a copy of the defaults with the overrides deep-merged
+// over them.
+func mergeSource(defaults map[string]interface{}, overrides map[string]interface{}) Source {
+	merged := Source{}
+	for key, value := range defaults {
+		merged[key] = value
+	}
+
+	for key, value := range overrides {
+		defaultMap, defaultIsMap := asSourceMap(merged[key])
+		overrideMap, overrideIsMap := asSourceMap(value)
+		if defaultIsMap && overrideIsMap {
+			merged[key] = map[string]interface{}(mergeSource(defaultMap, overrideMap))
+		} else {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+func asSourceMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case Source:
+		return m, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,125 @@
+package atc_test
+
+import (
+	. "github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceTypeDefaults", func() {
+	var defaults ResourceTypeDefaults
+
+	BeforeEach(func() {
+		defaults = ResourceTypeDefaults{
+			"registry-image": Source{
+				"registry_mirror": map[string]interface{}{
+					"host":     "some-mirror",
+					"username": "some-username",
+				},
+				"username": "some-username",
+			},
+		}
+	})
+
+	Describe("Apply", func() {
+		It("deep-merges the defaults beneath the source", func() {
+			Expect(defaults.Apply("registry-image", Source{
+				"repository": "some-repository",
+				"registry_mirror": map[string]interface{}{
+					"username": "some-other-username",
+				},
+			})).To(Equal(Source{
+				"repository": "some-repository",
+				"registry_mirror": map[string]interface{}{
+					"host":     "some-mirror",
+					"username": "some-other-username",
+				},
+				"username": "some-username",
+			}))
+		})
+
+		It("does not modify the defaults", func() {
+			defaults.Apply("registry-image", Source{
+				"registry_mirror": map[string]interface{}{
+					"username": "some-other-username",
+				},
+			})
+
+			Expect(defaults["registry-image"]["registry_mirror"]).To(Equal(map[string]interface{}{
+				"host":     "some-mirror",
+				"username": "some-username",
+			}))
+		})
+
+		It("returns the source of other types as-is", func() {
+			Expect(defaults.Apply("git", Source{"uri": "some-uri"})).To(Equal(Source{"uri": "some-uri"}))
+		})
+	})
+
+	Describe("Over", func() {
+		var base ResourceTypeDefaults
+
+		BeforeEach(func() {
+			base = ResourceTypeDefaults{
+				"registry-image": Source{
+					"username": "some-base-username",
+					"password": "some-base-password",
+				},
+				"git": Source{"private_key": "some-key"},
+			}
+		})
+
+		It("merges the defaults over the base defaults", func() {
+			Expect(defaults.Over(base).Apply("registry-image", Source{})).To(Equal(Source{
+				"registry_mirror": map[string]interface{}{
+					"host":     "some-mirror",
+					"username": "some-username",
+				},
+				"username": "some-username",
+				"password": "some-base-password",
+			}))
+		})
+
+		It("keeps the base defaults of types without defaults", func() {
+			Expect(ResourceTypeDefaults(nil).Over(base).Apply("git", Source{"uri": "some-uri"})).To(Equal(Source{
+				"uri":         "some-uri",
+				"private_key": "some-key",
+			}))
+		})
+
+		It("does not modify the base defaults", func() {
+			defaults.Over(base)
+
+			Expect(base["registry-image"]).To(Equal(Source{
+				"username": "some-base-username",
+				"password": "some-base-password",
+			}))
+		})
+	})
+
+	Describe("ParseResourceTypeDefaults", func() {
+		It("parses YAML", func() {
+			parsed, err := ParseResourceTypeDefaults([]byte(`
+registry-image:
+  registry_mirror:
+    host: some-mirror
+  tag: 1
+`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed).To(Equal(ResourceTypeDefaults{
+				"registry-image": Source{
+					"registry_mirror": map[string]interface{}{
+						"host": "some-mirror",
+					},
+					"tag": float64(1),
+				},
+			}))
+		})
+
+		It("returns an error for invalid YAML", func() {
+			_, err := ParseResourceTypeDefaults([]byte(`registry-image: [`))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -2,9 +2,36 @@ package atc
 
 type CheckRequestBody struct {
 	From Version `json:"from"`
+
+	// Recursive has every resource type and resource using the checked
+	// resource type rechecked by the check scheduler once it has been checked.
+	Recursive bool `json:"recursive,omitempty"`
+
+	// Async responds with the check as soon as it has been recorded, rather
+	// than once it has run, so that its events can be watched.
+	Async bool `json:"async,omitempty"`
+}
+
+// CheckResourceTypeResponseBody lists the resource types and resources which
+// were scheduled to be rechecked by a recursive check of a resource type.
+type CheckResourceTypeResponseBody struct {
+	ResourceTypes []string `json:"resource_types"`
+	Resources     []string `json:"resources"`
 }
 
 type CheckResponseBody struct {
 	ExitStatus int    `json:"exit_status"`
 	Stderr     string `json:"stderr"`
 }
+
+// A Check records a single run of a resource's check, whose logs and outcome
+// are kept as an event stream.
+type Check struct {
+	ID         int    `json:"id"`
+	Status     string `json:"status"`
+	ATCURL     string `json:"atc_url,omitempty"`
+	WorkerName string `json:"worker_name,omitempty"`
+	CheckError string `json:"check_error,omitempty"`
+	CreateTime int64  `json:"create_time,omitempty"`
+	EndTime    int64  `json:"end_time,omitempty"`
+}
@@ -34,6 +34,7 @@ func NewBuildStarter(
 	scanner Scanner,
 	inputMapper inputmapper.InputMapper,
 	execEngine engine.Engine,
+	baseResourceTypeDefaults atc.ResourceTypeDefaults,
 ) BuildStarter {
 	return &buildStarter{
 		pipeline:           pipeline,
@@ -42,6 +43,7 @@ func NewBuildStarter(
 		scanner:            scanner,
 		inputMapper:        inputMapper,
 		execEngine:         execEngine,
+		baseDefaults:       baseResourceTypeDefaults,
 	}
 }
 
@@ -52,6 +54,10 @@ type buildStarter struct {
 	execEngine         engine.Engine
 	scanner            Scanner
 	inputMapper        inputmapper.InputMapper
+
+	// baseDefaults are the resource type defaults configured on the ATC,
+	// beneath those of the pipeline
+	baseDefaults atc.ResourceTypeDefaults
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -170,12 +176,12 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		resourceConfigs = append(resourceConfigs, atc.ResourceConfig{
 			Name:   v.Name(),
 			Type:   v.Type(),
-			Source: v.Source(),
+			Source: s.baseDefaults.Apply(v.Type(), v.SourceWithDefaults()),
 			Tags:   v.Tags(),
 		})
 	}
 
-	plan, err := s.factory.Create(job.Config(), resourceConfigs, resourceTypes, buildInputs)
+	plan, err := s.factory.Create(job.Config(), resourceConfigs, resourceTypes.WithDefaults(s.baseDefaults), buildInputs)
 	if err != nil {
 		// Don't use ErrorBuild because it logs a build event, and this build hasn't started
 		err := nextPendingBuild.Finish(db.BuildStatusErrored)
@@ -185,6 +191,17 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
+	// tasks may load their image resource from a file, so its defaults are
+	// applied once the task is run
+	resourceTypeDefaults := s.pipeline.ResourceTypeDefaults().Over(s.baseDefaults)
+	if len(resourceTypeDefaults) != 0 {
+		plan.Each(func(p *atc.Plan) {
+			if p.Task != nil {
+				p.Task.ResourceTypeDefaults = resourceTypeDefaults
+			}
+		})
+	}
+
 	createdBuild, err := s.execEngine.CreateBuild(logger, nextPendingBuild, plan)
 	if err != nil {
 		logger.Error("failed-to-create-build", err)
@@ -43,7 +43,7 @@ var _ = Describe("BuildStarter", func() {
 		fakeScanner = new(schedulerfakes.FakeScanner)
 		fakeInputMapper = new(inputmapperfakes.FakeInputMapper)
 
-		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine)
+		buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine, nil)
 
 		disaster = errors.New("bad thing")
 	})
@@ -140,7 +140,7 @@ var _ = Describe("BuildStarter", func() {
 						fakeDBResourceType := new(dbfakes.FakeResourceType)
 						fakeDBResourceType.NameReturns("fake-resource-type")
 						fakeDBResourceType.TypeReturns("fake")
-						fakeDBResourceType.SourceReturns(atc.Source{"im": "fake"})
+						fakeDBResourceType.SourceWithDefaultsReturns(atc.Source{"im": "fake"})
 						fakeDBResourceType.PrivilegedReturns(true)
 						fakeDBResourceType.VersionReturns(atc.Version{"version": "1.2.3"})
 
@@ -497,6 +497,52 @@ var _ = Describe("BuildStarter", func() {
 									Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 								})
 
+								Context("when the pipeline has resource type defaults", func() {
+									BeforeEach(func() {
+										fakePipeline.ResourceTypeDefaultsReturns(atc.ResourceTypeDefaults{
+											"registry-image": atc.Source{"mirror": "some-mirror"},
+										})
+									})
+
+									It("adds them to the plan's tasks", func() {
+										_, _, actualPlan := fakeEngine.CreateBuildArgsForCall(0)
+										Expect(actualPlan.Task.ResourceTypeDefaults).To(Equal(atc.ResourceTypeDefaults{
+											"registry-image": atc.Source{"mirror": "some-mirror"},
+										}))
+									})
+
+									Context("when the ATC configures resource type defaults", func() {
+										BeforeEach(func() {
+											resource.TypeReturns("git")
+
+											buildStarter = scheduler.NewBuildStarter(fakePipeline, fakeUpdater, fakeFactory, fakeScanner, fakeInputMapper, fakeEngine, atc.ResourceTypeDefaults{
+												"git":            atc.Source{"branch": "master"},
+												"registry-image": atc.Source{"username": "some-username"},
+											})
+										})
+
+										It("merges them beneath the sources of the resources", func() {
+											_, actualResourceConfigs, _, _ := fakeFactory.CreateArgsForCall(0)
+											Expect(actualResourceConfigs).To(Equal(atc.ResourceConfigs{{
+												Name:   "some-resource",
+												Type:   "git",
+												Source: atc.Source{"branch": "master"},
+											}}))
+										})
+
+										It("merges the pipeline's defaults over them for the plan's tasks", func() {
+											_, _, actualPlan := fakeEngine.CreateBuildArgsForCall(0)
+											Expect(actualPlan.Task.ResourceTypeDefaults).To(Equal(atc.ResourceTypeDefaults{
+												"git": atc.Source{"branch": "master"},
+												"registry-image": atc.Source{
+													"mirror":   "some-mirror",
+													"username": "some-username",
+												},
+											}))
+										})
+									})
+								})
+
 								Context("when creating the engine build fails", func() {
 									BeforeEach(func() {
 										fakeEngine.CreateBuildReturns(nil, disaster)
@@ -33,6 +33,10 @@ func (factory *buildFactory) Create(
 	resourceTypes atc.VersionedResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if job.DefaultStepTimeout != "" {
+		job = withDefaultStepTimeout(job)
+	}
+
 	plan, err := factory.constructPlanFromJob(job, resources, resourceTypes, inputs)
 	if err != nil {
 		return atc.Plan{}, err
@@ -99,36 +103,126 @@ func (factory *buildFactory) constructPlanFromConfig(
 	resourceTypes atc.VersionedResourceTypes,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
+	if len(planConfig.Across) > 0 {
+		return factory.across(planConfig, resources, resourceTypes, inputs)
+	}
+
 	var plan atc.Plan
 	var err error
 
-	if planConfig.Attempts == 0 {
+	if planConfig.Attempts == nil || planConfig.Attempts.Count == 0 {
 		plan, err = factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
 		if err != nil {
 			return atc.Plan{}, err
 		}
 	} else {
-		retryStep := make(atc.RetryPlan, planConfig.Attempts)
+		attempts := *planConfig.Attempts
 
-		for i := 0; i < planConfig.Attempts; i++ {
+		retryStep := atc.RetryPlan{
+			Attempts: make([]atc.Plan, attempts.Count),
+			On:       attempts.On,
+		}
+
+		if attempts.Backoff != nil {
+			retryStep.Backoff = &atc.RetryBackoff{
+				Initial:    attempts.Backoff.Initial,
+				Max:        attempts.Backoff.Max,
+				Multiplier: attempts.Backoff.Multiplier,
+			}
+		}
+
+		for i := 0; i < attempts.Count; i++ {
 			attempt, err := factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
 			if err != nil {
 				return atc.Plan{}, err
 			}
 
-			retryStep[i] = attempt
+			retryStep.Attempts[i] = attempt
 		}
 
 		plan = factory.planFactory.NewPlan(retryStep)
 	}
 
-	return factory.applyHooks(constructionParams{
+	plan, err = factory.applyHooks(constructionParams{
 		plan:          plan,
 		hooks:         planConfig.Hooks(),
 		resources:     resources,
 		resourceTypes: resourceTypes,
 		inputs:        inputs,
 	})
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	if planConfig.If != "" {
+		plan = factory.planFactory.NewPlan(atc.IfPlan{
+			Condition: planConfig.If,
+			Step:      plan,
+		})
+	}
+
+	return plan, nil
+}
+
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	stepConfig := planConfig
+	stepConfig.Across = nil
+	stepConfig.FailFast = false
+
+	step, err := factory.constructPlanFromConfig(stepConfig, resources, resourceTypes, inputs)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	vars := make([]atc.AcrossVar, len(planConfig.Across))
+	for i, acrossVar := range planConfig.Across {
+		vars[i] = atc.AcrossVar{
+			Var:         acrossVar.Var,
+			Values:      acrossVar.Values,
+			File:        acrossVar.File,
+			MaxInFlight: acrossVar.MaxInFlight,
+		}
+	}
+
+	return factory.planFactory.NewPlan(atc.AcrossPlan{
+		Vars:     vars,
+		Step:     step,
+		FailFast: planConfig.FailFast,
+	}), nil
+}
+
+func (factory *buildFactory) constructInParallelPlan(
+	inParallelConfig atc.InParallelConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	steps := []atc.Plan{}
+
+	for _, planConfig := range inParallelConfig.Steps {
+		step, err := factory.constructPlanFromConfig(
+			planConfig,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		steps = append(steps, step)
+	}
+
+	return factory.planFactory.NewPlan(atc.InParallelPlan{
+		Steps:    steps,
+		Limit:    inParallelConfig.Limit,
+		FailFast: inParallelConfig.FailFast,
+	}), nil
 }
 
 func (factory *buildFactory) constructUnhookedPlan(
@@ -165,6 +259,11 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		workerSelector := planConfig.WorkerSelector
+		if workerSelector == "" {
+			workerSelector = resource.WorkerSelector
+		}
+
 		atcPutPlan := atc.PutPlan{
 			Type:     resource.Type,
 			Name:     logicalName,
@@ -174,6 +273,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Tags:     planConfig.Tags,
 			Inputs:   planConfig.Inputs,
 
+			WorkerSelector: workerSelector,
+
 			VersionedResourceTypes: resourceTypes,
 		}
 
@@ -189,6 +290,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Tags:   planConfig.Tags,
 			Source: resource.Source,
 
+			WorkerSelector: workerSelector,
+
 			VersionedResourceTypes: resourceTypes,
 		})
 
@@ -208,6 +311,11 @@ func (factory *buildFactory) constructUnhookedPlan(
 			return atc.Plan{}, ErrResourceNotFound
 		}
 
+		workerSelector := planConfig.WorkerSelector
+		if workerSelector == "" {
+			workerSelector = resource.WorkerSelector
+		}
+
 		name := planConfig.Get
 		var version atc.Version
 		for _, input := range inputs {
@@ -226,6 +334,8 @@ func (factory *buildFactory) constructUnhookedPlan(
 			Version:  &version,
 			Tags:     planConfig.Tags,
 
+			WorkerSelector: workerSelector,
+
 			VersionedResourceTypes: resourceTypes,
 		})
 
@@ -241,9 +351,27 @@ func (factory *buildFactory) constructUnhookedPlan(
 			InputMapping:      planConfig.InputMapping,
 			OutputMapping:     planConfig.OutputMapping,
 			ImageArtifactName: planConfig.ImageArtifactName,
+			WorkerSelector:    planConfig.WorkerSelector,
 
 			VersionedResourceTypes: resourceTypes,
 		})
+
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name:         planConfig.SetPipeline,
+			File:         planConfig.TaskConfigPath,
+			Vars:         planConfig.TaskVars,
+			VarFiles:     planConfig.VarFiles,
+			InstanceVars: planConfig.InstanceVars,
+		})
+
+	case planConfig.LoadVar != "":
+		plan = factory.planFactory.NewPlan(atc.LoadVarPlan{
+			Name:   planConfig.LoadVar,
+			File:   planConfig.TaskConfigPath,
+			Format: planConfig.Format,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -260,23 +388,27 @@ func (factory *buildFactory) constructUnhookedPlan(
 		})
 
 	case planConfig.Aggregate != nil:
-		aggregate := atc.AggregatePlan{}
-
-		for _, planConfig := range *planConfig.Aggregate {
-			nextStep, err := factory.constructPlanFromConfig(
-				planConfig,
-				resources,
-				resourceTypes,
-				inputs,
-			)
-			if err != nil {
-				return atc.Plan{}, err
-			}
-
-			aggregate = append(aggregate, nextStep)
+		// aggregate is run as in_parallel, with every step running at once
+		plan, err = factory.constructInParallelPlan(
+			atc.InParallelConfig{Steps: *planConfig.Aggregate},
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
 		}
 
-		plan = factory.planFactory.NewPlan(aggregate)
+	case planConfig.InParallel != nil:
+		plan, err = factory.constructInParallelPlan(
+			*planConfig.InParallel,
+			resources,
+			resourceTypes,
+			inputs,
+		)
+		if err != nil {
+			return atc.Plan{}, err
+		}
 	}
 
 	if planConfig.Timeout != "" {
@@ -405,3 +537,72 @@ func (factory *buildFactory) abortIfPresent(cp constructionParams) (construction
 
 	return cp, nil
 }
+
+// withDefaultStepTimeout returns a copy of the job in which every get, put,
+// task, set_pipeline and load_var step that does not configure its own
+// timeout has the job's default step timeout.
+func withDefaultStepTimeout(job atc.JobConfig) atc.JobConfig {
+	timeout := job.DefaultStepTimeout
+
+	job.Plan = defaultStepTimeouts(job.Plan, timeout)
+	job.Abort = defaultStepTimeoutHook(job.Abort, timeout)
+	job.Failure = defaultStepTimeoutHook(job.Failure, timeout)
+	job.Ensure = defaultStepTimeoutHook(job.Ensure, timeout)
+	job.Success = defaultStepTimeoutHook(job.Success, timeout)
+
+	return job
+}
+
+func defaultStepTimeouts(planSequence atc.PlanSequence, timeout string) atc.PlanSequence {
+	defaulted := make(atc.PlanSequence, len(planSequence))
+	for i, planConfig := range planSequence {
+		defaulted[i] = defaultStepTimeout(planConfig, timeout)
+	}
+
+	return defaulted
+}
+
+func defaultStepTimeout(planConfig atc.PlanConfig, timeout string) atc.PlanConfig {
+	isStep := planConfig.Get != "" ||
+		planConfig.Put != "" ||
+		planConfig.Task != "" ||
+		planConfig.SetPipeline != "" ||
+		planConfig.LoadVar != ""
+
+	if isStep && planConfig.Timeout == "" {
+		planConfig.Timeout = timeout
+	}
+
+	if planConfig.Do != nil {
+		do := defaultStepTimeouts(*planConfig.Do, timeout)
+		planConfig.Do = &do
+	}
+
+	if planConfig.Aggregate != nil {
+		aggregate := defaultStepTimeouts(*planConfig.Aggregate, timeout)
+		planConfig.Aggregate = &aggregate
+	}
+
+	if planConfig.InParallel != nil {
+		inParallel := *planConfig.InParallel
+		inParallel.Steps = defaultStepTimeouts(inParallel.Steps, timeout)
+		planConfig.InParallel = &inParallel
+	}
+
+	planConfig.Try = defaultStepTimeoutHook(planConfig.Try, timeout)
+	planConfig.Abort = defaultStepTimeoutHook(planConfig.Abort, timeout)
+	planConfig.Failure = defaultStepTimeoutHook(planConfig.Failure, timeout)
+	planConfig.Ensure = defaultStepTimeoutHook(planConfig.Ensure, timeout)
+	planConfig.Success = defaultStepTimeoutHook(planConfig.Success, timeout)
+
+	return planConfig
+}
+
+func defaultStepTimeoutHook(planConfig *atc.PlanConfig, timeout string) *atc.PlanConfig {
+	if planConfig == nil {
+		return nil
+	}
+
+	defaulted := defaultStepTimeout(*planConfig, timeout)
+	return &defaulted
+}
@@ -0,0 +1,138 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across Step", func() {
+	var (
+		resourceTypes atc.VersionedResourceTypes
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "registry-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+	})
+
+	Context("when there is a task annotated with 'across'", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task:     "some-task",
+						TaskVars: atc.Params{"go_version": "((.:go_version))"},
+						Across: []atc.AcrossVarConfig{
+							{
+								Var:         "go_version",
+								Values:      []interface{}{"1.11", "1.12"},
+								MaxInFlight: 2,
+							},
+							{
+								Var:  "platform",
+								File: "some-artifact/platforms.yml",
+							},
+						},
+						FailFast: true,
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{
+						Var:         "go_version",
+						Values:      []interface{}{"1.11", "1.12"},
+						MaxInFlight: 2,
+					},
+					{
+						Var:  "platform",
+						File: "some-artifact/platforms.yml",
+					},
+				},
+				Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "some-task",
+					Vars:                   atc.Params{"go_version": "((.:go_version))"},
+					VersionedResourceTypes: resourceTypes,
+				}),
+				FailFast: true,
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+
+		Context("when the step has hooks and attempts", func() {
+			It("runs them within each combination", func() {
+				actual, err := buildFactory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:     "some-task",
+							Attempts: &atc.AttemptsConfig{Count: 2},
+							Across: []atc.AcrossVarConfig{
+								{
+									Var:    "go_version",
+									Values: []interface{}{"1.11"},
+								},
+							},
+							Failure: &atc.PlanConfig{
+								Task: "some-failure-task",
+							},
+						},
+					},
+				}, nil, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				retryPlan := expectedPlanFactory.NewPlan(atc.RetryPlan{
+					Attempts: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some-task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some-task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					},
+				})
+
+				expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+					Vars: []atc.AcrossVar{
+						{
+							Var:    "go_version",
+							Values: []interface{}{"1.11"},
+						},
+					},
+					Step: expectedPlanFactory.NewPlan(atc.OnFailurePlan{
+						Step: retryPlan,
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some-failure-task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					}),
+				})
+
+				Expect(actual).To(Equal(expected))
+			})
+		})
+	})
+})
@@ -61,15 +61,17 @@ var _ = Describe("Factory Aggregate", func() {
 			}, resources, resourceTypes, nil)
 			Expect(err).NotTo(HaveOccurred())
 
-			expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "some thing",
-					VersionedResourceTypes: resourceTypes,
-				}),
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "some other thing",
-					VersionedResourceTypes: resourceTypes,
-				}),
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "some thing",
+						VersionedResourceTypes: resourceTypes,
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "some other thing",
+						VersionedResourceTypes: resourceTypes,
+					}),
+				},
 			})
 			Expect(actual).To(Equal(expected))
 		})
@@ -100,21 +102,25 @@ var _ = Describe("Factory Aggregate", func() {
 			}, resources, resourceTypes, nil)
 			Expect(err).NotTo(HaveOccurred())
 
-			expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "some thing",
-					VersionedResourceTypes: resourceTypes,
-				}),
-				expectedPlanFactory.NewPlan(atc.AggregatePlan{
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
 					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some nested thing",
+						Name:                   "some thing",
 						VersionedResourceTypes: resourceTypes,
 					}),
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some nested other thing",
-						VersionedResourceTypes: resourceTypes,
+					expectedPlanFactory.NewPlan(atc.InParallelPlan{
+						Steps: []atc.Plan{
+							expectedPlanFactory.NewPlan(atc.TaskPlan{
+								Name:                   "some nested thing",
+								VersionedResourceTypes: resourceTypes,
+							}),
+							expectedPlanFactory.NewPlan(atc.TaskPlan{
+								Name:                   "some nested other thing",
+								VersionedResourceTypes: resourceTypes,
+							}),
+						},
 					}),
-				}),
+				},
 			})
 			Expect(actual).To(Equal(expected))
 		})
@@ -138,17 +144,19 @@ var _ = Describe("Factory Aggregate", func() {
 			}, resources, resourceTypes, nil)
 			Expect(err).NotTo(HaveOccurred())
 
-			expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{
-				expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
-					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some thing",
-						VersionedResourceTypes: resourceTypes,
-					}),
-					Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some success hook",
-						VersionedResourceTypes: resourceTypes,
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some thing",
+							VersionedResourceTypes: resourceTypes,
+						}),
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some success hook",
+							VersionedResourceTypes: resourceTypes,
+						}),
 					}),
-				}),
+				},
 			})
 			Expect(actual).To(Equal(expected))
 		})
@@ -173,11 +181,13 @@ var _ = Describe("Factory Aggregate", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			expected := expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
-				Step: expectedPlanFactory.NewPlan(atc.AggregatePlan{
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some thing",
-						VersionedResourceTypes: resourceTypes,
-					}),
+				Step: expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some thing",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					},
 				}),
 				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
 					Name:                   "some success hook",
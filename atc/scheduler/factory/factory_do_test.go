@@ -120,11 +120,13 @@ var _ = Describe("Factory Do", func() {
 					Name:                   "some thing",
 					VersionedResourceTypes: resourceTypes,
 				}),
-				expectedPlanFactory.NewPlan(atc.AggregatePlan{
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some other thing",
-						VersionedResourceTypes: resourceTypes,
-					}),
+				expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some other thing",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					},
 				}),
 				expectedPlanFactory.NewPlan(atc.TaskPlan{
 					Name:                   "some thing-2",
@@ -165,17 +167,19 @@ var _ = Describe("Factory Do", func() {
 					Name:                   "starting-task",
 					VersionedResourceTypes: resourceTypes,
 				}),
-				Next: expectedPlanFactory.NewPlan(atc.AggregatePlan{
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some thing",
-						VersionedResourceTypes: resourceTypes,
-					}),
-					expectedPlanFactory.NewPlan(atc.DoPlan{
+				Next: expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
 						expectedPlanFactory.NewPlan(atc.TaskPlan{
-							Name:                   "some other thing",
+							Name:                   "some thing",
 							VersionedResourceTypes: resourceTypes,
 						}),
-					}),
+						expectedPlanFactory.NewPlan(atc.DoPlan{
+							expectedPlanFactory.NewPlan(atc.TaskPlan{
+								Name:                   "some other thing",
+								VersionedResourceTypes: resourceTypes,
+							}),
+						}),
+					},
 				}),
 			})
 
@@ -211,25 +215,27 @@ var _ = Describe("Factory Do", func() {
 			}, resources, resourceTypes, nil)
 			Expect(err).NotTo(HaveOccurred())
 
-			expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "some thing",
-					VersionedResourceTypes: resourceTypes,
-				}),
-				expectedPlanFactory.NewPlan(atc.DoPlan{
+			expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+				Steps: []atc.Plan{
 					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some other thing",
+						Name:                   "some thing",
 						VersionedResourceTypes: resourceTypes,
 					}),
+					expectedPlanFactory.NewPlan(atc.DoPlan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some other thing",
+							VersionedResourceTypes: resourceTypes,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some other thing-2",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					}),
 					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some other thing-2",
+						Name:                   "some thing-2",
 						VersionedResourceTypes: resourceTypes,
 					}),
-				}),
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "some thing-2",
-					VersionedResourceTypes: resourceTypes,
-				}),
+				},
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -75,6 +75,57 @@ var _ = Describe("Factory Get", func() {
 		})
 	})
 
+	Context("with a worker selector", func() {
+		BeforeEach(func() {
+			resources[0].WorkerSelector = "zone=us-east"
+
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Get:      "some-get",
+						Resource: "some-resource",
+					},
+					{
+						Get:            "some-other-get",
+						Resource:       "some-resource",
+						WorkerSelector: "disk in (ssd, nvme)",
+					},
+				},
+			}
+		})
+
+		It("uses the step's selector, or else the resource's", func() {
+			actual, err := buildFactory.Create(input, resources, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.DoPlan{
+				expectedPlanFactory.NewPlan(atc.GetPlan{
+					Type:     "git",
+					Name:     "some-get",
+					Resource: "some-resource",
+					Source: atc.Source{
+						"uri": "git://some-resource",
+					},
+					Version:                &version,
+					WorkerSelector:         "zone=us-east",
+					VersionedResourceTypes: resourceTypes,
+				}),
+				expectedPlanFactory.NewPlan(atc.GetPlan{
+					Type:     "git",
+					Name:     "some-other-get",
+					Resource: "some-resource",
+					Source: atc.Source{
+						"uri": "git://some-resource",
+					},
+					Version:                &version,
+					WorkerSelector:         "disk in (ssd, nvme)",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
 	Context("with a get for a non-existent resource", func() {
 		BeforeEach(func() {
 			input = atc.JobConfig{
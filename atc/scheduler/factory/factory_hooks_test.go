@@ -238,17 +238,21 @@ var _ = Describe("Factory Hooks", func() {
 					Name:                   "some-task",
 					VersionedResourceTypes: resourceTypes,
 				}),
-				Next: expectedPlanFactory.NewPlan(atc.AggregatePlan{
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "agg-task-1",
-						VersionedResourceTypes: resourceTypes,
-					}),
-					expectedPlanFactory.NewPlan(atc.AggregatePlan{
+				Next: expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
 						expectedPlanFactory.NewPlan(atc.TaskPlan{
-							Name:                   "agg-agg-task-1",
+							Name:                   "agg-task-1",
 							VersionedResourceTypes: resourceTypes,
 						}),
-					}),
+						expectedPlanFactory.NewPlan(atc.InParallelPlan{
+							Steps: []atc.Plan{
+								expectedPlanFactory.NewPlan(atc.TaskPlan{
+									Name:                   "agg-agg-task-1",
+									VersionedResourceTypes: resourceTypes,
+								}),
+							},
+						}),
+					},
 				}),
 			})
 
@@ -402,21 +406,23 @@ var _ = Describe("Factory Hooks", func() {
 					Name:                   "some-task",
 					VersionedResourceTypes: resourceTypes,
 				}),
-				Next: expectedPlanFactory.NewPlan(atc.AggregatePlan{
-					expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
-						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
-							Name:                   "agg-task-1",
-							VersionedResourceTypes: resourceTypes,
+				Next: expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+							Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+								Name:                   "agg-task-1",
+								VersionedResourceTypes: resourceTypes,
+							}),
+							Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+								Name:                   "agg-task-1-success",
+								VersionedResourceTypes: resourceTypes,
+							}),
 						}),
-						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
-							Name:                   "agg-task-1-success",
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "agg-task-2",
 							VersionedResourceTypes: resourceTypes,
 						}),
-					}),
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "agg-task-2",
-						VersionedResourceTypes: resourceTypes,
-					}),
+					},
 				}),
 			})
 
@@ -0,0 +1,142 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory If Step", func() {
+	var (
+		resourceTypes atc.VersionedResourceTypes
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "registry-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+	})
+
+	Context("when there is a task with an 'if'", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+						If:   `$BUILD_PIPELINE_NAME == "main"`,
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.IfPlan{
+				Condition: `$BUILD_PIPELINE_NAME == "main"`,
+				Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "some-task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+
+		Context("when the step has hooks and attempts", func() {
+			It("skips them along with the step", func() {
+				actual, err := buildFactory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:     "some-task",
+							If:       `((.:deploy))`,
+							Attempts: &atc.AttemptsConfig{Count: 2},
+							Success: &atc.PlanConfig{
+								Task: "some-success-task",
+							},
+						},
+					},
+				}, nil, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				retryPlan := expectedPlanFactory.NewPlan(atc.RetryPlan{
+					Attempts: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some-task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some-task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					},
+				})
+
+				expected := expectedPlanFactory.NewPlan(atc.IfPlan{
+					Condition: `((.:deploy))`,
+					Step: expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+						Step: retryPlan,
+						Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some-success-task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					}),
+				})
+
+				Expect(actual).To(Equal(expected))
+			})
+		})
+
+		Context("when the step also has 'across'", func() {
+			It("evaluates the condition within each combination", func() {
+				actual, err := buildFactory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task: "some-task",
+							If:   `((.:platform)) == "linux"`,
+							Across: []atc.AcrossVarConfig{
+								{
+									Var:    "platform",
+									Values: []interface{}{"linux", "darwin"},
+								},
+							},
+						},
+					},
+				}, nil, resourceTypes, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+					Vars: []atc.AcrossVar{
+						{
+							Var:    "platform",
+							Values: []interface{}{"linux", "darwin"},
+						},
+					},
+					Step: expectedPlanFactory.NewPlan(atc.IfPlan{
+						Condition: `((.:platform)) == "linux"`,
+						Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some-task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					}),
+				})
+
+				Expect(actual).To(Equal(expected))
+			})
+		})
+	})
+})
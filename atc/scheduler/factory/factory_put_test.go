@@ -269,24 +269,26 @@ var _ = Describe("Factory Put", func() {
 					VersionedResourceTypes: resourceTypes,
 				})
 
-				expected := expectedPlanFactory.NewPlan(atc.AggregatePlan{
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "some thing",
-						VersionedResourceTypes: resourceTypes,
-					}),
-					expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
-						Step: putPlan,
-						Next: expectedPlanFactory.NewPlan(atc.GetPlan{
-							Type:     "git",
-							Name:     "some-resource",
-							Resource: "some-resource",
-							Source: atc.Source{
-								"uri": "git://some-resource",
-							},
-							VersionFrom:            &putPlan.ID,
+				expected := expectedPlanFactory.NewPlan(atc.InParallelPlan{
+					Steps: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "some thing",
 							VersionedResourceTypes: resourceTypes,
 						}),
-					}),
+						expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+							Step: putPlan,
+							Next: expectedPlanFactory.NewPlan(atc.GetPlan{
+								Type:     "git",
+								Name:     "some-resource",
+								Resource: "some-resource",
+								Source: atc.Source{
+									"uri": "git://some-resource",
+								},
+								VersionFrom:            &putPlan.ID,
+								VersionedResourceTypes: resourceTypes,
+							}),
+						}),
+					},
 				})
 				Expect(actual).To(testhelpers.MatchPlan(expected))
 			})
@@ -41,25 +41,27 @@ var _ = Describe("Factory Retry Step", func() {
 				Plan: atc.PlanSequence{
 					{
 						Task:     "second task",
-						Attempts: 3,
+						Attempts: &atc.AttemptsConfig{Count: 3},
 					},
 				},
 			}, nil, resourceTypes, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			expected := expectedPlanFactory.NewPlan(atc.RetryPlan{
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "second task",
-					VersionedResourceTypes: resourceTypes,
-				}),
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "second task",
-					VersionedResourceTypes: resourceTypes,
-				}),
-				expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "second task",
-					VersionedResourceTypes: resourceTypes,
-				}),
+				Attempts: []atc.Plan{
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "second task",
+						VersionedResourceTypes: resourceTypes,
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "second task",
+						VersionedResourceTypes: resourceTypes,
+					}),
+					expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "second task",
+						VersionedResourceTypes: resourceTypes,
+					}),
+				},
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -72,7 +74,7 @@ var _ = Describe("Factory Retry Step", func() {
 				Plan: atc.PlanSequence{
 					{
 						Task:     "second task",
-						Attempts: 3,
+						Attempts: &atc.AttemptsConfig{Count: 3},
 						Success: &atc.PlanConfig{
 							Task: "second task",
 						},
@@ -83,6 +85,53 @@ var _ = Describe("Factory Retry Step", func() {
 
 			expected := expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
 				Step: expectedPlanFactory.NewPlan(atc.RetryPlan{
+					Attempts: []atc.Plan{
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "second task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "second task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+						expectedPlanFactory.NewPlan(atc.TaskPlan{
+							Name:                   "second task",
+							VersionedResourceTypes: resourceTypes,
+						}),
+					},
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "second task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+
+	Context("when there is a task annotated with 'attempts' with a backoff and conditions", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "second task",
+						Attempts: &atc.AttemptsConfig{
+							Count: 2,
+							Backoff: &atc.BackoffConfig{
+								Initial:    "1s",
+								Max:        "1m",
+								Multiplier: 3,
+							},
+							On: []string{atc.AttemptOnError},
+						},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.RetryPlan{
+				Attempts: []atc.Plan{
 					expectedPlanFactory.NewPlan(atc.TaskPlan{
 						Name:                   "second task",
 						VersionedResourceTypes: resourceTypes,
@@ -91,15 +140,13 @@ var _ = Describe("Factory Retry Step", func() {
 						Name:                   "second task",
 						VersionedResourceTypes: resourceTypes,
 					}),
-					expectedPlanFactory.NewPlan(atc.TaskPlan{
-						Name:                   "second task",
-						VersionedResourceTypes: resourceTypes,
-					}),
-				}),
-				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
-					Name:                   "second task",
-					VersionedResourceTypes: resourceTypes,
-				}),
+				},
+				Backoff: &atc.RetryBackoff{
+					Initial:    "1s",
+					Max:        "1m",
+					Multiplier: 3,
+				},
+				On: []string{atc.AttemptOnError},
 			})
 
 			Expect(actual).To(testhelpers.MatchPlan(expected))
@@ -0,0 +1,54 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory SetPipeline Step", func() {
+	var (
+		resourceTypes atc.VersionedResourceTypes
+
+		buildFactory        factory.BuildFactory
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+		buildFactory = factory.NewBuildFactory(42, actualPlanFactory)
+
+		resourceTypes = atc.VersionedResourceTypes{}
+	})
+
+	Context("when there is a set_pipeline step", func() {
+		It("builds correctly", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-artifact/pipeline.yml",
+						TaskVars:       atc.Params{"some": "var"},
+						VarFiles:       []string{"some-artifact/vars.yml"},
+						InstanceVars:   atc.InstanceVars{"branch": "5.10"},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.SetPipelinePlan{
+				Name:         "some-pipeline",
+				File:         "some-artifact/pipeline.yml",
+				Vars:         map[string]interface{}{"some": "var"},
+				VarFiles:     []string{"some-artifact/vars.yml"},
+				InstanceVars: atc.InstanceVars{"branch": "5.10"},
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
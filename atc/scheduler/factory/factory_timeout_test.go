@@ -57,4 +57,56 @@ var _ = Describe("Factory Timeout Step", func() {
 			Expect(actual).To(Equal(expected))
 		})
 	})
+
+	Context("When the job has a default step timeout", func() {
+		It("applies it to every step without a timeout of its own", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				DefaultStepTimeout: "1h",
+				Plan: atc.PlanSequence{
+					{
+						Task: "first task",
+					},
+					{
+						Task:    "second task",
+						Timeout: "10s",
+					},
+				},
+				Ensure: &atc.PlanConfig{
+					Task: "cleanup task",
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			firstPlan := expectedPlanFactory.NewPlan(atc.TimeoutPlan{
+				Duration: "1h",
+				Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "first task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+
+			secondPlan := expectedPlanFactory.NewPlan(atc.TimeoutPlan{
+				Duration: "10s",
+				Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "second task",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+
+			doPlan := expectedPlanFactory.NewPlan(atc.DoPlan{firstPlan, secondPlan})
+
+			expected := expectedPlanFactory.NewPlan(atc.EnsurePlan{
+				Step: doPlan,
+				Next: expectedPlanFactory.NewPlan(atc.TimeoutPlan{
+					Duration: "1h",
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "cleanup task",
+						VersionedResourceTypes: resourceTypes,
+					}),
+				}),
+			})
+
+			Expect(actual).To(Equal(expected))
+		})
+	})
 })
@@ -51,6 +51,20 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 			pinnedVersionID = id
 		}
 
+		var filter algorithm.VersionFilter
+		if input.Version.HasFilter() {
+			var found bool
+			var err error
+			filter, found, err = i.versionFilter(input)
+			if err != nil {
+				return nil, err
+			}
+
+			if !found {
+				continue
+			}
+		}
+
 		jobs := algorithm.JobSet{}
 		for _, passedJobName := range input.Passed {
 			jobs[db.JobIDs[passedJobName]] = struct{}{}
@@ -63,8 +77,43 @@ func (i *transformer) TransformInputConfigs(db *algorithm.VersionsDB, jobName st
 			ResourceID:      db.ResourceIDs[input.Resource],
 			Passed:          jobs,
 			JobID:           db.JobIDs[jobName],
+			VersionFilter:   filter,
 		})
 	}
 
 	return inputConfigs, nil
 }
+
+// versionFilter loads the versions of the input's resource so that only
+// resources with filtered inputs have their versions loaded.
+func (i *transformer) versionFilter(input atc.JobInput) (algorithm.VersionFilter, bool, error) {
+	versionFilter, err := input.Version.Filter()
+	if err != nil {
+		return nil, false, err
+	}
+
+	resource, found, err := i.pipeline.Resource(input.Resource)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	versions, err := resource.EnabledVersions()
+	if err != nil {
+		return nil, false, err
+	}
+
+	allowed := map[int]bool{}
+	for id, version := range versions {
+		if versionFilter(version) {
+			allowed[id] = true
+		}
+	}
+
+	return func(versionID int) bool {
+		return allowed[versionID]
+	}, true, nil
+}
@@ -106,6 +106,90 @@ var _ = Describe("Transformer", func() {
 				})
 			})
 
+			Context("when an input has a version filter", func() {
+				var fakeResource *dbfakes.FakeResource
+
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version: &atc.VersionConfig{
+							Every:  true,
+							Semver: &atc.VersionSemverConfig{Field: "tag", Constraint: ">=1.2 <2"},
+						},
+					}}
+
+					fakeResource = new(dbfakes.FakeResource)
+					fakeResource.EnabledVersionsReturns(map[int]atc.Version{
+						1: {"tag": "1.4.0"},
+						2: {"tag": "2.0.0"},
+					}, nil)
+					fakePipeline.ResourceReturns(fakeResource, true, nil)
+				})
+
+				It("filters the versions of the resource", func() {
+					Expect(tranformErr).ToNot(HaveOccurred())
+					Expect(fakePipeline.ResourceArgsForCall(0)).To(Equal("r1"))
+
+					Expect(algorithmInputs).To(HaveLen(1))
+					Expect(algorithmInputs[0].UseEveryVersion).To(BeTrue())
+
+					filter := algorithmInputs[0].VersionFilter
+					Expect(filter).ToNot(BeNil())
+					Expect(filter(1)).To(BeTrue())
+					Expect(filter(2)).To(BeFalse())
+					Expect(filter(3)).To(BeFalse())
+				})
+
+				Context("when the filter is invalid", func() {
+					BeforeEach(func() {
+						jobInputs[0].Version.Semver.Constraint = ">=latest"
+					})
+
+					It("returns an error without loading versions", func() {
+						Expect(tranformErr).To(HaveOccurred())
+						Expect(fakeResource.EnabledVersionsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when loading the versions fails", func() {
+					disaster := errors.New("oh no")
+
+					BeforeEach(func() {
+						fakeResource.EnabledVersionsReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(tranformErr).To(Equal(disaster))
+					})
+				})
+
+				Context("when the resource is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.ResourceReturns(nil, false, nil)
+					})
+
+					It("omits the input", func() {
+						Expect(tranformErr).ToNot(HaveOccurred())
+						Expect(algorithmInputs).To(BeEmpty())
+					})
+				})
+			})
+
+			Context("when an input without a version filter is transformed", func() {
+				BeforeEach(func() {
+					jobInputs = []atc.JobInput{{
+						Name:     "job-input-1",
+						Resource: "r1",
+						Version:  &atc.VersionConfig{Every: true},
+					}}
+				})
+
+				It("does not load the versions", func() {
+					Expect(fakePipeline.ResourceCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when an input has a pinned version", func() {
 				BeforeEach(func() {
 					jobInputs = []atc.JobInput{
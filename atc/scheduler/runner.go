@@ -56,15 +56,29 @@ func (runner *Runner) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 	defer runner.Logger.Info("done")
 
+	notifier, err := runner.Pipeline.SchedulingNotifier()
+	if err != nil {
+		runner.Logger.Error("failed-to-listen-for-scheduling-notifications", err)
+		return err
+	}
+
+	defer notifier.Close()
+
+	interval := runner.Interval
+
 dance:
 	for {
-		err := runner.tick(runner.Logger.Session("tick"))
+		err := runner.tick(runner.Logger.Session("tick"), interval)
 		if err != nil {
 			return err
 		}
 
 		select {
 		case <-time.After(runner.Interval):
+			interval = runner.Interval
+		case <-notifier.Notify():
+			// schedule straight away, even if another ATC scheduled recently
+			interval = 0
 		case <-signals:
 			break dance
 		}
@@ -73,12 +87,12 @@ dance:
 	return nil
 }
 
-func (runner *Runner) tick(logger lager.Logger) error {
+func (runner *Runner) tick(logger lager.Logger, interval time.Duration) error {
 	if runner.Noop {
 		return nil
 	}
 
-	schedulingLock, acquired, err := runner.Pipeline.AcquireSchedulingLock(logger, runner.Interval)
+	schedulingLock, acquired, err := runner.Pipeline.AcquireSchedulingLock(logger, interval)
 	if err != nil {
 		logger.Error("failed-to-acquire-scheduling-lock", err)
 		return nil
@@ -24,9 +24,13 @@ var _ = Describe("Runner", func() {
 		fakePipeline *dbfakes.FakePipeline
 		scheduler    *schedulerfakes.FakeBuildScheduler
 		noop         bool
+		interval     time.Duration
 
 		lock *lockfakes.FakeLock
 
+		fakeNotifier  *dbfakes.FakeNotifier
+		notifications chan struct{}
+
 		someVersions *algorithm.VersionsDB
 
 		process ifrit.Process
@@ -77,6 +81,7 @@ var _ = Describe("Runner", func() {
 
 		scheduler = new(schedulerfakes.FakeBuildScheduler)
 		noop = false
+		interval = 100 * time.Millisecond
 
 		someVersions = &algorithm.VersionsDB{
 			BuildOutputs: []algorithm.BuildOutput{
@@ -109,11 +114,11 @@ var _ = Describe("Runner", func() {
 		fakeResource1 = new(dbfakes.FakeResource)
 		fakeResource1.NameReturns("some-resource")
 		fakeResource1.TypeReturns("git")
-		fakeResource1.SourceReturns(atc.Source{"uri": "git://some-resource"})
+		fakeResource1.SourceWithDefaultsReturns(atc.Source{"uri": "git://some-resource"})
 		fakeResource2 = new(dbfakes.FakeResource)
 		fakeResource2.NameReturns("some-dependant-resource")
 		fakeResource2.TypeReturns("git")
-		fakeResource2.SourceReturns(atc.Source{"uri": "git://some-dependant-resource"})
+		fakeResource2.SourceWithDefaultsReturns(atc.Source{"uri": "git://some-dependant-resource"})
 
 		fakePipeline.JobsReturns([]db.Job{fakeJob1, fakeJob2}, nil)
 		fakePipeline.ResourcesReturns(db.Resources{fakeResource1, fakeResource2}, nil)
@@ -121,6 +126,11 @@ var _ = Describe("Runner", func() {
 
 		lock = new(lockfakes.FakeLock)
 		fakePipeline.AcquireSchedulingLockReturns(lock, true, nil)
+
+		notifications = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notifications)
+		fakePipeline.SchedulingNotifierReturns(fakeNotifier, nil)
 	})
 
 	JustBeforeEach(func() {
@@ -129,7 +139,7 @@ var _ = Describe("Runner", func() {
 			Pipeline:  fakePipeline,
 			Scheduler: scheduler,
 			Noop:      noop,
-			Interval:  100 * time.Millisecond,
+			Interval:  interval,
 		})
 	})
 
@@ -144,6 +154,39 @@ var _ = Describe("Runner", func() {
 		Expect(duration).To(Equal(100 * time.Millisecond))
 	})
 
+	Context("when notified to schedule", func() {
+		BeforeEach(func() {
+			interval = time.Hour
+		})
+
+		It("schedules straight away, ignoring when the pipeline was last scheduled", func() {
+			Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(Equal(1))
+
+			notifications <- struct{}{}
+
+			Eventually(fakePipeline.AcquireSchedulingLockCallCount).Should(Equal(2))
+
+			_, duration := fakePipeline.AcquireSchedulingLockArgsForCall(1)
+			Expect(duration).To(BeZero())
+		})
+
+		It("stops listening when it exits", func() {
+			ginkgomon.Interrupt(process)
+
+			Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when listening for notifications fails", func() {
+		BeforeEach(func() {
+			fakePipeline.SchedulingNotifierReturns(nil, errors.New("nope"))
+		})
+
+		It("exits with the error", func() {
+			Eventually(process.Wait()).Should(Receive(Equal(errors.New("nope"))))
+		})
+	})
+
 	Context("when it can't get the lock", func() {
 		BeforeEach(func() {
 			fakePipeline.AcquireSchedulingLockReturns(nil, false, nil)
@@ -227,7 +270,7 @@ func fakeDBResourceType(t atc.VersionedResourceType) *dbfakes.FakeResourceType {
 	fake := new(dbfakes.FakeResourceType)
 	fake.NameReturns(t.Name)
 	fake.TypeReturns(t.Type)
-	fake.SourceReturns(t.Source)
+	fake.SourceWithDefaultsReturns(t.Source)
 	fake.VersionReturns(t.Version)
 	return fake
 }
@@ -0,0 +1,304 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var localVarRegex = regexp.MustCompile(`\(\(\.:([-\w\p{L}]+)((?:\.[-\w\p{L}]+)*)\)\)`)
+
+// InterpolateLocalVars replaces references to build-local variables, e.g.
+// ((.:name)) or ((.:name.field)), within a JSON-decoded value. References to
+// variables that are not given are left untouched so that they can be
+// resolved later on.
+func InterpolateLocalVars(node interface{}, vars map[string]interface{}) (interface{}, error) {
+	switch typedNode := node.(type) {
+	case map[string]interface{}:
+		for key, val := range typedNode {
+			evaluated, err := InterpolateLocalVars(val, vars)
+			if err != nil {
+				return nil, err
+			}
+
+			typedNode[key] = evaluated
+		}
+
+	case []interface{}:
+		for i, val := range typedNode {
+			evaluated, err := InterpolateLocalVars(val, vars)
+			if err != nil {
+				return nil, err
+			}
+
+			typedNode[i] = evaluated
+		}
+
+	case string:
+		return interpolateLocalVarsInString(typedNode, vars)
+	}
+
+	return node, nil
+}
+
+// UndefinedLocalVarsError is returned when a value refers to build-local
+// variables which have not been set.
+type UndefinedLocalVarsError struct {
+	Vars []string
+}
+
+func (err UndefinedLocalVarsError) Error() string {
+	return fmt.Sprintf("undefined local vars: %s", strings.Join(err.Vars, ", "))
+}
+
+// ResolveLocalVars interpolates build-local variables like
+// InterpolateLocalVars, but returns an UndefinedLocalVarsError if the value
+// refers to any variables that are not given.
+func ResolveLocalVars(node interface{}, vars map[string]interface{}) (interface{}, error) {
+	resolved, err := InterpolateLocalVars(node, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	undefined := map[string]bool{}
+	collectLocalVarNames(resolved, undefined)
+
+	if len(undefined) > 0 {
+		names := []string{}
+		for name := range undefined {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return nil, UndefinedLocalVarsError{Vars: names}
+	}
+
+	return resolved, nil
+}
+
+func collectLocalVarNames(node interface{}, names map[string]bool) {
+	switch typedNode := node.(type) {
+	case map[string]interface{}:
+		for _, val := range typedNode {
+			collectLocalVarNames(val, names)
+		}
+
+	case []interface{}:
+		for _, val := range typedNode {
+			collectLocalVarNames(val, names)
+		}
+
+	case string:
+		for _, match := range localVarRegex.FindAllStringSubmatch(typedNode, -1) {
+			names[match[1]] = true
+		}
+	}
+}
+
+func interpolateLocalVarsInString(str string, vars map[string]interface{}) (interface{}, error) {
+	for _, match := range localVarRegex.FindAllStringSubmatch(str, -1) {
+		val, found, err := LookupLocalVar(vars, match[1], match[2])
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		// preserve the value's type when it makes up the entire string
+		if match[0] == str {
+			return val, nil
+		}
+
+		valStr, err := localVarString(match[0], val)
+		if err != nil {
+			return nil, err
+		}
+
+		str = strings.Replace(str, match[0], valStr, -1)
+	}
+
+	return str, nil
+}
+
+func localVarString(ref string, val interface{}) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case int, int64, bool:
+		return fmt.Sprintf("%v", v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("cannot interpolate local var '%s' of type %T within a string", strings.Trim(ref, "()"), val)
+	}
+}
+
+// SubstituteLocalVars replaces references to build-local variables within a
+// JSON-decoded value with references to the returned variables, which hold
+// their values. This way they are resolved in the same pass as the other
+// variables, and their values are never evaluated as a template themselves,
+// e.g. when a value loaded from an artifact contains ((secret)).
+//
+// It returns an UndefinedLocalVarsError if the value refers to any variables
+// that are not given.
+func SubstituteLocalVars(node interface{}, vars map[string]interface{}) (interface{}, boshtemplate.StaticVariables, error) {
+	substitutes := boshtemplate.StaticVariables{}
+
+	substituted, err := substituteLocalVars(node, vars, substitutes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	undefined := map[string]bool{}
+	collectLocalVarNames(substituted, undefined)
+
+	if len(undefined) > 0 {
+		names := []string{}
+		for name := range undefined {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return nil, nil, UndefinedLocalVarsError{Vars: names}
+	}
+
+	return substituted, substitutes, nil
+}
+
+func substituteLocalVars(node interface{}, vars map[string]interface{}, substitutes boshtemplate.StaticVariables) (interface{}, error) {
+	switch typedNode := node.(type) {
+	case map[string]interface{}:
+		for key, val := range typedNode {
+			substituted, err := substituteLocalVars(val, vars, substitutes)
+			if err != nil {
+				return nil, err
+			}
+
+			typedNode[key] = substituted
+		}
+
+	case []interface{}:
+		for i, val := range typedNode {
+			substituted, err := substituteLocalVars(val, vars, substitutes)
+			if err != nil {
+				return nil, err
+			}
+
+			typedNode[i] = substituted
+		}
+
+	case string:
+		str := typedNode
+
+		for _, match := range localVarRegex.FindAllStringSubmatch(typedNode, -1) {
+			// the var may have been referred to more than once
+			if !strings.Contains(str, match[0]) {
+				continue
+			}
+
+			val, found, err := LookupLocalVar(vars, match[1], match[2])
+			if err != nil {
+				return nil, err
+			}
+
+			if !found {
+				continue
+			}
+
+			// the template only interpolates strings and integers within a
+			// string, so the value is formatted the same way as when
+			// interpolating it directly
+			if match[0] != typedNode {
+				val, err = localVarString(match[0], val)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// the names of local vars cannot be parsed by the template, so
+			// they are given names it can parse
+			name := fmt.Sprintf("concourse-local-var-%d", len(substitutes))
+			substitutes[name] = decodeNumbers(val)
+
+			str = strings.Replace(str, match[0], "(("+name+"))", -1)
+		}
+
+		return str, nil
+	}
+
+	return node, nil
+}
+
+// decodeNumbers converts the json.Numbers within a value, e.g. loaded with a
+// load_var step, so that the template renders them as numbers.
+func decodeNumbers(val interface{}) interface{} {
+	switch typedVal := val.(type) {
+	case json.Number:
+		if i, err := typedVal.Int64(); err == nil {
+			return i
+		}
+
+		if f, err := typedVal.Float64(); err == nil {
+			return f
+		}
+
+		return typedVal.String()
+
+	case map[string]interface{}:
+		decoded := make(map[string]interface{}, len(typedVal))
+		for key, v := range typedVal {
+			decoded[key] = decodeNumbers(v)
+		}
+
+		return decoded
+
+	case []interface{}:
+		decoded := make([]interface{}, len(typedVal))
+		for i, v := range typedVal {
+			decoded[i] = decodeNumbers(v)
+		}
+
+		return decoded
+	}
+
+	return val
+}
+
+// LookupLocalVar returns the value of a build-local variable, descending into
+// the fields given by path, e.g. ".field.subfield". It is not found if the
+// variable is not set, and errors if one of the fields does not exist.
+func LookupLocalVar(vars map[string]interface{}, name string, path string) (interface{}, bool, error) {
+	val, found := vars[name]
+	if !found {
+		return nil, false, nil
+	}
+
+	if path == "" {
+		return val, true, nil
+	}
+
+	for _, key := range strings.Split(strings.TrimPrefix(path, "."), ".") {
+		fields, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("local var '%s' has no field '%s'", name, key)
+		}
+
+		val, found = fields[key]
+		if !found {
+			return nil, false, fmt.Errorf("local var '%s' has no field '%s'", name, key)
+		}
+	}
+
+	return val, true, nil
+}
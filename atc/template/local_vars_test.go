@@ -0,0 +1,122 @@
+package template_test
+
+import (
+	"encoding/json"
+
+	boshtemplate "github.com/cloudfoundry/bosh-cli/director/template"
+	"github.com/concourse/concourse/atc/template"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InterpolateLocalVars", func() {
+	var vars map[string]interface{}
+
+	BeforeEach(func() {
+		vars = map[string]interface{}{
+			"version":  "1.12",
+			"count":    3,
+			"platform": map[string]interface{}{"os": "linux", "arch": "amd64"},
+			"meta":     map[string]interface{}{"build": json.Number("42")},
+		}
+	})
+
+	It("interpolates numbers loaded from JSON within a string", func() {
+		result, err := template.InterpolateLocalVars("v((.:meta.build))", vars)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal("v42"))
+	})
+
+	It("interpolates local vars within nested values", func() {
+		result, err := template.InterpolateLocalVars(map[string]interface{}{
+			"image": "golang:((.:version))",
+			"list":  []interface{}{"((.:platform.os))-((.:platform.arch))", "((.:count))"},
+		}, vars)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(map[string]interface{}{
+			"image": "golang:1.12",
+			"list":  []interface{}{"linux-amd64", 3},
+		}))
+	})
+
+	It("preserves the type of a var that makes up an entire string", func() {
+		result, err := template.InterpolateLocalVars("((.:platform))", vars)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal(vars["platform"]))
+	})
+
+	It("leaves unknown vars and credential manager vars untouched", func() {
+		result, err := template.InterpolateLocalVars("((.:unknown)) ((secret))", vars)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal("((.:unknown)) ((secret))"))
+	})
+
+	It("errors when interpolating a map within a string", func() {
+		_, err := template.InterpolateLocalVars("os: ((.:platform))", vars)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("errors when a field does not exist", func() {
+		_, err := template.InterpolateLocalVars("((.:platform.bogus))", vars)
+		Expect(err).To(MatchError("local var 'platform' has no field 'bogus'"))
+	})
+})
+
+var _ = Describe("ResolveLocalVars", func() {
+	var vars map[string]interface{}
+
+	BeforeEach(func() {
+		vars = map[string]interface{}{"version": "1.12"}
+	})
+
+	It("interpolates local vars", func() {
+		result, err := template.ResolveLocalVars([]interface{}{"((.:version))", "((secret))"}, vars)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal([]interface{}{"1.12", "((secret))"}))
+	})
+
+	It("errors when local vars are undefined", func() {
+		_, err := template.ResolveLocalVars(map[string]interface{}{
+			"a": "((.:foo))",
+			"b": []interface{}{"((.:bar.baz)) ((.:foo))"},
+		}, vars)
+		Expect(err).To(Equal(template.UndefinedLocalVarsError{Vars: []string{"bar", "foo"}}))
+	})
+})
+
+var _ = Describe("SubstituteLocalVars", func() {
+	var vars map[string]interface{}
+
+	BeforeEach(func() {
+		vars = map[string]interface{}{
+			"version": "((secret))",
+			"meta":    map[string]interface{}{"build": json.Number("42")},
+		}
+	})
+
+	It("replaces local vars with vars holding their values", func() {
+		result, substitutes, err := template.SubstituteLocalVars([]interface{}{
+			"((.:version))",
+			"v((.:meta.build)) ((.:meta.build))",
+			"((.:meta))",
+			"((secret))",
+		}, vars)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(result).To(Equal([]interface{}{
+			"((concourse-local-var-0))",
+			"v((concourse-local-var-1)) ((concourse-local-var-1))",
+			"((concourse-local-var-2))",
+			"((secret))",
+		}))
+		Expect(substitutes).To(Equal(boshtemplate.StaticVariables{
+			"concourse-local-var-0": "((secret))",
+			"concourse-local-var-1": "42",
+			"concourse-local-var-2": map[string]interface{}{"build": int64(42)},
+		}))
+	})
+
+	It("errors when local vars are undefined", func() {
+		_, _, err := template.SubstituteLocalVars("((.:foo)) ((.:version))", vars)
+		Expect(err).To(Equal(template.UndefinedLocalVarsError{Vars: []string{"foo"}}))
+	})
+})
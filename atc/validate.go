@@ -3,11 +3,16 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/concourse/concourse/atc/condition"
 )
 
+var localVarNameRegex = regexp.MustCompile(`^[-\w]+$`)
+
 func formatErr(groupName string, err error) string {
 	lines := strings.Split(err.Error(), "\n")
 	indented := make([]string, len(lines))
@@ -49,9 +54,52 @@ func (c Config) Validate() ([]Warning, []string) {
 	}
 	warnings = append(warnings, jobWarnings...)
 
+	warnings = append(warnings, validateResourceTypeDefaults(c)...)
+
 	return warnings, errorMessages
 }
 
+// validateResourceTypeDefaults warns about defaults for types which nothing in
+// the pipeline uses. They are only warnings as the defaults may still apply to
+// the image resources of tasks loaded from files.
+func validateResourceTypeDefaults(c Config) []Warning {
+	usedTypes := map[string]bool{}
+	for _, resource := range c.Resources {
+		usedTypes[resource.Type] = true
+	}
+
+	for _, resourceType := range c.ResourceTypes {
+		usedTypes[resourceType.Type] = true
+	}
+
+	for _, job := range c.Jobs {
+		for _, plan := range job.Plans() {
+			if plan.TaskConfig != nil && plan.TaskConfig.ImageResource != nil {
+				usedTypes[plan.TaskConfig.ImageResource.Type] = true
+			}
+		}
+	}
+
+	unused := []string{}
+	for resourceType := range c.ResourceTypeDefaults {
+		if !usedTypes[resourceType] {
+			unused = append(unused, resourceType)
+		}
+	}
+
+	sort.Strings(unused)
+
+	warnings := []Warning{}
+	for _, resourceType := range unused {
+		warnings = append(warnings, Warning{
+			Type:    "pipeline",
+			Message: fmt.Sprintf("resource_type_defaults.%s is not used by any resource, resource type or task image resource", resourceType),
+		})
+	}
+
+	return warnings
+}
+
 func validateGroups(c Config) error {
 	errorMessages := []string{}
 
@@ -120,6 +168,39 @@ func validateResources(c Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.Webhook != nil {
+			if resource.Webhook.Secret == "" {
+				errorMessages = append(errorMessages, identifier+".webhook has no secret")
+			}
+
+			if resource.Webhook.Filter != "" {
+				_, err := condition.Parse(resource.Webhook.Filter)
+				if err != nil {
+					errorMessages = append(errorMessages, identifier+fmt.Sprintf(".webhook has an invalid filter: %s", err))
+				}
+			}
+		}
+
+		if resource.WorkerSelector != "" {
+			_, err := resource.WorkerSelector.Requirements()
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an invalid worker_selector: %s", err))
+			}
+		}
+
+		if resource.VersionHistory != nil {
+			if resource.VersionHistory.Keep < 0 {
+				errorMessages = append(errorMessages, identifier+".version_history.keep must not be negative")
+			}
+
+			keepFor, err := resource.VersionHistory.KeepForDuration()
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".version_history.keep_for is invalid: %s", err))
+			} else if keepFor < 0 {
+				errorMessages = append(errorMessages, identifier+".version_history.keep_for must not be negative")
+			}
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -156,6 +237,13 @@ func validateResourceTypes(c Config) error {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resourceType.WorkerSelector != "" {
+			_, err := resourceType.WorkerSelector.Requirements()
+			if err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an invalid worker_selector: %s", err))
+			}
+		}
 	}
 
 	return compositeErr(errorMessages)
@@ -224,6 +312,18 @@ func validateJobs(c Config) ([]Warning, error) {
 			)
 		}
 
+		if job.BuildTimeout != "" {
+			if _, err := time.ParseDuration(job.BuildTimeout); err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".build_timeout refers to a duration that could not be parsed ('%s')", job.BuildTimeout))
+			}
+		}
+
+		if job.DefaultStepTimeout != "" {
+			if _, err := time.ParseDuration(job.DefaultStepTimeout); err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".default_step_timeout refers to a duration that could not be parsed ('%s')", job.DefaultStepTimeout))
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -319,6 +419,14 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		foundTypes.Find("task")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
+	if plan.LoadVar != "" {
+		foundTypes.Find("load_var")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -327,6 +435,10 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		foundTypes.Find("aggregate")
 	}
 
+	if plan.InParallel != nil {
+		foundTypes.Find("in_parallel")
+	}
+
 	if plan.Try != nil {
 		foundTypes.Find("try")
 	}
@@ -355,11 +467,24 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			errorMessages = append(errorMessages, planErrMessages...)
 		}
 
+	case plan.InParallel != nil:
+		if plan.InParallel.Limit < 0 {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.limit", identifier)
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid limit (%d)", plan.InParallel.Limit))
+		}
+
+		for i, plan := range plan.InParallel.Steps {
+			subIdentifier := fmt.Sprintf("%s.in_parallel.steps[%d]", identifier, i)
+			planWarnings, planErrMessages := validatePlan(c, subIdentifier, plan)
+			warnings = append(warnings, planWarnings...)
+			errorMessages = append(errorMessages, planErrMessages...)
+		}
+
 	case plan.Get != "":
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "var_files", "instance_vars", "format"},
 			plan, identifier)...,
 		)
 
@@ -430,11 +555,21 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 			}
 		}
 
+		if plan.Version != nil && plan.Version.HasFilter() {
+			_, err := plan.Version.Filter()
+			if err != nil {
+				errorMessages = append(
+					errorMessages,
+					fmt.Sprintf("%s.version has an invalid filter: %s", identifier, err),
+				)
+			}
+		}
+
 	case plan.Put != "":
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "config", "file", "var_files", "instance_vars", "format"},
 			plan, identifier)...,
 		)
 
@@ -491,7 +626,52 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "var_files", "instance_vars", "format"},
+			plan, identifier)...,
+		)
+
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any file")
+		} else if !strings.Contains(plan.TaskConfigPath, "/") {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has a file that is not within an artifact ('%s')", plan.TaskConfigPath))
+		}
+
+		for i, varFile := range plan.VarFiles {
+			if !strings.Contains(varFile, "/") {
+				subIdentifier := fmt.Sprintf("%s.var_files[%d]", identifier, i)
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" is not within an artifact ('%s')", varFile))
+			}
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "format"},
+			plan, identifier)...,
+		)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+		if !localVarNameRegex.MatchString(plan.LoadVar) {
+			errorMessages = append(errorMessages, identifier+" has an invalid var name")
+		}
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any file")
+		} else if !strings.Contains(plan.TaskConfigPath, "/") {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has a file that is not within an artifact ('%s')", plan.TaskConfigPath))
+		}
+
+		switch plan.Format {
+		case "", LoadVarFormatRaw, LoadVarFormatTrim, LoadVarFormatJSON, LoadVarFormatYAML:
+		default:
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an unknown format ('%s')", plan.Format))
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "var_files", "instance_vars"},
 			plan, identifier)...,
 		)
 
@@ -538,14 +718,114 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]Warning, []st
 		}
 	}
 
-	if plan.Attempts < 0 {
-		subIdentifier := fmt.Sprintf("%s.attempts", identifier)
-		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
+	if plan.Attempts != nil {
+		errorMessages = append(errorMessages, validateAttempts(identifier, *plan.Attempts)...)
+	}
+
+	if plan.WorkerSelector != "" {
+		_, err := plan.WorkerSelector.Requirements()
+		if err != nil {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(".worker_selector is invalid: %s", err))
+		}
+	}
+
+	if plan.If != "" {
+		_, err := condition.Parse(plan.If)
+		if err != nil {
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(".if has an invalid condition: %s", err))
+		}
+	}
+
+	if len(plan.Across) > 0 {
+		errorMessages = append(errorMessages, validateAcross(identifier, plan.Across)...)
+	} else if plan.FailFast {
+		errorMessages = append(errorMessages, identifier+" specifies fail_fast without across")
 	}
 
 	return warnings, errorMessages
 }
 
+func validateAttempts(identifier string, attempts AttemptsConfig) []string {
+	errorMessages := []string{}
+
+	subIdentifier := fmt.Sprintf("%s.attempts", identifier)
+
+	if attempts.Count < 0 {
+		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", attempts.Count))
+	} else if attempts.Count == 0 && !attempts.isCountOnly() {
+		errorMessages = append(errorMessages, subIdentifier+" does not specify a count")
+	}
+
+	if attempts.Backoff != nil {
+		backoff := *attempts.Backoff
+
+		if backoff.Initial == "" {
+			errorMessages = append(errorMessages, subIdentifier+".backoff does not specify an initial duration")
+		} else if _, err := time.ParseDuration(backoff.Initial); err != nil {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".backoff.initial refers to a duration that could not be parsed ('%s')", backoff.Initial))
+		}
+
+		if backoff.Max != "" {
+			if _, err := time.ParseDuration(backoff.Max); err != nil {
+				errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".backoff.max refers to a duration that could not be parsed ('%s')", backoff.Max))
+			}
+		}
+
+		if backoff.Multiplier != 0 && backoff.Multiplier < 1 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".backoff has an invalid multiplier (%g)", backoff.Multiplier))
+		}
+	}
+
+	for i, on := range attempts.On {
+		if on != AttemptOnError && on != AttemptOnFailure {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(".on[%d] has an unknown condition ('%s')", i, on))
+		}
+	}
+
+	return errorMessages
+}
+
+func validateAcross(identifier string, vars []AcrossVarConfig) []string {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, acrossVar := range vars {
+		subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		if acrossVar.Var == "" {
+			errorMessages = append(errorMessages, subIdentifier+" has no var")
+		} else if !localVarNameRegex.MatchString(acrossVar.Var) {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid var name ('%s')", acrossVar.Var))
+		} else if other, exists := names[acrossVar.Var]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"%s.across[%d] and %s.across[%d] have the same var ('%s')",
+					identifier, other, identifier, i, acrossVar.Var))
+		} else {
+			names[acrossVar.Var] = i
+		}
+
+		if acrossVar.Values == nil && acrossVar.File == "" {
+			errorMessages = append(errorMessages, subIdentifier+" specifies neither values nor file")
+		}
+
+		if acrossVar.Values != nil && acrossVar.File != "" {
+			errorMessages = append(errorMessages, subIdentifier+" specifies both values and file")
+		}
+
+		if acrossVar.File != "" && !strings.Contains(acrossVar.File, "/") {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has a file that is not within an artifact ('%s')", acrossVar.File))
+		}
+
+		if acrossVar.MaxInFlight < 0 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid max_in_flight (%d)", acrossVar.MaxInFlight))
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -576,6 +856,18 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "var_files":
+			if len(plan.VarFiles) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "instance_vars":
+			if len(plan.InstanceVars) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "format":
+			if plan.Format != "" {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
@@ -13,6 +13,7 @@ var _ = Describe("ValidateConfig", func() {
 	var (
 		config Config
 
+		warnings      []Warning
 		errorMessages []string
 	)
 
@@ -84,7 +85,7 @@ var _ = Describe("ValidateConfig", func() {
 	})
 
 	JustBeforeEach(func() {
-		_, errorMessages = config.Validate()
+		warnings, errorMessages = config.Validate()
 	})
 
 	Context("when the config is valid", func() {
@@ -190,6 +191,75 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource's webhook has no secret", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has no secret"))
+			})
+		})
+
+		Context("when a resource's webhook has an invalid filter", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &WebhookConfig{
+					Secret: "((webhook-secret))",
+					Filter: `$.ref ==`,
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has an invalid filter: unexpected end of condition"))
+			})
+		})
+
+		Context("when a resource has an invalid worker selector", func() {
+			BeforeEach(func() {
+				config.Resources[0].WorkerSelector = "disk in (ssd"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has an invalid worker_selector: unbalanced parentheses in worker selector"))
+			})
+		})
+
+		Context("when a resource's version history has an invalid keep_for", func() {
+			BeforeEach(func() {
+				config.Resources[0].VersionHistory = &VersionHistoryConfig{
+					Keep:    10,
+					KeepFor: "a month",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.version_history.keep_for is invalid"))
+			})
+		})
+
+		Context("when a resource's version history keeps a negative number of versions", func() {
+			BeforeEach(func() {
+				config.Resources[0].VersionHistory = &VersionHistoryConfig{
+					Keep:    -1,
+					KeepFor: "30d",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.version_history.keep must not be negative"))
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -423,6 +493,61 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("resource type defaults", func() {
+		Context("when the defaults are for types in use", func() {
+			BeforeEach(func() {
+				config.ResourceTypeDefaults = ResourceTypeDefaults{
+					"some-type": Source{"mirror": "some-mirror"},
+				}
+			})
+
+			It("does not warn", func() {
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("when the defaults are for a type only used by a task's image resource", func() {
+			BeforeEach(func() {
+				config.ResourceTypeDefaults = ResourceTypeDefaults{
+					"registry-image": Source{"mirror": "some-mirror"},
+				}
+
+				config.Jobs[0].Plan = append(config.Jobs[0].Plan, PlanConfig{
+					Task: "some-other-task",
+					TaskConfig: &TaskConfig{
+						Platform: "linux",
+						Run:      TaskRunConfig{Path: "ls"},
+						ImageResource: &ImageResource{
+							Type:   "registry-image",
+							Source: Source{"repository": "some-repository"},
+						},
+					},
+				})
+			})
+
+			It("does not warn", func() {
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("when the defaults are for types which are not used", func() {
+			BeforeEach(func() {
+				config.ResourceTypeDefaults = ResourceTypeDefaults{
+					"some-type":        Source{"mirror": "some-mirror"},
+					"some-unused-type": Source{"mirror": "some-mirror"},
+				}
+			})
+
+			It("warns about the unused types", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(ConsistOf(Warning{
+					Type:    "pipeline",
+					Message: "resource_type_defaults.some-unused-type is not used by any resource, resource type or task image resource",
+				}))
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job JobConfig
 
@@ -446,6 +571,21 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has timeouts that could not be parsed", func() {
+			BeforeEach(func() {
+				job.BuildTimeout = "nope"
+				job.DefaultStepTimeout = "1 hour"
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.build_timeout refers to a duration that could not be parsed ('nope')"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.default_step_timeout refers to a duration that could not be parsed ('1 hour')"))
+			})
+		})
+
 		Context("when a job has a negative build_logs_to_retain", func() {
 			BeforeEach(func() {
 				job.BuildLogsToRetain = -1
@@ -541,6 +681,31 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has duplicate inputs via in_parallel", func() {
+			BeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					Get: "some-resource",
+				})
+				job.Plan = append(job.Plan, PlanConfig{
+					InParallel: &InParallelConfig{
+						Steps: PlanSequence{
+							{
+								Get: "some-resource",
+							},
+						},
+					},
+				})
+
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns a single error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(strings.Count(errorMessages[0], "has get steps with the same name: some-resource")).To(Equal(1))
+			})
+		})
+
 		Describe("plans", func() {
 			Context("when multiple actions are specified in the same plan", func() {
 				Context("when it's not just Get and Put", func() {
@@ -1104,7 +1269,7 @@ var _ = Describe("ValidateConfig", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
 						Put:      "some-resource",
-						Attempts: -1,
+						Attempts: &AttemptsConfig{Count: -1},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -1116,6 +1281,388 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a retry plan has a backoff without a count", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Attempts: &AttemptsConfig{
+							Backoff: &BackoffConfig{Initial: "1s"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts does not specify a count"))
+				})
+			})
+
+			Context("when a retry plan has an invalid backoff", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Attempts: &AttemptsConfig{
+							Count: 3,
+							Backoff: &BackoffConfig{
+								Max:        "bogus",
+								Multiplier: 0.5,
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each problem", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts.backoff does not specify an initial duration"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts.backoff.max refers to a duration that could not be parsed ('bogus')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts.backoff has an invalid multiplier (0.5)"))
+				})
+			})
+
+			Context("when a retry plan retries on an unknown condition", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Attempts: &AttemptsConfig{
+							Count: 3,
+							On:    []string{AttemptOnFailure, "bogus"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.attempts.on[1] has an unknown condition ('bogus')"))
+				})
+			})
+
+			Context("when a retry plan has a valid backoff", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Attempts: &AttemptsConfig{
+							Count: 3,
+							Backoff: &BackoffConfig{
+								Initial:    "1s",
+								Max:        "1m",
+								Multiplier: 2,
+							},
+							On: []string{AttemptOnError},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when a plan has an across var with neither values nor a file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "some-var"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[0] specifies neither values nor file"))
+				})
+			})
+
+			Context("when a plan has across vars with the same name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "some-var", Values: []interface{}{"a"}},
+							{Var: "some-var", File: "some-artifact/values.yml"},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[0] and jobs.some-other-job.plan[0].put.some-resource.across[1] have the same var ('some-var')"))
+				})
+			})
+
+			Context("when a plan has an across var with an invalid name", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "some.var", Values: []interface{}{"a"}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[0] has an invalid var name ('some.var')"))
+				})
+			})
+
+			Context("when a plan has an across var with a file outside of an artifact", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "some-var", File: "values.yml", MaxInFlight: -1},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[0] has a file that is not within an artifact ('values.yml')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[0] has an invalid max_in_flight (-1)"))
+				})
+			})
+
+			Context("when a plan specifies fail_fast without across", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						FailFast: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource specifies fail_fast without across"))
+				})
+			})
+
+			Context("when a plan has an invalid if condition", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						If:  `$BUILD_BOGUS == "main"`,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.if has an invalid condition: unknown build metadata '$BUILD_BOGUS'"))
+				})
+			})
+
+			Context("when a plan has an invalid worker selector", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:            "some-resource",
+						WorkerSelector: "zone=",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.worker_selector is invalid: invalid label value in requirement 'zone='"))
+				})
+			})
+
+			Context("when a set_pipeline plan does not specify a file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline: "some-pipeline",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify any file"))
+				})
+			})
+
+			Context("when a set_pipeline plan has files outside of an artifact", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "pipeline.yml",
+						VarFiles:       []string{"some-artifact/vars.yml", "vars.yml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has a file that is not within an artifact ('pipeline.yml')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline.var_files[1] is not within an artifact ('vars.yml')"))
+				})
+			})
+
+			Context("when a set_pipeline plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-artifact/pipeline.yml",
+						Privileged:     true,
+						Trigger:        true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (trigger, privileged)"))
+				})
+			})
+
+			Context("when an in_parallel plan has an invalid limit and step", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						InParallel: &InParallelConfig{
+							Steps: PlanSequence{
+								{Get: "some-resource"},
+								{Get: "some-nonexistent-resource"},
+							},
+							Limit: -1,
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.limit has an invalid limit (-1)"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].in_parallel.steps[1].get.some-nonexistent-resource refers to a resource that does not exist"))
+				})
+			})
+
+			Context("when a load_var plan does not specify a file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar: "some-var",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var does not specify any file"))
+				})
+			})
+
+			Context("when a load_var plan has an invalid name, file and format", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar:        "some.var",
+						TaskConfigPath: "version",
+						Format:         "toml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some.var has an invalid var name"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some.var has a file that is not within an artifact ('version')"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some.var has an unknown format ('toml')"))
+				})
+			})
+
+			Context("when a load_var plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-artifact/version",
+						Format:         "trim",
+						Trigger:        true,
+						VarFiles:       []string{"some-artifact/vars.yml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has invalid fields specified (trigger, var_files)"))
+				})
+			})
+
+			Context("when a get plan specifies a format", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Get:    "some-resource",
+						Format: "json",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (format)"))
+				})
+			})
+
+			Context("when a task plan specifies var_files", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some-artifact/task.yml",
+						VarFiles:       []string{"some-artifact/vars.yml"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task has invalid fields specified (var_files)"))
+				})
+			})
+
+			Context("when a task plan specifies instance_vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Task:           "some-task",
+						TaskConfigPath: "some-artifact/task.yml",
+						InstanceVars:   InstanceVars{"branch": "master"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].task.some-task has invalid fields specified (instance_vars)"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1216,6 +1763,60 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a get step has a version filter", func() {
+			var version *VersionConfig
+
+			JustBeforeEach(func() {
+				job.Plan = append(job.Plan, PlanConfig{
+					Get:     "some-resource",
+					Version: version,
+				})
+
+				config.Jobs = append(config.Jobs, job)
+
+				warnings, errorMessages = config.Validate()
+			})
+
+			Context("when the filter is valid", func() {
+				BeforeEach(func() {
+					version = &VersionConfig{
+						Match:  map[string]string{"tag": "^v1"},
+						Semver: &VersionSemverConfig{Field: "tag", Constraint: ">=1.2 <2"},
+					}
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when the semver constraint is invalid", func() {
+				BeforeEach(func() {
+					version = &VersionConfig{
+						Semver: &VersionSemverConfig{Field: "tag", Constraint: ">=latest"},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.version has an invalid filter: invalid constraint '>=latest'"))
+				})
+			})
+
+			Context("when the semver filter has no field", func() {
+				BeforeEach(func() {
+					version = &VersionConfig{
+						Semver: &VersionSemverConfig{Constraint: ">=1.2"},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].get.some-resource.version has an invalid filter: semver filter has no field"))
+				})
+			})
+		})
+
 		Context("when two jobs have the same name", func() {
 			BeforeEach(func() {
 				config.Jobs = append(config.Jobs, config.Jobs...)
@@ -0,0 +1,128 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cppforlife/go-semi-semantic/version"
+)
+
+// versionConstraint is a semver constraint such as ">=1.2 <2" or
+// "<1 || >=2". Comparisons separated by spaces or commas must all be
+// satisfied, while groups separated by || are alternatives.
+type versionConstraint [][]versionComparison
+
+type versionComparison struct {
+	operator string
+	version  version.Version
+}
+
+var versionOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+func parseVersionConstraint(source string) (versionConstraint, error) {
+	var constraint versionConstraint
+
+	for _, group := range strings.Split(source, "||") {
+		tokens := strings.Fields(strings.Replace(group, ",", " ", -1))
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("invalid constraint '%s': empty comparison", source)
+		}
+
+		var comparisons []versionComparison
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+
+			// allow a space between the operator and the version, e.g. ">= 1.2"
+			if isVersionOperator(token) && i+1 < len(tokens) {
+				i++
+				token += tokens[i]
+			}
+
+			comparison, err := parseVersionComparison(token)
+			if err != nil {
+				return nil, fmt.Errorf("invalid constraint '%s': %s", source, err)
+			}
+
+			comparisons = append(comparisons, comparison)
+		}
+
+		constraint = append(constraint, comparisons)
+	}
+
+	return constraint, nil
+}
+
+func (c versionConstraint) check(v version.Version) bool {
+	for _, group := range c {
+		satisfied := true
+		for _, comparison := range group {
+			if !comparison.check(v) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseVersionComparison(token string) (versionComparison, error) {
+	operator := "="
+	for _, op := range versionOperators {
+		if strings.HasPrefix(token, op) {
+			operator = op
+			break
+		}
+	}
+
+	v, err := parseSemver(strings.TrimPrefix(token, operator))
+	if err != nil {
+		return versionComparison{}, err
+	}
+
+	return versionComparison{operator: operator, version: v}, nil
+}
+
+func (c versionComparison) check(v version.Version) bool {
+	result := v.Compare(c.version)
+
+	switch c.operator {
+	case ">=":
+		return result >= 0
+	case "<=":
+		return result <= 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case "<":
+		return result < 0
+	default:
+		return result == 0
+	}
+}
+
+func isVersionOperator(token string) bool {
+	for _, op := range versionOperators {
+		if token == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSemver parses a version with an optional leading v, requiring it to
+// start with a number so that names like "latest" are not read as versions.
+func parseSemver(value string) (version.Version, error) {
+	value = strings.TrimPrefix(value, "v")
+	if value == "" || value[0] < '0' || value[0] > '9' {
+		return version.Version{}, fmt.Errorf("invalid version '%s'", value)
+	}
+
+	return version.NewVersionFromString(value)
+}
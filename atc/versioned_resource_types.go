@@ -18,6 +18,22 @@ func (types VersionedResourceTypes) Lookup(name string) (VersionedResourceType,
 	return VersionedResourceType{}, false
 }
 
+// WithDefaults returns a copy of the types with the defaults for each type's
+// own type merged beneath its source.
+func (types VersionedResourceTypes) WithDefaults(defaults ResourceTypeDefaults) VersionedResourceTypes {
+	if len(defaults) == 0 {
+		return types
+	}
+
+	newTypes := make(VersionedResourceTypes, len(types))
+	for i, t := range types {
+		t.Source = defaults.Apply(t.Type, t.Source)
+		newTypes[i] = t
+	}
+
+	return newTypes
+}
+
 func (types VersionedResourceTypes) Without(name string) VersionedResourceTypes {
 	newTypes := VersionedResourceTypes{}
 	for _, t := range types {
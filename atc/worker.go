@@ -19,6 +19,8 @@ type Worker struct {
 	ActiveContainers int `json:"active_containers"`
 	ActiveVolumes    int `json:"active_volumes"`
 
+	Capacity WorkerCapacity `json:"capacity"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
@@ -29,6 +31,12 @@ type Worker struct {
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
 	State     string   `json:"state"`
+
+	// labels matched by the worker selectors of steps and resources
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// URL at which other workers reach the worker to stream volumes directly
+	P2PURL string `json:"p2p_url,omitempty"`
 }
 
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
@@ -44,9 +52,33 @@ func (w Worker) Validate() error {
 		return ErrMissingWorkerGardenAddress
 	}
 
+	for key, value := range w.Labels {
+		err := ValidateWorkerLabel(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// WorkerCapacity is how much a worker advertises it can run. Zero values
+// are not limited.
+type WorkerCapacity struct {
+	MaxContainers int `json:"max_containers,omitempty"`
+
+	// number of CPUs; shown to operators, but not considered during placement
+	CPU int `json:"cpu,omitempty"`
+
+	// memory in bytes; shown to operators, but not considered during placement
+	Memory int64 `json:"memory,omitempty"`
+}
+
+// Full returns true if the given number of containers fill the capacity.
+func (c WorkerCapacity) Full(containers int) bool {
+	return c.MaxContainers > 0 && containers >= c.MaxContainers
+}
+
 type WorkerResourceType struct {
 	Type                 string `json:"type"`
 	Image                string `json:"image"`
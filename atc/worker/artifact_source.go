@@ -13,7 +13,8 @@ import (
 type ArtifactSource interface {
 	// StreamTo copies the data from the source to the destination. Note that
 	// this potentially uses a lot of network transfer, for larger artifacts, as
-	// the ATC will effectively act as a middleman.
+	// the ATC will effectively act as a middleman, unless the data can be
+	// streamed directly between workers (see StreamVolume).
 	StreamTo(lager.Logger, ArtifactDestination) error
 
 	// StreamFile returns the contents of a single file in the artifact source.
@@ -10,11 +10,12 @@ import (
 )
 
 type WorkerSpec struct {
-	Platform      string
-	ResourceType  string
-	Tags          []string
-	TeamID        int
-	ResourceTypes creds.VersionedResourceTypes
+	Platform       string
+	ResourceType   string
+	Tags           []string
+	WorkerSelector atc.WorkerSelector
+	TeamID         int
+	ResourceTypes  creds.VersionedResourceTypes
 }
 
 type ContainerSpec struct {
@@ -101,5 +102,9 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.WorkerSelector != "" {
+		attrs = append(attrs, fmt.Sprintf("worker selector '%s'", spec.WorkerSelector))
+	}
+
 	return strings.Join(attrs, ", ")
 }
@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"net"
 	"net/http"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	bclient "github.com/concourse/baggageclaim/client"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/worker/transport"
+	"github.com/concourse/concourse/worker/p2p"
 	"github.com/concourse/retryhttp"
 	"github.com/cppforlife/go-semi-semantic/version"
 
@@ -29,6 +31,8 @@ type dbWorkerProvider struct {
 	dbWorkerFactory                   db.WorkerFactory
 	workerVersion                     version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
+	streamEncoding                    p2p.Encoding
+	streamKey                         []byte
 }
 
 func NewDBWorkerProvider(
@@ -44,6 +48,8 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion version.Version,
 	baggageclaimResponseHeaderTimeout time.Duration,
+	streamEncoding p2p.Encoding,
+	streamKey []byte,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -58,6 +64,8 @@ func NewDBWorkerProvider(
 		dbWorkerFactory:                   workerFactory,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		streamEncoding:                    streamEncoding,
+		streamKey:                         streamKey,
 	}
 }
 
@@ -164,6 +172,22 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		},
 	))
 
+	var p2pClient p2p.Client
+	// volumes are streamed through the ATC unless it shares a key with the
+	// workers
+	if savedWorker.P2PURL() != "" && len(provider.streamKey) != 0 {
+		p2pClient = p2p.NewClient(savedWorker.P2PURL(), &http.Client{
+			Transport: &http.Transport{
+				// fail fast when the worker cannot be reached, so that volumes are
+				// streamed through the ATC instead
+				DialContext: (&net.Dialer{
+					Timeout: 5 * time.Second,
+				}).DialContext,
+				DisableKeepAlives: true,
+			},
+		}, tikTok, provider.streamKey, provider.streamEncoding)
+	}
+
 	volumeClient := NewVolumeClient(
 		bClient,
 		savedWorker,
@@ -172,6 +196,7 @@ func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok cl
 		provider.dbVolumeRepository,
 		provider.dbWorkerBaseResourceTypeFactory,
 		provider.dbWorkerTaskCacheFactory,
+		p2pClient,
 	)
 
 	containerProvider := NewContainerProvider(
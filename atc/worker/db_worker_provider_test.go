@@ -19,6 +19,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/worker/p2p"
 	"github.com/concourse/retryhttp/retryhttpfakes"
 	"github.com/cppforlife/go-semi-semantic/version"
 
@@ -173,6 +174,8 @@ var _ = Describe("DBProvider", func() {
 			fakeDBWorkerFactory,
 			wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
+			p2p.EncodingGzip,
+			[]byte("some-key"),
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
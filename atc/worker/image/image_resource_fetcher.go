@@ -171,6 +171,7 @@ func (i *imageResourceFetcher) Fetch(
 		logger.Session("init-image"),
 		getSess,
 		i.worker.Tags(),
+		"",
 		i.teamID,
 		i.customTypes,
 		resourceInstance,
@@ -250,7 +251,7 @@ func (i *imageResourceFetcher) ensureVersionOfType(
 		return err
 	}
 
-	versions, err := checkResourceType.Check(context.TODO(), source, nil)
+	versions, err := checkResourceType.Check(context.TODO(), resource.IOConfig{}, source, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -315,7 +316,7 @@ func (i *imageResourceFetcher) getLatestVersion(
 		return nil, err
 	}
 
-	versions, err := checkingResource.Check(context.TODO(), source, nil)
+	versions, err := checkingResource.Check(context.TODO(), resource.IOConfig{}, source, nil, nil)
 	if err != nil {
 		return nil, err
 	}
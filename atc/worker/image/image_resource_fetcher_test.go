@@ -347,7 +347,7 @@ var _ = Describe("Image", func() {
 
 							It("ran 'check' with the right config", func() {
 								Expect(fakeCheckResource.CheckCallCount()).To(Equal(1))
-								_, checkSource, checkVersion := fakeCheckResource.CheckArgsForCall(0)
+								_, _, checkSource, checkVersion, _ := fakeCheckResource.CheckArgsForCall(0)
 								Expect(checkVersion).To(BeNil())
 								Expect(checkSource).To(Equal(atc.Source{"some": "super-secret-sauce"}))
 							})
@@ -359,7 +359,7 @@ var _ = Describe("Image", func() {
 
 							It("fetches resource with correct session", func() {
 								Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
-								_, _, session, tags, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
+								_, _, session, tags, _, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
 								Expect(metadata).To(Equal(resource.EmptyMetadata{}))
 								Expect(session).To(Equal(resource.Session{
 									Metadata: db.ContainerMetadata{
@@ -575,7 +575,7 @@ var _ = Describe("Image", func() {
 
 					It("fetches resource with correct session", func() {
 						Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
-						_, _, session, tags, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
+						_, _, session, tags, _, actualTeamID, actualCustomTypes, resourceInstance, metadata, delegate := fakeResourceFetcher.FetchArgsForCall(0)
 						Expect(metadata).To(Equal(resource.EmptyMetadata{}))
 						Expect(session).To(Equal(resource.Session{
 							Metadata: db.ContainerMetadata{
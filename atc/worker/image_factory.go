@@ -48,6 +48,7 @@ type ImageFetchingDelegate interface {
 	Stdout() io.Writer
 	Stderr() io.Writer
 	ImageVersionDetermined(db.UsedResourceCache) error
+	WaitingForWorker(lager.Logger)
 }
 
 type ImageMetadata struct {
@@ -60,3 +61,4 @@ type NoopImageFetchingDelegate struct{}
 func (NoopImageFetchingDelegate) Stdout() io.Writer                                 { return ioutil.Discard }
 func (NoopImageFetchingDelegate) Stderr() io.Writer                                 { return ioutil.Discard }
 func (NoopImageFetchingDelegate) ImageVersionDetermined(db.UsedResourceCache) error { return nil }
+func (NoopImageFetchingDelegate) WaitingForWorker(lager.Logger)                     {}
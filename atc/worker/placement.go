@@ -1,30 +1,157 @@
 package worker
 
 import (
-	"github.com/concourse/concourse/atc/db"
+	"errors"
+	"fmt"
 	"math/rand"
+	"strings"
+	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
 )
 
+// ErrTooManyActiveTasks is returned when every worker a task container could
+// be placed on already runs the maximum number of active tasks.
+var ErrTooManyActiveTasks = errors.New("every worker has the maximum number of active tasks")
+
+// ActiveTasksCountInterval is how long the limit-active-tasks node reuses the
+// number of active tasks per worker before counting them again.
+const ActiveTasksCountInterval = 10 * time.Second
+
 type ContainerPlacementStrategy interface {
 	//TODO: Don't pass around container metadata since it's not guaranteed to be deterministic.
 	// Change this after check containers stop being reused
 	Choose(lager.Logger, []Worker, ContainerSpec, db.ContainerMetadata) (Worker, error)
 }
 
-type VolumeLocalityPlacementStrategy struct {
-	rand *rand.Rand
+// A ContainerPlacementStrategyNode is one link of a placement strategy chain.
+// It narrows down the workers a container could be placed on, either by
+// filtering out workers or by keeping only the best scoring ones.
+type ContainerPlacementStrategyNode interface {
+	Candidates(lager.Logger, []Worker, ContainerSpec, db.ContainerMetadata) ([]Worker, error)
+}
+
+// A placementObserver is a node which is told which worker was chosen, e.g.
+// to keep its own counts up to date between queries.
+type placementObserver interface {
+	Placed(Worker, ContainerSpec, db.ContainerMetadata)
+}
+
+type ContainerPlacementStrategyOptions struct {
+	// names of the nodes of the chain, in order
+	Nodes []string
+
+	// maximum number of active tasks per worker for limit-active-tasks, or 0
+	// for no maximum
+	MaxActiveTasksPerWorker int
+}
+
+// NewContainerPlacementStrategy returns a strategy chaining the named nodes.
+func NewContainerPlacementStrategy(opts ContainerPlacementStrategyOptions, workerFactory db.WorkerFactory) (ContainerPlacementStrategy, error) {
+	nodes := []ContainerPlacementStrategyNode{}
+
+	for _, name := range opts.Nodes {
+		switch strings.TrimSpace(name) {
+		case "volume-locality":
+			nodes = append(nodes, NewVolumeLocalityPlacementStrategyNode())
+		case "fewest-build-containers":
+			nodes = append(nodes, NewFewestBuildContainersPlacementStrategyNode())
+		case "fewest-volumes":
+			nodes = append(nodes, NewFewestVolumesPlacementStrategyNode())
+		case "limit-active-tasks":
+			nodes = append(nodes, NewLimitActiveTasksPlacementStrategyNode(opts.MaxActiveTasksPerWorker, workerFactory, clock.NewClock()))
+		case "random":
+			nodes = append(nodes, NewRandomPlacementStrategyNode())
+		default:
+			return nil, fmt.Errorf("unknown container placement strategy: %s", name)
+		}
+	}
+
+	return NewChainPlacementStrategy(nodes...), nil
+}
+
+type ChainPlacementStrategy struct {
+	nodes []ContainerPlacementStrategyNode
+	rand  *rand.Rand
+}
+
+// NewChainPlacementStrategy returns a strategy which narrows down the workers
+// by each node in turn, and chooses randomly between the workers remaining.
+func NewChainPlacementStrategy(nodes ...ContainerPlacementStrategyNode) ContainerPlacementStrategy {
+	return &ChainPlacementStrategy{
+		nodes: nodes,
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *ChainPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
+	candidates := workers
+
+	for _, node := range strategy.nodes {
+		var err error
+		candidates, err = node.Candidates(logger, candidates, spec, metadata)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoWorkers
+	}
+
+	chosen := candidates[strategy.rand.Intn(len(candidates))]
+
+	for _, node := range strategy.nodes {
+		if observer, ok := node.(placementObserver); ok {
+			observer.Placed(chosen, spec, metadata)
+		}
+	}
+
+	return chosen, nil
 }
 
 func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
-	return &VolumeLocalityPlacementStrategy{
-		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	return NewChainPlacementStrategy(NewVolumeLocalityPlacementStrategyNode())
+}
+
+func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
+	return NewChainPlacementStrategy(NewFewestBuildContainersPlacementStrategyNode())
+}
+
+func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+	return NewChainPlacementStrategy(NewRandomPlacementStrategyNode())
+}
+
+// fewest returns the workers with the lowest score.
+func fewest(workers []Worker, score func(Worker) int) []Worker {
+	var lowest []Worker
+	var lowestScore int
+
+	for i, w := range workers {
+		s := score(w)
+		if i == 0 || s < lowestScore {
+			lowest = nil
+			lowestScore = s
+		}
+
+		if s == lowestScore {
+			lowest = append(lowest, w)
+		}
 	}
+
+	return lowest
 }
 
-func (strategy *VolumeLocalityPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
+type VolumeLocalityPlacementStrategyNode struct{}
+
+func NewVolumeLocalityPlacementStrategyNode() ContainerPlacementStrategyNode {
+	return &VolumeLocalityPlacementStrategyNode{}
+}
+
+func (node *VolumeLocalityPlacementStrategyNode) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) ([]Worker, error) {
 	workersByCount := map[int][]Worker{}
 	var highestCount int
 	for _, w := range workers {
@@ -48,52 +175,122 @@ func (strategy *VolumeLocalityPlacementStrategy) Choose(logger lager.Logger, wor
 		}
 	}
 
-	highestLocalityWorkers := workersByCount[highestCount]
+	return workersByCount[highestCount], nil
+}
+
+type FewestBuildContainersPlacementStrategyNode struct{}
 
-	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+func NewFewestBuildContainersPlacementStrategyNode() ContainerPlacementStrategyNode {
+	return &FewestBuildContainersPlacementStrategyNode{}
 }
 
-type FewestBuildContainersPlacementStrategy struct {
-	rand *rand.Rand
+func (node *FewestBuildContainersPlacementStrategyNode) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) ([]Worker, error) {
+	// TODO: we want to remove this in the future when we don't reuse check containers
+	if metadata.Type == db.ContainerTypeCheck {
+		return workers, nil
+	}
+
+	return fewest(workers, Worker.BuildContainers), nil
 }
 
-func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
-	return &FewestBuildContainersPlacementStrategy{
-		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+type FewestVolumesPlacementStrategyNode struct{}
+
+func NewFewestVolumesPlacementStrategyNode() ContainerPlacementStrategyNode {
+	return &FewestVolumesPlacementStrategyNode{}
+}
+
+func (node *FewestVolumesPlacementStrategyNode) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) ([]Worker, error) {
+	return fewest(workers, Worker.ActiveVolumes), nil
+}
+
+type LimitActiveTasksPlacementStrategyNode struct {
+	maxTasks      int
+	workerFactory db.WorkerFactory
+	clock         clock.Clock
+
+	countsL     sync.Mutex
+	activeTasks map[string]int
+	countedAt   time.Time
+}
+
+// NewLimitActiveTasksPlacementStrategyNode returns a node which filters out
+// the workers running maxTasks or more tasks of started builds when placing a
+// task container. Other containers may be placed on any of the workers.
+//
+// The tasks are counted at most once per ActiveTasksCountInterval, and the
+// task containers placed by this node are added to the counts in between.
+// The limit is best-effort: tasks placed at the same time, or by other ATCs,
+// may still exceed it until the tasks are next counted.
+func NewLimitActiveTasksPlacementStrategyNode(maxTasks int, workerFactory db.WorkerFactory, clock clock.Clock) ContainerPlacementStrategyNode {
+	return &LimitActiveTasksPlacementStrategyNode{
+		maxTasks:      maxTasks,
+		workerFactory: workerFactory,
+		clock:         clock,
 	}
 }
 
-func (strategy *FewestBuildContainersPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
-	workersByWork := map[int][]Worker{}
-	var minWork int
+func (node *LimitActiveTasksPlacementStrategyNode) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) ([]Worker, error) {
+	if node.maxTasks == 0 || metadata.Type != db.ContainerTypeTask {
+		return workers, nil
+	}
+
+	node.countsL.Lock()
+	defer node.countsL.Unlock()
 
-	// TODO: we want to remove this in the future when we don't reuse check containers
-	if metadata.Type == db.ContainerTypeCheck {
-		return workers[strategy.rand.Intn(len(workers))], nil
+	if node.activeTasks == nil || node.clock.Since(node.countedAt) >= ActiveTasksCountInterval {
+		activeTasks, err := node.workerFactory.ActiveTasksCountPerWorker()
+		if err != nil {
+			logger.Error("failed-to-count-active-tasks", err)
+			return nil, err
+		}
+
+		node.activeTasks = activeTasks
+		node.countedAt = node.clock.Now()
 	}
 
-	for i, w := range workers {
-		work := w.BuildContainers()
-		workersByWork[work] = append(workersByWork[work], w)
-		if i == 0 || work < minWork {
-			minWork = work
+	candidates := []Worker{}
+	for _, w := range workers {
+		if node.activeTasks[w.Name()] < node.maxTasks {
+			candidates = append(candidates, w)
 		}
 	}
 
-	leastBusyWorkers := workersByWork[minWork]
-	return leastBusyWorkers[strategy.rand.Intn(len(leastBusyWorkers))], nil
+	if len(candidates) == 0 {
+		return nil, ErrTooManyActiveTasks
+	}
+
+	return candidates, nil
+}
+
+// Placed counts a task container placed on the worker until the tasks are
+// next counted.
+func (node *LimitActiveTasksPlacementStrategyNode) Placed(worker Worker, spec ContainerSpec, metadata db.ContainerMetadata) {
+	if node.maxTasks == 0 || metadata.Type != db.ContainerTypeTask {
+		return
+	}
+
+	node.countsL.Lock()
+	defer node.countsL.Unlock()
+
+	if node.activeTasks != nil {
+		node.activeTasks[worker.Name()]++
+	}
 }
 
-type RandomPlacementStrategy struct {
+type RandomPlacementStrategyNode struct {
 	rand *rand.Rand
 }
 
-func NewRandomPlacementStrategy() ContainerPlacementStrategy {
-	return &RandomPlacementStrategy{
+func NewRandomPlacementStrategyNode() ContainerPlacementStrategyNode {
+	return &RandomPlacementStrategyNode{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
-func (strategy *RandomPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) (Worker, error) {
-	return workers[strategy.rand.Intn(len(workers))], nil
+func (node *RandomPlacementStrategyNode) Candidates(logger lager.Logger, workers []Worker, spec ContainerSpec, metadata db.ContainerMetadata) ([]Worker, error) {
+	if len(workers) == 0 {
+		return workers, nil
+	}
+
+	return []Worker{workers[node.rand.Intn(len(workers))]}, nil
 }
@@ -1,9 +1,14 @@
 package worker_test
 
 import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 
@@ -343,3 +348,223 @@ var _ = Describe("RandomPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("FewestVolumesPlacementStrategyNode", func() {
+	Describe("Candidates", func() {
+		var (
+			node       ContainerPlacementStrategyNode
+			candidates []Worker
+			workerA    *workerfakes.FakeWorker
+			workerB    *workerfakes.FakeWorker
+			workerC    *workerfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("fewest-volumes-placement-test")
+			node = NewFewestVolumesPlacementStrategyNode()
+
+			workerA = new(workerfakes.FakeWorker)
+			workerA.ActiveVolumesReturns(30)
+			workerB = new(workerfakes.FakeWorker)
+			workerB.ActiveVolumesReturns(10)
+			workerC = new(workerfakes.FakeWorker)
+			workerC.ActiveVolumesReturns(10)
+
+			workers = []Worker{workerA, workerB, workerC}
+		})
+
+		JustBeforeEach(func() {
+			candidates, chooseErr = node.Candidates(logger, workers, spec, metadata)
+		})
+
+		It("keeps the workers with the fewest volumes", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(candidates).To(ConsistOf(workerB, workerC))
+		})
+	})
+})
+
+var _ = Describe("LimitActiveTasksPlacementStrategyNode", func() {
+	Describe("Candidates", func() {
+		var (
+			fakeWorkerFactory *dbfakes.FakeWorkerFactory
+			fakeClock         *fakeclock.FakeClock
+			maxTasks          int
+			node              ContainerPlacementStrategyNode
+			candidates        []Worker
+			workerA           *workerfakes.FakeWorker
+			workerB           *workerfakes.FakeWorker
+		)
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("limit-active-tasks-placement-test")
+			fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+			fakeWorkerFactory.ActiveTasksCountPerWorkerStub = func() (map[string]int, error) {
+				return map[string]int{"worker-a": 2, "worker-b": 1}, nil
+			}
+			fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+			maxTasks = 2
+
+			workerA = new(workerfakes.FakeWorker)
+			workerA.NameReturns("worker-a")
+			workerB = new(workerfakes.FakeWorker)
+			workerB.NameReturns("worker-b")
+
+			workers = []Worker{workerA, workerB}
+			metadata = db.ContainerMetadata{
+				Type: db.ContainerTypeTask,
+			}
+		})
+
+		JustBeforeEach(func() {
+			node = NewLimitActiveTasksPlacementStrategyNode(maxTasks, fakeWorkerFactory, fakeClock)
+			candidates, chooseErr = node.Candidates(logger, workers, spec, metadata)
+		})
+
+		It("filters out the workers with the maximum number of active tasks", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(candidates).To(ConsistOf(workerB))
+		})
+
+		Context("when placing again within the interval", func() {
+			It("reuses the counts", func() {
+				fakeClock.Increment(ActiveTasksCountInterval - time.Second)
+
+				candidates, chooseErr = node.Candidates(logger, workers, spec, metadata)
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(candidates).To(ConsistOf(workerB))
+				Expect(fakeWorkerFactory.ActiveTasksCountPerWorkerCallCount()).To(Equal(1))
+			})
+
+			It("counts the tasks placed since by a chain", func() {
+				chosen, err := NewChainPlacementStrategy(node).Choose(logger, workers, spec, metadata)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(chosen).To(Equal(workerB))
+
+				_, err = NewChainPlacementStrategy(node).Choose(logger, workers, spec, metadata)
+				Expect(err).To(Equal(ErrTooManyActiveTasks))
+			})
+		})
+
+		Context("when placing again after the interval", func() {
+			It("counts the tasks again", func() {
+				fakeClock.Increment(ActiveTasksCountInterval)
+
+				_, chooseErr = node.Candidates(logger, workers, spec, metadata)
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(fakeWorkerFactory.ActiveTasksCountPerWorkerCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when every worker has the maximum number of active tasks", func() {
+			BeforeEach(func() {
+				maxTasks = 1
+			})
+
+			It("returns ErrTooManyActiveTasks", func() {
+				Expect(chooseErr).To(Equal(ErrTooManyActiveTasks))
+			})
+		})
+
+		Context("when there is no maximum", func() {
+			BeforeEach(func() {
+				maxTasks = 0
+			})
+
+			It("keeps every worker without counting the tasks", func() {
+				Expect(candidates).To(ConsistOf(workerA, workerB))
+				Expect(fakeWorkerFactory.ActiveTasksCountPerWorkerCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the container is not for a task", func() {
+			BeforeEach(func() {
+				metadata = db.ContainerMetadata{
+					Type: db.ContainerTypeGet,
+				}
+			})
+
+			It("keeps every worker", func() {
+				Expect(candidates).To(ConsistOf(workerA, workerB))
+			})
+		})
+
+		Context("when counting the active tasks fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeWorkerFactory.ActiveTasksCountPerWorkerStub = nil
+				fakeWorkerFactory.ActiveTasksCountPerWorkerReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(chooseErr).To(Equal(disaster))
+			})
+		})
+	})
+})
+
+var _ = Describe("ContainerPlacementStrategy chains", func() {
+	var (
+		opts        ContainerPlacementStrategyOptions
+		strategyErr error
+		workerA     *workerfakes.FakeWorker
+		workerB     *workerfakes.FakeWorker
+		workerC     *workerfakes.FakeWorker
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("chain-placement-test")
+
+		workerA = new(workerfakes.FakeWorker)
+		workerA.BuildContainersReturns(10)
+		workerA.ActiveVolumesReturns(5)
+		workerB = new(workerfakes.FakeWorker)
+		workerB.BuildContainersReturns(10)
+		workerB.ActiveVolumesReturns(50)
+		workerC = new(workerfakes.FakeWorker)
+		workerC.BuildContainersReturns(20)
+		workerC.ActiveVolumesReturns(1)
+
+		workers = []Worker{workerA, workerB, workerC}
+
+		spec = ContainerSpec{}
+		metadata = db.ContainerMetadata{
+			Type: db.ContainerTypeTask,
+		}
+	})
+
+	JustBeforeEach(func() {
+		strategy, strategyErr = NewContainerPlacementStrategy(opts, new(dbfakes.FakeWorkerFactory))
+	})
+
+	Context("with multiple nodes", func() {
+		BeforeEach(func() {
+			opts = ContainerPlacementStrategyOptions{
+				Nodes: []string{"volume-locality", "fewest-build-containers", " fewest-volumes"},
+			}
+		})
+
+		It("narrows down the workers by each node in order", func() {
+			Expect(strategyErr).ToNot(HaveOccurred())
+
+			Consistently(func() Worker {
+				chosenWorker, chooseErr = strategy.Choose(logger, workers, spec, metadata)
+				Expect(chooseErr).ToNot(HaveOccurred())
+				return chosenWorker
+			}).Should(Equal(workerA))
+		})
+	})
+
+	Context("with an unknown node", func() {
+		BeforeEach(func() {
+			opts = ContainerPlacementStrategyOptions{
+				Nodes: []string{"volume-locality", "bogus"},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(strategyErr).To(MatchError("unknown container placement strategy: bogus"))
+		})
+	})
+})
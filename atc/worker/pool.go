@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/clock"
@@ -42,23 +43,71 @@ var (
 	ErrNoWorkers = errors.New("no workers")
 )
 
+// WaitForWorkerInterval is how often a step waiting for a worker with room
+// for its container checks the workers again.
+const WaitForWorkerInterval = 5 * time.Second
+
+// MaxWorkerRejections is how many rejections a NoCompatibleWorkersError
+// lists; the others are only counted.
+const MaxWorkerRejections = 10
+
 type NoCompatibleWorkersError struct {
 	Spec WorkerSpec
+
+	// why the running workers did not satisfy the spec, leaving out those
+	// belonging to other teams
+	Rejections []WorkerRejection
+
+	// how many rejections were left out beyond MaxWorkerRejections
+	MoreRejections int
+}
+
+// A WorkerRejection is why a worker did not satisfy a spec.
+type WorkerRejection struct {
+	Worker string
+	Reason string
 }
 
 func (err NoCompatibleWorkersError) Error() string {
-	return fmt.Sprintf("no workers satisfying: %s", err.Spec.Description())
+	msg := fmt.Sprintf("no workers satisfying: %s", err.Spec.Description())
+
+	if len(err.Rejections) > 0 {
+		reasons := []string{}
+		for _, rejection := range err.Rejections {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", rejection.Worker, rejection.Reason))
+		}
+
+		if err.MoreRejections > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d more", err.MoreRejections))
+		}
+
+		msg += fmt.Sprintf(" (%s)", strings.Join(reasons, "; "))
+	}
+
+	return msg
+}
+
+// FullWorkersError is returned when there are workers satisfying the spec,
+// but all of them are full.
+type FullWorkersError struct {
+	Spec WorkerSpec
+}
+
+func (err FullWorkersError) Error() string {
+	return fmt.Sprintf("all workers satisfying %s are full", err.Spec.Description())
 }
 
 type pool struct {
+	clock    clock.Clock
 	provider WorkerProvider
 
 	rand     *rand.Rand
 	strategy ContainerPlacementStrategy
 }
 
-func NewPool(provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
+func NewPool(clock clock.Clock, provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
 	return &pool{
+		clock:    clock,
 		provider: provider,
 		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
 		strategy: strategy,
@@ -77,14 +126,33 @@ func (pool *pool) allSatisfying(logger lager.Logger, spec WorkerSpec) ([]Worker,
 
 	compatibleTeamWorkers := []Worker{}
 	compatibleGeneralWorkers := []Worker{}
+	rejections := []WorkerRejection{}
+	moreRejections := 0
 	for _, worker := range workers {
 		satisfyingWorker, err := worker.Satisfying(logger, spec)
-		if err == nil {
-			if worker.IsOwnedByTeam() {
-				compatibleTeamWorkers = append(compatibleTeamWorkers, satisfyingWorker)
-			} else {
-				compatibleGeneralWorkers = append(compatibleGeneralWorkers, satisfyingWorker)
+		if err != nil {
+			// the workers of other teams are not the team's to know about
+			if err == ErrTeamMismatch {
+				continue
+			}
+
+			if len(rejections) == MaxWorkerRejections {
+				moreRejections++
+				continue
 			}
+
+			rejections = append(rejections, WorkerRejection{
+				Worker: worker.Name(),
+				Reason: err.Error(),
+			})
+
+			continue
+		}
+
+		if worker.IsOwnedByTeam() {
+			compatibleTeamWorkers = append(compatibleTeamWorkers, satisfyingWorker)
+		} else {
+			compatibleGeneralWorkers = append(compatibleGeneralWorkers, satisfyingWorker)
 		}
 	}
 
@@ -97,16 +165,37 @@ func (pool *pool) allSatisfying(logger lager.Logger, spec WorkerSpec) ([]Worker,
 	}
 
 	return nil, NoCompatibleWorkersError{
-		Spec: spec,
+		Spec:           spec,
+		Rejections:     rejections,
+		MoreRejections: moreRejections,
 	}
 }
 
+func notFull(workers []Worker) []Worker {
+	availableWorkers := []Worker{}
+	for _, worker := range workers {
+		if !worker.IsFull() {
+			availableWorkers = append(availableWorkers, worker)
+		}
+	}
+
+	return availableWorkers
+}
+
 func (pool *pool) Satisfying(logger lager.Logger, spec WorkerSpec) (Worker, error) {
 	compatibleWorkers, err := pool.allSatisfying(logger, spec)
 	if err != nil {
 		return nil, err
 	}
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
+
+	availableWorkers := notFull(compatibleWorkers)
+	if len(availableWorkers) == 0 {
+		return nil, FullWorkersError{
+			Spec: spec,
+		}
+	}
+
+	randomWorker := availableWorkers[pool.rand.Intn(len(availableWorkers))]
 	return randomWorker, nil
 }
 
@@ -120,37 +209,19 @@ func (pool *pool) FindOrCreateContainer(
 	workerSpec WorkerSpec,
 	resourceTypes creds.VersionedResourceTypes,
 ) (Container, error) {
-	workersWithContainer, err := pool.provider.FindWorkersForContainerByOwner(
-		logger.Session("find-worker"),
+	worker, err := pool.findOrChooseWorker(
+		ctx,
+		logger,
+		delegate,
 		owner,
+		metadata,
+		containerSpec,
+		workerSpec,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	compatibleWorkers, err := pool.allSatisfying(logger, workerSpec)
-	if err != nil {
-		return nil, err
-	}
-
-	var worker Worker
-dance:
-	for _, w := range workersWithContainer {
-		for _, c := range compatibleWorkers {
-			if w.Name() == c.Name() {
-				worker = c
-				break dance
-			}
-		}
-	}
-
-	if worker == nil {
-		worker, err = pool.strategy.Choose(logger, compatibleWorkers, containerSpec, metadata)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	return worker.FindOrCreateContainer(
 		ctx,
 		logger,
@@ -163,6 +234,68 @@ dance:
 	)
 }
 
+// findOrChooseWorker returns the worker which already has the owner's
+// container, or else one with room for it. If every satisfying worker is
+// full or runs too many tasks, it waits until one has room or the context is
+// done.
+func (pool *pool) findOrChooseWorker(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate ImageFetchingDelegate,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	containerSpec ContainerSpec,
+	workerSpec WorkerSpec,
+) (Worker, error) {
+	waiting := false
+
+	for {
+		workersWithContainer, err := pool.provider.FindWorkersForContainerByOwner(
+			logger.Session("find-worker"),
+			owner,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		compatibleWorkers, err := pool.allSatisfying(logger, workerSpec)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, w := range workersWithContainer {
+			for _, c := range compatibleWorkers {
+				if w.Name() == c.Name() {
+					return c, nil
+				}
+			}
+		}
+
+		availableWorkers := notFull(compatibleWorkers)
+		if len(availableWorkers) != 0 {
+			worker, err := pool.strategy.Choose(logger, availableWorkers, containerSpec, metadata)
+			if err != ErrTooManyActiveTasks {
+				return worker, err
+			}
+		}
+
+		if !waiting {
+			logger.Info("waiting-for-worker", lager.Data{"spec": workerSpec.Description()})
+			delegate.WaitingForWorker(logger)
+			waiting = true
+		}
+
+		timer := pool.clock.NewTimer(WaitForWorkerInterval)
+
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (pool *pool) FindContainerByHandle(logger lager.Logger, teamID int, handle string) (Container, bool, error) {
 	worker, found, err := pool.provider.FindWorkerForContainer(
 		logger.Session("find-worker"),
@@ -3,7 +3,10 @@ package worker_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/cloudfoundry/bosh-cli/director/template"
@@ -21,6 +24,7 @@ import (
 var _ = Describe("Pool", func() {
 	var (
 		logger       *lagertest.TestLogger
+		fakeClock    *fakeclock.FakeClock
 		fakeProvider *workerfakes.FakeWorkerProvider
 		fakeStrategy *workerfakes.FakeContainerPlacementStrategy
 		pool         Client
@@ -28,10 +32,11 @@ var _ = Describe("Pool", func() {
 
 	BeforeEach(func() {
 		logger = lagertest.NewTestLogger("test")
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		fakeProvider = new(workerfakes.FakeWorkerProvider)
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
-		pool = NewPool(fakeProvider, fakeStrategy)
+		pool = NewPool(fakeClock, fakeProvider, fakeStrategy)
 	})
 
 	Describe("Satisfying", func() {
@@ -125,8 +130,89 @@ var _ = Describe("Pool", func() {
 					workerC.SatisfyingReturns(nil, errors.New("nope"))
 				})
 
-				It("returns a NoCompatibleWorkersError", func() {
-					Expect(satisfyingErr).To(Equal(NoCompatibleWorkersError{
+				It("returns a NoCompatibleWorkersError with why each worker was rejected", func() {
+					workerA.NameReturns("workerA")
+					workerB.NameReturns("workerB")
+					workerC.NameReturns("workerC")
+					workerC.SatisfyingReturns(nil, UnmatchedWorkerRequirementError{
+						Requirement: atc.WorkerRequirement{Key: "zone", Operator: atc.WorkerSelectorEquals, Values: []string{"us-east"}},
+					})
+
+					_, err := pool.Satisfying(logger, spec)
+					Expect(err).To(Equal(NoCompatibleWorkersError{
+						Spec: spec,
+						Rejections: []WorkerRejection{
+							{Worker: "workerA", Reason: "nope"},
+							{Worker: "workerB", Reason: "nope"},
+							{Worker: "workerC", Reason: "labels do not match 'zone=us-east'"},
+						},
+					}))
+					Expect(err.Error()).To(Equal("no workers satisfying: platform 'some-platform', tag 'step', tag 'tags' (workerA: nope; workerB: nope; workerC: labels do not match 'zone=us-east')"))
+				})
+
+				It("leaves out the workers of other teams", func() {
+					workerA.NameReturns("workerA")
+					workerB.NameReturns("workerB")
+					workerB.SatisfyingReturns(nil, ErrTeamMismatch)
+					workerC.NameReturns("workerC")
+
+					_, err := pool.Satisfying(logger, spec)
+					Expect(err).To(Equal(NoCompatibleWorkersError{
+						Spec: spec,
+						Rejections: []WorkerRejection{
+							{Worker: "workerA", Reason: "nope"},
+							{Worker: "workerC", Reason: "nope"},
+						},
+					}))
+				})
+
+				Context("when there are more rejections than are listed", func() {
+					BeforeEach(func() {
+						workers := []Worker{}
+						for i := 0; i < MaxWorkerRejections+2; i++ {
+							worker := new(workerfakes.FakeWorker)
+							worker.NameReturns(fmt.Sprintf("worker-%d", i))
+							worker.SatisfyingReturns(nil, errors.New("nope"))
+							workers = append(workers, worker)
+						}
+
+						fakeProvider.RunningWorkersReturns(workers, nil)
+					})
+
+					It("lists only the first ones and counts the others", func() {
+						_, err := pool.Satisfying(logger, spec)
+						Expect(err).To(BeAssignableToTypeOf(NoCompatibleWorkersError{}))
+
+						noCompatibleErr := err.(NoCompatibleWorkersError)
+						Expect(noCompatibleErr.Rejections).To(HaveLen(MaxWorkerRejections))
+						Expect(noCompatibleErr.MoreRejections).To(Equal(2))
+						Expect(err.Error()).To(HaveSuffix("worker-9: nope; 2 more)"))
+					})
+				})
+			})
+
+			Context("when a satisfying worker is full", func() {
+				BeforeEach(func() {
+					workerA.IsFullReturns(true)
+				})
+
+				It("returns another worker satisfying the spec", func() {
+					for i := 0; i < 10; i++ {
+						satisfyingWorker, satisfyingErr = pool.Satisfying(logger, spec)
+						Expect(satisfyingErr).NotTo(HaveOccurred())
+						Expect(satisfyingWorker).To(Equal(workerB))
+					}
+				})
+			})
+
+			Context("when every satisfying worker is full", func() {
+				BeforeEach(func() {
+					workerA.IsFullReturns(true)
+					workerB.IsFullReturns(true)
+				})
+
+				It("returns a FullWorkersError", func() {
+					Expect(satisfyingErr).To(Equal(FullWorkersError{
 						Spec: spec,
 					}))
 				})
@@ -366,6 +452,18 @@ var _ = Describe("Pool", func() {
 					Expect(createErr).NotTo(HaveOccurred())
 					Expect(createdContainer).To(Equal(fakeContainer))
 				})
+
+				Context("when the worker is full", func() {
+					BeforeEach(func() {
+						workerA.IsFullReturns(true)
+					})
+
+					It("still returns the container it already has", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+						Expect(createdContainer).To(Equal(fakeContainer))
+						Expect(fakeImageFetchingDelegate.WaitingForWorkerCallCount()).To(BeZero())
+					})
+				})
 			})
 
 			Context("when multiple workers satisfy the spec", func() {
@@ -395,6 +493,11 @@ var _ = Describe("Pool", func() {
 				It("returns a NoCompatibleWorkersError", func() {
 					Expect(createErr).To(Equal(NoCompatibleWorkersError{
 						Spec: workerSpec,
+						Rejections: []WorkerRejection{
+							{Worker: "workerA", Reason: "nope"},
+							{Worker: "workerB", Reason: "nope"},
+							{Worker: "workerC", Reason: "nope"},
+						},
 					}))
 				})
 			})
@@ -474,6 +577,11 @@ var _ = Describe("Pool", func() {
 					It("returns a NoCompatibleWorkersError", func() {
 						Expect(createErr).To(Equal(NoCompatibleWorkersError{
 							Spec: workerSpec,
+							Rejections: []WorkerRejection{
+								{Reason: "nope"},
+								{Reason: "nope"},
+								{Reason: "nope"},
+							},
 						}))
 					})
 				})
@@ -574,6 +682,9 @@ var _ = Describe("Pool", func() {
 				It("returns NoCompatibleWorkersError", func() {
 					Expect(createErr).To(Equal(NoCompatibleWorkersError{
 						Spec: workerSpec,
+						Rejections: []WorkerRejection{
+							{Reason: "incompatible platform"},
+						},
 					}))
 				})
 			})
@@ -613,6 +724,99 @@ var _ = Describe("Pool", func() {
 						Expect(createErr).To(Equal(strategyError))
 					})
 				})
+
+				Context("when another compatible worker is full", func() {
+					var fullWorker *workerfakes.FakeWorker
+
+					BeforeEach(func() {
+						fullWorker = new(workerfakes.FakeWorker)
+						fullWorker.SatisfyingReturns(fullWorker, nil)
+						fullWorker.IsFullReturns(true)
+
+						fakeProvider.RunningWorkersReturns([]Worker{
+							fullWorker,
+							compatibleWorker,
+						}, nil)
+
+						fakeStrategy.ChooseReturns(compatibleWorker, nil)
+					})
+
+					It("only chooses from the workers with room", func() {
+						_, workers, _, _ := fakeStrategy.ChooseArgsForCall(0)
+						Expect(workers).To(ConsistOf(compatibleWorker))
+					})
+				})
+
+				Context("when every compatible worker is full", func() {
+					BeforeEach(func() {
+						compatibleWorker.IsFullReturnsOnCall(0, true)
+						compatibleWorker.IsFullReturnsOnCall(1, true)
+						fakeStrategy.ChooseReturns(compatibleWorker, nil)
+
+						go func() {
+							defer GinkgoRecover()
+
+							for i := 0; i < 2; i++ {
+								Eventually(fakeClock.WatcherCount).Should(Equal(1))
+								fakeClock.Increment(WaitForWorkerInterval)
+							}
+						}()
+					})
+
+					It("waits until one has room", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(compatibleWorker.IsFullCallCount()).To(Equal(3))
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+						Expect(createdContainer).To(Equal(fakeContainer))
+					})
+
+					It("tells the delegate that it is waiting, once", func() {
+						Expect(fakeImageFetchingDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when every compatible worker has too many active tasks", func() {
+					BeforeEach(func() {
+						fakeStrategy.ChooseReturnsOnCall(0, nil, ErrTooManyActiveTasks)
+						fakeStrategy.ChooseReturnsOnCall(1, compatibleWorker, nil)
+
+						go func() {
+							defer GinkgoRecover()
+
+							Eventually(fakeClock.WatcherCount).Should(Equal(1))
+							fakeClock.Increment(WaitForWorkerInterval)
+						}()
+					})
+
+					It("waits until one can take the task", func() {
+						Expect(createErr).ToNot(HaveOccurred())
+						Expect(fakeStrategy.ChooseCallCount()).To(Equal(2))
+						Expect(fakeImageFetchingDelegate.WaitingForWorkerCallCount()).To(Equal(1))
+						Expect(createdContainer).To(Equal(fakeContainer))
+					})
+				})
+
+				Context("when every compatible worker stays full", func() {
+					var cancel context.CancelFunc
+
+					BeforeEach(func() {
+						compatibleWorker.IsFullReturns(true)
+
+						ctx, cancel = context.WithCancel(ctx)
+
+						go func() {
+							defer GinkgoRecover()
+
+							Eventually(fakeImageFetchingDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+							cancel()
+						}()
+					})
+
+					It("stops waiting when the context is done", func() {
+						Expect(createErr).To(Equal(context.Canceled))
+						Expect(compatibleWorker.FindOrCreateContainerCallCount()).To(BeZero())
+					})
+				})
 			})
 		})
 	})
@@ -1,14 +1,20 @@
 package worker
 
 import (
+	"errors"
 	"io"
 
 	"code.cloudfoundry.org/lager"
 
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/worker/p2p"
 )
 
+// ErrP2PUnavailable is returned when streaming a volume directly to or from
+// a worker which does not advertise a p2p URL.
+var ErrP2PUnavailable = errors.New("worker does not support p2p streaming")
+
 //go:generate counterfeiter . Volume
 
 type Volume interface {
@@ -23,6 +29,28 @@ type Volume interface {
 	StreamIn(path string, tarStream io.Reader) error
 	StreamOut(path string) (io.ReadCloser, error)
 
+	// P2PStreamOutURL returns a signed, short-lived URL from which other
+	// workers can pull the contents of the volume at the path, along with the
+	// encoding negotiated with the volume's worker.
+	P2PStreamOutURL(logger lager.Logger, path string) (string, p2p.Encoding, error)
+
+	// P2PStreamIn has the volume's worker pull the contents from the URL into
+	// the volume at the path, returning the number of bytes pulled.
+	P2PStreamIn(logger lager.Logger, path string, url string, encoding p2p.Encoding) (int64, error)
+
+	// P2PStreamOut pulls the contents from a URL handed out by
+	// P2PStreamOutURL through the ATC, for when the destination's worker cannot
+	// reach the volume's worker.
+	P2PStreamOut(logger lager.Logger, url string) (io.ReadCloser, error)
+
+	// P2PUpload streams the contents, encoded with the encoding, into the
+	// volume at the path, returning the number of bytes uploaded.
+	P2PUpload(logger lager.Logger, path string, encoding p2p.Encoding, contents io.Reader) (int64, error)
+
+	// P2PClear has the volume's worker remove the contents of the volume at
+	// the path, e.g. those left by a stream which failed part way through.
+	P2PClear(logger lager.Logger, path string) error
+
 	COWStrategy() baggageclaim.COWStrategy
 
 	InitializeResourceCache(db.UsedResourceCache) error
@@ -43,6 +71,7 @@ type volume struct {
 	bcVolume     baggageclaim.Volume
 	dbVolume     db.CreatedVolume
 	volumeClient VolumeClient
+	p2pClient    p2p.Client
 }
 
 type byMountPath []VolumeMount
@@ -63,11 +92,13 @@ func NewVolume(
 	bcVolume baggageclaim.Volume,
 	dbVolume db.CreatedVolume,
 	volumeClient VolumeClient,
+	p2pClient p2p.Client,
 ) Volume {
 	return &volume{
 		bcVolume:     bcVolume,
 		dbVolume:     dbVolume,
 		volumeClient: volumeClient,
+		p2pClient:    p2pClient,
 	}
 }
 
@@ -91,6 +122,46 @@ func (v *volume) StreamOut(path string) (io.ReadCloser, error) {
 	return v.bcVolume.StreamOut(path)
 }
 
+func (v *volume) P2PStreamOutURL(logger lager.Logger, path string) (string, p2p.Encoding, error) {
+	if v.p2pClient == nil {
+		return "", "", ErrP2PUnavailable
+	}
+
+	return v.p2pClient.StreamOutURL(logger, v.bcVolume.Handle(), path)
+}
+
+func (v *volume) P2PStreamIn(logger lager.Logger, path string, url string, encoding p2p.Encoding) (int64, error) {
+	if v.p2pClient == nil {
+		return 0, ErrP2PUnavailable
+	}
+
+	return v.p2pClient.StreamIn(logger, v.bcVolume.Handle(), path, url, encoding)
+}
+
+func (v *volume) P2PStreamOut(logger lager.Logger, url string) (io.ReadCloser, error) {
+	if v.p2pClient == nil {
+		return nil, ErrP2PUnavailable
+	}
+
+	return v.p2pClient.StreamOut(logger, url)
+}
+
+func (v *volume) P2PUpload(logger lager.Logger, path string, encoding p2p.Encoding, contents io.Reader) (int64, error) {
+	if v.p2pClient == nil {
+		return 0, ErrP2PUnavailable
+	}
+
+	return v.p2pClient.Upload(logger, v.bcVolume.Handle(), path, encoding, contents)
+}
+
+func (v *volume) P2PClear(logger lager.Logger, path string) error {
+	if v.p2pClient == nil {
+		return ErrP2PUnavailable
+	}
+
+	return v.p2pClient.Clear(logger, v.bcVolume.Handle(), path)
+}
+
 func (v *volume) Properties() (baggageclaim.VolumeProperties, error) {
 	return v.bcVolume.Properties()
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/worker/p2p"
 )
 
 const creatingVolumeRetryDelay = 1 * time.Second
@@ -84,6 +85,7 @@ type volumeClient struct {
 	dbWorkerTaskCacheFactory        db.WorkerTaskCacheFactory
 	clock                           clock.Clock
 	dbWorker                        db.Worker
+	p2pClient                       p2p.Client
 }
 
 func NewVolumeClient(
@@ -95,6 +97,7 @@ func NewVolumeClient(
 	dbVolumeRepository db.VolumeRepository,
 	dbWorkerBaseResourceTypeFactory db.WorkerBaseResourceTypeFactory,
 	dbWorkerTaskCacheFactory db.WorkerTaskCacheFactory,
+	p2pClient p2p.Client,
 ) VolumeClient {
 	return &volumeClient{
 		baggageclaimClient:              baggageclaimClient,
@@ -104,6 +107,7 @@ func NewVolumeClient(
 		dbWorkerTaskCacheFactory:        dbWorkerTaskCacheFactory,
 		clock:                           clock,
 		dbWorker:                        dbWorker,
+		p2pClient:                       p2pClient,
 	}
 }
 
@@ -198,7 +202,7 @@ func (c *volumeClient) FindVolumeForResourceCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return NewVolume(bcVolume, dbVolume, c, c.p2pClient), true, nil
 }
 
 func (c *volumeClient) CreateVolumeForTaskCache(
@@ -303,7 +307,7 @@ func (c *volumeClient) FindVolumeForTaskCache(
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return NewVolume(bcVolume, dbVolume, c, c.p2pClient), true, nil
 }
 
 func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume, bool, error) {
@@ -327,7 +331,7 @@ func (c *volumeClient) LookupVolume(logger lager.Logger, handle string) (Volume,
 		return nil, false, nil
 	}
 
-	return NewVolume(bcVolume, dbVolume, c), true, nil
+	return NewVolume(bcVolume, dbVolume, c, c.p2pClient), true, nil
 }
 
 func (c *volumeClient) findOrCreateVolume(
@@ -361,7 +365,7 @@ func (c *volumeClient) findOrCreateVolume(
 
 		logger.Debug("found-created-volume")
 
-		return NewVolume(bcVolume, createdVolume, c), nil
+		return NewVolume(bcVolume, createdVolume, c, c.p2pClient), nil
 	}
 
 	if creatingVolume != nil {
@@ -435,5 +439,5 @@ func (c *volumeClient) findOrCreateVolume(
 
 	logger.Debug("created")
 
-	return NewVolume(bcVolume, createdVolume, c), nil
+	return NewVolume(bcVolume, createdVolume, c, c.p2pClient), nil
 }
@@ -16,6 +16,7 @@ import (
 
 	"github.com/concourse/baggageclaim/baggageclaimfakes"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/worker/p2p/p2pfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -33,6 +34,7 @@ var _ = Describe("VolumeClient", func() {
 		fakeWorkerTaskCacheFactory        *dbfakes.FakeWorkerTaskCacheFactory
 		fakeClock                         *fakeclock.FakeClock
 		dbWorker                          *dbfakes.FakeWorker
+		fakeP2PClient                     *p2pfakes.FakeClient
 
 		volumeClient worker.VolumeClient
 	)
@@ -50,6 +52,7 @@ var _ = Describe("VolumeClient", func() {
 		fakeWorkerBaseResourceTypeFactory = new(dbfakes.FakeWorkerBaseResourceTypeFactory)
 		fakeWorkerTaskCacheFactory = new(dbfakes.FakeWorkerTaskCacheFactory)
 		fakeLock = new(lockfakes.FakeLock)
+		fakeP2PClient = new(p2pfakes.FakeClient)
 
 		volumeClient = worker.NewVolumeClient(
 			fakeBaggageclaimClient,
@@ -60,6 +63,7 @@ var _ = Describe("VolumeClient", func() {
 			fakeDBVolumeRepository,
 			fakeWorkerBaseResourceTypeFactory,
 			fakeWorkerTaskCacheFactory,
+			fakeP2PClient,
 		)
 	})
 
@@ -248,7 +252,7 @@ var _ = Describe("VolumeClient", func() {
 
 			It("creates volume in baggageclaim", func() {
 				Expect(foundOrCreatedErr).NotTo(HaveOccurred())
-				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient, fakeP2PClient)))
 				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
 			})
 
@@ -432,7 +436,7 @@ var _ = Describe("VolumeClient", func() {
 
 			It("creates volume in baggageclaim", func() {
 				Expect(foundOrCreatedErr).NotTo(HaveOccurred())
-				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient)))
+				Expect(foundOrCreatedVolume).To(Equal(worker.NewVolume(fakeBaggageclaimVolume, fakeCreatedVolume, volumeClient, fakeP2PClient)))
 				Expect(fakeBaggageclaimClient.CreateVolumeCallCount()).To(Equal(1))
 			})
 		})
@@ -634,7 +638,7 @@ var _ = Describe("VolumeClient", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(found).To(BeTrue())
 
-						Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient)))
+						Expect(volume).To(Equal(worker.NewVolume(bcVolume, dbVolume, volumeClient, fakeP2PClient)))
 					})
 				})
 			})
@@ -664,6 +668,7 @@ var _ = Describe("VolumeClient", func() {
 				fakeDBVolumeRepository,
 				fakeWorkerBaseResourceTypeFactory,
 				fakeWorkerTaskCacheFactory,
+				fakeP2PClient,
 			).LookupVolume(testLogger, handle)
 		})
 
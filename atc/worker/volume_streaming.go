@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/worker/p2p"
+)
+
+// StreamVolume copies the contents of the source volume at the path into the
+// destination at the path.
+//
+// If the destination is a volume, its worker is told to pull the contents
+// directly from the source volume's worker, with the encoding negotiated
+// between the ATC and the source worker. If the workers cannot reach each
+// other, e.g. because they are forwarded through the TSA, the ATC pulls the
+// contents and uploads them to the destination's worker, still with the
+// negotiated encoding. If that fails too, the contents are streamed through
+// the ATC from one baggageclaim to the other. Baggageclaim only streams
+// gzipped tarballs, so this is always gzipped, whichever encoding is
+// preferred.
+//
+// Before streaming into the destination again, whatever a failed attempt left
+// there is cleared. If it cannot be cleared, the stream is not retried.
+func StreamVolume(
+	logger lager.Logger,
+	src Volume,
+	srcPath string,
+	dest ArtifactDestination,
+	destPath string,
+) error {
+	if destVolume, ok := dest.(Volume); ok {
+		err := p2pStreamVolume(logger, src, srcPath, destVolume, destPath)
+		if err == nil {
+			return nil
+		}
+
+		if _, ok := err.(destinationNotClearedError); ok {
+			return err
+		}
+
+		logger.Info("falling-back-to-streaming-through-baggageclaim", lager.Data{"error": err.Error()})
+	}
+
+	start := time.Now()
+
+	out, err := src.StreamOut(srcPath)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	in := &countingReader{Reader: out}
+
+	err = dest.StreamIn(destPath, in)
+	if err != nil {
+		return err
+	}
+
+	metric.VolumeStreamed{
+		Method:   "atc",
+		Encoding: string(p2p.EncodingGzip),
+		Bytes:    in.count,
+		Duration: time.Since(start),
+	}.Emit(logger)
+
+	return nil
+}
+
+func p2pStreamVolume(
+	logger lager.Logger,
+	src Volume,
+	srcPath string,
+	dest Volume,
+	destPath string,
+) error {
+	start := time.Now()
+
+	url, encoding, err := src.P2PStreamOutURL(logger.Session("p2p"), srcPath)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := dest.P2PStreamIn(logger.Session("p2p"), destPath, url, encoding)
+	if err == nil {
+		metric.VolumeStreamed{
+			Method:   "p2p",
+			Encoding: string(encoding),
+			Bytes:    bytes,
+			Duration: time.Since(start),
+		}.Emit(logger)
+
+		return nil
+	}
+
+	if err == ErrP2PUnavailable {
+		return err
+	}
+
+	logger.Info("falling-back-to-relaying-through-atc", lager.Data{"error": err.Error()})
+
+	err = clearDestination(logger, dest, destPath)
+	if err != nil {
+		return err
+	}
+
+	start = time.Now()
+
+	out, err := src.P2PStreamOut(logger.Session("relay"), url)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	bytes, err = dest.P2PUpload(logger.Session("relay"), destPath, encoding, out)
+	if err != nil {
+		clearErr := clearDestination(logger, dest, destPath)
+		if clearErr != nil {
+			return clearErr
+		}
+
+		return err
+	}
+
+	metric.VolumeStreamed{
+		Method:   "atc",
+		Encoding: string(encoding),
+		Bytes:    bytes,
+		Duration: time.Since(start),
+	}.Emit(logger)
+
+	return nil
+}
+
+// clearDestination removes whatever a failed stream may have left in the
+// destination at the path, so that streaming into it can be retried.
+func clearDestination(logger lager.Logger, dest Volume, path string) error {
+	err := dest.P2PClear(logger.Session("clear"), path)
+	if err != nil {
+		logger.Error("failed-to-clear-destination", err)
+		return destinationNotClearedError{err}
+	}
+
+	return nil
+}
+
+type destinationNotClearedError struct {
+	err error
+}
+
+func (err destinationNotClearedError) Error() string {
+	return fmt.Sprintf("failed to clear the destination after a failed stream: %s", err.err)
+}
+
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,200 @@
+package worker_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/worker/p2p"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamVolume", func() {
+	var (
+		logger *lagertest.TestLogger
+
+		fakeSource      *workerfakes.FakeVolume
+		fakeDestination *workerfakes.FakeVolume
+		destination     worker.ArtifactDestination
+
+		streamErr error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeSource = new(workerfakes.FakeVolume)
+		fakeSource.P2PStreamOutURLReturns("some-stream-out-url", p2p.EncodingGzip, nil)
+		fakeSource.P2PStreamOutReturns(ioutil.NopCloser(strings.NewReader("some-encoded-tarball")), nil)
+		fakeSource.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-tarball")), nil)
+
+		fakeDestination = new(workerfakes.FakeVolume)
+		destination = fakeDestination
+	})
+
+	JustBeforeEach(func() {
+		streamErr = worker.StreamVolume(logger, fakeSource, "some-src-path", destination, "some-dest-path")
+	})
+
+	It("has the destination's worker pull from the source's worker", func() {
+		Expect(streamErr).ToNot(HaveOccurred())
+
+		Expect(fakeSource.P2PStreamOutURLCallCount()).To(Equal(1))
+		_, path := fakeSource.P2PStreamOutURLArgsForCall(0)
+		Expect(path).To(Equal("some-src-path"))
+
+		Expect(fakeDestination.P2PStreamInCallCount()).To(Equal(1))
+		_, path, url, encoding := fakeDestination.P2PStreamInArgsForCall(0)
+		Expect(path).To(Equal("some-dest-path"))
+		Expect(url).To(Equal("some-stream-out-url"))
+		Expect(encoding).To(Equal(p2p.EncodingGzip))
+
+		Expect(fakeSource.P2PStreamOutCallCount()).To(BeZero())
+		Expect(fakeSource.StreamOutCallCount()).To(BeZero())
+		Expect(fakeDestination.StreamInCallCount()).To(BeZero())
+	})
+
+	itStreamsThroughTheATC := func() {
+		It("streams from one baggageclaim to the other through the ATC", func() {
+			Expect(streamErr).ToNot(HaveOccurred())
+
+			Expect(fakeSource.StreamOutCallCount()).To(Equal(1))
+			Expect(fakeSource.StreamOutArgsForCall(0)).To(Equal("some-src-path"))
+
+			Expect(fakeDestination.StreamInCallCount()).To(Equal(1))
+			path, in := fakeDestination.StreamInArgsForCall(0)
+			Expect(path).To(Equal("some-dest-path"))
+			Expect(ioutil.ReadAll(in)).To(Equal([]byte("some-tarball")))
+		})
+	}
+
+	Context("when the source's worker does not support p2p streaming", func() {
+		BeforeEach(func() {
+			fakeSource.P2PStreamOutURLReturns("", "", worker.ErrP2PUnavailable)
+		})
+
+		itStreamsThroughTheATC()
+
+		It("does not have the destination pull", func() {
+			Expect(fakeDestination.P2PStreamInCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the destination's worker does not support p2p streaming", func() {
+		BeforeEach(func() {
+			fakeDestination.P2PStreamInReturns(0, worker.ErrP2PUnavailable)
+		})
+
+		itStreamsThroughTheATC()
+
+		It("does not relay or clear the destination", func() {
+			Expect(fakeSource.P2PStreamOutCallCount()).To(BeZero())
+			Expect(fakeDestination.P2PClearCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the destination's worker cannot pull from the source's worker", func() {
+		BeforeEach(func() {
+			fakeDestination.P2PStreamInReturns(0, errors.New("unreachable"))
+		})
+
+		It("relays the contents through the ATC with the negotiated encoding", func() {
+			Expect(streamErr).ToNot(HaveOccurred())
+
+			Expect(fakeSource.P2PStreamOutCallCount()).To(Equal(1))
+			_, url := fakeSource.P2PStreamOutArgsForCall(0)
+			Expect(url).To(Equal("some-stream-out-url"))
+
+			Expect(fakeDestination.P2PUploadCallCount()).To(Equal(1))
+			_, path, encoding, contents := fakeDestination.P2PUploadArgsForCall(0)
+			Expect(path).To(Equal("some-dest-path"))
+			Expect(encoding).To(Equal(p2p.EncodingGzip))
+			Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-encoded-tarball")))
+
+			Expect(fakeSource.StreamOutCallCount()).To(BeZero())
+			Expect(fakeDestination.StreamInCallCount()).To(BeZero())
+		})
+
+		It("clears what the destination's worker may have pulled before relaying", func() {
+			Expect(fakeDestination.P2PClearCallCount()).To(Equal(1))
+			_, path := fakeDestination.P2PClearArgsForCall(0)
+			Expect(path).To(Equal("some-dest-path"))
+		})
+
+		Context("when the destination cannot be cleared", func() {
+			BeforeEach(func() {
+				fakeDestination.P2PClearReturns(errors.New("nope"))
+			})
+
+			It("errors without streaming into it again", func() {
+				Expect(streamErr).To(MatchError(ContainSubstring("nope")))
+
+				Expect(fakeDestination.P2PUploadCallCount()).To(BeZero())
+				Expect(fakeDestination.StreamInCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the ATC cannot upload to the destination's worker", func() {
+			BeforeEach(func() {
+				fakeDestination.P2PUploadReturns(0, errors.New("unreachable"))
+			})
+
+			itStreamsThroughTheATC()
+
+			It("clears what was uploaded before streaming through baggageclaim", func() {
+				Expect(fakeDestination.P2PClearCallCount()).To(Equal(2))
+				_, path := fakeDestination.P2PClearArgsForCall(1)
+				Expect(path).To(Equal("some-dest-path"))
+			})
+		})
+
+		Context("when the ATC cannot pull from the source's worker", func() {
+			BeforeEach(func() {
+				fakeSource.P2PStreamOutReturns(nil, errors.New("unreachable"))
+			})
+
+			itStreamsThroughTheATC()
+
+			It("does not upload to the destination's worker", func() {
+				Expect(fakeDestination.P2PUploadCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the destination is not a volume", func() {
+		var fakeArtifactDestination *workerfakes.FakeArtifactDestination
+
+		BeforeEach(func() {
+			fakeArtifactDestination = new(workerfakes.FakeArtifactDestination)
+			destination = fakeArtifactDestination
+		})
+
+		It("streams through the ATC", func() {
+			Expect(streamErr).ToNot(HaveOccurred())
+
+			Expect(fakeSource.P2PStreamOutURLCallCount()).To(BeZero())
+
+			Expect(fakeArtifactDestination.StreamInCallCount()).To(Equal(1))
+			path, _ := fakeArtifactDestination.StreamInArgsForCall(0)
+			Expect(path).To(Equal("some-dest-path"))
+		})
+	})
+
+	Context("when streaming through the ATC fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeSource.P2PStreamOutURLReturns("", "", worker.ErrP2PUnavailable)
+			fakeSource.StreamOutReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(streamErr).To(Equal(disaster))
+		})
+	})
+})
@@ -19,6 +19,17 @@ var ErrUnsupportedResourceType = errors.New("unsupported resource type")
 var ErrIncompatiblePlatform = errors.New("incompatible platform")
 var ErrMismatchedTags = errors.New("mismatched tags")
 var ErrTeamMismatch = errors.New("mismatched team")
+
+// UnmatchedWorkerRequirementError is returned when the labels of a worker do
+// not satisfy a requirement of the spec's worker selector.
+type UnmatchedWorkerRequirementError struct {
+	Requirement atc.WorkerRequirement
+}
+
+func (err UnmatchedWorkerRequirementError) Error() string {
+	return fmt.Sprintf("labels do not match '%s'", err.Requirement)
+}
+
 var ErrNotImplemented = errors.New("Not implemented")
 
 const userPropertyName = "user"
@@ -31,6 +42,7 @@ type Worker interface {
 	ActiveContainers() int
 	ActiveVolumes() int
 	BuildContainers() int
+	IsFull() bool
 
 	Description() string
 	Name() string
@@ -193,6 +205,12 @@ func (worker *gardenWorker) ActiveVolumes() int {
 	return worker.dbWorker.ActiveVolumes()
 }
 
+// IsFull returns true if the worker has as many containers as it advertised
+// it can run.
+func (worker *gardenWorker) IsFull() bool {
+	return worker.dbWorker.Capacity().Full(worker.dbWorker.ActiveContainers())
+}
+
 func (worker *gardenWorker) Name() string {
 	return worker.dbWorker.Name()
 }
@@ -249,6 +267,17 @@ func (worker *gardenWorker) Satisfying(logger lager.Logger, spec WorkerSpec) (Wo
 		return nil, ErrMismatchedTags
 	}
 
+	requirements, err := spec.WorkerSelector.Requirements()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, requirement := range requirements {
+		if !requirement.Matches(worker.dbWorker.Labels()) {
+			return nil, UnmatchedWorkerRequirementError{Requirement: requirement}
+		}
+	}
+
 	return worker, nil
 }
 
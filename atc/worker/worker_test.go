@@ -24,9 +24,11 @@ var _ = Describe("Worker", func() {
 		fakeVolumeClient      *wfakes.FakeVolumeClient
 		fakeContainerProvider *wfakes.FakeContainerProvider
 		activeContainers      int
+		capacity              atc.WorkerCapacity
 		resourceTypes         []atc.WorkerResourceType
 		platform              string
 		tags                  atc.Tags
+		labels                map[string]string
 		teamID                int
 		ephemeral             bool
 		workerName            string
@@ -42,6 +44,7 @@ var _ = Describe("Worker", func() {
 		logger = lagertest.NewTestLogger("test")
 		fakeVolumeClient = new(wfakes.FakeVolumeClient)
 		activeContainers = 42
+		capacity = atc.WorkerCapacity{}
 		resourceTypes = []atc.WorkerResourceType{
 			{
 				Type:    "some-resource",
@@ -51,6 +54,7 @@ var _ = Describe("Worker", func() {
 		}
 		platform = "some-platform"
 		tags = atc.Tags{"some", "tags"}
+		labels = map[string]string{"zone": "us-east"}
 		teamID = 17
 		ephemeral = true
 		workerName = "some-worker"
@@ -67,9 +71,11 @@ var _ = Describe("Worker", func() {
 	JustBeforeEach(func() {
 		dbWorker := new(dbfakes.FakeWorker)
 		dbWorker.ActiveContainersReturns(activeContainers)
+		dbWorker.CapacityReturns(capacity)
 		dbWorker.ResourceTypesReturns(resourceTypes)
 		dbWorker.PlatformReturns(platform)
 		dbWorker.TagsReturns(tags)
+		dbWorker.LabelsReturns(labels)
 		dbWorker.EphemeralReturns(ephemeral)
 		dbWorker.TeamIDReturns(teamID)
 		dbWorker.NameReturns(workerName)
@@ -87,6 +93,34 @@ var _ = Describe("Worker", func() {
 
 	})
 
+	Describe("IsFull", func() {
+		Context("when the worker has no maximum number of containers", func() {
+			It("is not full", func() {
+				Expect(gardenWorker.IsFull()).To(BeFalse())
+			})
+		})
+
+		Context("when the worker has fewer containers than its maximum", func() {
+			BeforeEach(func() {
+				capacity.MaxContainers = 43
+			})
+
+			It("is not full", func() {
+				Expect(gardenWorker.IsFull()).To(BeFalse())
+			})
+		})
+
+		Context("when the worker has reached its maximum number of containers", func() {
+			BeforeEach(func() {
+				capacity.MaxContainers = 42
+			})
+
+			It("is full", func() {
+				Expect(gardenWorker.IsFull()).To(BeTrue())
+			})
+		})
+	})
+
 	Describe("IsVersionCompatible", func() {
 		It("is compatible when versions are the same", func() {
 			requiredVersion := version.MustNewVersionFromString("1.2.3")
@@ -283,6 +317,30 @@ var _ = Describe("Worker", func() {
 				})
 			})
 
+			Context("when the worker's labels match the worker selector", func() {
+				BeforeEach(func() {
+					spec.WorkerSelector = "zone in (us-east, us-west), !gpu"
+				})
+
+				It("returns the worker", func() {
+					Expect(satisfyingErr).NotTo(HaveOccurred())
+					Expect(satisfyingWorker).To(Equal(gardenWorker))
+				})
+			})
+
+			Context("when the worker's labels do not match the worker selector", func() {
+				BeforeEach(func() {
+					spec.WorkerSelector = "zone=us-east, gpu"
+				})
+
+				It("returns the unmatched requirement", func() {
+					Expect(satisfyingErr).To(Equal(UnmatchedWorkerRequirementError{
+						Requirement: atc.WorkerRequirement{Key: "gpu", Operator: atc.WorkerSelectorExists},
+					}))
+					Expect(satisfyingErr).To(MatchError("labels do not match 'gpu'"))
+				})
+			})
+
 			Context("when all of the requested tags are present", func() {
 				BeforeEach(func() {
 					spec.Tags = []string{"some", "tags"}
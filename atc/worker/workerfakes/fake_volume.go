@@ -9,6 +9,7 @@ import (
 	baggageclaim "github.com/concourse/baggageclaim"
 	db "github.com/concourse/concourse/atc/db"
 	worker "github.com/concourse/concourse/atc/worker"
+	p2p "github.com/concourse/concourse/worker/p2p"
 )
 
 type FakeVolume struct {
@@ -82,6 +83,80 @@ type FakeVolume struct {
 	initializeTaskCacheReturnsOnCall map[int]struct {
 		result1 error
 	}
+	P2PClearStub        func(lager.Logger, string) error
+	p2PClearMutex       sync.RWMutex
+	p2PClearArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	p2PClearReturns struct {
+		result1 error
+	}
+	p2PClearReturnsOnCall map[int]struct {
+		result1 error
+	}
+	P2PStreamInStub        func(lager.Logger, string, string, p2p.Encoding) (int64, error)
+	p2PStreamInMutex       sync.RWMutex
+	p2PStreamInArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+		arg4 p2p.Encoding
+	}
+	p2PStreamInReturns struct {
+		result1 int64
+		result2 error
+	}
+	p2PStreamInReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	P2PStreamOutStub        func(lager.Logger, string) (io.ReadCloser, error)
+	p2PStreamOutMutex       sync.RWMutex
+	p2PStreamOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	p2PStreamOutReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	p2PStreamOutReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	P2PStreamOutURLStub        func(lager.Logger, string) (string, p2p.Encoding, error)
+	p2PStreamOutURLMutex       sync.RWMutex
+	p2PStreamOutURLArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	p2PStreamOutURLReturns struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}
+	p2PStreamOutURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}
+	P2PUploadStub        func(lager.Logger, string, p2p.Encoding, io.Reader) (int64, error)
+	p2PUploadMutex       sync.RWMutex
+	p2PUploadArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 p2p.Encoding
+		arg4 io.Reader
+	}
+	p2PUploadReturns struct {
+		result1 int64
+		result2 error
+	}
+	p2PUploadReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	PathStub        func() string
 	pathMutex       sync.RWMutex
 	pathArgsForCall []struct {
@@ -510,6 +585,330 @@ func (fake *FakeVolume) InitializeTaskCacheReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVolume) P2PClear(arg1 lager.Logger, arg2 string) error {
+	fake.p2PClearMutex.Lock()
+	ret, specificReturn := fake.p2PClearReturnsOnCall[len(fake.p2PClearArgsForCall)]
+	fake.p2PClearArgsForCall = append(fake.p2PClearArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("P2PClear", []interface{}{arg1, arg2})
+	fake.p2PClearMutex.Unlock()
+	if fake.P2PClearStub != nil {
+		return fake.P2PClearStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.p2PClearReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) P2PClearCallCount() int {
+	fake.p2PClearMutex.RLock()
+	defer fake.p2PClearMutex.RUnlock()
+	return len(fake.p2PClearArgsForCall)
+}
+
+func (fake *FakeVolume) P2PClearCalls(stub func(lager.Logger, string) error) {
+	fake.p2PClearMutex.Lock()
+	defer fake.p2PClearMutex.Unlock()
+	fake.P2PClearStub = stub
+}
+
+func (fake *FakeVolume) P2PClearArgsForCall(i int) (lager.Logger, string) {
+	fake.p2PClearMutex.RLock()
+	defer fake.p2PClearMutex.RUnlock()
+	argsForCall := fake.p2PClearArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolume) P2PClearReturns(result1 error) {
+	fake.p2PClearMutex.Lock()
+	defer fake.p2PClearMutex.Unlock()
+	fake.P2PClearStub = nil
+	fake.p2PClearReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) P2PClearReturnsOnCall(i int, result1 error) {
+	fake.p2PClearMutex.Lock()
+	defer fake.p2PClearMutex.Unlock()
+	fake.P2PClearStub = nil
+	if fake.p2PClearReturnsOnCall == nil {
+		fake.p2PClearReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.p2PClearReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) P2PStreamIn(arg1 lager.Logger, arg2 string, arg3 string, arg4 p2p.Encoding) (int64, error) {
+	fake.p2PStreamInMutex.Lock()
+	ret, specificReturn := fake.p2PStreamInReturnsOnCall[len(fake.p2PStreamInArgsForCall)]
+	fake.p2PStreamInArgsForCall = append(fake.p2PStreamInArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+		arg4 p2p.Encoding
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("P2PStreamIn", []interface{}{arg1, arg2, arg3, arg4})
+	fake.p2PStreamInMutex.Unlock()
+	if fake.P2PStreamInStub != nil {
+		return fake.P2PStreamInStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.p2PStreamInReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) P2PStreamInCallCount() int {
+	fake.p2PStreamInMutex.RLock()
+	defer fake.p2PStreamInMutex.RUnlock()
+	return len(fake.p2PStreamInArgsForCall)
+}
+
+func (fake *FakeVolume) P2PStreamInCalls(stub func(lager.Logger, string, string, p2p.Encoding) (int64, error)) {
+	fake.p2PStreamInMutex.Lock()
+	defer fake.p2PStreamInMutex.Unlock()
+	fake.P2PStreamInStub = stub
+}
+
+func (fake *FakeVolume) P2PStreamInArgsForCall(i int) (lager.Logger, string, string, p2p.Encoding) {
+	fake.p2PStreamInMutex.RLock()
+	defer fake.p2PStreamInMutex.RUnlock()
+	argsForCall := fake.p2PStreamInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolume) P2PStreamInReturns(result1 int64, result2 error) {
+	fake.p2PStreamInMutex.Lock()
+	defer fake.p2PStreamInMutex.Unlock()
+	fake.P2PStreamInStub = nil
+	fake.p2PStreamInReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) P2PStreamInReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.p2PStreamInMutex.Lock()
+	defer fake.p2PStreamInMutex.Unlock()
+	fake.P2PStreamInStub = nil
+	if fake.p2PStreamInReturnsOnCall == nil {
+		fake.p2PStreamInReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.p2PStreamInReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) P2PStreamOut(arg1 lager.Logger, arg2 string) (io.ReadCloser, error) {
+	fake.p2PStreamOutMutex.Lock()
+	ret, specificReturn := fake.p2PStreamOutReturnsOnCall[len(fake.p2PStreamOutArgsForCall)]
+	fake.p2PStreamOutArgsForCall = append(fake.p2PStreamOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("P2PStreamOut", []interface{}{arg1, arg2})
+	fake.p2PStreamOutMutex.Unlock()
+	if fake.P2PStreamOutStub != nil {
+		return fake.P2PStreamOutStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.p2PStreamOutReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) P2PStreamOutCallCount() int {
+	fake.p2PStreamOutMutex.RLock()
+	defer fake.p2PStreamOutMutex.RUnlock()
+	return len(fake.p2PStreamOutArgsForCall)
+}
+
+func (fake *FakeVolume) P2PStreamOutCalls(stub func(lager.Logger, string) (io.ReadCloser, error)) {
+	fake.p2PStreamOutMutex.Lock()
+	defer fake.p2PStreamOutMutex.Unlock()
+	fake.P2PStreamOutStub = stub
+}
+
+func (fake *FakeVolume) P2PStreamOutArgsForCall(i int) (lager.Logger, string) {
+	fake.p2PStreamOutMutex.RLock()
+	defer fake.p2PStreamOutMutex.RUnlock()
+	argsForCall := fake.p2PStreamOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolume) P2PStreamOutReturns(result1 io.ReadCloser, result2 error) {
+	fake.p2PStreamOutMutex.Lock()
+	defer fake.p2PStreamOutMutex.Unlock()
+	fake.P2PStreamOutStub = nil
+	fake.p2PStreamOutReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) P2PStreamOutReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.p2PStreamOutMutex.Lock()
+	defer fake.p2PStreamOutMutex.Unlock()
+	fake.P2PStreamOutStub = nil
+	if fake.p2PStreamOutReturnsOnCall == nil {
+		fake.p2PStreamOutReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.p2PStreamOutReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) P2PStreamOutURL(arg1 lager.Logger, arg2 string) (string, p2p.Encoding, error) {
+	fake.p2PStreamOutURLMutex.Lock()
+	ret, specificReturn := fake.p2PStreamOutURLReturnsOnCall[len(fake.p2PStreamOutURLArgsForCall)]
+	fake.p2PStreamOutURLArgsForCall = append(fake.p2PStreamOutURLArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("P2PStreamOutURL", []interface{}{arg1, arg2})
+	fake.p2PStreamOutURLMutex.Unlock()
+	if fake.P2PStreamOutURLStub != nil {
+		return fake.P2PStreamOutURLStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.p2PStreamOutURLReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeVolume) P2PStreamOutURLCallCount() int {
+	fake.p2PStreamOutURLMutex.RLock()
+	defer fake.p2PStreamOutURLMutex.RUnlock()
+	return len(fake.p2PStreamOutURLArgsForCall)
+}
+
+func (fake *FakeVolume) P2PStreamOutURLCalls(stub func(lager.Logger, string) (string, p2p.Encoding, error)) {
+	fake.p2PStreamOutURLMutex.Lock()
+	defer fake.p2PStreamOutURLMutex.Unlock()
+	fake.P2PStreamOutURLStub = stub
+}
+
+func (fake *FakeVolume) P2PStreamOutURLArgsForCall(i int) (lager.Logger, string) {
+	fake.p2PStreamOutURLMutex.RLock()
+	defer fake.p2PStreamOutURLMutex.RUnlock()
+	argsForCall := fake.p2PStreamOutURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeVolume) P2PStreamOutURLReturns(result1 string, result2 p2p.Encoding, result3 error) {
+	fake.p2PStreamOutURLMutex.Lock()
+	defer fake.p2PStreamOutURLMutex.Unlock()
+	fake.P2PStreamOutURLStub = nil
+	fake.p2PStreamOutURLReturns = struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolume) P2PStreamOutURLReturnsOnCall(i int, result1 string, result2 p2p.Encoding, result3 error) {
+	fake.p2PStreamOutURLMutex.Lock()
+	defer fake.p2PStreamOutURLMutex.Unlock()
+	fake.P2PStreamOutURLStub = nil
+	if fake.p2PStreamOutURLReturnsOnCall == nil {
+		fake.p2PStreamOutURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 p2p.Encoding
+			result3 error
+		})
+	}
+	fake.p2PStreamOutURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeVolume) P2PUpload(arg1 lager.Logger, arg2 string, arg3 p2p.Encoding, arg4 io.Reader) (int64, error) {
+	fake.p2PUploadMutex.Lock()
+	ret, specificReturn := fake.p2PUploadReturnsOnCall[len(fake.p2PUploadArgsForCall)]
+	fake.p2PUploadArgsForCall = append(fake.p2PUploadArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 p2p.Encoding
+		arg4 io.Reader
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("P2PUpload", []interface{}{arg1, arg2, arg3, arg4})
+	fake.p2PUploadMutex.Unlock()
+	if fake.P2PUploadStub != nil {
+		return fake.P2PUploadStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.p2PUploadReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVolume) P2PUploadCallCount() int {
+	fake.p2PUploadMutex.RLock()
+	defer fake.p2PUploadMutex.RUnlock()
+	return len(fake.p2PUploadArgsForCall)
+}
+
+func (fake *FakeVolume) P2PUploadCalls(stub func(lager.Logger, string, p2p.Encoding, io.Reader) (int64, error)) {
+	fake.p2PUploadMutex.Lock()
+	defer fake.p2PUploadMutex.Unlock()
+	fake.P2PUploadStub = stub
+}
+
+func (fake *FakeVolume) P2PUploadArgsForCall(i int) (lager.Logger, string, p2p.Encoding, io.Reader) {
+	fake.p2PUploadMutex.RLock()
+	defer fake.p2PUploadMutex.RUnlock()
+	argsForCall := fake.p2PUploadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeVolume) P2PUploadReturns(result1 int64, result2 error) {
+	fake.p2PUploadMutex.Lock()
+	defer fake.p2PUploadMutex.Unlock()
+	fake.P2PUploadStub = nil
+	fake.p2PUploadReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolume) P2PUploadReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.p2PUploadMutex.Lock()
+	defer fake.p2PUploadMutex.Unlock()
+	fake.P2PUploadStub = nil
+	if fake.p2PUploadReturnsOnCall == nil {
+		fake.p2PUploadReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.p2PUploadReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVolume) Path() string {
 	fake.pathMutex.Lock()
 	ret, specificReturn := fake.pathReturnsOnCall[len(fake.pathArgsForCall)]
@@ -929,6 +1328,16 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
 	defer fake.initializeTaskCacheMutex.RUnlock()
+	fake.p2PClearMutex.RLock()
+	defer fake.p2PClearMutex.RUnlock()
+	fake.p2PStreamInMutex.RLock()
+	defer fake.p2PStreamInMutex.RUnlock()
+	fake.p2PStreamOutMutex.RLock()
+	defer fake.p2PStreamOutMutex.RUnlock()
+	fake.p2PStreamOutURLMutex.RLock()
+	defer fake.p2PStreamOutURLMutex.RUnlock()
+	fake.p2PUploadMutex.RLock()
+	defer fake.p2PUploadMutex.RUnlock()
 	fake.pathMutex.RLock()
 	defer fake.pathMutex.RUnlock()
 	fake.propertiesMutex.RLock()
@@ -176,6 +176,16 @@ type FakeWorker struct {
 	gardenClientReturnsOnCall map[int]struct {
 		result1 garden.Client
 	}
+	IsFullStub        func() bool
+	isFullMutex       sync.RWMutex
+	isFullArgsForCall []struct {
+	}
+	isFullReturns struct {
+		result1 bool
+	}
+	isFullReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	IsOwnedByTeamStub        func() bool
 	isOwnedByTeamMutex       sync.RWMutex
 	isOwnedByTeamArgsForCall []struct {
@@ -988,6 +998,58 @@ func (fake *FakeWorker) GardenClientReturnsOnCall(i int, result1 garden.Client)
 	}{result1}
 }
 
+func (fake *FakeWorker) IsFull() bool {
+	fake.isFullMutex.Lock()
+	ret, specificReturn := fake.isFullReturnsOnCall[len(fake.isFullArgsForCall)]
+	fake.isFullArgsForCall = append(fake.isFullArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IsFull", []interface{}{})
+	fake.isFullMutex.Unlock()
+	if fake.IsFullStub != nil {
+		return fake.IsFullStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isFullReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) IsFullCallCount() int {
+	fake.isFullMutex.RLock()
+	defer fake.isFullMutex.RUnlock()
+	return len(fake.isFullArgsForCall)
+}
+
+func (fake *FakeWorker) IsFullCalls(stub func() bool) {
+	fake.isFullMutex.Lock()
+	defer fake.isFullMutex.Unlock()
+	fake.IsFullStub = stub
+}
+
+func (fake *FakeWorker) IsFullReturns(result1 bool) {
+	fake.isFullMutex.Lock()
+	defer fake.isFullMutex.Unlock()
+	fake.IsFullStub = nil
+	fake.isFullReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) IsFullReturnsOnCall(i int, result1 bool) {
+	fake.isFullMutex.Lock()
+	defer fake.isFullMutex.Unlock()
+	fake.IsFullStub = nil
+	if fake.isFullReturnsOnCall == nil {
+		fake.isFullReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isFullReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) IsOwnedByTeam() bool {
 	fake.isOwnedByTeamMutex.Lock()
 	ret, specificReturn := fake.isOwnedByTeamReturnsOnCall[len(fake.isOwnedByTeamArgsForCall)]
@@ -1467,6 +1529,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.findVolumeForTaskCacheMutex.RUnlock()
 	fake.gardenClientMutex.RLock()
 	defer fake.gardenClientMutex.RUnlock()
+	fake.isFullMutex.RLock()
+	defer fake.isFullMutex.RUnlock()
 	fake.isOwnedByTeamMutex.RLock()
 	defer fake.isOwnedByTeamMutex.RUnlock()
 	fake.isVersionCompatibleMutex.RLock()
@@ -0,0 +1,205 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// A WorkerSelector restricts the workers a step or resource may run on by the
+// labels the workers registered with, e.g.
+//
+//	zone=us-east, disk in (ssd, nvme), !gpu
+//
+// A selector is a comma-separated list of requirements, all of which must
+// hold:
+//
+//	key=value         the worker has the label with the value (also key==value)
+//	key!=value        the worker does not have the label with the value
+//	key in (a, b)     the worker has the label with one of the values
+//	key notin (a, b)  the worker does not have the label with any of the values
+//	key               the worker has the label
+//	!key              the worker does not have the label
+type WorkerSelector string
+
+type WorkerSelectorOperator string
+
+const (
+	WorkerSelectorEquals       WorkerSelectorOperator = "="
+	WorkerSelectorNotEquals    WorkerSelectorOperator = "!="
+	WorkerSelectorIn           WorkerSelectorOperator = "in"
+	WorkerSelectorNotIn        WorkerSelectorOperator = "notin"
+	WorkerSelectorExists       WorkerSelectorOperator = "exists"
+	WorkerSelectorDoesNotExist WorkerSelectorOperator = "!"
+)
+
+// A WorkerRequirement is a single requirement of a WorkerSelector.
+type WorkerRequirement struct {
+	Key      string
+	Operator WorkerSelectorOperator
+	Values   []string
+}
+
+var workerLabelRegex = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$`)
+var workerSelectorSetRegex = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+// ValidateWorkerLabel returns an error if the label key or value contains
+// characters which cannot be used in a WorkerSelector.
+func ValidateWorkerLabel(key string, value string) error {
+	if !workerLabelRegex.MatchString(key) {
+		return fmt.Errorf("invalid label key '%s'", key)
+	}
+
+	if !workerLabelRegex.MatchString(value) {
+		return fmt.Errorf("invalid value '%s' for label '%s'", value, key)
+	}
+
+	return nil
+}
+
+// Requirements parses the selector, returning an error if it is malformed.
+// An empty selector has no requirements.
+func (selector WorkerSelector) Requirements() ([]WorkerRequirement, error) {
+	if strings.TrimSpace(string(selector)) == "" {
+		return nil, nil
+	}
+
+	terms, err := splitWorkerSelector(string(selector))
+	if err != nil {
+		return nil, err
+	}
+
+	requirements := []WorkerRequirement{}
+	for _, term := range terms {
+		requirement, err := parseWorkerRequirement(strings.TrimSpace(term))
+		if err != nil {
+			return nil, err
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, nil
+}
+
+// splitWorkerSelector splits the selector on the commas which are not within
+// the parentheses of a set.
+func splitWorkerSelector(selector string) ([]string, error) {
+	terms := []string{}
+
+	depth := 0
+	start := 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("nested parentheses in worker selector")
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in worker selector")
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in worker selector")
+	}
+
+	return append(terms, selector[start:]), nil
+}
+
+func parseWorkerRequirement(term string) (WorkerRequirement, error) {
+	if term == "" {
+		return WorkerRequirement{}, fmt.Errorf("empty requirement in worker selector")
+	}
+
+	var requirement WorkerRequirement
+
+	if match := workerSelectorSetRegex.FindStringSubmatch(term); match != nil {
+		requirement.Key = match[1]
+		requirement.Operator = WorkerSelectorOperator(match[2])
+
+		for _, value := range strings.Split(match[3], ",") {
+			requirement.Values = append(requirement.Values, strings.TrimSpace(value))
+		}
+	} else if strings.HasPrefix(term, "!") && !strings.Contains(term, "=") {
+		requirement.Key = strings.TrimSpace(term[1:])
+		requirement.Operator = WorkerSelectorDoesNotExist
+	} else if i := strings.Index(term, "!="); i != -1 {
+		requirement.Key = strings.TrimSpace(term[:i])
+		requirement.Operator = WorkerSelectorNotEquals
+		requirement.Values = []string{strings.TrimSpace(term[i+2:])}
+	} else if i := strings.Index(term, "=="); i != -1 {
+		requirement.Key = strings.TrimSpace(term[:i])
+		requirement.Operator = WorkerSelectorEquals
+		requirement.Values = []string{strings.TrimSpace(term[i+2:])}
+	} else if i := strings.Index(term, "="); i != -1 {
+		requirement.Key = strings.TrimSpace(term[:i])
+		requirement.Operator = WorkerSelectorEquals
+		requirement.Values = []string{strings.TrimSpace(term[i+1:])}
+	} else {
+		requirement.Key = term
+		requirement.Operator = WorkerSelectorExists
+	}
+
+	if !workerLabelRegex.MatchString(requirement.Key) {
+		return WorkerRequirement{}, fmt.Errorf("invalid label key in requirement '%s'", term)
+	}
+
+	for _, value := range requirement.Values {
+		if !workerLabelRegex.MatchString(value) {
+			return WorkerRequirement{}, fmt.Errorf("invalid label value in requirement '%s'", term)
+		}
+	}
+
+	return requirement, nil
+}
+
+// Matches returns true if the labels satisfy the requirement.
+func (requirement WorkerRequirement) Matches(labels map[string]string) bool {
+	value, found := labels[requirement.Key]
+
+	switch requirement.Operator {
+	case WorkerSelectorEquals, WorkerSelectorIn:
+		return found && requirement.hasValue(value)
+	case WorkerSelectorNotEquals, WorkerSelectorNotIn:
+		return !found || !requirement.hasValue(value)
+	case WorkerSelectorExists:
+		return found
+	case WorkerSelectorDoesNotExist:
+		return !found
+	default:
+		return false
+	}
+}
+
+func (requirement WorkerRequirement) hasValue(value string) bool {
+	for _, v := range requirement.Values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (requirement WorkerRequirement) String() string {
+	switch requirement.Operator {
+	case WorkerSelectorEquals, WorkerSelectorNotEquals:
+		return requirement.Key + string(requirement.Operator) + strings.Join(requirement.Values, "")
+	case WorkerSelectorIn, WorkerSelectorNotIn:
+		return fmt.Sprintf("%s %s (%s)", requirement.Key, requirement.Operator, strings.Join(requirement.Values, ", "))
+	case WorkerSelectorDoesNotExist:
+		return "!" + requirement.Key
+	default:
+		return requirement.Key
+	}
+}
@@ -0,0 +1,94 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WorkerSelector", func() {
+	Describe("Requirements", func() {
+		It("returns no requirements for an empty selector", func() {
+			requirements, err := atc.WorkerSelector(" ").Requirements()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirements).To(BeEmpty())
+		})
+
+		It("parses every kind of requirement", func() {
+			requirements, err := atc.WorkerSelector("zone=us-east, tier==2, arch != arm, disk in (ssd, nvme), os notin (windows), gpu, !spot").Requirements()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirements).To(Equal([]atc.WorkerRequirement{
+				{Key: "zone", Operator: atc.WorkerSelectorEquals, Values: []string{"us-east"}},
+				{Key: "tier", Operator: atc.WorkerSelectorEquals, Values: []string{"2"}},
+				{Key: "arch", Operator: atc.WorkerSelectorNotEquals, Values: []string{"arm"}},
+				{Key: "disk", Operator: atc.WorkerSelectorIn, Values: []string{"ssd", "nvme"}},
+				{Key: "os", Operator: atc.WorkerSelectorNotIn, Values: []string{"windows"}},
+				{Key: "gpu", Operator: atc.WorkerSelectorExists},
+				{Key: "spot", Operator: atc.WorkerSelectorDoesNotExist},
+			}))
+		})
+
+		DescribeTable("malformed selectors",
+			func(selector string, message string) {
+				_, err := atc.WorkerSelector(selector).Requirements()
+				Expect(err).To(MatchError(message))
+			},
+			Entry("empty requirement", "zone=us-east,,gpu", "empty requirement in worker selector"),
+			Entry("unbalanced parentheses", "disk in (ssd, nvme", "unbalanced parentheses in worker selector"),
+			Entry("nested parentheses", "disk in ((ssd))", "nested parentheses in worker selector"),
+			Entry("invalid key", "zo ne=us-east", "invalid label key in requirement 'zo ne=us-east'"),
+			Entry("missing value", "zone=", "invalid label value in requirement 'zone='"),
+			Entry("empty set value", "disk in (ssd,)", "invalid label value in requirement 'disk in (ssd,)'"),
+		)
+	})
+
+	Describe("WorkerRequirement", func() {
+		labels := map[string]string{"zone": "us-east", "disk": "ssd"}
+
+		DescribeTable("Matches",
+			func(selector string, matches bool) {
+				requirements, err := atc.WorkerSelector(selector).Requirements()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requirements).To(HaveLen(1))
+				Expect(requirements[0].Matches(labels)).To(Equal(matches))
+			},
+			Entry("equal value", "zone=us-east", true),
+			Entry("different value", "zone=us-west", false),
+			Entry("missing label", "region=us", false),
+			Entry("not equal to a different value", "zone!=us-west", true),
+			Entry("not equal to the value", "zone!=us-east", false),
+			Entry("not equal on a missing label", "region!=us", true),
+			Entry("in the set", "disk in (nvme, ssd)", true),
+			Entry("not in the set", "disk in (nvme, hdd)", false),
+			Entry("in a set on a missing label", "region in (us)", false),
+			Entry("notin the set", "disk notin (nvme, hdd)", true),
+			Entry("notin a set with the value", "disk notin (ssd)", false),
+			Entry("notin on a missing label", "region notin (us)", true),
+			Entry("existing label", "disk", true),
+			Entry("missing label exists", "gpu", false),
+			Entry("negated existing label", "!disk", false),
+			Entry("negated missing label", "!gpu", true),
+		)
+
+		It("describes itself", func() {
+			requirements, err := atc.WorkerSelector("zone==us-east,arch!=arm,disk in (ssd,nvme),os notin (windows),gpu,!spot").Requirements()
+			Expect(err).NotTo(HaveOccurred())
+
+			descriptions := []string{}
+			for _, requirement := range requirements {
+				descriptions = append(descriptions, requirement.String())
+			}
+
+			Expect(descriptions).To(Equal([]string{
+				"zone=us-east",
+				"arch!=arm",
+				"disk in (ssd, nvme)",
+				"os notin (windows)",
+				"gpu",
+				"!spot",
+			}))
+		})
+	})
+})
@@ -60,5 +60,27 @@ var _ = Describe("Worker", func() {
 				Expect(err.Error()).To(ContainSubstring("missing garden address"))
 			})
 		})
+
+		Context("when labels are valid", func() {
+			BeforeEach(func() {
+				worker.Labels = map[string]string{"zone": "us-east", "disk": "ssd"}
+			})
+
+			It("returns no errors", func() {
+				Expect(worker.Validate()).To(Succeed())
+			})
+		})
+
+		Context("when a label value cannot be selected", func() {
+			BeforeEach(func() {
+				worker.Labels = map[string]string{"zone": "us east"}
+			})
+
+			It("returns errors", func() {
+				err := worker.Validate()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid value 'us east' for label 'zone'"))
+			})
+		})
 	})
 })
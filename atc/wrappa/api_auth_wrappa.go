@@ -118,11 +118,14 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		// authorized (requested team matches resource team)
 		case atc.CheckResource,
 			atc.CheckResourceType,
+			atc.ListResourceChecks,
+			atc.ResourceCheckEvents,
 			atc.CreateJobBuild,
 			atc.CreatePipelineBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
 			atc.EnableResourceVersion,
+			atc.SaveResourceVersion,
 			atc.PinResourceVersion,
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
@@ -136,6 +139,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.RenamePipeline,
 			atc.UnpauseJob,
 			atc.UnpausePipeline,
+			atc.ArchivePipeline,
 			atc.ExposePipeline,
 			atc.HidePipeline,
 			atc.SaveConfig,
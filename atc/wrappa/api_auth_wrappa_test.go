@@ -216,9 +216,12 @@ var _ = Describe("APIAuthWrappa", func() {
 				// authorized (requested team matches resource team)
 				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
 				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
+				atc.ListResourceChecks:      authorized(inputHandlers[atc.ListResourceChecks]),
+				atc.ResourceCheckEvents:     authorized(inputHandlers[atc.ResourceCheckEvents]),
 				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
 				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
 				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.SaveResourceVersion:     authorized(inputHandlers[atc.SaveResourceVersion]),
 				atc.EnableResourceVersion:   authorized(inputHandlers[atc.EnableResourceVersion]),
 				atc.PinResourceVersion:      authorized(inputHandlers[atc.PinResourceVersion]),
 				atc.UnpinResource:           authorized(inputHandlers[atc.UnpinResource]),
@@ -234,6 +237,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.SaveConfig:              authorized(inputHandlers[atc.SaveConfig]),
 				atc.UnpauseJob:              authorized(inputHandlers[atc.UnpauseJob]),
 				atc.UnpausePipeline:         authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.ArchivePipeline:         authorized(inputHandlers[atc.ArchivePipeline]),
 				atc.ExposePipeline:          authorized(inputHandlers[atc.ExposePipeline]),
 				atc.HidePipeline:            authorized(inputHandlers[atc.HidePipeline]),
 				atc.CreatePipelineBuild:     authorized(inputHandlers[atc.CreatePipelineBuild]),
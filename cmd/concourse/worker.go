@@ -1,12 +1,15 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	gclient "code.cloudfoundry.org/garden/client"
 	gconn "code.cloudfoundry.org/garden/client/connection"
 	"code.cloudfoundry.org/lager"
@@ -14,6 +17,7 @@ import (
 	bclient "github.com/concourse/baggageclaim/client"
 	"github.com/concourse/concourse"
 	"github.com/concourse/concourse/worker"
+	"github.com/concourse/concourse/worker/p2p"
 	"github.com/concourse/flag"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
@@ -40,6 +44,11 @@ type WorkerCommand struct {
 	HealthcheckBindPort uint16        `long:"healthcheck-bind-port"  default:"8888"     description:"Port on which to listen for health checking requests."`
 	HealthCheckTimeout  time.Duration `long:"healthcheck-timeout"    default:"5s"       description:"HTTP timeout for the full duration of health checking."`
 
+	P2PBindIP   flag.IP  `long:"p2p-bind-ip"                       description:"IP address on which to listen for volume streaming requests from other workers and the ATC. Defaults to 0.0.0.0 if --p2p-url is set, so that they can reach it, and to 127.0.0.1 otherwise, as it is then forwarded through the TSA."`
+	P2PBindPort uint16   `long:"p2p-bind-port" default:"7789"      description:"Port on which to listen for volume streaming requests from other workers and the ATC."`
+	P2PURL      flag.URL `long:"p2p-url"                             description:"URL at which other workers and the ATC can reach this worker to stream volumes directly. If not set, the p2p server is forwarded through the TSA."`
+	P2PKey      string   `long:"p2p-key"                             description:"Key shared with the ATC's --volume-stream-key, with which it signs its requests to stream volumes. Volumes are streamed from one baggageclaim to another through the ATC if not set."`
+
 	SweepInterval time.Duration `long:"sweep-interval" default:"30s" description:"Interval on which containers and volumes will be garbage collected from the worker."`
 
 	RebalanceInterval time.Duration `long:"rebalance-interval" description:"Duration after which the registration should be swapped to another random SSH gateway."`
@@ -121,6 +130,43 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 		},
 	}
 
+	if cmd.P2PURL.URL != nil && cmd.P2PKey == "" {
+		return nil, errors.New("--p2p-key is required with --p2p-url")
+	}
+
+	if cmd.P2PKey != "" {
+		if cmd.P2PURL.URL != nil {
+			atcWorker.P2PURL = cmd.P2PURL.String()
+		}
+
+		p2pServer, err := p2p.NewServer(
+			logger.Session("p2p"),
+			clock.NewClock(),
+			[]byte(cmd.P2PKey),
+			bclient.New(cmd.baggageclaimURL(), http.DefaultTransport),
+			&http.Client{},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		p2pHandler, err := p2pServer.Handler()
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, grouper.Member{
+			Name: "p2p",
+			Runner: NewLoggingRunner(
+				logger.Session("p2p-runner"),
+				http_server.New(
+					cmd.p2pAddr(),
+					p2pHandler,
+				),
+			),
+		})
+	}
+
 	if cmd.TSA.WorkerPrivateKey != nil {
 		tsaClient := cmd.TSA.Client(atcWorker)
 
@@ -139,6 +185,12 @@ func (cmd *WorkerCommand) Runner(args []string) (ifrit.Runner, error) {
 			LocalBaggageclaimAddr:    cmd.baggageclaimAddr(),
 		}
 
+		// forward the p2p server unless other workers can reach it directly
+		if cmd.P2PKey != "" && cmd.P2PURL.URL == nil {
+			beacon.LocalP2PNetwork = "tcp"
+			beacon.LocalP2PAddr = cmd.p2pAddr()
+		}
+
 		members = append(members, grouper.Member{
 			Name: "beacon",
 			Runner: NewLoggingRunner(
@@ -210,6 +262,19 @@ func (cmd *WorkerCommand) baggageclaimURL() string {
 	return fmt.Sprintf("http://%s", cmd.baggageclaimAddr())
 }
 
+func (cmd *WorkerCommand) p2pAddr() string {
+	bindIP := cmd.P2PBindIP.IP
+	if bindIP == nil {
+		if cmd.P2PURL.URL != nil {
+			bindIP = net.IPv4zero
+		} else {
+			bindIP = net.IPv4(127, 0, 0, 1)
+		}
+	}
+
+	return fmt.Sprintf("%s:%d", bindIP, cmd.P2PBindPort)
+}
+
 func (cmd *WorkerCommand) workerName() (string, error) {
 	if cmd.Worker.Name != "" {
 		return cmd.Worker.Name, nil
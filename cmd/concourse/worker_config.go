@@ -1,6 +1,7 @@
 package main
 
 import (
+	"runtime"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -11,18 +12,30 @@ type WorkerConfig struct {
 	Tags     []string `long:"tag"   description:"A tag to set during registration. Can be specified multiple times."`
 	TeamName string   `long:"team"  description:"The name of the team that this worker will be assigned to."`
 
+	Labels map[string]string `long:"label" value-name:"NAME:VALUE" description:"A label to set during registration, matched by worker selectors. Can be specified multiple times."`
+
 	HTTPProxy  string `long:"http-proxy"  env:"http_proxy"                  description:"HTTP proxy endpoint to use for containers."`
 	HTTPSProxy string `long:"https-proxy" env:"https_proxy"                 description:"HTTPS proxy endpoint to use for containers."`
 	NoProxy    string `long:"no-proxy"    env:"no_proxy"                    description:"Blacklist of addresses to skip the proxy when reaching."`
 
 	Ephemeral bool `long:"ephemeral" description:"If set, the worker will be immediately removed upon stalling."`
 
+	MaxContainers int   `long:"max-containers" description:"Maximum number of containers to run at once. Once reached, no more containers are placed on the worker. The limit is best-effort, as concurrent placements may exceed it. If not specified, there is no maximum."`
+	CPU           int   `long:"cpu"            description:"Number of CPUs to advertise. This is only shown through the API and is not used to place containers. If not specified, the number of CPUs on the machine is used."`
+	Memory        int64 `long:"memory"         description:"Memory in bytes to advertise. This is only shown through the API and is not used to place containers."`
+
 	Version string `long:"version" hidden:"true" description:"Version of the worker. This is normally baked in to the binary, so this flag is hidden."`
 }
 
 func (c WorkerConfig) Worker() atc.Worker {
+	cpu := c.CPU
+	if cpu == 0 {
+		cpu = runtime.NumCPU()
+	}
+
 	return atc.Worker{
 		Tags:          c.Tags,
+		Labels:        c.Labels,
 		Team:          c.TeamName,
 		Name:          c.Name,
 		StartTime:     time.Now().Unix(),
@@ -31,5 +44,10 @@ func (c WorkerConfig) Worker() atc.Worker {
 		HTTPSProxyURL: c.HTTPSProxy,
 		NoProxy:       c.NoProxy,
 		Ephemeral:     c.Ephemeral,
+		Capacity: atc.WorkerCapacity{
+			MaxContainers: c.MaxContainers,
+			CPU:           cpu,
+			Memory:        c.Memory,
+		},
 	}
 }
@@ -99,7 +99,7 @@ func (command *BuildsCommand) Execute([]string) error {
 
 		var found bool
 		builds, _, found, err = currentTeam.PipelineBuilds(
-			string(command.Pipeline),
+			command.Pipeline.Ref(),
 			page,
 		)
 		if err != nil {
@@ -2,15 +2,19 @@ package commands
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type CheckResourceCommand struct {
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of a resource to check version for"`
 	Version  *atc.Version             `short:"f" long:"from"                     value-name:"VERSION"           description:"Version of the resource to check from, e.g. ref:abcd or path:thing-1.2.3.tgz"`
+	Watch    bool                     `short:"w" long:"watch"                                                   description:"Stream the output of the check as it runs"`
 }
 
 func (command *CheckResourceCommand) Execute(args []string) error {
@@ -29,7 +33,16 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 		version = *command.Version
 	}
 
+	if command.Watch {
+		return command.checkAndWatch(target.Team(), version)
+	}
+
 	found, err := target.Team().CheckResource(command.Resource.PipelineName, command.Resource.ResourceName, version)
+
+	return command.reportCheck(found, err)
+}
+
+func (command *CheckResourceCommand) reportCheck(found bool, err error) error {
 	if err != nil {
 		return err
 	}
@@ -41,3 +54,30 @@ func (command *CheckResourceCommand) Execute(args []string) error {
 	fmt.Printf("checked '%s'\n", command.Resource.ResourceName)
 	return nil
 }
+
+// checkAndWatch starts the check and streams the events of the check that it
+// records. If no check is recorded, e.g. because the pipeline is paused, the
+// outcome is reported as it would be without watching.
+func (command *CheckResourceCommand) checkAndWatch(team concourse.Team, version atc.Version) error {
+	check, found, err := team.StartCheckResource(command.Resource.PipelineName, command.Resource.ResourceName, version)
+	if err != nil || !found || check == nil {
+		return command.reportCheck(found, err)
+	}
+
+	return command.watchCheck(team, check.ID)
+}
+
+func (command *CheckResourceCommand) watchCheck(team concourse.Team, checkID int) error {
+	eventSource, err := team.ResourceCheckEvents(command.Resource.PipelineName, command.Resource.ResourceName, checkID)
+	if err != nil {
+		return err
+	}
+
+	exitCode := eventstream.Render(os.Stdout, eventSource, eventstream.RenderOptions{})
+
+	eventSource.Close()
+
+	os.Exit(exitCode)
+
+	return nil
+}
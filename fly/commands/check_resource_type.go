@@ -6,11 +6,13 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type CheckResourceTypeCommand struct {
 	ResourceType flaghelpers.ResourceFlag `short:"r" long:"resource-type" required:"true" value-name:"PIPELINE/RESOURCE-TYPE" description:"Name of a resource-type to check"`
 	Version      *atc.Version             `short:"f" long:"from"                     value-name:"VERSION"           description:"Version of the resource type to check from, e.g. digest:sha256@..."`
+	Recursive    bool                     `long:"recursive"                                                             description:"Also schedule every resource type and resource using the resource type to be rechecked"`
 }
 
 func (command *CheckResourceTypeCommand) Execute(args []string) error {
@@ -29,6 +31,10 @@ func (command *CheckResourceTypeCommand) Execute(args []string) error {
 		version = *command.Version
 	}
 
+	if command.Recursive {
+		return command.checkRecursively(target.Team(), version)
+	}
+
 	found, err := target.Team().CheckResourceType(command.ResourceType.PipelineName, command.ResourceType.ResourceName, version)
 	if err != nil {
 		return err
@@ -41,3 +47,26 @@ func (command *CheckResourceTypeCommand) Execute(args []string) error {
 	fmt.Printf("checked '%s'\n", command.ResourceType.ResourceName)
 	return nil
 }
+
+func (command *CheckResourceTypeCommand) checkRecursively(team concourse.Team, version atc.Version) error {
+	dependents, found, err := team.CheckResourceTypeRecursively(command.ResourceType.PipelineName, command.ResourceType.ResourceName, version)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' or resource-type '%s' not found\n", command.ResourceType.PipelineName, command.ResourceType.ResourceName)
+	}
+
+	fmt.Printf("checked '%s'\n", command.ResourceType.ResourceName)
+
+	for _, resourceType := range dependents.ResourceTypes {
+		fmt.Printf("scheduled resource-type '%s' to be rechecked\n", resourceType)
+	}
+
+	for _, resource := range dependents.Resources {
+		fmt.Printf("scheduled resource '%s' to be rechecked\n", resource)
+	}
+
+	return nil
+}
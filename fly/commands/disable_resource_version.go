@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type DisableResourceVersionCommand struct {
+	Resource  flaghelpers.ResourceFlag `short:"r" long:"resource"   required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+	VersionID int                      `short:"i" long:"version-id" required:"true" value-name:"ID"                description:"ID of the version to disable, as listed by resource-versions"`
+	Reason    string                   `short:"m" long:"reason"     required:"true" value-name:"REASON"            description:"Why the version is being disabled, kept in its annotations"`
+}
+
+func (command *DisableResourceVersionCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Team().DisableResourceVersion(command.Resource.PipelineName, command.Resource.ResourceName, command.VersionID, command.Reason)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("could not find version %d of resource '%s' in pipeline '%s'\n", command.VersionID, command.Resource.ResourceName, command.Resource.PipelineName)
+	}
+
+	fmt.Printf("disabled version %d of '%s'\n", command.VersionID, command.Resource.ResourceName)
+
+	return nil
+}
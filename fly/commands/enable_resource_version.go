@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type EnableResourceVersionCommand struct {
+	Resource  flaghelpers.ResourceFlag `short:"r" long:"resource"   required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource"`
+	VersionID int                      `short:"i" long:"version-id" required:"true" value-name:"ID"                description:"ID of the version to enable, as listed by resource-versions"`
+	Comment   string                   `short:"m" long:"comment"                    value-name:"COMMENT"           description:"Why the version is being enabled, kept in its annotations"`
+}
+
+func (command *EnableResourceVersionCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	found, err := target.Team().EnableResourceVersion(command.Resource.PipelineName, command.Resource.ResourceName, command.VersionID, command.Comment)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("could not find version %d of resource '%s' in pipeline '%s'\n", command.VersionID, command.Resource.ResourceName, command.Resource.PipelineName)
+	}
+
+	fmt.Printf("enabled version %d of '%s'\n", command.VersionID, command.Resource.ResourceName)
+
+	return nil
+}
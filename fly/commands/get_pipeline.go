@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 
@@ -20,9 +24,14 @@ import (
 type GetPipelineCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Get configuration of this pipeline"`
 	JSON     bool                     `short:"j" long:"json"                     description:"Print config as json instead of yaml"`
+	Split    string                   `long:"split" value-name:"DIR"             description:"Write the config to a file per group of jobs in this directory instead of printing it, for use with set-pipeline -c DIR"`
 }
 
 func (command *GetPipelineCommand) Validate() error {
+	if command.JSON && command.Split != "" {
+		return errors.New("--json cannot be used with --split")
+	}
+
 	return command.Pipeline.Validate()
 }
 
@@ -33,7 +42,7 @@ func (command *GetPipelineCommand) Execute(args []string) error {
 	}
 
 	asJSON := command.JSON
-	pipelineName := string(command.Pipeline)
+	pipelineRef := command.Pipeline.Ref()
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -45,7 +54,7 @@ func (command *GetPipelineCommand) Execute(args []string) error {
 		return err
 	}
 
-	config, rawConfig, _, found, err := target.Team().PipelineConfig(pipelineName)
+	config, rawConfig, _, found, err := target.Team().PipelineConfig(pipelineRef)
 	if err != nil {
 		if _, ok := err.(concourse.PipelineConfigError); ok {
 			_ = dumpRawConfig(rawConfig, asJSON)
@@ -60,6 +69,10 @@ func (command *GetPipelineCommand) Execute(args []string) error {
 		return errors.New("pipeline not found")
 	}
 
+	if command.Split != "" {
+		return split(config, command.Split)
+	}
+
 	return dump(config, asJSON)
 }
 
@@ -80,6 +93,126 @@ func dump(config atc.Config, asJSON bool) error {
 	return err
 }
 
+// split writes the jobs of each group to their own file in dir, in the
+// group's order. Everything else, including the groups themselves and any jobs
+// not in a group, is written to pipeline.yml.
+//
+// The directory must be empty, so that files left over from another config
+// are not read back along with it by set-pipeline.
+func split(config atc.Config, dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) != 0 {
+		return fmt.Errorf("directory '%s' is not empty", dir)
+	}
+
+	assigned := map[string]bool{}
+	fileNames := map[string]bool{}
+
+	for _, group := range config.Groups {
+		var jobs atc.JobConfigs
+		for _, name := range group.Jobs {
+			job, found := config.Jobs.Lookup(name)
+			if !found || assigned[name] {
+				continue
+			}
+
+			assigned[name] = true
+			jobs = append(jobs, job)
+		}
+
+		if len(jobs) == 0 {
+			continue
+		}
+
+		err = writeSplitFile(
+			filepath.Join(dir, splitFileName(group.Name, fileNames)),
+			atc.Config{Jobs: jobs},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	var ungrouped atc.JobConfigs
+	for _, job := range config.Jobs {
+		if !assigned[job.Name] {
+			ungrouped = append(ungrouped, job)
+		}
+	}
+
+	return writeSplitFile(
+		filepath.Join(dir, "pipeline.yml"),
+		atc.Config{
+			Groups:               config.Groups,
+			ResourceTypeDefaults: config.ResourceTypeDefaults,
+			Resources:            config.Resources,
+			ResourceTypes:        config.ResourceTypes,
+			Jobs:                 ungrouped,
+		},
+	)
+}
+
+func writeSplitFile(path string, config atc.Config) error {
+	// marshal via a map so that empty sections are left out
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	var sections map[string]interface{}
+	err = json.Unmarshal(payload, &sections)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range sections {
+		if value == nil {
+			delete(sections, key)
+		}
+	}
+
+	payload, err = yaml.Marshal(sections)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(path, payload, 0644)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %s\n", path)
+
+	return nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// splitFileName returns the name of the file for the group's jobs, adding a
+// suffix if another group's file already has the name. Names are compared
+// case-insensitively as they may end up on a case-insensitive filesystem.
+func splitFileName(groupName string, taken map[string]bool) string {
+	base := "jobs-" + unsafeFileNameChars.ReplaceAllString(groupName, "_")
+
+	name := base + ".yml"
+	for i := 2; taken[strings.ToLower(name)]; i++ {
+		name = fmt.Sprintf("%s-%d.yml", base, i)
+	}
+
+	taken[strings.ToLower(name)] = true
+
+	return name
+}
+
 func dumpRawConfig(rawConfig atc.RawConfig, asJSON bool) error {
 	var payload []byte
 	if asJSON {
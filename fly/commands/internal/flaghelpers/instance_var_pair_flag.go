@@ -0,0 +1,31 @@
+package flaghelpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InstanceVarPairFlag is a var identifying a pipeline instance, given as
+// 'name=value'. The value is read the same way as the instance vars given
+// as part of a PipelineFlag.
+type InstanceVarPairFlag struct {
+	Name  string
+	Value interface{}
+}
+
+func (pair *InstanceVarPairFlag) UnmarshalFlag(value string) error {
+	vs := strings.SplitN(value, "=", 2)
+	if len(vs) != 2 || vs[0] == "" {
+		return fmt.Errorf("invalid instance var '%s' (must be name=value)", value)
+	}
+
+	parsed, err := parseInstanceVar(vs[1])
+	if err != nil {
+		return fmt.Errorf("invalid instance var '%s': %s", value, err)
+	}
+
+	pair.Name = vs[0]
+	pair.Value = parsed
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package flaghelpers_test
+
+import (
+	. "github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InstanceVarPairFlag", func() {
+	It("reads the value as YAML", func() {
+		var pair InstanceVarPairFlag
+		Expect(pair.UnmarshalFlag("number=5.1")).To(Succeed())
+		Expect(pair).To(Equal(InstanceVarPairFlag{Name: "number", Value: 5.1}))
+	})
+
+	It("keeps scalars which would not be rendered as given as strings, like a PipelineFlag", func() {
+		for _, value := range []string{"5.10", "yes", "007"} {
+			var pair InstanceVarPairFlag
+			Expect(pair.UnmarshalFlag("version=" + value)).To(Succeed())
+			Expect(pair.Value).To(Equal(value))
+
+			pipelineFlag := PipelineFlag("some-pipeline/version:" + value)
+			Expect(pipelineFlag.Validate()).To(Succeed())
+			Expect(pipelineFlag.Ref().InstanceVars["version"]).To(Equal(pair.Value))
+		}
+	})
+
+	It("errors when the value is not given", func() {
+		var pair InstanceVarPairFlag
+		Expect(pair.UnmarshalFlag("version")).To(MatchError("invalid instance var 'version' (must be name=value)"))
+	})
+})
@@ -1,23 +1,98 @@
 package flaghelpers
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/jessevdk/go-flags"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/concourse/concourse/fly/rc"
 )
 
+// PipelineFlag refers to a pipeline either by name or, for a pipeline
+// instance, as 'name/key:value,other-key:value'.
 type PipelineFlag string
 
 func (flag *PipelineFlag) Validate() error {
+	_, err := flag.parse()
+	return err
+}
+
+// ValidateName is used in place of Validate where only a pipeline name, and
+// not a pipeline instance, makes sense.
+func (flag *PipelineFlag) ValidateName() error {
 	if strings.Contains(string(*flag), "/") {
 		return errors.New("pipeline name cannot contain '/'")
 	}
 	return nil
 }
 
+// Ref returns the pipeline the flag refers to. The flag must have been
+// validated.
+func (flag PipelineFlag) Ref() atc.PipelineRef {
+	ref, _ := flag.parse()
+	return ref
+}
+
+func (flag PipelineFlag) parse() (atc.PipelineRef, error) {
+	vs := strings.SplitN(string(flag), "/", 2)
+
+	ref := atc.PipelineRef{Name: vs[0]}
+	if len(vs) == 1 {
+		return ref, nil
+	}
+
+	ref.InstanceVars = atc.InstanceVars{}
+
+	for _, pair := range strings.Split(vs[1], ",") {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return atc.PipelineRef{}, fmt.Errorf("invalid instance var '%s' (must be key:value)", pair)
+		}
+
+		value, err := parseInstanceVar(kv[1])
+		if err != nil {
+			return atc.PipelineRef{}, fmt.Errorf("invalid instance var '%s': %s", pair, err)
+		}
+
+		ref.InstanceVars[kv[0]] = value
+	}
+
+	return ref, nil
+}
+
+// parseInstanceVar reads an instance var's value as YAML, keeping ambiguous
+// scalars as strings.
+func parseInstanceVar(text string) (interface{}, error) {
+	var value interface{}
+	err := yaml.Unmarshal([]byte(text), &value)
+	if err != nil {
+		return nil, err
+	}
+
+	return ambiguousScalarAsString(text, value), nil
+}
+
+// ambiguousScalarAsString keeps a scalar which YAML reads as something other
+// than a string as the given text, unless the text is exactly how the value
+// is rendered, so that e.g. '5.10' is not read as 5.1 and 'yes' is not read as
+// true.
+func ambiguousScalarAsString(text string, value interface{}) interface{} {
+	switch value.(type) {
+	case nil, bool, int, int64, uint64, float64:
+		rendered, err := json.Marshal(value)
+		if err != nil || string(rendered) != text {
+			return text
+		}
+	}
+
+	return value
+}
+
 func (flag *PipelineFlag) Complete(match string) []flags.Completion {
 	fly := parseFlags()
 
@@ -38,8 +113,9 @@ func (flag *PipelineFlag) Complete(match string) []flags.Completion {
 
 	comps := []flags.Completion{}
 	for _, pipeline := range pipelines {
-		if strings.HasPrefix(pipeline.Name, match) {
-			comps = append(comps, flags.Completion{Item: pipeline.Name})
+		ref := pipeline.Ref().String()
+		if strings.HasPrefix(ref, match) {
+			comps = append(comps, flags.Completion{Item: ref})
 		}
 	}
 
@@ -0,0 +1,73 @@
+package flaghelpers_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineFlag", func() {
+	Context("when only a pipeline name is specified", func() {
+		It("refers to the pipeline by name", func() {
+			pipelineFlag := PipelineFlag("some-pipeline")
+
+			Expect(pipelineFlag.Validate()).To(Succeed())
+			Expect(pipelineFlag.Ref()).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+		})
+	})
+
+	Context("when instance vars are specified", func() {
+		It("refers to the pipeline instance", func() {
+			pipelineFlag := PipelineFlag("some-pipeline/branch:release,number:5.1")
+
+			Expect(pipelineFlag.Validate()).To(Succeed())
+			Expect(pipelineFlag.Ref()).To(Equal(atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"branch": "release",
+					"number": 5.1,
+				},
+			}))
+		})
+
+		It("keeps scalars which would not be rendered as given as strings", func() {
+			pipelineFlag := PipelineFlag("some-pipeline/version:5.10,enabled:yes,count:007")
+
+			Expect(pipelineFlag.Validate()).To(Succeed())
+			Expect(pipelineFlag.Ref()).To(Equal(atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"version": "5.10",
+					"enabled": "yes",
+					"count":   "007",
+				},
+			}))
+		})
+
+		It("reads quoted values as strings", func() {
+			pipelineFlag := PipelineFlag(`some-pipeline/version:"5.1"`)
+
+			Expect(pipelineFlag.Validate()).To(Succeed())
+			Expect(pipelineFlag.Ref()).To(Equal(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"version": "5.1"},
+			}))
+		})
+
+		It("can only be validated as a pipeline instance", func() {
+			pipelineFlag := PipelineFlag("some-pipeline/branch:release")
+
+			Expect(pipelineFlag.ValidateName()).To(MatchError("pipeline name cannot contain '/'"))
+		})
+	})
+
+	Context("when an instance var is malformed", func() {
+		It("displays an error message", func() {
+			pipelineFlag := PipelineFlag("some-pipeline/branch")
+
+			Expect(pipelineFlag.Validate()).To(MatchError("invalid instance var 'branch' (must be key:value)"))
+		})
+	})
+})
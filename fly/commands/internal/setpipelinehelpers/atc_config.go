@@ -12,12 +12,11 @@ import (
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
-	"github.com/onsi/gomega/gexec"
 	"github.com/vito/go-interact/interact"
 )
 
 type ATCConfig struct {
-	PipelineName     string
+	PipelineRef      atc.PipelineRef
 	Team             concourse.Team
 	Target           string
 	SkipInteraction  bool
@@ -43,7 +42,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 	if err != nil {
 		return err
 	}
-	existingConfig, _, existingConfigVersion, _, err := atcConfig.Team.PipelineConfig(atcConfig.PipelineName)
+	existingConfig, _, existingConfigVersion, _, err := atcConfig.Team.PipelineConfig(atcConfig.PipelineRef)
 	errorMessages := []string{}
 	if err != nil {
 		if configError, ok := err.(concourse.PipelineConfigError); ok {
@@ -76,7 +75,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 	}
 
 	created, updated, warnings, err := atcConfig.Team.CreateOrUpdatePipelineConfig(
-		atcConfig.PipelineName,
+		atcConfig.PipelineRef,
 		existingConfigVersion,
 		evaluatedTemplate,
 		atcConfig.CheckCredentials,
@@ -103,9 +102,11 @@ func (atcConfig ATCConfig) showPipelineUpdateResult(created bool, updated bool)
 			fmt.Println("Could not parse targetURL")
 		}
 
-		pipelineURL, err := url.Parse("/teams/" + atcConfig.Team.Name() + "/pipelines/" + atcConfig.PipelineName)
+		pipelineURL, err := url.Parse("/teams/" + atcConfig.Team.Name() + "/pipelines/" + atcConfig.PipelineRef.Name)
 		if err != nil {
 			fmt.Println("Could not parse pipelineURL")
+		} else {
+			pipelineURL.RawQuery = atcConfig.PipelineRef.QueryParams().Encode()
 		}
 
 		fmt.Println("pipeline created!")
@@ -120,50 +121,6 @@ func (atcConfig ATCConfig) showPipelineUpdateResult(created bool, updated bool)
 }
 
 func diff(existingConfig atc.Config, newConfig atc.Config) bool {
-	var diffExists bool
-
 	stdout, _ := ui.ForTTY(os.Stdout)
-
-	indent := gexec.NewPrefixedWriter("  ", stdout)
-
-	groupDiffs := groupDiffIndices(GroupIndex(existingConfig.Groups), GroupIndex(newConfig.Groups))
-	if len(groupDiffs) > 0 {
-		diffExists = true
-		fmt.Println("groups:")
-
-		for _, diff := range groupDiffs {
-			diff.Render(indent, "group")
-		}
-	}
-
-	resourceDiffs := diffIndices(ResourceIndex(existingConfig.Resources), ResourceIndex(newConfig.Resources))
-	if len(resourceDiffs) > 0 {
-		diffExists = true
-		fmt.Println("resources:")
-
-		for _, diff := range resourceDiffs {
-			diff.Render(indent, "resource")
-		}
-	}
-
-	resourceTypeDiffs := diffIndices(ResourceTypeIndex(existingConfig.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))
-	if len(resourceTypeDiffs) > 0 {
-		diffExists = true
-		fmt.Println("resource types:")
-
-		for _, diff := range resourceTypeDiffs {
-			diff.Render(indent, "resource type")
-		}
-	}
-
-	jobDiffs := diffIndices(JobIndex(existingConfig.Jobs), JobIndex(newConfig.Jobs))
-	if len(jobDiffs) > 0 {
-		diffExists = true
-		fmt.Println("jobs:")
-
-		for _, diff := range jobDiffs {
-			diff.Render(indent, "job")
-		}
-	}
-	return diffExists
+	return existingConfig.Diff(stdout, newConfig)
 }
@@ -0,0 +1,164 @@
+package templatehelpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"gopkg.in/yaml.v2"
+)
+
+// configFileExtensions are the extensions of the files which are read from a
+// directory given as a config path.
+var configFileExtensions = []string{".yml", ".yaml"}
+
+// kindsOfEntries are the singular names of the entries of each top-level list
+// of a pipeline config, used when reporting conflicts.
+var kindsOfEntries = map[string]string{
+	"jobs":           "job",
+	"resources":      "resource",
+	"resource_types": "resource type",
+	"groups":         "group",
+}
+
+// ExpandConfigPaths replaces each directory in the given paths with the YAML
+// files directly within it, in lexical order.
+func ExpandConfigPaths(paths []atc.PathFlag) ([]atc.PathFlag, error) {
+	var expanded []atc.PathFlag
+
+	for _, path := range paths {
+		infos, err := ioutil.ReadDir(string(path))
+		if err != nil {
+			// not a directory; any other problem surfaces when reading it
+			expanded = append(expanded, path)
+			continue
+		}
+
+		var files []string
+		for _, info := range infos {
+			if !info.IsDir() && hasConfigFileExtension(info.Name()) {
+				files = append(files, info.Name())
+			}
+		}
+
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no .yml or .yaml files found in directory %s", path)
+		}
+
+		sort.Strings(files)
+
+		for _, file := range files {
+			expanded = append(expanded, atc.PathFlag(filepath.Join(string(path), file)))
+		}
+	}
+
+	return expanded, nil
+}
+
+func hasConfigFileExtension(name string) bool {
+	for _, ext := range configFileExtensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configFragment is the evaluated content of one of several config files.
+type configFragment struct {
+	path    atc.PathFlag
+	content []byte
+}
+
+// mergeConfigFragments merges the top-level keys of each fragment into a
+// single config. Lists such as jobs and resources are concatenated, erroring
+// if an entry with the same name is defined more than once. Any other key may
+// only be defined by one fragment.
+func mergeConfigFragments(fragments []configFragment) ([]byte, error) {
+	var merged yaml.MapSlice
+	definedIn := map[string]atc.PathFlag{}
+	entriesDefinedIn := map[string]atc.PathFlag{}
+
+	for _, fragment := range fragments {
+		var config yaml.MapSlice
+		err := yaml.Unmarshal(fragment.content, &config)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %s", fragment.path, err.Error())
+		}
+
+		for _, item := range config {
+			key := fmt.Sprintf("%v", item.Key)
+
+			entries, isList := item.Value.([]interface{})
+
+			index := indexOfKey(merged, key)
+			if index == -1 {
+				definedIn[key] = fragment.path
+				merged = append(merged, yaml.MapItem{Key: key, Value: item.Value})
+			} else {
+				existing, existingIsList := merged[index].Value.([]interface{})
+				if !isList || !existingIsList {
+					return nil, fmt.Errorf("'%s' is defined in both %s and %s", key, definedIn[key], fragment.path)
+				}
+
+				merged[index].Value = append(existing, entries...)
+			}
+
+			if !isList {
+				continue
+			}
+
+			kind, found := kindsOfEntries[key]
+			if !found {
+				kind = key + " entry"
+			}
+
+			for _, entry := range entries {
+				name, hasName := entryName(entry)
+				if !hasName {
+					continue
+				}
+
+				id := key + "/" + name
+				if previous, found := entriesDefinedIn[id]; found && previous != fragment.path {
+					// duplicates within one file are left to the pipeline's validation
+					return nil, fmt.Errorf("%s '%s' is defined in both %s and %s", kind, name, previous, fragment.path)
+				}
+
+				entriesDefinedIn[id] = fragment.path
+			}
+		}
+	}
+
+	return yaml.Marshal(merged)
+}
+
+func indexOfKey(config yaml.MapSlice, key string) int {
+	for i, item := range config {
+		if item.Key == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func entryName(entry interface{}) (string, bool) {
+	fields, ok := entry.(yaml.MapSlice)
+	if !ok {
+		return "", false
+	}
+
+	for _, field := range fields {
+		if field.Key == "name" {
+			name, ok := field.Value.(string)
+			return name, ok
+		}
+	}
+
+	return "", false
+}
@@ -11,15 +11,21 @@ import (
 )
 
 type YamlTemplateWithParams struct {
-	filePath               atc.PathFlag
+	filePaths              []atc.PathFlag
 	templateVariablesFiles []atc.PathFlag
 	templateVariables      []flaghelpers.VariablePairFlag
 	yamlTemplateVariables  []flaghelpers.YAMLVariablePairFlag
 }
 
 func NewYamlTemplateWithParams(filePath atc.PathFlag, templateVariablesFiles []atc.PathFlag, templateVariables []flaghelpers.VariablePairFlag, yamlTemplateVariables []flaghelpers.YAMLVariablePairFlag) YamlTemplateWithParams {
+	return NewMultiFileYamlTemplateWithParams([]atc.PathFlag{filePath}, templateVariablesFiles, templateVariables, yamlTemplateVariables)
+}
+
+// NewMultiFileYamlTemplateWithParams evaluates each of the given config files
+// and merges them into one config.
+func NewMultiFileYamlTemplateWithParams(filePaths []atc.PathFlag, templateVariablesFiles []atc.PathFlag, templateVariables []flaghelpers.VariablePairFlag, yamlTemplateVariables []flaghelpers.YAMLVariablePairFlag) YamlTemplateWithParams {
 	return YamlTemplateWithParams{
-		filePath:               filePath,
+		filePaths:              filePaths,
 		templateVariablesFiles: templateVariablesFiles,
 		templateVariables:      templateVariables,
 		yamlTemplateVariables:  yamlTemplateVariables,
@@ -30,23 +36,6 @@ func (yamlTemplate YamlTemplateWithParams) Evaluate(
 	allowEmpty bool,
 	strict bool,
 ) ([]byte, error) {
-	config, err := ioutil.ReadFile(string(yamlTemplate.filePath))
-	if err != nil {
-		return nil, fmt.Errorf("could not read file: %s", err.Error())
-	}
-
-	if strict {
-		// We use a generic map here, since templates are not evaluated yet.
-		// (else a template string may cause an error when a struct is expected)
-		// If we don't check Strict now, then the subsequent steps will mask any
-		// duplicate key errors.
-		// We should consider being strict throughout the entire stack by default.
-		err = yaml.UnmarshalStrict(config, make(map[string]interface{}))
-		if err != nil {
-			return nil, fmt.Errorf("error parsing yaml before applying templates: %s", err.Error())
-		}
-	}
-
 	var params []boshtemplate.Variables
 
 	// first, we take explicitly specified variables on the command line
@@ -77,6 +66,44 @@ func (yamlTemplate YamlTemplateWithParams) Evaluate(
 		params = append(params, staticVars)
 	}
 
+	if len(yamlTemplate.filePaths) == 1 {
+		return evaluateFile(yamlTemplate.filePaths[0], params, allowEmpty, strict)
+	}
+
+	var fragments []configFragment
+	for _, filePath := range yamlTemplate.filePaths {
+		evaluatedConfig, err := evaluateFile(filePath, params, allowEmpty, strict)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", filePath, err.Error())
+		}
+
+		fragments = append(fragments, configFragment{
+			path:    filePath,
+			content: evaluatedConfig,
+		})
+	}
+
+	return mergeConfigFragments(fragments)
+}
+
+func evaluateFile(filePath atc.PathFlag, params []boshtemplate.Variables, allowEmpty bool, strict bool) ([]byte, error) {
+	config, err := ioutil.ReadFile(string(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("could not read file: %s", err.Error())
+	}
+
+	if strict {
+		// We use a generic map here, since templates are not evaluated yet.
+		// (else a template string may cause an error when a struct is expected)
+		// If we don't check Strict now, then the subsequent steps will mask any
+		// duplicate key errors.
+		// We should consider being strict throughout the entire stack by default.
+		err = yaml.UnmarshalStrict(config, make(map[string]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing yaml before applying templates: %s", err.Error())
+		}
+	}
+
 	evaluatedConfig, err := template.NewTemplateResolver(config, params).Resolve(false, allowEmpty)
 	if err != nil {
 		return nil, err
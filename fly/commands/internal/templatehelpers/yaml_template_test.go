@@ -73,5 +73,140 @@ var _ = Describe("YAML Template With Params", func() {
     nested: ((param3))
 `))
 		})
+
+		Context("when there are several files", func() {
+			BeforeEach(func() {
+				err := ioutil.WriteFile(
+					filepath.Join(tmpdir, "jobs.yml"),
+					[]byte(`jobs:
+- name: some-job
+  plan:
+  - get: ((resource))
+`),
+					0644,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(
+					filepath.Join(tmpdir, "resources.yml"),
+					[]byte(`resources:
+- name: some-resource
+  type: git
+jobs:
+- name: some-other-job
+`),
+					0644,
+				)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("merges them after resolving each", func() {
+				vars := []flaghelpers.VariablePairFlag{
+					{Name: "resource", Value: "some-resource"},
+				}
+				paths := []atc.PathFlag{
+					atc.PathFlag(filepath.Join(tmpdir, "jobs.yml")),
+					atc.PathFlag(filepath.Join(tmpdir, "resources.yml")),
+				}
+
+				result, err := templatehelpers.NewMultiFileYamlTemplateWithParams(paths, nil, vars, nil).Evaluate(false, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(result)).To(Equal(`jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+- name: some-other-job
+resources:
+- name: some-resource
+  type: git
+`))
+			})
+
+			Context("when an entry is defined in more than one", func() {
+				BeforeEach(func() {
+					err := ioutil.WriteFile(
+						filepath.Join(tmpdir, "more-jobs.yml"),
+						[]byte(`jobs:
+- name: some-job
+`),
+						0644,
+					)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("errors", func() {
+					paths := []atc.PathFlag{
+						atc.PathFlag(filepath.Join(tmpdir, "jobs.yml")),
+						atc.PathFlag(filepath.Join(tmpdir, "more-jobs.yml")),
+					}
+
+					_, err := templatehelpers.NewMultiFileYamlTemplateWithParams(paths, nil, nil, nil).Evaluate(false, false)
+					Expect(err).To(MatchError("job 'some-job' is defined in both " + string(paths[0]) + " and " + string(paths[1])))
+				})
+			})
+
+			Context("when any other key is defined in more than one", func() {
+				BeforeEach(func() {
+					err := ioutil.WriteFile(
+						filepath.Join(tmpdir, "display.yml"),
+						[]byte(`display:
+  background_image: foo.png
+`),
+						0644,
+					)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("errors", func() {
+					paths := []atc.PathFlag{
+						atc.PathFlag(filepath.Join(tmpdir, "display.yml")),
+						atc.PathFlag(filepath.Join(tmpdir, "display.yml")),
+					}
+
+					_, err := templatehelpers.NewMultiFileYamlTemplateWithParams(paths, nil, nil, nil).Evaluate(false, false)
+					Expect(err).To(MatchError("'display' is defined in both " + string(paths[0]) + " and " + string(paths[1])))
+				})
+			})
+		})
+	})
+
+	Describe("ExpandConfigPaths", func() {
+		var tmpdir string
+
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = ioutil.TempDir("", "yaml-template-test")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, name := range []string{"b.yml", "a.yaml", "notes.txt"} {
+				err = ioutil.WriteFile(filepath.Join(tmpdir, name), []byte("{}"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpdir)
+		})
+
+		It("replaces directories with the YAML files within them, in order", func() {
+			paths, err := templatehelpers.ExpandConfigPaths([]atc.PathFlag{
+				"some-file.yml",
+				atc.PathFlag(tmpdir),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(Equal([]atc.PathFlag{
+				"some-file.yml",
+				atc.PathFlag(filepath.Join(tmpdir, "a.yaml")),
+				atc.PathFlag(filepath.Join(tmpdir, "b.yml")),
+			}))
+		})
+
+		It("errors for directories without any YAML files", func() {
+			empty := filepath.Join(tmpdir, "empty")
+			Expect(os.Mkdir(empty, 0755)).To(Succeed())
+
+			_, err := templatehelpers.ExpandConfigPaths([]atc.PathFlag{atc.PathFlag(empty)})
+			Expect(err).To(MatchError("no .yml or .yaml files found in directory " + empty))
+		})
 	})
 })
@@ -19,7 +19,7 @@ func (command *OrderPipelinesCommand) Validate() ([]string, error) {
 	var pipelines []string
 
 	for _, p := range command.Pipelines {
-		err := p.Validate()
+		err := p.ValidateName()
 		if err != nil {
 			return nil, err
 		}
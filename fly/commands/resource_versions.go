@@ -5,7 +5,9 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/rc"
@@ -18,6 +20,8 @@ type ResourceVersionsCommand struct {
 	Count    int                      `short:"c" long:"count" default:"50" description:"Number of builds you want to limit the return to"`
 	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of a resource to get versions for"`
 	Json     bool                     `long:"json" description:"Print command result as JSON"`
+
+	ShowAnnotations bool `long:"show-annotations" description:"Show who disabled, enabled or pinned each version and why"`
 }
 
 func (command *ResourceVersionsCommand) Execute([]string) error {
@@ -56,6 +60,10 @@ func (command *ResourceVersionsCommand) Execute([]string) error {
 		},
 	}
 
+	if command.ShowAnnotations {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: "annotations", Color: color.New(color.Bold)})
+	}
+
 	var rangeUntil int
 	if command.Count < len(versions) {
 		rangeUntil = command.Count
@@ -79,12 +87,57 @@ func (command *ResourceVersionsCommand) Execute([]string) error {
 
 		sort.Strings(fields)
 
-		table.Data = append(table.Data, []ui.TableCell{
+		row := ui.TableRow{
 			{Contents: strconv.Itoa(version.ID)},
 			{Contents: strings.Join(fields, ",")},
 			enabledCell,
-		})
+		}
+
+		if !command.ShowAnnotations {
+			table.Data = append(table.Data, row)
+			continue
+		}
+
+		if len(version.Annotations) == 0 {
+			table.Data = append(table.Data, append(row, ui.TableCell{Contents: "n/a", Color: ui.OffColor}))
+			continue
+		}
+
+		// each annotation after the first gets a row of its own beneath the
+		// version
+		for i, annotation := range version.Annotations {
+			if i > 0 {
+				row = ui.TableRow{{}, {}, {}}
+			}
+
+			table.Data = append(table.Data, append(row, ui.TableCell{Contents: annotationContents(annotation)}))
+		}
 	}
 
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
+
+func annotationContents(annotation atc.VersionAnnotation) string {
+	var action string
+	switch annotation.Action {
+	case atc.VersionActionDisable:
+		action = "disabled"
+	case atc.VersionActionEnable:
+		action = "enabled"
+	case atc.VersionActionPin:
+		action = "pinned"
+	default:
+		action = annotation.Action
+	}
+
+	contents := action + " " + time.Unix(annotation.CreatedAt, 0).Format(timeDateLayout)
+	if annotation.UserName != "" {
+		contents += " by " + annotation.UserName
+	}
+
+	if annotation.Comment != "" {
+		contents += ": " + annotation.Comment
+	}
+
+	return contents
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type SaveVersionCommand struct {
+	Resource flaghelpers.ResourceFlag `short:"r" long:"resource" required:"true" value-name:"PIPELINE/RESOURCE" description:"Name of the resource to save the version for"`
+	Version  atc.Version              `short:"v" long:"version"  required:"true" value-name:"KEY:VALUE"         description:"Version to save, e.g. ref:abcd (can be specified multiple times)"`
+	Metadata map[string]string        `long:"metadata"                           value-name:"KEY:VALUE"         description:"Metadata to save with the version (can be specified multiple times)"`
+}
+
+func (command *SaveVersionCommand) Execute(args []string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var metadata []atc.MetadataField
+	for name, value := range command.Metadata {
+		metadata = append(metadata, atc.MetadataField{Name: name, Value: value})
+	}
+
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].Name < metadata[j].Name
+	})
+
+	created, found, err := target.Team().SaveResourceVersion(
+		command.Resource.PipelineName,
+		command.Resource.ResourceName,
+		command.Version,
+		metadata,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' or resource '%s' not found\n", command.Resource.PipelineName, command.Resource.ResourceName)
+	}
+
+	if created {
+		fmt.Printf("saved version of '%s'\n", command.Resource.ResourceName)
+	} else {
+		fmt.Printf("version of '%s' already existed and is now the latest\n", command.Resource.ResourceName)
+	}
+
+	return nil
+}
@@ -15,8 +15,9 @@ type SetPipelineCommand struct {
 
 	CheckCredentials bool `long:"check-creds"  description:"Validate credential variables against credential manager"`
 
-	Pipeline flaghelpers.PipelineFlag `short:"p"  long:"pipeline"  required:"true"  description:"Pipeline to configure"`
-	Config   atc.PathFlag             `short:"c"  long:"config"    required:"true"  description:"Pipeline configuration file"`
+	Pipeline     flaghelpers.PipelineFlag          `short:"p"  long:"pipeline"      required:"true"              description:"Pipeline to configure"`
+	InstanceVars []flaghelpers.InstanceVarPairFlag `long:"instance-var"  value-name:"[NAME=YAML]"           description:"Specify a var identifying an instance of the pipeline, which is also set as a variable in the pipeline"`
+	Config       []atc.PathFlag                    `short:"c"  long:"config"        required:"true"              description:"Pipeline configuration file, or a directory of them. Can be specified multiple times to merge several files into one configuration"`
 
 	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the pipeline"`
 	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
@@ -33,9 +34,27 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 	if err != nil {
 		return err
 	}
-	configPath := command.Config
+	configPaths, err := templatehelpers.ExpandConfigPaths(command.Config)
+	if err != nil {
+		return err
+	}
+
 	templateVariablesFiles := command.VarsFrom
-	pipelineName := string(command.Pipeline)
+	pipelineRef := command.Pipeline.Ref()
+
+	yamlVars := command.YAMLVar
+	for name, value := range pipelineRef.InstanceVars {
+		yamlVars = append(yamlVars, flaghelpers.YAMLVariablePairFlag{Name: name, Value: value})
+	}
+
+	for _, instanceVar := range command.InstanceVars {
+		if pipelineRef.InstanceVars == nil {
+			pipelineRef.InstanceVars = atc.InstanceVars{}
+		}
+
+		pipelineRef.InstanceVars[instanceVar.Name] = instanceVar.Value
+		yamlVars = append(yamlVars, flaghelpers.YAMLVariablePairFlag{Name: instanceVar.Name, Value: instanceVar.Value})
+	}
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -51,12 +70,12 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 
 	atcConfig := setpipelinehelpers.ATCConfig{
 		Team:             target.Team(),
-		PipelineName:     pipelineName,
+		PipelineRef:      pipelineRef,
 		Target:           target.Client().URL(),
 		SkipInteraction:  command.SkipInteractive,
 		CheckCredentials: command.CheckCredentials,
 	}
 
-	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(configPath, templateVariablesFiles, command.Var, command.YAMLVar)
+	yamlTemplateWithParams := templatehelpers.NewMultiFileYamlTemplateWithParams(configPaths, templateVariablesFiles, command.Var, yamlVars)
 	return atcConfig.Set(yamlTemplateWithParams)
 }
@@ -8,9 +8,9 @@ import (
 )
 
 type ValidatePipelineCommand struct {
-	Config atc.PathFlag `short:"c" long:"config" required:"true"        description:"Pipeline configuration file"`
-	Strict bool         `short:"s" long:"strict"                        description:"Fail on warnings"`
-	Output bool         `short:"o" long:"output"                        description:"Output templated pipeline to stdout"`
+	Config []atc.PathFlag `short:"c" long:"config" required:"true"        description:"Pipeline configuration file, or a directory of them. Can be specified multiple times to merge several files into one configuration"`
+	Strict bool           `short:"s" long:"strict"                        description:"Fail on warnings"`
+	Output bool           `short:"o" long:"output"                        description:"Output templated pipeline to stdout"`
 
 	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the pipeline"`
 	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
@@ -19,6 +19,11 @@ type ValidatePipelineCommand struct {
 }
 
 func (command *ValidatePipelineCommand) Execute(args []string) error {
-	yamlTemplate := templatehelpers.NewYamlTemplateWithParams(command.Config, command.VarsFrom, command.Var, command.YAMLVar)
+	configPaths, err := templatehelpers.ExpandConfigPaths(command.Config)
+	if err != nil {
+		return err
+	}
+
+	yamlTemplate := templatehelpers.NewMultiFileYamlTemplateWithParams(configPaths, command.VarsFrom, command.Var, command.YAMLVar)
 	return validatepipelinehelpers.Validate(yamlTemplate, command.Strict, command.Output)
 }
@@ -3,6 +3,7 @@ package eventstream
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/concourse/concourse/atc/event"
@@ -20,6 +21,8 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 
 	exitStatus := 0
 
+	combinations := map[event.OriginID]map[int]string{}
+
 	for {
 		ev, err := src.NextEvent()
 		if err != nil {
@@ -55,6 +58,56 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 		case event.FinishTask:
 			exitStatus = e.ExitStatus
 
+		case event.StartCombination:
+			vars := formatCombinationVars(e.Vars)
+
+			if combinations[e.Origin.ID] == nil {
+				combinations[e.Origin.ID] = map[int]string{}
+			}
+			combinations[e.Origin.ID][e.Index] = vars
+
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1macross %s\x1b[0m\n", vars)
+
+		case event.FinishCombination:
+			printColor := ui.SucceededColor
+			status := "succeeded"
+			if !e.Succeeded {
+				printColor = ui.FailedColor
+				status = "failed"
+			}
+
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1macross %s\x1b[0m %s\n", combinations[e.Origin.ID][e.Index], printColor.SprintFunc()(status))
+
+		case event.Retry:
+			waiting := ""
+			if e.Delay != "" {
+				waiting = " after waiting " + e.Delay
+			}
+
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mattempt %d, retrying due to %s%s\x1b[0m\n", e.Attempt, e.Cause, waiting)
+
+		case event.Skipped:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mskipped\x1b[0m as '%s' does not hold\n", e.Condition)
+
+		case event.WaitingForWorker:
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "\x1b[1mwaiting for worker\x1b[0m as every compatible worker is full\n")
+
+		case event.FinishCheck:
+			dstImpl.SetTimestamp(e.Time)
+
+			if !e.Succeeded {
+				fmt.Fprintf(dstImpl, "%s\n", ui.FailedColor.SprintFunc()("failed"))
+				return 1
+			}
+
+			fmt.Fprintf(dstImpl, "%s (%d new versions)\n", ui.SucceededColor.SprintFunc()("succeeded"), e.NewVersions)
+			return exitStatus
+
 		case event.Error:
 			errCol := ui.ErroredColor.SprintFunc()
 			dstImpl.SetTimestamp(0)
@@ -93,9 +146,29 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			}
 
 			printColorFunc := printColor.SprintFunc()
-			fmt.Fprintf(dstImpl, "%s\n", printColorFunc(e.Status))
+			if e.Reason != "" {
+				fmt.Fprintf(dstImpl, "%s (%s)\n", printColorFunc(e.Status), e.Reason)
+			} else {
+				fmt.Fprintf(dstImpl, "%s\n", printColorFunc(e.Status))
+			}
 
 			return exitStatus
 		}
 	}
 }
+
+func formatCombinationVars(vars map[string]interface{}) string {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s: %v", name, vars[name])
+	}
+
+	return strings.Join(pairs, ", ")
+}
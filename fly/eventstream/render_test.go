@@ -148,6 +148,76 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when combination events are received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.StartCombination{
+				Time:   time.Now().Unix(),
+				Origin: event.Origin{ID: "some-across"},
+				Index:  1,
+				Vars:   map[string]interface{}{"platform": "linux", "go_version": "1.12"},
+			}
+
+			receivedEvents <- event.FinishCombination{
+				Time:      time.Now().Unix(),
+				Origin:    event.Origin{ID: "some-across"},
+				Index:     1,
+				Succeeded: false,
+			}
+		})
+
+		It("prints the combination's vars when it starts", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1macross go_version: 1.12, platform: linux\x1b[0m\n"))
+		})
+
+		It("prints the combination's result when it finishes", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1macross go_version: 1.12, platform: linux\x1b[0m " + ui.FailedColor.SprintFunc()("failed") + "\n"))
+		})
+	})
+
+	Context("when a Retry event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.Retry{
+				Time:    time.Now().Unix(),
+				Origin:  event.Origin{ID: "some-retry"},
+				Attempt: 2,
+				PlanID:  "some-attempt",
+				Cause:   "failure",
+				Delay:   "10s",
+			}
+		})
+
+		It("prints the attempt and why it is being retried", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mattempt 2, retrying due to failure after waiting 10s\x1b[0m\n"))
+		})
+	})
+
+	Context("when a Skipped event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.Skipped{
+				Time:      time.Now().Unix(),
+				Origin:    event.Origin{ID: "some-if"},
+				Condition: `$BUILD_PIPELINE_NAME == "main"`,
+			}
+		})
+
+		It("prints the condition which did not hold", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mskipped\x1b[0m as '$BUILD_PIPELINE_NAME == \"main\"' does not hold\n"))
+		})
+	})
+
+	Context("when a WaitingForWorker event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.WaitingForWorker{
+				Time:   time.Now().Unix(),
+				Origin: event.Origin{ID: "some-task"},
+			}
+		})
+
+		It("prints that it is waiting for a worker", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mwaiting for worker\x1b[0m as every compatible worker is full\n"))
+		})
+	})
+
 	Context("when a FinishTask event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.FinishTask{
@@ -294,5 +364,60 @@ var _ = Describe("V1.0 Renderer", func() {
 				})
 			})
 		})
+
+		Context("with a reason", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.Status{
+					Status: atc.StatusAborted,
+					Time:   time.Now().Unix(),
+					Reason: "timed out",
+				}
+			})
+
+			It("prints the reason after the status", func() {
+				Expect(out.Contents()).To(ContainSubstring(ui.AbortedColor.SprintFunc()("aborted") + " (timed out)\n"))
+			})
+
+			It("exits 3", func() {
+				Expect(exitStatus).To(Equal(3))
+			})
+		})
+	})
+
+	Describe("receiving a FinishCheck event", func() {
+		Context("when the check succeeded", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.FinishCheck{
+					Time:        time.Now().Unix(),
+					Succeeded:   true,
+					NewVersions: 2,
+				}
+			})
+
+			It("prints the number of new versions", func() {
+				Expect(out.Contents()).To(ContainSubstring(ui.SucceededColor.SprintFunc()("succeeded") + " (2 new versions)\n"))
+			})
+
+			It("exits 0", func() {
+				Expect(exitStatus).To(Equal(0))
+			})
+		})
+
+		Context("when the check failed", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.FinishCheck{
+					Time:      time.Now().Unix(),
+					Succeeded: false,
+				}
+			})
+
+			It("prints failed", func() {
+				Expect(out.Contents()).To(ContainSubstring(ui.FailedColor.SprintFunc()("failed") + "\n"))
+			})
+
+			It("exits 1", func() {
+				Expect(exitStatus).To(Equal(1))
+			})
+		})
 	})
 })
@@ -0,0 +1,140 @@
+package integration_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("archive-pipeline", func() {
+		var (
+			stdin io.Writer
+			args  []string
+			sess  *gexec.Session
+		)
+
+		BeforeEach(func() {
+			stdin = nil
+			args = []string{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "archive-pipeline"}, args...)...)
+			stdin, err = flyCmd.StdinPipe()
+			Expect(err).NotTo(HaveOccurred())
+
+			sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the pipeline name is not specified", func() {
+			It("errors", func() {
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: the required flag `" + osFlag("p", "pipeline") + "' was not specified"))
+			})
+		})
+
+		Context("when specifying a malformed pipeline instance", func() {
+			BeforeEach(func() {
+				args = append(args, "-p", "forbidden/pipelinename")
+			})
+
+			It("fails and says the instance vars are invalid", func() {
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say(`error: invalid instance var 'pipelinename' \(must be key:value\)`))
+			})
+		})
+
+		Context("when the pipeline name is specified", func() {
+			var path string
+
+			BeforeEach(func() {
+				var err error
+				path, err = atc.Routes.CreatePathForRoute(atc.ArchivePipeline, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+				Expect(err).NotTo(HaveOccurred())
+
+				args = append(args, "-p", "awesome-pipeline")
+			})
+
+			yes := func() {
+				Eventually(sess).Should(gbytes.Say(`archive pipeline 'awesome-pipeline'\? \[yN\]: `))
+				fmt.Fprintf(stdin, "y\n")
+			}
+
+			no := func() {
+				Eventually(sess).Should(gbytes.Say(`archive pipeline 'awesome-pipeline'\? \[yN\]: `))
+				fmt.Fprintf(stdin, "n\n")
+			}
+
+			It("warns that the pipeline's configuration will be removed", func() {
+				Eventually(sess).Should(gbytes.Say("!!! archiving the pipeline will remove its configuration"))
+			})
+
+			It("bails out if the user says no", func() {
+				no()
+				Eventually(sess).Should(gbytes.Say(`bailing out`))
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+
+			Context("when the pipeline exists", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", path),
+							ghttp.RespondWith(http.StatusOK, nil),
+						),
+					)
+				})
+
+				It("archives the pipeline if the user says yes", func() {
+					yes()
+					Eventually(sess).Should(gbytes.Say(`archived 'awesome-pipeline'`))
+					Eventually(sess).Should(gexec.Exit(0))
+				})
+
+				Context("when run noninteractively", func() {
+					BeforeEach(func() {
+						args = append(args, "-n")
+					})
+
+					It("archives the pipeline without confirming", func() {
+						Eventually(sess).Should(gbytes.Say(`archived 'awesome-pipeline'`))
+						Eventually(sess).Should(gexec.Exit(0))
+					})
+				})
+			})
+
+			Context("when the pipeline doesn't exist", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", path),
+							ghttp.RespondWith(http.StatusNotFound, nil),
+						),
+					)
+				})
+
+				It("prints helpful message", func() {
+					yes()
+					Eventually(sess.Err).Should(gbytes.Say(`pipeline 'awesome-pipeline' not found`))
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+		})
+	})
+})
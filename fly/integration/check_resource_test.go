@@ -1,9 +1,15 @@
 package integration_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"os/exec"
 
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/vito/go-sse/sse"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -145,4 +151,65 @@ var _ = Describe("CheckResource", func() {
 
 		})
 	})
+
+	Context("when watching the check", func() {
+		BeforeEach(func() {
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check",
+				ghttp.CombineHandlers(
+					ghttp.VerifyJSON(`{"from":null,"async":true}`),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.Check{ID: 2, Status: "started"}),
+				),
+			)
+
+			atcServer.RouteToHandler("GET", "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/checks/2/events",
+				func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+					w.WriteHeader(http.StatusOK)
+
+					for id, e := range []atc.Event{
+						event.Log{Payload: "fetching refs\n"},
+						event.FinishCheck{Succeeded: true, NewVersions: 3},
+					} {
+						payload, err := json.Marshal(event.Message{Event: e})
+						Expect(err).NotTo(HaveOccurred())
+
+						err = sse.Event{ID: fmt.Sprintf("%d", id), Name: "event", Data: payload}.Write(w)
+						Expect(err).NotTo(HaveOccurred())
+					}
+
+					err := sse.Event{Name: "end"}.Write(w)
+					Expect(err).NotTo(HaveOccurred())
+				},
+			)
+		})
+
+		It("streams the output of the new check", func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource", "--watch")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("fetching refs"))
+			Expect(sess.Out).To(gbytes.Say(`succeeded \(3 new versions\)`))
+		})
+
+		Context("when the check finishes without being recorded", func() {
+			BeforeEach(func() {
+				atcServer.RouteToHandler("POST", "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/check",
+					ghttp.RespondWith(http.StatusOK, ""),
+				)
+			})
+
+			It("reports the check as it would without watching", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource", "-r", "mypipeline/myresource", "--watch")
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("checked 'myresource'"))
+			})
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"os/exec"
 
+	"github.com/concourse/concourse/atc"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -73,6 +74,35 @@ var _ = Describe("CheckResourceType", func() {
 		})
 	})
 
+	Context("when checking recursively", func() {
+		BeforeEach(func() {
+			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resource-types/myresource/check"
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.VerifyJSON(`{"from":null,"recursive":true}`),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.CheckResourceTypeResponseBody{
+						ResourceTypes: []string{"some-child-type"},
+						Resources:     []string{"some-resource", "some-other-resource"},
+					}),
+				),
+			)
+		})
+
+		It("prints the resource types and resources scheduled to be rechecked", func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "check-resource-type", "-r", "mypipeline/myresource", "--recursive")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("checked 'myresource'"))
+			Expect(sess.Out).To(gbytes.Say("scheduled resource-type 'some-child-type' to be rechecked"))
+			Expect(sess.Out).To(gbytes.Say("scheduled resource 'some-resource' to be rechecked"))
+			Expect(sess.Out).To(gbytes.Say("scheduled resource 'some-other-resource' to be rechecked"))
+		})
+	})
+
 	Context("when pipeline or resource-type is not found", func() {
 		BeforeEach(func() {
 			expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resource-types/myresource/check"
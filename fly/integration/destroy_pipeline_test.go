@@ -49,8 +49,8 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
-		Context("when specifying a pipeline name with a '/' character", func() {
-			It("fails and says '/' characters are not allowed", func() {
+		Context("when specifying a malformed pipeline instance", func() {
+			It("fails and says the instance vars are invalid", func() {
 				flyCmd := exec.Command(flyPath, "-t", targetName, "destroy-pipeline", "-p", "forbidden/pipelinename")
 
 				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
@@ -59,7 +59,7 @@ var _ = Describe("Fly CLI", func() {
 				<-sess.Exited
 				Expect(sess.ExitCode()).To(Equal(1))
 
-				Expect(sess.Err).To(gbytes.Say("error: pipeline name cannot contain '/'"))
+				Expect(sess.Err).To(gbytes.Say(`error: invalid instance var 'pipelinename' \(must be key:value\)`))
 			})
 		})
 
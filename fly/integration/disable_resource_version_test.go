@@ -0,0 +1,100 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("disable-resource-version", func() {
+		var (
+			flyCmd *exec.Cmd
+		)
+
+		expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/versions/42/disable"
+
+		Context("when a reason is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "disable-resource-version", "-r", "mypipeline/myresource", "-i", "42", "-m", "the version is broken")
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", expectedURL),
+							ghttp.VerifyJSON(`{"comment":"the version is broken"}`),
+							ghttp.RespondWith(http.StatusOK, nil),
+						),
+					)
+				})
+
+				It("disables the version with the reason", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out).To(gbytes.Say("disabled version 42 of 'myresource'"))
+				})
+			})
+
+			Context("when the version is not found", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("PUT", expectedURL),
+							ghttp.RespondWith(http.StatusNotFound, nil),
+						),
+					)
+				})
+
+				It("fails with an error", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(1))
+					Expect(sess.Err).To(gbytes.Say("could not find version 42 of resource 'myresource' in pipeline 'mypipeline'"))
+				})
+			})
+		})
+
+		Context("when no reason is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "disable-resource-version", "-r", "mypipeline/myresource", "-i", "42")
+			})
+
+			It("asks for one without disabling the version", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("the required flag `-m, --reason' was not specified"))
+			})
+		})
+	})
+
+	Describe("enable-resource-version", func() {
+		It("enables the version with the comment", func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/versions/42/enable"),
+					ghttp.VerifyJSON(`{"comment":"the version was fixed"}`),
+					ghttp.RespondWith(http.StatusOK, nil),
+				),
+			)
+
+			flyCmd := exec.Command(flyPath, "-t", targetName, "enable-resource-version", "-r", "mypipeline/myresource", "-i", "42", "-m", "the version was fixed")
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("enabled version 42 of 'myresource'"))
+		})
+	})
+})
@@ -2,8 +2,12 @@ package integration_test
 
 import (
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -100,8 +104,8 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
-			Context("when specifying a pipeline name with a '/' character in it", func() {
-				It("fails and says '/' characters are not allowed", func() {
+			Context("when specifying a malformed pipeline instance", func() {
+				It("fails and says the instance vars are invalid", func() {
 					flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "-p", "forbidden/pipelinename")
 
 					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
@@ -110,7 +114,7 @@ var _ = Describe("Fly CLI", func() {
 					<-sess.Exited
 					Expect(sess.ExitCode()).To(Equal(1))
 
-					Expect(sess.Err).To(gbytes.Say("error: pipeline name cannot contain '/'"))
+					Expect(sess.Err).To(gbytes.Say(`error: invalid instance var 'pipelinename' \(must be key:value\)`))
 				})
 			})
 
@@ -190,6 +194,149 @@ var _ = Describe("Fly CLI", func() {
 					})
 				})
 
+				Context("when --split is given", func() {
+					var dir string
+
+					BeforeEach(func() {
+						var err error
+						dir, err = ioutil.TempDir("", "fly-get-pipeline-split")
+						Expect(err).NotTo(HaveOccurred())
+
+						config.Groups = atc.GroupConfigs{
+							{
+								Name: "some/group",
+								Jobs: []string{"some-job"},
+							},
+						}
+
+						config.ResourceTypeDefaults = atc.ResourceTypeDefaults{
+							"some-type": atc.Source{"some": "default"},
+						}
+					})
+
+					JustBeforeEach(func() {
+						atcServer.AppendHandlers(
+							ghttp.CombineHandlers(
+								ghttp.VerifyRequest("GET", path),
+								ghttp.RespondWithJSONEncoded(200, atc.ConfigResponse{Config: &config}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+							),
+						)
+					})
+
+					AfterEach(func() {
+						os.RemoveAll(dir)
+					})
+
+					It("writes the jobs of each group to their own file", func() {
+						flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--split", dir)
+
+						sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+
+						<-sess.Exited
+						Expect(sess.ExitCode()).To(Equal(0))
+
+						Expect(sess.Out).To(gbytes.Say("wrote " + filepath.Join(dir, "jobs-some_group.yml")))
+						Expect(sess.Out).To(gbytes.Say("wrote " + filepath.Join(dir, "pipeline.yml")))
+
+						var groupConfig atc.Config
+						contents, err := ioutil.ReadFile(filepath.Join(dir, "jobs-some_group.yml"))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(yaml.Unmarshal(contents, &groupConfig)).To(Succeed())
+						Expect(groupConfig).To(Equal(atc.Config{
+							Jobs: atc.JobConfigs{config.Jobs[0]},
+						}))
+
+						var restConfig atc.Config
+						contents, err = ioutil.ReadFile(filepath.Join(dir, "pipeline.yml"))
+						Expect(err).NotTo(HaveOccurred())
+						Expect(yaml.Unmarshal(contents, &restConfig)).To(Succeed())
+						Expect(restConfig).To(Equal(atc.Config{
+							Groups:               config.Groups,
+							ResourceTypeDefaults: config.ResourceTypeDefaults,
+							Resources:            config.Resources,
+							ResourceTypes:        config.ResourceTypes,
+							Jobs:                 atc.JobConfigs{config.Jobs[1]},
+						}))
+					})
+
+					Context("when group names map to the same file name", func() {
+						BeforeEach(func() {
+							config.Groups = atc.GroupConfigs{
+								{
+									Name: "some/group",
+									Jobs: []string{"some-job"},
+								},
+								{
+									Name: "some_group",
+									Jobs: []string{"some-other-job"},
+								},
+							}
+						})
+
+						It("writes each group's jobs to a file of its own", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--split", dir)
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(0))
+
+							Expect(sess.Out).To(gbytes.Say("wrote " + filepath.Join(dir, "jobs-some_group.yml")))
+							Expect(sess.Out).To(gbytes.Say("wrote " + filepath.Join(dir, "jobs-some_group-2.yml")))
+
+							var groupConfig atc.Config
+							contents, err := ioutil.ReadFile(filepath.Join(dir, "jobs-some_group.yml"))
+							Expect(err).NotTo(HaveOccurred())
+							Expect(yaml.Unmarshal(contents, &groupConfig)).To(Succeed())
+							Expect(groupConfig.Jobs).To(Equal(atc.JobConfigs{config.Jobs[0]}))
+
+							var otherGroupConfig atc.Config
+							contents, err = ioutil.ReadFile(filepath.Join(dir, "jobs-some_group-2.yml"))
+							Expect(err).NotTo(HaveOccurred())
+							Expect(yaml.Unmarshal(contents, &otherGroupConfig)).To(Succeed())
+							Expect(otherGroupConfig.Jobs).To(Equal(atc.JobConfigs{config.Jobs[1]}))
+						})
+					})
+
+					Context("when the directory is not empty", func() {
+						BeforeEach(func() {
+							err := ioutil.WriteFile(filepath.Join(dir, "jobs-stale.yml"), []byte("jobs: []"), 0644)
+							Expect(err).NotTo(HaveOccurred())
+						})
+
+						It("errors without writing any files", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--split", dir)
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(1))
+
+							Expect(sess.Err).To(gbytes.Say("error: directory '" + regexp.QuoteMeta(dir) + "' is not empty"))
+
+							_, err = os.Stat(filepath.Join(dir, "pipeline.yml"))
+							Expect(os.IsNotExist(err)).To(BeTrue())
+						})
+					})
+
+					Context("when -j is also given", func() {
+						It("errors", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "--pipeline", "some-pipeline", "--split", dir, "-j")
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(1))
+
+							Expect(sess.Err).To(gbytes.Say("error: --json cannot be used with --split"))
+						})
+					})
+				})
+
 				Context("when atc returns an error loading config", func() {
 					BeforeEach(func() {
 						configResponse := atc.ConfigResponse{
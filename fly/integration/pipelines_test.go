@@ -31,6 +31,7 @@ var _ = Describe("Fly CLI", func() {
 								{Name: "pipeline-1-longer", Paused: false, Public: false},
 								{Name: "pipeline-2", Paused: true, Public: false},
 								{Name: "pipeline-3", Paused: false, Public: true},
+								{Name: "pipeline-3", InstanceVars: atc.InstanceVars{"branch": "5.1"}, Paused: false, Public: true},
 							}),
 						),
 					)
@@ -52,6 +53,7 @@ var _ = Describe("Fly CLI", func() {
                   "name": "pipeline-1-longer",
                   "paused": false,
                   "public": false,
+                  "archived": false,
                   "team_name": ""
                 },
                 {
@@ -59,6 +61,7 @@ var _ = Describe("Fly CLI", func() {
                   "name": "pipeline-2",
                   "paused": true,
                   "public": false,
+                  "archived": false,
                   "team_name": ""
                 },
                 {
@@ -66,6 +69,16 @@ var _ = Describe("Fly CLI", func() {
                   "name": "pipeline-3",
                   "paused": false,
                   "public": true,
+                  "archived": false,
+                  "team_name": ""
+                },
+                {
+                  "id": 0,
+                  "name": "pipeline-3",
+                  "instance_vars": {"branch": "5.1"},
+                  "paused": false,
+                  "public": true,
+                  "archived": false,
                   "team_name": ""
                 }
               ]`))
@@ -87,6 +100,7 @@ var _ = Describe("Fly CLI", func() {
 							{{Contents: "pipeline-1-longer"}, {Contents: "no"}, {Contents: "no"}},
 							{{Contents: "pipeline-2"}, {Contents: "yes", Color: color.New(color.FgCyan)}, {Contents: "no"}},
 							{{Contents: "pipeline-3"}, {Contents: "no"}, {Contents: "yes", Color: color.New(color.FgCyan)}},
+							{{Contents: `pipeline-3/branch:"5.1"`}, {Contents: "no"}, {Contents: "yes", Color: color.New(color.FgCyan)}},
 						},
 					}))
 				})
@@ -125,6 +139,7 @@ var _ = Describe("Fly CLI", func() {
                   "name": "pipeline-1-longer",
                   "paused": false,
                   "public": false,
+                  "archived": false,
                   "team_name": "main"
                 },
                 {
@@ -132,6 +147,7 @@ var _ = Describe("Fly CLI", func() {
                   "name": "pipeline-2",
                   "paused": true,
                   "public": false,
+                  "archived": false,
                   "team_name": "main"
                 },
                 {
@@ -139,6 +155,7 @@ var _ = Describe("Fly CLI", func() {
                   "name": "pipeline-3",
                   "paused": false,
                   "public": true,
+                  "archived": false,
                   "team_name": "main"
                 },
                 {
@@ -146,6 +163,7 @@ var _ = Describe("Fly CLI", func() {
                   "name": "foreign-pipeline-1",
                   "paused": false,
                   "public": true,
+                  "archived": false,
                   "team_name": "other"
                 },
                 {
@@ -153,6 +171,7 @@ var _ = Describe("Fly CLI", func() {
                   "name": "foreign-pipeline-2",
                   "paused": false,
                   "public": true,
+                  "archived": false,
                   "team_name": "other"
                 }
               ]`))
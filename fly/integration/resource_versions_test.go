@@ -2,6 +2,7 @@ package integration_test
 
 import (
 	"os/exec"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/ui"
@@ -90,6 +91,50 @@ var _ = Describe("Fly CLI", func() {
 					}))
 				})
 			})
+
+			Context("when --show-annotations is given", func() {
+				var disabledAt, enabledAt time.Time
+
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--show-annotations")
+
+					disabledAt = time.Date(2019, 3, 1, 10, 0, 0, 0, time.UTC)
+					enabledAt = time.Date(2019, 3, 2, 10, 0, 0, 0, time.UTC)
+
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/pipeline/resources/foo/versions"),
+							ghttp.RespondWithJSONEncoded(200, []atc.ResourceVersion{
+								{ID: 2, Version: atc.Version{"version": "2"}, Enabled: true, Annotations: []atc.VersionAnnotation{
+									{Action: atc.VersionActionDisable, UserName: "some-user", Comment: "the version is broken", CreatedAt: disabledAt.Unix()},
+									{Action: atc.VersionActionEnable, CreatedAt: enabledAt.Unix()},
+								}},
+								{ID: 1, Version: atc.Version{"version": "1"}, Enabled: true},
+							}),
+						),
+					)
+				})
+
+				It("lists the annotations of each version", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out).To(PrintTable(ui.Table{
+						Headers: ui.TableRow{
+							{Contents: "id", Color: color.New(color.Bold)},
+							{Contents: "version", Color: color.New(color.Bold)},
+							{Contents: "enabled", Color: color.New(color.Bold)},
+							{Contents: "annotations", Color: color.New(color.Bold)},
+						},
+						Data: []ui.TableRow{
+							{{Contents: "2"}, {Contents: "version:2"}, {Contents: "yes"}, {Contents: "disabled " + disabledAt.Local().Format("2006-01-02@15:04:05-0700") + " by some-user: the version is broken"}},
+							{{}, {}, {}, {Contents: "enabled " + enabledAt.Local().Format("2006-01-02@15:04:05-0700")}},
+							{{Contents: "1"}, {Contents: "version:1"}, {Contents: "yes"}, {Contents: "n/a"}},
+						},
+					}))
+				})
+			})
 		})
 
 		Context("and the api returns an internal server error", func() {
@@ -0,0 +1,85 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("SaveVersion", func() {
+	var (
+		flyCmd         *exec.Cmd
+		expectedStatus int
+	)
+
+	BeforeEach(func() {
+		expectedStatus = http.StatusCreated
+	})
+
+	JustBeforeEach(func() {
+		expectedURL := "/api/v1/teams/main/pipelines/mypipeline/resources/myresource/versions"
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", expectedURL),
+				ghttp.VerifyJSON(`{
+					"version": {"ref": "fake-ref", "tag": "v1.2.3"},
+					"metadata": [{"name": "author", "value": "someone"}, {"name": "url", "value": "https://example.com"}]
+				}`),
+				ghttp.RespondWith(expectedStatus, nil),
+			),
+		)
+
+		flyCmd = exec.Command(
+			flyPath, "-t", targetName, "save-version",
+			"-r", "mypipeline/myresource",
+			"-v", "ref:fake-ref",
+			"-v", "tag:v1.2.3",
+			"--metadata", "url:https://example.com",
+			"--metadata", "author:someone",
+		)
+	})
+
+	Context("when the version is new", func() {
+		It("saves the version", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("saved version of 'myresource'"))
+		})
+	})
+
+	Context("when the version already exists", func() {
+		BeforeEach(func() {
+			expectedStatus = http.StatusOK
+		})
+
+		It("says that it is now the latest version", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+			Expect(sess.Out).To(gbytes.Say("version of 'myresource' already existed and is now the latest"))
+		})
+	})
+
+	Context("when the pipeline or resource is not found", func() {
+		BeforeEach(func() {
+			expectedStatus = http.StatusNotFound
+		})
+
+		It("fails with an error", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("pipeline 'mypipeline' or resource 'myresource' not found"))
+		})
+	})
+})
@@ -740,8 +740,8 @@ this is super secure
 				})
 			})
 
-			Context("when specifying a pipeline name with a '/' character in it", func() {
-				It("fails and says '/' characters are not allowed", func() {
+			Context("when specifying a malformed pipeline instance", func() {
+				It("fails and says the instance vars are invalid", func() {
 					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "forbidden/pipelinename", "-c", configFile.Name())
 
 					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
@@ -750,7 +750,7 @@ this is super secure
 					<-sess.Exited
 					Expect(sess.ExitCode()).To(Equal(1))
 
-					Expect(sess.Err).To(gbytes.Say("error: pipeline name cannot contain '/'"))
+					Expect(sess.Err).To(gbytes.Say(`error: invalid instance var 'pipelinename' \(must be key:value\)`))
 				})
 			})
 
@@ -1039,6 +1039,111 @@ this is super secure
 				})
 			})
 
+			Context("when configuring an instance of the pipeline", func() {
+				BeforeEach(func() {
+					path, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("PUT", path, ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", path, "instance_vars=%7B%22branch%22%3A%225.1%22%7D"),
+						ghttp.RespondWith(http.StatusCreated, "{}"),
+					))
+					config.Resources[0].Name = "updated-name"
+				})
+
+				It("saves the config for the instance and links to it", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-n", "-p", "awesome-pipeline", "--instance-var", "branch='5.1'", "-c", configFile.Name())
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					pipelineURL := urljoiner.Join(atcServer.URL(), "teams/main/pipelines", "awesome-pipeline")
+
+					Eventually(sess).Should(gbytes.Say("pipeline created!"))
+					Eventually(sess).Should(gbytes.Say(fmt.Sprintf(`you can view your pipeline here: %s\?instance_vars=`, pipelineURL)))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+
+				It("accepts the instance vars as part of the pipeline flag", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-n", "-p", "awesome-pipeline/branch:'5.1'", "-c", configFile.Name())
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say("pipeline created!"))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
+			Context("when an instance is configured and then fetched with an ambiguous instance var", func() {
+				var savedConfigs map[string][]byte
+
+				BeforeEach(func() {
+					savedConfigs = map[string][]byte{}
+
+					path, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("PUT", path, ghttp.CombineHandlers(
+						func(w http.ResponseWriter, r *http.Request) {
+							savedConfigs[r.URL.Query().Get("instance_vars")] = getConfig(r)
+						},
+						ghttp.RespondWith(http.StatusCreated, "{}"),
+					))
+
+					path, err = atc.Routes.CreatePathForRoute(atc.GetConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("GET", path, func(w http.ResponseWriter, r *http.Request) {
+						payload, found := savedConfigs[r.URL.Query().Get("instance_vars")]
+						if !found {
+							w.WriteHeader(http.StatusNotFound)
+							return
+						}
+
+						var savedConfig atc.Config
+						err := yaml.Unmarshal(payload, &savedConfig)
+						Expect(err).NotTo(HaveOccurred())
+
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: &savedConfig}, http.Header{atc.ConfigVersionHeader: {"42"}})(w, r)
+					})
+
+					config.Resources[0].Name = "updated-name"
+				})
+
+				It("reads the instance var the same way with either flag", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-n", "-p", "awesome-pipeline", "--instance-var", "branch=5.10", "-c", configFile.Name())
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+
+					Expect(savedConfigs).To(HaveKey(`{"branch":"5.10"}`))
+
+					atcServer.AppendHandlers(infoHandler())
+
+					flyCmd = exec.Command(flyPath, "-t", targetName, "get-pipeline", "-p", "awesome-pipeline/branch:5.10")
+
+					sess, err = gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+
+					var fetchedConfig atc.Config
+					err = yaml.Unmarshal(sess.Out.Contents(), &fetchedConfig)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fetchedConfig.Resources[0].Name).To(Equal("updated-name"))
+				})
+			})
+
 			Context("when the server returns warnings", func() {
 				BeforeEach(func() {
 					path, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
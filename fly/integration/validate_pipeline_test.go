@@ -102,5 +102,40 @@ var _ = Describe("Fly CLI", func() {
 
 			Expect(sess.Err).To(gbytes.Say("configuration invalid"))
 		})
+
+		It("returns valid on configuration split across a directory", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"validate-pipeline",
+				"-c", "fixtures/split-pipeline",
+				"-o",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gbytes.Say("jobs:"))
+			Eventually(sess).Should(gbytes.Say("resources:"))
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(0))
+		})
+
+		It("returns invalid when the merged files conflict", func() {
+			flyCmd := exec.Command(
+				flyPath,
+				"validate-pipeline",
+				"-c", "fixtures/split-pipeline",
+				"-c", "fixtures/testConfigConflicting.yml",
+			)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+
+			Expect(sess.Err).To(gbytes.Say("resource 'some-resource' is defined in both fixtures/split-pipeline/resources.yml and fixtures/testConfigConflicting.yml"))
+		})
 	})
 })
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/concourse/concourse/atc"
@@ -20,15 +21,52 @@ func (checkResourceError CheckResourceError) Error() string {
 }
 
 func (team *team) CheckResource(pipelineName string, resourceName string, version atc.Version) (bool, error) {
+	response, found, err := team.checkResource(pipelineName, resourceName, atc.CheckRequestBody{From: version})
+	if found {
+		response.Result.(io.ReadCloser).Close()
+	}
+
+	return found, err
+}
+
+// StartCheckResource is CheckResource, except that it returns as soon as the
+// check has been recorded, returning the check. If the check finished without
+// being recorded, e.g. because the pipeline is paused, no check is returned.
+func (team *team) StartCheckResource(pipelineName string, resourceName string, version atc.Version) (*atc.Check, bool, error) {
+	response, found, err := team.checkResource(pipelineName, resourceName, atc.CheckRequestBody{
+		From:  version,
+		Async: true,
+	})
+	if err != nil || !found {
+		return nil, found, err
+	}
+
+	body := response.Result.(io.ReadCloser)
+	defer body.Close()
+
+	if !response.Created {
+		return nil, true, nil
+	}
+
+	var check atc.Check
+	err = json.NewDecoder(body).Decode(&check)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &check, true, nil
+}
+
+func (team *team) checkResource(pipelineName string, resourceName string, requestBody atc.CheckRequestBody) (internal.Response, bool, error) {
 	params := rata.Params{
 		"pipeline_name": pipelineName,
 		"resource_name": resourceName,
 		"team_name":     team.name,
 	}
 
-	jsonBytes, err := json.Marshal(atc.CheckRequestBody{From: version})
+	jsonBytes, err := json.Marshal(requestBody)
 	if err != nil {
-		return false, err
+		return internal.Response{}, false, err
 	}
 
 	response := internal.Response{}
@@ -42,9 +80,9 @@ func (team *team) CheckResource(pipelineName string, resourceName string, versio
 
 	switch err.(type) {
 	case nil:
-		return true, nil
+		return response, true, nil
 	case internal.ResourceNotFoundError:
-		return false, nil
+		return response, false, nil
 	default:
 		if unexpectedResponseError, ok := err.(internal.UnexpectedResponseError); ok {
 			switch unexpectedResponseError.StatusCode {
@@ -53,10 +91,10 @@ func (team *team) CheckResource(pipelineName string, resourceName string, versio
 
 				err = json.Unmarshal([]byte(unexpectedResponseError.Body), &checkResourceErr)
 				if err != nil {
-					return false, err
+					return response, false, err
 				}
 
-				return false, checkResourceErr
+				return response, false, checkResourceErr
 			case http.StatusInternalServerError:
 				checkResourceErr := CheckResourceError{
 					atc.CheckResponseBody{
@@ -65,10 +103,10 @@ func (team *team) CheckResource(pipelineName string, resourceName string, versio
 					},
 				}
 
-				return false, checkResourceErr
+				return response, false, checkResourceErr
 			}
 		}
 
-		return false, err
+		return response, false, err
 	}
 }
@@ -104,3 +104,61 @@ var _ = Describe("CheckResource", func() {
 		})
 	})
 })
+
+var _ = Describe("StartCheckResource", func() {
+	expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/check"
+
+	Context("when the ATC records the check", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.VerifyJSON(`{"from":{"ref":"fake-ref"},"async":true}`),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.Check{ID: 42, Status: "started"}),
+				),
+			)
+		})
+
+		It("returns the check", func() {
+			check, found, err := team.StartCheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(check).To(Equal(&atc.Check{ID: 42, Status: "started"}))
+		})
+	})
+
+	Context("when the check finishes without being recorded", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.RespondWith(http.StatusOK, ""),
+				),
+			)
+		})
+
+		It("returns no check", func() {
+			check, found, err := team.StartCheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(check).To(BeNil())
+		})
+	})
+
+	Context("when pipeline or resource does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("returns false", func() {
+			_, found, err := team.StartCheckResource("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -11,24 +11,36 @@ import (
 )
 
 func (team *team) CheckResourceType(pipelineName string, resourceTypeName string, version atc.Version) (bool, error) {
+	return team.checkResourceType(pipelineName, resourceTypeName, atc.CheckRequestBody{From: version}, nil)
+}
+
+// CheckResourceTypeRecursively checks the resource type and then schedules
+// every resource type and resource using it to be rechecked, returning those
+// scheduled.
+func (team *team) CheckResourceTypeRecursively(pipelineName string, resourceTypeName string, version atc.Version) (atc.CheckResourceTypeResponseBody, bool, error) {
+	var dependents atc.CheckResourceTypeResponseBody
+	found, err := team.checkResourceType(pipelineName, resourceTypeName, atc.CheckRequestBody{From: version, Recursive: true}, &dependents)
+	return dependents, found, err
+}
+
+func (team *team) checkResourceType(pipelineName string, resourceTypeName string, checkRequest atc.CheckRequestBody, result interface{}) (bool, error) {
 	params := rata.Params{
 		"pipeline_name":      pipelineName,
 		"resource_type_name": resourceTypeName,
 		"team_name":          team.name,
 	}
 
-	jsonBytes, err := json.Marshal(atc.CheckRequestBody{From: version})
+	jsonBytes, err := json.Marshal(checkRequest)
 	if err != nil {
 		return false, err
 	}
 
-	response := internal.Response{}
+	response := internal.Response{Result: result}
 	err = team.connection.Send(internal.Request{
-		ReturnResponseBody: true,
-		RequestName:        atc.CheckResourceType,
-		Params:             params,
-		Body:               bytes.NewBuffer(jsonBytes),
-		Header:             http.Header{"Content-Type": []string{"application/json"}},
+		RequestName: atc.CheckResourceType,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
 	}, &response)
 
 	switch err.(type) {
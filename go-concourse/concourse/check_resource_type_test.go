@@ -80,3 +80,69 @@ var _ = Describe("CheckResourceType", func() {
 	})
 
 })
+
+var _ = Describe("CheckResourceTypeRecursively", func() {
+	expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resource-types/myresource/check"
+
+	Context("when ATC request succeeds", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.VerifyJSON(`{"from":{"ref":"fake-ref"},"recursive":true}`),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.CheckResourceTypeResponseBody{
+						ResourceTypes: []string{"some-child-type"},
+						Resources:     []string{"some-resource"},
+					}),
+				),
+			)
+		})
+
+		It("returns the scheduled dependents", func() {
+			dependents, found, err := team.CheckResourceTypeRecursively("mypipeline", "myresource", atc.Version{"ref": "fake-ref"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(dependents).To(Equal(atc.CheckResourceTypeResponseBody{
+				ResourceTypes: []string{"some-child-type"},
+				Resources:     []string{"some-resource"},
+			}))
+		})
+	})
+
+	Context("when pipeline or resource-type does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.RespondWithJSONEncoded(http.StatusNotFound, ""),
+				),
+			)
+		})
+
+		It("returns false", func() {
+			_, found, err := team.CheckResourceTypeRecursively("mypipeline", "myresource", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("when ATC responds with an internal server error", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.RespondWith(http.StatusInternalServerError, "internal server error"),
+				),
+			)
+		})
+
+		It("returns a CheckResourceError", func() {
+			_, _, err := team.CheckResourceTypeRecursively("mypipeline", "myresource", nil)
+			Expect(err).To(HaveOccurred())
+
+			cre, ok := err.(concourse.CheckResourceError)
+			Expect(ok).To(BeTrue())
+			Expect(cre.Error()).To(ContainSubstring("internal server error"))
+		})
+	})
+})
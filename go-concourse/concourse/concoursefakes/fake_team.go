@@ -9,6 +9,19 @@ import (
 )
 
 type FakeTeam struct {
+	ArchivePipelineStub        func(atc.PipelineRef) (bool, error)
+	archivePipelineMutex       sync.RWMutex
+	archivePipelineArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	archivePipelineReturns struct {
+		result1 bool
+		result2 error
+	}
+	archivePipelineReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	BuildInputsForJobStub        func(string, string) ([]atc.BuildInput, bool, error)
 	buildInputsForJobMutex       sync.RWMutex
 	buildInputsForJobArgsForCall []struct {
@@ -104,6 +117,23 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	CheckResourceTypeRecursivelyStub        func(string, string, atc.Version) (atc.CheckResourceTypeResponseBody, bool, error)
+	checkResourceTypeRecursivelyMutex       sync.RWMutex
+	checkResourceTypeRecursivelyArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+	}
+	checkResourceTypeRecursivelyReturns struct {
+		result1 atc.CheckResourceTypeResponseBody
+		result2 bool
+		result3 error
+	}
+	checkResourceTypeRecursivelyReturnsOnCall map[int]struct {
+		result1 atc.CheckResourceTypeResponseBody
+		result2 bool
+		result3 error
+	}
 	ClearTaskCacheStub        func(string, string, string, string) (int64, error)
 	clearTaskCacheMutex       sync.RWMutex
 	clearTaskCacheArgsForCall []struct {
@@ -164,10 +194,10 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
-	CreateOrUpdatePipelineConfigStub        func(string, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)
+	CreateOrUpdatePipelineConfigStub        func(atc.PipelineRef, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)
 	createOrUpdatePipelineConfigMutex       sync.RWMutex
 	createOrUpdatePipelineConfigArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 []byte
 		arg4 bool
@@ -198,10 +228,10 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
-	DeletePipelineStub        func(string) (bool, error)
+	DeletePipelineStub        func(atc.PipelineRef) (bool, error)
 	deletePipelineMutex       sync.RWMutex
 	deletePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	deletePipelineReturns struct {
 		result1 bool
@@ -222,12 +252,13 @@ type FakeTeam struct {
 	destroyTeamReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DisableResourceVersionStub        func(string, string, int) (bool, error)
+	DisableResourceVersionStub        func(string, string, int, string) (bool, error)
 	disableResourceVersionMutex       sync.RWMutex
 	disableResourceVersionArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 int
+		arg4 string
 	}
 	disableResourceVersionReturns struct {
 		result1 bool
@@ -237,12 +268,13 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	EnableResourceVersionStub        func(string, string, int) (bool, error)
+	EnableResourceVersionStub        func(string, string, int, string) (bool, error)
 	enableResourceVersionMutex       sync.RWMutex
 	enableResourceVersionArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 int
+		arg4 string
 	}
 	enableResourceVersionReturns struct {
 		result1 bool
@@ -252,10 +284,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	ExposePipelineStub        func(string) (bool, error)
+	ExposePipelineStub        func(atc.PipelineRef) (bool, error)
 	exposePipelineMutex       sync.RWMutex
 	exposePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	exposePipelineReturns struct {
 		result1 bool
@@ -265,10 +297,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	HidePipelineStub        func(string) (bool, error)
+	HidePipelineStub        func(atc.PipelineRef) (bool, error)
 	hidePipelineMutex       sync.RWMutex
 	hidePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	hidePipelineReturns struct {
 		result1 bool
@@ -428,10 +460,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PausePipelineStub        func(string) (bool, error)
+	PausePipelineStub        func(atc.PipelineRef) (bool, error)
 	pausePipelineMutex       sync.RWMutex
 	pausePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pausePipelineReturns struct {
 		result1 bool
@@ -441,10 +473,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PipelineStub        func(string) (atc.Pipeline, bool, error)
+	PipelineStub        func(atc.PipelineRef) (atc.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pipelineReturns struct {
 		result1 atc.Pipeline
@@ -456,10 +488,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	PipelineBuildsStub        func(string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)
+	PipelineBuildsStub        func(atc.PipelineRef, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)
 	pipelineBuildsMutex       sync.RWMutex
 	pipelineBuildsArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 concourse.Page
 	}
 	pipelineBuildsReturns struct {
@@ -474,10 +506,10 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
-	PipelineConfigStub        func(string) (atc.Config, atc.RawConfig, string, bool, error)
+	PipelineConfigStub        func(atc.PipelineRef) (atc.Config, atc.RawConfig, string, bool, error)
 	pipelineConfigMutex       sync.RWMutex
 	pipelineConfigArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pipelineConfigReturns struct {
 		result1 atc.Config
@@ -493,10 +525,10 @@ type FakeTeam struct {
 		result4 bool
 		result5 error
 	}
-	RenamePipelineStub        func(string, string) (bool, error)
+	RenamePipelineStub        func(atc.PipelineRef, string) (bool, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	renamePipelineReturns struct {
@@ -537,6 +569,37 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	ResourceCheckEventsStub        func(string, string, int) (concourse.Events, error)
+	resourceCheckEventsMutex       sync.RWMutex
+	resourceCheckEventsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	resourceCheckEventsReturns struct {
+		result1 concourse.Events
+		result2 error
+	}
+	resourceCheckEventsReturnsOnCall map[int]struct {
+		result1 concourse.Events
+		result2 error
+	}
+	ResourceChecksStub        func(string, string) ([]atc.Check, bool, error)
+	resourceChecksMutex       sync.RWMutex
+	resourceChecksArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	resourceChecksReturns struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}
+	resourceChecksReturnsOnCall map[int]struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}
 	ResourceVersionsStub        func(string, string, concourse.Page) ([]atc.ResourceVersion, concourse.Pagination, bool, error)
 	resourceVersionsMutex       sync.RWMutex
 	resourceVersionsArgsForCall []struct {
@@ -556,6 +619,41 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	SaveResourceVersionStub        func(string, string, atc.Version, []atc.MetadataField) (bool, bool, error)
+	saveResourceVersionMutex       sync.RWMutex
+	saveResourceVersionArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+		arg4 []atc.MetadataField
+	}
+	saveResourceVersionReturns struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}
+	saveResourceVersionReturnsOnCall map[int]struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}
+	StartCheckResourceStub        func(string, string, atc.Version) (*atc.Check, bool, error)
+	startCheckResourceMutex       sync.RWMutex
+	startCheckResourceArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+	}
+	startCheckResourceReturns struct {
+		result1 *atc.Check
+		result2 bool
+		result3 error
+	}
+	startCheckResourceReturnsOnCall map[int]struct {
+		result1 *atc.Check
+		result2 bool
+		result3 error
+	}
 	UnpauseJobStub        func(string, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
@@ -570,10 +668,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	UnpausePipelineStub        func(string) (bool, error)
+	UnpausePipelineStub        func(atc.PipelineRef) (bool, error)
 	unpausePipelineMutex       sync.RWMutex
 	unpausePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	unpausePipelineReturns struct {
 		result1 bool
@@ -602,6 +700,69 @@ type FakeTeam struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeTeam) ArchivePipeline(arg1 atc.PipelineRef) (bool, error) {
+	fake.archivePipelineMutex.Lock()
+	ret, specificReturn := fake.archivePipelineReturnsOnCall[len(fake.archivePipelineArgsForCall)]
+	fake.archivePipelineArgsForCall = append(fake.archivePipelineArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	fake.recordInvocation("ArchivePipeline", []interface{}{arg1})
+	fake.archivePipelineMutex.Unlock()
+	if fake.ArchivePipelineStub != nil {
+		return fake.ArchivePipelineStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.archivePipelineReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ArchivePipelineCallCount() int {
+	fake.archivePipelineMutex.RLock()
+	defer fake.archivePipelineMutex.RUnlock()
+	return len(fake.archivePipelineArgsForCall)
+}
+
+func (fake *FakeTeam) ArchivePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
+	fake.archivePipelineMutex.Lock()
+	defer fake.archivePipelineMutex.Unlock()
+	fake.ArchivePipelineStub = stub
+}
+
+func (fake *FakeTeam) ArchivePipelineArgsForCall(i int) atc.PipelineRef {
+	fake.archivePipelineMutex.RLock()
+	defer fake.archivePipelineMutex.RUnlock()
+	argsForCall := fake.archivePipelineArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) ArchivePipelineReturns(result1 bool, result2 error) {
+	fake.archivePipelineMutex.Lock()
+	defer fake.archivePipelineMutex.Unlock()
+	fake.ArchivePipelineStub = nil
+	fake.archivePipelineReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ArchivePipelineReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.archivePipelineMutex.Lock()
+	defer fake.archivePipelineMutex.Unlock()
+	fake.ArchivePipelineStub = nil
+	if fake.archivePipelineReturnsOnCall == nil {
+		fake.archivePipelineReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.archivePipelineReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) BuildInputsForJob(arg1 string, arg2 string) ([]atc.BuildInput, bool, error) {
 	fake.buildInputsForJobMutex.Lock()
 	ret, specificReturn := fake.buildInputsForJobReturnsOnCall[len(fake.buildInputsForJobArgsForCall)]
@@ -1001,6 +1162,74 @@ func (fake *FakeTeam) CheckResourceTypeReturnsOnCall(i int, result1 bool, result
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CheckResourceTypeRecursively(arg1 string, arg2 string, arg3 atc.Version) (atc.CheckResourceTypeResponseBody, bool, error) {
+	fake.checkResourceTypeRecursivelyMutex.Lock()
+	ret, specificReturn := fake.checkResourceTypeRecursivelyReturnsOnCall[len(fake.checkResourceTypeRecursivelyArgsForCall)]
+	fake.checkResourceTypeRecursivelyArgsForCall = append(fake.checkResourceTypeRecursivelyArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CheckResourceTypeRecursively", []interface{}{arg1, arg2, arg3})
+	fake.checkResourceTypeRecursivelyMutex.Unlock()
+	if fake.CheckResourceTypeRecursivelyStub != nil {
+		return fake.CheckResourceTypeRecursivelyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.checkResourceTypeRecursivelyReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) CheckResourceTypeRecursivelyCallCount() int {
+	fake.checkResourceTypeRecursivelyMutex.RLock()
+	defer fake.checkResourceTypeRecursivelyMutex.RUnlock()
+	return len(fake.checkResourceTypeRecursivelyArgsForCall)
+}
+
+func (fake *FakeTeam) CheckResourceTypeRecursivelyCalls(stub func(string, string, atc.Version) (atc.CheckResourceTypeResponseBody, bool, error)) {
+	fake.checkResourceTypeRecursivelyMutex.Lock()
+	defer fake.checkResourceTypeRecursivelyMutex.Unlock()
+	fake.CheckResourceTypeRecursivelyStub = stub
+}
+
+func (fake *FakeTeam) CheckResourceTypeRecursivelyArgsForCall(i int) (string, string, atc.Version) {
+	fake.checkResourceTypeRecursivelyMutex.RLock()
+	defer fake.checkResourceTypeRecursivelyMutex.RUnlock()
+	argsForCall := fake.checkResourceTypeRecursivelyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CheckResourceTypeRecursivelyReturns(result1 atc.CheckResourceTypeResponseBody, result2 bool, result3 error) {
+	fake.checkResourceTypeRecursivelyMutex.Lock()
+	defer fake.checkResourceTypeRecursivelyMutex.Unlock()
+	fake.CheckResourceTypeRecursivelyStub = nil
+	fake.checkResourceTypeRecursivelyReturns = struct {
+		result1 atc.CheckResourceTypeResponseBody
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) CheckResourceTypeRecursivelyReturnsOnCall(i int, result1 atc.CheckResourceTypeResponseBody, result2 bool, result3 error) {
+	fake.checkResourceTypeRecursivelyMutex.Lock()
+	defer fake.checkResourceTypeRecursivelyMutex.Unlock()
+	fake.CheckResourceTypeRecursivelyStub = nil
+	if fake.checkResourceTypeRecursivelyReturnsOnCall == nil {
+		fake.checkResourceTypeRecursivelyReturnsOnCall = make(map[int]struct {
+			result1 atc.CheckResourceTypeResponseBody
+			result2 bool
+			result3 error
+		})
+	}
+	fake.checkResourceTypeRecursivelyReturnsOnCall[i] = struct {
+		result1 atc.CheckResourceTypeResponseBody
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ClearTaskCache(arg1 string, arg2 string, arg3 string, arg4 string) (int64, error) {
 	fake.clearTaskCacheMutex.Lock()
 	ret, specificReturn := fake.clearTaskCacheReturnsOnCall[len(fake.clearTaskCacheArgsForCall)]
@@ -1263,7 +1492,7 @@ func (fake *FakeTeam) CreateOrUpdateReturnsOnCall(i int, result1 atc.Team, resul
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeam) CreateOrUpdatePipelineConfig(arg1 string, arg2 string, arg3 []byte, arg4 bool) (bool, bool, []concourse.ConfigWarning, error) {
+func (fake *FakeTeam) CreateOrUpdatePipelineConfig(arg1 atc.PipelineRef, arg2 string, arg3 []byte, arg4 bool) (bool, bool, []concourse.ConfigWarning, error) {
 	var arg3Copy []byte
 	if arg3 != nil {
 		arg3Copy = make([]byte, len(arg3))
@@ -1272,7 +1501,7 @@ func (fake *FakeTeam) CreateOrUpdatePipelineConfig(arg1 string, arg2 string, arg
 	fake.createOrUpdatePipelineConfigMutex.Lock()
 	ret, specificReturn := fake.createOrUpdatePipelineConfigReturnsOnCall[len(fake.createOrUpdatePipelineConfigArgsForCall)]
 	fake.createOrUpdatePipelineConfigArgsForCall = append(fake.createOrUpdatePipelineConfigArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 []byte
 		arg4 bool
@@ -1295,13 +1524,13 @@ func (fake *FakeTeam) CreateOrUpdatePipelineConfigCallCount() int {
 	return len(fake.createOrUpdatePipelineConfigArgsForCall)
 }
 
-func (fake *FakeTeam) CreateOrUpdatePipelineConfigCalls(stub func(string, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)) {
+func (fake *FakeTeam) CreateOrUpdatePipelineConfigCalls(stub func(atc.PipelineRef, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)) {
 	fake.createOrUpdatePipelineConfigMutex.Lock()
 	defer fake.createOrUpdatePipelineConfigMutex.Unlock()
 	fake.CreateOrUpdatePipelineConfigStub = stub
 }
 
-func (fake *FakeTeam) CreateOrUpdatePipelineConfigArgsForCall(i int) (string, string, []byte, bool) {
+func (fake *FakeTeam) CreateOrUpdatePipelineConfigArgsForCall(i int) (atc.PipelineRef, string, []byte, bool) {
 	fake.createOrUpdatePipelineConfigMutex.RLock()
 	defer fake.createOrUpdatePipelineConfigMutex.RUnlock()
 	argsForCall := fake.createOrUpdatePipelineConfigArgsForCall[i]
@@ -1404,11 +1633,11 @@ func (fake *FakeTeam) CreatePipelineBuildReturnsOnCall(i int, result1 atc.Build,
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) DeletePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) DeletePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.deletePipelineMutex.Lock()
 	ret, specificReturn := fake.deletePipelineReturnsOnCall[len(fake.deletePipelineArgsForCall)]
 	fake.deletePipelineArgsForCall = append(fake.deletePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("DeletePipeline", []interface{}{arg1})
 	fake.deletePipelineMutex.Unlock()
@@ -1428,13 +1657,13 @@ func (fake *FakeTeam) DeletePipelineCallCount() int {
 	return len(fake.deletePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) DeletePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) DeletePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.deletePipelineMutex.Lock()
 	defer fake.deletePipelineMutex.Unlock()
 	fake.DeletePipelineStub = stub
 }
 
-func (fake *FakeTeam) DeletePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) DeletePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.deletePipelineMutex.RLock()
 	defer fake.deletePipelineMutex.RUnlock()
 	argsForCall := fake.deletePipelineArgsForCall[i]
@@ -1527,18 +1756,19 @@ func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) DisableResourceVersion(arg1 string, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) DisableResourceVersion(arg1 string, arg2 string, arg3 int, arg4 string) (bool, error) {
 	fake.disableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
 	fake.disableResourceVersionArgsForCall = append(fake.disableResourceVersionArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 int
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("DisableResourceVersion", []interface{}{arg1, arg2, arg3, arg4})
 	fake.disableResourceVersionMutex.Unlock()
 	if fake.DisableResourceVersionStub != nil {
-		return fake.DisableResourceVersionStub(arg1, arg2, arg3)
+		return fake.DisableResourceVersionStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1553,17 +1783,17 @@ func (fake *FakeTeam) DisableResourceVersionCallCount() int {
 	return len(fake.disableResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) DisableResourceVersionCalls(stub func(string, string, int) (bool, error)) {
+func (fake *FakeTeam) DisableResourceVersionCalls(stub func(string, string, int, string) (bool, error)) {
 	fake.disableResourceVersionMutex.Lock()
 	defer fake.disableResourceVersionMutex.Unlock()
 	fake.DisableResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (string, string, int) {
+func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (string, string, int, string) {
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
 	argsForCall := fake.disableResourceVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTeam) DisableResourceVersionReturns(result1 bool, result2 error) {
@@ -1592,18 +1822,19 @@ func (fake *FakeTeam) DisableResourceVersionReturnsOnCall(i int, result1 bool, r
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) EnableResourceVersion(arg1 string, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) EnableResourceVersion(arg1 string, arg2 string, arg3 int, arg4 string) (bool, error) {
 	fake.enableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.enableResourceVersionReturnsOnCall[len(fake.enableResourceVersionArgsForCall)]
 	fake.enableResourceVersionArgsForCall = append(fake.enableResourceVersionArgsForCall, struct {
 		arg1 string
 		arg2 string
 		arg3 int
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("EnableResourceVersion", []interface{}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("EnableResourceVersion", []interface{}{arg1, arg2, arg3, arg4})
 	fake.enableResourceVersionMutex.Unlock()
 	if fake.EnableResourceVersionStub != nil {
-		return fake.EnableResourceVersionStub(arg1, arg2, arg3)
+		return fake.EnableResourceVersionStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1618,17 +1849,17 @@ func (fake *FakeTeam) EnableResourceVersionCallCount() int {
 	return len(fake.enableResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) EnableResourceVersionCalls(stub func(string, string, int) (bool, error)) {
+func (fake *FakeTeam) EnableResourceVersionCalls(stub func(string, string, int, string) (bool, error)) {
 	fake.enableResourceVersionMutex.Lock()
 	defer fake.enableResourceVersionMutex.Unlock()
 	fake.EnableResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) EnableResourceVersionArgsForCall(i int) (string, string, int) {
+func (fake *FakeTeam) EnableResourceVersionArgsForCall(i int) (string, string, int, string) {
 	fake.enableResourceVersionMutex.RLock()
 	defer fake.enableResourceVersionMutex.RUnlock()
 	argsForCall := fake.enableResourceVersionArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTeam) EnableResourceVersionReturns(result1 bool, result2 error) {
@@ -1657,11 +1888,11 @@ func (fake *FakeTeam) EnableResourceVersionReturnsOnCall(i int, result1 bool, re
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) ExposePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) ExposePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.exposePipelineMutex.Lock()
 	ret, specificReturn := fake.exposePipelineReturnsOnCall[len(fake.exposePipelineArgsForCall)]
 	fake.exposePipelineArgsForCall = append(fake.exposePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("ExposePipeline", []interface{}{arg1})
 	fake.exposePipelineMutex.Unlock()
@@ -1681,13 +1912,13 @@ func (fake *FakeTeam) ExposePipelineCallCount() int {
 	return len(fake.exposePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) ExposePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) ExposePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.exposePipelineMutex.Lock()
 	defer fake.exposePipelineMutex.Unlock()
 	fake.ExposePipelineStub = stub
 }
 
-func (fake *FakeTeam) ExposePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) ExposePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.exposePipelineMutex.RLock()
 	defer fake.exposePipelineMutex.RUnlock()
 	argsForCall := fake.exposePipelineArgsForCall[i]
@@ -1720,11 +1951,11 @@ func (fake *FakeTeam) ExposePipelineReturnsOnCall(i int, result1 bool, result2 e
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) HidePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) HidePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.hidePipelineMutex.Lock()
 	ret, specificReturn := fake.hidePipelineReturnsOnCall[len(fake.hidePipelineArgsForCall)]
 	fake.hidePipelineArgsForCall = append(fake.hidePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("HidePipeline", []interface{}{arg1})
 	fake.hidePipelineMutex.Unlock()
@@ -1744,13 +1975,13 @@ func (fake *FakeTeam) HidePipelineCallCount() int {
 	return len(fake.hidePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) HidePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) HidePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.hidePipelineMutex.Lock()
 	defer fake.hidePipelineMutex.Unlock()
 	fake.HidePipelineStub = stub
 }
 
-func (fake *FakeTeam) HidePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) HidePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.hidePipelineMutex.RLock()
 	defer fake.hidePipelineMutex.RUnlock()
 	argsForCall := fake.hidePipelineArgsForCall[i]
@@ -2469,11 +2700,11 @@ func (fake *FakeTeam) PauseJobReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) PausePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) PausePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.pausePipelineMutex.Lock()
 	ret, specificReturn := fake.pausePipelineReturnsOnCall[len(fake.pausePipelineArgsForCall)]
 	fake.pausePipelineArgsForCall = append(fake.pausePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("PausePipeline", []interface{}{arg1})
 	fake.pausePipelineMutex.Unlock()
@@ -2493,13 +2724,13 @@ func (fake *FakeTeam) PausePipelineCallCount() int {
 	return len(fake.pausePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) PausePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) PausePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.pausePipelineMutex.Lock()
 	defer fake.pausePipelineMutex.Unlock()
 	fake.PausePipelineStub = stub
 }
 
-func (fake *FakeTeam) PausePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) PausePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.pausePipelineMutex.RLock()
 	defer fake.pausePipelineMutex.RUnlock()
 	argsForCall := fake.pausePipelineArgsForCall[i]
@@ -2532,11 +2763,11 @@ func (fake *FakeTeam) PausePipelineReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Pipeline(arg1 string) (atc.Pipeline, bool, error) {
+func (fake *FakeTeam) Pipeline(arg1 atc.PipelineRef) (atc.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
 	fake.pipelineArgsForCall = append(fake.pipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("Pipeline", []interface{}{arg1})
 	fake.pipelineMutex.Unlock()
@@ -2556,13 +2787,13 @@ func (fake *FakeTeam) PipelineCallCount() int {
 	return len(fake.pipelineArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineCalls(stub func(string) (atc.Pipeline, bool, error)) {
+func (fake *FakeTeam) PipelineCalls(stub func(atc.PipelineRef) (atc.Pipeline, bool, error)) {
 	fake.pipelineMutex.Lock()
 	defer fake.pipelineMutex.Unlock()
 	fake.PipelineStub = stub
 }
 
-func (fake *FakeTeam) PipelineArgsForCall(i int) string {
+func (fake *FakeTeam) PipelineArgsForCall(i int) atc.PipelineRef {
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	argsForCall := fake.pipelineArgsForCall[i]
@@ -2598,11 +2829,11 @@ func (fake *FakeTeam) PipelineReturnsOnCall(i int, result1 atc.Pipeline, result2
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) PipelineBuilds(arg1 string, arg2 concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
+func (fake *FakeTeam) PipelineBuilds(arg1 atc.PipelineRef, arg2 concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
 	fake.pipelineBuildsMutex.Lock()
 	ret, specificReturn := fake.pipelineBuildsReturnsOnCall[len(fake.pipelineBuildsArgsForCall)]
 	fake.pipelineBuildsArgsForCall = append(fake.pipelineBuildsArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 concourse.Page
 	}{arg1, arg2})
 	fake.recordInvocation("PipelineBuilds", []interface{}{arg1, arg2})
@@ -2623,13 +2854,13 @@ func (fake *FakeTeam) PipelineBuildsCallCount() int {
 	return len(fake.pipelineBuildsArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineBuildsCalls(stub func(string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)) {
+func (fake *FakeTeam) PipelineBuildsCalls(stub func(atc.PipelineRef, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)) {
 	fake.pipelineBuildsMutex.Lock()
 	defer fake.pipelineBuildsMutex.Unlock()
 	fake.PipelineBuildsStub = stub
 }
 
-func (fake *FakeTeam) PipelineBuildsArgsForCall(i int) (string, concourse.Page) {
+func (fake *FakeTeam) PipelineBuildsArgsForCall(i int) (atc.PipelineRef, concourse.Page) {
 	fake.pipelineBuildsMutex.RLock()
 	defer fake.pipelineBuildsMutex.RUnlock()
 	argsForCall := fake.pipelineBuildsArgsForCall[i]
@@ -2668,11 +2899,11 @@ func (fake *FakeTeam) PipelineBuildsReturnsOnCall(i int, result1 []atc.Build, re
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeam) PipelineConfig(arg1 string) (atc.Config, atc.RawConfig, string, bool, error) {
+func (fake *FakeTeam) PipelineConfig(arg1 atc.PipelineRef) (atc.Config, atc.RawConfig, string, bool, error) {
 	fake.pipelineConfigMutex.Lock()
 	ret, specificReturn := fake.pipelineConfigReturnsOnCall[len(fake.pipelineConfigArgsForCall)]
 	fake.pipelineConfigArgsForCall = append(fake.pipelineConfigArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("PipelineConfig", []interface{}{arg1})
 	fake.pipelineConfigMutex.Unlock()
@@ -2692,13 +2923,13 @@ func (fake *FakeTeam) PipelineConfigCallCount() int {
 	return len(fake.pipelineConfigArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineConfigCalls(stub func(string) (atc.Config, atc.RawConfig, string, bool, error)) {
+func (fake *FakeTeam) PipelineConfigCalls(stub func(atc.PipelineRef) (atc.Config, atc.RawConfig, string, bool, error)) {
 	fake.pipelineConfigMutex.Lock()
 	defer fake.pipelineConfigMutex.Unlock()
 	fake.PipelineConfigStub = stub
 }
 
-func (fake *FakeTeam) PipelineConfigArgsForCall(i int) string {
+func (fake *FakeTeam) PipelineConfigArgsForCall(i int) atc.PipelineRef {
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
 	argsForCall := fake.pipelineConfigArgsForCall[i]
@@ -2740,11 +2971,11 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4, result5}
 }
 
-func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, error) {
+func (fake *FakeTeam) RenamePipeline(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
 	fake.renamePipelineArgsForCall = append(fake.renamePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("RenamePipeline", []interface{}{arg1, arg2})
@@ -2765,13 +2996,13 @@ func (fake *FakeTeam) RenamePipelineCallCount() int {
 	return len(fake.renamePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) RenamePipelineCalls(stub func(string, string) (bool, error)) {
+func (fake *FakeTeam) RenamePipelineCalls(stub func(atc.PipelineRef, string) (bool, error)) {
 	fake.renamePipelineMutex.Lock()
 	defer fake.renamePipelineMutex.Unlock()
 	fake.RenamePipelineStub = stub
 }
 
-func (fake *FakeTeam) RenamePipelineArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) RenamePipelineArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	argsForCall := fake.renamePipelineArgsForCall[i]
@@ -2935,6 +3166,138 @@ func (fake *FakeTeam) ResourceReturnsOnCall(i int, result1 atc.Resource, result2
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) ResourceCheckEvents(arg1 string, arg2 string, arg3 int) (concourse.Events, error) {
+	fake.resourceCheckEventsMutex.Lock()
+	ret, specificReturn := fake.resourceCheckEventsReturnsOnCall[len(fake.resourceCheckEventsArgsForCall)]
+	fake.resourceCheckEventsArgsForCall = append(fake.resourceCheckEventsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ResourceCheckEvents", []interface{}{arg1, arg2, arg3})
+	fake.resourceCheckEventsMutex.Unlock()
+	if fake.ResourceCheckEventsStub != nil {
+		return fake.ResourceCheckEventsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.resourceCheckEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) ResourceCheckEventsCallCount() int {
+	fake.resourceCheckEventsMutex.RLock()
+	defer fake.resourceCheckEventsMutex.RUnlock()
+	return len(fake.resourceCheckEventsArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceCheckEventsCalls(stub func(string, string, int) (concourse.Events, error)) {
+	fake.resourceCheckEventsMutex.Lock()
+	defer fake.resourceCheckEventsMutex.Unlock()
+	fake.ResourceCheckEventsStub = stub
+}
+
+func (fake *FakeTeam) ResourceCheckEventsArgsForCall(i int) (string, string, int) {
+	fake.resourceCheckEventsMutex.RLock()
+	defer fake.resourceCheckEventsMutex.RUnlock()
+	argsForCall := fake.resourceCheckEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) ResourceCheckEventsReturns(result1 concourse.Events, result2 error) {
+	fake.resourceCheckEventsMutex.Lock()
+	defer fake.resourceCheckEventsMutex.Unlock()
+	fake.ResourceCheckEventsStub = nil
+	fake.resourceCheckEventsReturns = struct {
+		result1 concourse.Events
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ResourceCheckEventsReturnsOnCall(i int, result1 concourse.Events, result2 error) {
+	fake.resourceCheckEventsMutex.Lock()
+	defer fake.resourceCheckEventsMutex.Unlock()
+	fake.ResourceCheckEventsStub = nil
+	if fake.resourceCheckEventsReturnsOnCall == nil {
+		fake.resourceCheckEventsReturnsOnCall = make(map[int]struct {
+			result1 concourse.Events
+			result2 error
+		})
+	}
+	fake.resourceCheckEventsReturnsOnCall[i] = struct {
+		result1 concourse.Events
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) ResourceChecks(arg1 string, arg2 string) ([]atc.Check, bool, error) {
+	fake.resourceChecksMutex.Lock()
+	ret, specificReturn := fake.resourceChecksReturnsOnCall[len(fake.resourceChecksArgsForCall)]
+	fake.resourceChecksArgsForCall = append(fake.resourceChecksArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceChecks", []interface{}{arg1, arg2})
+	fake.resourceChecksMutex.Unlock()
+	if fake.ResourceChecksStub != nil {
+		return fake.ResourceChecksStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.resourceChecksReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) ResourceChecksCallCount() int {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	return len(fake.resourceChecksArgsForCall)
+}
+
+func (fake *FakeTeam) ResourceChecksCalls(stub func(string, string) ([]atc.Check, bool, error)) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = stub
+}
+
+func (fake *FakeTeam) ResourceChecksArgsForCall(i int) (string, string) {
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
+	argsForCall := fake.resourceChecksArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) ResourceChecksReturns(result1 []atc.Check, result2 bool, result3 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	fake.resourceChecksReturns = struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) ResourceChecksReturnsOnCall(i int, result1 []atc.Check, result2 bool, result3 error) {
+	fake.resourceChecksMutex.Lock()
+	defer fake.resourceChecksMutex.Unlock()
+	fake.ResourceChecksStub = nil
+	if fake.resourceChecksReturnsOnCall == nil {
+		fake.resourceChecksReturnsOnCall = make(map[int]struct {
+			result1 []atc.Check
+			result2 bool
+			result3 error
+		})
+	}
+	fake.resourceChecksReturnsOnCall[i] = struct {
+		result1 []atc.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ResourceVersions(arg1 string, arg2 string, arg3 concourse.Page) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
 	fake.resourceVersionsMutex.Lock()
 	ret, specificReturn := fake.resourceVersionsReturnsOnCall[len(fake.resourceVersionsArgsForCall)]
@@ -3006,6 +3369,148 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) SaveResourceVersion(arg1 string, arg2 string, arg3 atc.Version, arg4 []atc.MetadataField) (bool, bool, error) {
+	var arg4Copy []atc.MetadataField
+	if arg4 != nil {
+		arg4Copy = make([]atc.MetadataField, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.saveResourceVersionMutex.Lock()
+	ret, specificReturn := fake.saveResourceVersionReturnsOnCall[len(fake.saveResourceVersionArgsForCall)]
+	fake.saveResourceVersionArgsForCall = append(fake.saveResourceVersionArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+		arg4 []atc.MetadataField
+	}{arg1, arg2, arg3, arg4Copy})
+	fake.recordInvocation("SaveResourceVersion", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.saveResourceVersionMutex.Unlock()
+	if fake.SaveResourceVersionStub != nil {
+		return fake.SaveResourceVersionStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.saveResourceVersionReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SaveResourceVersionCallCount() int {
+	fake.saveResourceVersionMutex.RLock()
+	defer fake.saveResourceVersionMutex.RUnlock()
+	return len(fake.saveResourceVersionArgsForCall)
+}
+
+func (fake *FakeTeam) SaveResourceVersionCalls(stub func(string, string, atc.Version, []atc.MetadataField) (bool, bool, error)) {
+	fake.saveResourceVersionMutex.Lock()
+	defer fake.saveResourceVersionMutex.Unlock()
+	fake.SaveResourceVersionStub = stub
+}
+
+func (fake *FakeTeam) SaveResourceVersionArgsForCall(i int) (string, string, atc.Version, []atc.MetadataField) {
+	fake.saveResourceVersionMutex.RLock()
+	defer fake.saveResourceVersionMutex.RUnlock()
+	argsForCall := fake.saveResourceVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) SaveResourceVersionReturns(result1 bool, result2 bool, result3 error) {
+	fake.saveResourceVersionMutex.Lock()
+	defer fake.saveResourceVersionMutex.Unlock()
+	fake.SaveResourceVersionStub = nil
+	fake.saveResourceVersionReturns = struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SaveResourceVersionReturnsOnCall(i int, result1 bool, result2 bool, result3 error) {
+	fake.saveResourceVersionMutex.Lock()
+	defer fake.saveResourceVersionMutex.Unlock()
+	fake.SaveResourceVersionStub = nil
+	if fake.saveResourceVersionReturnsOnCall == nil {
+		fake.saveResourceVersionReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 bool
+			result3 error
+		})
+	}
+	fake.saveResourceVersionReturnsOnCall[i] = struct {
+		result1 bool
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) StartCheckResource(arg1 string, arg2 string, arg3 atc.Version) (*atc.Check, bool, error) {
+	fake.startCheckResourceMutex.Lock()
+	ret, specificReturn := fake.startCheckResourceReturnsOnCall[len(fake.startCheckResourceArgsForCall)]
+	fake.startCheckResourceArgsForCall = append(fake.startCheckResourceArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 atc.Version
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("StartCheckResource", []interface{}{arg1, arg2, arg3})
+	fake.startCheckResourceMutex.Unlock()
+	if fake.StartCheckResourceStub != nil {
+		return fake.StartCheckResourceStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.startCheckResourceReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) StartCheckResourceCallCount() int {
+	fake.startCheckResourceMutex.RLock()
+	defer fake.startCheckResourceMutex.RUnlock()
+	return len(fake.startCheckResourceArgsForCall)
+}
+
+func (fake *FakeTeam) StartCheckResourceCalls(stub func(string, string, atc.Version) (*atc.Check, bool, error)) {
+	fake.startCheckResourceMutex.Lock()
+	defer fake.startCheckResourceMutex.Unlock()
+	fake.StartCheckResourceStub = stub
+}
+
+func (fake *FakeTeam) StartCheckResourceArgsForCall(i int) (string, string, atc.Version) {
+	fake.startCheckResourceMutex.RLock()
+	defer fake.startCheckResourceMutex.RUnlock()
+	argsForCall := fake.startCheckResourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) StartCheckResourceReturns(result1 *atc.Check, result2 bool, result3 error) {
+	fake.startCheckResourceMutex.Lock()
+	defer fake.startCheckResourceMutex.Unlock()
+	fake.StartCheckResourceStub = nil
+	fake.startCheckResourceReturns = struct {
+		result1 *atc.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) StartCheckResourceReturnsOnCall(i int, result1 *atc.Check, result2 bool, result3 error) {
+	fake.startCheckResourceMutex.Lock()
+	defer fake.startCheckResourceMutex.Unlock()
+	fake.StartCheckResourceStub = nil
+	if fake.startCheckResourceReturnsOnCall == nil {
+		fake.startCheckResourceReturnsOnCall = make(map[int]struct {
+			result1 *atc.Check
+			result2 bool
+			result3 error
+		})
+	}
+	fake.startCheckResourceReturnsOnCall[i] = struct {
+		result1 *atc.Check
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) UnpauseJob(arg1 string, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
@@ -3070,11 +3575,11 @@ func (fake *FakeTeam) UnpauseJobReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) UnpausePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) UnpausePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.unpausePipelineMutex.Lock()
 	ret, specificReturn := fake.unpausePipelineReturnsOnCall[len(fake.unpausePipelineArgsForCall)]
 	fake.unpausePipelineArgsForCall = append(fake.unpausePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("UnpausePipeline", []interface{}{arg1})
 	fake.unpausePipelineMutex.Unlock()
@@ -3094,13 +3599,13 @@ func (fake *FakeTeam) UnpausePipelineCallCount() int {
 	return len(fake.unpausePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) UnpausePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) UnpausePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.unpausePipelineMutex.Lock()
 	defer fake.unpausePipelineMutex.Unlock()
 	fake.UnpausePipelineStub = stub
 }
 
-func (fake *FakeTeam) UnpausePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) UnpausePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.unpausePipelineMutex.RLock()
 	defer fake.unpausePipelineMutex.RUnlock()
 	argsForCall := fake.unpausePipelineArgsForCall[i]
@@ -3202,6 +3707,8 @@ func (fake *FakeTeam) VersionedResourceTypesReturnsOnCall(i int, result1 atc.Ver
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.archivePipelineMutex.RLock()
+	defer fake.archivePipelineMutex.RUnlock()
 	fake.buildInputsForJobMutex.RLock()
 	defer fake.buildInputsForJobMutex.RUnlock()
 	fake.buildsMutex.RLock()
@@ -3214,6 +3721,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.checkResourceMutex.RUnlock()
 	fake.checkResourceTypeMutex.RLock()
 	defer fake.checkResourceTypeMutex.RUnlock()
+	fake.checkResourceTypeRecursivelyMutex.RLock()
+	defer fake.checkResourceTypeRecursivelyMutex.RUnlock()
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
 	fake.createBuildMutex.RLock()
@@ -3274,8 +3783,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renameTeamMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
+	fake.resourceCheckEventsMutex.RLock()
+	defer fake.resourceCheckEventsMutex.RUnlock()
+	fake.resourceChecksMutex.RLock()
+	defer fake.resourceChecksMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.saveResourceVersionMutex.RLock()
+	defer fake.saveResourceVersionMutex.RUnlock()
+	fake.startCheckResourceMutex.RLock()
+	defer fake.startCheckResourceMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.unpausePipelineMutex.RLock()
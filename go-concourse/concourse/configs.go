@@ -16,9 +16,9 @@ import (
 	"github.com/tedsuo/rata"
 )
 
-func (team *team) PipelineConfig(pipelineName string) (atc.Config, atc.RawConfig, string, bool, error) {
+func (team *team) PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, atc.RawConfig, string, bool, error) {
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
@@ -32,6 +32,7 @@ func (team *team) PipelineConfig(pipelineName string) (atc.Config, atc.RawConfig
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.GetConfig,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &response)
 
 	switch err.(type) {
@@ -68,13 +69,17 @@ type setConfigResponse struct {
 	Warnings []ConfigWarning `json:"warnings"`
 }
 
-func (team *team) CreateOrUpdatePipelineConfig(pipelineName string, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error) {
+func (team *team) CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error) {
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
 	queryParams := url.Values{}
+	for key, values := range pipelineRef.QueryParams() {
+		queryParams[key] = values
+	}
+
 	if checkCredentials {
 		queryParams.Add(atc.SaveConfigCheckCreds, "")
 	}
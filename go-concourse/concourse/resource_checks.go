@@ -0,0 +1,51 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/eventstream"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ResourceChecks(pipelineName string, resourceName string) ([]atc.Check, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"resource_name": resourceName,
+		"team_name":     team.name,
+	}
+
+	var checks []atc.Check
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListResourceChecks,
+		Params:      params,
+	}, &internal.Response{
+		Result: &checks,
+	})
+	switch err.(type) {
+	case nil:
+		return checks, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
+
+func (team *team) ResourceCheckEvents(pipelineName string, resourceName string, checkID int) (Events, error) {
+	sseEvents, err := team.connection.ConnectToEventStream(internal.Request{
+		RequestName: atc.ResourceCheckEvents,
+		Params: rata.Params{
+			"pipeline_name": pipelineName,
+			"resource_name": resourceName,
+			"team_name":     team.name,
+			"check_id":      strconv.Itoa(checkID),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return eventstream.NewSSEEventStream(sseEvents), nil
+}
@@ -0,0 +1,98 @@
+package concourse_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/vito/go-sse/sse"
+)
+
+var _ = Describe("ATC Handler Resource Checks", func() {
+	Describe("team.ResourceChecks", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/checks"
+
+		Context("when the resource exists", func() {
+			var expectedChecks []atc.Check
+
+			BeforeEach(func() {
+				expectedChecks = []atc.Check{
+					{ID: 2, Status: "errored", CheckError: "some-error"},
+					{ID: 1, Status: "succeeded", WorkerName: "some-worker"},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedChecks),
+					),
+				)
+			})
+
+			It("returns the checks", func() {
+				checks, found, err := team.ResourceChecks("some-pipeline", "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(checks).To(Equal(expectedChecks))
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns not found", func() {
+				_, found, err := team.ResourceChecks("some-pipeline", "some-resource")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("team.ResourceCheckEvents", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/pipelines/some-pipeline/resources/some-resource/checks/42/events"),
+					func(w http.ResponseWriter, r *http.Request) {
+						w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+						w.WriteHeader(http.StatusOK)
+
+						payload, err := json.Marshal(event.Message{Event: event.FinishCheck{Succeeded: true}})
+						Expect(err).NotTo(HaveOccurred())
+
+						err = sse.Event{ID: "0", Name: "event", Data: payload}.Write(w)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = sse.Event{ID: fmt.Sprintf("%d", 1), Name: "end"}.Write(w)
+						Expect(err).NotTo(HaveOccurred())
+					},
+				),
+			)
+		})
+
+		It("streams the check's events", func() {
+			stream, err := team.ResourceCheckEvents("some-pipeline", "some-resource", 42)
+			Expect(err).NotTo(HaveOccurred())
+
+			next, err := stream.NextEvent()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(Equal(event.FinishCheck{Succeeded: true}))
+
+			_, err = stream.NextEvent()
+			Expect(err).To(Equal(io.EOF))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package concourse
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -42,15 +44,55 @@ func (team *team) ResourceVersions(pipelineName string, resourceName string, pag
 	}
 }
 
-func (team *team) DisableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error) {
-	return team.sendResourceVersion(pipelineName, resourceName, resourceVersionID, atc.DisableResourceVersion)
+// DisableResourceVersion disables a version of the resource, recording the
+// comment as the reason in the version's annotations.
+func (team *team) DisableResourceVersion(pipelineName string, resourceName string, resourceVersionID int, comment string) (bool, error) {
+	return team.sendResourceVersion(pipelineName, resourceName, resourceVersionID, comment, atc.DisableResourceVersion)
 }
 
-func (team *team) EnableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error) {
-	return team.sendResourceVersion(pipelineName, resourceName, resourceVersionID, atc.EnableResourceVersion)
+// EnableResourceVersion enables a version of the resource, recording the
+// comment as the reason in the version's annotations.
+func (team *team) EnableResourceVersion(pipelineName string, resourceName string, resourceVersionID int, comment string) (bool, error) {
+	return team.sendResourceVersion(pipelineName, resourceName, resourceVersionID, comment, atc.EnableResourceVersion)
 }
 
-func (team *team) sendResourceVersion(pipelineName string, resourceName string, resourceVersionID int, resourceVersionReq string) (bool, error) {
+// SaveResourceVersion injects a version into a resource without running a
+// check. It returns whether the version was new to the resource, and whether
+// the resource was found.
+func (team *team) SaveResourceVersion(pipelineName string, resourceName string, version atc.Version, metadata []atc.MetadataField) (bool, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineName,
+		"resource_name": resourceName,
+		"team_name":     team.name,
+	}
+
+	jsonBytes, err := json.Marshal(atc.SaveVersionRequest{
+		Version:  version,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return false, false, err
+	}
+
+	response := internal.Response{}
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.SaveResourceVersion,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, &response)
+
+	switch err.(type) {
+	case nil:
+		return response.Created, true, nil
+	case internal.ResourceNotFoundError:
+		return false, false, nil
+	default:
+		return false, false, err
+	}
+}
+
+func (team *team) sendResourceVersion(pipelineName string, resourceName string, resourceVersionID int, comment string, resourceVersionReq string) (bool, error) {
 	params := rata.Params{
 		"pipeline_name":              pipelineName,
 		"resource_name":              resourceName,
@@ -58,9 +100,16 @@ func (team *team) sendResourceVersion(pipelineName string, resourceName string,
 		"team_name":                  team.name,
 	}
 
-	err := team.connection.Send(internal.Request{
+	jsonBytes, err := json.Marshal(atc.VersionAnnotationRequest{Comment: comment})
+	if err != nil {
+		return false, err
+	}
+
+	err = team.connection.Send(internal.Request{
 		RequestName: resourceVersionReq,
 		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
 	}, nil)
 
 	switch err.(type) {
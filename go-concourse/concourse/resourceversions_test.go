@@ -243,6 +243,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("PUT", expectedURL),
+					ghttp.VerifyJSON(`{"comment":"the version is broken"}`),
 					ghttp.RespondWith(expectedStatus, nil),
 				),
 			)
@@ -255,7 +256,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the disable resource and returns no error", func() {
 				Expect(func() {
-					disabled, err := team.DisableResourceVersion(pipelineName, resourceName, resourceVersionID)
+					disabled, err := team.DisableResourceVersion(pipelineName, resourceName, resourceVersionID, "the version is broken")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(disabled).To(BeTrue())
 				}).To(Change(func() int {
@@ -271,7 +272,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the disable resource and returns an error", func() {
 				Expect(func() {
-					disabled, err := team.DisableResourceVersion(pipelineName, resourceName, resourceVersionID)
+					disabled, err := team.DisableResourceVersion(pipelineName, resourceName, resourceVersionID, "the version is broken")
 					Expect(err).To(HaveOccurred())
 					Expect(disabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -287,7 +288,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the disable resource and returns an error", func() {
 				Expect(func() {
-					disabled, err := team.DisableResourceVersion(pipelineName, resourceName, resourceVersionID)
+					disabled, err := team.DisableResourceVersion(pipelineName, resourceName, resourceVersionID, "the version is broken")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(disabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -310,6 +311,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			atcServer.AppendHandlers(
 				ghttp.CombineHandlers(
 					ghttp.VerifyRequest("PUT", expectedURL),
+					ghttp.VerifyJSON(`{"comment":"the version was fixed"}`),
 					ghttp.RespondWith(expectedStatus, nil),
 				),
 			)
@@ -322,7 +324,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the enable resource and returns no error", func() {
 				Expect(func() {
-					enabled, err := team.EnableResourceVersion(pipelineName, resourceName, resourceVersionID)
+					enabled, err := team.EnableResourceVersion(pipelineName, resourceName, resourceVersionID, "the version was fixed")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(enabled).To(BeTrue())
 				}).To(Change(func() int {
@@ -338,7 +340,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the enable resource and returns an error", func() {
 				Expect(func() {
-					enabled, err := team.EnableResourceVersion(pipelineName, resourceName, resourceVersionID)
+					enabled, err := team.EnableResourceVersion(pipelineName, resourceName, resourceVersionID, "the version was fixed")
 					Expect(err).To(HaveOccurred())
 					Expect(enabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -354,7 +356,7 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 
 			It("calls the enable resource and returns an error", func() {
 				Expect(func() {
-					enabled, err := team.EnableResourceVersion(pipelineName, resourceName, resourceVersionID)
+					enabled, err := team.EnableResourceVersion(pipelineName, resourceName, resourceVersionID, "the version was fixed")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(enabled).To(BeFalse())
 				}).To(Change(func() int {
@@ -363,4 +365,80 @@ var _ = Describe("ATC Handler Resource Versions", func() {
 			})
 		})
 	})
+
+	Describe("SaveResourceVersion", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/resources/myresource/versions"
+
+		var (
+			expectedStatus int
+			created        bool
+			found          bool
+			clientErr      error
+		)
+
+		BeforeEach(func() {
+			expectedStatus = http.StatusCreated
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL),
+					ghttp.VerifyJSONRepresenting(atc.SaveVersionRequest{
+						Version:  atc.Version{"ref": "abcdef"},
+						Metadata: []atc.MetadataField{{Name: "author", Value: "someone"}},
+					}),
+					ghttp.RespondWith(expectedStatus, nil),
+				),
+			)
+
+			created, found, clientErr = team.SaveResourceVersion(
+				"mypipeline",
+				"myresource",
+				atc.Version{"ref": "abcdef"},
+				[]atc.MetadataField{{Name: "author", Value: "someone"}},
+			)
+		})
+
+		Context("when the version is new", func() {
+			It("returns that it was created", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(created).To(BeTrue())
+			})
+		})
+
+		Context("when the version already exists", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusOK
+			})
+
+			It("returns that it was not created", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(created).To(BeFalse())
+			})
+		})
+
+		Context("when the resource does not exist", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusNotFound
+			})
+
+			It("returns false", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when saving the version fails", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusBadRequest
+			})
+
+			It("returns an error", func() {
+				Expect(clientErr).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -14,17 +14,18 @@ type Team interface {
 	RenameTeam(teamName, name string) (bool, error)
 	DestroyTeam(teamName string) error
 
-	Pipeline(name string) (atc.Pipeline, bool, error)
-	PipelineBuilds(pipelineName string, page Page) ([]atc.Build, Pagination, bool, error)
-	DeletePipeline(pipelineName string) (bool, error)
-	PausePipeline(pipelineName string) (bool, error)
-	UnpausePipeline(pipelineName string) (bool, error)
-	ExposePipeline(pipelineName string) (bool, error)
-	HidePipeline(pipelineName string) (bool, error)
-	RenamePipeline(pipelineName, name string) (bool, error)
+	Pipeline(pipelineRef atc.PipelineRef) (atc.Pipeline, bool, error)
+	PipelineBuilds(pipelineRef atc.PipelineRef, page Page) ([]atc.Build, Pagination, bool, error)
+	DeletePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	PausePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	UnpausePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	ArchivePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	ExposePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	HidePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	RenamePipeline(pipelineRef atc.PipelineRef, name string) (bool, error)
 	ListPipelines() ([]atc.Pipeline, error)
-	PipelineConfig(pipelineName string) (atc.Config, atc.RawConfig, string, bool, error)
-	CreateOrUpdatePipelineConfig(pipelineName string, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, atc.RawConfig, string, bool, error)
+	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
 
 	CreatePipelineBuild(pipelineName string, plan atc.Plan) (atc.Build, error)
 
@@ -46,9 +47,14 @@ type Team interface {
 	VersionedResourceTypes(pipelineName string) (atc.VersionedResourceTypes, bool, error)
 	ResourceVersions(pipelineName string, resourceName string, page Page) ([]atc.ResourceVersion, Pagination, bool, error)
 	CheckResource(pipelineName string, resourceName string, version atc.Version) (bool, error)
+	StartCheckResource(pipelineName string, resourceName string, version atc.Version) (*atc.Check, bool, error)
 	CheckResourceType(pipelineName string, resourceTypeName string, version atc.Version) (bool, error)
-	DisableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error)
-	EnableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error)
+	CheckResourceTypeRecursively(pipelineName string, resourceTypeName string, version atc.Version) (atc.CheckResourceTypeResponseBody, bool, error)
+	ResourceChecks(pipelineName string, resourceName string) ([]atc.Check, bool, error)
+	ResourceCheckEvents(pipelineName string, resourceName string, checkID int) (Events, error)
+	DisableResourceVersion(pipelineName string, resourceName string, resourceVersionID int, comment string) (bool, error)
+	EnableResourceVersion(pipelineName string, resourceName string, resourceVersionID int, comment string) (bool, error)
+	SaveResourceVersion(pipelineName string, resourceName string, version atc.Version, metadata []atc.MetadataField) (bool, bool, error)
 
 	BuildsWithVersionAsInput(pipelineName string, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
 	BuildsWithVersionAsOutput(pipelineName string, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
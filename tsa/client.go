@@ -46,6 +46,7 @@ func (err *HandshakeError) Error() string {
 const (
 	gardenForwardAddr       = "0.0.0.0:7777"
 	baggageclaimForwardAddr = "0.0.0.0:7788"
+	p2pForwardAddr          = "0.0.0.0:7789"
 )
 
 // Client is used to communicate with a pool of remote SSH gateways.
@@ -69,6 +70,11 @@ type RegisterOptions struct {
 	LocalBaggageclaimNetwork string
 	LocalBaggageclaimAddr    string
 
+	// The local network and address of the server streaming volumes between
+	// workers to forward through the SSH gateway, if any.
+	LocalP2PNetwork string
+	LocalP2PAddr    string
+
 	// Under normal circumstances, the connection is kept alive by continuously
 	// sending a keepalive request to the SSH gateway. When the context is
 	// canceled, the keepalive loop is stopped, and the connection will break
@@ -89,7 +95,7 @@ type RegisterOptions struct {
 }
 
 // Register invokes the 'forward-worker' command, proxying traffic through the
-// tunnel and to the configured Garden/Baggageclaim addresses. It will also
+// tunnel and to the configured Garden/Baggageclaim/P2P addresses. It will also
 // continuously keep the connection alive. The SSH gateway will continuously
 // heartbeat the worker.
 //
@@ -126,6 +132,20 @@ func (client *Client) Register(ctx context.Context, opts RegisterOptions) error
 
 	go proxyListenerTo(ctx, baggageclaimListener, opts.LocalBaggageclaimNetwork, opts.LocalBaggageclaimAddr)
 
+	command := "forward-worker --garden " + gardenForwardAddr + " --baggageclaim " + baggageclaimForwardAddr
+
+	if opts.LocalP2PAddr != "" {
+		p2pListener, err := sshClient.Listen("tcp", p2pForwardAddr)
+		if err != nil {
+			logger.Error("failed-to-listen-for-p2p", err)
+			return err
+		}
+
+		go proxyListenerTo(ctx, p2pListener, opts.LocalP2PNetwork, opts.LocalP2PAddr)
+
+		command += " --p2p " + p2pForwardAddr
+	}
+
 	eventsR, eventsW := io.Pipe()
 	defer eventsW.Close()
 
@@ -155,12 +175,7 @@ func (client *Client) Register(ctx context.Context, opts RegisterOptions) error
 		}
 	}()
 
-	err = client.run(
-		ctx,
-		sshClient,
-		"forward-worker --garden "+gardenForwardAddr+" --baggageclaim "+baggageclaimForwardAddr,
-		eventsW,
-	)
+	err = client.run(ctx, sshClient, command, eventsW)
 	if err != nil {
 		if ctx.Err() != nil && opts.DrainTimeout != 0 {
 			if _, ok := err.(*ssh.ExitMissingError); ok {
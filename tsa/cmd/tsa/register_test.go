@@ -21,6 +21,7 @@ import (
 	"github.com/concourse/concourse/tsa"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
 )
 
 type registration struct {
@@ -238,6 +239,39 @@ var _ = Describe("Register", func() {
 			Expect(fakeBackend.CreateCallCount()).To(Equal(1))
 		})
 
+		Context("when the p2p server is forwarded", func() {
+			var p2pServer *ghttp.Server
+
+			BeforeEach(func() {
+				p2pServer = ghttp.NewServer()
+				p2pServer.RouteToHandler("GET", "/some-path", ghttp.RespondWith(http.StatusOK, "some-response"))
+
+				opts.LocalP2PNetwork = "tcp"
+				opts.LocalP2PAddr = p2pServer.Addr()
+			})
+
+			AfterEach(func() {
+				p2pServer.Close()
+			})
+
+			It("registers a forwarded p2p URL", func() {
+				registration := <-registered
+
+				p2pURL, err := url.Parse(registration.worker.P2PURL)
+				Expect(err).NotTo(HaveOccurred())
+				host, port, err := net.SplitHostPort(p2pURL.Host)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(host).To(Equal(forwardHost))
+				Expect(port).NotTo(Equal("7789")) // should NOT respect bind addr
+
+				response, err := http.Get(registration.worker.P2PURL + "/some-path")
+				Expect(err).NotTo(HaveOccurred())
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+		})
+
 		It("fires the registered and heartbeated callbacks", func() {
 			<-registerDone
 			<-heartbeatEvent
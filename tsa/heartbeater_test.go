@@ -79,6 +79,11 @@ var _ = Describe("Heartbeater", func() {
 			ResourceTypes: resourceTypes,
 			Platform:      "some-platform",
 			Tags:          []string{"some", "tags"},
+			Capacity: atc.WorkerCapacity{
+				MaxContainers: 100,
+				CPU:           4,
+				Memory:        1024,
+			},
 		}
 
 		expectedWorker = worker
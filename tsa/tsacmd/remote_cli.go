@@ -28,6 +28,7 @@ type forwardWorkerRequest struct {
 
 	gardenAddr       string
 	baggageclaimAddr string
+	p2pAddr          string
 }
 
 func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, channel ssh.Channel) error {
@@ -44,7 +45,7 @@ func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, cha
 	}
 
 	forwards := map[string]ForwardedTCPIP{}
-	for i := 0; i < 2; i++ {
+	for i := 0; i < req.expectedForwards(); i++ {
 		select {
 		case forwarded := <-state.ForwardedTCPIPs:
 			logger.Info("forwarded-tcpip", lager.Data{
@@ -72,6 +73,15 @@ func (req forwardWorkerRequest) Handle(ctx context.Context, state ConnState, cha
 	worker.GardenAddr = fmt.Sprintf("%s:%d", req.server.forwardHost, gardenForward.BoundPort)
 	worker.BaggageclaimURL = fmt.Sprintf("http://%s:%d", req.server.forwardHost, baggageclaimForward.BoundPort)
 
+	if req.p2pAddr != "" {
+		p2pForward, found := forwards[req.p2pAddr]
+		if !found {
+			return fmt.Errorf("p2p address (%s) not forwarded", req.p2pAddr)
+		}
+
+		worker.P2PURL = fmt.Sprintf("http://%s:%d", req.server.forwardHost, p2pForward.BoundPort)
+	}
+
 	heartbeater := tsa.NewHeartbeater(
 		clock.NewClock(),
 		req.server.heartbeatInterval,
@@ -135,6 +145,10 @@ func (r forwardWorkerRequest) expectedForwards() int {
 		expected++
 	}
 
+	if r.p2pAddr != "" {
+		expected++
+	}
+
 	return expected
 }
 
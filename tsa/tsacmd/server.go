@@ -17,7 +17,8 @@ import (
 	"golang.org/x/crypto/ssh"
 )
 
-const maxForwards = 2
+// garden, baggageclaim and p2p
+const maxForwards = 3
 
 type server struct {
 	logger            lager.Logger
@@ -499,6 +500,7 @@ func (server *server) parseRequest(cli string) (request, string, error) {
 
 		var garden = fs.String("garden", "", "garden address to forward")
 		var baggageclaim = fs.String("baggageclaim", "", "baggageclaim address to forward")
+		var p2p = fs.String("p2p", "", "p2p address to forward")
 
 		err := fs.Parse(args)
 		if err != nil {
@@ -510,6 +512,7 @@ func (server *server) parseRequest(cli string) (request, string, error) {
 
 			gardenAddr:       *garden,
 			baggageclaimAddr: *baggageclaim,
+			p2pAddr:          *p2p,
 		}
 	case tsa.LandWorker:
 		req = landWorkerRequest{
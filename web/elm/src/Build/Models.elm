@@ -69,6 +69,8 @@ type StepHeaderType
     = StepHeaderPut
     | StepHeaderGet Bool
     | StepHeaderTask
+    | StepHeaderSetPipeline
+    | StepHeaderLoadVar
 
 
 type Hoverable
@@ -115,6 +117,8 @@ type StepTree
     = Task Step
     | Get Step
     | Put Step
+    | SetPipeline Step
+    | LoadVar Step
     | Aggregate (Array StepTree)
     | Do (Array StepTree)
     | OnSuccess HookedStep
@@ -124,6 +128,8 @@ type StepTree
     | Try StepTree
     | Retry StepID Int TabFocus (Array StepTree)
     | Timeout StepTree
+    | Across StepTree
+    | If StepTree
 
 
 type alias StepFocus =
@@ -154,6 +160,7 @@ type StepState
     | StepStateSucceeded
     | StepStateFailed
     | StepStateErrored
+    | StepStateSkipped
 
 
 type alias Version =
@@ -181,9 +188,16 @@ type BuildEvent
     = BuildStatus Concourse.BuildStatus Date
     | Initialize Origin
     | StartTask Origin
+    | StartStep Origin
+    | FinishStep Origin Bool
     | FinishTask Origin Int
     | FinishGet Origin Int Concourse.Version Concourse.Metadata
     | FinishPut Origin Int Concourse.Version Concourse.Metadata
+    | StartCombination Origin StepID (Dict String String)
+    | FinishCombination Origin
+    | RetryAttempt Origin StepID Int String (Maybe String)
+    | Skipped Origin
+    | WaitingForWorker Origin
     | Log Origin String (Maybe Date)
     | Error Origin String
     | BuildError String
@@ -214,6 +214,18 @@ handleEvent event model =
             , OutNoop
             )
 
+        StartStep origin ->
+            ( updateStep origin.id setRunning model
+            , []
+            , OutNoop
+            )
+
+        FinishStep origin succeeded ->
+            ( updateStep origin.id (finishStepWith succeeded) model
+            , []
+            , OutNoop
+            )
+
         FinishTask origin exitStatus ->
             ( updateStep origin.id (finishStep exitStatus) model
             , []
@@ -232,6 +244,33 @@ handleEvent event model =
             , OutNoop
             )
 
+        StartCombination origin planID vars ->
+            ( updateStep planID (appendStepLog (combinationHeader vars) Nothing) model
+            , []
+            , OutNoop
+            )
+
+        FinishCombination origin ->
+            ( model, [], OutNoop )
+
+        RetryAttempt origin planID attempt cause delay ->
+            ( updateStep planID (appendStepLog (retryHeader attempt cause delay) Nothing) model
+            , []
+            , OutNoop
+            )
+
+        Skipped origin ->
+            ( updateStep origin.id (StepTree.mapAll (\step -> { step | state = StepStateSkipped })) model
+            , []
+            , OutNoop
+            )
+
+        WaitingForWorker origin ->
+            ( updateStep origin.id (appendStepLog "waiting for worker as every compatible worker is full\n" Nothing) model
+            , []
+            , OutNoop
+            )
+
         BuildStatus status date ->
             case model.steps of
                 Just st ->
@@ -268,6 +307,32 @@ updateStep id update model =
     { model | steps = Maybe.map (StepTree.updateAt id update) model.steps }
 
 
+combinationHeader : Dict String String -> String
+combinationHeader vars =
+    let
+        formatted =
+            vars
+                |> Dict.toList
+                |> List.map (\( name, value ) -> name ++ ": " ++ value)
+                |> String.join ", "
+    in
+    "across " ++ formatted ++ "\n"
+
+
+retryHeader : Int -> String -> Maybe String -> String
+retryHeader attempt cause delay =
+    let
+        waiting =
+            case delay of
+                Just d ->
+                    " after waiting " ++ d
+
+                Nothing ->
+                    ""
+    in
+    "attempt " ++ toString attempt ++ ", retrying due to " ++ cause ++ waiting ++ "\n"
+
+
 setRunning : StepTree -> StepTree
 setRunning =
     setStepState StepStateRunning
@@ -324,6 +389,15 @@ finishStep exitStatus tree =
     setStepState stepState tree
 
 
+finishStepWith : Bool -> StepTree -> StepTree
+finishStepWith succeeded =
+    if succeeded then
+        setStepState StepStateSucceeded
+
+    else
+        setStepState StepStateFailed
+
+
 setResourceInfo : Concourse.Version -> Concourse.Metadata -> StepTree -> StepTree
 setResourceInfo version metadata tree =
     StepTree.map (\step -> { step | version = Just version, metadata = metadata }) tree
@@ -3,6 +3,7 @@ module Build.StepTree exposing
     , finished
     , init
     , map
+    , mapAll
     , setHighlight
     , switchTab
     , toggleStep
@@ -64,6 +65,12 @@ init hl resources plan =
         Concourse.BuildStepPut name ->
             initBottom hl Put plan.id name
 
+        Concourse.BuildStepSetPipeline name ->
+            initBottom hl SetPipeline plan.id name
+
+        Concourse.BuildStepLoadVar name ->
+            initBottom hl LoadVar plan.id name
+
         Concourse.BuildStepAggregate plans ->
             initMultiStep hl resources plan.id Aggregate plans
 
@@ -91,6 +98,12 @@ init hl resources plan =
         Concourse.BuildStepTimeout plan ->
             initWrappedStep hl resources Timeout plan
 
+        Concourse.BuildStepAcross plan ->
+            initWrappedStep hl resources Across plan
+
+        Concourse.BuildStepIf subPlan ->
+            initIfStep hl resources plan.id subPlan
+
 
 initMultiStep :
     Highlight
@@ -184,6 +197,23 @@ initWrappedStep hl resources create plan =
     }
 
 
+{-| If steps can be focused themselves, so that the steps within them can be
+marked as skipped.
+-}
+initIfStep :
+    Highlight
+    -> Concourse.BuildResources
+    -> StepID
+    -> Concourse.BuildPlan
+    -> StepTreeModel
+initIfStep hl resources planId plan =
+    let
+        model =
+            initWrappedStep hl resources If plan
+    in
+    { model | foci = Dict.insert planId { update = identity } model.foci }
+
+
 initHookedStep :
     Highlight
     -> Concourse.BuildResources
@@ -236,6 +266,12 @@ treeIsActive tree =
         Timeout tree ->
             treeIsActive tree
 
+        Across tree ->
+            treeIsActive tree
+
+        If tree ->
+            treeIsActive tree
+
         Retry _ _ _ trees ->
             List.any treeIsActive (Array.toList trees)
 
@@ -248,6 +284,12 @@ treeIsActive tree =
         Put step ->
             stepIsActive step
 
+        SetPipeline step ->
+            stepIsActive step
+
+        LoadVar step ->
+            stepIsActive step
+
 
 stepIsActive : Step -> Bool
 stepIsActive =
@@ -377,11 +419,30 @@ focusRetry tab tree =
             Debug.crash "impossible (non-retry tab focus)"
 
 
+{-| Steps run by an across step are given IDs of the form "<id>/<index>",
+which are not part of the build plan. Their events are folded into the step
+they were templated from.
+-}
+acrossTemplateID : StepID -> Maybe StepID
+acrossTemplateID id =
+    case List.reverse (String.split "/" id) of
+        _ :: parent :: rest ->
+            Just (String.join "/" (List.reverse (parent :: rest)))
+
+        _ ->
+            Nothing
+
+
 updateAt : StepID -> (StepTree -> StepTree) -> StepTreeModel -> StepTreeModel
 updateAt id update root =
     case Dict.get id root.foci of
         Nothing ->
-            Debug.crash ("updateAt: id " ++ id ++ " not found")
+            case acrossTemplateID id of
+                Just templateID ->
+                    updateAt templateID update root
+
+                Nothing ->
+                    Debug.crash ("updateAt: id " ++ id ++ " not found")
 
         Just focus ->
             { root | tree = focus.update update root.tree }
@@ -399,10 +460,63 @@ map f tree =
         Put step ->
             Put (f step)
 
+        SetPipeline step ->
+            SetPipeline (f step)
+
+        LoadVar step ->
+            LoadVar (f step)
+
         _ ->
             tree
 
 
+{-| Applies the function to every step within the tree, however deeply nested.
+-}
+mapAll : (Step -> Step) -> StepTree -> StepTree
+mapAll f tree =
+    case tree of
+        Aggregate trees ->
+            Aggregate (Array.map (mapAll f) trees)
+
+        Do trees ->
+            Do (Array.map (mapAll f) trees)
+
+        OnSuccess hookedStep ->
+            OnSuccess (mapAllHooked f hookedStep)
+
+        OnFailure hookedStep ->
+            OnFailure (mapAllHooked f hookedStep)
+
+        OnAbort hookedStep ->
+            OnAbort (mapAllHooked f hookedStep)
+
+        Ensure hookedStep ->
+            Ensure (mapAllHooked f hookedStep)
+
+        Try step ->
+            Try (mapAll f step)
+
+        Retry id tab focus trees ->
+            Retry id tab focus (Array.map (mapAll f) trees)
+
+        Timeout step ->
+            Timeout (mapAll f step)
+
+        Across step ->
+            Across (mapAll f step)
+
+        If step ->
+            If (mapAll f step)
+
+        _ ->
+            map f tree
+
+
+mapAllHooked : (Step -> Step) -> HookedStep -> HookedStep
+mapAllHooked f { step, hook } =
+    { step = mapAll f step, hook = mapAll f hook }
+
+
 wrapMultiStep : Int -> Dict StepID StepFocus -> Dict StepID StepFocus
 wrapMultiStep i =
     Dict.map (\_ subFocus -> { update = \upd tree -> setMultiStepIndex i (subFocus.update upd) tree })
@@ -439,6 +553,12 @@ updateStep update tree =
         Timeout step ->
             Timeout (update step)
 
+        Across step ->
+            Across (update step)
+
+        If step ->
+            If (update step)
+
         _ ->
             Debug.crash "impossible"
 
@@ -529,6 +649,12 @@ viewTree model tree =
         Put step ->
             viewStep model step StepHeaderPut
 
+        SetPipeline step ->
+            viewStep model step StepHeaderSetPipeline
+
+        LoadVar step ->
+            viewStep model step StepHeaderLoadVar
+
         Try step ->
             viewTree model step
 
@@ -547,6 +673,12 @@ viewTree model tree =
         Timeout step ->
             viewTree model step
 
+        Across step ->
+            viewTree model step
+
+        If step ->
+            viewTree model step
+
         Aggregate steps ->
             Html.div [ class "aggregate" ]
                 (Array.toList <| Array.map (viewSeq model) steps)
@@ -595,8 +727,8 @@ viewHooked name model step hook =
 
 
 isActive : StepState -> Bool
-isActive =
-    (/=) StepStatePending
+isActive state =
+    state /= StepStatePending && state /= StepStateSkipped
 
 
 autoExpanded : StepState -> Bool
@@ -765,6 +897,13 @@ viewStepState state buildFinished =
                 ]
                 []
 
+        StepStateSkipped ->
+            Html.i
+                [ attribute "data-step-state" "skipped"
+                , class "right fa fa-fw fa-ban"
+                ]
+                []
+
 
 viewStepHeaderIcon : StepHeaderType -> Bool -> StepID -> Html Msg
 viewStepHeaderIcon headerType tooltip id =
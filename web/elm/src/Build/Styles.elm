@@ -121,6 +121,12 @@ stepHeaderIcon icon =
 
                 StepHeaderTask ->
                     "terminal"
+
+                StepHeaderSetPipeline ->
+                    "breadcrumb-pipeline"
+
+                StepHeaderLoadVar ->
+                    "cogs"
     in
     [ ( "height", "28px" )
     , ( "width", "28px" )
@@ -17,6 +17,7 @@ module Concourse exposing
     , Cause
     , HookedPlan
     , Info
+    , InstanceVars
     , Job
     , JobBuildIdentifier
     , JobIdentifier
@@ -62,6 +63,7 @@ import Date exposing (Date)
 import Dict exposing (Dict)
 import Json.Decode
 import Json.Decode.Extra exposing ((|:))
+import Json.Encode
 
 
 
@@ -329,6 +331,8 @@ type BuildStep
     = BuildStepTask StepName
     | BuildStepGet StepName (Maybe Version)
     | BuildStepPut StepName
+    | BuildStepSetPipeline StepName
+    | BuildStepLoadVar StepName
     | BuildStepAggregate (Array BuildPlan)
     | BuildStepDo (Array BuildPlan)
     | BuildStepOnSuccess HookedPlan
@@ -338,6 +342,8 @@ type BuildStep
     | BuildStepTry BuildPlan
     | BuildStepRetry (Array BuildPlan)
     | BuildStepTimeout BuildPlan
+    | BuildStepAcross BuildPlan
+    | BuildStepIf BuildPlan
 
 
 type alias HookedPlan =
@@ -361,8 +367,11 @@ decodeBuildPlan_ =
             [ Json.Decode.field "task" <| lazy (\_ -> decodeBuildStepTask)
             , Json.Decode.field "get" <| lazy (\_ -> decodeBuildStepGet)
             , Json.Decode.field "put" <| lazy (\_ -> decodeBuildStepPut)
+            , Json.Decode.field "set_pipeline" <| lazy (\_ -> decodeBuildStepSetPipeline)
+            , Json.Decode.field "load_var" <| lazy (\_ -> decodeBuildStepLoadVar)
             , Json.Decode.field "dependent_get" <| lazy (\_ -> decodeBuildStepGet)
             , Json.Decode.field "aggregate" <| lazy (\_ -> decodeBuildStepAggregate)
+            , Json.Decode.field "in_parallel" <| lazy (\_ -> decodeBuildStepInParallel)
             , Json.Decode.field "do" <| lazy (\_ -> decodeBuildStepDo)
             , Json.Decode.field "on_success" <| lazy (\_ -> decodeBuildStepOnSuccess)
             , Json.Decode.field "on_failure" <| lazy (\_ -> decodeBuildStepOnFailure)
@@ -371,6 +380,8 @@ decodeBuildPlan_ =
             , Json.Decode.field "try" <| lazy (\_ -> decodeBuildStepTry)
             , Json.Decode.field "retry" <| lazy (\_ -> decodeBuildStepRetry)
             , Json.Decode.field "timeout" <| lazy (\_ -> decodeBuildStepTimeout)
+            , Json.Decode.field "across" <| lazy (\_ -> decodeBuildStepAcross)
+            , Json.Decode.field "if" <| lazy (\_ -> decodeBuildStepIf)
             ]
 
 
@@ -393,12 +404,30 @@ decodeBuildStepPut =
         |: Json.Decode.field "name" Json.Decode.string
 
 
+decodeBuildStepSetPipeline : Json.Decode.Decoder BuildStep
+decodeBuildStepSetPipeline =
+    Json.Decode.succeed BuildStepSetPipeline
+        |: Json.Decode.field "name" Json.Decode.string
+
+
+decodeBuildStepLoadVar : Json.Decode.Decoder BuildStep
+decodeBuildStepLoadVar =
+    Json.Decode.succeed BuildStepLoadVar
+        |: Json.Decode.field "name" Json.Decode.string
+
+
 decodeBuildStepAggregate : Json.Decode.Decoder BuildStep
 decodeBuildStepAggregate =
     Json.Decode.succeed BuildStepAggregate
         |: Json.Decode.array (lazy (\_ -> decodeBuildPlan_))
 
 
+decodeBuildStepInParallel : Json.Decode.Decoder BuildStep
+decodeBuildStepInParallel =
+    Json.Decode.succeed BuildStepAggregate
+        |: Json.Decode.field "steps" (Json.Decode.array (lazy (\_ -> decodeBuildPlan_)))
+
+
 decodeBuildStepDo : Json.Decode.Decoder BuildStep
 decodeBuildStepDo =
     Json.Decode.succeed BuildStepDo
@@ -455,6 +484,18 @@ decodeBuildStepTimeout =
         |: (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan_))
 
 
+decodeBuildStepAcross : Json.Decode.Decoder BuildStep
+decodeBuildStepAcross =
+    Json.Decode.succeed BuildStepAcross
+        |: (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan_))
+
+
+decodeBuildStepIf : Json.Decode.Decoder BuildStep
+decodeBuildStepIf =
+    Json.Decode.succeed BuildStepIf
+        |: (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan_))
+
+
 
 -- Info
 
@@ -561,9 +602,14 @@ type alias PipelineIdentifier =
     }
 
 
+type alias InstanceVars =
+    List ( String, String )
+
+
 type alias Pipeline =
     { id : Int
     , name : PipelineName
+    , instanceVars : InstanceVars
     , paused : Bool
     , public : Bool
     , teamName : TeamName
@@ -583,12 +629,24 @@ decodePipeline =
     Json.Decode.succeed Pipeline
         |: Json.Decode.field "id" Json.Decode.int
         |: Json.Decode.field "name" Json.Decode.string
+        |: (defaultTo [] <| Json.Decode.field "instance_vars" decodeInstanceVars)
         |: Json.Decode.field "paused" Json.Decode.bool
         |: Json.Decode.field "public" Json.Decode.bool
         |: Json.Decode.field "team_name" Json.Decode.string
         |: (defaultTo [] <| Json.Decode.field "groups" (Json.Decode.list decodePipelineGroup))
 
 
+decodeInstanceVars : Json.Decode.Decoder InstanceVars
+decodeInstanceVars =
+    Json.Decode.keyValuePairs
+        (Json.Decode.oneOf
+            [ Json.Decode.string
+            , Json.Decode.map (Json.Encode.encode 0) Json.Decode.value
+            ]
+        )
+        |> Json.Decode.map (List.sortBy Tuple.first)
+
+
 decodePipelineGroup : Json.Decode.Decoder PipelineGroup
 decodePipelineGroup =
     Json.Decode.succeed PipelineGroup
@@ -606,6 +664,7 @@ type alias Resource =
     , pipelineName : String
     , name : String
     , failingToCheck : Bool
+    , rateLimited : Bool
     , checkError : String
     , checkSetupError : String
     , lastChecked : Maybe Date
@@ -645,6 +704,7 @@ decodeResource =
         |: Json.Decode.field "pipeline_name" Json.Decode.string
         |: Json.Decode.field "name" Json.Decode.string
         |: (defaultTo False <| Json.Decode.field "failing_to_check" Json.Decode.bool)
+        |: (defaultTo False <| Json.Decode.field "rate_limited" Json.Decode.bool)
         |: (defaultTo "" <| Json.Decode.field "check_error" Json.Decode.string)
         |: (defaultTo "" <| Json.Decode.field "check_setup_error" Json.Decode.string)
         |: Json.Decode.maybe (Json.Decode.field "last_checked" (Json.Decode.map dateFromSeconds Json.Decode.float))
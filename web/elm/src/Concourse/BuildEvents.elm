@@ -17,6 +17,7 @@ import Date exposing (Date)
 import Dict exposing (Dict)
 import EventSource.LowLevel as ES
 import Json.Decode
+import Json.Encode
 
 
 decodeBuildEventEnvelope : Json.Decode.Decoder BuildEvent
@@ -57,6 +58,19 @@ decodeBuildEvent eventType =
                 "data"
                 (Json.Decode.map Initialize (Json.Decode.field "origin" decodeOrigin))
 
+        "start" ->
+            Json.Decode.field
+                "data"
+                (Json.Decode.map StartStep (Json.Decode.field "origin" decodeOrigin))
+
+        "finish" ->
+            Json.Decode.field
+                "data"
+                (Json.Decode.map2 FinishStep
+                    (Json.Decode.field "origin" decodeOrigin)
+                    (Json.Decode.field "succeeded" Json.Decode.bool)
+                )
+
         "start-task" ->
             Json.Decode.field
                 "data"
@@ -76,6 +90,41 @@ decodeBuildEvent eventType =
         "finish-put" ->
             Json.Decode.field "data" (decodeFinishResource FinishPut)
 
+        "start-combination" ->
+            Json.Decode.field
+                "data"
+                (Json.Decode.map3 StartCombination
+                    (Json.Decode.field "origin" decodeOrigin)
+                    (Json.Decode.field "plan_id" Json.Decode.string)
+                    (Json.Decode.field "vars" <| Json.Decode.dict decodeCombinationValue)
+                )
+
+        "finish-combination" ->
+            Json.Decode.field
+                "data"
+                (Json.Decode.map FinishCombination (Json.Decode.field "origin" decodeOrigin))
+
+        "retry" ->
+            Json.Decode.field
+                "data"
+                (Json.Decode.map5 RetryAttempt
+                    (Json.Decode.field "origin" decodeOrigin)
+                    (Json.Decode.field "plan_id" Json.Decode.string)
+                    (Json.Decode.field "attempt" Json.Decode.int)
+                    (Json.Decode.field "cause" Json.Decode.string)
+                    (Json.Decode.maybe <| Json.Decode.field "delay" Json.Decode.string)
+                )
+
+        "skipped" ->
+            Json.Decode.field
+                "data"
+                (Json.Decode.map Skipped (Json.Decode.field "origin" decodeOrigin))
+
+        "waiting-for-worker" ->
+            Json.Decode.field
+                "data"
+                (Json.Decode.map WaitingForWorker (Json.Decode.field "origin" decodeOrigin))
+
         unknown ->
             Json.Decode.fail ("unknown event type: " ++ unknown)
 
@@ -145,3 +194,11 @@ decodeOrigin =
     Json.Decode.map2 Origin
         (Json.Decode.map (Maybe.withDefault "") << Json.Decode.maybe <| Json.Decode.field "source" Json.Decode.string)
         (Json.Decode.field "id" Json.Decode.string)
+
+
+decodeCombinationValue : Json.Decode.Decoder String
+decodeCombinationValue =
+    Json.Decode.oneOf
+        [ Json.Decode.string
+        , Json.Decode.map (Json.Encode.encode 0) Json.Decode.value
+        ]
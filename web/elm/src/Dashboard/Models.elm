@@ -7,6 +7,7 @@ import Concourse.PipelineStatus as PipelineStatus
 type alias Pipeline =
     { id : Int
     , name : String
+    , instanceVars : Concourse.InstanceVars
     , teamName : String
     , public : Bool
     , jobs : List Concourse.Job
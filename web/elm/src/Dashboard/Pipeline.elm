@@ -68,7 +68,13 @@ hdPipelineView { pipeline, pipelineRunningKeyframes } =
             [ style <| Styles.pipelineCardBodyHd
             , class "dashboardhd-pipeline-name"
             ]
-            [ Html.text pipeline.name ]
+            [ Html.text <|
+                if List.isEmpty pipeline.instanceVars then
+                    pipeline.name
+
+                else
+                    pipeline.name ++ "/" ++ instanceVarsText pipeline
+            ]
         ]
             ++ (if pipeline.resourceError then
                     [ Html.div [ style Styles.resourceErrorTriangle ] [] ]
@@ -118,6 +124,7 @@ headerView pipeline =
                 , style Styles.pipelineName
                 ]
                 [ Html.text pipeline.name ]
+            , instanceVarsView pipeline
             , Html.div
                 [ classList
                     [ ( "dashboard-resource-error", pipeline.resourceError )
@@ -128,6 +135,26 @@ headerView pipeline =
         ]
 
 
+instanceVarsView : Pipeline -> Html Msg
+instanceVarsView pipeline =
+    if List.isEmpty pipeline.instanceVars then
+        Html.text ""
+
+    else
+        Html.div
+            [ class "dashboard-pipeline-instance-vars"
+            , style Styles.pipelineInstanceVars
+            ]
+            [ Html.text <| instanceVarsText pipeline ]
+
+
+instanceVarsText : Pipeline -> String
+instanceVarsText pipeline =
+    pipeline.instanceVars
+        |> List.map (\( key, value ) -> key ++ ":" ++ value)
+        |> String.join ","
+
+
 bodyView : Pipeline -> Html Msg
 bodyView pipeline =
     Html.div
@@ -24,6 +24,7 @@ module Dashboard.Styles exposing
     , pipelineCardHd
     , pipelineCardHeader
     , pipelineCardTransitionAge
+    , pipelineInstanceVars
     , pipelineName
     , pipelineStatusIcon
     , previewPlaceholder
@@ -138,6 +139,16 @@ pipelineName =
     ]
 
 
+pipelineInstanceVars : List ( String, String )
+pipelineInstanceVars =
+    pipelineName
+        ++ [ ( "font-size", "0.6em" )
+           , ( "letter-spacing", "normal" )
+           , ( "padding-top", "4px" )
+           , ( "color", Colors.bottomBarText )
+           ]
+
+
 cardBody : List ( String, String )
 cardBody =
     [ ( "width", "200px" )
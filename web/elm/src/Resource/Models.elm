@@ -40,6 +40,7 @@ type CheckStatus
     = CheckingSuccessfully
     | CurrentlyChecking
     | FailingToCheck
+    | RateLimited
 
 
 type alias Model =
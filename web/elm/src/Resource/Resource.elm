@@ -231,6 +231,9 @@ handleCallbackWithoutTopBar action model =
                     if resource.failingToCheck then
                         Models.FailingToCheck
 
+                    else if resource.rateLimited then
+                        Models.RateLimited
+
                     else
                         Models.CheckingSuccessfully
                 , checkError = resource.checkError
@@ -973,6 +976,9 @@ checkSection ({ checkStatus, checkSetupError, checkError } as model) =
                 Models.CheckingSuccessfully ->
                     "checking successfully"
 
+                Models.RateLimited ->
+                    "check rate limited"
+
         stepBody =
             if failingToCheck then
                 if not (String.isEmpty checkSetupError) then
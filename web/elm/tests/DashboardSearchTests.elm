@@ -112,6 +112,7 @@ all =
                                 [ { id = 0
                                   , name = "pipeline"
                                   , paused = False
+                                  , instanceVars = []
                                   , public = True
                                   , teamName = "team"
                                   , groups = []
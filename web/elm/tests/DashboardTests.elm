@@ -1490,6 +1490,7 @@ all =
                                             [ { id = 0
                                               , name = "pipeline"
                                               , paused = False
+                                              , instanceVars = []
                                               , public = True
                                               , teamName = "team"
                                               , groups = []
@@ -1501,6 +1502,7 @@ all =
                                               , pipelineName = "pipeline"
                                               , name = "resource"
                                               , failingToCheck = True
+                                              , rateLimited = False
                                               , checkError = ""
                                               , checkSetupError = ""
                                               , lastChecked = Nothing
@@ -2071,6 +2073,7 @@ all =
                                     { id = 0
                                     , name = "pipeline"
                                     , teamName = "team"
+                                    , instanceVars = []
                                     , public = True
                                     , jobs = []
                                     , resourceError = False
@@ -2127,6 +2130,7 @@ all =
                                     { id = 0
                                     , name = "pipeline"
                                     , teamName = "team"
+                                    , instanceVars = []
                                     , public = True
                                     , jobs = []
                                     , resourceError = False
@@ -2877,6 +2881,7 @@ givenPipelineWithJob user =
         [ { id = 0
           , name = "pipeline"
           , paused = False
+          , instanceVars = []
           , public = True
           , teamName = "team"
           , groups = []
@@ -2921,6 +2926,7 @@ oneTeamOnePipelinePaused teamName user =
         [ { id = 0
           , name = "pipeline"
           , paused = True
+          , instanceVars = []
           , public = True
           , teamName = teamName
           , groups = []
@@ -2940,6 +2946,7 @@ oneTeamOnePipelineNonPublic teamName user =
         [ { id = 0
           , name = "pipeline"
           , paused = False
+          , instanceVars = []
           , public = False
           , teamName = teamName
           , groups = []
@@ -2962,6 +2969,7 @@ onePipeline teamName =
     { id = 0
     , name = "pipeline"
     , paused = False
+    , instanceVars = []
     , public = True
     , teamName = teamName
     , groups = []
@@ -2973,6 +2981,7 @@ onePipelinePaused teamName =
     { id = 0
     , name = "pipeline"
     , paused = True
+    , instanceVars = []
     , public = True
     , teamName = teamName
     , groups = []
@@ -2992,6 +3001,7 @@ apiData pipelines user =
                                 { id = i
                                 , name = p
                                 , paused = False
+                                , instanceVars = []
                                 , public = True
                                 , teamName = teamName
                                 , groups = []
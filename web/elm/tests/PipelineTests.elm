@@ -85,6 +85,7 @@ all =
                                     { id = 0
                                     , name = "pipeline"
                                     , paused = False
+                                    , instanceVars = []
                                     , public = True
                                     , teamName = "team"
                                     , groups = groups
@@ -811,6 +812,7 @@ all =
                                     { id = 0
                                     , name = "pipeline"
                                     , paused = True
+                                    , instanceVars = []
                                     , public = True
                                     , teamName = "team"
                                     , groups = []
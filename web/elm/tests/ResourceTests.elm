@@ -2945,6 +2945,7 @@ all =
                                     , pipelineName = pipelineName
                                     , name = resourceName
                                     , failingToCheck = True
+                                    , rateLimited = False
                                     , checkError = "some error"
                                     , checkSetupError = ""
                                     , lastChecked = Nothing
@@ -2966,6 +2967,29 @@ all =
                                    , containing [ text "some error" ]
                                    ]
                             )
+            , test "rate limited check says so" <|
+                \_ ->
+                    init
+                        |> handleCallback
+                            (Callback.ResourceFetched <|
+                                Ok
+                                    { teamName = teamName
+                                    , pipelineName = pipelineName
+                                    , name = resourceName
+                                    , failingToCheck = False
+                                    , rateLimited = True
+                                    , checkError = ""
+                                    , checkSetupError = ""
+                                    , lastChecked = Nothing
+                                    , pinnedVersion = Nothing
+                                    , pinnedInConfig = False
+                                    , pinComment = Nothing
+                                    }
+                            )
+                        |> Tuple.first
+                        |> queryView
+                        |> Query.find [ class "resource-check-status" ]
+                        |> Query.has [ containing [ text "check rate limited" ] ]
             ]
         ]
 
@@ -3049,6 +3073,7 @@ givenResourcePinnedStatically =
                 , pipelineName = pipelineName
                 , name = resourceName
                 , failingToCheck = False
+                , rateLimited = False
                 , checkError = ""
                 , checkSetupError = ""
                 , lastChecked = Nothing
@@ -3069,6 +3094,7 @@ givenResourcePinnedDynamically =
                 , pipelineName = pipelineName
                 , name = resourceName
                 , failingToCheck = False
+                , rateLimited = False
                 , checkError = ""
                 , checkSetupError = ""
                 , lastChecked = Nothing
@@ -3089,6 +3115,7 @@ givenResourcePinnedWithComment =
                 , pipelineName = pipelineName
                 , name = resourceName
                 , failingToCheck = False
+                , rateLimited = False
                 , checkError = ""
                 , checkSetupError = ""
                 , lastChecked = Nothing
@@ -3110,6 +3137,7 @@ givenResourceIsNotPinned =
                 , pipelineName = pipelineName
                 , name = resourceName
                 , failingToCheck = False
+                , rateLimited = False
                 , checkError = ""
                 , checkSetupError = ""
                 , lastChecked = Nothing
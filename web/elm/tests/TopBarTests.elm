@@ -1015,6 +1015,7 @@ onePipeline teamName =
     { id = 0
     , name = "pipeline"
     , paused = False
+    , instanceVars = []
     , public = True
     , teamName = teamName
     , groups = []
@@ -23,6 +23,9 @@ type Beacon struct {
 
 	LocalBaggageclaimNetwork string
 	LocalBaggageclaimAddr    string
+
+	LocalP2PNetwork string
+	LocalP2PAddr    string
 }
 
 // total number of active registrations; all but one are "live", the rest
@@ -120,6 +123,9 @@ func (beacon *Beacon) registerWorker(
 		LocalBaggageclaimNetwork: beacon.LocalBaggageclaimNetwork,
 		LocalBaggageclaimAddr:    beacon.LocalBaggageclaimAddr,
 
+		LocalP2PNetwork: beacon.LocalP2PNetwork,
+		LocalP2PAddr:    beacon.LocalP2PAddr,
+
 		DrainTimeout: beacon.DrainTimeout,
 
 		RegisteredFunc: func() {
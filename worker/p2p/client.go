@@ -0,0 +1,257 @@
+package p2p
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/tedsuo/rata"
+)
+
+//go:generate counterfeiter . Client
+
+// Client is used by the ATC to stream volumes between the p2p servers of two
+// workers.
+type Client interface {
+	// StreamOutURL returns a signed, short-lived URL from which other workers
+	// can pull the contents of the volume at the path, along with the encoding
+	// the server chose for them.
+	StreamOutURL(logger lager.Logger, handle string, path string) (string, Encoding, error)
+
+	// StreamIn pulls the contents from the URL into the volume at the path,
+	// returning the number of bytes pulled.
+	StreamIn(logger lager.Logger, handle string, path string, url string, encoding Encoding) (int64, error)
+
+	// StreamOut pulls the contents from a URL handed out by StreamOutURL, for
+	// when the worker pulling from it cannot reach it.
+	StreamOut(logger lager.Logger, url string) (io.ReadCloser, error)
+
+	// Upload streams the contents, encoded with the encoding, into the volume
+	// at the path, returning the number of bytes uploaded.
+	Upload(logger lager.Logger, handle string, path string, encoding Encoding, contents io.Reader) (int64, error)
+
+	// Clear removes the contents of the volume at the path, so that streaming
+	// into it can be retried after an attempt which failed part way through.
+	Clear(logger lager.Logger, handle string, path string) error
+}
+
+type client struct {
+	apiURL           string
+	requestGenerator *rata.RequestGenerator
+	httpClient       *http.Client
+	clock            clock.Clock
+
+	key        []byte
+	requestTTL time.Duration
+
+	encodings []Encoding
+}
+
+// NewClient returns a client which signs its requests with the key shared
+// with the workers, and offers the preferred encoding, followed by the other
+// supported encodings, when asking for a URL.
+func NewClient(
+	apiURL string,
+	httpClient *http.Client,
+	clock clock.Clock,
+	key []byte,
+	preferredEncoding Encoding,
+) Client {
+	return &client{
+		apiURL:           apiURL,
+		requestGenerator: rata.NewRequestGenerator(apiURL, Routes),
+		httpClient:       httpClient,
+		clock:            clock,
+		key:              key,
+		requestTTL:       DefaultRequestTTL,
+		encodings:        PreferredEncodings(preferredEncoding),
+	}
+}
+
+func (c *client) StreamOutURL(logger lager.Logger, handle string, path string) (string, Encoding, error) {
+	request, err := c.requestGenerator.CreateRequest(StreamOutURL, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := url.Values{"path": {path}}
+	for _, encoding := range c.encodings {
+		query.Add("encoding", string(encoding))
+	}
+
+	request.URL.RawQuery = query.Encode()
+
+	signRequest(c.key, request, nil, c.clock.Now().Add(c.requestTTL))
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return "", "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated {
+		return "", "", getError(response)
+	}
+
+	var body StreamOutURLResponse
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return "", "", err
+	}
+
+	// the server hands out a URL relative to the address it is reached at
+	base, err := url.Parse(c.apiURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	streamOutURL, err := base.Parse(body.URL)
+	if err != nil {
+		return "", "", err
+	}
+
+	return streamOutURL.String(), body.Encoding, nil
+}
+
+func (c *client) StreamIn(logger lager.Logger, handle string, path string, streamOutURL string, encoding Encoding) (int64, error) {
+	payload, err := json.Marshal(StreamInRequest{
+		URL:      streamOutURL,
+		Encoding: encoding,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := c.requestGenerator.CreateRequest(StreamIn, rata.Params{
+		"handle": handle,
+	}, bytes.NewBuffer(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	request.URL.RawQuery = url.Values{"path": {path}}.Encode()
+	request.Header.Set("Content-Type", "application/json")
+
+	signRequest(c.key, request, payload, c.clock.Now().Add(c.requestTTL))
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, getError(response)
+	}
+
+	var body StreamInResponse
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return 0, err
+	}
+
+	return body.Bytes, nil
+}
+
+func (c *client) StreamOut(logger lager.Logger, streamOutURL string) (io.ReadCloser, error) {
+	response, err := c.httpClient.Get(streamOutURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		defer response.Body.Close()
+		return nil, getError(response)
+	}
+
+	return response.Body, nil
+}
+
+func (c *client) Upload(logger lager.Logger, handle string, path string, encoding Encoding, contents io.Reader) (int64, error) {
+	request, err := c.requestGenerator.CreateRequest(Upload, rata.Params{
+		"handle": handle,
+	}, contents)
+	if err != nil {
+		return 0, err
+	}
+
+	request.URL.RawQuery = url.Values{
+		"path":     {path},
+		"encoding": {string(encoding)},
+	}.Encode()
+
+	// the contents are streamed, so they are not signed along with the request
+	signRequest(c.key, request, nil, c.clock.Now().Add(c.requestTTL))
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, getError(response)
+	}
+
+	var body StreamInResponse
+	err = json.NewDecoder(response.Body).Decode(&body)
+	if err != nil {
+		return 0, err
+	}
+
+	return body.Bytes, nil
+}
+
+func (c *client) Clear(logger lager.Logger, handle string, path string) error {
+	request, err := c.requestGenerator.CreateRequest(Clear, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	request.URL.RawQuery = url.Values{"path": {path}}.Encode()
+
+	signRequest(c.key, request, nil, c.clock.Now().Add(c.requestTTL))
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		return getError(response)
+	}
+
+	return nil
+}
+
+func getError(response *http.Response) error {
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	var errorResponse ErrorResponse
+	err = json.Unmarshal(body, &errorResponse)
+	if err != nil || errorResponse.Message == "" {
+		return fmt.Errorf("unexpected response: %s", response.Status)
+	}
+
+	return errors.New(errorResponse.Message)
+}
@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+)
+
+// An Encoding is the compression applied to the tar stream of a volume.
+type Encoding string
+
+const (
+	EncodingGzip Encoding = "gzip"
+)
+
+// SupportedEncodings are the encodings this version of the server can stream
+// with. Baggageclaim only streams gzipped tarballs, and the server streams
+// them as they are rather than spending CPU on re-encoding them, so gzip is
+// the only encoding until baggageclaim can stream others.
+var SupportedEncodings = []Encoding{EncodingGzip}
+
+var ErrNoSupportedEncoding = errors.New("none of the encodings are supported")
+
+type UnsupportedEncodingError struct {
+	Encoding Encoding
+}
+
+func (err UnsupportedEncodingError) Error() string {
+	return fmt.Sprintf("unsupported encoding '%s'", err.Encoding)
+}
+
+// Supported returns true if the server can stream with the encoding.
+func (encoding Encoding) Supported() bool {
+	for _, supported := range SupportedEncodings {
+		if encoding == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PreferredEncodings returns the supported encodings with the given one first,
+// in the order in which a client offers them to a server.
+func PreferredEncodings(preferred Encoding) []Encoding {
+	encodings := []Encoding{preferred}
+	for _, encoding := range SupportedEncodings {
+		if encoding != preferred {
+			encodings = append(encodings, encoding)
+		}
+	}
+
+	return encodings
+}
+
+// NegotiateEncoding returns the first of the offered encodings which the
+// server supports.
+func NegotiateEncoding(offered []Encoding) (Encoding, error) {
+	for _, encoding := range offered {
+		if encoding.Supported() {
+			return encoding, nil
+		}
+	}
+
+	return "", ErrNoSupportedEncoding
+}
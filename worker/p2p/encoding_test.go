@@ -0,0 +1,37 @@
+package p2p_test
+
+import (
+	"github.com/concourse/concourse/worker/p2p"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encoding", func() {
+	Describe("PreferredEncodings", func() {
+		It("offers the preferred encoding first", func() {
+			Expect(p2p.PreferredEncodings("zstd")).To(Equal([]p2p.Encoding{
+				"zstd",
+				p2p.EncodingGzip,
+			}))
+		})
+
+		It("offers each encoding once", func() {
+			Expect(p2p.PreferredEncodings(p2p.EncodingGzip)).To(Equal([]p2p.Encoding{
+				p2p.EncodingGzip,
+			}))
+		})
+	})
+
+	Describe("NegotiateEncoding", func() {
+		It("chooses the first supported encoding", func() {
+			Expect(p2p.NegotiateEncoding([]p2p.Encoding{"bogus", p2p.EncodingGzip})).To(Equal(p2p.EncodingGzip))
+		})
+
+		Context("when none of the encodings are supported", func() {
+			It("returns an error", func() {
+				_, err := p2p.NegotiateEncoding([]p2p.Encoding{"bogus", "zstd"})
+				Expect(err).To(Equal(p2p.ErrNoSupportedEncoding))
+			})
+		})
+	})
+})
@@ -0,0 +1,389 @@
+package p2p_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/baggageclaim/baggageclaimfakes"
+	"github.com/concourse/concourse/worker/p2p"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("P2P", func() {
+	var (
+		logger    *lagertest.TestLogger
+		fakeClock *fakeclock.FakeClock
+
+		sourceBaggageclaim      *baggageclaimfakes.FakeClient
+		sourceVolume            *baggageclaimfakes.FakeVolume
+		destinationBaggageclaim *baggageclaimfakes.FakeClient
+		destinationVolume       *baggageclaimfakes.FakeVolume
+
+		streamedIn []byte
+
+		sourceServer      *httptest.Server
+		destinationServer *httptest.Server
+
+		key               []byte
+		preferredEncoding p2p.Encoding
+
+		sourceClient      p2p.Client
+		destinationClient p2p.Client
+	)
+
+	startServer := func(bcClient *baggageclaimfakes.FakeClient) *httptest.Server {
+		p2pServer, err := p2p.NewServer(
+			logger,
+			fakeClock,
+			[]byte("some-key"),
+			bcClient,
+			http.DefaultClient,
+		)
+		Expect(err).ToNot(HaveOccurred())
+
+		handler, err := p2pServer.Handler()
+		Expect(err).ToNot(HaveOccurred())
+
+		return httptest.NewServer(handler)
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("p2p")
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		sourceVolume = new(baggageclaimfakes.FakeVolume)
+		sourceVolume.PathReturns("/some/source/volume")
+		sourceVolume.StreamOutStub = func(string) (io.ReadCloser, error) {
+			buf := new(bytes.Buffer)
+
+			encoder := gzip.NewWriter(buf)
+			_, err := encoder.Write([]byte("some-tarball"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(encoder.Close()).To(Succeed())
+
+			return ioutil.NopCloser(buf), nil
+		}
+
+		sourceBaggageclaim = new(baggageclaimfakes.FakeClient)
+		sourceBaggageclaim.LookupVolumeReturns(sourceVolume, true, nil)
+
+		streamedIn = nil
+
+		destinationVolume = new(baggageclaimfakes.FakeVolume)
+		destinationVolume.PathReturns("/some/destination/volume")
+		destinationVolume.StreamInStub = func(path string, tarStream io.Reader) error {
+			decoder, err := gzip.NewReader(tarStream)
+			if err != nil {
+				return err
+			}
+
+			streamedIn, err = ioutil.ReadAll(decoder)
+			return err
+		}
+
+		destinationBaggageclaim = new(baggageclaimfakes.FakeClient)
+		destinationBaggageclaim.LookupVolumeReturns(destinationVolume, true, nil)
+
+		sourceServer = startServer(sourceBaggageclaim)
+		destinationServer = startServer(destinationBaggageclaim)
+
+		key = []byte("some-key")
+		preferredEncoding = p2p.EncodingGzip
+	})
+
+	JustBeforeEach(func() {
+		sourceClient = p2p.NewClient(sourceServer.URL, http.DefaultClient, fakeClock, key, preferredEncoding)
+		destinationClient = p2p.NewClient(destinationServer.URL, http.DefaultClient, fakeClock, key, preferredEncoding)
+	})
+
+	AfterEach(func() {
+		sourceServer.Close()
+		destinationServer.Close()
+	})
+
+	It("streams the volume from the source worker to the destination worker", func() {
+		streamOutURL, chosenEncoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(streamOutURL).To(HavePrefix(sourceServer.URL + "/volumes/some-source-handle/stream-out?"))
+		Expect(chosenEncoding).To(Equal(p2p.EncodingGzip))
+
+		bytes, err := destinationClient.StreamIn(logger, "some-destination-handle", "other/path", streamOutURL, chosenEncoding)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bytes).To(BeNumerically(">", 0))
+
+		_, handle := sourceBaggageclaim.LookupVolumeArgsForCall(0)
+		Expect(handle).To(Equal("some-source-handle"))
+		Expect(sourceVolume.StreamOutArgsForCall(0)).To(Equal("some/path"))
+
+		_, handle = destinationBaggageclaim.LookupVolumeArgsForCall(0)
+		Expect(handle).To(Equal("some-destination-handle"))
+
+		path, _ := destinationVolume.StreamInArgsForCall(0)
+		Expect(path).To(Equal("other/path"))
+		Expect(string(streamedIn)).To(Equal("some-tarball"))
+	})
+
+	It("relays the volume through the ATC", func() {
+		streamOutURL, chosenEncoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+		Expect(err).ToNot(HaveOccurred())
+
+		out, err := sourceClient.StreamOut(logger, streamOutURL)
+		Expect(err).ToNot(HaveOccurred())
+
+		defer out.Close()
+
+		bytes, err := destinationClient.Upload(logger, "some-destination-handle", "other/path", chosenEncoding, out)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bytes).To(BeNumerically(">", 0))
+
+		path, _ := destinationVolume.StreamInArgsForCall(0)
+		Expect(path).To(Equal("other/path"))
+		Expect(string(streamedIn)).To(Equal("some-tarball"))
+	})
+
+	Context("when the preferred encoding is not supported", func() {
+		BeforeEach(func() {
+			preferredEncoding = "zstd"
+		})
+
+		It("falls back on gzip", func() {
+			_, chosenEncoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chosenEncoding).To(Equal(p2p.EncodingGzip))
+		})
+	})
+
+	Context("when the requests are signed with another key", func() {
+		BeforeEach(func() {
+			key = []byte("some-other-key")
+		})
+
+		It("refuses to hand out a URL", func() {
+			_, _, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+			Expect(err).To(MatchError(p2p.ErrUnauthorized.Error()))
+		})
+
+		It("refuses to stream into the volume", func() {
+			_, err := destinationClient.StreamIn(logger, "some-destination-handle", ".", sourceServer.URL, p2p.EncodingGzip)
+			Expect(err).To(MatchError(p2p.ErrUnauthorized.Error()))
+
+			Expect(destinationBaggageclaim.LookupVolumeCallCount()).To(BeZero())
+		})
+
+		It("refuses to upload into the volume", func() {
+			_, err := destinationClient.Upload(logger, "some-destination-handle", ".", p2p.EncodingGzip, strings.NewReader("some-tarball"))
+			Expect(err).To(MatchError(p2p.ErrUnauthorized.Error()))
+
+			Expect(destinationBaggageclaim.LookupVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a request is not signed", func() {
+		It("is refused", func() {
+			response, err := http.Post(sourceServer.URL+"/volumes/some-source-handle/stream-out-url?path=some/path", "", nil)
+			Expect(err).ToNot(HaveOccurred())
+			defer response.Body.Close()
+
+			Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("when the server has no key shared with the ATC", func() {
+		It("fails to start", func() {
+			_, err := p2p.NewServer(logger, fakeClock, nil, sourceBaggageclaim, http.DefaultClient)
+			Expect(err).To(Equal(p2p.ErrMissingKey))
+		})
+	})
+
+	Context("when the URL has been tampered with", func() {
+		It("refuses to stream the volume", func() {
+			streamOutURL, encoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+			Expect(err).ToNot(HaveOccurred())
+
+			tampered, err := url.Parse(streamOutURL)
+			Expect(err).ToNot(HaveOccurred())
+
+			query := tampered.Query()
+			query.Set("path", ".")
+			tampered.RawQuery = query.Encode()
+
+			_, err = destinationClient.StreamIn(logger, "some-destination-handle", ".", tampered.String(), encoding)
+			Expect(err).To(MatchError(ContainSubstring(p2p.ErrInvalidSignature.Error())))
+
+			Expect(sourceBaggageclaim.LookupVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the URL has expired", func() {
+		It("refuses to stream the volume", func() {
+			streamOutURL, encoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeClock.Increment(p2p.DefaultURLTTL + time.Second)
+
+			_, err = sourceClient.StreamOut(logger, streamOutURL)
+			Expect(err).To(MatchError(p2p.ErrExpiredURL.Error()))
+
+			_, err = destinationClient.StreamIn(logger, "some-destination-handle", ".", streamOutURL, encoding)
+			Expect(err).To(MatchError(ContainSubstring(p2p.ErrExpiredURL.Error())))
+
+			Expect(sourceBaggageclaim.LookupVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	table.DescribeTable("when the path is not within the volume",
+		func(path string) {
+			streamOutURL, encoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", path)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = sourceClient.StreamOut(logger, streamOutURL)
+			Expect(err).To(MatchError(p2p.ErrInvalidPath.Error()))
+
+			streamOutURL, _, err = sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = destinationClient.StreamIn(logger, "some-destination-handle", path, streamOutURL, encoding)
+			Expect(err).To(MatchError(p2p.ErrInvalidPath.Error()))
+
+			_, err = destinationClient.Upload(logger, "some-destination-handle", path, p2p.EncodingGzip, strings.NewReader("some-tarball"))
+			Expect(err).To(MatchError(p2p.ErrInvalidPath.Error()))
+
+			err = destinationClient.Clear(logger, "some-destination-handle", path)
+			Expect(err).To(MatchError(p2p.ErrInvalidPath.Error()))
+
+			Expect(sourceVolume.StreamOutCallCount()).To(BeZero())
+			Expect(destinationVolume.StreamInCallCount()).To(BeZero())
+		},
+		table.Entry("with an absolute path", "/etc"),
+		table.Entry("with a path to the parent", ".."),
+		table.Entry("with a path escaping the volume", "some/../../etc"),
+	)
+
+	Context("when the path does not exist in the source volume", func() {
+		BeforeEach(func() {
+			sourceVolume.StreamOutStub = nil
+			sourceVolume.StreamOutReturns(nil, baggageclaim.ErrFileNotFound)
+		})
+
+		It("returns an error", func() {
+			streamOutURL, encoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", "bogus/path")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = destinationClient.StreamIn(logger, "some-destination-handle", ".", streamOutURL, encoding)
+			Expect(err).To(HaveOccurred())
+
+			Expect(destinationVolume.StreamInCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the destination does not support the encoding", func() {
+		It("returns an error", func() {
+			streamOutURL, _, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = destinationClient.StreamIn(logger, "some-destination-handle", ".", streamOutURL, "bogus")
+			Expect(err).To(MatchError(p2p.UnsupportedEncodingError{Encoding: "bogus"}.Error()))
+
+			_, err = destinationClient.Upload(logger, "some-destination-handle", ".", "bogus", strings.NewReader("some-tarball"))
+			Expect(err).To(MatchError(p2p.UnsupportedEncodingError{Encoding: "bogus"}.Error()))
+
+			Expect(destinationBaggageclaim.LookupVolumeCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("clearing the destination", func() {
+		var (
+			volumeDir  string
+			outsideDir string
+		)
+
+		BeforeEach(func() {
+			var err error
+			volumeDir, err = ioutil.TempDir("", "p2p-volume")
+			Expect(err).ToNot(HaveOccurred())
+
+			outsideDir, err = ioutil.TempDir("", "p2p-outside")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(os.MkdirAll(filepath.Join(volumeDir, "some/path"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(volumeDir, "some/path/file"), []byte("partial"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(volumeDir, "other-file"), []byte("other"), 0644)).To(Succeed())
+
+			Expect(ioutil.WriteFile(filepath.Join(outsideDir, "file"), []byte("outside"), 0644)).To(Succeed())
+			Expect(os.Symlink(outsideDir, filepath.Join(volumeDir, "some/path/link"))).To(Succeed())
+			Expect(os.Symlink(outsideDir, filepath.Join(volumeDir, "escape"))).To(Succeed())
+
+			destinationVolume.PathReturns(volumeDir)
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(volumeDir)).To(Succeed())
+			Expect(os.RemoveAll(outsideDir)).To(Succeed())
+		})
+
+		It("removes the contents at the path, leaving the rest of the volume", func() {
+			err := destinationClient.Clear(logger, "some-destination-handle", "some/path")
+			Expect(err).ToNot(HaveOccurred())
+
+			entries, err := ioutil.ReadDir(filepath.Join(volumeDir, "some/path"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(BeEmpty())
+
+			Expect(filepath.Join(volumeDir, "other-file")).To(BeAnExistingFile())
+			Expect(filepath.Join(outsideDir, "file")).To(BeAnExistingFile())
+		})
+
+		It("does not follow symlinks out of the volume", func() {
+			err := destinationClient.Clear(logger, "some-destination-handle", "escape")
+			Expect(err).To(MatchError(p2p.ErrInvalidPath.Error()))
+
+			Expect(filepath.Join(outsideDir, "file")).To(BeAnExistingFile())
+		})
+
+		It("succeeds when there is nothing at the path", func() {
+			err := destinationClient.Clear(logger, "some-destination-handle", "bogus/path")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the request is signed with another key", func() {
+			BeforeEach(func() {
+				key = []byte("some-other-key")
+			})
+
+			It("refuses to clear the volume", func() {
+				err := destinationClient.Clear(logger, "some-destination-handle", "some/path")
+				Expect(err).To(MatchError(p2p.ErrUnauthorized.Error()))
+
+				Expect(filepath.Join(volumeDir, "some/path/file")).To(BeAnExistingFile())
+			})
+		})
+	})
+
+	Context("when the destination volume does not exist", func() {
+		BeforeEach(func() {
+			destinationBaggageclaim.LookupVolumeReturns(nil, false, nil)
+		})
+
+		It("returns an error", func() {
+			streamOutURL, encoding, err := sourceClient.StreamOutURL(logger, "some-source-handle", "some/path")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = destinationClient.StreamIn(logger, "some-destination-handle", ".", streamOutURL, encoding)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,455 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package p2pfakes
+
+import (
+	io "io"
+	sync "sync"
+
+	lager "code.cloudfoundry.org/lager"
+	p2p "github.com/concourse/concourse/worker/p2p"
+)
+
+type FakeClient struct {
+	ClearStub        func(lager.Logger, string, string) error
+	clearMutex       sync.RWMutex
+	clearArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}
+	clearReturns struct {
+		result1 error
+	}
+	clearReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StreamInStub        func(lager.Logger, string, string, string, p2p.Encoding) (int64, error)
+	streamInMutex       sync.RWMutex
+	streamInArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 p2p.Encoding
+	}
+	streamInReturns struct {
+		result1 int64
+		result2 error
+	}
+	streamInReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	StreamOutStub        func(lager.Logger, string) (io.ReadCloser, error)
+	streamOutMutex       sync.RWMutex
+	streamOutArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
+	streamOutReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	streamOutReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	StreamOutURLStub        func(lager.Logger, string, string) (string, p2p.Encoding, error)
+	streamOutURLMutex       sync.RWMutex
+	streamOutURLArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}
+	streamOutURLReturns struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}
+	streamOutURLReturnsOnCall map[int]struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}
+	UploadStub        func(lager.Logger, string, string, p2p.Encoding, io.Reader) (int64, error)
+	uploadMutex       sync.RWMutex
+	uploadArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+		arg4 p2p.Encoding
+		arg5 io.Reader
+	}
+	uploadReturns struct {
+		result1 int64
+		result2 error
+	}
+	uploadReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) Clear(arg1 lager.Logger, arg2 string, arg3 string) error {
+	fake.clearMutex.Lock()
+	ret, specificReturn := fake.clearReturnsOnCall[len(fake.clearArgsForCall)]
+	fake.clearArgsForCall = append(fake.clearArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Clear", []interface{}{arg1, arg2, arg3})
+	fake.clearMutex.Unlock()
+	if fake.ClearStub != nil {
+		return fake.ClearStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.clearReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ClearCallCount() int {
+	fake.clearMutex.RLock()
+	defer fake.clearMutex.RUnlock()
+	return len(fake.clearArgsForCall)
+}
+
+func (fake *FakeClient) ClearCalls(stub func(lager.Logger, string, string) error) {
+	fake.clearMutex.Lock()
+	defer fake.clearMutex.Unlock()
+	fake.ClearStub = stub
+}
+
+func (fake *FakeClient) ClearArgsForCall(i int) (lager.Logger, string, string) {
+	fake.clearMutex.RLock()
+	defer fake.clearMutex.RUnlock()
+	argsForCall := fake.clearArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) ClearReturns(result1 error) {
+	fake.clearMutex.Lock()
+	defer fake.clearMutex.Unlock()
+	fake.ClearStub = nil
+	fake.clearReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ClearReturnsOnCall(i int, result1 error) {
+	fake.clearMutex.Lock()
+	defer fake.clearMutex.Unlock()
+	fake.ClearStub = nil
+	if fake.clearReturnsOnCall == nil {
+		fake.clearReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.clearReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) StreamIn(arg1 lager.Logger, arg2 string, arg3 string, arg4 string, arg5 p2p.Encoding) (int64, error) {
+	fake.streamInMutex.Lock()
+	ret, specificReturn := fake.streamInReturnsOnCall[len(fake.streamInArgsForCall)]
+	fake.streamInArgsForCall = append(fake.streamInArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 p2p.Encoding
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("StreamIn", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.streamInMutex.Unlock()
+	if fake.StreamInStub != nil {
+		return fake.StreamInStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.streamInReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) StreamInCallCount() int {
+	fake.streamInMutex.RLock()
+	defer fake.streamInMutex.RUnlock()
+	return len(fake.streamInArgsForCall)
+}
+
+func (fake *FakeClient) StreamInCalls(stub func(lager.Logger, string, string, string, p2p.Encoding) (int64, error)) {
+	fake.streamInMutex.Lock()
+	defer fake.streamInMutex.Unlock()
+	fake.StreamInStub = stub
+}
+
+func (fake *FakeClient) StreamInArgsForCall(i int) (lager.Logger, string, string, string, p2p.Encoding) {
+	fake.streamInMutex.RLock()
+	defer fake.streamInMutex.RUnlock()
+	argsForCall := fake.streamInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) StreamInReturns(result1 int64, result2 error) {
+	fake.streamInMutex.Lock()
+	defer fake.streamInMutex.Unlock()
+	fake.StreamInStub = nil
+	fake.streamInReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StreamInReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.streamInMutex.Lock()
+	defer fake.streamInMutex.Unlock()
+	fake.StreamInStub = nil
+	if fake.streamInReturnsOnCall == nil {
+		fake.streamInReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.streamInReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StreamOut(arg1 lager.Logger, arg2 string) (io.ReadCloser, error) {
+	fake.streamOutMutex.Lock()
+	ret, specificReturn := fake.streamOutReturnsOnCall[len(fake.streamOutArgsForCall)]
+	fake.streamOutArgsForCall = append(fake.streamOutArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("StreamOut", []interface{}{arg1, arg2})
+	fake.streamOutMutex.Unlock()
+	if fake.StreamOutStub != nil {
+		return fake.StreamOutStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.streamOutReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) StreamOutCallCount() int {
+	fake.streamOutMutex.RLock()
+	defer fake.streamOutMutex.RUnlock()
+	return len(fake.streamOutArgsForCall)
+}
+
+func (fake *FakeClient) StreamOutCalls(stub func(lager.Logger, string) (io.ReadCloser, error)) {
+	fake.streamOutMutex.Lock()
+	defer fake.streamOutMutex.Unlock()
+	fake.StreamOutStub = stub
+}
+
+func (fake *FakeClient) StreamOutArgsForCall(i int) (lager.Logger, string) {
+	fake.streamOutMutex.RLock()
+	defer fake.streamOutMutex.RUnlock()
+	argsForCall := fake.streamOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) StreamOutReturns(result1 io.ReadCloser, result2 error) {
+	fake.streamOutMutex.Lock()
+	defer fake.streamOutMutex.Unlock()
+	fake.StreamOutStub = nil
+	fake.streamOutReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StreamOutReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.streamOutMutex.Lock()
+	defer fake.streamOutMutex.Unlock()
+	fake.StreamOutStub = nil
+	if fake.streamOutReturnsOnCall == nil {
+		fake.streamOutReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.streamOutReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) StreamOutURL(arg1 lager.Logger, arg2 string, arg3 string) (string, p2p.Encoding, error) {
+	fake.streamOutURLMutex.Lock()
+	ret, specificReturn := fake.streamOutURLReturnsOnCall[len(fake.streamOutURLArgsForCall)]
+	fake.streamOutURLArgsForCall = append(fake.streamOutURLArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("StreamOutURL", []interface{}{arg1, arg2, arg3})
+	fake.streamOutURLMutex.Unlock()
+	if fake.StreamOutURLStub != nil {
+		return fake.StreamOutURLStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.streamOutURLReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) StreamOutURLCallCount() int {
+	fake.streamOutURLMutex.RLock()
+	defer fake.streamOutURLMutex.RUnlock()
+	return len(fake.streamOutURLArgsForCall)
+}
+
+func (fake *FakeClient) StreamOutURLCalls(stub func(lager.Logger, string, string) (string, p2p.Encoding, error)) {
+	fake.streamOutURLMutex.Lock()
+	defer fake.streamOutURLMutex.Unlock()
+	fake.StreamOutURLStub = stub
+}
+
+func (fake *FakeClient) StreamOutURLArgsForCall(i int) (lager.Logger, string, string) {
+	fake.streamOutURLMutex.RLock()
+	defer fake.streamOutURLMutex.RUnlock()
+	argsForCall := fake.streamOutURLArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) StreamOutURLReturns(result1 string, result2 p2p.Encoding, result3 error) {
+	fake.streamOutURLMutex.Lock()
+	defer fake.streamOutURLMutex.Unlock()
+	fake.StreamOutURLStub = nil
+	fake.streamOutURLReturns = struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) StreamOutURLReturnsOnCall(i int, result1 string, result2 p2p.Encoding, result3 error) {
+	fake.streamOutURLMutex.Lock()
+	defer fake.streamOutURLMutex.Unlock()
+	fake.StreamOutURLStub = nil
+	if fake.streamOutURLReturnsOnCall == nil {
+		fake.streamOutURLReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 p2p.Encoding
+			result3 error
+		})
+	}
+	fake.streamOutURLReturnsOnCall[i] = struct {
+		result1 string
+		result2 p2p.Encoding
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) Upload(arg1 lager.Logger, arg2 string, arg3 string, arg4 p2p.Encoding, arg5 io.Reader) (int64, error) {
+	fake.uploadMutex.Lock()
+	ret, specificReturn := fake.uploadReturnsOnCall[len(fake.uploadArgsForCall)]
+	fake.uploadArgsForCall = append(fake.uploadArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 string
+		arg4 p2p.Encoding
+		arg5 io.Reader
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("Upload", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.uploadMutex.Unlock()
+	if fake.UploadStub != nil {
+		return fake.UploadStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.uploadReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) UploadCallCount() int {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	return len(fake.uploadArgsForCall)
+}
+
+func (fake *FakeClient) UploadCalls(stub func(lager.Logger, string, string, p2p.Encoding, io.Reader) (int64, error)) {
+	fake.uploadMutex.Lock()
+	defer fake.uploadMutex.Unlock()
+	fake.UploadStub = stub
+}
+
+func (fake *FakeClient) UploadArgsForCall(i int) (lager.Logger, string, string, p2p.Encoding, io.Reader) {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	argsForCall := fake.uploadArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeClient) UploadReturns(result1 int64, result2 error) {
+	fake.uploadMutex.Lock()
+	defer fake.uploadMutex.Unlock()
+	fake.UploadStub = nil
+	fake.uploadReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UploadReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.uploadMutex.Lock()
+	defer fake.uploadMutex.Unlock()
+	fake.UploadStub = nil
+	if fake.uploadReturnsOnCall == nil {
+		fake.uploadReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.uploadReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.clearMutex.RLock()
+	defer fake.clearMutex.RUnlock()
+	fake.streamInMutex.RLock()
+	defer fake.streamInMutex.RUnlock()
+	fake.streamOutMutex.RLock()
+	defer fake.streamOutMutex.RUnlock()
+	fake.streamOutURLMutex.RLock()
+	defer fake.streamOutURLMutex.RUnlock()
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ p2p.Client = new(FakeClient)
@@ -0,0 +1,61 @@
+// Package p2p streams volumes directly between workers.
+//
+// Each worker runs a Server next to its baggageclaim server. To stream a
+// volume from one worker to another, the ATC asks the source worker for a
+// signed, short-lived URL to the volume's contents, and then tells the
+// destination worker to pull the contents from that URL into its own volume,
+// so that the data never passes through the ATC.
+//
+// When the workers cannot reach each other, the ATC pulls from the URL itself
+// and uploads the contents to the destination worker, so that they are still
+// streamed with the negotiated encoding. Before streaming into a destination
+// again, the ATC clears whatever a failed attempt left there.
+//
+// The ATC offers the encodings it accepts in order of preference, and the
+// source worker chooses the first one it supports.
+package p2p
+
+import "github.com/tedsuo/rata"
+
+const (
+	StreamOutURL = "StreamOutURL"
+	StreamOut    = "StreamOut"
+	StreamIn     = "StreamIn"
+	Upload       = "Upload"
+	Clear        = "Clear"
+)
+
+var Routes = rata.Routes{
+	{Path: "/volumes/:handle/stream-out-url", Method: "POST", Name: StreamOutURL},
+	{Path: "/volumes/:handle/stream-out", Method: "GET", Name: StreamOut},
+	{Path: "/volumes/:handle/stream-in", Method: "PUT", Name: StreamIn},
+	{Path: "/volumes/:handle/upload", Method: "PUT", Name: Upload},
+	{Path: "/volumes/:handle/contents", Method: "DELETE", Name: Clear},
+}
+
+// StreamOutURLResponse is the response to a StreamOutURL request.
+type StreamOutURLResponse struct {
+	URL string `json:"url"`
+
+	// the encoding chosen from the ones offered in the request
+	Encoding Encoding `json:"encoding"`
+}
+
+// StreamInRequest is the body of a StreamIn request.
+type StreamInRequest struct {
+	// the URL to pull the volume's contents from
+	URL string `json:"url"`
+
+	// the encoding the contents are streamed with
+	Encoding Encoding `json:"encoding"`
+}
+
+// StreamInResponse is the response to a StreamIn or Upload request.
+type StreamInResponse struct {
+	// the number of bytes pulled, before decompression
+	Bytes int64 `json:"bytes"`
+}
+
+type ErrorResponse struct {
+	Message string `json:"error"`
+}
@@ -0,0 +1,462 @@
+package p2p
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/tedsuo/rata"
+)
+
+// DefaultURLTTL is how long the URLs handed out by a Server remain valid.
+const DefaultURLTTL = time.Minute
+
+var ErrInvalidSignature = errors.New("invalid signature")
+var ErrExpiredURL = errors.New("url has expired")
+var ErrMissingKey = errors.New("no key shared with the atc")
+var ErrInvalidPath = errors.New("path must be within the volume")
+
+type Server struct {
+	logger lager.Logger
+	clock  clock.Clock
+
+	// the URL at which other workers reach the server
+	url string
+
+	baggageclaimClient baggageclaim.Client
+	httpClient         *http.Client
+
+	// the key shared with the ATC, with which it signs its requests
+	atcKey []byte
+
+	key    []byte
+	urlTTL time.Duration
+}
+
+// NewServer returns a server streaming the volumes of the given baggageclaim
+// server. The tarballs baggageclaim streams are passed through as they are.
+//
+// Only requests signed with the key shared with the ATC may ask for a URL or
+// stream into a volume. URLs are signed with a random key, so that only the
+// URLs handed out by this server are accepted.
+func NewServer(
+	logger lager.Logger,
+	clock clock.Clock,
+	atcKey []byte,
+	baggageclaimClient baggageclaim.Client,
+	httpClient *http.Client,
+) (*Server, error) {
+	if len(atcKey) == 0 {
+		return nil, ErrMissingKey
+	}
+
+	key := make([]byte, 32)
+
+	_, err := rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		logger:             logger,
+		clock:              clock,
+		atcKey:             atcKey,
+		baggageclaimClient: baggageclaimClient,
+		httpClient:         httpClient,
+		key:                key,
+		urlTTL:             DefaultURLTTL,
+	}, nil
+}
+
+func (s *Server) Handler() (http.Handler, error) {
+	return rata.NewRouter(Routes, rata.Handlers{
+		StreamOutURL: s.authenticated(s.streamOutURL),
+		StreamOut:    http.HandlerFunc(s.streamOut),
+		StreamIn:     s.authenticated(s.streamIn),
+		Upload:       s.authenticatedStream(s.upload),
+		Clear:        s.authenticated(s.clear),
+	})
+}
+
+func (s *Server) streamOutURL(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	query := r.URL.Query()
+	path := query.Get("path")
+
+	offered := []Encoding{}
+	for _, encoding := range query["encoding"] {
+		offered = append(offered, Encoding(encoding))
+	}
+
+	// clients which do not offer any encodings get what baggageclaim streams
+	if len(offered) == 0 {
+		offered = []Encoding{EncodingGzip}
+	}
+
+	encoding, err := NegotiateEncoding(offered)
+	if err != nil {
+		respondWithError(w, http.StatusNotAcceptable, err)
+		return
+	}
+
+	expires := strconv.FormatInt(s.clock.Now().Add(s.urlTTL).Unix(), 10)
+
+	// the URL is relative, as the server may be reached through an address it
+	// does not know of, e.g. when forwarded through the TSA
+	streamOutPath, err := rata.NewRequestGenerator("", Routes).CreateRequest(StreamOut, rata.Params{
+		"handle": handle,
+	}, nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	streamOutURL := streamOutPath.URL
+	streamOutURL.RawQuery = url.Values{
+		"path":      {path},
+		"encoding":  {string(encoding)},
+		"expires":   {expires},
+		"signature": {s.sign(handle, path, encoding, expires)},
+	}.Encode()
+
+	respondWithJSON(w, http.StatusCreated, StreamOutURLResponse{
+		URL:      streamOutURL.String(),
+		Encoding: encoding,
+	})
+}
+
+func (s *Server) streamOut(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	query := r.URL.Query()
+	path := query.Get("path")
+	encoding := Encoding(query.Get("encoding"))
+	expires := query.Get("expires")
+
+	logger := s.logger.Session("stream-out", lager.Data{
+		"volume":   handle,
+		"path":     path,
+		"encoding": encoding,
+	})
+
+	if !hmac.Equal([]byte(query.Get("signature")), []byte(s.sign(handle, path, encoding, expires))) {
+		respondWithError(w, http.StatusForbidden, ErrInvalidSignature)
+		return
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || s.clock.Now().Unix() > expiresAt {
+		respondWithError(w, http.StatusForbidden, ErrExpiredURL)
+		return
+	}
+
+	volume, found, err := s.baggageclaimClient.LookupVolume(logger, handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !found {
+		respondWithError(w, http.StatusNotFound, baggageclaim.ErrVolumeNotFound)
+		return
+	}
+
+	err = checkPath(volume, path)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	out, err := volume.StreamOut(path)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			respondWithError(w, http.StatusNotFound, err)
+			return
+		}
+
+		logger.Error("failed-to-stream-out", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	defer out.Close()
+
+	w.WriteHeader(http.StatusOK)
+
+	_, err = io.Copy(w, out)
+	if err != nil {
+		logger.Error("failed-to-stream-out", err)
+
+		// abort the response so that the destination sees a truncated stream
+		// rather than an incomplete tarball
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func (s *Server) streamIn(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	path := r.URL.Query().Get("path")
+
+	var request StreamInRequest
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	logger := s.logger.Session("stream-in", lager.Data{
+		"volume":   handle,
+		"path":     path,
+		"encoding": request.Encoding,
+	})
+
+	if !request.Encoding.Supported() {
+		respondWithError(w, http.StatusBadRequest, UnsupportedEncodingError{request.Encoding})
+		return
+	}
+
+	volume, found, err := s.baggageclaimClient.LookupVolume(logger, handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !found {
+		respondWithError(w, http.StatusNotFound, baggageclaim.ErrVolumeNotFound)
+		return
+	}
+
+	err = checkPath(volume, path)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	response, err := s.httpClient.Get(request.URL)
+	if err != nil {
+		logger.Error("failed-to-pull", err)
+		respondWithError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err := fmt.Errorf("pulling from source worker failed: %s", getError(response))
+		logger.Error("failed-to-pull", err)
+		respondWithError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	body := &countingReader{Reader: response.Body}
+
+	err = volume.StreamIn(path, body)
+	if err != nil {
+		logger.Error("failed-to-stream-in", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, StreamInResponse{
+		Bytes: body.count,
+	})
+}
+
+func (s *Server) upload(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	query := r.URL.Query()
+	path := query.Get("path")
+	encoding := Encoding(query.Get("encoding"))
+
+	logger := s.logger.Session("upload", lager.Data{
+		"volume":   handle,
+		"path":     path,
+		"encoding": encoding,
+	})
+
+	if !encoding.Supported() {
+		respondWithError(w, http.StatusBadRequest, UnsupportedEncodingError{encoding})
+		return
+	}
+
+	volume, found, err := s.baggageclaimClient.LookupVolume(logger, handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !found {
+		respondWithError(w, http.StatusNotFound, baggageclaim.ErrVolumeNotFound)
+		return
+	}
+
+	err = checkPath(volume, path)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	body := &countingReader{Reader: r.Body}
+
+	err = volume.StreamIn(path, body)
+	if err != nil {
+		logger.Error("failed-to-stream-in", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, StreamInResponse{
+		Bytes: body.count,
+	})
+}
+
+func (s *Server) clear(w http.ResponseWriter, r *http.Request) {
+	handle := rata.Param(r, "handle")
+	path := r.URL.Query().Get("path")
+
+	logger := s.logger.Session("clear", lager.Data{
+		"volume": handle,
+		"path":   path,
+	})
+
+	volume, found, err := s.baggageclaimClient.LookupVolume(logger, handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-volume", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if !found {
+		respondWithError(w, http.StatusNotFound, baggageclaim.ErrVolumeNotFound)
+		return
+	}
+
+	err = checkPath(volume, path)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = clearPath(volume, path)
+	if err != nil {
+		if err == ErrInvalidPath {
+			respondWithError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		logger.Error("failed-to-clear", err)
+		respondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkPath checks that the path is relative to the volume and does not
+// escape it.
+func checkPath(volume baggageclaim.Volume, path string) error {
+	cleaned := filepath.Clean(path)
+	if filepath.IsAbs(path) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return ErrInvalidPath
+	}
+
+	root := filepath.Clean(volume.Path())
+
+	resolved := filepath.Join(root, cleaned)
+	if resolved != root && !strings.HasPrefix(resolved, root+string(filepath.Separator)) {
+		return ErrInvalidPath
+	}
+
+	return nil
+}
+
+// clearPath removes the contents of the volume at the path, such as those
+// left by a stream which failed part way through. Symlinks are resolved, and
+// the path must still be within the volume once they are.
+func clearPath(volume baggageclaim.Volume, path string) error {
+	root, err := filepath.EvalSymlinks(volume.Path())
+	if err != nil {
+		return err
+	}
+
+	target, err := filepath.EvalSymlinks(filepath.Join(root, path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if target != root && !strings.HasPrefix(target, root+string(filepath.Separator)) {
+		return ErrInvalidPath
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return os.Remove(target)
+	}
+
+	entries, err := ioutil.ReadDir(target)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err := os.RemoveAll(filepath.Join(target, entry.Name()))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) sign(handle string, path string, encoding Encoding, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s", handle, path, encoding, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+func respondWithJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func respondWithError(w http.ResponseWriter, status int, err error) {
+	respondWithJSON(w, status, ErrorResponse{Message: err.Error()})
+}